@@ -0,0 +1,91 @@
+package main
+
+import (
+	"OnlineShopBackend/internal/catalogue"
+	"OnlineShopBackend/internal/usecase"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// runCommand runs the command given in arguments of the service instead of the server:
+//
+//	import [-format csv|jsonl] [-dry-run] <file|->
+//	export [-format csv|jsonl] [-out file]
+func runCommand(ctx context.Context, args []string, catalogueUsecase usecase.ICatalogueUsecase, l *zap.Logger) error {
+	l.Sugar().Debugf("Enter in main runCommand() with args: %v", args)
+	switch args[0] {
+	case "import":
+		return importCommand(ctx, args[1:], catalogueUsecase)
+	case "export":
+		return exportCommand(ctx, args[1:], catalogueUsecase)
+	default:
+		return fmt.Errorf("unknown command: %q", args[0])
+	}
+}
+
+// importCommand imports catalogue from file or standard input and prints the report
+func importCommand(ctx context.Context, args []string, catalogueUsecase usecase.ICatalogueUsecase) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", catalogue.FormatCSV, "format of file: csv or jsonl")
+	dryRun := flags.Bool("dry-run", false, "validate file without changes")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import [-format csv|jsonl] [-dry-run] <file|->")
+	}
+
+	var r io.Reader = os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("error on open file: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+	report, err := catalogueUsecase.Import(ctx, r, *format, *dryRun)
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("error on print report: %w", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", report.Failed, report.Total)
+	}
+	return nil
+}
+
+// exportCommand writes catalogue to file or standard output
+func exportCommand(ctx context.Context, args []string, catalogueUsecase usecase.ICatalogueUsecase) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", catalogue.FormatCSV, "format of file: csv or jsonl")
+	out := flags.String("out", "", "path to file, standard output by default")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("error on create file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	return catalogueUsecase.Export(ctx, w, *format)
+}
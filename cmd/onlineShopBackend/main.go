@@ -5,14 +5,21 @@ import (
 	"OnlineShopBackend/internal/app/logger"
 	"OnlineShopBackend/internal/app/router"
 	"OnlineShopBackend/internal/app/server"
+	"OnlineShopBackend/internal/carrier"
 	"OnlineShopBackend/internal/delivery"
 	"OnlineShopBackend/internal/delivery/user/password"
 	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/imaging"
+	"OnlineShopBackend/internal/invoice"
+	"OnlineShopBackend/internal/metrics"
 	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/notification"
+	"OnlineShopBackend/internal/payment"
 	"OnlineShopBackend/internal/repository"
 	"OnlineShopBackend/internal/repository/cash"
 	"OnlineShopBackend/internal/usecase"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -53,22 +60,132 @@ func main() {
 	cartStore := repository.NewCartStore(pgstore, lsug)
 	orderStore := repository.NewOrderRepo(pgstore, lsug)
 
-	redis, err := cash.NewRedisCash(cfg.CashHost, cfg.CashPort, time.Duration(cfg.CashTTL), l)
+	cashStorage, err := newCashStorage(cfg, l)
 	if err != nil {
 		log.Fatalf("can't initialize cash: %v", err)
 	}
-	itemsCash := cash.NewItemsCash(redis, l)
-	categoriesCash := cash.NewCategoriesCash(redis, l)
+	if cfg.CashEvents {
+		cashStorage = withCashEvents(ctx, cfg, cashStorage, l)
+	}
+	itemsCash := cash.NewItemsCash(cashStorage, l)
+	categoriesCash := cash.NewCategoriesCash(cashStorage, l)
+
+	// Totals of carts and orders are in the base currency
+	err = models.ValidCurrency(cfg.BaseCurrency)
+	if err != nil {
+		log.Fatalf("invalid base currency: %v", err)
+	}
+	currencyStore := repository.NewCurrencyRepo(pgstore, lsug)
+	currencyUsecase := usecase.NewCurrencyUsecase(currencyStore, cfg.BaseCurrency, l)
 
-	itemUsecase := usecase.NewItemUsecase(itemStore, itemsCash, l)
+	itemUsecase := usecase.NewItemUsecase(itemStore, itemsCash, cfg.BaseCurrency, l)
 	categoryUsecase := usecase.NewCategoryUsecase(categoryStore, categoriesCash, l)
 	userUsecase := usecase.NewUserUsecase(userStore, l)
 
-	cartUsecase := usecase.NewCartUseCase(cartStore, l)
-	orderUsecase := usecase.NewOrderUsecase(orderStore, lsug)
+	promotionStore := repository.NewPromotionRepo(pgstore, lsug)
+	promotionUsecase := usecase.NewPromotionUsecase(promotionStore, l)
+	taxStore := repository.NewTaxRepo(pgstore, lsug)
+	taxUsecase := usecase.NewTaxUsecase(taxStore, l)
+	cartTTL := models.CartTTL{
+		Guest: time.Duration(cfg.CartGuestTTL) * time.Second,
+		User:  time.Duration(cfg.CartUserTTL) * time.Second,
+	}
+	cartUsecase := usecase.NewCartUseCase(cartStore, promotionStore, currencyStore, taxStore, cfg.BaseCurrency, cartTTL, l)
+	shippingStore := repository.NewShippingRepo(pgstore, lsug)
+	shippingUsecase := usecase.NewShippingUsecase(shippingStore, l)
+	paymentProvider, err := newPaymentProvider(cfg, l)
+	if err != nil {
+		log.Fatalf("can't initialize payment provider: %v", err)
+	}
+	paymentStore := repository.NewPaymentRepo(pgstore, lsug)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentStore, paymentProvider, l)
+	orderUsecase := usecase.NewOrderUsecase(orderStore, itemStore, promotionStore, currencyStore, taxStore, shippingStore, cfg.BaseCurrency, lsug)
+	catalogueUsecase := usecase.NewCatalogueUsecase(itemStore, categoryStore, cashStorage, cfg.BaseCurrency, l)
+	returnStore := repository.NewReturnRepo(pgstore, lsug)
+	returnUsecase := usecase.NewReturnUsecase(returnStore, orderStore, paymentUsecase, l)
+	shipmentCarrier, err := newCarrier(cfg, l)
+	if err != nil {
+		log.Fatalf("can't initialize carrier: %v", err)
+	}
+	shipmentStore := repository.NewShipmentRepo(pgstore, lsug)
+	shipmentUsecase := usecase.NewShipmentUsecase(shipmentStore, orderStore, paymentStore, shipmentCarrier, l)
+	reviewStore := repository.NewReviewRepo(pgstore, lsug)
+	reviewUsecase := usecase.NewReviewUsecase(reviewStore, itemStore, cashStorage, l)
+	wishlistStore := repository.NewWishlistRepo(pgstore, lsug)
+	wishlistUsecase := usecase.NewWishlistUsecase(wishlistStore, itemStore, cartUsecase, cashStorage, l)
+	notifier, err := newNotifier(cfg, l)
+	if err != nil {
+		log.Fatalf("can't initialize notifier: %v", err)
+	}
+	abandonedCartStore := repository.NewAbandonedCartRepo(pgstore, lsug)
+	cartReminderUsecase := usecase.NewCartReminderUsecase(abandonedCartStore, notifier, time.Duration(cfg.CartAbandonAfter)*time.Second, cfg.ServerURL, l)
+
+	// Arguments after flags are a command, which is run instead of the server
+	if args := flag.Args(); len(args) > 0 {
+		err = runCommand(ctx, args, catalogueUsecase, l)
+		if shutDownErr := pgstore.ShutDown(cfg.Timeout); shutDownErr != nil {
+			l.Error(shutDownErr.Error())
+		}
+		if shutDownErr := cashStorage.ShutDown(cfg.Timeout); shutDownErr != nil {
+			l.Error(shutDownErr.Error())
+		}
+		cancel()
+		if err != nil {
+			log.Fatalf("error on run command %s: %v", args[0], err)
+		}
+		return
+	}
 
-	filestorage := filestorage.NewOnDiskLocalStorage(cfg.ServerURL, cfg.FsPath, l)
-	delivery := delivery.NewDelivery(itemUsecase, userUsecase, categoryUsecase, cartUsecase, l, filestorage, orderUsecase)
+	filestorage, err := newFileStorage(cfg, l)
+	if err != nil {
+		log.Fatalf("can't initialize file storage: %v", err)
+	}
+	images := imaging.NewProcessor(cfg.ImageMaxSize, l)
+	uploadStore := repository.NewUploadRepo(pgstore, lsug)
+	uploadUsecase := usecase.NewUploadUsecase(uploadStore, filestorage, time.Duration(cfg.UploadTTL)*time.Second, cfg.ImageMaxSize, l)
+	go cleanUploads(ctx, uploadUsecase, time.Duration(cfg.UploadGCInterval)*time.Second, l)
+	imageStore := repository.NewImageRepo(pgstore, lsug)
+	storageUsecase := usecase.NewStorageUsecase(imageStore, filestorage, cashStorage, time.Duration(cfg.StorageGCGrace)*time.Second, l)
+	shop := models.ShopDetails{Name: cfg.ShopName, Address: cfg.ShopAddress, TaxId: cfg.ShopTaxId, Email: cfg.ShopEmail}
+	invoiceRenderer, err := invoice.NewRenderer(shop, cfg.InvoiceFont)
+	if err != nil {
+		log.Fatalf("can't initialize invoice renderer: %v", err)
+	}
+	invoiceStore := repository.NewInvoiceRepo(pgstore, lsug)
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceStore, orderStore, userStore, filestorage, invoiceRenderer, l)
+	if cfg.StorageGCInterval > 0 {
+		go checkStorage(ctx, storageUsecase, time.Duration(cfg.StorageGCInterval)*time.Second, cfg.StorageGCDryRun, l)
+	}
+	if cfg.CartGCInterval > 0 {
+		go purgeCarts(ctx, cartUsecase, time.Duration(cfg.CartGCInterval)*time.Second, l)
+	}
+	if cfg.AbandonInterval > 0 {
+		go remindCarts(ctx, cartReminderUsecase, time.Duration(cfg.AbandonInterval)*time.Second, l)
+	}
+	if cfg.TrackInterval > 0 {
+		go trackShipments(ctx, shipmentUsecase, time.Duration(cfg.TrackInterval)*time.Second, l)
+	}
+	delivery := delivery.NewDelivery(delivery.Usecases{
+		Item:         itemUsecase,
+		User:         userUsecase,
+		Category:     categoryUsecase,
+		Cart:         cartUsecase,
+		Order:        orderUsecase,
+		Catalogue:    catalogueUsecase,
+		Upload:       uploadUsecase,
+		Storage:      storageUsecase,
+		Review:       reviewUsecase,
+		Promotion:    promotionUsecase,
+		Currency:     currencyUsecase,
+		Tax:          taxUsecase,
+		Shipping:     shippingUsecase,
+		Payment:      paymentUsecase,
+		Wishlist:     wishlistUsecase,
+		CartReminder: cartReminderUsecase,
+		Return:       returnUsecase,
+		Shipment:     shipmentUsecase,
+		Invoice:      invoiceUsecase,
+	}, l, filestorage, images)
 
 	router := router.NewRouter(delivery, l)
 	serverOptions := map[string]int{
@@ -102,7 +219,7 @@ func main() {
 		l.Info("Database connection stopped sucessful")
 	}
 
-	err = redis.ShutDown(cfg.Timeout)
+	err = cashStorage.ShutDown(cfg.Timeout)
 	if err != nil {
 		l.Error(err.Error())
 	} else {
@@ -119,6 +236,221 @@ func main() {
 	cancel()
 }
 
+// newCashStorage returns cash storage of the backend selected in configuration:
+// redis, memory (in-process cash of single instance) or tiered (in-process
+// cash in front of redis)
+func newCashStorage(cfg *config.Config, l *zap.Logger) (cash.ICashStorage, error) {
+	l.Sugar().Debugf("Enter in main newCashStorage() with backend: %s", cfg.CashBackend)
+	switch cfg.CashBackend {
+	case "redis":
+		return cash.NewRedisCash(cfg.CashHost, cfg.CashPort, time.Duration(cfg.CashTTL), l), nil
+	case "memory":
+		return cash.NewMemoryCash(time.Duration(cfg.CashTTL)*time.Hour, cfg.CashLocalSize, l), nil
+	case "tiered":
+		// Local values live shortly, because generations of tags
+		// are lost if redis restarts without persistence
+		local := cash.NewMemoryCash(time.Duration(cfg.CashLocalTTL)*time.Second, cfg.CashLocalSize, l)
+		shared := cash.NewRedisCash(cfg.CashHost, cfg.CashPort, time.Duration(cfg.CashTTL), l)
+		return cash.NewTieredCash(local, shared, l), nil
+	default:
+		return nil, fmt.Errorf("unknown cash backend: %q", cfg.CashBackend)
+	}
+}
+
+// newFileStorage returns file storage selected in configuration: disk
+// (local folder of single instance) or s3 (S3 compatible object storage)
+func newFileStorage(cfg *config.Config, l *zap.Logger) (filestorage.FileStorager, error) {
+	l.Sugar().Debugf("Enter in main newFileStorage() with storage: %s", cfg.FileStorage)
+	switch cfg.FileStorage {
+	case "disk":
+		return filestorage.NewOnDiskLocalStorage(cfg.ServerURL, cfg.FsPath, l), nil
+	case "s3":
+		return filestorage.NewS3Storage(cfg.ServerURL, filestorage.S3Options{
+			Endpoint:   cfg.S3Endpoint,
+			Region:     cfg.S3Region,
+			Bucket:     cfg.S3Bucket,
+			AccessKey:  cfg.S3AccessKey,
+			SecretKey:  cfg.S3SecretKey,
+			PathStyle:  cfg.S3PathStyle,
+			PublicURL:  cfg.S3PublicURL,
+			PresignTTL: time.Duration(cfg.S3PresignTTL) * time.Second,
+			Timeout:    time.Duration(cfg.Timeout) * time.Second,
+		}, l)
+	default:
+		return nil, fmt.Errorf("unknown file storage: %q", cfg.FileStorage)
+	}
+}
+
+// newPaymentProvider returns payment provider selected in configuration:
+// fake (approves all payments, for local testing only). Webhooks of the
+// provider can't be checked without the secret, so it is required
+func newPaymentProvider(cfg *config.Config, l *zap.Logger) (payment.PaymentProvider, error) {
+	l.Sugar().Debugf("Enter in main newPaymentProvider() with provider: %s", cfg.PaymentProvider)
+	if cfg.PaymentSecret == "" {
+		return nil, fmt.Errorf("secret of webhooks of payment provider is not set")
+	}
+	switch cfg.PaymentProvider {
+	case "fake":
+		if cfg.IsProd {
+			return nil, fmt.Errorf("fake payment provider approves all payments and can't be used in production")
+		}
+		return payment.NewFakeProvider(cfg.PaymentSecret, l), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider: %q", cfg.PaymentProvider)
+	}
+}
+
+// newCarrier returns carrier selected in configuration
+func newCarrier(cfg *config.Config, l *zap.Logger) (carrier.Carrier, error) {
+	l.Sugar().Debugf("Enter in main newCarrier() with carrier: %s", cfg.Carrier)
+	switch cfg.Carrier {
+	case "fake":
+		if cfg.IsProd {
+			l.Warn("fake carrier delivers all shipments by itself")
+		}
+		return carrier.NewFakeCarrier(time.Duration(cfg.FakeCarrierStep)*time.Second, l), nil
+	default:
+		return nil, fmt.Errorf("unknown carrier: %q", cfg.Carrier)
+	}
+}
+
+// newNotifier returns notifier selected in configuration: log (writes
+// notifications to the log) or file (appends them to the file as JSON lines)
+func newNotifier(cfg *config.Config, l *zap.Logger) (notification.Notifier, error) {
+	l.Sugar().Debugf("Enter in main newNotifier() with notifier: %s", cfg.Notifier)
+	switch cfg.Notifier {
+	case "log":
+		return notification.NewLogNotifier(l), nil
+	case "file":
+		return notification.NewFileNotifier(cfg.NotifierPath, l), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier: %q", cfg.Notifier)
+	}
+}
+
+// cleanUploads periodically deletes expired upload sessions
+// and their files until the context is done
+func cleanUploads(ctx context.Context, uploadUsecase usecase.IUploadUsecase, interval time.Duration, l *zap.Logger) {
+	l.Sugar().Debugf("Enter in main cleanUploads() with interval: %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := uploadUsecase.DeleteExpiredUploads(ctx)
+			if err != nil {
+				l.Sugar().Errorf("error on clean uploads: %v", err)
+			}
+		}
+	}
+}
+
+// purgeCarts periodically deletes expired guest carts until the context
+// is done
+func purgeCarts(ctx context.Context, cartUsecase usecase.ICartUsecase, interval time.Duration, l *zap.Logger) {
+	l.Sugar().Debugf("Enter in main purgeCarts() with interval: %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			guests, err := cartUsecase.PurgeExpiredCarts(ctx)
+			if err != nil {
+				l.Sugar().Errorf("error on purge carts: %v", err)
+				continue
+			}
+			metrics.CartsMetrics.GuestCartsPurged.Add(float64(guests))
+		}
+	}
+}
+
+// remindCarts periodically records abandoned carts of users and
+// reminds users about them until the context is done
+func remindCarts(ctx context.Context, cartReminderUsecase usecase.ICartReminderUsecase, interval time.Duration, l *zap.Logger) {
+	l.Sugar().Debugf("Enter in main remindCarts() with interval: %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			abandoned, sent, err := cartReminderUsecase.RemindAbandonedCarts(ctx)
+			metrics.CartsMetrics.AbandonedCarts.Add(float64(abandoned))
+			metrics.CartsMetrics.CartRemindersSent.Add(float64(sent))
+			if err != nil {
+				l.Sugar().Errorf("error on remind abandoned carts: %v", err)
+			}
+		}
+	}
+}
+
+// trackShipments periodically updates statuses of shipments on the way
+// from the carrier until the context is done
+func trackShipments(ctx context.Context, shipmentUsecase usecase.IShipmentUsecase, interval time.Duration, l *zap.Logger) {
+	l.Sugar().Debugf("Enter in main trackShipments() with interval: %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := shipmentUsecase.TrackShipments(ctx)
+			if err != nil {
+				l.Sugar().Errorf("error on track shipments: %v", err)
+				continue
+			}
+			l.Sugar().Debugf("statuses of %d shipments are changed", changed)
+		}
+	}
+}
+
+// checkStorage periodically checks consistency of the file storage until the
+// context is done. In dry run problems are only logged
+func checkStorage(ctx context.Context, storageUsecase usecase.IStorageUsecase, interval time.Duration, dryRun bool, l *zap.Logger) {
+	l.Sugar().Debugf("Enter in main checkStorage() with interval: %v, dryRun: %t", interval, dryRun)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := storageUsecase.CheckStorage(ctx, dryRun)
+			if err != nil {
+				l.Sugar().Errorf("error on check storage: %v", err)
+				continue
+			}
+			for _, name := range report.OrphanFiles {
+				l.Sugar().Warnf("orphaned file: %s", name)
+			}
+			for _, ref := range report.DanglingRefs {
+				l.Sugar().Warnf("dangling reference of %s %v to missing file: %s", ref.Kind, ref.OwnerId, ref.URL)
+			}
+		}
+	}
+}
+
+// withCashEvents wraps cash storage to publish invalidation events
+// to other instances of the service and listens to their events
+func withCashEvents(ctx context.Context, cfg *config.Config, storage cash.ICashStorage, l *zap.Logger) cash.ICashStorage {
+	l.Debug("Enter in main withCashEvents()")
+	redis := cash.NewRedisCash(cfg.CashHost, cfg.CashPort, time.Duration(cfg.CashTTL), l)
+	eventsCash := cash.NewEventsCash(storage, cash.NewRedisEventBus(redis, l), l)
+	go func() {
+		err := eventsCash.Listen(ctx)
+		if err != nil {
+			l.Sugar().Errorf("error on listen cash events: %v", err)
+		}
+	}()
+	return eventsCash
+}
+
 func createCashOnStartService(ctx context.Context, categoryUsecase usecase.ICategoryUsecase, itemUsecase usecase.IItemUsecase, l *zap.Logger) error {
 	l.Debug("Enter in main createCashOnStartService")
 	l.Debug("Start create cash...")
@@ -226,7 +558,7 @@ func setCustomerRights(userStore repository.UserStore, logger *zap.Logger) {
 		return
 	}
 	customerRights := models.Rights{
-		Name: "Customer",
+		Name:  "Customer",
 		Rules: []string{"Customer"},
 	}
 	rightsId, err := userStore.CreateRights(ctx, &customerRights)
@@ -16,12 +16,49 @@ type Config struct {
 	AdminMail         string `toml:"admin_mail" env:"ADMIN_MAIL" envDefault:"admin@mail.ru"`
 	AdminPass         string `toml:"admin_pass" env:"ADMIN_PASS" envDefault:"12345678" json:"-"`
 	Port              string `toml:"port" env:"PORT" envDefault:":8000"`
+	FileStorage       string `toml:"file_storage" env:"FILE_STORAGE" envDefault:"disk"`
 	FsPath            string `toml:"fs_path" env:"FS_PATH" envDefault:"./static/files/"`
+	S3Endpoint        string `toml:"s3_endpoint" env:"S3_ENDPOINT" envDefault:"http://localhost:9000"`
+	S3Region          string `toml:"s3_region" env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket          string `toml:"s3_bucket" env:"S3_BUCKET" envDefault:"shop"`
+	S3AccessKey       string `toml:"s3_access_key" env:"S3_ACCESS_KEY" envDefault:""`
+	S3SecretKey       string `toml:"s3_secret_key" env:"S3_SECRET_KEY" envDefault:"" json:"-"`
+	S3PathStyle       bool   `toml:"s3_path_style" env:"S3_PATH_STYLE" envDefault:"true"`
+	S3PublicURL       string `toml:"s3_public_url" env:"S3_PUBLIC_URL" envDefault:""`
+	S3PresignTTL      int    `toml:"s3_presign_ttl" env:"S3_PRESIGN_TTL" envDefault:"900"`
 	ServerURL         string `toml:"server_url" env:"SERVER_URL" envDefault:"http://localhost:8000"`
+	ImageMaxSize      int64  `toml:"image_max_size" env:"IMAGE_MAX_SIZE" envDefault:"10485760"`
+	UploadTTL         int    `toml:"upload_ttl" env:"UPLOAD_TTL" envDefault:"900"`
+	UploadGCInterval  int    `toml:"upload_gc_interval" env:"UPLOAD_GC_INTERVAL" envDefault:"300"`
+	StorageGCInterval int    `toml:"storage_gc_interval" env:"STORAGE_GC_INTERVAL" envDefault:"86400"`
+	StorageGCDryRun   bool   `toml:"storage_gc_dry_run" env:"STORAGE_GC_DRY_RUN" envDefault:"true"`
+	StorageGCGrace    int    `toml:"storage_gc_grace" env:"STORAGE_GC_GRACE" envDefault:"3600"`
+	BaseCurrency      string `toml:"base_currency" env:"BASE_CURRENCY" envDefault:"RUB"`
+	CartGuestTTL      int    `toml:"cart_guest_ttl" env:"CART_GUEST_TTL" envDefault:"604800"`
+	CartUserTTL       int    `toml:"cart_user_ttl" env:"CART_USER_TTL" envDefault:"2592000"`
+	CartGCInterval    int    `toml:"cart_gc_interval" env:"CART_GC_INTERVAL" envDefault:"3600"`
+	CartAbandonAfter  int    `toml:"cart_abandon_after" env:"CART_ABANDON_AFTER" envDefault:"86400"`
+	AbandonInterval   int    `toml:"cart_abandon_interval" env:"CART_ABANDON_INTERVAL" envDefault:"3600"`
+	Notifier          string `toml:"notifier" env:"NOTIFIER" envDefault:"log"`
+	NotifierPath      string `toml:"notifier_path" env:"NOTIFIER_PATH" envDefault:"notifications.jsonl"`
+	PaymentProvider   string `toml:"payment_provider" env:"PAYMENT_PROVIDER" envDefault:"fake"`
+	PaymentSecret     string `toml:"payment_secret" env:"PAYMENT_SECRET" envDefault:"" json:"-"`
+	Carrier           string `toml:"carrier" env:"CARRIER" envDefault:"fake"`
+	FakeCarrierStep   int    `toml:"fake_carrier_step" env:"FAKE_CARRIER_STEP" envDefault:"3600"`
+	TrackInterval     int    `toml:"shipment_track_interval" env:"SHIPMENT_TRACK_INTERVAL" envDefault:"600"`
+	ShopName          string `toml:"shop_name" env:"SHOP_NAME" envDefault:"Online Shop"`
+	ShopAddress       string `toml:"shop_address" env:"SHOP_ADDRESS" envDefault:""`
+	ShopTaxId         string `toml:"shop_tax_id" env:"SHOP_TAX_ID" envDefault:""`
+	ShopEmail         string `toml:"shop_email" env:"SHOP_EMAIL" envDefault:""`
+	InvoiceFont       string `toml:"invoice_font" env:"INVOICE_FONT" envDefault:""`
 	Timeout           int    `toml:"timeout" env:"TIMEOUT" envDefault:"5"`
 	CashHost          string `toml:"cash_host" env:"CASH_HOST" envDefault:"localhost"`
 	CashPort          string `toml:"cash_port" env:"CASH_PORT" envDefault:"6379"`
 	CashTTL           int    `toml:"cash_ttl" env:"CASH_TTL" envDefault:"24"`
+	CashBackend       string `toml:"cash_backend" env:"CASH_BACKEND" envDefault:"redis"`
+	CashLocalTTL      int    `toml:"cash_local_ttl" env:"CASH_LOCAL_TTL" envDefault:"60"`
+	CashLocalSize     int    `toml:"cash_local_size" env:"CASH_LOCAL_SIZE" envDefault:"10000"`
+	CashEvents        bool   `toml:"cash_events" env:"CASH_EVENTS" envDefault:"false"`
 	LogLevel          string `toml:"log_level" env:"LOG_LEVEL" envDefault:"debug"`
 	ReadTimeout       int    `toml:"read_timeout" env:"READ_TIMEOUT" envDefault:"30"`
 	WriteTimeout      int    `toml:"write_timeout" env:"WRITE_TIMEOUT" envDefault:"30"`
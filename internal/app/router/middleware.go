@@ -20,7 +20,7 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Auth-Token, Set-Cookie")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Auth-Token, X-Cart-Token, Set-Cookie")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -73,6 +73,12 @@ func JWTMiddleware(c *gin.Context) {
 		c.Abort()
 		return
 	}
+	// Tokens of carts and links have the subject, they are not sessions
+	if claims.Subject != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "not a session token"})
+		c.Abort()
+		return
+	}
 
 	c.Set("claims", claims)
 }
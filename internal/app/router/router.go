@@ -50,7 +50,6 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 	gin := gin.Default()
 	gin.Use(CORSMiddleware())
 	gin.Use(ginzap.RecoveryWithZap(logger, true))
-	gin.Static("/files", "./static/files")
 	docs.SwaggerInfo.BasePath = "/"
 	gin.Group("/docs").Any("/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router := &Router{
@@ -73,6 +72,48 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.GetFileList,
 		},
+		{
+			"GetFile",
+			http.MethodGet,
+			"/files/*filepath",
+			noOpMiddleware,
+			delivery.GetFile,
+		},
+		{
+			"GetFile",
+			http.MethodHead,
+			"/files/*filepath",
+			noOpMiddleware,
+			delivery.GetFile,
+		},
+		{
+			"PutUpload",
+			http.MethodPut,
+			"/files/uploads/:uploadID",
+			noOpMiddleware,
+			delivery.PutUpload,
+		},
+		{
+			"CreateUpload",
+			http.MethodPost,
+			"/images/uploads",
+			AdminAuth(),
+			delivery.CreateUpload,
+		},
+		{
+			"ConfirmUpload",
+			http.MethodPost,
+			"/images/uploads/:uploadID/confirm",
+			AdminAuth(),
+			delivery.ConfirmUpload,
+		},
+		{
+			"CheckStorage",
+			http.MethodPost,
+			"/images/check", //?dryRun=true
+			AdminAuth(),
+			delivery.CheckStorage,
+		},
 		// -------------------------CATEGORY----------------------------------------------------------------------------
 		{
 			"CreateCategory",
@@ -141,7 +182,7 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 		{
 			"GetItemsByCategory",
 			http.MethodGet,
-			"/items/", //?param=categoryName&offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name or price, sort_order == asc or desc)
+			"/items/", //?param=categoryName&offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name, price or rating, sort_order == asc or desc)
 			noOpMiddleware,
 			delivery.GetItemsByCategory,
 		},
@@ -166,6 +207,41 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.DeleteItemImage,
 		},
+		{
+			"UpdateItemImages",
+			http.MethodPut,
+			"/items/image/update/:itemID",
+			AdminAuth(),
+			delivery.UpdateItemImages,
+		},
+		{
+			"CreateReview",
+			http.MethodPost,
+			"/reviews/create/:itemID",
+			UserAuth(),
+			delivery.CreateReview,
+		},
+		{
+			"ItemReviews",
+			http.MethodGet,
+			"/reviews/item/:itemID", //?offset=0&limit=20
+			noOpMiddleware,
+			delivery.ItemReviews,
+		},
+		{
+			"ReviewsList",
+			http.MethodGet,
+			"/reviews/list", //?status=pending&offset=0&limit=20 (status == pending, approved or rejected)
+			AdminAuth(),
+			delivery.ReviewsList,
+		},
+		{
+			"ModerateReview",
+			http.MethodPut,
+			"/reviews/moderate/:reviewID",
+			AdminAuth(),
+			delivery.ModerateReview,
+		},
 		{
 			"ItemsQuantity",
 			http.MethodGet,
@@ -197,14 +273,14 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 		{
 			"ItemsList",
 			http.MethodGet,
-			"/items/list", //?offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name or price, sort_order == asc or desc)
+			"/items/list", //?offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name, price or rating, sort_order == asc or desc)
 			noOpMiddleware,
 			delivery.ItemsList,
 		},
 		{
 			"SearchLine",
 			http.MethodGet,
-			"/items/search/", //?param=searchRequest&offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name or price, sort_order == asc or desc)
+			"/items/search/", //?param=searchRequest&offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name, price or rating, sort_order == asc or desc)
 			noOpMiddleware,
 			delivery.SearchLine,
 		},
@@ -215,6 +291,34 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			AdminAuth(),
 			delivery.DeleteItem,
 		},
+		{
+			"SetItemStock",
+			http.MethodPut,
+			"/items/stock/:itemID",
+			AdminAuth(),
+			delivery.SetItemStock,
+		},
+		{
+			"SetQuantityLimits",
+			http.MethodPut,
+			"/items/limits/:itemID",
+			AdminAuth(),
+			delivery.SetQuantityLimits,
+		},
+		{
+			"ImportCatalogue",
+			http.MethodPost,
+			"/items/import", //?format=csv&dryRun=true (format == csv or jsonl)
+			AdminAuth(),
+			delivery.ImportCatalogue,
+		},
+		{
+			"ExportCatalogue",
+			http.MethodGet,
+			"/items/export", //?format=csv (format == csv or jsonl)
+			AdminAuth(),
+			delivery.ExportCatalogue,
+		},
 		{
 			"AddFavouriteItem",
 			http.MethodPost,
@@ -232,10 +336,95 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 		{
 			"GetFavouriteItems",
 			http.MethodGet,
-			"/items/favList/", //?param=userIDt&offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name or price, sort_order == asc or desc)
+			"/items/favList/", //?param=userIDt&offset=20&limit=10&sort_type=name&sort_order=asc (sort_type == name, price or rating, sort_order == asc or desc)
 			UserAuth(),
 			delivery.GetFavouriteItems,
 		},
+		// -------------------------WISHLISTS---------------------------------------------------------------------------
+		{
+			"CreateWishlist",
+			http.MethodPost,
+			"/wishlists/create",
+			UserAuth(),
+			delivery.CreateWishlist,
+		},
+		{
+			"WishlistsList",
+			http.MethodGet,
+			"/wishlists/list",
+			UserAuth(),
+			delivery.WishlistsList,
+		},
+		{
+			"GetSharedWishlist",
+			http.MethodGet,
+			"/wishlists/shared/:token",
+			noOpMiddleware,
+			delivery.GetSharedWishlist,
+		},
+		{
+			"GetWishlist",
+			http.MethodGet,
+			"/wishlists/:wishlistID",
+			UserAuth(),
+			delivery.GetWishlist,
+		},
+		{
+			"RenameWishlist",
+			http.MethodPut,
+			"/wishlists/update/:wishlistID",
+			UserAuth(),
+			delivery.RenameWishlist,
+		},
+		{
+			"DeleteWishlist",
+			http.MethodDelete,
+			"/wishlists/delete/:wishlistID",
+			UserAuth(),
+			delivery.DeleteWishlist,
+		},
+		{
+			"ShareWishlist",
+			http.MethodPost,
+			"/wishlists/share/:wishlistID",
+			UserAuth(),
+			delivery.ShareWishlist,
+		},
+		{
+			"UnshareWishlist",
+			http.MethodDelete,
+			"/wishlists/share/:wishlistID",
+			UserAuth(),
+			delivery.UnshareWishlist,
+		},
+		{
+			"AddWishlistItem",
+			http.MethodPut,
+			"/wishlists/addItem/:wishlistID/:itemID",
+			UserAuth(),
+			delivery.AddWishlistItem,
+		},
+		{
+			"DeleteWishlistItem",
+			http.MethodDelete,
+			"/wishlists/deleteItem/:wishlistID/:itemID",
+			UserAuth(),
+			delivery.DeleteWishlistItem,
+		},
+		{
+			"MoveWishlistItem",
+			http.MethodPut,
+			"/wishlists/move/:wishlistID",
+			UserAuth(),
+			delivery.MoveWishlistItem,
+		},
+		{
+			"MoveWishlistItemToCart",
+			http.MethodPut,
+			"/wishlists/toCart/:wishlistID/:itemID",
+			UserAuth(),
+			delivery.MoveWishlistItemToCart,
+		},
 		// -------------------------CART--------------------------------------------------------------------------------
 		{
 			"GetCart",
@@ -265,6 +454,13 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.AddItemToCart,
 		},
+		{
+			"ChangeItemQuantity",
+			http.MethodPut,
+			"/cart/quantity",
+			UserAuth(),
+			delivery.ChangeItemQuantity,
+		},
 		{
 			"DeleteItemFromCart",
 			http.MethodDelete,
@@ -279,6 +475,356 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.DeleteCart,
 		},
+		{
+			"ApplyPromoCode",
+			http.MethodPut,
+			"/cart/promo",
+			UserAuth(),
+			delivery.ApplyPromoCode,
+		},
+		{
+			"RemovePromoCode",
+			http.MethodDelete,
+			"/cart/promo/:cartID",
+			UserAuth(),
+			delivery.RemovePromoCode,
+		},
+		{
+			"CreateGuestCart",
+			http.MethodPost,
+			"/cart/guest",
+			noOpMiddleware,
+			delivery.CreateGuestCart,
+		},
+		{
+			"GetGuestCart",
+			http.MethodGet,
+			"/cart/guest", // X-Cart-Token header or cart_token cookie
+			noOpMiddleware,
+			delivery.GetGuestCart,
+		},
+		{
+			"AddItemToGuestCart",
+			http.MethodPut,
+			"/cart/guest/addItem/:itemID",
+			noOpMiddleware,
+			delivery.AddItemToGuestCart,
+		},
+		{
+			"DeleteItemFromGuestCart",
+			http.MethodDelete,
+			"/cart/guest/delete/:itemID",
+			noOpMiddleware,
+			delivery.DeleteItemFromGuestCart,
+		},
+		{
+			"ChangeGuestItemQuantity",
+			http.MethodPut,
+			"/cart/guest/quantity",
+			noOpMiddleware,
+			delivery.ChangeGuestItemQuantity,
+		},
+		{
+			"UnsubscribeCartReminders",
+			http.MethodGet,
+			"/cart/reminders/unsubscribe/:token",
+			noOpMiddleware,
+			delivery.UnsubscribeCartReminders,
+		},
+		// -------------------------PROMOTIONS--------------------------------------------------------------------------
+		{
+			"CreatePromotion",
+			http.MethodPost,
+			"/promotions/create",
+			AdminAuth(),
+			delivery.CreatePromotion,
+		},
+		{
+			"UpdatePromotion",
+			http.MethodPut,
+			"/promotions/update/:promotionID",
+			AdminAuth(),
+			delivery.UpdatePromotion,
+		},
+		{
+			"PromotionsList",
+			http.MethodGet,
+			"/promotions/list",
+			AdminAuth(),
+			delivery.PromotionsList,
+		},
+		{
+			"GetPromotion",
+			http.MethodGet,
+			"/promotions/:promotionID",
+			AdminAuth(),
+			delivery.GetPromotion,
+		},
+		{
+			"DeletePromotion",
+			http.MethodDelete,
+			"/promotions/delete/:promotionID",
+			AdminAuth(),
+			delivery.DeletePromotion,
+		},
+		// -------------------------CURRENCIES--------------------------------------------------------------------------
+		{
+			"RatesList",
+			http.MethodGet,
+			"/currencies/rates",
+			noOpMiddleware,
+			delivery.RatesList,
+		},
+		{
+			"SetRate",
+			http.MethodPut,
+			"/currencies/rates/:currency",
+			AdminAuth(),
+			delivery.SetRate,
+		},
+		{
+			"DeleteRate",
+			http.MethodDelete,
+			"/currencies/rates/:currency",
+			AdminAuth(),
+			delivery.DeleteRate,
+		},
+		// -------------------------TAXES-------------------------------------------------------------------------------
+		{
+			"CreateTaxRule",
+			http.MethodPost,
+			"/taxes/create",
+			AdminAuth(),
+			delivery.CreateTaxRule,
+		},
+		{
+			"UpdateTaxRule",
+			http.MethodPut,
+			"/taxes/update/:taxRuleID",
+			AdminAuth(),
+			delivery.UpdateTaxRule,
+		},
+		{
+			"TaxRulesList",
+			http.MethodGet,
+			"/taxes/list",
+			AdminAuth(),
+			delivery.TaxRulesList,
+		},
+		{
+			"DeleteTaxRule",
+			http.MethodDelete,
+			"/taxes/delete/:taxRuleID",
+			AdminAuth(),
+			delivery.DeleteTaxRule,
+		},
+		{
+			"SetItemTaxClass",
+			http.MethodPut,
+			"/taxes/class/item/:itemID",
+			AdminAuth(),
+			delivery.SetItemTaxClass,
+		},
+		{
+			"SetCategoryTaxClass",
+			http.MethodPut,
+			"/taxes/class/category/:categoryID",
+			AdminAuth(),
+			delivery.SetCategoryTaxClass,
+		},
+		// -------------------------SHIPPING----------------------------------------------------------------------------
+		{
+			"CreateShippingMethod",
+			http.MethodPost,
+			"/shipping/create",
+			AdminAuth(),
+			delivery.CreateShippingMethod,
+		},
+		{
+			"UpdateShippingMethod",
+			http.MethodPut,
+			"/shipping/update/:methodID",
+			AdminAuth(),
+			delivery.UpdateShippingMethod,
+		},
+		{
+			"ShippingMethodsList",
+			http.MethodGet,
+			"/shipping/list",
+			AdminAuth(),
+			delivery.ShippingMethodsList,
+		},
+		{
+			"DeleteShippingMethod",
+			http.MethodDelete,
+			"/shipping/delete/:methodID",
+			AdminAuth(),
+			delivery.DeleteShippingMethod,
+		},
+		{
+			"SetItemWeight",
+			http.MethodPut,
+			"/shipping/weight/:itemID",
+			AdminAuth(),
+			delivery.SetItemWeight,
+		},
+		{
+			"ShippingQuotes",
+			http.MethodGet,
+			"/checkout/shipping/:cartID",
+			UserAuth(),
+			delivery.ShippingQuotes,
+		},
+		// -------------------------PAYMENTS----------------------------------------------------------------------------
+		{
+			"PayOrder",
+			http.MethodPost,
+			"/order/:orderID/pay",
+			UserAuth(),
+			delivery.PayOrder,
+		},
+		{
+			"OrderPayments",
+			http.MethodGet,
+			"/payments/order/:orderID",
+			AdminAuth(),
+			delivery.OrderPayments,
+		},
+		{
+			"CapturePayment",
+			http.MethodPost,
+			"/payments/capture/:paymentID",
+			AdminAuth(),
+			delivery.CapturePayment,
+		},
+		{
+			"RefundPayment",
+			http.MethodPost,
+			"/payments/refund/:paymentID",
+			AdminAuth(),
+			delivery.RefundPayment,
+		},
+		{
+			"PaymentWebhook",
+			http.MethodPost,
+			"/payments/webhook",
+			noOpMiddleware,
+			delivery.PaymentWebhook,
+		},
+		// -------------------------SHIPMENTS---------------------------------------------------------------------------
+		{
+			"CreateShipment",
+			http.MethodPost,
+			"/shipments/create/:orderID",
+			AdminAuth(),
+			delivery.CreateShipment,
+		},
+		{
+			"GetShipment",
+			http.MethodGet,
+			"/shipments/:shipmentID",
+			AdminAuth(),
+			delivery.GetShipment,
+		},
+		{
+			"OrderShipments",
+			http.MethodGet,
+			"/shipments/list/:orderID",
+			AdminAuth(),
+			delivery.OrderShipments,
+		},
+		{
+			"UserOrderShipments",
+			http.MethodGet,
+			"/shipments/order/:orderID",
+			UserAuth(),
+			delivery.UserOrderShipments,
+		},
+		{
+			"ShipShipment",
+			http.MethodPost,
+			"/shipments/ship/:shipmentID",
+			AdminAuth(),
+			delivery.ShipShipment,
+		},
+		{
+			"TrackShipment",
+			http.MethodPost,
+			"/shipments/track/:shipmentID",
+			AdminAuth(),
+			delivery.TrackShipment,
+		},
+		{
+			"SetShipmentStatus",
+			http.MethodPut,
+			"/shipments/status/:shipmentID",
+			AdminAuth(),
+			delivery.SetShipmentStatus,
+		},
+		{
+			"DeleteShipment",
+			http.MethodDelete,
+			"/shipments/delete/:shipmentID",
+			AdminAuth(),
+			delivery.DeleteShipment,
+		},
+		// -------------------------RETURNS-----------------------------------------------------------------------------
+		{
+			"RequestReturn",
+			http.MethodPost,
+			"/returns/create/:orderID",
+			UserAuth(),
+			delivery.RequestReturn,
+		},
+		{
+			"OrderReturns",
+			http.MethodGet,
+			"/returns/order/:orderID",
+			UserAuth(),
+			delivery.OrderReturns,
+		},
+		{
+			"ReturnsList",
+			http.MethodGet,
+			"/returns/list",
+			AdminAuth(),
+			delivery.ReturnsList,
+		},
+		{
+			"ApproveReturn",
+			http.MethodPut,
+			"/returns/approve/:returnID",
+			AdminAuth(),
+			delivery.ApproveReturn,
+		},
+		{
+			"RejectReturn",
+			http.MethodPut,
+			"/returns/reject/:returnID",
+			AdminAuth(),
+			delivery.RejectReturn,
+		},
+		{
+			"ReceiveReturn",
+			http.MethodPut,
+			"/returns/receive/:returnID",
+			AdminAuth(),
+			delivery.ReceiveReturn,
+		},
+		{
+			"RefundReturn",
+			http.MethodPost,
+			"/returns/refund/:returnID",
+			AdminAuth(),
+			delivery.RefundReturn,
+		},
+		{
+			"OrderRefunds",
+			http.MethodGet,
+			"/returns/refunds/:orderID",
+			AdminAuth(),
+			delivery.OrderRefunds,
+		},
 		// -------------------------USER--------------------------------------------------------------------------------
 		{
 			"CreateUser",
@@ -373,6 +919,20 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 			UserAuth(),
 			delivery.GetOrder,
 		},
+		{
+			"Reorder",
+			http.MethodPost,
+			"/order/:orderID/reorder",
+			UserAuth(),
+			delivery.Reorder,
+		},
+		{
+			"OrderInvoice",
+			http.MethodGet,
+			"/order/:orderID/invoice",
+			UserAuth(),
+			delivery.OrderInvoice,
+		},
 		{
 			"GetOrdersForUsers",
 			http.MethodGet,
@@ -407,6 +967,8 @@ func NewRouter(delivery *delivery.Delivery, logger *zap.Logger) *Router {
 		switch route.Method {
 		case http.MethodGet:
 			gin.GET(route.Pattern, route.Middleware, route.HandlerFunc)
+		case http.MethodHead:
+			gin.HEAD(route.Pattern, route.Middleware, route.HandlerFunc)
 		case http.MethodPost:
 			gin.POST(route.Pattern, route.Middleware, route.HandlerFunc)
 		case http.MethodPut:
@@ -0,0 +1,25 @@
+package carrier
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"time"
+)
+
+// Carrier is a delivery service which takes shipments and reports their
+// state by tracking number
+type Carrier interface {
+	// Name is saved with shipments to track them by the same carrier
+	Name() string
+	// Register hands the shipment to the carrier, tracking number is returned
+	Register(ctx context.Context, shipment *models.Shipment) (string, error)
+	// Track returns current state of the shipment with the tracking number
+	Track(ctx context.Context, trackingNumber string) (*Tracking, error)
+}
+
+// Tracking is a state of the shipment reported by the carrier
+type Tracking struct {
+	Status models.ShipmentStatus
+	// UpdatedAt is time when the shipment got the status
+	UpdatedAt time.Time
+}
@@ -0,0 +1,65 @@
+package carrier
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ Carrier = &FakeCarrier{}
+
+// FakeCarrier is a carrier for local testing. Time of registration is kept
+// in the tracking number, and the shipment goes in transit after the step
+// and is delivered after two steps, so tracking updates can be seen without
+// any state of the carrier
+type FakeCarrier struct {
+	step   time.Duration
+	now    func() time.Time
+	logger *zap.Logger
+}
+
+func NewFakeCarrier(step time.Duration, logger *zap.Logger) *FakeCarrier {
+	logger.Debug("Enter in carrier NewFakeCarrier()")
+	return &FakeCarrier{step: step, now: time.Now, logger: logger}
+}
+
+func (carrier *FakeCarrier) Name() string {
+	return "fake"
+}
+
+// Register returns tracking number with time of registration
+func (carrier *FakeCarrier) Register(ctx context.Context, shipment *models.Shipment) (string, error) {
+	carrier.logger.Sugar().Debugf("Enter in carrier FakeCarrier Register() with args: ctx, shipment: %v", shipment)
+	if len(shipment.Lines) == 0 {
+		return "", fmt.Errorf("shipment is empty")
+	}
+	return fmt.Sprintf("FAKE-%d-%s", carrier.now().Unix(), uuid.NewString()[:8]), nil
+}
+
+// Track returns status of the shipment by time passed since registration
+func (carrier *FakeCarrier) Track(ctx context.Context, trackingNumber string) (*Tracking, error) {
+	carrier.logger.Sugar().Debugf("Enter in carrier FakeCarrier Track() with args: ctx, trackingNumber: %s", trackingNumber)
+	parts := strings.Split(trackingNumber, "-")
+	if len(parts) != 3 || parts[0] != "FAKE" {
+		return nil, fmt.Errorf("unknown tracking number: %s", trackingNumber)
+	}
+	unix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unknown tracking number: %s", trackingNumber)
+	}
+	registered := time.Unix(unix, 0)
+	passed := carrier.now().Sub(registered)
+	switch {
+	case passed >= 2*carrier.step:
+		return &Tracking{Status: models.ShipmentDelivered, UpdatedAt: registered.Add(2 * carrier.step)}, nil
+	case passed >= carrier.step:
+		return &Tracking{Status: models.ShipmentInTransit, UpdatedAt: registered.Add(carrier.step)}, nil
+	}
+	return &Tracking{Status: models.ShipmentShipped, UpdatedAt: registered}, nil
+}
@@ -0,0 +1,46 @@
+package carrier
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFakeCarrier(t *testing.T) {
+	ctx := context.Background()
+	carrier := NewFakeCarrier(time.Hour, zap.L())
+	registered := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := registered
+	carrier.now = func() time.Time { return now }
+
+	_, err := carrier.Register(ctx, &models.Shipment{})
+	require.Error(t, err)
+
+	trackingNumber, err := carrier.Register(ctx, &models.Shipment{Lines: []models.ShipmentLine{{ItemId: uuid.New(), Quantity: 1}}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		passed    time.Duration
+		status    models.ShipmentStatus
+		updatedAt time.Time
+	}{
+		{0, models.ShipmentShipped, registered},
+		{90 * time.Minute, models.ShipmentInTransit, registered.Add(time.Hour)},
+		{3 * time.Hour, models.ShipmentDelivered, registered.Add(2 * time.Hour)},
+	}
+	for _, test := range tests {
+		now = registered.Add(test.passed)
+		tracking, err := carrier.Track(ctx, trackingNumber)
+		require.NoError(t, err)
+		require.Equal(t, test.status, tracking.Status)
+		require.True(t, test.updatedAt.Equal(tracking.UpdatedAt))
+	}
+
+	_, err = carrier.Track(ctx, "UPS-1")
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/carrier/carrier.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	carrier "OnlineShopBackend/internal/carrier"
+	models "OnlineShopBackend/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCarrier is a mock of Carrier interface.
+type MockCarrier struct {
+	ctrl     *gomock.Controller
+	recorder *MockCarrierMockRecorder
+}
+
+// MockCarrierMockRecorder is the mock recorder for MockCarrier.
+type MockCarrierMockRecorder struct {
+	mock *MockCarrier
+}
+
+// NewMockCarrier creates a new mock instance.
+func NewMockCarrier(ctrl *gomock.Controller) *MockCarrier {
+	mock := &MockCarrier{ctrl: ctrl}
+	mock.recorder = &MockCarrierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCarrier) EXPECT() *MockCarrierMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockCarrier) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockCarrierMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockCarrier)(nil).Name))
+}
+
+// Register mocks base method.
+func (m *MockCarrier) Register(ctx context.Context, shipment *models.Shipment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, shipment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockCarrierMockRecorder) Register(ctx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockCarrier)(nil).Register), ctx, shipment)
+}
+
+// Track mocks base method.
+func (m *MockCarrier) Track(ctx context.Context, trackingNumber string) (*carrier.Tracking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", ctx, trackingNumber)
+	ret0, _ := ret[0].(*carrier.Tracking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockCarrierMockRecorder) Track(ctx, trackingNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockCarrier)(nil).Track), ctx, trackingNumber)
+}
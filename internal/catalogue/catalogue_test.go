@@ -0,0 +1,134 @@
+package catalogue
+
+import (
+	"OnlineShopBackend/internal/models"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRecords = []models.CatalogueRecord{
+	{Category: "Tools", CategoryDescription: "Tools for home"},
+	{
+		Sku:         "SKU-1",
+		Title:       "Hammer",
+		Description: "Steel hammer, 500 g",
+		Price:       1200,
+		Category:    "Tools",
+		Vendor:      "Acme",
+		Images:      []string{"http://localhost/files/1.jpeg", "http://localhost/files/2.jpeg"},
+	},
+	{Sku: "SKU-2", Title: "Nail", Price: 1, Category: "Tools"},
+}
+
+func readAll(t *testing.T, reader Reader) ([]models.CatalogueRecord, []*RowError) {
+	records := []models.CatalogueRecord{}
+	rowErrors := []*RowError{}
+	for {
+		record, _, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, rowErrors
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrors = append(rowErrors, rowErr)
+			continue
+		}
+		require.NoError(t, err)
+		records = append(records, *record)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatJSONL} {
+		buf := &bytes.Buffer{}
+		writer, err := NewWriter(buf, format)
+		require.NoError(t, err)
+		for i := range testRecords {
+			require.NoError(t, writer.Write(&testRecords[i]))
+		}
+		require.NoError(t, writer.Flush())
+
+		reader, err := NewReader(buf, format)
+		require.NoError(t, err)
+		records, rowErrors := readAll(t, reader)
+		require.Empty(t, rowErrors, format)
+		require.Equal(t, testRecords, records, format)
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, err := NewReader(strings.NewReader(""), "xml")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = NewWriter(&bytes.Buffer{}, "xml")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = ContentType("xml")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestCSVReader(t *testing.T) {
+	// Columns in any order, missing columns are empty
+	data := "\ufeffTitle,SKU,price,category\n" +
+		"Hammer,SKU-1,1200,Tools\n" +
+		"Nail,SKU-2,cheap,Tools\n" +
+		"Saw,SKU-3,\"500,Tools\n"
+	reader, err := NewReader(strings.NewReader(data), FormatCSV)
+	require.NoError(t, err)
+
+	record, line, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, 2, line)
+	require.Equal(t, &models.CatalogueRecord{Sku: "SKU-1", Title: "Hammer", Price: 1200, Category: "Tools"}, record)
+
+	_, line, err = reader.Read()
+	var rowErr *RowError
+	require.ErrorAs(t, err, &rowErr)
+	require.Equal(t, 3, line)
+	require.Equal(t, 3, rowErr.Line)
+
+	_, _, err = reader.Read()
+	require.ErrorAs(t, err, &rowErr)
+	require.Equal(t, 4, rowErr.Line)
+
+	_, _, err = reader.Read()
+	require.ErrorIs(t, err, io.EOF)
+
+	reader, err = NewReader(strings.NewReader("title,price\nHammer,1200\n"), FormatCSV)
+	require.NoError(t, err)
+	_, _, err = reader.Read()
+	require.ErrorIs(t, err, ErrInvalidCatalogue)
+
+	reader, err = NewReader(strings.NewReader(""), FormatCSV)
+	require.NoError(t, err)
+	_, _, err = reader.Read()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestJSONReader(t *testing.T) {
+	data := `{"sku":"SKU-1","title":"Hammer","price":1200,"category":"Tools"}
+
+{"sku":"SKU-2","price":"cheap"}
+{"category":"Tools","category_description":"Tools for home"}
+`
+	reader, err := NewReader(strings.NewReader(data), FormatJSONL)
+	require.NoError(t, err)
+	records, rowErrors := readAll(t, reader)
+	require.Equal(t, []models.CatalogueRecord{
+		{Sku: "SKU-1", Title: "Hammer", Price: 1200, Category: "Tools"},
+		{Category: "Tools", CategoryDescription: "Tools for home"},
+	}, records)
+	require.Len(t, rowErrors, 1)
+	require.Equal(t, 3, rowErrors[0].Line)
+}
+
+func TestCSVWriterEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := NewWriter(buf, FormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+	require.Equal(t, "sku,title,description,price,currency,category,category_description,vendor,images\n", buf.String())
+}
@@ -0,0 +1,218 @@
+package catalogue
+
+import (
+	"OnlineShopBackend/internal/models"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Formats of catalogue files
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Columns of csv catalogue, images of item are separated by imagesSeparator.
+// Price is in minor units of the currency, empty currency means the base one
+const (
+	columnSku                 = "sku"
+	columnTitle               = "title"
+	columnDescription         = "description"
+	columnPrice               = "price"
+	columnCurrency            = "currency"
+	columnCategory            = "category"
+	columnCategoryDescription = "category_description"
+	columnVendor              = "vendor"
+	columnImages              = "images"
+	imagesSeparator           = "|"
+)
+
+var columns = []string{
+	columnSku,
+	columnTitle,
+	columnDescription,
+	columnPrice,
+	columnCurrency,
+	columnCategory,
+	columnCategoryDescription,
+	columnVendor,
+	columnImages,
+}
+
+// maxLineSize is a max size of line of json lines catalogue
+const maxLineSize = 1 << 20
+
+// ErrUnsupportedFormat is returned for unknown format of catalogue
+var ErrUnsupportedFormat = errors.New("unsupported catalogue format")
+
+// ErrInvalidCatalogue is returned if catalogue can not be read at all
+var ErrInvalidCatalogue = errors.New("invalid catalogue")
+
+// RowError is an error of one row, reading may be continued after it
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (err *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", err.Line, err.Err)
+}
+
+func (err *RowError) Unwrap() error {
+	return err.Err
+}
+
+// Reader reads catalogue records one by one, so files of any size
+// are processed without loading them in memory
+type Reader interface {
+	// Read returns the next record and number of its line, io.EOF at the
+	// end of catalogue or *RowError if the row can not be parsed
+	Read() (*models.CatalogueRecord, int, error)
+}
+
+// NewReader returns reader of catalogue in given format
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return &csvReader{reader: reader}, nil
+	case FormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		return &jsonReader{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header map[string]int
+}
+
+func (reader *csvReader) Read() (*models.CatalogueRecord, int, error) {
+	if reader.header == nil {
+		err := reader.readHeader()
+		if err != nil {
+			return nil, 1, err
+		}
+	}
+	row, err := reader.reader.Read()
+	if err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, parseErr.StartLine, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("error on read catalogue: %w", err)
+	}
+	line, _ := reader.reader.FieldPos(0)
+	field := func(column string) string {
+		i, ok := reader.header[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+	record := &models.CatalogueRecord{
+		Sku:                 field(columnSku),
+		Title:               field(columnTitle),
+		Description:         field(columnDescription),
+		Currency:            field(columnCurrency),
+		Category:            field(columnCategory),
+		CategoryDescription: field(columnCategoryDescription),
+		Vendor:              field(columnVendor),
+	}
+	if images := field(columnImages); images != "" {
+		record.Images = strings.Split(images, imagesSeparator)
+	}
+	if price := field(columnPrice); price != "" {
+		value, err := strconv.ParseInt(price, 10, 64)
+		if err != nil {
+			return nil, line, &RowError{Line: line, Err: fmt.Errorf("invalid price %q", price)}
+		}
+		record.Price = value
+	}
+	return record, line, nil
+}
+
+func (reader *csvReader) readHeader() error {
+	row, err := reader.reader.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("error on read header of catalogue: %w", err)
+	}
+	reader.header = make(map[string]int, len(row))
+	for i, column := range row {
+		if i == 0 {
+			// Spreadsheet editors often write byte order mark
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		reader.header[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := reader.header[columnSku]; !ok {
+		return fmt.Errorf("%w: column %q not found in header", ErrInvalidCatalogue, columnSku)
+	}
+	return nil
+}
+
+type jsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// jsonRecord is a record of json lines catalogue
+type jsonRecord struct {
+	Sku                 string   `json:"sku"`
+	Title               string   `json:"title,omitempty"`
+	Description         string   `json:"description,omitempty"`
+	Price               int64    `json:"price,omitempty"`
+	Currency            string   `json:"currency,omitempty"`
+	Category            string   `json:"category"`
+	CategoryDescription string   `json:"category_description,omitempty"`
+	Vendor              string   `json:"vendor,omitempty"`
+	Images              []string `json:"images,omitempty"`
+}
+
+func (reader *jsonReader) Read() (*models.CatalogueRecord, int, error) {
+	for reader.scanner.Scan() {
+		reader.line++
+		data := strings.TrimSpace(reader.scanner.Text())
+		if data == "" {
+			continue
+		}
+		in := jsonRecord{}
+		err := json.Unmarshal([]byte(data), &in)
+		if err != nil {
+			return nil, reader.line, &RowError{Line: reader.line, Err: err}
+		}
+		return &models.CatalogueRecord{
+			Sku:                 strings.TrimSpace(in.Sku),
+			Title:               strings.TrimSpace(in.Title),
+			Description:         in.Description,
+			Price:               in.Price,
+			Currency:            strings.TrimSpace(in.Currency),
+			Category:            strings.TrimSpace(in.Category),
+			CategoryDescription: in.CategoryDescription,
+			Vendor:              in.Vendor,
+			Images:              in.Images,
+		}, reader.line, nil
+	}
+	err := reader.scanner.Err()
+	if err != nil {
+		return nil, reader.line + 1, fmt.Errorf("error on read catalogue: %w", err)
+	}
+	return nil, reader.line, io.EOF
+}
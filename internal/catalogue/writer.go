@@ -0,0 +1,115 @@
+package catalogue
+
+import (
+	"OnlineShopBackend/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Writer writes catalogue records one by one
+type Writer interface {
+	Write(record *models.CatalogueRecord) error
+	// Flush writes buffered data to the underlying writer
+	Flush() error
+}
+
+// NewWriter returns writer of catalogue in given format
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatJSONL:
+		return &jsonWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+// ContentType returns MIME type of catalogue in given format
+func ContentType(format string) (string, error) {
+	switch format {
+	case FormatCSV:
+		return "text/csv", nil
+	case FormatJSONL:
+		return "application/x-ndjson", nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (writer *csvWriter) Write(record *models.CatalogueRecord) error {
+	if !writer.headerWritten {
+		err := writer.writer.Write(columns)
+		if err != nil {
+			return fmt.Errorf("error on write header of catalogue: %w", err)
+		}
+		writer.headerWritten = true
+	}
+	price := ""
+	if !record.IsCategory() {
+		price = strconv.FormatInt(record.Price, 10)
+	}
+	err := writer.writer.Write([]string{
+		record.Sku,
+		record.Title,
+		record.Description,
+		price,
+		record.Currency,
+		record.Category,
+		record.CategoryDescription,
+		record.Vendor,
+		strings.Join(record.Images, imagesSeparator),
+	})
+	if err != nil {
+		return fmt.Errorf("error on write catalogue record: %w", err)
+	}
+	return nil
+}
+
+func (writer *csvWriter) Flush() error {
+	if !writer.headerWritten {
+		// Empty catalogue is written with header only
+		err := writer.writer.Write(columns)
+		if err != nil {
+			return fmt.Errorf("error on write header of catalogue: %w", err)
+		}
+		writer.headerWritten = true
+	}
+	writer.writer.Flush()
+	return writer.writer.Error()
+}
+
+type jsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (writer *jsonWriter) Write(record *models.CatalogueRecord) error {
+	err := writer.encoder.Encode(jsonRecord{
+		Sku:                 record.Sku,
+		Title:               record.Title,
+		Description:         record.Description,
+		Price:               record.Price,
+		Currency:            record.Currency,
+		Category:            record.Category,
+		CategoryDescription: record.CategoryDescription,
+		Vendor:              record.Vendor,
+		Images:              record.Images,
+	})
+	if err != nil {
+		return fmt.Errorf("error on write catalogue record: %w", err)
+	}
+	return nil
+}
+
+func (writer *jsonWriter) Flush() error {
+	return nil
+}
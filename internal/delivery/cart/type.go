@@ -3,12 +3,74 @@ package cart
 import (
 	"OnlineShopBackend/internal/delivery/item"
 	"sort"
+	"time"
 )
 
 type Cart struct {
-	Id     string     `json:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
-	UserId string     `json:"userId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
-	Items  []CartItem `json:"items" binding:"min=0" minimum:"0"`
+	Id        string     `json:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserId    string     `json:"userId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Items     []CartItem `json:"items" binding:"min=0" minimum:"0"`
+	PromoCode string     `json:"promoCode,omitempty" example:"SPRING10"`
+	// ExpireAt is prolonged on every activity with the cart
+	ExpireAt *time.Time `json:"expireAt,omitempty" example:"2023-01-01T12:00:00Z"`
+	// Warnings are changes of the items since they were added to the cart
+	Warnings []Warning `json:"warnings,omitempty"`
+	Totals
+}
+
+// Warning is a structure for displaying change of the item of the cart:
+// price_changed, unavailable (the item is removed from the cart) or
+// quantity_reduced (the quantity is reduced to the stock)
+type Warning struct {
+	Kind      string      `json:"kind" example:"price_changed" enums:"price_changed,unavailable,quantity_reduced"`
+	ItemId    string      `json:"itemId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Title     string      `json:"title" example:"Пылесос"`
+	OldPrice  *item.Price `json:"oldPrice,omitempty"`
+	NewPrice  *item.Price `json:"newPrice,omitempty"`
+	Requested int         `json:"requested,omitempty" example:"5"`
+	Available int         `json:"available,omitempty" example:"2"`
+}
+
+// Totals is a structure for amounts of the cart or order in minor units of the base currency
+type Totals struct {
+	Currency          string     `json:"currency,omitempty" example:"RUB"`
+	Subtotal          int64      `json:"subtotal" example:"2000000"`
+	FormattedSubtotal string     `json:"formattedSubtotal,omitempty" example:"20000.00 RUB"`
+	Discounts         []Discount `json:"discounts,omitempty"`
+	Taxes             []Tax      `json:"taxes,omitempty"`
+	Shipping          int64      `json:"shipping,omitempty" example:"30000"`
+	FormattedShipping string     `json:"formattedShipping,omitempty" example:"300.00 RUB"`
+	Total             int64      `json:"total" example:"1800000"`
+	FormattedTotal    string     `json:"formattedTotal,omitempty" example:"18000.00 RUB"`
+	// DisplayTotal is the total converted to the currency requested by the client
+	DisplayTotal *item.Price `json:"displayTotal,omitempty"`
+}
+
+// Discount is a structure for displaying discount applied to the cart or order
+type Discount struct {
+	PromotionId string `json:"promotionId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Code        string `json:"code,omitempty" example:"SPRING10"`
+	Name        string `json:"name" example:"Spring sale"`
+	Amount      int64  `json:"amount" example:"200000"`
+	Formatted   string `json:"formatted,omitempty" example:"2000.00 RUB"`
+}
+
+// Tax is a structure for displaying tax of the cart or order, inclusive
+// tax is already in prices, exclusive tax is added to the total
+type Tax struct {
+	TaxRuleId string `json:"taxRuleId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Name      string `json:"name" example:"VAT"`
+	TaxClass  string `json:"taxClass" example:"standard"`
+	Rate      int64  `json:"rate" example:"2000"`
+	Inclusive bool   `json:"inclusive" example:"true"`
+	Amount    int64  `json:"amount" example:"300000"`
+	Formatted string `json:"formatted,omitempty" example:"3000.00 RUB"`
+}
+
+// PromoCode is a structure for applying promo code to the cart
+type PromoCode struct {
+	CartId string `json:"cartId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Code   string `json:"code" binding:"required,max=64" example:"SPRING10"`
 }
 
 func (cart *Cart) SortCartItems() {
@@ -32,3 +94,21 @@ type CartItem struct {
 type Quantity struct {
 	Quantity int `json:"quantity" example:"3" default:"1" binding:"required" minimum:"1"`
 }
+
+// GuestCart is a structure for the created cart of the anonymous visitor,
+// the token is passed in the X-Cart-Token header to the guest cart methods
+// and to the login to merge the cart into the cart of the user
+type GuestCart struct {
+	CartId string `json:"cartId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Token  string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// ItemQuantity is a structure for change of the quantity of the item in the cart:
+// either exact quantity is set (zero removes the item) or the quantity is decreased.
+// Cart id isn't needed for the guest cart
+type ItemQuantity struct {
+	CartId    string `json:"cartId,omitempty" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ItemId    string `json:"itemId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Quantity  *int   `json:"quantity,omitempty" binding:"omitempty,min=0" example:"3" minimum:"0"`
+	Decrement int    `json:"decrement,omitempty" binding:"omitempty,min=1" example:"1" minimum:"1"`
+}
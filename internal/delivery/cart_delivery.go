@@ -23,11 +23,14 @@ import (
 // GetCart - get a specific cart by id
 //
 //	@Summary		Get cart by id
-//	@Description	The method allows you to get the cart by id.
+//	@Description	The method allows you to get the cart by id. With country of shipping the cart shows taxes as it would be ordered.
 //	@Tags			carts
 //	@Accept			json
 //	@Produce		json
-//	@Param			cartID	path		string		true	"Id of cart"
+//	@Param			cartID		path		string		true	"Id of cart"
+//	@Param			currency	query		string		false	"Currency of display prices, e.g. USD"
+//	@Param			country		query		string		false	"Country of shipping for preview of taxes"
+//	@Param			region		query		string		false	"Region (city) of shipping for preview of taxes"
 //	@Success		200		{object}	cart.Cart	"Cart structure"
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		403		"Forbidden"
@@ -36,6 +39,10 @@ import (
 //	@Router			/cart/{cartID} [get]
 func (delivery *Delivery) GetCart(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery GetCart()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	ctx := c.Request.Context()
 
 	cartId, err := uuid.Parse(c.Param("cartID"))
@@ -58,39 +65,24 @@ func (delivery *Delivery) GetCart(c *gin.Context) {
 		return
 	}
 
-	cartItems := make([]cart.CartItem, len(modelCart.Items))
-	for idx, item := range modelCart.Items {
-		cartItems[idx].Item.Id = item.Id.String()
-		cartItems[idx].Item.Title = item.Title
-		cartItems[idx].Item.Description = item.Description
-		cartItems[idx].Item.Category.Id = item.Category.Id.String()
-		cartItems[idx].Item.Category.Name = item.Category.Name
-		cartItems[idx].Item.Category.Description = item.Category.Description
-		cartItems[idx].Item.Category.Image = item.Category.Image
-		cartItems[idx].Item.Price = item.Price
-		cartItems[idx].Item.Vendor = item.Vendor
-		cartItems[idx].Item.Images = item.Images
-		cartItems[idx].Quantity.Quantity = item.Quantity
-	}
-
-	cart := cart.Cart{
-		Id:     modelCart.Id.String(),
-		UserId: modelCart.UserId.String(),
-		Items:  cartItems,
+	if !delivery.applyCartTaxes(c, modelCart) {
+		return
 	}
-	cart.SortCartItems()
 
-	c.JSON(http.StatusOK, cart)
+	c.JSON(http.StatusOK, cartToDelivery(modelCart, display))
 }
 
 // GetCartByUserId - get a specific cart by user id
 //
 //	@Summary		Get cart by user id
-//	@Description	The method allows you to get the cart by user id.
+//	@Description	The method allows you to get the cart by user id. With country of shipping the cart shows taxes as it would be ordered.
 //	@Tags			carts
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	path		string		true	"Id of user"
+//	@Param			userID		path		string		true	"Id of user"
+//	@Param			currency	query		string		false	"Currency of display prices, e.g. USD"
+//	@Param			country		query		string		false	"Country of shipping for preview of taxes"
+//	@Param			region		query		string		false	"Region (city) of shipping for preview of taxes"
 //	@Success		200		{object}	cart.Cart	"Cart structure"
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		403		"Forbidden"
@@ -99,6 +91,10 @@ func (delivery *Delivery) GetCart(c *gin.Context) {
 //	@Router			/cart/byUser/{userID} [get]
 func (delivery *Delivery) GetCartByUserId(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery GetCartByUserId()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	ctx := c.Request.Context()
 
 	userId, err := uuid.Parse(c.Param("userID"))
@@ -121,29 +117,11 @@ func (delivery *Delivery) GetCartByUserId(c *gin.Context) {
 		return
 	}
 
-	cartItems := make([]cart.CartItem, len(modelCart.Items))
-	for idx, item := range modelCart.Items {
-		cartItems[idx].Item.Id = item.Id.String()
-		cartItems[idx].Item.Title = item.Title
-		cartItems[idx].Item.Description = item.Description
-		cartItems[idx].Item.Category.Id = item.Category.Id.String()
-		cartItems[idx].Item.Category.Name = item.Category.Name
-		cartItems[idx].Item.Category.Description = item.Category.Description
-		cartItems[idx].Item.Category.Image = item.Category.Image
-		cartItems[idx].Item.Price = item.Price
-		cartItems[idx].Item.Vendor = item.Vendor
-		cartItems[idx].Item.Images = item.Images
-		cartItems[idx].Quantity.Quantity = item.Quantity
-	}
-
-	cart := cart.Cart{
-		Id:     modelCart.Id.String(),
-		UserId: modelCart.UserId.String(),
-		Items:  cartItems,
+	if !delivery.applyCartTaxes(c, modelCart) {
+		return
 	}
-	cart.SortCartItems()
 
-	c.JSON(http.StatusOK, cart)
+	c.JSON(http.StatusOK, cartToDelivery(modelCart, display))
 }
 
 // CreateCart - create a new cart
@@ -193,6 +171,7 @@ func (delivery *Delivery) CreateCart(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Maximal quantity of the item is already in the cart"
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/cart/addItem [put]
 func (delivery *Delivery) AddItemToCart(c *gin.Context) {
@@ -224,6 +203,11 @@ func (delivery *Delivery) AddItemToCart(c *gin.Context) {
 		return
 	}
 	err = delivery.cartUsecase.AddItemToCart(ctx, cartId, itemId)
+	if err != nil && errors.Is(err, models.ErrQuantityLimit) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -311,3 +295,233 @@ func (delivery *Delivery) DeleteItemFromCart(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+// ApplyPromoCode - apply promo code to the cart
+//
+//	@Summary		Method provides to apply promo code to cart
+//	@Description	Method provides to apply promo code to cart, the previous promo code of the cart is replaced.
+//	@Description	The promo code is checked again when the order is placed.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			promoCode	body		cart.PromoCode	true	"Cart id and promo code"
+//	@Param			currency	query		string			false	"Currency of display prices, e.g. USD"
+//	@Success		200			{object}	cart.Cart		"Cart with discounts"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"Cart or promo code not found"
+//	@Failure		422			{object}	ErrorResponse	"Promo code can't be applied to the cart"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/cart/promo [put]
+func (delivery *Delivery) ApplyPromoCode(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ApplyPromoCode()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
+	var promoCode cart.PromoCode
+	if err := c.ShouldBindJSON(&promoCode); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	cartId, err := uuid.Parse(promoCode.CartId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelCart, err := delivery.cartUsecase.ApplyPromoCode(c.Request.Context(), cartId, promoCode.Code)
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("cart with id: %v or promo code %s not found", cartId, promoCode.Code)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrPromoCode):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, cartToDelivery(modelCart, display))
+}
+
+// RemovePromoCode - remove promo code from the cart
+//
+//	@Summary		Method provides to remove promo code from cart
+//	@Description	Method provides to remove promo code from cart, automatic promotions are still applied.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			cartID	path	string	true	"id of cart"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/promo/{cartID} [delete]
+func (delivery *Delivery) RemovePromoCode(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RemovePromoCode()")
+	cartId, err := uuid.Parse(c.Param("cartID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.cartUsecase.RemovePromoCode(c.Request.Context(), cartId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("cart with id: %v not found", cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ChangeItemQuantity - set or decrease quantity of the item in the cart
+//
+//	@Summary		Method provides to change quantity of item in cart
+//	@Description	Method provides to set exact quantity of the item in the cart or to decrease it.
+//	@Description	Quantity is checked against minimal and maximal quantity of the item, zero quantity removes the item.
+//	@Description	Decrease removes the item when its quantity becomes less than the minimal quantity.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			quantity	body		cart.ItemQuantity	true	"Cart id, item id and quantity or decrement"
+//	@Success		200			{object}	cart.Quantity		"New quantity of the item in the cart"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Quantity is out of limits of the item"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/cart/quantity [put]
+func (delivery *Delivery) ChangeItemQuantity(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ChangeItemQuantity()")
+	var itemQuantity cart.ItemQuantity
+	if err := c.ShouldBindJSON(&itemQuantity); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	cartId, err := uuid.Parse(itemQuantity.CartId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	delivery.changeItemQuantity(c, cartId, itemQuantity)
+}
+
+// changeItemQuantity sets or decreases quantity of the item in the cart
+// and writes the new quantity in response
+func (delivery *Delivery) changeItemQuantity(c *gin.Context, cartId uuid.UUID, itemQuantity cart.ItemQuantity) {
+	if (itemQuantity.Quantity == nil) == (itemQuantity.Decrement == 0) {
+		err := fmt.Errorf("either quantity or decrement must be set")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	itemId, err := uuid.Parse(itemQuantity.ItemId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := c.Request.Context()
+	var quantity int
+	if itemQuantity.Quantity != nil {
+		quantity = *itemQuantity.Quantity
+		err = delivery.cartUsecase.SetItemQuantity(ctx, cartId, itemId, quantity)
+	} else {
+		quantity, err = delivery.cartUsecase.DecrementItem(ctx, cartId, itemId, itemQuantity.Decrement)
+	}
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("item with id: %v not found in cart with id: %v", itemId, cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidQuantity):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case errors.Is(err, models.ErrQuantityLimit):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, cart.Quantity{Quantity: quantity})
+}
+
+// cartToDelivery converts cart with its items and discounts
+func cartToDelivery(modelCart *models.Cart, display *displayPrices) cart.Cart {
+	cartItems := make([]cart.CartItem, len(modelCart.Items))
+	for idx, item := range modelCart.Items {
+		cartItems[idx].Item.Id = item.Id.String()
+		cartItems[idx].Item.Title = item.Title
+		cartItems[idx].Item.Description = item.Description
+		cartItems[idx].Item.Category.Id = item.Category.Id.String()
+		cartItems[idx].Item.Category.Name = item.Category.Name
+		cartItems[idx].Item.Category.Description = item.Category.Description
+		cartItems[idx].Item.Category.Image = item.Category.Image
+		cartItems[idx].Item.Price = item.Price
+		cartItems[idx].Item.Currency = item.Currency
+		cartItems[idx].Item.FormattedPrice = item.Money().Format()
+		cartItems[idx].Item.DisplayPrice = display.price(item.Money())
+		cartItems[idx].Item.Vendor = item.Vendor
+		cartItems[idx].Item.Images = item.ImageURLs()
+		cartItems[idx].Item.ImageVariants = itemImages(item.Images)
+		cartItems[idx].Quantity.Quantity = item.Quantity
+	}
+
+	cart := cart.Cart{
+		Id:        modelCart.Id.String(),
+		Items:     cartItems,
+		PromoCode: modelCart.PromoCode,
+		Totals:    totalsToDelivery(modelCart.Pricing, display),
+	}
+	// Guest cart has no user
+	if modelCart.UserId != uuid.Nil {
+		cart.UserId = modelCart.UserId.String()
+	}
+	if !modelCart.ExpireAt.IsZero() {
+		cart.ExpireAt = &modelCart.ExpireAt
+	}
+	cart.Warnings = warningsToDelivery(modelCart.Warnings)
+	cart.SortCartItems()
+	return cart
+}
+
+// warningsToDelivery converts warnings about changed items of the cart
+func warningsToDelivery(modelWarnings []models.CartWarning) []cart.Warning {
+	if len(modelWarnings) == 0 {
+		return nil
+	}
+	warnings := make([]cart.Warning, len(modelWarnings))
+	for idx, warning := range modelWarnings {
+		warnings[idx] = cart.Warning{
+			Kind:      string(warning.Kind),
+			ItemId:    warning.ItemId.String(),
+			Title:     warning.Title,
+			Requested: warning.Requested,
+			Available: warning.Available,
+		}
+		if warning.Kind == models.WarningPriceChanged {
+			warnings[idx].OldPrice = moneyToPrice(warning.OldPrice)
+			warnings[idx].NewPrice = moneyToPrice(warning.NewPrice)
+		}
+	}
+	return warnings
+}
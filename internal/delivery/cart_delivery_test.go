@@ -4,6 +4,8 @@ import (
 	"OnlineShopBackend/internal/delivery/cart"
 	"OnlineShopBackend/internal/delivery/category"
 	"OnlineShopBackend/internal/delivery/item"
+	"OnlineShopBackend/internal/delivery/order"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
 	fs "OnlineShopBackend/internal/filestorage/mocks"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/usecase/mocks"
@@ -14,6 +16,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -43,7 +46,7 @@ var (
 		Id:     testId,
 		Title:  "test",
 		Price:  1,
-		Images: []string{"test"},
+		Images: []models.ItemImage{{URL: "test", Primary: true}},
 	}
 	testModelItemWithQuantity = models.ItemWithQuantity{
 		Quantity: 1,
@@ -124,13 +127,14 @@ func TestGetCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -146,6 +150,7 @@ func TestGetCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -162,6 +167,7 @@ func TestGetCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -178,6 +184,7 @@ func TestGetCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -190,6 +197,57 @@ func TestGetCart(t *testing.T) {
 	require.Equal(t, 200, w.Code)
 }
 
+func TestGetCartWarnings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase}, logger, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	c.Params = []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testCartId.String(),
+		},
+	}
+	modelCart := &models.Cart{
+		Id: testCartId,
+		Warnings: []models.CartWarning{
+			{Kind: models.WarningUnavailable, ItemId: testId, Title: "Deleted"},
+			{
+				Kind:     models.WarningPriceChanged,
+				ItemId:   testId,
+				Title:    "Changed",
+				OldPrice: models.Money{Amount: 1000, Currency: "RUB"},
+				NewPrice: models.Money{Amount: 1500, Currency: "RUB"},
+			},
+		},
+	}
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(modelCart, nil)
+	delivery.GetCart(c)
+	require.Equal(t, 200, w.Code)
+	var res cart.Cart
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, []cart.Warning{
+		{Kind: "unavailable", ItemId: testId.String(), Title: "Deleted"},
+		{
+			Kind:     "price_changed",
+			ItemId:   testId.String(),
+			Title:    "Changed",
+			OldPrice: &item.Price{Amount: 1000, Currency: "RUB", Formatted: models.Money{Amount: 1000, Currency: "RUB"}.Format()},
+			NewPrice: &item.Price{Amount: 1500, Currency: "RUB", Formatted: models.Money{Amount: 1500, Currency: "RUB"}.Format()},
+		},
+	}, res.Warnings)
+}
+
 func TestGetCartByUserId(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -200,13 +258,14 @@ func TestGetCartByUserId(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -222,6 +281,7 @@ func TestGetCartByUserId(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -238,6 +298,7 @@ func TestGetCartByUserId(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -254,6 +315,7 @@ func TestGetCartByUserId(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -275,13 +337,14 @@ func TestCreateCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -298,6 +361,7 @@ func TestCreateCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -315,6 +379,7 @@ func TestCreateCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -338,13 +403,14 @@ func TestAddItemToCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockCartJson(c, testWrongShortCart, "PUT")
 	delivery.AddItemToCart(c)
@@ -355,6 +421,7 @@ func TestAddItemToCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockCartJson(c, testShortCart, "PUT")
 	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(err)
@@ -366,6 +433,7 @@ func TestAddItemToCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockCartJson(c, testShortCart, "PUT")
 	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(nil)
@@ -383,13 +451,14 @@ func TestDeleteCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -406,6 +475,7 @@ func TestDeleteCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -423,6 +493,7 @@ func TestDeleteCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -446,13 +517,14 @@ func TestDeleteItemFromCart(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	delivery.DeleteItemFromCart(c)
 	require.Equal(t, 400, w.Code)
@@ -462,6 +534,7 @@ func TestDeleteItemFromCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -481,6 +554,7 @@ func TestDeleteItemFromCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -501,6 +575,7 @@ func TestDeleteItemFromCart(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -517,3 +592,129 @@ func TestDeleteItemFromCart(t *testing.T) {
 	delivery.DeleteItemFromCart(c)
 	require.Equal(t, 200, w.Code)
 }
+
+func TestChangeItemQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase}, logger, nil, nil)
+	quantity := 3
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	// Quantity and decrement can't be set together
+	MockCartJson(c, cart.ItemQuantity{CartId: testCartId.String(), ItemId: testId.String(), Quantity: &quantity, Decrement: 1}, "PUT")
+	delivery.ChangeItemQuantity(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	MockCartJson(c, cart.ItemQuantity{CartId: testCartId.String(), ItemId: testId.String(), Quantity: &quantity}, "PUT")
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testCartId, testId, quantity).Return(models.ErrQuantityLimit)
+	delivery.ChangeItemQuantity(c)
+	require.Equal(t, 422, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	MockCartJson(c, cart.ItemQuantity{CartId: testCartId.String(), ItemId: testId.String(), Quantity: &quantity}, "PUT")
+	cartUsecase.EXPECT().SetItemQuantity(ctx, testCartId, testId, quantity).Return(nil)
+	delivery.ChangeItemQuantity(c)
+	require.Equal(t, 200, w.Code)
+	require.JSONEq(t, `{"quantity":3}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	MockCartJson(c, cart.ItemQuantity{CartId: testCartId.String(), ItemId: testId.String(), Decrement: 2}, "PUT")
+	cartUsecase.EXPECT().DecrementItem(ctx, testCartId, testId, 2).Return(0, models.ErrorNotFound{})
+	delivery.ChangeItemQuantity(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	MockCartJson(c, cart.ItemQuantity{CartId: testCartId.String(), ItemId: testId.String(), Decrement: 2}, "PUT")
+	cartUsecase.EXPECT().DecrementItem(ctx, testCartId, testId, 2).Return(1, nil)
+	delivery.ChangeItemQuantity(c)
+	require.Equal(t, 200, w.Code)
+	require.JSONEq(t, `{"quantity":1}`, w.Body.String())
+}
+
+func TestReorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase, Order: orderUsecase}, logger, nil, nil)
+	userId := uuid.New()
+	orderId := uuid.New()
+	newContext := func(orderId string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "orderID",
+				Value: orderId,
+			},
+		}
+		c.Set("claims", &jwtauth.Payload{UserId: userId})
+		return w, c
+	}
+
+	w, c := newContext("1")
+	delivery.Reorder(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(orderId.String())
+	orderUsecase.EXPECT().GetOrder(ctx, orderId).Return(nil, fmt.Errorf("can't get order: %w", models.ErrorNotFound{}))
+	delivery.Reorder(c)
+	require.Equal(t, 404, w.Code)
+
+	modelOrder := &models.Order{ID: orderId, User: models.User{ID: uuid.New()}}
+	w, c = newContext(orderId.String())
+	orderUsecase.EXPECT().GetOrder(ctx, orderId).Return(modelOrder, nil)
+	cartUsecase.EXPECT().Reorder(ctx, userId, modelOrder).Return(nil, models.ErrorNotFound{})
+	delivery.Reorder(c)
+	require.Equal(t, 404, w.Code)
+
+	w, c = newContext(orderId.String())
+	orderUsecase.EXPECT().GetOrder(ctx, orderId).Return(modelOrder, nil)
+	cartUsecase.EXPECT().Reorder(ctx, userId, modelOrder).Return(&models.Reorder{
+		CartId:  testCartId,
+		Added:   []models.ReorderLine{{ItemId: testId, Title: "Item", Requested: 3, Added: 2}},
+		Skipped: []models.ReorderLine{{ItemId: testId, Title: "Deleted", Requested: 1, Reason: models.ReorderDeleted}},
+	}, nil)
+	delivery.Reorder(c)
+	require.Equal(t, 200, w.Code)
+	var res order.Reorder
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, testCartId.String(), res.CartId)
+	require.Equal(t, []order.ReorderLine{{ItemId: testId.String(), Title: "Item", Requested: 3, Added: 2}}, res.Added)
+	require.Equal(t, "deleted", res.Skipped[0].Reason)
+}
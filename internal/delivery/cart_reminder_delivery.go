@@ -0,0 +1,60 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/metrics"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UnsubscribeCartReminders - unsubscribe from reminders about abandoned carts
+//
+//	@Summary		Unsubscribe from cart reminders
+//	@Description	Method provides to stop reminders about abandoned carts by the link from the reminder without login.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			token	path	string	true	"token of unsubscribe link"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/reminders/unsubscribe/{token} [get]
+func (delivery *Delivery) UnsubscribeCartReminders(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UnsubscribeCartReminders()")
+	userId, err := jwtauth.ParseUnsubscribeToken(c.Param("token"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.cartReminderUsecase.Unsubscribe(c.Request.Context(), userId)
+	switch {
+	case err == nil:
+	case errors.Is(err, models.ErrorNotFound{}):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	default:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// recoverCart counts the abandoned cart which became an order. Errors
+// are only logged so that the order doesn't fail because of metrics
+func (delivery *Delivery) recoverCart(c *gin.Context, cartId uuid.UUID) {
+	recovered, err := delivery.cartReminderUsecase.RecoverCart(c.Request.Context(), cartId)
+	if err != nil {
+		delivery.logger.Sugar().Warnf("error on recover abandoned cart %v: %v", cartId, err)
+		return
+	}
+	if recovered {
+		metrics.CartsMetrics.AbandonedCartsRecovered.Inc()
+	}
+}
@@ -0,0 +1,56 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUnsubscribeCartReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	cartReminderUsecase := mocks.NewMockICartReminderUsecase(ctrl)
+	delivery := NewDelivery(Usecases{CartReminder: cartReminderUsecase}, zap.L(), nil, nil)
+	userId := uuid.New()
+
+	// Token of the guest cart isn't accepted
+	cartToken, err := jwtauth.NewCartToken(testCartId)
+	require.NoError(t, err)
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"token": cartToken}, nil, "")
+	delivery.UnsubscribeCartReminders(c)
+	require.Equal(t, 400, w.Code)
+
+	token, err := jwtauth.NewUnsubscribeToken(userId)
+	require.NoError(t, err)
+	// Token of the link isn't signed with the key of sessions
+	key, err := jwtauth.NewJWTKeyConfig()
+	require.NoError(t, err)
+	_, err = jwt.ParseWithClaims(token, &jwtauth.Payload{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(key.Key), nil
+	})
+	require.Error(t, err)
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	for _, test := range tests {
+		w, c = newWishlistContext(uuid.Nil, map[string]string{"token": token}, nil, "")
+		cartReminderUsecase.EXPECT().Unsubscribe(ctx, userId).Return(test.err)
+		delivery.UnsubscribeCartReminders(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
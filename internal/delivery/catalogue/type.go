@@ -0,0 +1,18 @@
+package catalogue
+
+// RowError is a structure for displaying the error of one row of imported catalogue
+type RowError struct {
+	Line  int    `json:"line" example:"12"`
+	Sku   string `json:"sku,omitempty" example:"SKU-0001"`
+	Error string `json:"error" example:"price must be positive"`
+}
+
+// ImportReport is a structure for displaying the result of import of catalogue
+type ImportReport struct {
+	DryRun  bool       `json:"dryRun" example:"false"`
+	Total   int        `json:"total" example:"100"`
+	Created int        `json:"created" example:"80"`
+	Updated int        `json:"updated" example:"19"`
+	Failed  int        `json:"failed" example:"1"`
+	Errors  []RowError `json:"errors"`
+}
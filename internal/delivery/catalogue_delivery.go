@@ -0,0 +1,160 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/catalogue"
+	deliveryCatalogue "OnlineShopBackend/internal/delivery/catalogue"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogueOptions is the structure for import and export of catalogue
+type CatalogueOptions struct {
+	Format string `form:"format"`
+	DryRun bool   `form:"dryRun"`
+}
+
+// ImportCatalogue - create or update categories and items from file
+//
+//	@Summary		Import catalogue
+//	@Description	Method provides to create or update items by sku and categories from csv or json lines file.
+//	@Description	File is sent as request body or as "file" field of multipart form.
+//	@Description	Rows without sku create categories or update their description.
+//	@Description	With dryRun=true database is not changed and the report shows what would be done.
+//	@Tags			items
+//	@Accept			text/csv,application/x-ndjson,multipart/form-data
+//	@Produce		json
+//	@Param			format	query		string	false	"format of file: csv or jsonl"
+//	@Param			dryRun	query		bool	false	"validate file without changes"
+//	@Success		200		{object}	catalogue.ImportReport
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/items/import [post]
+func (delivery *Delivery) ImportCatalogue(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ImportCatalogue()")
+	ctx := c.Request.Context()
+	var options CatalogueOptions
+	err := c.ShouldBindQuery(&options)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	contentType := c.ContentType()
+	if contentType == "multipart/form-data" {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			delivery.logger.Error(err.Error())
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+		defer file.Close()
+		body = file
+		if options.Format == "" {
+			options.Format = strings.TrimPrefix(filepath.Ext(header.Filename), ".")
+		}
+	}
+	if options.Format == "" {
+		options.Format = formatByContentType(contentType)
+	}
+	delivery.logger.Sugar().Debugf("options is %v", options)
+
+	report, err := delivery.catalogueUsecase.Import(ctx, body, options.Format, options.DryRun)
+	if err != nil && (errors.Is(err, catalogue.ErrUnsupportedFormat) || errors.Is(err, catalogue.ErrInvalidCatalogue)) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, reportToDelivery(report))
+}
+
+// ExportCatalogue - get all categories and items as file
+//
+//	@Summary		Export catalogue
+//	@Description	Method provides to get all categories and items as csv or json lines file.
+//	@Description	The file may be imported back without changes.
+//	@Tags			items
+//	@Produce		text/csv,application/x-ndjson
+//	@Param			format	query	string	false	"format of file: csv (default) or jsonl"
+//	@Success		200		{file}	file
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/items/export [get]
+func (delivery *Delivery) ExportCatalogue(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ExportCatalogue()")
+	ctx := c.Request.Context()
+	var options CatalogueOptions
+	err := c.ShouldBindQuery(&options)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if options.Format == "" {
+		options.Format = catalogue.FormatCSV
+	}
+	contentType, err := catalogue.ContentType(options.Format)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=catalogue.%s", options.Format))
+	// Catalogue is streamed, so after the first written
+	// byte the error can only be logged
+	err = delivery.catalogueUsecase.Export(ctx, c.Writer, options.Format)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		if !c.Writer.Written() {
+			delivery.SetError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// formatByContentType returns format of catalogue by content type of request
+func formatByContentType(contentType string) string {
+	switch contentType {
+	case "application/x-ndjson", "application/jsonl", "application/json":
+		return catalogue.FormatJSONL
+	default:
+		return catalogue.FormatCSV
+	}
+}
+
+func reportToDelivery(report *models.ImportReport) deliveryCatalogue.ImportReport {
+	result := deliveryCatalogue.ImportReport{
+		DryRun:  report.DryRun,
+		Total:   report.Total,
+		Created: report.Created,
+		Updated: report.Updated,
+		Failed:  report.Failed,
+		Errors:  make([]deliveryCatalogue.RowError, 0, len(report.Errors)),
+	}
+	for _, rowErr := range report.Errors {
+		result.Errors = append(result.Errors, deliveryCatalogue.RowError{
+			Line:  rowErr.Line,
+			Sku:   rowErr.Sku,
+			Error: rowErr.Error,
+		})
+	}
+	return result
+}
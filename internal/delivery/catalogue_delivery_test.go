@@ -0,0 +1,129 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/catalogue"
+	deliveryCatalogue "OnlineShopBackend/internal/delivery/catalogue"
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testImportReport = &models.ImportReport{
+	Total:   2,
+	Created: 1,
+	Failed:  1,
+	Errors:  []models.ImportRowError{{Line: 3, Sku: "SKU-2", Error: "price must be positive"}},
+}
+
+func TestImportCatalogue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	catalogueUsecase := mocks.NewMockICatalogueUsecase(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	delivery := NewDelivery(Usecases{Catalogue: catalogueUsecase}, logger, filestorage, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/items/import?dryRun=true", strings.NewReader("data"))
+	c.Request.Header.Set("Content-Type", "application/x-ndjson")
+	catalogueUsecase.EXPECT().Import(gomock.Any(), gomock.Any(), catalogue.FormatJSONL, true).Return(testImportReport, nil)
+	delivery.ImportCatalogue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	report := deliveryCatalogue.ImportReport{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Equal(t, 1, report.Created)
+	require.Equal(t, []deliveryCatalogue.RowError{{Line: 3, Sku: "SKU-2", Error: "price must be positive"}}, report.Errors)
+
+	// File of multipart form, format is known by extension
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "catalogue.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("sku\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/items/import", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	catalogueUsecase.EXPECT().Import(gomock.Any(), gomock.Any(), catalogue.FormatCSV, false).
+		DoAndReturn(func(_ interface{}, r io.Reader, _ string, _ bool) (*models.ImportReport, error) {
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, "sku\n", string(data))
+			return &models.ImportReport{}, nil
+		})
+	delivery.ImportCatalogue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/items/import?format=xml", strings.NewReader("data"))
+	catalogueUsecase.EXPECT().Import(gomock.Any(), gomock.Any(), "xml", false).Return(nil, catalogue.ErrUnsupportedFormat)
+	delivery.ImportCatalogue(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/items/import?dryRun=maybe", strings.NewReader("data"))
+	delivery.ImportCatalogue(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/items/import", strings.NewReader("data"))
+	catalogueUsecase.EXPECT().Import(gomock.Any(), gomock.Any(), catalogue.FormatCSV, false).Return(nil, fmt.Errorf("error"))
+	delivery.ImportCatalogue(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestExportCatalogue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	catalogueUsecase := mocks.NewMockICatalogueUsecase(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	delivery := NewDelivery(Usecases{Catalogue: catalogueUsecase}, logger, filestorage, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/items/export?format=jsonl", nil)
+	catalogueUsecase.EXPECT().Export(gomock.Any(), gomock.Any(), catalogue.FormatJSONL).
+		DoAndReturn(func(_ interface{}, w io.Writer, _ string) error {
+			_, err := w.Write([]byte("{}\n"))
+			return err
+		})
+	delivery.ExportCatalogue(c)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	require.Equal(t, "attachment; filename=catalogue.jsonl", w.Header().Get("Content-Disposition"))
+	require.Equal(t, "{}\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/items/export?format=xml", nil)
+	delivery.ExportCatalogue(c)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/items/export", nil)
+	catalogueUsecase.EXPECT().Export(gomock.Any(), gomock.Any(), catalogue.FormatCSV).Return(fmt.Errorf("error"))
+	delivery.ExportCatalogue(c)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
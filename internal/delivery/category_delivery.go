@@ -14,12 +14,10 @@ import (
 	"OnlineShopBackend/internal/models"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-module/carbon/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -149,6 +147,7 @@ func (delivery *Delivery) UpdateCategory(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		413	{object}	ErrorResponse
 //	@Failure		415	{object}	ErrorResponse
 //	@Failure		500	{object}	ErrorResponse
 //	@Failure		507	{object}	ErrorResponse
@@ -169,54 +168,12 @@ func (delivery *Delivery) UploadCategoryImage(c *gin.Context) {
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-	var name string
-	contentType := c.ContentType()
-
-	if contentType == "image/jpeg" {
-		name = carbon.Now().ToShortDateTimeString() + ".jpeg"
-	} else if contentType == "image/png" {
-		name = carbon.Now().ToShortDateTimeString() + ".png"
-	} else {
-		err := fmt.Errorf("unsupported media type: %s", contentType)
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusUnsupportedMediaType, err)
-		return
-	}
-
-	file, err := io.ReadAll(c.Request.Body)
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusUnsupportedMediaType, err)
-		return
-	}
-
 	delivery.logger.Info("Read id", zap.String("id", id))
-	delivery.logger.Info("File len=", zap.Int32("len", int32(len(file))))
-	path, err := delivery.filestorage.PutCategoryImage(id, name, file)
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInsufficientStorage, err)
-		return
-	}
 	ctx := c.Request.Context()
-	category, err := delivery.categoryUsecase.GetCategory(ctx, uid)
-	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
-		err = fmt.Errorf("category with id: %s not found", uid)
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusNotFound, err)
-		return
-	}
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
-		return
-	}
-	category.Image = path
-
-	err = delivery.categoryUsecase.UpdateCategory(ctx, category)
+	_, status, err := delivery.attachCategoryImage(ctx, uid, c.Request.Body)
 	if err != nil {
 		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
+		delivery.SetError(c, status, err)
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{})
@@ -254,7 +211,10 @@ func (delivery *Delivery) DeleteCategoryImage(c *gin.Context) {
 	}
 	delivery.logger.Debug(fmt.Sprintf("image options is %v", imageOptions))
 
-	err = delivery.filestorage.DeleteCategoryImage(imageOptions.Id, imageOptions.Name)
+	// Delete files of all variants of the picture
+	err = deleteImage(imageOptions.Name, func(filename string) error {
+		return delivery.filestorage.DeleteCategoryImage(imageOptions.Id, filename)
+	})
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -475,12 +435,6 @@ func (delivery *Delivery) DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	// Deleting the cache of the list of products from this category
-	err = delivery.categoryUsecase.DeleteCategoryCash(ctx, deletedCategory.Name)
-	if err != nil {
-		delivery.logger.Error(fmt.Sprintf("error on delete category cash: %v", err))
-	}
-
 	// If the category has a picture, delete this picture
 	if deletedCategory.Image != "" {
 		err = delivery.filestorage.DeleteCategoryImageById(id)
@@ -521,11 +475,6 @@ func (delivery *Delivery) DeleteCategory(c *gin.Context) {
 			if err != nil {
 				delivery.logger.Error(fmt.Sprintf("error on update item: %v", err))
 			}
-			// Updating the cache of the list of items in the category
-			err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, &item, "create")
-			if err != nil {
-				delivery.logger.Error(fmt.Sprintf("error on update cash of no category: %v", err))
-			}
 		}
 		delivery.logger.Sugar().Infof("Category with id: %s deleted success", id)
 		c.JSON(http.StatusOK, gin.H{})
@@ -543,10 +492,6 @@ func (delivery *Delivery) DeleteCategory(c *gin.Context) {
 		if err != nil {
 			delivery.logger.Error(fmt.Sprintf("error on update item: %v", err))
 		}
-		err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, &item, "create")
-		if err != nil {
-			delivery.logger.Error(fmt.Sprintf("error on update cash of no category: %v", err))
-		}
 	}
 	delivery.logger.Sugar().Infof("Category with id: %s deleted success", id)
 	c.JSON(http.StatusOK, gin.H{})
@@ -3,6 +3,7 @@ package delivery
 import (
 	"OnlineShopBackend/internal/delivery/category"
 	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/imaging"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/usecase/mocks"
 	"bytes"
@@ -151,7 +152,7 @@ func TestCreateCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -226,7 +227,7 @@ func TestUpdateCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -348,7 +349,7 @@ func TestGetCategoryList(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -385,7 +386,7 @@ func TestGetCategoryList2(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -421,7 +422,7 @@ func TestGetCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -509,7 +510,7 @@ func TestUploadCategoryImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, imaging.NewProcessor(testImageMaxSize, logger))
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -532,7 +533,8 @@ func TestUploadCategoryImage(t *testing.T) {
 			Value: testId.String(),
 		},
 	}
-
+	MockCatFile(c, "jpeg", []byte("not an image"))
+	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testModelsCategoryWithId, nil)
 	delivery.UploadCategoryImage(c)
 	require.Equal(t, 415, w.Code)
 
@@ -565,7 +567,13 @@ func TestUploadCategoryImage(t *testing.T) {
 		},
 	}
 	MockCatFile(c, "jpeg", testFile)
-	filestorage.EXPECT().PutCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+".jpeg", testFile).Return("", fmt.Errorf("error"))
+	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testModelsCategoryWithId, nil)
+	gomock.InOrder(
+		filestorage.EXPECT().PutCategoryImage(testId.String(), gomock.Any(), gomock.Any()).Return("testImagePath", nil).Times(2),
+		filestorage.EXPECT().PutCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+"_large.jpeg", gomock.Any()).Return("", fmt.Errorf("error")),
+		filestorage.EXPECT().DeleteCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+"_thumb.jpeg").Return(nil),
+		filestorage.EXPECT().DeleteCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+"_medium.jpeg").Return(nil),
+	)
 	delivery.UploadCategoryImage(c)
 	require.Equal(t, 507, w.Code)
 
@@ -582,7 +590,6 @@ func TestUploadCategoryImage(t *testing.T) {
 		},
 	}
 	MockCatFile(c, "png", testFile)
-	filestorage.EXPECT().PutCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+".png", testFile).Return("testImagePath", nil)
 	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(&testEmptyModelsCategory, fmt.Errorf("error"))
 	delivery.UploadCategoryImage(c)
 	require.Equal(t, 500, w.Code)
@@ -600,7 +607,6 @@ func TestUploadCategoryImage(t *testing.T) {
 		},
 	}
 	MockCatFile(c, "png", testFile)
-	filestorage.EXPECT().PutCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+".png", testFile).Return("testImagePath", nil)
 	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(&testEmptyModelsCategory, models.ErrorNotFound{})
 	delivery.UploadCategoryImage(c)
 	require.Equal(t, 404, w.Code)
@@ -618,8 +624,8 @@ func TestUploadCategoryImage(t *testing.T) {
 		},
 	}
 	MockCatFile(c, "png", testFile)
-	filestorage.EXPECT().PutCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+".png", testFile).Return("testImagePath", nil)
 	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testModelsCategoryWithId, nil)
+	filestorage.EXPECT().PutCategoryImage(testId.String(), gomock.Any(), gomock.Any()).Return("testImagePath", nil).Times(len(imaging.VariantNames("name_large.jpeg")))
 	categoryUsecase.EXPECT().UpdateCategory(ctx, &testCategoryWithImage).Return(fmt.Errorf("error"))
 	delivery.UploadCategoryImage(c)
 	require.Equal(t, 500, w.Code)
@@ -637,8 +643,8 @@ func TestUploadCategoryImage(t *testing.T) {
 		},
 	}
 	MockCatFile(c, "png", testFile)
-	filestorage.EXPECT().PutCategoryImage(testId.String(), carbon.Now().ToShortDateTimeString()+".png", testFile).Return("testImagePath", nil)
 	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testModelsCategoryWithId, nil)
+	filestorage.EXPECT().PutCategoryImage(testId.String(), gomock.Any(), gomock.Any()).Return("testImagePath", nil).Times(len(imaging.VariantNames("name_large.jpeg")))
 	categoryUsecase.EXPECT().UpdateCategory(ctx, &testCategoryWithImage).Return(nil)
 	delivery.UploadCategoryImage(c)
 	require.Equal(t, 201, w.Code)
@@ -655,7 +661,7 @@ func TestDeleteCategoryImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -771,7 +777,7 @@ func TestDeleteCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -880,7 +886,6 @@ func TestDeleteCategory(t *testing.T) {
 	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(testCategoryWithImage2, nil)
 	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(0, nil)
 	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	categoryUsecase.EXPECT().DeleteCategoryCash(ctx, testCategoryWithImage2.Name).Return(fmt.Errorf("error"))
 	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
 	delivery.DeleteCategory(c)
 	require.Equal(t, 200, w.Code)
@@ -904,11 +909,9 @@ func TestDeleteCategory(t *testing.T) {
 	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(1, nil)
 	itemUsecase.EXPECT().GetItemsByCategory(ctx, testCategoryWithImage2.Name, limitOptions, sortOptions).Return([]models.Item{*testModelsItemWithId}, nil)
 	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	categoryUsecase.EXPECT().DeleteCategoryCash(ctx, testCategoryWithImage2.Name).Return(nil)
 	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
 	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(&testNoCategoryWithId, nil)
 	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemNoCat).Return(fmt.Errorf("error"))
-	itemUsecase.EXPECT().UpdateItemsInCategoryCash(ctx, &testModelsItemNoCat, "create").Return(fmt.Errorf("error"))
 	delivery.DeleteCategory(c)
 	require.Equal(t, 200, w.Code)
 
@@ -946,12 +949,10 @@ func TestDeleteCategory(t *testing.T) {
 	itemUsecase.EXPECT().ItemsQuantityInCategory(ctx, testCategoryWithImage2.Name).Return(1, nil)
 	itemUsecase.EXPECT().GetItemsByCategory(ctx, testCategoryWithImage2.Name, limitOptions, sortOptions).Return([]models.Item{*testModelsItemWithId}, nil)
 	categoryUsecase.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	categoryUsecase.EXPECT().DeleteCategoryCash(ctx, testCategoryWithImage2.Name).Return(fmt.Errorf("error"))
 	filestorage.EXPECT().DeleteCategoryImageById(testId.String()).Return(nil)
 	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(&models.Category{}, models.ErrorNotFound{})
 	categoryUsecase.EXPECT().CreateCategory(ctx, &testNoCategory).Return(testId, nil)
 	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemNoCat).Return(fmt.Errorf("error"))
-	itemUsecase.EXPECT().UpdateItemsInCategoryCash(ctx, &testModelsItemNoCat, "create").Return(fmt.Errorf("error"))
 	delivery.DeleteCategory(c)
 	require.Equal(t, 200, w.Code)
 }
@@ -967,7 +968,7 @@ func TestDeleteCategory2(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -0,0 +1,22 @@
+package currency
+
+import "time"
+
+// ShortRate is a structure for setting exchange rate of the currency
+type ShortRate struct {
+	Rate float64 `json:"rate" binding:"required,gt=0" example:"0.0108"`
+}
+
+// Rate is a structure for displaying exchange rate, the rate is a number
+// of units of the currency for one unit of the base currency
+type Rate struct {
+	Currency  string    `json:"currency" example:"USD"`
+	Rate      float64   `json:"rate" example:"0.0108"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// RatesList is a structure for list of exchange rates
+type RatesList struct {
+	Base  string `json:"base" example:"RUB"`
+	Rates []Rate `json:"rates"`
+}
@@ -0,0 +1,199 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/currency"
+	"OnlineShopBackend/internal/delivery/item"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RatesList - returns exchange rates
+//
+//	@Summary		Get list of exchange rates
+//	@Description	Method provides to get exchange rates used for display prices. Rate is a number of units of the currency for one unit of the base currency.
+//	@Tags			currencies
+//	@Produce		json
+//	@Success		200	{object}	currency.RatesList
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/currencies/rates [get]
+func (delivery *Delivery) RatesList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RatesList()")
+	rates, err := delivery.currencyUsecase.GetRatesList(c.Request.Context())
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := currency.RatesList{
+		Base:  delivery.currencyUsecase.BaseCurrency(),
+		Rates: make([]currency.Rate, 0, len(rates)),
+	}
+	for _, rate := range rates {
+		list.Rates = append(list.Rates, currency.Rate{
+			Currency:  rate.Currency,
+			Rate:      rate.Rate,
+			UpdatedAt: rate.UpdatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// SetRate - set exchange rate of the currency
+//
+//	@Summary		Set exchange rate
+//	@Description	Method provides to create or change exchange rate of the currency relative to the base currency.
+//	@Tags			currencies
+//	@Accept			json
+//	@Produce		json
+//	@Param			currency	path	string				true	"ISO 4217 code of currency"
+//	@Param			rate		body	currency.ShortRate	true	"Exchange rate"
+//	@Success		200			{object}	currency.Rate
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/currencies/rates/{currency} [put]
+func (delivery *Delivery) SetRate(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetRate()")
+	var shortRate currency.ShortRate
+	if err := c.ShouldBindJSON(&shortRate); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	rate := models.ExchangeRate{
+		Currency: c.Param("currency"),
+		Rate:     shortRate.Rate,
+	}
+	err := delivery.currencyUsecase.SetRate(c.Request.Context(), &rate)
+	switch {
+	case errors.Is(err, models.ErrUnknownCurrency), errors.Is(err, models.ErrInvalidExchangeRate):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, currency.Rate{
+		Currency:  rate.Currency,
+		Rate:      rate.Rate,
+		UpdatedAt: rate.UpdatedAt,
+	})
+}
+
+// DeleteRate - delete exchange rate of the currency
+//
+//	@Summary		Delete exchange rate
+//	@Description	Method provides to delete exchange rate, prices in the currency can't be displayed and added to totals without it.
+//	@Tags			currencies
+//	@Produce		json
+//	@Param			currency	path	string	true	"ISO 4217 code of currency"
+//	@Success		200
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/currencies/rates/{currency} [delete]
+func (delivery *Delivery) DeleteRate(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteRate()")
+	code := c.Param("currency")
+	err := delivery.currencyUsecase.DeleteRate(c.Request.Context(), code)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("exchange rate of currency %s not found", code)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// displayPrices converts prices to the currency requested by the client
+type displayPrices struct {
+	rates    *models.ExchangeRates
+	currency string
+}
+
+// displayCurrency returns converter of prices to the currency of the query
+// parameter currency or nil if the parameter is empty. If the currency can't
+// be used, the error is written to the response and false is returned
+func (delivery *Delivery) displayCurrency(c *gin.Context) (*displayPrices, bool) {
+	code := models.NormalizeCurrency(c.Query("currency"))
+	if code == "" {
+		return nil, true
+	}
+	err := models.ValidCurrency(code)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return nil, false
+	}
+	rates, err := delivery.currencyUsecase.GetRates(c.Request.Context())
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	if !rates.Has(code) {
+		err = fmt.Errorf("%w: %s", models.ErrNoExchangeRate, code)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return nil, false
+	}
+	return &displayPrices{rates: rates, currency: code}, true
+}
+
+// price returns the price converted to the display currency, nil means
+// display currency is not requested or the price can't be converted
+func (display *displayPrices) price(money models.Money) *item.Price {
+	if display == nil {
+		return nil
+	}
+	converted, err := display.rates.Convert(money, display.currency)
+	if err != nil {
+		return nil
+	}
+	return &item.Price{
+		Amount:    converted.Amount,
+		Currency:  converted.Currency,
+		Formatted: converted.Format(),
+	}
+}
+
+// moneyToPrice converts the amount with its currency
+func moneyToPrice(money models.Money) *item.Price {
+	return &item.Price{
+		Amount:    money.Amount,
+		Currency:  money.Currency,
+		Formatted: money.Format(),
+	}
+}
+
+// totalsToDelivery converts amounts of the cart or order
+func totalsToDelivery(pricing models.Pricing, display *displayPrices) cart.Totals {
+	total := models.Money{Amount: pricing.Total, Currency: pricing.Currency}
+	totals := cart.Totals{
+		Currency:          pricing.Currency,
+		Subtotal:          pricing.Subtotal,
+		FormattedSubtotal: models.Money{Amount: pricing.Subtotal, Currency: pricing.Currency}.Format(),
+		Discounts:         discountsToDelivery(pricing.Discounts, pricing.Currency),
+		Taxes:             taxesToDelivery(pricing.Taxes, pricing.Currency),
+		Shipping:          pricing.Shipping,
+		Total:             pricing.Total,
+		FormattedTotal:    total.Format(),
+		DisplayTotal:      display.price(total),
+	}
+	if pricing.Shipping > 0 {
+		totals.FormattedShipping = models.Money{Amount: pricing.Shipping, Currency: pricing.Currency}.Format()
+	}
+	return totals
+}
@@ -3,8 +3,12 @@ package delivery
 import (
 	"OnlineShopBackend/internal/delivery/file"
 	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/imaging"
 	"OnlineShopBackend/internal/metrics"
 	"OnlineShopBackend/internal/usecase"
+	"errors"
+	"fmt"
+	"io/fs"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -28,27 +32,75 @@ type Delivery struct {
 	logger          *zap.Logger
 	filestorage     filestorage.FileStorager
 	orderUsecase    usecase.IOrderUsecase
+	catalogueUsecase usecase.ICatalogueUsecase
+	images          imaging.ImageProcessor
+	uploadUsecase   usecase.IUploadUsecase
+	storageUsecase  usecase.IStorageUsecase
+	reviewUsecase   usecase.IReviewUsecase
+	promotionUsecase usecase.IPromotionUsecase
+	currencyUsecase usecase.ICurrencyUsecase
+	taxUsecase      usecase.ITaxUsecase
+	shippingUsecase usecase.IShippingUsecase
+	paymentUsecase  usecase.IPaymentUsecase
+	wishlistUsecase usecase.IWishlistUsecase
+	cartReminderUsecase usecase.ICartReminderUsecase
+	returnUsecase   usecase.IReturnUsecase
+	shipmentUsecase usecase.IShipmentUsecase
+	invoiceUsecase  usecase.IInvoiceUsecase
+}
+
+// Usecases are usecases of the delivery layer, usecases not needed
+// by handlers can be left nil
+type Usecases struct {
+	Item         usecase.IItemUsecase
+	User         usecase.IUserUsecase
+	Category     usecase.ICategoryUsecase
+	Cart         usecase.ICartUsecase
+	Order        usecase.IOrderUsecase
+	Catalogue    usecase.ICatalogueUsecase
+	Upload       usecase.IUploadUsecase
+	Storage      usecase.IStorageUsecase
+	Review       usecase.IReviewUsecase
+	Promotion    usecase.IPromotionUsecase
+	Currency     usecase.ICurrencyUsecase
+	Tax          usecase.ITaxUsecase
+	Shipping     usecase.IShippingUsecase
+	Payment      usecase.IPaymentUsecase
+	Wishlist     usecase.IWishlistUsecase
+	CartReminder usecase.ICartReminderUsecase
+	Return       usecase.IReturnUsecase
+	Shipment     usecase.IShipmentUsecase
+	Invoice      usecase.IInvoiceUsecase
 }
 
 // NewDelivery initialize delivery layer
-func NewDelivery(
-	itemUsecase usecase.IItemUsecase,
-	userUsecase usecase.IUserUsecase,
-	categoryUsecase usecase.ICategoryUsecase,
-	cartUsecase usecase.ICartUsecase,
-	logger *zap.Logger, fs filestorage.FileStorager,
-	orderUsecase usecase.IOrderUsecase,
-) *Delivery {
+func NewDelivery(usecases Usecases, logger *zap.Logger, fs filestorage.FileStorager, images imaging.ImageProcessor) *Delivery {
 	logger.Debug("Enter in NewDelivery()")
 	metrics.DeliveryMetrics.NewDeliveryTotal.Inc()
 
 	return &Delivery{
-		itemUsecase:     itemUsecase,
-		categoryUsecase: categoryUsecase,
-		cartUsecase:     cartUsecase,
-		userUsecase: userUsecase,
-		logger:          logger, filestorage: fs,
-		orderUsecase: orderUsecase,
+		itemUsecase:         usecases.Item,
+		categoryUsecase:     usecases.Category,
+		cartUsecase:         usecases.Cart,
+		userUsecase:         usecases.User,
+		logger:              logger,
+		filestorage:         fs,
+		orderUsecase:        usecases.Order,
+		catalogueUsecase:    usecases.Catalogue,
+		images:              images,
+		uploadUsecase:       usecases.Upload,
+		storageUsecase:      usecases.Storage,
+		reviewUsecase:       usecases.Review,
+		promotionUsecase:    usecases.Promotion,
+		currencyUsecase:     usecases.Currency,
+		taxUsecase:          usecases.Tax,
+		shippingUsecase:     usecases.Shipping,
+		paymentUsecase:      usecases.Payment,
+		wishlistUsecase:     usecases.Wishlist,
+		cartReminderUsecase: usecases.CartReminder,
+		returnUsecase:       usecases.Return,
+		shipmentUsecase:     usecases.Shipment,
+		invoiceUsecase:      usecases.Invoice,
 	}
 }
 
@@ -92,3 +144,31 @@ func (delivery *Delivery) GetFileList(c *gin.Context) {
 
 	c.JSON(http.StatusOK, files)
 }
+
+// GetFile returns file from the file storage
+//
+//	@Summary		Get file
+//	@Description	Method provides to get file from the file storage. Files in the object storage are given by redirect to temporary url.
+//	@Tags			files
+//	@Produce		octet-stream
+//	@Param			filepath	path	string	true	"path of file"
+//	@Success		200
+//	@Success		302	"Redirect to the file"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/files/{filepath} [get]
+func (delivery *Delivery) GetFile(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetFile()")
+	name := c.Param("filepath")
+	err := delivery.filestorage.ServeFile(c.Writer, c.Request, name)
+	if err != nil && errors.Is(err, fs.ErrNotExist) {
+		delivery.logger.Sugar().Debugf("file %s not found", name)
+		delivery.SetError(c, http.StatusNotFound, fmt.Errorf("file %s not found", name))
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+}
@@ -5,6 +5,7 @@ import (
 	fs "OnlineShopBackend/internal/filestorage/mocks"
 	"OnlineShopBackend/internal/usecase/mocks"
 	"fmt"
+	iofs "io/fs"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -25,7 +26,7 @@ func TestIndex(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -47,7 +48,7 @@ func TestGetFileList(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -74,3 +75,40 @@ func TestGetFileList(t *testing.T) {
 	delivery.GetFileList(c)
 	require.Equal(t, 200, w.Code)
 }
+
+func TestGetFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	filestorage := fs.NewMockFileStorager(ctrl)
+	delivery := NewDelivery(Usecases{}, logger, filestorage, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/files/items/1/test.jpeg", nil)
+	c.Params = []gin.Param{{Key: "filepath", Value: "/items/1/test.jpeg"}}
+	filestorage.EXPECT().ServeFile(gomock.Any(), gomock.Any(), "/items/1/test.jpeg").Return(fmt.Errorf("error: %w", iofs.ErrNotExist))
+	delivery.GetFile(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/files/items/1/test.jpeg", nil)
+	c.Params = []gin.Param{{Key: "filepath", Value: "/items/1/test.jpeg"}}
+	filestorage.EXPECT().ServeFile(gomock.Any(), gomock.Any(), "/items/1/test.jpeg").Return(fmt.Errorf("error"))
+	delivery.GetFile(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/files/items/1/test.jpeg", nil)
+	c.Params = []gin.Param{{Key: "filepath", Value: "/items/1/test.jpeg"}}
+	filestorage.EXPECT().ServeFile(gomock.Any(), gomock.Any(), "/items/1/test.jpeg").
+		DoAndReturn(func(w http.ResponseWriter, r *http.Request, name string) error {
+			http.Redirect(w, r, "http://localhost:9000/shop/items/1/test.jpeg", http.StatusFound)
+			return nil
+		})
+	delivery.GetFile(c)
+	require.Equal(t, 302, w.Code)
+	require.Equal(t, "http://localhost:9000/shop/items/1/test.jpeg", w.Header().Get("Location"))
+}
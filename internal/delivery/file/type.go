@@ -1,5 +1,7 @@
 package file
 
+import "time"
+
 type FilesInfo struct {
 	Name       string `json:"name" example:"20221213125935.jpeg"`
 	Path       string `json:"path" example:"storage\\files\\categories\\d0d3df2d-f6c8-4956-9d76-998ee1ec8a39\\20221213125935.jpeg"`
@@ -10,3 +12,23 @@ type FilesInfo struct {
 type FileListResponse struct {
 	Files []FilesInfo `json:"files"`
 }
+
+// ImageRef is a structure for displaying the reference to the image from the item or category
+type ImageRef struct {
+	Kind    string `json:"kind" example:"item"`
+	OwnerId string `json:"ownerId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	URL     string `json:"url" example:"http://localhost:8000/files/items/00000000-0000-0000-0000-000000000000/20230101120000_large.jpeg"`
+}
+
+// StorageReport is a structure for displaying the result of consistency check of the file storage
+type StorageReport struct {
+	DryRun       bool       `json:"dryRun" example:"true"`
+	CheckedAt    time.Time  `json:"checkedAt" example:"2023-01-01T12:00:00Z"`
+	Files        int        `json:"files" example:"120"`
+	Refs         int        `json:"refs" example:"30"`
+	OrphanFiles  []string   `json:"orphanFiles" example:"items/00000000-0000-0000-0000-000000000000/20230101120000_large.jpeg"`
+	DanglingRefs []ImageRef `json:"danglingRefs"`
+	DeletedFiles int        `json:"deletedFiles" example:"0"`
+	RemovedRefs  int        `json:"removedRefs" example:"0"`
+	Errors       []string   `json:"errors"`
+}
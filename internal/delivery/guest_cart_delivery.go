@@ -0,0 +1,253 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// cartTokenHeader is a header with the token of the guest cart
+	cartTokenHeader = "X-Cart-Token"
+	// cartTokenCookie is a cookie with the token of the guest cart,
+	// it is used by the login through redirects of social networks
+	cartTokenCookie = "cart_token"
+)
+
+var errNoCartToken = errors.New("token of guest cart is empty")
+
+// CreateGuestCart - create a cart for the anonymous visitor
+//
+//	@Summary		Method provides to create guest cart
+//	@Description	Method provides to create cart without login. The token of the cart is returned
+//	@Description	and set as cookie, it must be passed in the X-Cart-Token header to the guest cart methods.
+//	@Description	When the visitor logs in, the guest cart is merged into the cart of the user.
+//	@Tags			carts
+//	@Produce		json
+//	@Success		201	{object}	cart.GuestCart
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/guest [post]
+func (delivery *Delivery) CreateGuestCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateGuestCart()")
+	cartId, err := delivery.cartUsecase.Create(c.Request.Context(), uuid.Nil)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	token, err := jwtauth.NewCartToken(cartId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.SetCookie(cartTokenCookie, token, int(jwtauth.CartTokenTTL.Seconds()), "/", "", false, true)
+	c.JSON(http.StatusCreated, cart.GuestCart{CartId: cartId.String(), Token: token})
+}
+
+// GetGuestCart - get the cart of the anonymous visitor
+//
+//	@Summary		Get guest cart
+//	@Description	The method allows you to get the guest cart by its token.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			X-Cart-Token	header		string		true	"Token of guest cart"
+//	@Param			currency		query		string		false	"Currency of display prices, e.g. USD"
+//	@Param			country			query		string		false	"Country of shipping for preview of taxes"
+//	@Param			region			query		string		false	"Region (city) of shipping for preview of taxes"
+//	@Success		200				{object}	cart.Cart	"Cart structure"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse	"Invalid token of cart"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/cart/guest [get]
+func (delivery *Delivery) GetGuestCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetGuestCart()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
+	cartId, ok := delivery.guestCart(c)
+	if !ok {
+		return
+	}
+	modelCart, err := delivery.cartUsecase.GetCart(c.Request.Context(), cartId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err := fmt.Errorf("cart with id: %v not found", cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !delivery.applyCartTaxes(c, modelCart) {
+		return
+	}
+
+	c.JSON(http.StatusOK, cartToDelivery(modelCart, display))
+}
+
+// AddItemToGuestCart - add item to the cart of the anonymous visitor
+//
+//	@Summary		Method provides to add item to guest cart
+//	@Description	Method provides to add item to guest cart.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			X-Cart-Token	header	string	true	"Token of guest cart"
+//	@Param			itemID			path	string	true	"id of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse	"Invalid token of cart"
+//	@Failure		422	{object}	ErrorResponse	"Maximal quantity of the item is already in the cart"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/guest/addItem/{itemID} [put]
+func (delivery *Delivery) AddItemToGuestCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery AddItemToGuestCart()")
+	cartId, ok := delivery.guestCart(c)
+	if !ok {
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.cartUsecase.AddItemToCart(c.Request.Context(), cartId, itemId)
+	if err != nil && errors.Is(err, models.ErrQuantityLimit) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteItemFromGuestCart - delete item from the cart of the anonymous visitor
+//
+//	@Summary		Method provides to delete item from guest cart
+//	@Description	Method provides to delete item from guest cart.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			X-Cart-Token	header	string	true	"Token of guest cart"
+//	@Param			itemID			path	string	true	"id of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse	"Invalid token of cart"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/cart/guest/delete/{itemID} [delete]
+func (delivery *Delivery) DeleteItemFromGuestCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteItemFromGuestCart()")
+	cartId, ok := delivery.guestCart(c)
+	if !ok {
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.cartUsecase.DeleteItemFromCart(c.Request.Context(), cartId, itemId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ChangeGuestItemQuantity - set or decrease quantity of the item in the guest cart
+//
+//	@Summary		Method provides to change quantity of item in guest cart
+//	@Description	Method provides to set exact quantity of the item in the guest cart or to decrease it,
+//	@Description	limits of quantity are the same as for the cart of the user.
+//	@Tags			carts
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Cart-Token	header		string				true	"Token of guest cart"
+//	@Param			quantity		body		cart.ItemQuantity	true	"Item id and quantity or decrement"
+//	@Success		200				{object}	cart.Quantity		"New quantity of the item in the cart"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse	"Invalid token of cart"
+//	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422				{object}	ErrorResponse	"Quantity is out of limits of the item"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/cart/guest/quantity [put]
+func (delivery *Delivery) ChangeGuestItemQuantity(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ChangeGuestItemQuantity()")
+	cartId, ok := delivery.guestCart(c)
+	if !ok {
+		return
+	}
+	var itemQuantity cart.ItemQuantity
+	if err := c.ShouldBindJSON(&itemQuantity); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	delivery.changeItemQuantity(c, cartId, itemQuantity)
+}
+
+// guestCart returns id of the guest cart of the request
+// or writes error response if the token is invalid
+func (delivery *Delivery) guestCart(c *gin.Context) (uuid.UUID, bool) {
+	cartId, err := guestCartId(c)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnauthorized, err)
+		return uuid.Nil, false
+	}
+	return cartId, true
+}
+
+// guestCartId returns id of the guest cart from the token in the header or cookie
+func guestCartId(c *gin.Context) (uuid.UUID, error) {
+	token := c.GetHeader(cartTokenHeader)
+	if token == "" {
+		token, _ = c.Cookie(cartTokenCookie)
+	}
+	if token == "" {
+		return uuid.Nil, errNoCartToken
+	}
+	return jwtauth.ParseCartToken(token)
+}
+
+// mergeGuestCart merges the guest cart of the request into the cart of the user
+// and returns id of the cart of the user. Errors are only logged so that the
+// login doesn't fail because of the guest cart, then cartId is returned
+func (delivery *Delivery) mergeGuestCart(c *gin.Context, userId uuid.UUID, cartId uuid.UUID) uuid.UUID {
+	guestId, err := guestCartId(c)
+	if errors.Is(err, errNoCartToken) {
+		return cartId
+	}
+	if err != nil {
+		delivery.logger.Sugar().Warnf("guest cart isn't merged: %v", err)
+		return cartId
+	}
+	mergedId, err := delivery.cartUsecase.MergeGuestCart(c.Request.Context(), guestId, userId)
+	if err != nil {
+		delivery.logger.Sugar().Warnf("guest cart %v isn't merged: %v", guestId, err)
+		// The cart of the token is already merged or belongs to a user
+		if errors.Is(err, models.ErrorNotFound{}) || errors.Is(err, models.ErrNotGuestCart) {
+			c.SetCookie(cartTokenCookie, "", -1, "/", "", false, true)
+		}
+		return cartId
+	}
+	c.SetCookie(cartTokenCookie, "", -1, "/", "", false, true)
+	return mergedId
+}
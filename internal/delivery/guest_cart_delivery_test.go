@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase}, logger, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	cartUsecase.EXPECT().Create(ctx, uuid.Nil).Return(uuid.Nil, err)
+	delivery.CreateGuestCart(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	cartUsecase.EXPECT().Create(ctx, uuid.Nil).Return(testCartId, nil)
+	delivery.CreateGuestCart(c)
+	require.Equal(t, 201, w.Code)
+	var guestCart cart.GuestCart
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &guestCart))
+	require.Equal(t, testCartId.String(), guestCart.CartId)
+	cartId, err := jwtauth.ParseCartToken(guestCart.Token)
+	require.NoError(t, err)
+	require.Equal(t, testCartId, cartId)
+	// Token of the cart isn't signed with the key of sessions
+	key, err := jwtauth.NewJWTKeyConfig()
+	require.NoError(t, err)
+	_, err = jwt.ParseWithClaims(guestCart.Token, &jwtauth.Payload{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(key.Key), nil
+	})
+	require.Error(t, err)
+	require.Contains(t, w.Header().Get("Set-Cookie"), cartTokenCookie)
+}
+
+func TestAddItemToGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase}, logger, nil, nil)
+	token, err := jwtauth.NewCartToken(testCartId)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	delivery.AddItemToGuestCart(c)
+	require.Equal(t, 401, w.Code)
+
+	// Session token of the user isn't a token of the cart
+	sessionToken, err := jwtauth.NewJWT(jwtauth.Payload{UserId: testUserId})
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	c.Request.Header.Set(cartTokenHeader, sessionToken)
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	delivery.AddItemToGuestCart(c)
+	require.Equal(t, 401, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	c.Request.Header.Set(cartTokenHeader, token)
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(nil)
+	delivery.AddItemToGuestCart(c)
+	require.Equal(t, 200, w.Code)
+
+	// Token from the cookie
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	c.Request.AddCookie(&http.Cookie{Name: cartTokenCookie, Value: token})
+	c.Params = []gin.Param{{Key: "itemID", Value: testId.String()}}
+	cartUsecase.EXPECT().AddItemToCart(ctx, testCartId, testId).Return(nil)
+	delivery.AddItemToGuestCart(c)
+	require.Equal(t, 200, w.Code)
+}
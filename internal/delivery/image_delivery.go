@@ -0,0 +1,196 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/item"
+	"OnlineShopBackend/internal/imaging"
+	"OnlineShopBackend/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/golang-module/carbon/v2"
+	"github.com/google/uuid"
+)
+
+// putImageFunc puts a file of image in the file storage and returns its url
+type putImageFunc func(filename string, file []byte) (string, error)
+
+// deleteImageFunc deletes a file of image from the file storage
+type deleteImageFunc func(filename string) error
+
+// attachItemImage processes the image, puts it in the file storage and adds
+// it to the item. It returns url of the image or the http status and error
+func (delivery *Delivery) attachItemImage(ctx context.Context, id uuid.UUID, r io.Reader) (string, int, error) {
+	delivery.logger.Sugar().Debugf("Enter in delivery attachItemImage() with args: ctx, id: %v, r", id)
+	// Request item for which the picture is installed
+	item, err := delivery.itemUsecase.GetItem(ctx, id)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		return "", http.StatusNotFound, fmt.Errorf("item with id: %v not found", id)
+	}
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+
+	// Put variants of the picture in the file storage and get url of the large one
+	path, status, err := delivery.storeImage(r,
+		func(filename string, file []byte) (string, error) {
+			return delivery.filestorage.PutItemImage(id.String(), filename, file)
+		},
+		func(filename string) error {
+			return delivery.filestorage.DeleteItemImage(id.String(), filename)
+		})
+	if err != nil {
+		return "", status, err
+	}
+
+	// Add the picture to the end of the item pictures list
+	item.Images = append(item.Images, models.ItemImage{URL: path})
+
+	err = delivery.itemUsecase.UpdateItem(ctx, item)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	return path, 0, nil
+}
+
+// attachCategoryImage processes the image, puts it in the file storage and sets
+// it as image of the category. It returns url of the image or the http status and error
+func (delivery *Delivery) attachCategoryImage(ctx context.Context, id uuid.UUID, r io.Reader) (string, int, error) {
+	delivery.logger.Sugar().Debugf("Enter in delivery attachCategoryImage() with args: ctx, id: %v, r", id)
+	category, err := delivery.categoryUsecase.GetCategory(ctx, id)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		return "", http.StatusNotFound, fmt.Errorf("category with id: %s not found", id)
+	}
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+
+	path, status, err := delivery.storeImage(r,
+		func(filename string, file []byte) (string, error) {
+			return delivery.filestorage.PutCategoryImage(id.String(), filename, file)
+		},
+		func(filename string) error {
+			return delivery.filestorage.DeleteCategoryImage(id.String(), filename)
+		})
+	if err != nil {
+		return "", status, err
+	}
+	category.Image = path
+
+	err = delivery.categoryUsecase.UpdateCategory(ctx, category)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	return path, 0, nil
+}
+
+// storeImage processes uploaded image and puts all its variants in the file
+// storage. It returns url of the large variant or the http status and error.
+// If some variant can not be stored, already stored variants are deleted,
+// so the storage does not contain partially uploaded images
+func (delivery *Delivery) storeImage(r io.Reader, put putImageFunc, remove deleteImageFunc) (string, int, error) {
+	delivery.logger.Debug("Enter in delivery storeImage()")
+	// The file name is compiled from the date and time at the time of creation,
+	// the format is detected by content of the file
+	images, err := delivery.images.Process(r, carbon.Now().ToShortDateTimeString())
+	switch {
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		return "", http.StatusUnsupportedMediaType, err
+	case errors.Is(err, imaging.ErrTooLarge):
+		return "", http.StatusRequestEntityTooLarge, err
+	case errors.Is(err, imaging.ErrInvalidImage):
+		return "", http.StatusBadRequest, err
+	case err != nil:
+		return "", http.StatusInternalServerError, err
+	}
+
+	var large string
+	stored := make([]string, 0, len(images))
+	for _, image := range images {
+		url, err := put(image.Name, image.Data)
+		if err != nil {
+			for _, name := range stored {
+				if err := remove(name); err != nil {
+					delivery.logger.Sugar().Errorf("error on delete image variant %s: %v", name, err)
+				}
+			}
+			return "", http.StatusInsufficientStorage, fmt.Errorf("error on put image variant %s: %w", image.Name, err)
+		}
+		stored = append(stored, image.Name)
+		if image.Variant == imaging.VariantLarge {
+			large = url
+		}
+	}
+	delivery.logger.Sugar().Infof("Image %s stored with %d variants", path.Base(large), len(stored))
+	return large, 0, nil
+}
+
+// deleteImage deletes files of all variants of image by name of the large variant
+func deleteImage(name string, remove deleteImageFunc) error {
+	for _, filename := range imaging.FileNames(name) {
+		err := remove(filename)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeImages returns images of item with given urls in the same order,
+// images which already were in the item keep their alt texts and primary flag
+func mergeImages(images []models.ItemImage, urls []string) []models.ItemImage {
+	existing := make(map[string]models.ItemImage, len(images))
+	for _, image := range images {
+		existing[image.URL] = image
+	}
+	result := make([]models.ItemImage, 0, len(urls))
+	for _, url := range urls {
+		image, ok := existing[url]
+		if !ok {
+			image = models.ItemImage{URL: url}
+		}
+		result = append(result, image)
+	}
+	return models.NormalizeImages(result)
+}
+
+// itemImages returns images of item with urls of their variants
+func itemImages(images []models.ItemImage) []item.Image {
+	var result []item.Image
+	for _, image := range images {
+		variants := imageVariants([]string{image.URL})
+		if len(variants) == 0 {
+			continue
+		}
+		result = append(result, item.Image{
+			Id:            image.Id.String(),
+			Alt:           image.Alt,
+			Position:      image.Position,
+			Primary:       image.Primary,
+			ImageVariants: variants[0],
+		})
+	}
+	return result
+}
+
+// imageVariants returns urls of variants of images,
+// empty urls are skipped
+func imageVariants(images []string) []item.ImageVariants {
+	var variants []item.ImageVariants
+	for _, image := range images {
+		urls := imaging.VariantURLs(image)
+		if urls == nil {
+			continue
+		}
+		variants = append(variants, item.ImageVariants{
+			Thumb:  urls[imaging.VariantThumb],
+			Medium: urls[imaging.VariantMedium],
+			Large:  urls[imaging.VariantLarge],
+			WebP:   urls[imaging.VariantWebP],
+		})
+	}
+	return variants
+}
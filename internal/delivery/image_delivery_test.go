@@ -0,0 +1,124 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/item"
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/imaging"
+	"OnlineShopBackend/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testImageMaxSize = 1 << 20
+
+func TestStoreImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	filestorage := fs.NewMockFileStorager(ctrl)
+	delivery := NewDelivery(Usecases{}, logger, filestorage, imaging.NewProcessor(testImageMaxSize, logger))
+	put := func(filename string, file []byte) (string, error) {
+		return "http://localhost:8000/files/" + filename, nil
+	}
+	remove := func(filename string) error {
+		return nil
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockFile(c, "png", testFile)
+	url, status, err := delivery.storeImage(c.Request.Body, put, remove)
+	require.NoError(t, err)
+	require.Equal(t, 0, status)
+	require.Regexp(t, `^http://localhost:8000/files/\d+_large\.jpeg$`, url)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockFile(c, "jpeg", testFile[:len(testFile)/2])
+	_, status, err = delivery.storeImage(c.Request.Body, put, remove)
+	require.ErrorIs(t, err, imaging.ErrInvalidImage)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	delivery = NewDelivery(Usecases{}, logger, filestorage, imaging.NewProcessor(int64(len(testFile)-1), logger))
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockFile(c, "jpeg", testFile)
+	_, status, err = delivery.storeImage(c.Request.Body, put, remove)
+	require.ErrorIs(t, err, imaging.ErrTooLarge)
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
+
+func TestImageVariants(t *testing.T) {
+	variants := imageVariants([]string{"", "http://localhost:8000/files/items/1/20230101120000_large.png", "http://localhost:8000/files/items/1/old.jpeg"})
+	require.Equal(t, []item.ImageVariants{
+		{
+			Thumb:  "http://localhost:8000/files/items/1/20230101120000_thumb.png",
+			Medium: "http://localhost:8000/files/items/1/20230101120000_medium.png",
+			Large:  "http://localhost:8000/files/items/1/20230101120000_large.png",
+			WebP:   "http://localhost:8000/files/items/1/20230101120000_large.webp",
+		},
+		{
+			Thumb:  "http://localhost:8000/files/items/1/old.jpeg",
+			Medium: "http://localhost:8000/files/items/1/old.jpeg",
+			Large:  "http://localhost:8000/files/items/1/old.jpeg",
+		},
+	}, variants)
+	require.Nil(t, imageVariants([]string{""}))
+}
+
+func TestMergeImages(t *testing.T) {
+	id := uuid.New()
+	images := []models.ItemImage{
+		{Id: uuid.New(), URL: "1.jpeg", Position: 0},
+		{Id: id, URL: "2.jpeg", Alt: "Back side", Position: 1, Primary: true},
+	}
+	merged := mergeImages(images, []string{"3.jpeg", "", "2.jpeg", "3.jpeg"})
+	require.Equal(t, []models.ItemImage{
+		{URL: "3.jpeg", Position: 0},
+		{Id: id, URL: "2.jpeg", Alt: "Back side", Position: 1, Primary: true},
+	}, merged)
+
+	// The first image becomes primary, if the primary one is removed
+	merged = mergeImages(images, []string{"3.jpeg", "1.jpeg"})
+	require.True(t, merged[0].Primary)
+	require.False(t, merged[1].Primary)
+	require.Empty(t, mergeImages(images, nil))
+}
+
+func TestItemImages(t *testing.T) {
+	require.Nil(t, itemImages(nil))
+	images := itemImages([]models.ItemImage{
+		{Id: testId, URL: "http://localhost:8000/files/items/1/20230101120000_large.png", Alt: "Front side", Position: 0, Primary: true},
+		{Id: testId2, URL: "", Position: 1},
+	})
+	require.Equal(t, []item.Image{
+		{
+			Id:       testId.String(),
+			Alt:      "Front side",
+			Position: 0,
+			Primary:  true,
+			ImageVariants: item.ImageVariants{
+				Thumb:  "http://localhost:8000/files/items/1/20230101120000_thumb.png",
+				Medium: "http://localhost:8000/files/items/1/20230101120000_medium.png",
+				Large:  "http://localhost:8000/files/items/1/20230101120000_large.png",
+				WebP:   "http://localhost:8000/files/items/1/20230101120000_large.webp",
+			},
+		},
+	}, images)
+}
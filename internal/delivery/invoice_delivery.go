@@ -0,0 +1,63 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OrderInvoice - get invoice of the order as PDF file
+//
+//	@Summary		Get invoice of order
+//	@Description	Method provides to get the invoice of the order with shop details, customer, address, lines,
+//	@Description	taxes and totals as PDF file, the paid order gets a receipt. The file is rendered again when
+//	@Description	the order changes until it is handed to the carrier. Users get invoices of their own orders,
+//	@Description	admins get invoices of any order.
+//	@Tags			order
+//	@Produce		application/pdf
+//	@Param			orderID	path	string	true	"id of order"
+//	@Success		200		{file}	file
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/order/{orderID}/invoice [get]
+func (delivery *Delivery) OrderInvoice(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery OrderInvoice()")
+	userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+	if !ok {
+		err := fmt.Errorf("incorrect claims")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var invoice *models.Invoice
+	var data []byte
+	if userCr.Role == "Admin" {
+		invoice, data, err = delivery.invoiceUsecase.GetInvoice(c.Request.Context(), orderId)
+	} else {
+		invoice, data, err = delivery.invoiceUsecase.GetUserInvoice(c.Request.Context(), userCr.UserId, orderId)
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		if errors.Is(err, models.ErrorNotFound{}) {
+			delivery.SetError(c, http.StatusNotFound, err)
+			return
+		}
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", invoice.Number))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
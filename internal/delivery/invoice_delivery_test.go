@@ -0,0 +1,55 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOrderInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	invoiceUsecase := mocks.NewMockIInvoiceUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Invoice: invoiceUsecase}, zap.L(), nil, nil)
+	userId := uuid.New()
+	orderId := uuid.New()
+	invoice := &models.Invoice{OrderId: orderId, Number: "INV-20230131-1A2B3C4D"}
+
+	w, c := newWishlistContext(userId, map[string]string{"orderID": "invalid"}, nil, "")
+	delivery.OrderInvoice(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, nil, "")
+	invoiceUsecase.EXPECT().GetUserInvoice(ctx, userId, orderId).Return(nil, nil, models.ErrorNotFound{})
+	delivery.OrderInvoice(c)
+	require.Equal(t, 404, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, nil, "")
+	invoiceUsecase.EXPECT().GetUserInvoice(ctx, userId, orderId).Return(nil, nil, fmt.Errorf("test error"))
+	delivery.OrderInvoice(c)
+	require.Equal(t, 500, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, nil, "")
+	invoiceUsecase.EXPECT().GetUserInvoice(ctx, userId, orderId).Return(invoice, []byte("%PDF-1.4"), nil)
+	delivery.OrderInvoice(c)
+	require.Equal(t, 200, w.Code)
+	require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	require.Equal(t, "attachment; filename=INV-20230131-1A2B3C4D.pdf", w.Header().Get("Content-Disposition"))
+	require.Equal(t, "%PDF-1.4", w.Body.String())
+
+	// Admin gets invoice of any order
+	w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, nil, "")
+	c.Set("claims", &jwtauth.Payload{UserId: userId, Role: "Admin"})
+	invoiceUsecase.EXPECT().GetInvoice(ctx, orderId).Return(invoice, []byte("%PDF-1.4"), nil)
+	delivery.OrderInvoice(c)
+	require.Equal(t, 200, w.Code)
+}
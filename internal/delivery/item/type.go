@@ -4,12 +4,14 @@ import (
 	"OnlineShopBackend/internal/delivery/category"
 )
 
-// ShortItem is a structure for create new item
+// ShortItem is a structure for create new item, price is in minor units
+// of the currency, price without currency is in the base currency
 type ShortItem struct {
 	Title       string   `json:"title" binding:"required" example:"Пылесос"`
 	Description string   `json:"description" binding:"required" example:"Мощность всасывания 1.5 кВт"`
 	Category    string   `json:"category" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
-	Price       int32    `json:"price" example:"1990" default:"10" binding:"required" minimum:"0"`
+	Price       int64    `json:"price" example:"199000" default:"1000" binding:"required" minimum:"0"`
+	Currency    string   `json:"currency,omitempty" binding:"omitempty,len=3" example:"RUB"`
 	Vendor      string   `json:"vendor" example:"Витязь"`
 	Images      []string `json:"image,omitempty"`
 }
@@ -31,23 +33,74 @@ type OutItem struct {
 	Title       string            `json:"title" binding:"required" example:"Пылесос"`
 	Description string            `json:"description" binding:"required" example:"Мощность всасывания 1.5 кВт"`
 	Category    category.Category `json:"category" binding:"required"`
-	Price       int32             `json:"price" example:"1990" default:"10" binding:"required" minimum:"0"`
+	Price       int64             `json:"price" example:"199000" default:"1000" binding:"required" minimum:"0"`
+	Currency    string            `json:"currency" example:"RUB"`
 	Vendor      string            `json:"vendor" binding:"required" example:"Витязь"`
-	Images      []string          `json:"image,omitempty"`
-	IsFavourite bool              `json:"isFavourite" example:"false"`
+	// FormattedPrice is the price in major units with the currency
+	FormattedPrice string `json:"formattedPrice,omitempty" example:"1990.00 RUB"`
+	// DisplayPrice is the price converted to the currency requested by the client
+	DisplayPrice *Price `json:"displayPrice,omitempty"`
+	// Images contains urls of images, the primary image is first
+	Images []string `json:"image,omitempty"`
+	// ImageVariants contains images in order of their positions
+	// with urls of resized copies of every image
+	ImageVariants []Image `json:"images,omitempty"`
+	IsFavourite   bool    `json:"isFavourite" example:"false"`
+	// Rating is an average rating of approved reviews
+	Rating       float64 `json:"rating,omitempty" example:"4.5" minimum:"0" maximum:"5"`
+	ReviewsCount int     `json:"reviewsCount,omitempty" example:"12" minimum:"0"`
 }
 
-// InItem is a structure for update item
+// Price is a structure for output price in minor units of the currency
+type Price struct {
+	Amount    int64  `json:"amount" example:"2150"`
+	Currency  string `json:"currency" example:"USD"`
+	Formatted string `json:"formatted" example:"21.50 USD"`
+}
+
+// Image is a structure for output image of the item
+type Image struct {
+	Id       string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Alt      string `json:"alt" example:"Пылесос, вид спереди"`
+	Position int    `json:"position" example:"0" minimum:"0"`
+	Primary  bool   `json:"primary" example:"true"`
+	ImageVariants
+}
+
+// ImageVariants is a structure for urls of resized copies of the image.
+// Images uploaded before resizing was added have the same url in all sizes
+type ImageVariants struct {
+	Thumb  string `json:"thumb" example:"http://localhost:8000/files/items/00000000-0000-0000-0000-000000000000/20230101120000_thumb.jpeg"`
+	Medium string `json:"medium" example:"http://localhost:8000/files/items/00000000-0000-0000-0000-000000000000/20230101120000_medium.jpeg"`
+	Large  string `json:"large" example:"http://localhost:8000/files/items/00000000-0000-0000-0000-000000000000/20230101120000_large.jpeg"`
+	WebP   string `json:"webp,omitempty" example:"http://localhost:8000/files/items/00000000-0000-0000-0000-000000000000/20230101120000_large.webp"`
+}
+
+// InItem is a structure for update item, price without currency is in the base currency
 type InItem struct {
 	Id          string   `json:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 	Title       string   `json:"title" binding:"required" example:"Пылесос"`
 	Description string   `json:"description" binding:"required" example:"Мощность всасывания 1.5 кВт"`
 	Category    string   `json:"category" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
-	Price       int32    `json:"price" example:"1990" default:"10" binding:"required" minimum:"0"`
+	Price       int64    `json:"price" example:"199000" default:"1000" binding:"required" minimum:"0"`
+	Currency    string   `json:"currency,omitempty" binding:"omitempty,len=3" example:"RUB"`
 	Vendor      string   `json:"vendor" binding:"required" example:"Витязь"`
 	Images      []string `json:"image,omitempty"`
 }
 
+// ImagesUpdate is a structure for change order, alt texts and primary image of item.
+// The list contains all images of item in the new order
+type ImagesUpdate struct {
+	Images []ImageUpdate `json:"images" binding:"required,dive"`
+}
+
+// ImageUpdate is a structure for update of the image of item
+type ImageUpdate struct {
+	Id      string `json:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Alt     string `json:"alt" binding:"max=255" example:"Пылесос, вид спереди"`
+	Primary bool   `json:"primary" example:"true"`
+}
+
 // ItemsQuantity is a structure for result of the request for the quantity of items
 type ItemsQuantity struct {
 	Quantity int `json:"quantity" example:"10" default:"0" binding:"min=0" minimum:"0"`
@@ -58,3 +111,16 @@ type ItemsList struct {
 	List     []OutItem `json:"items" binding:"min=0" minimum:"0"`
 	Quantity int       `json:"quantity" example:"10" default:"0" binding:"min=0" minimum:"0"`
 }
+
+// Stock is a structure for setting stock of the item, null stock means
+// that the stock isn't tracked and quantity in carts isn't limited
+type Stock struct {
+	Stock *int `json:"stock" binding:"omitempty,min=0" example:"25" minimum:"0"`
+}
+
+// QuantityLimits is a structure for setting limits of the quantity of the item
+// in a cart line, zero maximum means that the quantity isn't limited
+type QuantityLimits struct {
+	MinQuantity int `json:"minQuantity" binding:"min=1" example:"1" minimum:"1"`
+	MaxQuantity int `json:"maxQuantity" binding:"min=0" example:"10" minimum:"0"`
+}
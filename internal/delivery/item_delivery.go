@@ -9,13 +9,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
-	"github.com/golang-module/carbon/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -110,14 +108,20 @@ func (delivery *Delivery) CreateItem(c *gin.Context) {
 		Title:       deliveryItem.Title,
 		Description: deliveryItem.Description,
 		Price:       deliveryItem.Price,
+		Currency:    deliveryItem.Currency,
 		Category: models.Category{
 			Id: categoryId,
 		},
 		Vendor: deliveryItem.Vendor,
-		Images: deliveryItem.Images,
+		Images: models.ImagesFromURLs(deliveryItem.Images),
 	}
 
 	id, err := delivery.itemUsecase.CreateItem(ctx, &modelsItem)
+	if err != nil && errors.Is(err, models.ErrUnknownCurrency) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
 	if err != nil {
 		delivery.SetError(c, http.StatusInternalServerError, err)
 		return
@@ -135,6 +139,7 @@ func (delivery *Delivery) CreateItem(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			itemID	path		string			true	"id of item"
+//	@Param			currency	query		string			false	"Currency of display prices, e.g. USD"
 //	@Success		200		{object}	item.OutItem	"Item structure"
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		403		"Forbidden"
@@ -143,6 +148,10 @@ func (delivery *Delivery) CreateItem(c *gin.Context) {
 //	@Router			/items/{itemID} [get]
 func (delivery *Delivery) GetItem(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery GetItem()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	id := c.Param("itemID")
 	if id == "" {
 		err := fmt.Errorf("empty item in request")
@@ -182,9 +191,15 @@ func (delivery *Delivery) GetItem(c *gin.Context) {
 			Description: modelsItem.Category.Description,
 			Image:       modelsItem.Category.Image,
 		},
-		Price:  modelsItem.Price,
-		Vendor: modelsItem.Vendor,
-		Images: modelsItem.Images,
+		Price:          modelsItem.Price,
+		Currency:       modelsItem.Currency,
+		FormattedPrice: modelsItem.Money().Format(),
+		DisplayPrice:   display.price(modelsItem.Money()),
+		Vendor:         modelsItem.Vendor,
+		Images:         modelsItem.ImageURLs(),
+		ImageVariants:  itemImages(modelsItem.Images),
+		Rating:         modelsItem.Rating,
+		ReviewsCount:   modelsItem.ReviewsCount,
 		// If the item in the favourites, put true, if not, put false
 		IsFavourite: delivery.IsFavourite(c, modelsItem.Id),
 	}
@@ -226,18 +241,6 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-	// If the item list is empty, add an empty line to it so as not to cause a mistake on the frontend
-	if len(deliveryItem.Images) == 0 {
-		deliveryItem.Images = append(deliveryItem.Images, "")
-	}
-	if len(deliveryItem.Images) > 1 {
-		for i, v := range deliveryItem.Images {
-			// If there is an empty line in the image list, we delete it to correctly display the images of item on the frontend
-			if v == "" {
-				deliveryItem.Images = append(deliveryItem.Images[:i], deliveryItem.Images[i+1:]...)
-			}
-		}
-	}
 	// Get the condition of item before the update
 	itemBeforUpdate, err := delivery.itemUsecase.GetItem(ctx, uid)
 	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
@@ -259,9 +262,11 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 		Category: models.Category{
 			Id: categoryUid,
 		},
-		Price:  deliveryItem.Price,
-		Vendor: deliveryItem.Vendor,
-		Images: deliveryItem.Images,
+		Price:    deliveryItem.Price,
+		Currency: deliveryItem.Currency,
+		Vendor:   deliveryItem.Vendor,
+		// Alt texts and primary image are kept for images which stay in the list
+		Images: mergeImages(itemBeforUpdate.Images, deliveryItem.Images),
 	}
 
 	if itemBeforUpdate.Category.Id != categoryUid {
@@ -288,25 +293,15 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 		delivery.SetError(c, http.StatusNotFound, err)
 		return
 	}
-	if err != nil {
+	if err != nil && errors.Is(err, models.ErrUnknownCurrency) {
 		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
+		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-	// Update the cache of the item list in the category
-	err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, updatingItem, "create")
 	if err != nil {
-		delivery.logger.Warn(err.Error())
-	} else {
-		delivery.logger.Info("Category cash of updating item updated success")
-	}
-	if itemBeforUpdate.Category.Id != categoryUid {
-		err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, itemBeforUpdate, "delete")
-		if err != nil {
-			delivery.logger.Warn(err.Error())
-		} else {
-			delivery.logger.Info("Category cash of old item updated success")
-		}
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
 	}
 	c.JSON(http.StatusOK, gin.H{})
 }
@@ -320,8 +315,9 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 //	@Produce		json
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"		default("name")
+//	@Param			sortType	query		string			false	"Sort type (name, price or rating)"		default("name")
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"		default("asc")
+//	@Param			currency	query		string			false	"Currency of display prices, e.g. USD"
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
 //	@Failure		403			"Forbidden"
@@ -330,6 +326,10 @@ func (delivery *Delivery) UpdateItem(c *gin.Context) {
 //	@Router			/items/list [get]
 func (delivery *Delivery) ItemsList(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery ItemsList()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	ctx := c.Request.Context()
 	var options Options
 	err := c.Bind(&options)
@@ -395,9 +395,15 @@ func (delivery *Delivery) ItemsList(c *gin.Context) {
 				Description: modelsItem.Category.Description,
 				Image:       modelsItem.Category.Image,
 			},
-			Price:  modelsItem.Price,
-			Vendor: modelsItem.Vendor,
-			Images: modelsItem.Images,
+			Price:          modelsItem.Price,
+			Currency:       modelsItem.Currency,
+			FormattedPrice: modelsItem.Money().Format(),
+			DisplayPrice:   display.price(modelsItem.Money()),
+			Vendor:         modelsItem.Vendor,
+			Images:         modelsItem.ImageURLs(),
+			ImageVariants:  itemImages(modelsItem.Images),
+			Rating:         modelsItem.Rating,
+			ReviewsCount:   modelsItem.ReviewsCount,
 			// If the item in the favourites, put true, if not, put false
 			IsFavourite: delivery.IsFavourite(c, modelsItem.Id),
 		}
@@ -541,8 +547,9 @@ func (delivery *Delivery) ItemsQuantityInSearch(c *gin.Context) {
 //	@Param			param		query		string			false	"Search param"
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"		default("name")
+//	@Param			sortType	query		string			false	"Sort type (name, price or rating)"		default("name")
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"		default("asc")
+//	@Param			currency	query		string			false	"Currency of display prices, e.g. USD"
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
 //	@Failure		403			"Forbidden"
@@ -551,6 +558,10 @@ func (delivery *Delivery) ItemsQuantityInSearch(c *gin.Context) {
 //	@Router			/items/search [get]
 func (delivery *Delivery) SearchLine(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery SearchLine()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	var options SearchOptions
 	err := c.Bind(&options)
 	if err != nil {
@@ -608,9 +619,15 @@ func (delivery *Delivery) SearchLine(c *gin.Context) {
 				Description: modelsItem.Category.Description,
 				Image:       modelsItem.Category.Image,
 			},
-			Price:       modelsItem.Price,
-			Vendor:      modelsItem.Vendor,
-			Images:      modelsItem.Images,
+			Price:          modelsItem.Price,
+			Currency:       modelsItem.Currency,
+			FormattedPrice: modelsItem.Money().Format(),
+			DisplayPrice:   display.price(modelsItem.Money()),
+			Vendor:         modelsItem.Vendor,
+			Images:         modelsItem.ImageURLs(),
+			ImageVariants:  itemImages(modelsItem.Images),
+			Rating:         modelsItem.Rating,
+			ReviewsCount:   modelsItem.ReviewsCount,
 			// If the item in the favourites, put true, if not, put false
 			IsFavourite: delivery.IsFavourite(c, modelsItem.Id),
 		}
@@ -631,8 +648,9 @@ func (delivery *Delivery) SearchLine(c *gin.Context) {
 //	@Param			param		query		string			false	"Category name"
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"		default("name")
+//	@Param			sortType	query		string			false	"Sort type (name, price or rating)"		default("name")
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"		default("asc")
+//	@Param			currency	query		string			false	"Currency of display prices, e.g. USD"
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
 //	@Failure		403			"Forbidden"
@@ -641,6 +659,10 @@ func (delivery *Delivery) SearchLine(c *gin.Context) {
 //	@Router			/items [get]
 func (delivery *Delivery) GetItemsByCategory(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery GetItemsByCategory()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	var options SearchOptions
 	err := c.Bind(&options)
 	if err != nil {
@@ -696,9 +718,15 @@ func (delivery *Delivery) GetItemsByCategory(c *gin.Context) {
 				Description: modelsItem.Category.Description,
 				Image:       modelsItem.Category.Image,
 			},
-			Price:       modelsItem.Price,
-			Vendor:      modelsItem.Vendor,
-			Images:      modelsItem.Images,
+			Price:          modelsItem.Price,
+			Currency:       modelsItem.Currency,
+			FormattedPrice: modelsItem.Money().Format(),
+			DisplayPrice:   display.price(modelsItem.Money()),
+			Vendor:         modelsItem.Vendor,
+			Images:         modelsItem.ImageURLs(),
+			ImageVariants:  itemImages(modelsItem.Images),
+			Rating:         modelsItem.Rating,
+			ReviewsCount:   modelsItem.ReviewsCount,
 			// If the item in the favourites, put true, if not, put false
 			IsFavourite: delivery.IsFavourite(c, modelsItem.Id),
 		}
@@ -722,6 +750,7 @@ func (delivery *Delivery) GetItemsByCategory(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		413	{object}	ErrorResponse
 //	@Failure		415	{object}	ErrorResponse
 //	@Failure		500	{object}	ErrorResponse
 //	@Failure		507	{object}	ErrorResponse
@@ -742,67 +771,12 @@ func (delivery *Delivery) UploadItemImage(c *gin.Context) {
 		delivery.SetError(c, http.StatusBadRequest, err)
 		return
 	}
-	var name string
-	contentType := c.ContentType()
-
-	// By value Content Type, determine the extension of the file
-	if contentType == "image/jpeg" {
-		// The file name is compiled from the date and time at the time of creation
-		name = carbon.Now().ToShortDateTimeString() + ".jpeg"
-	} else if contentType == "image/png" {
-		name = carbon.Now().ToShortDateTimeString() + ".png"
-	} else {
-		err := fmt.Errorf("unsupported media type: %s", contentType)
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusUnsupportedMediaType, err)
-		return
-	}
-
-	file, err := io.ReadAll(c.Request.Body)
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusUnsupportedMediaType, err)
-		return
-	}
-
 	delivery.logger.Info("Read id", zap.String("id", id))
-	delivery.logger.Info("File len=", zap.Int32("len", int32(len(file))))
-
-	// Request item for which the picture is installed
-	item, err := delivery.itemUsecase.GetItem(ctx, uid)
-	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
-		delivery.logger.Sugar().Errorf("item with id: %v not found", uid)
-		err = fmt.Errorf("item with id: %v not found", uid)
-		delivery.SetError(c, http.StatusNotFound, err)
-		return
-	}
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
-		return
-	}
-
-	// Put the picture in the file storage and get it url
-	path, err := delivery.filestorage.PutItemImage(id, name, file)
-	if err != nil {
-		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInsufficientStorage, err)
-		return
-	}
-
-	// Add url of picture to the item pictures list
-	item.Images = append(item.Images, path)
-	for i, v := range item.Images {
-		// If the list of pictures has an empty line, remove it from the list
-		if v == "" {
-			item.Images = append(item.Images[:i], item.Images[i+1:]...)
-		}
-	}
 
-	err = delivery.itemUsecase.UpdateItem(ctx, item)
+	_, status, err := delivery.attachItemImage(ctx, uid, c.Request.Body)
 	if err != nil {
 		delivery.logger.Error(err.Error())
-		delivery.SetError(c, http.StatusInternalServerError, err)
+		delivery.SetError(c, status, err)
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{})
@@ -863,7 +837,10 @@ func (delivery *Delivery) DeleteItemImage(c *gin.Context) {
 		return
 	}
 
-	err = delivery.filestorage.DeleteItemImage(imageOptions.Id, imageOptions.Name)
+	// Delete files of all variants of the picture
+	err = deleteImage(imageOptions.Name, func(filename string) error {
+		return delivery.filestorage.DeleteItemImage(imageOptions.Id, filename)
+	})
 	if err != nil {
 		delivery.logger.Error(err.Error())
 		delivery.SetError(c, http.StatusInternalServerError, err)
@@ -871,18 +848,15 @@ func (delivery *Delivery) DeleteItemImage(c *gin.Context) {
 
 	}
 
-	// Delete the address of the picture from the list of pictures of item
-	for idx, imagePath := range item.Images {
-		if strings.Contains(imagePath, imageOptions.Name) {
-			item.Images = append(item.Images[:idx], item.Images[idx+1:]...)
-			break
+	// Delete the picture from the list of pictures of item, if it was
+	// primary, the first of the rest pictures becomes primary
+	images := make([]models.ItemImage, 0, len(item.Images))
+	for _, image := range item.Images {
+		if !strings.Contains(image.URL, imageOptions.Name) {
+			images = append(images, image)
 		}
 	}
-	// If, after deleting the picture from the list, the list is empty - add 
-	// an empty line there so that item is correctly displayed on the frontend
-	if len(item.Images) == 0 {
-		item.Images = append(item.Images, "")
-	}
+	item.Images = images
 	err = delivery.itemUsecase.UpdateItem(ctx, item)
 	if err != nil {
 		delivery.logger.Error(err.Error())
@@ -892,6 +866,108 @@ func (delivery *Delivery) DeleteItemImage(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{})
 }
 
+// UpdateItemImages changes order, alt texts and primary image of item
+//
+//	@Summary		Change order, alt texts and primary image of item
+//	@Description	Method provides to change order, alt texts and primary image of item.
+//	@Description	The list must contain all images of item in the new order, only one of them may be primary.
+//	@Description	If no image is primary, the first image becomes primary.
+//	@Tags			items
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID	path		string				true	"id of item"
+//	@Param			images	body		item.ImagesUpdate	true	"Images of item in the new order"
+//	@Success		200		{array}		item.Image
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/items/image/update/{itemID} [put]
+func (delivery *Delivery) UpdateItemImages(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UpdateItemImages()")
+	ctx := c.Request.Context()
+	uid, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var update item.ImagesUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	modelsItem, err := delivery.itemUsecase.GetItem(ctx, uid)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("item with id: %v not found", uid)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The list must contain every image of item exactly once
+	if len(update.Images) != len(modelsItem.Images) {
+		err = fmt.Errorf("item has %d images, but %d images are given", len(modelsItem.Images), len(update.Images))
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	existing := make(map[uuid.UUID]models.ItemImage, len(modelsItem.Images))
+	for _, image := range modelsItem.Images {
+		existing[image.Id] = image
+	}
+	images := make([]models.ItemImage, 0, len(update.Images))
+	primary := 0
+	for _, imageUpdate := range update.Images {
+		id, err := uuid.Parse(imageUpdate.Id)
+		if err != nil {
+			delivery.logger.Error(err.Error())
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+		image, ok := existing[id]
+		if !ok {
+			err = fmt.Errorf("image with id: %v is not an image of item or repeated", id)
+			delivery.logger.Error(err.Error())
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+		delete(existing, id)
+		if imageUpdate.Primary {
+			primary++
+		}
+		image.Alt = imageUpdate.Alt
+		image.Primary = imageUpdate.Primary
+		images = append(images, image)
+	}
+	if primary > 1 {
+		err = fmt.Errorf("only one image may be primary, but %d are given", primary)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	modelsItem.Images = models.NormalizeImages(images)
+	err = delivery.itemUsecase.UpdateItem(ctx, modelsItem)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	result := itemImages(modelsItem.Images)
+	if result == nil {
+		result = make([]item.Image, 0)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // DeleteItem deleted item by id
 //
 //	@Summary		Method provides to delete item
@@ -951,12 +1027,6 @@ func (delivery *Delivery) DeleteItem(c *gin.Context) {
 		return
 	}
 
-	// Update the item list cache in the deleting item's category
-	err = delivery.itemUsecase.UpdateItemsInCategoryCash(ctx, deletedItem, "delete")
-	if err != nil {
-		delivery.logger.Sugar().Errorf("error on update cash in category items list: %v", err)
-	}
-
 	// If item has pictures, we remove them from the storage of pictures
 	if len(deletedItem.Images) > 0 {
 		err = delivery.filestorage.DeleteItemImagesFolderById(id)
@@ -1075,8 +1145,9 @@ func (delivery *Delivery) DeleteFavouriteItem(c *gin.Context) {
 //	@Param			param		query		string			false	"ID of user"
 //	@Param			limit		query		int				false	"Quantity of recordings"		default(10)	minimum(0)
 //	@Param			offset		query		int				false	"Offset when receiving records"	default(0)	mininum(0)
-//	@Param			sortType	query		string			false	"Sort type (name or price)"
+//	@Param			sortType	query		string			false	"Sort type (name, price or rating)"
 //	@Param			sortOrder	query		string			false	"Sort order (asc or desc)"
+//	@Param			currency	query		string			false	"Currency of display prices, e.g. USD"
 //	@Success		200			{object}	item.ItemsList	"List of items"
 //	@Failure		400			{object}	ErrorResponse
 //	@Failure		403			"Forbidden"
@@ -1085,6 +1156,10 @@ func (delivery *Delivery) DeleteFavouriteItem(c *gin.Context) {
 //	@Router			/items/favList [get]
 func (delivery *Delivery) GetFavouriteItems(c *gin.Context) {
 	delivery.logger.Debug("Enter in delivery GetFavouriteItems()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
 	var options SearchOptions
 	err := c.Bind(&options)
 	if err != nil {
@@ -1147,10 +1222,16 @@ func (delivery *Delivery) GetFavouriteItems(c *gin.Context) {
 				Description: modelsItem.Category.Description,
 				Image:       modelsItem.Category.Image,
 			},
-			Price:       modelsItem.Price,
-			Vendor:      modelsItem.Vendor,
-			Images:      modelsItem.Images,
-			IsFavourite: true,
+			Price:          modelsItem.Price,
+			Currency:       modelsItem.Currency,
+			FormattedPrice: modelsItem.Money().Format(),
+			DisplayPrice:   display.price(modelsItem.Money()),
+			Vendor:         modelsItem.Vendor,
+			Images:         modelsItem.ImageURLs(),
+			ImageVariants:  itemImages(modelsItem.Images),
+			Rating:         modelsItem.Rating,
+			ReviewsCount:   modelsItem.ReviewsCount,
+			IsFavourite:    true,
 		}
 	}
 	c.JSON(http.StatusOK, item.ItemsList{
@@ -1239,3 +1320,103 @@ func (delivery *Delivery) GetUserId(c *gin.Context) (uuid.UUID, error) {
 	}
 	return claims.UserId, nil
 }
+
+// SetItemStock - set stock of the item
+//
+//	@Summary		Set stock of item
+//	@Description	Method provides to set stock of the item, null stock means that the stock isn't tracked.
+//	@Description	Quantities of the item in carts merged on login are limited by the stock.
+//	@Tags			items
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID	path	string		true	"id of item"
+//	@Param			stock	body	item.Stock	true	"Stock of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/items/stock/{itemID} [put]
+func (delivery *Delivery) SetItemStock(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetItemStock()")
+	id, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var stock item.Stock
+	if err := c.ShouldBindJSON(&stock); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.itemUsecase.SetItemStock(c.Request.Context(), id, stock.Stock)
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("item with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidStock):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetQuantityLimits - set limits of the quantity of the item in a cart
+//
+//	@Summary		Set quantity limits of item
+//	@Description	Method provides to set minimal and maximal quantity of the item in a cart line, zero maximum means no limit.
+//	@Tags			items
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID	path	string				true	"id of item"
+//	@Param			limits	body	item.QuantityLimits	true	"Quantity limits of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/items/limits/{itemID} [put]
+func (delivery *Delivery) SetQuantityLimits(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetQuantityLimits()")
+	id, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var limits item.QuantityLimits
+	if err := c.ShouldBindJSON(&limits); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.itemUsecase.SetQuantityLimits(c.Request.Context(), id, models.QuantityLimits{
+		Min: limits.MinQuantity,
+		Max: limits.MaxQuantity,
+	})
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("item with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidLimits):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
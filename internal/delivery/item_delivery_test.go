@@ -4,6 +4,7 @@ import (
 	"OnlineShopBackend/internal/delivery/category"
 	"OnlineShopBackend/internal/delivery/item"
 	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/imaging"
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/usecase/mocks"
 	"bytes"
@@ -126,8 +127,9 @@ var (
 			Name:        "testName",
 			Description: "testDescription",
 		},
-		Price:  10,
-		Vendor: "testVendor",
+		Price:          10,
+		FormattedPrice: "0.10",
+		Vendor:         "testVendor",
 	}
 	testModelsItemWithId = &models.Item{
 		Id:          testId,
@@ -173,7 +175,7 @@ var (
 		Category:    models.Category{},
 		Price:       10,
 		Vendor:      "testVendor",
-		Images:      []string{""},
+		Images:      []models.ItemImage{},
 	}
 	testModelsItemWithImage = models.Item{
 		Id:          testId,
@@ -186,7 +188,7 @@ var (
 		},
 		Price:  10,
 		Vendor: "testVendor",
-		Images: []string{"testName"},
+		Images: []models.ItemImage{{URL: "testName"}},
 	}
 	testModelsItemWithImage35 = models.Item{
 		Id:          testId,
@@ -194,7 +196,7 @@ var (
 		Description: "testDescription",
 		Price:       10,
 		Vendor:      "testVendor",
-		Images:      []string{""},
+		Images:      []models.ItemImage{},
 	}
 	testModelsItemWithImage2 = models.Item{
 		Id:          testId,
@@ -207,7 +209,7 @@ var (
 		},
 		Price:  10,
 		Vendor: "testVendor",
-		Images: []string{"testName.jpeg"},
+		Images: []models.ItemImage{{URL: "testName.jpeg"}},
 	}
 	testEmptyItem = item.ShortItem{
 		Title:       "",
@@ -271,13 +273,14 @@ func TestCreateItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testShortItem, post)
 	bytesRes, _ := json.Marshal(&testItemId)
@@ -290,6 +293,7 @@ func TestCreateItem(t *testing.T) {
 	c, _ = gin.CreateTestContext(w)
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, wrongShortItem, post)
 	delivery.CreateItem(c)
@@ -299,6 +303,7 @@ func TestCreateItem(t *testing.T) {
 	c, _ = gin.CreateTestContext(w)
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testEmptyItem, post)
 	delivery.CreateItem(c)
@@ -308,6 +313,7 @@ func TestCreateItem(t *testing.T) {
 	c, _ = gin.CreateTestContext(w)
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testShortItem, post)
 	itemUsecase.EXPECT().CreateItem(ctx, testModelsItemWithoutId).Return(uuid.Nil, fmt.Errorf("error"))
@@ -322,6 +328,7 @@ func TestCreateItem(t *testing.T) {
 	c, _ = gin.CreateTestContext(w)
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testShortItemWithoutCat, post)
 	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(nil, models.ErrorNotFound{})
@@ -333,6 +340,7 @@ func TestCreateItem(t *testing.T) {
 	c, _ = gin.CreateTestContext(w)
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testShortItemWithoutCat, post)
 	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(nil, err)
@@ -343,6 +351,7 @@ func TestCreateItem(t *testing.T) {
 	c, _ = gin.CreateTestContext(w)
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testShortItemWithoutCat, post)
 	categoryUsecase.EXPECT().GetCategoryByName(ctx, "NoCategory").Return(nil, models.ErrorNotFound{})
@@ -363,13 +372,14 @@ func TestGetItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -388,6 +398,7 @@ func TestGetItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	delivery.GetItem(c)
 	require.Equal(t, 400, w.Code)
@@ -397,6 +408,7 @@ func TestGetItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -420,13 +432,14 @@ func TestUpdateItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, wrongInItem, put)
 	delivery.UpdateItem(c)
@@ -437,6 +450,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItemWithWrongId, put)
 	delivery.UpdateItem(c)
@@ -447,6 +461,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItemWithWrongCatId, put)
 	delivery.UpdateItem(c)
@@ -457,6 +472,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(nil, fmt.Errorf("error"))
@@ -468,6 +484,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(nil, models.ErrorNotFound{})
@@ -479,6 +496,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithIdAndOtherCatId, nil)
@@ -492,6 +510,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(nil, models.ErrorNotFound{})
@@ -503,6 +522,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithIdAndOtherCatId, nil)
@@ -515,6 +535,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithIdAndOtherCatId, nil)
@@ -527,6 +548,7 @@ func TestUpdateItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testInItem, put)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithIdAndOtherCatId, nil)
@@ -547,13 +569,14 @@ func TestItemsList(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?offset=0&limit=1&sortType=name&sortOrder=asc")
 
@@ -572,6 +595,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?offset=0&limit=1")
 
@@ -584,6 +608,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?offset=0&limit=k")
 
@@ -595,6 +620,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	testOutItems.Quantity = 1
@@ -611,6 +637,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	testOutItems.Quantity = 100
@@ -627,6 +654,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	w = httptest.NewRecorder()
@@ -634,6 +662,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	itemUsecase.EXPECT().ItemsQuantity(ctx).Return(100, nil)
@@ -647,6 +676,7 @@ func TestItemsList(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	itemUsecase.EXPECT().ItemsQuantity(ctx).Return(0, nil)
@@ -665,13 +695,14 @@ func TestItemsQuantity(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	testQuantity := item.ItemsQuantity{
@@ -688,6 +719,7 @@ func TestItemsQuantity(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	itemUsecase.EXPECT().ItemsQuantity(ctx).Return(-1, fmt.Errorf("error"))
 	delivery.ItemsQuantity(c)
@@ -705,13 +737,14 @@ func TestSearchLine(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=1")
 	testLimitOptions := map[string]int{"offset": 0, "limit": 1}
@@ -730,6 +763,7 @@ func TestSearchLine(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=1")
 
@@ -742,6 +776,7 @@ func TestSearchLine(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=k")
 
@@ -753,6 +788,7 @@ func TestSearchLine(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?offset=0&limit=1")
 
@@ -764,6 +800,7 @@ func TestSearchLine(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=0")
 
@@ -778,6 +815,7 @@ func TestSearchLine(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=0")
 
@@ -798,13 +836,14 @@ func TestGetItemsByCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=1")
 	testLimitOptions := map[string]int{"offset": 0, "limit": 1}
@@ -823,6 +862,7 @@ func TestGetItemsByCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=1")
 
@@ -835,6 +875,7 @@ func TestGetItemsByCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=k")
 
@@ -846,6 +887,7 @@ func TestGetItemsByCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?offset=0&limit=1")
 
@@ -857,6 +899,7 @@ func TestGetItemsByCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=0")
 
@@ -871,6 +914,7 @@ func TestGetItemsByCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=0")
 
@@ -891,13 +935,14 @@ func TestUploadItemImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, imaging.NewProcessor(testImageMaxSize, logger))
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	delivery.UploadItemImage(c)
 	require.Equal(t, 400, w.Code)
@@ -907,6 +952,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -914,6 +960,8 @@ func TestUploadItemImage(t *testing.T) {
 			Value: testId.String(),
 		},
 	}
+	MockFile(c, "jpeg", []byte("not an image"))
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{}, nil)
 	delivery.UploadItemImage(c)
 	require.Equal(t, 415, w.Code)
 
@@ -922,6 +970,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -939,6 +988,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -956,6 +1006,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -965,7 +1016,12 @@ func TestUploadItemImage(t *testing.T) {
 	}
 	MockFile(c, "jpeg", testFile)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{}, nil)
-	filestorage.EXPECT().PutItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+".jpeg", testFile).Return("", fmt.Errorf("error"))
+	// Already stored variants are deleted if the next one is not stored
+	gomock.InOrder(
+		filestorage.EXPECT().PutItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+"_thumb.jpeg", gomock.Any()).Return("testName", nil),
+		filestorage.EXPECT().PutItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+"_medium.jpeg", gomock.Any()).Return("", fmt.Errorf("error")),
+		filestorage.EXPECT().DeleteItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+"_thumb.jpeg").Return(nil),
+	)
 	delivery.UploadItemImage(c)
 	require.Equal(t, 507, w.Code)
 
@@ -974,6 +1030,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -983,7 +1040,7 @@ func TestUploadItemImage(t *testing.T) {
 	}
 	MockFile(c, "png", testFile)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{}, nil)
-	filestorage.EXPECT().PutItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+".png", testFile).Return("", fmt.Errorf("error"))
+	filestorage.EXPECT().PutItemImage(testId.String(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("error"))
 	delivery.UploadItemImage(c)
 	require.Equal(t, 507, w.Code)
 
@@ -992,6 +1049,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1001,7 +1059,7 @@ func TestUploadItemImage(t *testing.T) {
 	}
 	MockFile(c, "jpeg", testFile)
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithId, nil)
-	filestorage.EXPECT().PutItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+".jpeg", testFile).Return("testName", nil)
+	filestorage.EXPECT().PutItemImage(testId.String(), gomock.Any(), gomock.Any()).Return("testName", nil).Times(len(imaging.VariantNames("name_large.jpeg")))
 	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemWithImage).Return(fmt.Errorf("error"))
 	delivery.UploadItemImage(c)
 	require.Equal(t, 500, w.Code)
@@ -1011,6 +1069,7 @@ func TestUploadItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1019,7 +1078,7 @@ func TestUploadItemImage(t *testing.T) {
 		},
 	}
 	MockFile(c, "jpeg", testFile)
-	filestorage.EXPECT().PutItemImage(testId.String(), carbon.Now().ToShortDateTimeString()+".jpeg", testFile).Return("testName", nil)
+	filestorage.EXPECT().PutItemImage(testId.String(), gomock.Any(), gomock.Any()).Return("testName", nil).Times(len(imaging.VariantNames("name_large.jpeg")))
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testModelsItemWithId2, nil)
 	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemWithImage).Return(nil)
 	delivery.UploadItemImage(c)
@@ -1037,13 +1096,14 @@ func TestDeleteItemImage(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	delivery.DeleteItemImage(c)
 	require.Equal(t, 400, w.Code)
@@ -1053,6 +1113,7 @@ func TestDeleteItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?id=%s&name=testName.jpg", testId.String()))
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{}, models.ErrorNotFound{})
@@ -1064,6 +1125,7 @@ func TestDeleteItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?id=%s&name=testName.jpg", testId.String()))
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{}, err)
@@ -1075,6 +1137,7 @@ func TestDeleteItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?id=%s&name=testName.jpg", testId.String()))
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{}, nil)
@@ -1087,11 +1150,12 @@ func TestDeleteItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?id=%s&name=testName.jpeg", testId.String()))
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&testModelsItemWithImage2, nil)
 	filestorage.EXPECT().DeleteItemImage(testId.String(), "testName.jpeg").Return(nil)
-	testModelsItemWithImage2.Images = []string{}
+	testModelsItemWithImage2.Images = []models.ItemImage{}
 	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemWithImage2).Return(fmt.Errorf("error"))
 	delivery.DeleteItemImage(c)
 	require.Equal(t, 500, w.Code)
@@ -1101,16 +1165,108 @@ func TestDeleteItemImage(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?id=%s&name=testName.jpeg", testId.String()))
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&testModelsItemWithImage2, nil)
 	filestorage.EXPECT().DeleteItemImage(testId.String(), "testName.jpeg").Return(nil)
-	testModelsItemWithImage2.Images = []string{}
+	testModelsItemWithImage2.Images = []models.ItemImage{}
 	itemUsecase.EXPECT().UpdateItem(ctx, &testModelsItemWithImage2).Return(nil)
 	delivery.DeleteItemImage(c)
 	require.Equal(t, 200, w.Code)
 }
 
+func TestUpdateItemImages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemUsecase := mocks.NewMockIItemUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Item: itemUsecase}, logger, nil, nil)
+	firstId, secondId := uuid.New(), uuid.New()
+	testItem := func() *models.Item {
+		return &models.Item{
+			Id: testId,
+			Images: []models.ItemImage{
+				{Id: firstId, URL: "http://localhost:8000/files/items/1/20230101120000_large.jpeg", Position: 0, Primary: true},
+				{Id: secondId, URL: "http://localhost:8000/files/items/1/20230101130000_large.jpeg", Position: 1},
+			},
+		}
+	}
+	newContext := func(update item.ImagesUpdate) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "itemID",
+				Value: testId.String(),
+			},
+		}
+		MockJson(c, update, "PUT")
+		return w, c
+	}
+	reordered := item.ImagesUpdate{Images: []item.ImageUpdate{
+		{Id: secondId.String(), Alt: "Back side", Primary: true},
+		{Id: firstId.String(), Alt: "Front side"},
+	}}
+
+	w, c := newContext(item.ImagesUpdate{Images: []item.ImageUpdate{{Id: "1"}}})
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(reordered)
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(nil, models.ErrorNotFound{})
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 404, w.Code)
+
+	// Not every image of item is given
+	w, c = newContext(item.ImagesUpdate{Images: reordered.Images[:1]})
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testItem(), nil)
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 400, w.Code)
+
+	// Image of other item
+	w, c = newContext(item.ImagesUpdate{Images: []item.ImageUpdate{reordered.Images[0], {Id: uuid.New().String()}}})
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testItem(), nil)
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 400, w.Code)
+
+	// Two primary images
+	w, c = newContext(item.ImagesUpdate{Images: []item.ImageUpdate{reordered.Images[0], {Id: firstId.String(), Primary: true}}})
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testItem(), nil)
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(reordered)
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testItem(), nil)
+	itemUsecase.EXPECT().UpdateItem(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 500, w.Code)
+
+	w, c = newContext(reordered)
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(testItem(), nil)
+	itemUsecase.EXPECT().UpdateItem(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, updated *models.Item) error {
+		require.Equal(t, []models.ItemImage{
+			{Id: secondId, URL: "http://localhost:8000/files/items/1/20230101130000_large.jpeg", Alt: "Back side", Position: 0, Primary: true},
+			{Id: firstId, URL: "http://localhost:8000/files/items/1/20230101120000_large.jpeg", Alt: "Front side", Position: 1},
+		}, updated.Images)
+		return nil
+	})
+	delivery.UpdateItemImages(c)
+	require.Equal(t, 200, w.Code)
+	var images []item.Image
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &images))
+	require.Len(t, images, 2)
+	require.Equal(t, secondId.String(), images[0].Id)
+	require.Equal(t, "Back side", images[0].Alt)
+	require.True(t, images[0].Primary)
+	require.Equal(t, "http://localhost:8000/files/items/1/20230101130000_thumb.jpeg", images[0].Thumb)
+}
+
 func TestDeleteItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1122,13 +1278,14 @@ func TestDeleteItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	delivery.DeleteItem(c)
 	require.Equal(t, 400, w.Code)
@@ -1138,6 +1295,7 @@ func TestDeleteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1153,6 +1311,7 @@ func TestDeleteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1169,6 +1328,7 @@ func TestDeleteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1186,6 +1346,7 @@ func TestDeleteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1195,7 +1356,6 @@ func TestDeleteItem(t *testing.T) {
 	}
 	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&testModelsItemWithImage, nil)
 	itemUsecase.EXPECT().DeleteItem(ctx, testId).Return(nil)
-	itemUsecase.EXPECT().UpdateItemsInCategoryCash(ctx, &testModelsItemWithImage, "delete").Return(fmt.Errorf("error"))
 	filestorage.EXPECT().DeleteItemImagesFolderById(testId.String()).Return(fmt.Errorf("error"))
 	delivery.DeleteItem(c)
 	require.Equal(t, 200, w.Code)
@@ -1212,13 +1372,14 @@ func TestItemsQuantityInCategory(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1234,6 +1395,7 @@ func TestItemsQuantityInCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1251,6 +1413,7 @@ func TestItemsQuantityInCategory(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1275,13 +1438,14 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1297,6 +1461,7 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1314,6 +1479,7 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1338,13 +1504,14 @@ func TestAddFavouriteItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testWrongAddFav, post)
 	delivery.AddFavouriteItem(c)
@@ -1355,6 +1522,7 @@ func TestAddFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testAddFav, post)
 	itemUsecase.EXPECT().AddFavouriteItem(ctx, testId, testId2).Return(models.ErrorNotFound{})
@@ -1366,6 +1534,7 @@ func TestAddFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testAddFav, post)
 	itemUsecase.EXPECT().AddFavouriteItem(ctx, testId, testId2).Return(err)
@@ -1377,6 +1546,7 @@ func TestAddFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockJson(c, testAddFav, post)
 	itemUsecase.EXPECT().AddFavouriteItem(ctx, testId, testId2).Return(nil)
@@ -1395,13 +1565,14 @@ func TestDeleteFavouriteItem(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1417,6 +1588,7 @@ func TestDeleteFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1436,6 +1608,7 @@ func TestDeleteFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1456,6 +1629,7 @@ func TestDeleteFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1476,6 +1650,7 @@ func TestDeleteFavouriteItem(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1503,13 +1678,14 @@ func TestGetFavouriteItems(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?param=%s&offset=0&limit=1", testId.String()))
 	testLimitOptions := map[string]int{"offset": 0, "limit": 1}
@@ -1525,6 +1701,7 @@ func TestGetFavouriteItems(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?param=%s&offset=0&limit=1", testId.String()))
 
@@ -1537,6 +1714,7 @@ func TestGetFavouriteItems(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?param=test&offset=0&limit=k")
 
@@ -1548,6 +1726,7 @@ func TestGetFavouriteItems(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse("?offset=0&limit=1")
 
@@ -1559,6 +1738,7 @@ func TestGetFavouriteItems(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Request.URL, _ = url.Parse(fmt.Sprintf("?param=%s&offset=0&limit=0", testId.String()))
 
@@ -1579,13 +1759,14 @@ func TestItemsQuantityInSearch(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := mocks.NewMockIOrderUsecase(ctrl)
-	delivery := NewDelivery(itemUsecase, nil, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1601,6 +1782,7 @@ func TestItemsQuantityInSearch(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -1618,6 +1800,7 @@ func TestItemsQuantityInSearch(t *testing.T) {
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -14,6 +14,10 @@ type Order struct {
 	ShipmentTime time.Time       `json:"shipment_time" binding:"required" time_format:"2006-01-02"`
 	Address      OrderAddress    `json:"address" binding:"required"`
 	Status       string          `json:"status,omitempty"`
+	// Shipping method is empty for orders shipped in the standard period
+	ShippingMethodId string `json:"shippingMethodId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ShippingMethod   string `json:"shippingMethod,omitempty" example:"Courier"`
+	cart.Totals
 }
 
 func (order *Order) SortOrderItems() {
@@ -37,6 +41,8 @@ type CartAdressUser struct {
 	Cart    cart.Cart    `json:"cart"`
 	User    UserForCart  `json:"user"`
 	Address OrderAddress `json:"address"`
+	// ShippingMethodId is optional, without it the order is shipped in the standard period
+	ShippingMethodId string `json:"shippingMethodId,omitempty" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 }
 
 type OrderId struct {
@@ -55,3 +61,20 @@ type StatusWithUserAndId struct {
 	Status  string      `json:"status"`
 	OrderId string      `json:"order_id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
 }
+
+// ReorderLine is a line of the previous order copied to the cart, added
+// quantity is less than requested if it's limited by stock or limits
+type ReorderLine struct {
+	ItemId    string `json:"itemId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Title     string `json:"title"`
+	Requested int    `json:"requested"`
+	Added     int    `json:"added"`
+	// Reason is why the line is skipped: deleted, unavailable or quantity_limit
+	Reason string `json:"reason,omitempty" example:"deleted"`
+}
+
+type Reorder struct {
+	CartId  string        `json:"cartId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Added   []ReorderLine `json:"added"`
+	Skipped []ReorderLine `json:"skipped"`
+}
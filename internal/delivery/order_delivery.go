@@ -6,6 +6,7 @@ import (
 	"OnlineShopBackend/internal/delivery/item"
 	"OnlineShopBackend/internal/delivery/order"
 	"OnlineShopBackend/internal/models"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 //	@Failure		400				{object}	ErrorResponse
 //	@Failure		403				"Forbidden"
 //	@Failure		404				{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422				{object}	ErrorResponse	"Promo code of the cart or shipping method can't be applied"
 //	@Failure		500				{object}	ErrorResponse
 //	@Router			/order/create/ [post]
 func (d *Delivery) CreateOrder(c *gin.Context) {
@@ -53,9 +55,10 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 		return
 	}
 	cartModel := models.Cart{
-		Id:     id,
-		UserId: user.ID,
-		Items:  make([]models.ItemWithQuantity, 0, len(cart.Cart.Items)),
+		Id:        id,
+		UserId:    user.ID,
+		Items:     make([]models.ItemWithQuantity, 0, len(cart.Cart.Items)),
+		PromoCode: cart.Cart.PromoCode,
 	}
 	for _, oitem := range cart.Cart.Items {
 		id, err = uuid.Parse(oitem.Item.Id)
@@ -64,11 +67,11 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 			d.SetError(c, http.StatusInternalServerError, err)
 			return
 		}
+		// Prices are taken from the catalogue when the order is placed
 		itemM := models.ItemWithQuantity{
 			Item: models.Item{
 				Id:    id,
 				Title: oitem.Item.Title,
-				Price: oitem.Item.Price,
 			},
 			Quantity: oitem.Quantity.Quantity,
 		}
@@ -82,12 +85,43 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 		Street:  cart.Address.Street,
 	}
 
-	ordr, err := d.orderUsecase.PlaceOrder(ctx, &cartModel, user, addressMdl)
+	shippingMethodId := uuid.Nil
+	if cart.ShippingMethodId != "" {
+		shippingMethodId, err = uuid.Parse(cart.ShippingMethodId)
+		if err != nil {
+			d.logger.Sugar().Errorf("can't parse shipping method id: %s", err)
+			d.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	ordr, err := d.orderUsecase.PlaceOrder(ctx, &cartModel, user, addressMdl, shippingMethodId)
+	if err != nil && (errors.Is(err, models.ErrItemUnavailable) || errors.Is(err, models.ErrInvalidQuantity)) {
+		d.logger.Sugar().Errorf("can't order items: %s", err)
+		d.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrShippingUnavailable) {
+		d.logger.Sugar().Errorf("can't ship order: %s", err)
+		d.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err != nil && (errors.Is(err, models.ErrPromoCode) || errors.Is(err, models.ErrorNotFound{})) {
+		d.logger.Sugar().Errorf("can't apply promo code to order: %s", err)
+		d.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err != nil && errors.Is(err, models.ErrUnknownCurrency) {
+		d.logger.Sugar().Errorf("can't price order: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
 	if err != nil {
 		d.logger.Sugar().Errorf("can't create order: %s", err)
 		d.SetError(c, http.StatusInternalServerError, err)
 		return
 	}
+	d.recoverCart(c, cartModel.Id)
 	err = d.cartUsecase.DeleteCart(ctx, cartModel.Id)
 	if err != nil {
 		d.logger.Sugar().Warnf("error when deleting cart with id: %v, err: %v", id, err)
@@ -114,7 +148,8 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
-//	@Param			orderID	path		string		true	"Id of order"
+//	@Param			orderID		path		string		true	"Id of order"
+//	@Param			currency	query		string		false	"Currency of display prices, e.g. USD"
 //	@Success		200		{object}	order.Order	"Order structure"
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		403		"Forbidden"
@@ -123,6 +158,10 @@ func (d *Delivery) CreateOrder(c *gin.Context) {
 //	@Router			/order/{orderID} [get]
 func (d *Delivery) GetOrder(c *gin.Context) {
 	d.logger.Sugar().Debug("Enter the delivery GetOrder()")
+	display, ok := d.displayCurrency(c)
+	if !ok {
+		return
+	}
 	ctx := c.Request.Context()
 	orderId, err := uuid.Parse(c.Param(("orderID")))
 	if err != nil {
@@ -144,7 +183,12 @@ func (d *Delivery) GetOrder(c *gin.Context) {
 		Address:      order.OrderAddress(modelOrder.Address),
 		Status:       string(modelOrder.Status),
 		Items:        make([]cart.CartItem, 0, len(modelOrder.Items)),
+		Totals:       totalsToDelivery(modelOrder.Pricing, display),
 	}
+	if modelOrder.ShippingMethodId != uuid.Nil {
+		order.ShippingMethodId = modelOrder.ShippingMethodId.String()
+	}
+	order.ShippingMethod = modelOrder.ShippingMethod
 	for _, oitem := range modelOrder.Items {
 		cartItem := cart.CartItem{
 			Item: item.OutItem{
@@ -157,9 +201,13 @@ func (d *Delivery) GetOrder(c *gin.Context) {
 					Description: oitem.Category.Description,
 					Image:       oitem.Category.Image,
 				},
-				Price:  oitem.Price,
-				Vendor: oitem.Vendor,
-				Images: oitem.Images,
+				Price:          oitem.Price,
+				Currency:       oitem.Currency,
+				FormattedPrice: oitem.Money().Format(),
+				DisplayPrice:   display.price(oitem.Money()),
+				Vendor:         oitem.Vendor,
+				Images:         oitem.ImageURLs(),
+				ImageVariants:  itemImages(oitem.Images),
 			},
 		}
 		cartItem.Quantity.Quantity = oitem.Quantity
@@ -176,7 +224,8 @@ func (d *Delivery) GetOrder(c *gin.Context) {
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
-//	@Param			userID	path		string		true	"Id of the user"
+//	@Param			userID		path		string		true	"Id of the user"
+//	@Param			currency	query		string		false	"Currency of display prices, e.g. USD"
 //	@Success		200		{array}		order.Order	"List of orders"
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		403		"Forbidden"
@@ -185,6 +234,10 @@ func (d *Delivery) GetOrder(c *gin.Context) {
 //	@Router			/order/list/{userID} [get]
 func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 	d.logger.Sugar().Debug("Enter the delivery GetOrdersForUser()")
+	display, ok := d.displayCurrency(c)
+	if !ok {
+		return
+	}
 	ctx := c.Request.Context()
 	userId, err := uuid.Parse(c.Param(("userID")))
 	if err != nil {
@@ -208,7 +261,12 @@ func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 			Address:      order.OrderAddress(modelOrder.Address),
 			Status:       string(modelOrder.Status),
 			Items:        make([]cart.CartItem, 0, len(modelOrder.Items)),
+			Totals:       totalsToDelivery(modelOrder.Pricing, display),
+		}
+		if modelOrder.ShippingMethodId != uuid.Nil {
+			order.ShippingMethodId = modelOrder.ShippingMethodId.String()
 		}
+		order.ShippingMethod = modelOrder.ShippingMethod
 		for _, oitem := range modelOrder.Items {
 			cartItem := cart.CartItem{
 				Item: item.OutItem{
@@ -221,9 +279,13 @@ func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 						Description: oitem.Category.Description,
 						Image:       oitem.Category.Image,
 					},
-					Price:  oitem.Price,
-					Vendor: oitem.Vendor,
-					Images: oitem.Images,
+					Price:          oitem.Price,
+					Currency:       oitem.Currency,
+					FormattedPrice: oitem.Money().Format(),
+					DisplayPrice:   display.price(oitem.Money()),
+					Vendor:         oitem.Vendor,
+					Images:         oitem.ImageURLs(),
+					ImageVariants:  itemImages(oitem.Images),
 				},
 			}
 			cartItem.Quantity.Quantity = oitem.Quantity
@@ -247,6 +309,7 @@ func (d *Delivery) GetOrdersForUser(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Order has payments or returns"
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/order/delete/{orderID} [delete]
 func (d *Delivery) DeleteOrder(c *gin.Context) {
@@ -264,6 +327,10 @@ func (d *Delivery) DeleteOrder(c *gin.Context) {
 	err = d.orderUsecase.DeleteOrder(ctx, &models.Order{ID: orderId})
 	if err != nil {
 		d.logger.Sugar().Errorf("Can't delete order with orderID %s", err)
+		if errors.Is(err, models.ErrOrderNotDeletable) {
+			d.SetError(c, http.StatusUnprocessableEntity, err)
+			return
+		}
 		d.SetError(c, http.StatusInternalServerError, err)
 		return
 	}
@@ -273,7 +340,8 @@ func (d *Delivery) DeleteOrder(c *gin.Context) {
 // ChangeAddress - change address of a specific order by Id
 //
 //	@Summary		Change address of a  specific order by Id
-//	@Description	The method allows you to change address of an order by Id.
+//	@Description	The method allows you to change address of an order by Id. Taxes and shipping of the order
+//	@Description	are calculated again for the address, the order can't be changed after its payment is started.
 //	@Tags			order
 //	@Accept			json
 //	@Produce		json
@@ -282,6 +350,7 @@ func (d *Delivery) DeleteOrder(c *gin.Context) {
 //	@Failure		400	{object}	ErrorResponse
 //	@Failure		403	"Forbidden"
 //	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/order/changeaddress/ [patch]
 func (d *Delivery) ChangeAddress(c *gin.Context) {
@@ -317,7 +386,14 @@ func (d *Delivery) ChangeAddress(c *gin.Context) {
 		}, models.UserAddress(address.Address))
 	if err != nil {
 		d.logger.Sugar().Errorf("can't change address for order with id: %s %s", orderID, err)
-		d.SetError(c, http.StatusInternalServerError, err)
+		switch {
+		case errors.Is(err, models.ErrorNotFound{}):
+			d.SetError(c, http.StatusNotFound, err)
+		case errors.Is(err, models.ErrOrderNotChangeable), errors.Is(err, models.ErrShippingUnavailable):
+			d.SetError(c, http.StatusUnprocessableEntity, err)
+		default:
+			d.SetError(c, http.StatusInternalServerError, err)
+		}
 		return
 	}
 }
@@ -374,3 +450,77 @@ func (d *Delivery) ChangeStatus(c *gin.Context) {
 		return
 	}
 }
+
+// Reorder - copy items of the previous order to the cart
+//
+//	@Summary		Reorder
+//	@Description	The method copies all still available items and quantities of the previous order of the user into the current cart of the user. Lines of deleted or unavailable items are skipped and reported.
+//	@Tags			order
+//	@Produce		json
+//	@Param			orderID	path		string			true	"Id of order"
+//	@Success		200		{object}	order.Reorder	"Id of the cart with added and skipped lines"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/order/{orderID}/reorder [post]
+func (d *Delivery) Reorder(c *gin.Context) {
+	d.logger.Debug("Enter in delivery Reorder()")
+	userId, ok := d.claimsUser(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		d.logger.Sugar().Errorf("can't parse order id: %s", err)
+		d.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelOrder, err := d.orderUsecase.GetOrder(ctx, orderId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("order not found: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		d.logger.Sugar().Errorf("can't get order: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	reorder, err := d.cartUsecase.Reorder(ctx, userId, modelOrder)
+	// Orders of other users are not found
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		d.logger.Sugar().Errorf("order not found: %s", err)
+		d.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		d.logger.Sugar().Errorf("can't reorder: %s", err)
+		d.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, reorderToDelivery(reorder))
+}
+
+// reorderToDelivery converts result of reorder to the response
+func reorderToDelivery(reorder *models.Reorder) order.Reorder {
+	lines := func(modelLines []models.ReorderLine) []order.ReorderLine {
+		res := make([]order.ReorderLine, 0, len(modelLines))
+		for _, line := range modelLines {
+			res = append(res, order.ReorderLine{
+				ItemId:    line.ItemId.String(),
+				Title:     line.Title,
+				Requested: line.Requested,
+				Added:     line.Added,
+				Reason:    string(line.Reason),
+			})
+		}
+		return res
+	}
+	return order.Reorder{
+		CartId:  reorder.CartId.String(),
+		Added:   lines(reorder.Added),
+		Skipped: lines(reorder.Skipped),
+	}
+}
@@ -11,6 +11,7 @@ package delivery
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"net/http/httptest"
 	"testing"
 
@@ -115,12 +116,13 @@ func TestCreateOrder(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockCartUserAddressJson(c, testCartUserAddress, "POST")
 	delivery.CreateOrder(c)
@@ -136,12 +138,13 @@ func TestCreateOrderInternalError(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{Err: fmt.Errorf("test")}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	MockCartUserAddressJson(c, testCartUserAddress, "POST")
 	delivery.CreateOrder(c)
@@ -157,12 +160,13 @@ func TestCreateOrderBadRequestError(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testCartUserAddress.Cart = testOrderCartWrongID
 	MockCartUserAddressJson(c, testCartUserAddress, "POST")
@@ -179,12 +183,13 @@ func TestDeleteOrder(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -205,12 +210,13 @@ func TestDeleteOrderBadRequest(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -231,12 +237,13 @@ func TestDeleteOrderInternalError(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{Err: fmt.Errorf("internal error")}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -257,12 +264,13 @@ func TestGetOrder(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -283,12 +291,13 @@ func TestGetOrderBadRequest(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -309,12 +318,13 @@ func TestGetOrderInternal(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{Err: fmt.Errorf("internal error")}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -335,12 +345,13 @@ func TestGetOrderForUser(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -365,12 +376,13 @@ func TestGetOrderForUserBadRequest(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -392,12 +404,13 @@ func TestGetOrderForUserInternal(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{Err: fmt.Errorf("inetrnal error")}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	c.Params = []gin.Param{
 		{
@@ -419,12 +432,13 @@ func TestChangeAddress(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testAddressWithUserAndId.User.Role = "admin"
 	MockCartUserAddressJson(c, testAddressWithUserAndId, "PATCH")
@@ -441,12 +455,13 @@ func TestChangeAddressForbidden(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testAddressWithUserAndId.User.Role = "user"
 	MockCartUserAddressJson(c, testAddressWithUserAndId, "PATCH")
@@ -463,12 +478,13 @@ func TestChangeAddressBadRequst(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testAddressWithUserAndId.User.Role = "admin"
 	testAddressWithUserAndId.User.Id = "wrong"
@@ -486,12 +502,13 @@ func TestChangeAddressInternal(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{Err: fmt.Errorf("Internal Error")}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testAddressWithUserAndId.User.Role = "admin"
 	MockCartUserAddressJson(c, testAddressWithUserAndId, "PATCH")
@@ -508,12 +525,13 @@ func TestChangeAddressInternal(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testStatusWithUSerAndId.User.Role = "admin"
 	MockCartUserAddressJson(c, testStatusWithUSerAndId, "PATCH")
@@ -530,12 +548,13 @@ func TestChangeAddressInternal(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testStatusWithUSerAndId.User.Role = "user"
 	MockCartUserAddressJson(c, testStatusWithUSerAndId, "PATCH")
@@ -552,12 +571,13 @@ func TestChangeStatusBadRequest(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	testStatusWithUSerAndId.User.Role = "admin"
@@ -579,12 +599,13 @@ func TestChangeStatusInternal(t *testing.T) {
 	cartUsecase := mocks.NewMockICartUsecase(ctrl)
 	filestorage := fs.NewMockFileStorager(ctrl)
 	orderUsecase := &mocks.OrderUsecaseMock{Err: fmt.Errorf("test error")}
-	delivery := NewDelivery(itemUsecase, categoryUsecase, cartUsecase, logger, filestorage, orderUsecase)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Cart: cartUsecase, Order: orderUsecase}, logger, filestorage, nil)
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
 	c.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 	testStatusWithUSerAndId.User.Role = "admin"
 	MockCartUserAddressJson(c, testStatusWithUSerAndId, "PATCH")
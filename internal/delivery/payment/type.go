@@ -0,0 +1,31 @@
+package payment
+
+import "time"
+
+// Payment is a structure for displaying payment of the order, amounts
+// are in minor units of the currency
+type Payment struct {
+	Id              string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	OrderId         string    `json:"orderId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Provider        string    `json:"provider" example:"fake"`
+	Status          string    `json:"status" example:"authorized" enums:"pending,authorized,captured,failed,refunded"`
+	Amount          int64     `json:"amount" example:"100000"`
+	Refunded        int64     `json:"refunded" example:"0"`
+	Currency        string    `json:"currency" example:"RUB"`
+	FormattedAmount string    `json:"formattedAmount" example:"1000.00 RUB"`
+	RedirectURL     string    `json:"redirectUrl,omitempty" example:"https://pay.example.com/confirm"`
+	FailureReason   string    `json:"failureReason,omitempty" example:"insufficient funds"`
+	CreatedAt       time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt       time.Time `json:"updatedAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// PaymentsList is a structure for list of payments of the order
+type PaymentsList struct {
+	List []Payment `json:"payments"`
+}
+
+// Refund is a structure for refund of the payment, zero amount refunds
+// everything not refunded yet
+type Refund struct {
+	Amount int64 `json:"amount" binding:"min=0" example:"50000" minimum:"0"`
+}
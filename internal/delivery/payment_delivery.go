@@ -0,0 +1,251 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/payment"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PayOrder - start payment of the order
+//
+//	@Summary		Pay order
+//	@Description	Method provides to pay the order of the user through the payment provider. Money of the order total is
+//	@Description	authorized at once or after confirmation of the buyer on redirectUrl. If the order already has payment
+//	@Description	in progress, it is returned again. Status of the order is changed with status of the payment.
+//	@Tags			payments
+//	@Produce		json
+//	@Param			orderID	path		string	true	"id of order"
+//	@Success		201		{object}	payment.Payment
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422		{object}	ErrorResponse	"Order can't be paid in current status"
+//	@Failure		500		{object}	ErrorResponse
+//	@Failure		502		{object}	ErrorResponse	"Payment provider error"
+//	@Router			/order/{orderID}/pay [post]
+func (delivery *Delivery) PayOrder(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery PayOrder()")
+	userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+	if !ok {
+		err := fmt.Errorf("incorrect claims")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPayment, err := delivery.paymentUsecase.Pay(c.Request.Context(), orderId, userCr.UserId)
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("order with id: %v not found", orderId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrPaymentNotAllowed):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+		return
+	case errors.Is(err, models.ErrPaymentProvider):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadGateway, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, paymentToDelivery(modelPayment))
+}
+
+// OrderPayments - returns payments of the order
+//
+//	@Summary		Get payments of order
+//	@Description	Method provides to get all payments of the order, newest first.
+//	@Tags			payments
+//	@Produce		json
+//	@Param			orderID	path		string	true	"id of order"
+//	@Success		200		{object}	payment.PaymentsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/payments/order/{orderID} [get]
+func (delivery *Delivery) OrderPayments(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery OrderPayments()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	payments, err := delivery.paymentUsecase.GetOrderPayments(c.Request.Context(), orderId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := payment.PaymentsList{List: make([]payment.Payment, 0, len(payments))}
+	for i := range payments {
+		list.List = append(list.List, paymentToDelivery(&payments[i]))
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// CapturePayment - charge authorized payment
+//
+//	@Summary		Capture payment
+//	@Description	Method provides to charge money reserved by the authorized payment, the order becomes paid.
+//	@Tags			payments
+//	@Produce		json
+//	@Param			paymentID	path		string	true	"id of payment"
+//	@Success		200			{object}	payment.Payment
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Payment is not authorized"
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		502			{object}	ErrorResponse	"Payment provider error"
+//	@Router			/payments/capture/{paymentID} [post]
+func (delivery *Delivery) CapturePayment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CapturePayment()")
+	id, err := uuid.Parse(c.Param("paymentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPayment, err := delivery.paymentUsecase.Capture(c.Request.Context(), id)
+	if delivery.paymentError(c, id, err) {
+		return
+	}
+	c.JSON(http.StatusOK, paymentToDelivery(modelPayment))
+}
+
+// RefundPayment - return money of captured payment
+//
+//	@Summary		Refund payment
+//	@Description	Method provides to return the amount of the captured payment, zero amount returns everything not
+//	@Description	refunded yet. The order becomes refunded when the payment is fully refunded.
+//	@Tags			payments
+//	@Accept			json
+//	@Produce		json
+//	@Param			paymentID	path		string			true	"id of payment"
+//	@Param			refund		body		payment.Refund	true	"Amount of refund"
+//	@Success		200			{object}	payment.Payment
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Payment is not captured or amount is too big"
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		502			{object}	ErrorResponse	"Payment provider error"
+//	@Router			/payments/refund/{paymentID} [post]
+func (delivery *Delivery) RefundPayment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RefundPayment()")
+	id, err := uuid.Parse(c.Param("paymentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var refund payment.Refund
+	if err := c.ShouldBindJSON(&refund); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPayment, err := delivery.paymentUsecase.Refund(c.Request.Context(), id, refund.Amount)
+	if delivery.paymentError(c, id, err) {
+		return
+	}
+	c.JSON(http.StatusOK, paymentToDelivery(modelPayment))
+}
+
+// PaymentWebhook - receive event of the payment provider
+//
+//	@Summary		Webhook of payment provider
+//	@Description	Method receives changes of payments from the payment provider. Webhooks are verified by signature,
+//	@Description	repeated and late events are accepted without changes.
+//	@Tags			payments
+//	@Accept			json
+//	@Produce		json
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse	"Invalid signature"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/payments/webhook [post]
+func (delivery *Delivery) PaymentWebhook(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery PaymentWebhook()")
+	payload, err := c.GetRawData()
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.paymentUsecase.HandleWebhook(c.Request.Context(), payload, c.Request.Header)
+	switch {
+	case errors.Is(err, models.ErrInvalidSignature):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnauthorized, err)
+		return
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("payment of webhook not found")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// paymentError writes to the response error of operation with the payment,
+// true is returned if there was an error
+func (delivery *Delivery) paymentError(c *gin.Context, id uuid.UUID, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("payment with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+	case errors.Is(err, models.ErrPaymentNotAllowed):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, models.ErrPaymentProvider):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadGateway, err)
+	default:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+	}
+	return true
+}
+
+func paymentToDelivery(modelPayment *models.Payment) payment.Payment {
+	return payment.Payment{
+		Id:              modelPayment.Id.String(),
+		OrderId:         modelPayment.OrderId.String(),
+		Provider:        modelPayment.Provider,
+		Status:          string(modelPayment.Status),
+		Amount:          modelPayment.Amount,
+		Refunded:        modelPayment.Refunded,
+		Currency:        modelPayment.Currency,
+		FormattedAmount: models.Money{Amount: modelPayment.Amount, Currency: modelPayment.Currency}.Format(),
+		RedirectURL:     modelPayment.RedirectURL,
+		FailureReason:   modelPayment.FailureReason,
+		CreatedAt:       modelPayment.CreatedAt,
+		UpdatedAt:       modelPayment.UpdatedAt,
+	}
+}
@@ -0,0 +1,129 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/payment"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPayOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	paymentUsecase := mocks.NewMockIPaymentUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Payment: paymentUsecase}, logger, nil, nil)
+	userId := uuid.New()
+	newContext := func(orderId string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "orderID",
+				Value: orderId,
+			},
+		}
+		c.Set("claims", &jwtauth.Payload{UserId: userId})
+		return w, c
+	}
+
+	w, c := newContext("1")
+	delivery.PayOrder(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{fmt.Errorf("%w: order is delivered", models.ErrPaymentNotAllowed), 422},
+		{fmt.Errorf("%w: timeout", models.ErrPaymentProvider), 502},
+		{fmt.Errorf("error"), 500},
+	}
+	for _, test := range tests {
+		w, c = newContext(testId.String())
+		paymentUsecase.EXPECT().Pay(ctx, testId, userId).Return(nil, test.err)
+		delivery.PayOrder(c)
+		require.Equal(t, test.code, w.Code)
+	}
+
+	paymentId := uuid.New()
+	w, c = newContext(testId.String())
+	paymentUsecase.EXPECT().Pay(ctx, testId, userId).Return(&models.Payment{
+		Id:          paymentId,
+		OrderId:     testId,
+		Provider:    "fake",
+		Amount:      100000,
+		Currency:    "RUB",
+		Status:      models.PaymentAuthorized,
+		RedirectURL: "https://pay.example.com/confirm",
+	}, nil)
+	delivery.PayOrder(c)
+	require.Equal(t, 201, w.Code)
+	var result payment.Payment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, payment.Payment{
+		Id:              paymentId.String(),
+		OrderId:         testId.String(),
+		Provider:        "fake",
+		Status:          "authorized",
+		Amount:          100000,
+		Currency:        "RUB",
+		FormattedAmount: "1000.00 RUB",
+		RedirectURL:     "https://pay.example.com/confirm",
+	}, result)
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	paymentUsecase := mocks.NewMockIPaymentUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Payment: paymentUsecase}, logger, nil, nil)
+	payload := []byte(`{"id":"evt_1","paymentId":"fake_1","status":"captured"}`)
+	header := http.Header{"X-Fake-Signature": []string{"signature"}}
+	newContext := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: header,
+			Body:   io.NopCloser(bytes.NewReader(payload)),
+		}
+		return w, c
+	}
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrInvalidSignature, 401},
+		{models.ErrorNotFound{}, 404},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	for _, test := range tests {
+		w, c := newContext()
+		paymentUsecase.EXPECT().HandleWebhook(ctx, payload, header).Return(test.err)
+		delivery.PaymentWebhook(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
@@ -0,0 +1,38 @@
+package promotion
+
+import "time"
+
+// ShortPromotion is a structure for creating and updating promotion,
+// promotion without code is applied automatically
+type ShortPromotion struct {
+	Code         string     `json:"code,omitempty" binding:"max=64" example:"SPRING10"`
+	Name         string     `json:"name" binding:"required,max=256" example:"Spring sale"`
+	Kind         string     `json:"kind" binding:"required,oneof=percent fixed" example:"percent" enums:"percent,fixed"`
+	Value        int64      `json:"value" binding:"required,min=1" example:"10" minimum:"1"`
+	Scope        string     `json:"scope" binding:"required,oneof=cart item category" example:"cart" enums:"cart,item,category"`
+	ScopeId      string     `json:"scopeId,omitempty" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	MinOrder     int64      `json:"minOrder" binding:"min=0" example:"10000" minimum:"0"`
+	UsageLimit   int        `json:"usageLimit" binding:"min=0" example:"100" minimum:"0"`
+	PerUserLimit int        `json:"perUserLimit" binding:"min=0" example:"1" minimum:"0"`
+	StartsAt     *time.Time `json:"startsAt,omitempty" example:"2023-03-01T00:00:00Z"`
+	EndsAt       *time.Time `json:"endsAt,omitempty" example:"2023-06-01T00:00:00Z"`
+	Active       bool       `json:"active" example:"true"`
+}
+
+// PromotionId is a structure for result of creating promotion
+type PromotionId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Promotion is a structure for displaying promotion
+type Promotion struct {
+	Id string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ShortPromotion
+	Used      int       `json:"used" example:"3"`
+	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// PromotionsList is a structure for list of promotions
+type PromotionsList struct {
+	List []Promotion `json:"promotions"`
+}
@@ -0,0 +1,288 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/promotion"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreatePromotion - create new promotion
+//
+//	@Summary		Create promotion
+//	@Description	Method provides to create promotion with percentage or fixed discount of the whole cart, the item or items of the category.
+//	@Description	Promotion without code is applied automatically to every cart matching its conditions. Zero limits mean unlimited usages.
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotion	body		promotion.ShortPromotion	true	"Data for creating promotion"
+//	@Success		201			{object}	promotion.PromotionId
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/promotions/create [post]
+func (delivery *Delivery) CreatePromotion(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreatePromotion()")
+	var shortPromotion promotion.ShortPromotion
+	if err := c.ShouldBindJSON(&shortPromotion); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPromotion, err := promotionFromDelivery(uuid.Nil, shortPromotion)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	id, err := delivery.promotionUsecase.CreatePromotion(c.Request.Context(), modelPromotion)
+	if err != nil && errors.Is(err, models.ErrInvalidPromotion) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, promotion.PromotionId{Value: id.String()})
+}
+
+// UpdatePromotion - update promotion
+//
+//	@Summary		Update promotion
+//	@Description	Method provides to change settings of the promotion, the number of usages is kept.
+//	@Tags			promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotionID	path	string						true	"id of promotion"
+//	@Param			promotion	body	promotion.ShortPromotion	true	"Data for updating promotion"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/promotions/update/{promotionID} [put]
+func (delivery *Delivery) UpdatePromotion(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UpdatePromotion()")
+	id, err := uuid.Parse(c.Param("promotionID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var shortPromotion promotion.ShortPromotion
+	if err := c.ShouldBindJSON(&shortPromotion); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPromotion, err := promotionFromDelivery(id, shortPromotion)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.promotionUsecase.UpdatePromotion(c.Request.Context(), modelPromotion)
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("promotion with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidPromotion):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetPromotion - returns promotion by id
+//
+//	@Summary		Get promotion by id
+//	@Description	Method provides to get promotion by id.
+//	@Tags			promotions
+//	@Produce		json
+//	@Param			promotionID	path		string	true	"id of promotion"
+//	@Success		200			{object}	promotion.Promotion
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/promotions/{promotionID} [get]
+func (delivery *Delivery) GetPromotion(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetPromotion()")
+	id, err := uuid.Parse(c.Param("promotionID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelPromotion, err := delivery.promotionUsecase.GetPromotion(c.Request.Context(), id)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("promotion with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, promotionToDelivery(modelPromotion))
+}
+
+// PromotionsList - returns all promotions
+//
+//	@Summary		Get list of promotions
+//	@Description	Method provides to get all promotions, newest first.
+//	@Tags			promotions
+//	@Produce		json
+//	@Success		200	{object}	promotion.PromotionsList
+//	@Failure		403	"Forbidden"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/promotions/list [get]
+func (delivery *Delivery) PromotionsList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery PromotionsList()")
+	promotions, err := delivery.promotionUsecase.GetPromotions(c.Request.Context())
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := promotion.PromotionsList{List: make([]promotion.Promotion, 0, len(promotions))}
+	for i := range promotions {
+		list.List = append(list.List, promotionToDelivery(&promotions[i]))
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// DeletePromotion - delete promotion by id
+//
+//	@Summary		Delete promotion
+//	@Description	Method provides to delete promotion, discounts of placed orders are kept.
+//	@Tags			promotions
+//	@Produce		json
+//	@Param			promotionID	path	string	true	"id of promotion"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/promotions/delete/{promotionID} [delete]
+func (delivery *Delivery) DeletePromotion(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeletePromotion()")
+	id, err := uuid.Parse(c.Param("promotionID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.promotionUsecase.DeletePromotion(c.Request.Context(), id)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("promotion with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func promotionFromDelivery(id uuid.UUID, shortPromotion promotion.ShortPromotion) (*models.Promotion, error) {
+	modelPromotion := &models.Promotion{
+		Id:           id,
+		Code:         shortPromotion.Code,
+		Name:         shortPromotion.Name,
+		Kind:         models.DiscountKind(shortPromotion.Kind),
+		Value:        shortPromotion.Value,
+		Scope:        models.DiscountScope(shortPromotion.Scope),
+		MinOrder:     shortPromotion.MinOrder,
+		UsageLimit:   shortPromotion.UsageLimit,
+		PerUserLimit: shortPromotion.PerUserLimit,
+		Active:       shortPromotion.Active,
+	}
+	if shortPromotion.ScopeId != "" {
+		scopeId, err := uuid.Parse(shortPromotion.ScopeId)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse scope id: %w", err)
+		}
+		modelPromotion.ScopeId = scopeId
+	}
+	if shortPromotion.StartsAt != nil {
+		modelPromotion.StartsAt = *shortPromotion.StartsAt
+	}
+	if shortPromotion.EndsAt != nil {
+		modelPromotion.EndsAt = *shortPromotion.EndsAt
+	}
+	return modelPromotion, nil
+}
+
+func promotionToDelivery(modelPromotion *models.Promotion) promotion.Promotion {
+	result := promotion.Promotion{
+		Id: modelPromotion.Id.String(),
+		ShortPromotion: promotion.ShortPromotion{
+			Code:         modelPromotion.Code,
+			Name:         modelPromotion.Name,
+			Kind:         string(modelPromotion.Kind),
+			Value:        modelPromotion.Value,
+			Scope:        string(modelPromotion.Scope),
+			MinOrder:     modelPromotion.MinOrder,
+			UsageLimit:   modelPromotion.UsageLimit,
+			PerUserLimit: modelPromotion.PerUserLimit,
+			StartsAt:     optionalTime(modelPromotion.StartsAt),
+			EndsAt:       optionalTime(modelPromotion.EndsAt),
+			Active:       modelPromotion.Active,
+		},
+		Used:      modelPromotion.Used,
+		CreatedAt: modelPromotion.CreatedAt,
+	}
+	if modelPromotion.ScopeId != uuid.Nil {
+		result.ScopeId = modelPromotion.ScopeId.String()
+	}
+	return result
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// discountsToDelivery converts discounts of the cart or order
+func discountsToDelivery(discounts []models.AppliedDiscount, currency string) []cart.Discount {
+	if len(discounts) == 0 {
+		return nil
+	}
+	result := make([]cart.Discount, 0, len(discounts))
+	for _, discount := range discounts {
+		result = append(result, cart.Discount{
+			Code:      discount.Code,
+			Name:      discount.Name,
+			Amount:    discount.Amount,
+			Formatted: models.Money{Amount: discount.Amount, Currency: currency}.Format(),
+		})
+		if discount.PromotionId != uuid.Nil {
+			result[len(result)-1].PromotionId = discount.PromotionId.String()
+		}
+	}
+	return result
+}
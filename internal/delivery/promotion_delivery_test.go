@@ -0,0 +1,295 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/promotion"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	promotionUsecase := mocks.NewMockIPromotionUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Promotion: promotionUsecase}, logger, nil, nil)
+	newContext := func(content interface{}) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		MockJson(c, content, "POST")
+		return w, c
+	}
+	endsAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	shortPromotion := promotion.ShortPromotion{
+		Code:    "spring",
+		Name:    "Spring",
+		Kind:    "percent",
+		Value:   15,
+		Scope:   "category",
+		ScopeId: testId.String(),
+		EndsAt:  &endsAt,
+		Active:  true,
+	}
+
+	w, c := newContext(promotion.ShortPromotion{Name: "Spring", Kind: "gift", Value: 15, Scope: "cart"})
+	delivery.CreatePromotion(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: empty name", models.ErrInvalidPromotion), 400},
+		{fmt.Errorf("error"), 500},
+		{nil, 201},
+	}
+	for _, test := range tests {
+		w, c = newContext(shortPromotion)
+		promotionUsecase.EXPECT().CreatePromotion(ctx, &models.Promotion{
+			Code:    "spring",
+			Name:    "Spring",
+			Kind:    models.DiscountPercent,
+			Value:   15,
+			Scope:   models.ScopeCategory,
+			ScopeId: testId,
+			EndsAt:  endsAt,
+			Active:  true,
+		}).Return(testId2, test.err)
+		delivery.CreatePromotion(c)
+		require.Equal(t, test.code, w.Code)
+	}
+	var id promotion.PromotionId
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &id))
+	require.Equal(t, testId2.String(), id.Value)
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	promotionUsecase := mocks.NewMockIPromotionUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Promotion: promotionUsecase}, logger, nil, nil)
+	newContext := func(promotionId string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "promotionID",
+				Value: promotionId,
+			},
+		}
+		MockJson(c, promotion.ShortPromotion{Name: "Fixed", Kind: "fixed", Value: 500, Scope: "cart", MinOrder: 3000}, "PUT")
+		return w, c
+	}
+
+	w, c := newContext("1")
+	delivery.UpdatePromotion(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{models.ErrInvalidPromotion, 400},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	for _, test := range tests {
+		w, c = newContext(testId.String())
+		promotionUsecase.EXPECT().UpdatePromotion(ctx, &models.Promotion{
+			Id:       testId,
+			Name:     "Fixed",
+			Kind:     models.DiscountFixed,
+			Value:    500,
+			Scope:    models.ScopeCart,
+			MinOrder: 3000,
+		}).Return(test.err)
+		delivery.UpdatePromotion(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
+
+func TestGetPromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	promotionUsecase := mocks.NewMockIPromotionUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Promotion: promotionUsecase}, logger, nil, nil)
+	newContext := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "promotionID",
+				Value: testId.String(),
+			},
+		}
+		return w, c
+	}
+
+	w, c := newContext()
+	promotionUsecase.EXPECT().GetPromotion(ctx, testId).Return(nil, models.ErrorNotFound{})
+	delivery.GetPromotion(c)
+	require.Equal(t, 404, w.Code)
+
+	createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	w, c = newContext()
+	promotionUsecase.EXPECT().GetPromotion(ctx, testId).Return(&models.Promotion{
+		Id:           testId,
+		Name:         "Automatic",
+		Kind:         models.DiscountFixed,
+		Value:        100,
+		Scope:        models.ScopeCart,
+		PerUserLimit: 1,
+		Used:         2,
+		Active:       true,
+		CreatedAt:    createdAt,
+	}, nil)
+	delivery.GetPromotion(c)
+	require.Equal(t, 200, w.Code)
+	require.JSONEq(t, `{"id":"`+testId.String()+`","name":"Automatic","kind":"fixed","value":100,"scope":"cart","minOrder":0,
+		"usageLimit":0,"perUserLimit":1,"active":true,"used":2,"createdAt":"2023-01-01T12:00:00Z"}`, w.Body.String())
+
+	w, c = newContext()
+	promotionUsecase.EXPECT().GetPromotions(ctx).Return(nil, fmt.Errorf("error"))
+	delivery.PromotionsList(c)
+	require.Equal(t, 500, w.Code)
+
+	w, c = newContext()
+	promotionUsecase.EXPECT().GetPromotions(ctx).Return([]models.Promotion{{Id: testId}, {Id: testId2}}, nil)
+	delivery.PromotionsList(c)
+	require.Equal(t, 200, w.Code)
+	var list promotion.PromotionsList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.List, 2)
+	require.Equal(t, testId2.String(), list.List[1].Id)
+
+	w, c = newContext()
+	promotionUsecase.EXPECT().DeletePromotion(ctx, testId).Return(models.ErrorNotFound{})
+	delivery.DeletePromotion(c)
+	require.Equal(t, 404, w.Code)
+
+	w, c = newContext()
+	promotionUsecase.EXPECT().DeletePromotion(ctx, testId).Return(nil)
+	delivery.DeletePromotion(c)
+	require.Equal(t, 200, w.Code)
+}
+
+func TestApplyPromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase}, logger, nil, nil)
+	newContext := func(content interface{}) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		MockJson(c, content, "PUT")
+		return w, c
+	}
+
+	w, c := newContext(cart.PromoCode{CartId: testCartId.String()})
+	delivery.ApplyPromoCode(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{models.ErrPromoExpired, 422},
+		{fmt.Errorf("error"), 500},
+	}
+	for _, test := range tests {
+		w, c = newContext(cart.PromoCode{CartId: testCartId.String(), Code: "sale"})
+		cartUsecase.EXPECT().ApplyPromoCode(ctx, testCartId, "sale").Return(nil, test.err)
+		delivery.ApplyPromoCode(c)
+		require.Equal(t, test.code, w.Code)
+	}
+
+	promotionId := uuid.New()
+	w, c = newContext(cart.PromoCode{CartId: testCartId.String(), Code: "sale"})
+	cartUsecase.EXPECT().ApplyPromoCode(ctx, testCartId, "sale").Return(&models.Cart{
+		Id:        testCartId,
+		UserId:    testUserId,
+		Items:     []models.ItemWithQuantity{},
+		PromoCode: "SALE",
+		Pricing: models.Pricing{
+			Currency:  "RUB",
+			Subtotal:  1000,
+			Discounts: []models.AppliedDiscount{{PromotionId: promotionId, Code: "SALE", Name: "Sale", Amount: 200}},
+			Total:     800,
+		},
+	}, nil)
+	delivery.ApplyPromoCode(c)
+	require.Equal(t, 200, w.Code)
+	var result cart.Cart
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, cart.Cart{
+		Id:        testCartId.String(),
+		UserId:    testUserId.String(),
+		Items:     []cart.CartItem{},
+		PromoCode: "SALE",
+		Totals: cart.Totals{
+			Currency:          "RUB",
+			Subtotal:          1000,
+			FormattedSubtotal: "10.00 RUB",
+			Discounts: []cart.Discount{{
+				PromotionId: promotionId.String(), Code: "SALE", Name: "Sale", Amount: 200, Formatted: "2.00 RUB",
+			}},
+			Total:          800,
+			FormattedTotal: "8.00 RUB",
+		},
+	}, result)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	c.Params = []gin.Param{
+		{
+			Key:   "cartID",
+			Value: testCartId.String(),
+		},
+	}
+	cartUsecase.EXPECT().RemovePromoCode(ctx, testCartId).Return(nil)
+	delivery.RemovePromoCode(c)
+	require.Equal(t, 200, w.Code)
+}
@@ -0,0 +1,356 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/returns"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestReturn - request return of the line of the order
+//
+//	@Summary		Request return
+//	@Description	Method provides to request return of the quantity of the line of the delivered order with the reason.
+//	@Description	The line can be returned by several requests while its quantity isn't exhausted.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID	path		string					true	"id of order"
+//	@Param			return	body		returns.ReturnRequest	true	"Line, quantity and reason of return"
+//	@Success		201		{object}	returns.ReturnId
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422		{object}	ErrorResponse	"Order isn't delivered or quantity is too big"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/returns/create/{orderID} [post]
+func (delivery *Delivery) RequestReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RequestReturn()")
+	userId, ok := delivery.claimsUser(c)
+	if !ok {
+		return
+	}
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var request returns.ReturnRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	itemId, err := uuid.Parse(request.ItemId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	id, err := delivery.returnUsecase.RequestReturn(c.Request.Context(), &models.Return{
+		OrderId:  orderId,
+		UserId:   userId,
+		ItemId:   itemId,
+		Quantity: request.Quantity,
+		Reason:   request.Reason,
+	})
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.JSON(http.StatusCreated, returns.ReturnId{Value: id.String()})
+}
+
+// OrderReturns - get return requests of the order
+//
+//	@Summary		Get returns of order
+//	@Description	Method provides to get return requests of the order of the user with their statuses.
+//	@Tags			returns
+//	@Produce		json
+//	@Param			orderID	path		string	true	"id of order"
+//	@Success		200		{object}	returns.ReturnsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/returns/order/{orderID} [get]
+func (delivery *Delivery) OrderReturns(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery OrderReturns()")
+	userId, ok := delivery.claimsUser(c)
+	if !ok {
+		return
+	}
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelReturns, err := delivery.returnUsecase.GetOrderReturns(c.Request.Context(), userId, orderId)
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, returnsToDelivery(modelReturns))
+}
+
+// ReturnsList - get return requests for processing
+//
+//	@Summary		Get returns
+//	@Description	Method provides to get return requests in the status, all requests are returned without status.
+//	@Tags			returns
+//	@Produce		json
+//	@Param			status	query		string	false	"status of returns"	Enums(requested,approved,rejected,received,refunding,refunded)
+//	@Success		200		{object}	returns.ReturnsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/returns/list [get]
+func (delivery *Delivery) ReturnsList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ReturnsList()")
+	modelReturns, err := delivery.returnUsecase.GetReturns(c.Request.Context(), models.ReturnStatus(c.Query("status")))
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, returnsToDelivery(modelReturns))
+}
+
+// ApproveReturn - approve requested return
+//
+//	@Summary		Approve return
+//	@Description	Method provides to approve requested return with the comment for the buyer.
+//	@Tags			returns
+//	@Accept			json
+//	@Param			returnID	path	string				true	"id of return"
+//	@Param			decision	body	returns.Decision	false	"Comment for the buyer"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Return isn't requested"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/returns/approve/{returnID} [put]
+func (delivery *Delivery) ApproveReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ApproveReturn()")
+	id, decision, ok := delivery.returnDecision(c)
+	if !ok {
+		return
+	}
+	err := delivery.returnUsecase.ApproveReturn(c.Request.Context(), id, decision.Comment)
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// RejectReturn - reject requested return
+//
+//	@Summary		Reject return
+//	@Description	Method provides to reject requested return with the comment for the buyer.
+//	@Tags			returns
+//	@Accept			json
+//	@Param			returnID	path	string				true	"id of return"
+//	@Param			decision	body	returns.Decision	false	"Comment for the buyer"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Return isn't requested"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/returns/reject/{returnID} [put]
+func (delivery *Delivery) RejectReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RejectReturn()")
+	id, decision, ok := delivery.returnDecision(c)
+	if !ok {
+		return
+	}
+	err := delivery.returnUsecase.RejectReturn(c.Request.Context(), id, decision.Comment)
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// ReceiveReturn - receive items of approved return
+//
+//	@Summary		Receive return
+//	@Description	Method provides to mark items of the approved return as received, returned quantity is restocked.
+//	@Tags			returns
+//	@Param			returnID	path	string	true	"id of return"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Return isn't approved"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/returns/receive/{returnID} [put]
+func (delivery *Delivery) ReceiveReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ReceiveReturn()")
+	id, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.returnUsecase.ReceiveReturn(c.Request.Context(), id)
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// RefundReturn - refund received return
+//
+//	@Summary		Refund return
+//	@Description	Method provides to refund the amount for the received return. The amount is refunded through
+//	@Description	the captured payment of the order, offline refund is only recorded. Refunds of the order
+//	@Description	with refunds of its payments can't exceed its total.
+//	@Tags			returns
+//	@Accept			json
+//	@Produce		json
+//	@Param			returnID	path		string					true	"id of return"
+//	@Param			refund		body		returns.RefundRequest	true	"Amount of refund"
+//	@Success		201			{object}	returns.Refund
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Return isn't received, amount is too big or no payment can refund it"
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		502			{object}	ErrorResponse	"Payment provider error"
+//	@Router			/returns/refund/{returnID} [post]
+func (delivery *Delivery) RefundReturn(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RefundReturn()")
+	id, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var request returns.RefundRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	refund, err := delivery.returnUsecase.RefundReturn(c.Request.Context(), id, request.Amount, request.Offline)
+	if delivery.returnError(c, err) {
+		return
+	}
+	c.JSON(http.StatusCreated, refundToDelivery(refund))
+}
+
+// OrderRefunds - get refunds of the order
+//
+//	@Summary		Get refunds of order
+//	@Description	Method provides to get refunds of the order for reconciliation.
+//	@Tags			returns
+//	@Produce		json
+//	@Param			orderID	path		string	true	"id of order"
+//	@Success		200		{object}	returns.RefundsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/returns/refunds/{orderID} [get]
+func (delivery *Delivery) OrderRefunds(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery OrderRefunds()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	refunds, err := delivery.returnUsecase.GetOrderRefunds(c.Request.Context(), orderId)
+	if delivery.returnError(c, err) {
+		return
+	}
+	list := returns.RefundsList{List: make([]returns.Refund, 0, len(refunds))}
+	for i := range refunds {
+		list.List = append(list.List, refundToDelivery(&refunds[i]))
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// returnDecision parses id of the return and optional comment of the decision
+func (delivery *Delivery) returnDecision(c *gin.Context) (uuid.UUID, returns.Decision, bool) {
+	var decision returns.Decision
+	id, err := uuid.Parse(c.Param("returnID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return uuid.Nil, decision, false
+	}
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if err := c.ShouldBindJSON(&decision); err != nil {
+			delivery.logger.Error(err.Error())
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return uuid.Nil, decision, false
+		}
+	}
+	return id, decision, true
+}
+
+// returnError writes to the response error of operation with the return,
+// true is returned if there was an error
+func (delivery *Delivery) returnError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, models.ErrorNotFound{}):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+	case errors.Is(err, models.ErrInvalidReturn):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+	case errors.Is(err, models.ErrReturnNotAllowed),
+		errors.Is(err, models.ErrPaymentNotAllowed):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, models.ErrPaymentProvider):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadGateway, err)
+	default:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+	}
+	return true
+}
+
+func returnsToDelivery(modelReturns []models.Return) returns.ReturnsList {
+	list := returns.ReturnsList{List: make([]returns.Return, 0, len(modelReturns))}
+	for _, ret := range modelReturns {
+		list.List = append(list.List, returns.Return{
+			Id:        ret.Id.String(),
+			OrderId:   ret.OrderId.String(),
+			UserId:    ret.UserId.String(),
+			ItemId:    ret.ItemId.String(),
+			Title:     ret.Title,
+			Quantity:  ret.Quantity,
+			Reason:    ret.Reason,
+			Status:    string(ret.Status),
+			Comment:   ret.Comment,
+			CreatedAt: ret.CreatedAt,
+			UpdatedAt: ret.UpdatedAt,
+		})
+	}
+	return list
+}
+
+func refundToDelivery(refund *models.Refund) returns.Refund {
+	result := returns.Refund{
+		Id:              refund.Id.String(),
+		OrderId:         refund.OrderId.String(),
+		Amount:          refund.Amount,
+		Currency:        refund.Currency,
+		FormattedAmount: models.Money{Amount: refund.Amount, Currency: refund.Currency}.Format(),
+		CreatedAt:       refund.CreatedAt,
+	}
+	if refund.ReturnId != uuid.Nil {
+		result.ReturnId = refund.ReturnId.String()
+	}
+	if refund.PaymentId != uuid.Nil {
+		result.PaymentId = refund.PaymentId.String()
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/returns"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRequestReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Return: returnUsecase}, zap.L(), nil, nil)
+	userId := uuid.New()
+	orderId := uuid.New()
+	itemId := uuid.New()
+	request := returns.ReturnRequest{ItemId: itemId.String(), Quantity: 1, Reason: "broken"}
+
+	w, c := newWishlistContext(userId, map[string]string{"orderID": "1"}, request, "POST")
+	delivery.RequestReturn(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, returns.ReturnRequest{
+		ItemId: itemId.String(), Reason: "broken"}, "POST")
+	delivery.RequestReturn(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{models.ErrInvalidReturn, 400},
+		{models.ErrReturnNotAllowed, 422},
+		{fmt.Errorf("error"), 500},
+		{nil, 201},
+	}
+	id := uuid.New()
+	for _, test := range tests {
+		w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, request, "POST")
+		returnUsecase.EXPECT().RequestReturn(ctx, &models.Return{OrderId: orderId, UserId: userId, ItemId: itemId,
+			Quantity: 1, Reason: "broken"}).Return(id, test.err)
+		delivery.RequestReturn(c)
+		require.Equal(t, test.code, w.Code)
+	}
+	var res returns.ReturnId
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, id.String(), res.Value)
+}
+
+func TestApproveReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Return: returnUsecase}, zap.L(), nil, nil)
+	id := uuid.New()
+
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"returnID": "1"}, nil, "")
+	delivery.ApproveReturn(c)
+	require.Equal(t, 400, w.Code)
+
+	// Comment is optional
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"returnID": id.String()}, nil, "")
+	returnUsecase.EXPECT().ApproveReturn(ctx, id, "").Return(nil)
+	delivery.ApproveReturn(c)
+	require.Equal(t, 200, w.Code)
+
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"returnID": id.String()},
+		returns.Decision{Comment: "send it back"}, "PUT")
+	returnUsecase.EXPECT().ApproveReturn(ctx, id, "send it back").Return(models.ErrReturnNotAllowed)
+	delivery.ApproveReturn(c)
+	require.Equal(t, 422, w.Code)
+}
+
+func TestRefundReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	returnUsecase := mocks.NewMockIReturnUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Return: returnUsecase}, zap.L(), nil, nil)
+	id := uuid.New()
+
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"returnID": id.String()}, returns.RefundRequest{}, "POST")
+	delivery.RefundReturn(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{models.ErrReturnNotAllowed, 422},
+		{models.ErrPaymentProvider, 502},
+		{fmt.Errorf("error"), 500},
+	}
+	for _, test := range tests {
+		w, c = newWishlistContext(uuid.Nil, map[string]string{"returnID": id.String()}, returns.RefundRequest{Amount: 500}, "POST")
+		returnUsecase.EXPECT().RefundReturn(ctx, id, int64(500), false).Return(nil, test.err)
+		delivery.RefundReturn(c)
+		require.Equal(t, test.code, w.Code)
+	}
+
+	refund := &models.Refund{Id: uuid.New(), OrderId: uuid.New(), ReturnId: id, Amount: 500, Currency: "RUB"}
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"returnID": id.String()}, returns.RefundRequest{Amount: 500, Offline: true}, "POST")
+	returnUsecase.EXPECT().RefundReturn(ctx, id, int64(500), true).Return(refund, nil)
+	delivery.RefundReturn(c)
+	require.Equal(t, 201, w.Code)
+	var res returns.Refund
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, id.String(), res.ReturnId)
+	require.Empty(t, res.PaymentId)
+	require.Equal(t, "5.00 RUB", res.FormattedAmount)
+}
@@ -0,0 +1,66 @@
+package returns
+
+import "time"
+
+// ReturnRequest is a structure for requesting return of the line of the order
+type ReturnRequest struct {
+	ItemId   string `json:"itemId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Quantity int    `json:"quantity" binding:"required,min=1" example:"1" minimum:"1"`
+	Reason   string `json:"reason" binding:"required,max=1024" example:"Не подошёл размер"`
+}
+
+// ReturnId is a structure for result of requesting return
+type ReturnId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Return is a structure for displaying return request
+type Return struct {
+	Id        string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	OrderId   string    `json:"orderId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserId    string    `json:"userId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ItemId    string    `json:"itemId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Title     string    `json:"title" example:"Кроссовки"`
+	Quantity  int       `json:"quantity" example:"1"`
+	Reason    string    `json:"reason" example:"Не подошёл размер"`
+	Status    string    `json:"status" example:"requested" enums:"requested,approved,rejected,received,refunding,refunded"`
+	Comment   string    `json:"comment,omitempty" example:"Отправьте товар курьером"`
+	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// ReturnsList is a structure for list of return requests
+type ReturnsList struct {
+	List []Return `json:"returns"`
+}
+
+// Decision is a structure for approval or rejection of return with the comment for the buyer
+type Decision struct {
+	Comment string `json:"comment" binding:"max=1024" example:"Отправьте товар курьером"`
+}
+
+// RefundRequest is a structure for refund of received return, amount is
+// in minor units of the currency of the order. Offline refund is made
+// outside of the payment provider and is only recorded
+type RefundRequest struct {
+	Amount  int64 `json:"amount" binding:"required,min=1" example:"50000" minimum:"1"`
+	Offline bool  `json:"offline" example:"false"`
+}
+
+// Refund is a structure for displaying refund of the order, payment id is
+// empty for refunds made outside of the payment provider
+type Refund struct {
+	Id              string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	OrderId         string    `json:"orderId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ReturnId        string    `json:"returnId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	PaymentId       string    `json:"paymentId,omitempty" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Amount          int64     `json:"amount" example:"50000"`
+	Currency        string    `json:"currency" example:"RUB"`
+	FormattedAmount string    `json:"formattedAmount" example:"500.00 RUB"`
+	CreatedAt       time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// RefundsList is a structure for list of refunds of the order
+type RefundsList struct {
+	List []Refund `json:"refunds"`
+}
@@ -0,0 +1,37 @@
+package review
+
+import "time"
+
+// ShortReview is a structure for creating review of the item
+type ShortReview struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5" example:"5" minimum:"1" maximum:"5"`
+	Text   string `json:"text" binding:"max=4000" example:"Мощный и тихий"`
+}
+
+// ReviewId is a structure for result of creating review
+type ReviewId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Review is a structure for displaying review of the item
+type Review struct {
+	Id        string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ItemId    string    `json:"itemId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserId    string    `json:"userId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UserName  string    `json:"userName" example:"Иван"`
+	Rating    int       `json:"rating" example:"5" minimum:"1" maximum:"5"`
+	Text      string    `json:"text" example:"Мощный и тихий"`
+	Status    string    `json:"status" example:"approved" enums:"pending,approved,rejected"`
+	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// ReviewsList is a structure for list of reviews
+type ReviewsList struct {
+	List []Review `json:"reviews"`
+}
+
+// Moderation is a structure for moderation of the review
+type Moderation struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected" example:"approved"`
+}
@@ -0,0 +1,239 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/review"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultReviewsLimit is a limit of reviews list if it is not given
+const defaultReviewsLimit = 20
+
+// ReviewsOptions is the structure for parsing parameters of reviews list
+type ReviewsOptions struct {
+	Offset int    `form:"offset" binding:"min=0"`
+	Limit  int    `form:"limit" binding:"min=0,max=100"`
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+}
+
+// CreateReview - create review of the ordered item
+//
+//	@Summary		Create review of the item
+//	@Description	Method provides to create review of the item with rating from 1 to 5. Only users who ordered the item can review it.
+//	@Description	The previous review of the user is replaced, the review is shown after approval by moderator.
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID	path		string				true	"id of item"
+//	@Param			review	body		review.ShortReview	true	"Data for creating review"
+//	@Success		201		{object}	review.ReviewId
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Item is not ordered by user"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/reviews/create/{itemID} [post]
+func (delivery *Delivery) CreateReview(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateReview()")
+	ctx := c.Request.Context()
+	userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+	if !ok {
+		err := fmt.Errorf("incorrect claims")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var shortReview review.ShortReview
+	if err := c.ShouldBindJSON(&shortReview); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := delivery.reviewUsecase.CreateReview(ctx, &models.Review{
+		ItemId: itemId,
+		UserId: userCr.UserId,
+		Rating: shortReview.Rating,
+		Text:   shortReview.Text,
+	})
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("item with id: %v not found", itemId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrNotBuyer):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusForbidden, err)
+		return
+	case errors.Is(err, models.ErrInvalidRating):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, review.ReviewId{Value: id.String()})
+}
+
+// ItemReviews - returns approved reviews of the item
+//
+//	@Summary		Get reviews of the item
+//	@Description	Method provides to get approved reviews of the item, newest first.
+//	@Tags			reviews
+//	@Produce		json
+//	@Param			itemID	path		string	true	"id of item"
+//	@Param			offset	query		int		false	"Offset when receiving records"	default(0)	mininum(0)
+//	@Param			limit	query		int		false	"Quantity of recordings"		default(20)	minimum(0)	maximum(100)
+//	@Success		200		{object}	review.ReviewsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/reviews/item/{itemID} [get]
+func (delivery *Delivery) ItemReviews(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ItemReviews()")
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	options, ok := delivery.reviewsOptions(c)
+	if !ok {
+		return
+	}
+	reviews, err := delivery.reviewUsecase.GetItemReviews(c.Request.Context(), itemId, options.Offset, options.Limit)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, reviewsToDelivery(reviews))
+}
+
+// ReviewsList - returns reviews of all items for moderation
+//
+//	@Summary		Get reviews for moderation
+//	@Description	Method provides to get reviews of all items with given status, by default reviews waiting for moderation. Reviews are sorted from oldest to newest.
+//	@Tags			reviews
+//	@Produce		json
+//	@Param			status	query		string	false	"Status of reviews"				default(pending)	Enums(pending, approved, rejected)
+//	@Param			offset	query		int		false	"Offset when receiving records"	default(0)			mininum(0)
+//	@Param			limit	query		int		false	"Quantity of recordings"		default(20)			minimum(0)	maximum(100)
+//	@Success		200		{object}	review.ReviewsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/reviews/list [get]
+func (delivery *Delivery) ReviewsList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ReviewsList()")
+	options, ok := delivery.reviewsOptions(c)
+	if !ok {
+		return
+	}
+	if options.Status == "" {
+		options.Status = string(models.ReviewPending)
+	}
+	reviews, err := delivery.reviewUsecase.GetReviews(c.Request.Context(), models.ReviewStatus(options.Status), options.Offset, options.Limit)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, reviewsToDelivery(reviews))
+}
+
+// ModerateReview - approve or reject the review
+//
+//	@Summary		Moderate review
+//	@Description	Method provides to approve or reject the review. Only approved reviews are shown and counted in the rating of the item.
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			reviewID	path	string				true	"id of review"
+//	@Param			moderation	body	review.Moderation	true	"New status of review"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/reviews/moderate/{reviewID} [put]
+func (delivery *Delivery) ModerateReview(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ModerateReview()")
+	id, err := uuid.Parse(c.Param("reviewID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var moderation review.Moderation
+	if err := c.ShouldBindJSON(&moderation); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.reviewUsecase.ModerateReview(c.Request.Context(), id, models.ReviewStatus(moderation.Status))
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("review with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidModeration):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// reviewsOptions binds parameters of reviews list, it sets the
+// error of request and returns false if parameters are invalid
+func (delivery *Delivery) reviewsOptions(c *gin.Context) (ReviewsOptions, bool) {
+	var options ReviewsOptions
+	err := c.ShouldBindQuery(&options)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return options, false
+	}
+	if options.Limit == 0 {
+		options.Limit = defaultReviewsLimit
+	}
+	return options, true
+}
+
+func reviewsToDelivery(reviews []models.Review) review.ReviewsList {
+	list := review.ReviewsList{List: make([]review.Review, 0, len(reviews))}
+	for _, modelsReview := range reviews {
+		list.List = append(list.List, review.Review{
+			Id:        modelsReview.Id.String(),
+			ItemId:    modelsReview.ItemId.String(),
+			UserId:    modelsReview.UserId.String(),
+			UserName:  modelsReview.UserName,
+			Rating:    modelsReview.Rating,
+			Text:      modelsReview.Text,
+			Status:    string(modelsReview.Status),
+			CreatedAt: modelsReview.CreatedAt,
+			UpdatedAt: modelsReview.UpdatedAt,
+		})
+	}
+	return list
+}
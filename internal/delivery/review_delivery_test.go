@@ -0,0 +1,233 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/review"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	reviewUsecase := mocks.NewMockIReviewUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Review: reviewUsecase}, logger, nil, nil)
+	userId := uuid.New()
+	newContext := func(itemId string, content interface{}) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "itemID",
+				Value: itemId,
+			},
+		}
+		c.Set("claims", &jwtauth.Payload{UserId: userId})
+		MockJson(c, content, "POST")
+		return w, c
+	}
+
+	w, c := newContext("1", review.ShortReview{Rating: 5})
+	delivery.CreateReview(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(testId.String(), review.ShortReview{Rating: 6})
+	delivery.CreateReview(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{fmt.Errorf("error on check: %w", models.ErrNotBuyer), 403},
+		{fmt.Errorf("error"), 500},
+		{nil, 201},
+	}
+	reviewId := uuid.New()
+	for _, test := range tests {
+		w, c = newContext(testId.String(), review.ShortReview{Rating: 4, Text: "Good"})
+		reviewUsecase.EXPECT().CreateReview(ctx, &models.Review{
+			ItemId: testId,
+			UserId: userId,
+			Rating: 4,
+			Text:   "Good",
+		}).Return(reviewId, test.err)
+		delivery.CreateReview(c)
+		require.Equal(t, test.code, w.Code)
+	}
+	var id review.ReviewId
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &id))
+	require.Equal(t, reviewId.String(), id.Value)
+}
+
+func TestItemReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	reviewUsecase := mocks.NewMockIReviewUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Review: reviewUsecase}, logger, nil, nil)
+	newContext := func(itemId string, query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		c.Request.URL, _ = url.Parse(query)
+		c.Params = []gin.Param{
+			{
+				Key:   "itemID",
+				Value: itemId,
+			},
+		}
+		return w, c
+	}
+
+	w, c := newContext("1", "")
+	delivery.ItemReviews(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(testId.String(), "?limit=1000")
+	delivery.ItemReviews(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(testId.String(), "")
+	reviewUsecase.EXPECT().GetItemReviews(ctx, testId, 0, defaultReviewsLimit).Return(nil, fmt.Errorf("error"))
+	delivery.ItemReviews(c)
+	require.Equal(t, 500, w.Code)
+
+	createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	reviewId, userId := uuid.New(), uuid.New()
+	w, c = newContext(testId.String(), "?offset=10&limit=5")
+	reviewUsecase.EXPECT().GetItemReviews(ctx, testId, 10, 5).Return([]models.Review{
+		{
+			Id:        reviewId,
+			ItemId:    testId,
+			UserId:    userId,
+			UserName:  "Ivan",
+			Rating:    5,
+			Text:      "Good",
+			Status:    models.ReviewApproved,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+	}, nil)
+	delivery.ItemReviews(c)
+	require.Equal(t, 200, w.Code)
+	var list review.ReviewsList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, review.ReviewsList{List: []review.Review{
+		{
+			Id:        reviewId.String(),
+			ItemId:    testId.String(),
+			UserId:    userId.String(),
+			UserName:  "Ivan",
+			Rating:    5,
+			Text:      "Good",
+			Status:    "approved",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+	}}, list)
+}
+
+func TestReviewsList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	reviewUsecase := mocks.NewMockIReviewUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Review: reviewUsecase}, logger, nil, nil)
+	newContext := func(query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		c.Request.URL, _ = url.Parse(query)
+		return w, c
+	}
+
+	w, c := newContext("?status=deleted")
+	delivery.ReviewsList(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext("")
+	reviewUsecase.EXPECT().GetReviews(ctx, models.ReviewPending, 0, defaultReviewsLimit).Return(nil, fmt.Errorf("error"))
+	delivery.ReviewsList(c)
+	require.Equal(t, 500, w.Code)
+
+	w, c = newContext("?status=rejected&limit=1")
+	reviewUsecase.EXPECT().GetReviews(ctx, models.ReviewRejected, 0, 1).Return([]models.Review{}, nil)
+	delivery.ReviewsList(c)
+	require.Equal(t, 200, w.Code)
+	require.JSONEq(t, `{"reviews":[]}`, w.Body.String())
+}
+
+func TestModerateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	reviewUsecase := mocks.NewMockIReviewUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Review: reviewUsecase}, logger, nil, nil)
+	reviewId := uuid.New()
+	newContext := func(id string, content interface{}) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "reviewID",
+				Value: id,
+			},
+		}
+		MockJson(c, content, "PUT")
+		return w, c
+	}
+
+	w, c := newContext("1", review.Moderation{Status: "approved"})
+	delivery.ModerateReview(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(reviewId.String(), review.Moderation{Status: "pending"})
+	delivery.ModerateReview(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("error on get review: %w", models.ErrorNotFound{}), 404},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	for _, test := range tests {
+		w, c = newContext(reviewId.String(), review.Moderation{Status: "approved"})
+		reviewUsecase.EXPECT().ModerateReview(ctx, reviewId, models.ReviewApproved).Return(test.err)
+		delivery.ModerateReview(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
@@ -0,0 +1,333 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/shipments"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateShipment - create shipment of the order
+//
+//	@Summary		Create shipment
+//	@Description	Method provides to create pending shipment with the part of lines of the order. Lines can't exceed
+//	@Description	quantities not sent by other shipments, failed shipments don't count. Without lines all lines
+//	@Description	not sent yet are added to the shipment.
+//	@Tags			shipments
+//	@Accept			json
+//	@Produce		json
+//	@Param			orderID		path		string					true	"id of order"
+//	@Param			shipment	body		shipments.NewShipment	false	"Lines of shipment"
+//	@Success		201			{object}	shipments.ShipmentId
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"All lines are shipped or order can't be shipped"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/shipments/create/{orderID} [post]
+func (delivery *Delivery) CreateShipment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateShipment()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var newShipment shipments.NewShipment
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if err := c.ShouldBindJSON(&newShipment); err != nil {
+			delivery.logger.Error(err.Error())
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+	lines := make([]models.ShipmentLine, 0, len(newShipment.Lines))
+	for _, line := range newShipment.Lines {
+		itemId, err := uuid.Parse(line.ItemId)
+		if err != nil {
+			delivery.logger.Error(err.Error())
+			delivery.SetError(c, http.StatusBadRequest, err)
+			return
+		}
+		lines = append(lines, models.ShipmentLine{ItemId: itemId, Quantity: line.Quantity})
+	}
+	id, err := delivery.shipmentUsecase.CreateShipment(c.Request.Context(), orderId, lines)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusCreated, shipments.ShipmentId{Value: id.String()})
+}
+
+// GetShipment - get shipment by id
+//
+//	@Summary		Get shipment
+//	@Description	Method provides to get shipment with its lines, carrier and tracking number.
+//	@Tags			shipments
+//	@Produce		json
+//	@Param			shipmentID	path		string	true	"id of shipment"
+//	@Success		200			{object}	shipments.Shipment
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/shipments/{shipmentID} [get]
+func (delivery *Delivery) GetShipment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetShipment()")
+	id, err := uuid.Parse(c.Param("shipmentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	shipment, err := delivery.shipmentUsecase.GetShipment(c.Request.Context(), id)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, shipmentToDelivery(shipment))
+}
+
+// OrderShipments - get shipments of the order
+//
+//	@Summary		Get shipments of order
+//	@Description	Method provides to get all shipments of the order.
+//	@Tags			shipments
+//	@Produce		json
+//	@Param			orderID	path		string	true	"id of order"
+//	@Success		200		{object}	shipments.ShipmentsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/shipments/list/{orderID} [get]
+func (delivery *Delivery) OrderShipments(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery OrderShipments()")
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelShipments, err := delivery.shipmentUsecase.GetOrderShipments(c.Request.Context(), orderId)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, shipmentsToDelivery(modelShipments))
+}
+
+// UserOrderShipments - get shipments of the order of the user
+//
+//	@Summary		Track order
+//	@Description	Method provides to get shipments of the order of the user with carriers, tracking numbers and statuses.
+//	@Tags			shipments
+//	@Produce		json
+//	@Param			orderID	path		string	true	"id of order"
+//	@Success		200		{object}	shipments.ShipmentsList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/shipments/order/{orderID} [get]
+func (delivery *Delivery) UserOrderShipments(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UserOrderShipments()")
+	userId, ok := delivery.claimsUser(c)
+	if !ok {
+		return
+	}
+	orderId, err := uuid.Parse(c.Param("orderID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelShipments, err := delivery.shipmentUsecase.GetUserOrderShipments(c.Request.Context(), userId, orderId)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, shipmentsToDelivery(modelShipments))
+}
+
+// ShipShipment - hand the shipment to the carrier
+//
+//	@Summary		Ship shipment
+//	@Description	Method provides to hand the pending shipment to the carrier, the tracking number of the carrier
+//	@Description	is saved and the order is picked by courier.
+//	@Tags			shipments
+//	@Produce		json
+//	@Param			shipmentID	path		string	true	"id of shipment"
+//	@Success		200			{object}	shipments.Shipment
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Shipment isn't pending"
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		502			{object}	ErrorResponse	"Carrier error"
+//	@Router			/shipments/ship/{shipmentID} [post]
+func (delivery *Delivery) ShipShipment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ShipShipment()")
+	id, err := uuid.Parse(c.Param("shipmentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	shipment, err := delivery.shipmentUsecase.Ship(c.Request.Context(), id)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, shipmentToDelivery(shipment))
+}
+
+// TrackShipment - update status of the shipment from the carrier
+//
+//	@Summary		Track shipment
+//	@Description	Method provides to update status of the shipment on the way from the carrier at once,
+//	@Description	statuses are also updated by the background task.
+//	@Tags			shipments
+//	@Produce		json
+//	@Param			shipmentID	path		string	true	"id of shipment"
+//	@Success		200			{object}	shipments.Shipment
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Shipment isn't on the way"
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		502			{object}	ErrorResponse	"Carrier error"
+//	@Router			/shipments/track/{shipmentID} [post]
+func (delivery *Delivery) TrackShipment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery TrackShipment()")
+	id, err := uuid.Parse(c.Param("shipmentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	shipment, err := delivery.shipmentUsecase.Track(c.Request.Context(), id)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, shipmentToDelivery(shipment))
+}
+
+// SetShipmentStatus - change status of the shipment by hand
+//
+//	@Summary		Change status of shipment
+//	@Description	Method provides to change status of the shipment handed to the carrier by hand, e.g. when
+//	@Description	the carrier doesn't report failed delivery. Delivered and failed shipments can't be changed.
+//	@Tags			shipments
+//	@Accept			json
+//	@Produce		json
+//	@Param			shipmentID	path		string						true	"id of shipment"
+//	@Param			status		body		shipments.ShipmentStatus	true	"New status"
+//	@Success		200			{object}	shipments.Shipment
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Status can't be changed"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/shipments/status/{shipmentID} [put]
+func (delivery *Delivery) SetShipmentStatus(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetShipmentStatus()")
+	id, err := uuid.Parse(c.Param("shipmentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var status shipments.ShipmentStatus
+	if err := c.ShouldBindJSON(&status); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	shipment, err := delivery.shipmentUsecase.SetStatus(c.Request.Context(), id, models.ShipmentStatus(status.Status))
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, shipmentToDelivery(shipment))
+}
+
+// DeleteShipment - delete pending shipment
+//
+//	@Summary		Delete shipment
+//	@Description	Method provides to delete shipment which isn't handed to the carrier, its lines can be shipped again.
+//	@Tags			shipments
+//	@Param			shipmentID	path	string	true	"id of shipment"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Shipment isn't pending"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/shipments/delete/{shipmentID} [delete]
+func (delivery *Delivery) DeleteShipment(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteShipment()")
+	id, err := uuid.Parse(c.Param("shipmentID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.shipmentUsecase.DeleteShipment(c.Request.Context(), id)
+	if delivery.shipmentError(c, err) {
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// shipmentError writes to the response error of operation with the shipment,
+// true is returned if there was an error
+func (delivery *Delivery) shipmentError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, models.ErrorNotFound{}):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+	case errors.Is(err, models.ErrInvalidShipment):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+	case errors.Is(err, models.ErrShipmentNotAllowed):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+	case errors.Is(err, models.ErrCarrier):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadGateway, err)
+	default:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+	}
+	return true
+}
+
+func shipmentsToDelivery(modelShipments []models.Shipment) shipments.ShipmentsList {
+	list := shipments.ShipmentsList{List: make([]shipments.Shipment, 0, len(modelShipments))}
+	for i := range modelShipments {
+		list.List = append(list.List, shipmentToDelivery(&modelShipments[i]))
+	}
+	return list
+}
+
+func shipmentToDelivery(shipment *models.Shipment) shipments.Shipment {
+	result := shipments.Shipment{
+		Id:             shipment.Id.String(),
+		OrderId:        shipment.OrderId.String(),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Status:         string(shipment.Status),
+		Lines:          make([]shipments.Line, 0, len(shipment.Lines)),
+		CreatedAt:      shipment.CreatedAt,
+		UpdatedAt:      shipment.UpdatedAt,
+		ShippedAt:      optionalTime(shipment.ShippedAt),
+		DeliveredAt:    optionalTime(shipment.DeliveredAt),
+	}
+	for _, line := range shipment.Lines {
+		result.Lines = append(result.Lines, shipments.Line{
+			ItemId:   line.ItemId.String(),
+			Title:    line.Title,
+			Quantity: line.Quantity,
+		})
+	}
+	return result
+}
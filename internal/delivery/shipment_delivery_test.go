@@ -0,0 +1,141 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/shipments"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateShipment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentUsecase := mocks.NewMockIShipmentUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Shipment: shipmentUsecase}, zap.L(), nil, nil)
+	orderId := uuid.New()
+	itemId := uuid.New()
+
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"orderID": orderId.String()}, shipments.NewShipment{
+		Lines: []shipments.Line{{ItemId: itemId.String()}}}, "POST")
+	delivery.CreateShipment(c)
+	require.Equal(t, 400, w.Code)
+
+	// Lines are optional
+	id := uuid.New()
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"orderID": orderId.String()}, nil, "")
+	shipmentUsecase.EXPECT().CreateShipment(ctx, orderId, []models.ShipmentLine{}).Return(id, nil)
+	delivery.CreateShipment(c)
+	require.Equal(t, 201, w.Code)
+	var res shipments.ShipmentId
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, id.String(), res.Value)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{models.ErrInvalidShipment, 400},
+		{models.ErrShipmentNotAllowed, 422},
+		{fmt.Errorf("error"), 500},
+		{nil, 201},
+	}
+	for _, test := range tests {
+		w, c = newWishlistContext(uuid.Nil, map[string]string{"orderID": orderId.String()}, shipments.NewShipment{
+			Lines: []shipments.Line{{ItemId: itemId.String(), Quantity: 2}}}, "POST")
+		shipmentUsecase.EXPECT().CreateShipment(ctx, orderId, []models.ShipmentLine{{ItemId: itemId, Quantity: 2}}).Return(id, test.err)
+		delivery.CreateShipment(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
+
+func TestShipShipment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentUsecase := mocks.NewMockIShipmentUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Shipment: shipmentUsecase}, zap.L(), nil, nil)
+	id := uuid.New()
+
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"shipmentID": "1"}, nil, "")
+	delivery.ShipShipment(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"shipmentID": id.String()}, nil, "")
+	shipmentUsecase.EXPECT().Ship(ctx, id).Return(nil, models.ErrCarrier)
+	delivery.ShipShipment(c)
+	require.Equal(t, 502, w.Code)
+
+	shippedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"shipmentID": id.String()}, nil, "")
+	shipmentUsecase.EXPECT().Ship(ctx, id).Return(&models.Shipment{Id: id, OrderId: uuid.New(), Carrier: "fake",
+		TrackingNumber: "FAKE-1", Status: models.ShipmentShipped, ShippedAt: shippedAt,
+		Lines: []models.ShipmentLine{{ItemId: uuid.New(), Title: "Shoes", Quantity: 1}}}, nil)
+	delivery.ShipShipment(c)
+	require.Equal(t, 200, w.Code)
+	var res shipments.Shipment
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, "FAKE-1", res.TrackingNumber)
+	require.Equal(t, "shipped", res.Status)
+	require.Len(t, res.Lines, 1)
+	require.True(t, shippedAt.Equal(*res.ShippedAt))
+	require.Nil(t, res.DeliveredAt)
+}
+
+func TestSetShipmentStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentUsecase := mocks.NewMockIShipmentUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Shipment: shipmentUsecase}, zap.L(), nil, nil)
+	id := uuid.New()
+
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"shipmentID": id.String()}, shipments.ShipmentStatus{Status: "pending"}, "PUT")
+	delivery.SetShipmentStatus(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"shipmentID": id.String()}, shipments.ShipmentStatus{Status: "failed"}, "PUT")
+	shipmentUsecase.EXPECT().SetStatus(ctx, id, models.ShipmentFailed).Return(nil, models.ErrShipmentNotAllowed)
+	delivery.SetShipmentStatus(c)
+	require.Equal(t, 422, w.Code)
+}
+
+func TestUserOrderShipments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentUsecase := mocks.NewMockIShipmentUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Shipment: shipmentUsecase}, zap.L(), nil, nil)
+	userId := uuid.New()
+	orderId := uuid.New()
+
+	w, c := newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, nil, "")
+	shipmentUsecase.EXPECT().GetUserOrderShipments(ctx, userId, orderId).Return(nil, models.ErrorNotFound{})
+	delivery.UserOrderShipments(c)
+	require.Equal(t, 404, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"orderID": orderId.String()}, nil, "")
+	shipmentUsecase.EXPECT().GetUserOrderShipments(ctx, userId, orderId).Return([]models.Shipment{
+		{Id: uuid.New(), OrderId: orderId, Status: models.ShipmentPending},
+		{Id: uuid.New(), OrderId: orderId, Status: models.ShipmentInTransit, TrackingNumber: "FAKE-1"},
+	}, nil)
+	delivery.UserOrderShipments(c)
+	require.Equal(t, 200, w.Code)
+	var res shipments.ShipmentsList
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Len(t, res.List, 2)
+	require.Equal(t, "in_transit", res.List[1].Status)
+}
@@ -0,0 +1,45 @@
+package shipments
+
+import "time"
+
+// Line is a structure for quantity of the item of the order in the shipment
+type Line struct {
+	ItemId   string `json:"itemId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Title    string `json:"title,omitempty" example:"Кроссовки"`
+	Quantity int    `json:"quantity" binding:"required,min=1" example:"1" minimum:"1"`
+}
+
+// NewShipment is a structure for creating shipment of the order, all lines
+// not sent yet are shipped without lines
+type NewShipment struct {
+	Lines []Line `json:"lines" binding:"dive"`
+}
+
+// ShipmentId is a structure for result of creating shipment
+type ShipmentId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Shipment is a structure for displaying shipment of the order
+type Shipment struct {
+	Id             string     `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	OrderId        string     `json:"orderId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Carrier        string     `json:"carrier" example:"fake"`
+	TrackingNumber string     `json:"trackingNumber,omitempty" example:"FAKE-1672574400-3f2a9c1b"`
+	Status         string     `json:"status" example:"in_transit" enums:"pending,shipped,in_transit,delivered,failed"`
+	Lines          []Line     `json:"lines"`
+	CreatedAt      time.Time  `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt      time.Time  `json:"updatedAt" example:"2023-01-01T12:00:00Z"`
+	ShippedAt      *time.Time `json:"shippedAt,omitempty" example:"2023-01-01T12:00:00Z"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty" example:"2023-01-03T12:00:00Z"`
+}
+
+// ShipmentsList is a structure for list of shipments of the order
+type ShipmentsList struct {
+	List []Shipment `json:"shipments"`
+}
+
+// ShipmentStatus is a structure for changing status of the shipment by hand
+type ShipmentStatus struct {
+	Status string `json:"status" binding:"required,oneof=shipped in_transit delivered failed" example:"failed" enums:"shipped,in_transit,delivered,failed"`
+}
@@ -0,0 +1,65 @@
+package shipping
+
+import "time"
+
+// Zone is a structure for country or region of delivery, region is
+// compared with the city of the address
+type Zone struct {
+	Country   string `json:"country" binding:"required,max=64" example:"Russia"`
+	Region    string `json:"region,omitempty" binding:"max=64" example:"Moscow"`
+	Surcharge int64  `json:"surcharge" binding:"min=0" example:"10000" minimum:"0"`
+}
+
+// ShortShippingMethod is a structure for creating and updating shipping
+// method, amounts are in minor units of the base currency and weights are in grams
+type ShortShippingMethod struct {
+	Name       string `json:"name" binding:"required,max=256" example:"Courier"`
+	Kind       string `json:"kind" binding:"required,oneof=courier pickup standard express" example:"courier" enums:"courier,pickup,standard,express"`
+	Price      int64  `json:"price" binding:"min=0" example:"30000" minimum:"0"`
+	PricePerKg int64  `json:"pricePerKg" binding:"min=0" example:"5000" minimum:"0"`
+	FreeFrom   int64  `json:"freeFrom" binding:"min=0" example:"500000" minimum:"0"`
+	MaxWeight  int64  `json:"maxWeight" binding:"min=0" example:"20000" minimum:"0"`
+	MinDays    int    `json:"minDays" binding:"min=0" example:"1" minimum:"0"`
+	MaxDays    int    `json:"maxDays" binding:"min=0" example:"3" minimum:"0"`
+	Zones      []Zone `json:"zones,omitempty" binding:"dive"`
+	Active     bool   `json:"active" example:"true"`
+}
+
+// ShippingMethodId is a structure for result of creating shipping method
+type ShippingMethodId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// ShippingMethod is a structure for displaying shipping method
+type ShippingMethod struct {
+	Id string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ShortShippingMethod
+	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// ShippingMethodsList is a structure for list of shipping methods
+type ShippingMethodsList struct {
+	List []ShippingMethod `json:"shippingMethods"`
+}
+
+// Weight is a structure for setting weight of the item in grams
+type Weight struct {
+	Weight int64 `json:"weight" binding:"min=0" example:"1500" minimum:"0"`
+}
+
+// Quote is a structure for displaying price and estimated dates of
+// delivery of the cart by the method
+type Quote struct {
+	MethodId       string    `json:"methodId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Name           string    `json:"name" example:"Courier"`
+	Kind           string    `json:"kind" example:"courier"`
+	Price          int64     `json:"price" example:"30000"`
+	FormattedPrice string    `json:"formattedPrice" example:"300.00 RUB"`
+	EstimatedFrom  time.Time `json:"estimatedFrom" example:"2023-01-02T12:00:00Z"`
+	EstimatedTo    time.Time `json:"estimatedTo" example:"2023-01-04T12:00:00Z"`
+}
+
+// QuotesList is a structure for list of available shipping methods
+type QuotesList struct {
+	Quotes []Quote `json:"quotes"`
+}
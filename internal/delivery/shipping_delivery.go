@@ -0,0 +1,324 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/shipping"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateShippingMethod - create new shipping method
+//
+//	@Summary		Create shipping method
+//	@Description	Method provides to create way of delivery of orders. Price is in minor units of the base currency,
+//	@Description	price per kg is added for every started kilogram, weights are in grams. Delivery is free from the
+//	@Description	order total freeFrom if it is not zero. Method without zones delivers everywhere, region of the zone
+//	@Description	is compared with the city of the address and its surcharge is added to the price.
+//	@Tags			shipping
+//	@Accept			json
+//	@Produce		json
+//	@Param			shippingMethod	body		shipping.ShortShippingMethod	true	"Data for creating shipping method"
+//	@Success		201				{object}	shipping.ShippingMethodId
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		403				"Forbidden"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/shipping/create [post]
+func (delivery *Delivery) CreateShippingMethod(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateShippingMethod()")
+	var shortMethod shipping.ShortShippingMethod
+	if err := c.ShouldBindJSON(&shortMethod); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	id, err := delivery.shippingUsecase.CreateShippingMethod(c.Request.Context(), shippingMethodFromDelivery(uuid.Nil, shortMethod))
+	if err != nil && errors.Is(err, models.ErrInvalidShippingMethod) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, shipping.ShippingMethodId{Value: id.String()})
+}
+
+// UpdateShippingMethod - update shipping method
+//
+//	@Summary		Update shipping method
+//	@Description	Method provides to change shipping method, delivery of placed orders is kept.
+//	@Tags			shipping
+//	@Accept			json
+//	@Produce		json
+//	@Param			methodID		path	string							true	"id of shipping method"
+//	@Param			shippingMethod	body	shipping.ShortShippingMethod	true	"Data for updating shipping method"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/shipping/update/{methodID} [put]
+func (delivery *Delivery) UpdateShippingMethod(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UpdateShippingMethod()")
+	id, err := uuid.Parse(c.Param("methodID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var shortMethod shipping.ShortShippingMethod
+	if err := c.ShouldBindJSON(&shortMethod); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.shippingUsecase.UpdateShippingMethod(c.Request.Context(), shippingMethodFromDelivery(id, shortMethod))
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("shipping method with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidShippingMethod):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ShippingMethodsList - returns all shipping methods
+//
+//	@Summary		Get list of shipping methods
+//	@Description	Method provides to get all shipping methods including inactive ones in order they were created.
+//	@Tags			shipping
+//	@Produce		json
+//	@Success		200	{object}	shipping.ShippingMethodsList
+//	@Failure		403	"Forbidden"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/shipping/list [get]
+func (delivery *Delivery) ShippingMethodsList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ShippingMethodsList()")
+	methods, err := delivery.shippingUsecase.GetShippingMethods(c.Request.Context())
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := shipping.ShippingMethodsList{List: make([]shipping.ShippingMethod, 0, len(methods))}
+	for _, method := range methods {
+		list.List = append(list.List, shipping.ShippingMethod{
+			Id:                  method.Id.String(),
+			ShortShippingMethod: shippingMethodToDelivery(method),
+			CreatedAt:           method.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteShippingMethod - delete shipping method by id
+//
+//	@Summary		Delete shipping method
+//	@Description	Method provides to delete shipping method, placed orders keep its name and price of delivery.
+//	@Tags			shipping
+//	@Produce		json
+//	@Param			methodID	path	string	true	"id of shipping method"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/shipping/delete/{methodID} [delete]
+func (delivery *Delivery) DeleteShippingMethod(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteShippingMethod()")
+	id, err := uuid.Parse(c.Param("methodID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.shippingUsecase.DeleteShippingMethod(c.Request.Context(), id)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("shipping method with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetItemWeight - set weight of the item
+//
+//	@Summary		Set weight of item
+//	@Description	Method provides to set weight of the item in grams for calculation of delivery price.
+//	@Tags			shipping
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID	path	string			true	"id of item"
+//	@Param			weight	body	shipping.Weight	true	"Weight in grams"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/shipping/weight/{itemID} [put]
+func (delivery *Delivery) SetItemWeight(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetItemWeight()")
+	id, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var weight shipping.Weight
+	if err := c.ShouldBindJSON(&weight); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.shippingUsecase.SetItemWeight(c.Request.Context(), id, weight.Weight)
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("item with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidShippingMethod):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ShippingQuotes - returns available shipping methods for the cart
+//
+//	@Summary		Get shipping methods for cart
+//	@Description	Method provides to get shipping methods which can deliver the cart to the address with price
+//	@Description	and estimated dates of delivery, cheapest and fastest first. Id of chosen method is passed on
+//	@Description	creating of order.
+//	@Tags			carts
+//	@Produce		json
+//	@Param			cartID	path		string	true	"Id of cart"
+//	@Param			country	query		string	true	"Country of shipping"
+//	@Param			region	query		string	false	"Region (city) of shipping"
+//	@Success		200		{object}	shipping.QuotesList
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/checkout/shipping/{cartID} [get]
+func (delivery *Delivery) ShippingQuotes(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ShippingQuotes()")
+	ctx := c.Request.Context()
+	cartId, err := uuid.Parse(c.Param("cartID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	country := c.Query("country")
+	if country == "" {
+		err = fmt.Errorf("country of shipping is required")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	modelCart, err := delivery.cartUsecase.GetCart(ctx, cartId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("cart with id: %v not found", cartId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	address := models.UserAddress{Country: country, City: c.Query("region")}
+	quotes, err := delivery.shippingUsecase.QuoteShipping(ctx, modelCart, address)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := shipping.QuotesList{Quotes: make([]shipping.Quote, 0, len(quotes))}
+	for _, quote := range quotes {
+		list.Quotes = append(list.Quotes, shipping.Quote{
+			MethodId:       quote.Method.Id.String(),
+			Name:           quote.Method.Name,
+			Kind:           string(quote.Method.Kind),
+			Price:          quote.Price,
+			FormattedPrice: models.Money{Amount: quote.Price, Currency: modelCart.Currency}.Format(),
+			EstimatedFrom:  quote.EstimatedFrom,
+			EstimatedTo:    quote.EstimatedTo,
+		})
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func shippingMethodFromDelivery(id uuid.UUID, shortMethod shipping.ShortShippingMethod) *models.ShippingMethod {
+	method := &models.ShippingMethod{
+		Id:         id,
+		Name:       shortMethod.Name,
+		Kind:       models.ShippingKind(shortMethod.Kind),
+		Price:      shortMethod.Price,
+		PricePerKg: shortMethod.PricePerKg,
+		FreeFrom:   shortMethod.FreeFrom,
+		MaxWeight:  shortMethod.MaxWeight,
+		MinDays:    shortMethod.MinDays,
+		MaxDays:    shortMethod.MaxDays,
+		Zones:      make([]models.ShippingZone, 0, len(shortMethod.Zones)),
+		Active:     shortMethod.Active,
+	}
+	for _, zone := range shortMethod.Zones {
+		method.Zones = append(method.Zones, models.ShippingZone{
+			Country:   zone.Country,
+			Region:    zone.Region,
+			Surcharge: zone.Surcharge,
+		})
+	}
+	return method
+}
+
+func shippingMethodToDelivery(method models.ShippingMethod) shipping.ShortShippingMethod {
+	shortMethod := shipping.ShortShippingMethod{
+		Name:       method.Name,
+		Kind:       string(method.Kind),
+		Price:      method.Price,
+		PricePerKg: method.PricePerKg,
+		FreeFrom:   method.FreeFrom,
+		MaxWeight:  method.MaxWeight,
+		MinDays:    method.MinDays,
+		MaxDays:    method.MaxDays,
+		Active:     method.Active,
+	}
+	for _, zone := range method.Zones {
+		shortMethod.Zones = append(shortMethod.Zones, shipping.Zone{
+			Country:   zone.Country,
+			Region:    zone.Region,
+			Surcharge: zone.Surcharge,
+		})
+	}
+	return shortMethod
+}
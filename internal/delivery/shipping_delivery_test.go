@@ -0,0 +1,158 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/shipping"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestUpdateShippingMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	shippingUsecase := mocks.NewMockIShippingUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Shipping: shippingUsecase}, logger, nil, nil)
+	newContext := func(id string, content interface{}) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "methodID",
+				Value: id,
+			},
+		}
+		MockJson(c, content, "PUT")
+		return w, c
+	}
+	shortMethod := shipping.ShortShippingMethod{
+		Name:    "Courier",
+		Kind:    "courier",
+		Price:   30000,
+		MinDays: 1,
+		MaxDays: 3,
+		Zones:   []shipping.Zone{{Country: "Russia", Region: "Moscow", Surcharge: 10000}},
+		Active:  true,
+	}
+
+	w, c := newContext("invalid", shortMethod)
+	delivery.UpdateShippingMethod(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext(testId.String(), shipping.ShortShippingMethod{Name: "Drone", Kind: "drone"})
+	delivery.UpdateShippingMethod(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{fmt.Errorf("%w: invalid days of delivery", models.ErrInvalidShippingMethod), 400},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	for _, test := range tests {
+		w, c = newContext(testId.String(), shortMethod)
+		shippingUsecase.EXPECT().UpdateShippingMethod(ctx, &models.ShippingMethod{
+			Id:      testId,
+			Name:    "Courier",
+			Kind:    models.ShippingCourier,
+			Price:   30000,
+			MinDays: 1,
+			MaxDays: 3,
+			Zones:   []models.ShippingZone{{Country: "Russia", Region: "Moscow", Surcharge: 10000}},
+			Active:  true,
+		}).Return(test.err)
+		delivery.UpdateShippingMethod(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
+
+func TestShippingQuotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	shippingUsecase := mocks.NewMockIShippingUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase, Shipping: shippingUsecase}, logger, nil, nil)
+	newContext := func(query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{RawQuery: query},
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "cartID",
+				Value: testCartId.String(),
+			},
+		}
+		return w, c
+	}
+	modelCart := &models.Cart{
+		Id:      testCartId,
+		UserId:  testUserId,
+		Items:   []models.ItemWithQuantity{},
+		Pricing: models.Pricing{Currency: "RUB", Subtotal: 1000, Total: 1000},
+	}
+	address := models.UserAddress{Country: "Russia", City: "Moscow"}
+
+	// Country of shipping is required
+	w, c := newContext("")
+	delivery.ShippingQuotes(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newContext("country=Russia&region=Moscow")
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(nil, models.ErrorNotFound{})
+	delivery.ShippingQuotes(c)
+	require.Equal(t, 404, w.Code)
+
+	w, c = newContext("country=Russia&region=Moscow")
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(modelCart, nil)
+	shippingUsecase.EXPECT().QuoteShipping(ctx, modelCart, address).Return(nil, fmt.Errorf("error"))
+	delivery.ShippingQuotes(c)
+	require.Equal(t, 500, w.Code)
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	w, c = newContext("country=Russia&region=Moscow")
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(modelCart, nil)
+	shippingUsecase.EXPECT().QuoteShipping(ctx, modelCart, address).Return([]models.ShippingQuote{{
+		Method:        models.ShippingMethod{Id: testId, Name: "Courier", Kind: models.ShippingCourier},
+		Price:         30000,
+		EstimatedFrom: now.AddDate(0, 0, 1),
+		EstimatedTo:   now.AddDate(0, 0, 3),
+	}}, nil)
+	delivery.ShippingQuotes(c)
+	require.Equal(t, 200, w.Code)
+	var result shipping.QuotesList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, []shipping.Quote{{
+		MethodId:       testId.String(),
+		Name:           "Courier",
+		Kind:           "courier",
+		Price:          30000,
+		FormattedPrice: "300.00 RUB",
+		EstimatedFrom:  now.AddDate(0, 0, 1),
+		EstimatedTo:    now.AddDate(0, 0, 3),
+	}}, result.Quotes)
+}
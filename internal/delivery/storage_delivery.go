@@ -0,0 +1,68 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/file"
+	"OnlineShopBackend/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageCheckOptions is the structure for consistency check of the file storage
+type StorageCheckOptions struct {
+	DryRun bool `form:"dryRun"`
+}
+
+// CheckStorage - find orphaned files and dangling references to images
+//
+//	@Summary		Check consistency of the file storage
+//	@Description	Method provides to find files not referenced by any item or category and references of items and categories to missing files.
+//	@Description	Orphaned files are deleted and dangling references are removed, with dryRun=true nothing is changed and the report shows what would be done.
+//	@Description	Files put in the storage recently are not considered orphaned, they may be not referenced yet.
+//	@Tags			images
+//	@Produce		json
+//	@Param			dryRun	query		bool	false	"report without changes"
+//	@Success		200		{object}	file.StorageReport
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/images/check [post]
+func (delivery *Delivery) CheckStorage(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CheckStorage()")
+	var options StorageCheckOptions
+	err := c.ShouldBindQuery(&options)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	report, err := delivery.storageUsecase.CheckStorage(c.Request.Context(), options.DryRun)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, storageReportToDelivery(report))
+}
+
+func storageReportToDelivery(report *models.StorageReport) file.StorageReport {
+	result := file.StorageReport{
+		DryRun:       report.DryRun,
+		CheckedAt:    report.CheckedAt,
+		Files:        report.Files,
+		Refs:         report.Refs,
+		OrphanFiles:  append(make([]string, 0, len(report.OrphanFiles)), report.OrphanFiles...),
+		DanglingRefs: make([]file.ImageRef, 0, len(report.DanglingRefs)),
+		DeletedFiles: report.DeletedFiles,
+		RemovedRefs:  report.RemovedRefs,
+		Errors:       append(make([]string, 0, len(report.Errors)), report.Errors...),
+	}
+	for _, ref := range report.DanglingRefs {
+		result.DanglingRefs = append(result.DanglingRefs, file.ImageRef{
+			Kind:    ref.Kind,
+			OwnerId: ref.OwnerId.String(),
+			URL:     ref.URL,
+		})
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/file"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckStorage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	storageUsecase := mocks.NewMockIStorageUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Storage: storageUsecase}, logger, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		URL:    &url.URL{RawQuery: "dryRun=maybe"},
+	}
+	delivery.CheckStorage(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	storageUsecase.EXPECT().CheckStorage(ctx, false).Return(nil, fmt.Errorf("error"))
+	delivery.CheckStorage(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		URL:    &url.URL{RawQuery: "dryRun=true"},
+	}
+	checkedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	storageUsecase.EXPECT().CheckStorage(ctx, true).Return(&models.StorageReport{
+		DryRun:      true,
+		CheckedAt:   checkedAt,
+		Files:       2,
+		Refs:        1,
+		OrphanFiles: []string{"items/1/old.jpeg"},
+		DanglingRefs: []models.ImageRef{
+			{Kind: models.UploadKindItem, OwnerId: testId, Category: "Tools", URL: "http://localhost:8000/files/items/1/missing.jpeg"},
+		},
+	}, nil)
+	delivery.CheckStorage(c)
+	require.Equal(t, 200, w.Code)
+	var report file.StorageReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Equal(t, file.StorageReport{
+		DryRun:      true,
+		CheckedAt:   checkedAt,
+		Files:       2,
+		Refs:        1,
+		OrphanFiles: []string{"items/1/old.jpeg"},
+		DanglingRefs: []file.ImageRef{
+			{Kind: models.UploadKindItem, OwnerId: testId.String(), URL: "http://localhost:8000/files/items/1/missing.jpeg"},
+		},
+		Errors: []string{},
+	}, report)
+}
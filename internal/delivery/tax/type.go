@@ -0,0 +1,37 @@
+package tax
+
+import "time"
+
+// ShortTaxRule is a structure for creating and updating tax rule,
+// rate is in hundredths of percent
+type ShortTaxRule struct {
+	Name      string `json:"name" binding:"required,max=256" example:"VAT"`
+	Country   string `json:"country" binding:"required,max=64" example:"Russia"`
+	Region    string `json:"region,omitempty" binding:"max=64" example:"Moscow"`
+	TaxClass  string `json:"taxClass,omitempty" binding:"max=64" example:"standard"`
+	Rate      int64  `json:"rate" binding:"min=0,max=10000" example:"2000" minimum:"0" maximum:"10000"`
+	Inclusive bool   `json:"inclusive" example:"true"`
+}
+
+// TaxRuleId is a structure for result of creating tax rule
+type TaxRuleId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// TaxRule is a structure for displaying tax rule
+type TaxRule struct {
+	Id string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ShortTaxRule
+	CreatedAt time.Time `json:"createdAt" example:"2023-01-01T12:00:00Z"`
+}
+
+// TaxRulesList is a structure for list of tax rules
+type TaxRulesList struct {
+	List []TaxRule `json:"taxRules"`
+}
+
+// TaxClass is a structure for setting tax class of the item or category,
+// empty class resets it to the inherited one
+type TaxClass struct {
+	TaxClass string `json:"taxClass" binding:"max=64" example:"reduced"`
+}
@@ -0,0 +1,311 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/tax"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateTaxRule - create new tax rule
+//
+//	@Summary		Create tax rule
+//	@Description	Method provides to create tax of items of the tax class shipped to the country or the region of the country.
+//	@Description	The rule of the region overrides the rule of the whole country. Region is compared with the city of the address.
+//	@Description	Rate is in hundredths of percent. Inclusive tax is already in prices, exclusive tax is added to the total.
+//	@Tags			taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			taxRule	body		tax.ShortTaxRule	true	"Data for creating tax rule"
+//	@Success		201		{object}	tax.TaxRuleId
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/taxes/create [post]
+func (delivery *Delivery) CreateTaxRule(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateTaxRule()")
+	var shortRule tax.ShortTaxRule
+	if err := c.ShouldBindJSON(&shortRule); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	id, err := delivery.taxUsecase.CreateTaxRule(c.Request.Context(), taxRuleFromDelivery(uuid.Nil, shortRule))
+	if err != nil && errors.Is(err, models.ErrInvalidTaxRule) {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, tax.TaxRuleId{Value: id.String()})
+}
+
+// UpdateTaxRule - update tax rule
+//
+//	@Summary		Update tax rule
+//	@Description	Method provides to change tax rule, taxes of placed orders are kept.
+//	@Tags			taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			taxRuleID	path	string				true	"id of tax rule"
+//	@Param			taxRule		body	tax.ShortTaxRule	true	"Data for updating tax rule"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/taxes/update/{taxRuleID} [put]
+func (delivery *Delivery) UpdateTaxRule(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UpdateTaxRule()")
+	id, err := uuid.Parse(c.Param("taxRuleID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var shortRule tax.ShortTaxRule
+	if err := c.ShouldBindJSON(&shortRule); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.taxUsecase.UpdateTaxRule(c.Request.Context(), taxRuleFromDelivery(id, shortRule))
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("tax rule with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	case errors.Is(err, models.ErrInvalidTaxRule):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// TaxRulesList - returns all tax rules
+//
+//	@Summary		Get list of tax rules
+//	@Description	Method provides to get all tax rules ordered by country, region and tax class.
+//	@Tags			taxes
+//	@Produce		json
+//	@Success		200	{object}	tax.TaxRulesList
+//	@Failure		403	"Forbidden"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/taxes/list [get]
+func (delivery *Delivery) TaxRulesList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery TaxRulesList()")
+	rules, err := delivery.taxUsecase.GetTaxRules(c.Request.Context())
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	list := tax.TaxRulesList{List: make([]tax.TaxRule, 0, len(rules))}
+	for _, rule := range rules {
+		list.List = append(list.List, tax.TaxRule{
+			Id: rule.Id.String(),
+			ShortTaxRule: tax.ShortTaxRule{
+				Name:      rule.Name,
+				Country:   rule.Country,
+				Region:    rule.Region,
+				TaxClass:  rule.TaxClass,
+				Rate:      rule.Rate,
+				Inclusive: rule.Inclusive,
+			},
+			CreatedAt: rule.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteTaxRule - delete tax rule by id
+//
+//	@Summary		Delete tax rule
+//	@Description	Method provides to delete tax rule, taxes of placed orders are kept.
+//	@Tags			taxes
+//	@Produce		json
+//	@Param			taxRuleID	path	string	true	"id of tax rule"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/taxes/delete/{taxRuleID} [delete]
+func (delivery *Delivery) DeleteTaxRule(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteTaxRule()")
+	id, err := uuid.Parse(c.Param("taxRuleID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.taxUsecase.DeleteTaxRule(c.Request.Context(), id)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("tax rule with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetItemTaxClass - set tax class of the item
+//
+//	@Summary		Set tax class of item
+//	@Description	Method provides to set tax class of the item, it overrides the class of the category. Empty class resets the item to the class of its category.
+//	@Tags			taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			itemID		path	string			true	"id of item"
+//	@Param			taxClass	body	tax.TaxClass	true	"Tax class"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/taxes/class/item/{itemID} [put]
+func (delivery *Delivery) SetItemTaxClass(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetItemTaxClass()")
+	id, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var class tax.TaxClass
+	if err := c.ShouldBindJSON(&class); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.taxUsecase.SetItemTaxClass(c.Request.Context(), id, class.TaxClass)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("item with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SetCategoryTaxClass - set tax class of the category
+//
+//	@Summary		Set tax class of category
+//	@Description	Method provides to set tax class of items of the category. Empty class resets the category to the standard class.
+//	@Tags			taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			categoryID	path	string			true	"id of category"
+//	@Param			taxClass	body	tax.TaxClass	true	"Tax class"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/taxes/class/category/{categoryID} [put]
+func (delivery *Delivery) SetCategoryTaxClass(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery SetCategoryTaxClass()")
+	id, err := uuid.Parse(c.Param("categoryID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	var class tax.TaxClass
+	if err := c.ShouldBindJSON(&class); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.taxUsecase.SetCategoryTaxClass(c.Request.Context(), id, class.TaxClass)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("category with id: %v not found", id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// applyCartTaxes adds to the cart taxes of shipping to the address of
+// query parameters country and region, without country the cart is not
+// taxed. If taxes can't be calculated, the error is written to the
+// response and false is returned
+func (delivery *Delivery) applyCartTaxes(c *gin.Context, modelCart *models.Cart) bool {
+	country := c.Query("country")
+	if country == "" {
+		return true
+	}
+	address := models.UserAddress{Country: country, City: c.Query("region")}
+	err := delivery.cartUsecase.ApplyTaxes(c.Request.Context(), modelCart, address)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return false
+	}
+	return true
+}
+
+func taxRuleFromDelivery(id uuid.UUID, shortRule tax.ShortTaxRule) *models.TaxRule {
+	return &models.TaxRule{
+		Id:        id,
+		Name:      shortRule.Name,
+		Country:   shortRule.Country,
+		Region:    shortRule.Region,
+		TaxClass:  shortRule.TaxClass,
+		Rate:      shortRule.Rate,
+		Inclusive: shortRule.Inclusive,
+	}
+}
+
+// taxesToDelivery converts taxes of the cart or order
+func taxesToDelivery(taxes []models.TaxLine, currency string) []cart.Tax {
+	if len(taxes) == 0 {
+		return nil
+	}
+	result := make([]cart.Tax, 0, len(taxes))
+	for _, line := range taxes {
+		result = append(result, cart.Tax{
+			Name:      line.Name,
+			TaxClass:  line.TaxClass,
+			Rate:      line.Rate,
+			Inclusive: line.Inclusive,
+			Amount:    line.Amount,
+			Formatted: models.Money{Amount: line.Amount, Currency: currency}.Format(),
+		})
+		if line.TaxRuleId != uuid.Nil {
+			result[len(result)-1].TaxRuleId = line.TaxRuleId.String()
+		}
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/cart"
+	"OnlineShopBackend/internal/delivery/tax"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateTaxRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	taxUsecase := mocks.NewMockITaxUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Tax: taxUsecase}, logger, nil, nil)
+	newContext := func(content interface{}) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{},
+		}
+		MockJson(c, content, "POST")
+		return w, c
+	}
+	shortRule := tax.ShortTaxRule{Name: "VAT", Country: "Russia", Rate: 2000, Inclusive: true}
+
+	w, c := newContext(tax.ShortTaxRule{Name: "VAT", Country: "Russia", Rate: 10001})
+	delivery.CreateTaxRule(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: empty country", models.ErrInvalidTaxRule), 400},
+		{fmt.Errorf("error"), 500},
+		{nil, 201},
+	}
+	for _, test := range tests {
+		w, c = newContext(shortRule)
+		taxUsecase.EXPECT().CreateTaxRule(ctx, &models.TaxRule{
+			Name:      "VAT",
+			Country:   "Russia",
+			Rate:      2000,
+			Inclusive: true,
+		}).Return(testId, test.err)
+		delivery.CreateTaxRule(c)
+		require.Equal(t, test.code, w.Code)
+	}
+	var id tax.TaxRuleId
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &id))
+	require.Equal(t, testId.String(), id.Value)
+}
+
+func TestGetCartWithTaxes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	cartUsecase := mocks.NewMockICartUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Cart: cartUsecase}, logger, nil, nil)
+	newContext := func(query string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Header: make(http.Header),
+			URL:    &url.URL{RawQuery: query},
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "cartID",
+				Value: testCartId.String(),
+			},
+		}
+		return w, c
+	}
+	newCart := func() *models.Cart {
+		return &models.Cart{
+			Id:      testCartId,
+			UserId:  testUserId,
+			Items:   []models.ItemWithQuantity{},
+			Pricing: models.Pricing{Currency: "RUB", Subtotal: 1000, Total: 1000},
+		}
+	}
+	address := models.UserAddress{Country: "Russia", City: "Moscow"}
+
+	// Without country the cart is not taxed
+	w, c := newContext("")
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(newCart(), nil)
+	delivery.GetCart(c)
+	require.Equal(t, 200, w.Code)
+
+	w, c = newContext("country=Russia&region=Moscow")
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(newCart(), nil)
+	cartUsecase.EXPECT().ApplyTaxes(ctx, newCart(), address).Return(fmt.Errorf("error"))
+	delivery.GetCart(c)
+	require.Equal(t, 500, w.Code)
+
+	ruleId := uuid.New()
+	w, c = newContext("country=Russia&region=Moscow")
+	cartUsecase.EXPECT().GetCart(ctx, testCartId).Return(newCart(), nil)
+	cartUsecase.EXPECT().ApplyTaxes(ctx, newCart(), address).DoAndReturn(
+		func(ctx context.Context, modelCart *models.Cart, address models.UserAddress) error {
+			modelCart.Taxes = []models.TaxLine{{TaxRuleId: ruleId, Name: "VAT", TaxClass: "standard", Rate: 2000, Amount: 200}}
+			modelCart.Total = 1200
+			return nil
+		})
+	delivery.GetCart(c)
+	require.Equal(t, 200, w.Code)
+	var result cart.Cart
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Equal(t, []cart.Tax{{
+		TaxRuleId: ruleId.String(),
+		Name:      "VAT",
+		TaxClass:  "standard",
+		Rate:      2000,
+		Amount:    200,
+		Formatted: "2.00 RUB",
+	}}, result.Taxes)
+	require.Equal(t, "12.00 RUB", result.FormattedTotal)
+}
@@ -0,0 +1,17 @@
+package upload
+
+import "time"
+
+// UploadRequest is a structure for creating an upload session
+type UploadRequest struct {
+	Kind string `json:"kind" binding:"required,oneof=item category" example:"item"`
+	Id   string `json:"id" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Upload is a structure for displaying an upload session
+type Upload struct {
+	Id        string    `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	UploadURL string    `json:"uploadUrl" example:"http://localhost:8000/files/uploads/00000000-0000-0000-0000-000000000000"`
+	Method    string    `json:"method" example:"PUT"`
+	ExpiresAt time.Time `json:"expiresAt" example:"2023-01-01T12:15:00Z"`
+}
@@ -0,0 +1,187 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/upload"
+	"OnlineShopBackend/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateUpload creates an upload session for image
+//
+//	@Summary		Create an upload session for image of item or category
+//	@Description	Method provides to get url to upload an image directly to the file storage. The file is uploaded by PUT request to the url and the upload is confirmed after that.
+//	@Tags			images
+//	@Accept			json
+//	@Produce		json
+//	@Param			upload	body		upload.UploadRequest	true	"Owner of the image"
+//	@Success		201		{object}	upload.Upload			"Upload session"
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		403		"Forbidden"
+//	@Failure		404		{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/images/uploads [post]
+func (delivery *Delivery) CreateUpload(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateUpload()")
+	var request upload.UploadRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ownerId, err := uuid.Parse(request.Id)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := c.Request.Context()
+
+	// The owner of the image must exist before the file is uploaded
+	if request.Kind == models.UploadKindItem {
+		_, err = delivery.itemUsecase.GetItem(ctx, ownerId)
+	} else {
+		_, err = delivery.categoryUsecase.GetCategory(ctx, ownerId)
+	}
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		err = fmt.Errorf("%s with id: %v not found", request.Kind, ownerId)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	session, err := delivery.uploadUsecase.CreateUpload(ctx, request.Kind, ownerId)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusCreated, upload.Upload{
+		Id:        session.Id.String(),
+		UploadURL: session.UploadURL,
+		Method:    http.MethodPut,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+// PutUpload uploads file of the session
+//
+//	@Summary		Upload file of the upload session
+//	@Description	Method provides to upload the file of the session to the local file storage. The url of the method is returned on creation of the session, the file can be uploaded only once.
+//	@Tags			images
+//	@Accept			octet-stream
+//	@Produce		json
+//	@Param			uploadID	path	string	true	"id of upload session"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		410	{object}	ErrorResponse
+//	@Failure		413	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/files/uploads/{uploadID} [put]
+func (delivery *Delivery) PutUpload(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery PutUpload()")
+	id, err := uuid.Parse(c.Param("uploadID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err = delivery.uploadUsecase.PutUploadFile(c.Request.Context(), id, c.Request.Body)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, uploadErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ConfirmUpload attaches uploaded image to its owner
+//
+//	@Summary		Confirm the upload of image
+//	@Description	Method provides to process the uploaded image and to add it to the item or category of the upload session.
+//	@Tags			images
+//	@Accept			json
+//	@Produce		json
+//	@Param			uploadID	path		string				true	"id of upload session"
+//	@Success		201			{object}	item.ImageVariants	"Urls of the image"
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		409			{object}	ErrorResponse
+//	@Failure		410			{object}	ErrorResponse
+//	@Failure		413			{object}	ErrorResponse
+//	@Failure		415			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		507			{object}	ErrorResponse
+//	@Router			/images/uploads/{uploadID}/confirm [post]
+func (delivery *Delivery) ConfirmUpload(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ConfirmUpload()")
+	id, err := uuid.Parse(c.Param("uploadID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	ctx := c.Request.Context()
+	session, file, err := delivery.uploadUsecase.StartUpload(ctx, id)
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, uploadErrorStatus(err), err)
+		return
+	}
+	defer file.Close()
+
+	var attach func(ctx context.Context, id uuid.UUID, r io.Reader) (string, int, error)
+	if session.Kind == models.UploadKindItem {
+		attach = delivery.attachItemImage
+	} else {
+		attach = delivery.attachCategoryImage
+	}
+	path, status, err := attach(ctx, session.OwnerId, file)
+	if err != nil {
+		// The confirmation can be repeated until the session expires
+		delivery.uploadUsecase.FailUpload(ctx, id)
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, status, err)
+		return
+	}
+	err = delivery.uploadUsecase.CompleteUpload(ctx, id)
+	if err != nil {
+		// The image is already attached, so the request is successful
+		delivery.logger.Error(err.Error())
+	}
+	c.JSON(http.StatusCreated, imageVariants([]string{path})[0])
+}
+
+// uploadErrorStatus returns http status of errors of upload sessions
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrorNotFound{}):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrUploadExpired):
+		return http.StatusGone
+	case errors.Is(err, models.ErrUploadCompleted), errors.Is(err, fs.ErrExist):
+		return http.StatusConflict
+	case errors.Is(err, fs.ErrNotExist):
+		// The session exists, but the file is not uploaded yet
+		return http.StatusConflict
+	case errors.Is(err, models.ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,209 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/item"
+	"OnlineShopBackend/internal/delivery/upload"
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/imaging"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemUsecase := mocks.NewMockIItemUsecase(ctrl)
+	categoryUsecase := mocks.NewMockICategoryUsecase(ctrl)
+	uploadUsecase := mocks.NewMockIUploadUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Upload: uploadUsecase}, logger, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockJson(c, upload.UploadRequest{Kind: "user", Id: testId.String()}, "POST")
+	delivery.CreateUpload(c)
+	require.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockJson(c, upload.UploadRequest{Kind: models.UploadKindCategory, Id: testId.String()}, "POST")
+	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(nil, models.ErrorNotFound{})
+	delivery.CreateUpload(c)
+	require.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	MockJson(c, upload.UploadRequest{Kind: models.UploadKindItem, Id: testId.String()}, "POST")
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{Id: testId}, nil)
+	uploadUsecase.EXPECT().CreateUpload(ctx, models.UploadKindItem, testId).Return(nil, fmt.Errorf("error"))
+	delivery.CreateUpload(c)
+	require.Equal(t, 500, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	expiresAt := time.Now().Add(15 * time.Minute).UTC().Truncate(time.Second)
+	MockJson(c, upload.UploadRequest{Kind: models.UploadKindItem, Id: testId.String()}, "POST")
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{Id: testId}, nil)
+	uploadUsecase.EXPECT().CreateUpload(ctx, models.UploadKindItem, testId).Return(&models.UploadSession{
+		Id:        testId2,
+		Kind:      models.UploadKindItem,
+		OwnerId:   testId,
+		Status:    models.UploadPending,
+		ExpiresAt: expiresAt,
+		UploadURL: "http://localhost:8000/files/uploads/" + testId2.String(),
+	}, nil)
+	delivery.CreateUpload(c)
+	require.Equal(t, 201, w.Code)
+	var session upload.Upload
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+	require.Equal(t, upload.Upload{
+		Id:        testId2.String(),
+		UploadURL: "http://localhost:8000/files/uploads/" + testId2.String(),
+		Method:    http.MethodPut,
+		ExpiresAt: expiresAt,
+	}, session)
+}
+
+func TestPutUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	uploadUsecase := mocks.NewMockIUploadUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Upload: uploadUsecase}, logger, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+	}
+	delivery.PutUpload(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{models.ErrUploadExpired, 410},
+		{models.ErrUploadCompleted, 409},
+		{models.ErrUploadTooLarge, 413},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	for _, test := range tests {
+		w = httptest.NewRecorder()
+		c, _ = gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Method: http.MethodPut,
+			Header: make(http.Header),
+			Body:   io.NopCloser(bytes.NewReader(testFile)),
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "uploadID",
+				Value: testId.String(),
+			},
+		}
+		uploadUsecase.EXPECT().PutUploadFile(ctx, testId, c.Request.Body).Return(test.err)
+		delivery.PutUpload(c)
+		require.Equal(t, test.code, w.Code)
+	}
+}
+
+func TestConfirmUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger := zap.L()
+	itemUsecase := mocks.NewMockIItemUsecase(ctrl)
+	categoryUsecase := mocks.NewMockICategoryUsecase(ctrl)
+	uploadUsecase := mocks.NewMockIUploadUsecase(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	delivery := NewDelivery(Usecases{Item: itemUsecase, Category: categoryUsecase, Upload: uploadUsecase}, logger, filestorage, imaging.NewProcessor(testImageMaxSize, logger))
+	newContext := func() (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = &http.Request{
+			Method: http.MethodPost,
+			Header: make(http.Header),
+		}
+		c.Params = []gin.Param{
+			{
+				Key:   "uploadID",
+				Value: testId2.String(),
+			},
+		}
+		return w, c
+	}
+
+	w, c := newContext()
+	uploadUsecase.EXPECT().StartUpload(ctx, testId2).Return(nil, nil, models.ErrorNotFound{})
+	delivery.ConfirmUpload(c)
+	require.Equal(t, 404, w.Code)
+
+	w, c = newContext()
+	uploadUsecase.EXPECT().StartUpload(ctx, testId2).Return(nil, nil, models.ErrUploadCompleted)
+	delivery.ConfirmUpload(c)
+	require.Equal(t, 409, w.Code)
+
+	// The file is not an image, the session can be confirmed again
+	w, c = newContext()
+	uploadUsecase.EXPECT().StartUpload(ctx, testId2).Return(&models.UploadSession{
+		Id:      testId2,
+		Kind:    models.UploadKindCategory,
+		OwnerId: testId,
+	}, io.NopCloser(strings.NewReader("not an image")), nil)
+	categoryUsecase.EXPECT().GetCategory(ctx, testId).Return(&models.Category{Id: testId}, nil)
+	uploadUsecase.EXPECT().FailUpload(ctx, testId2)
+	delivery.ConfirmUpload(c)
+	require.Equal(t, 415, w.Code)
+
+	w, c = newContext()
+	uploadUsecase.EXPECT().StartUpload(ctx, testId2).Return(&models.UploadSession{
+		Id:      testId2,
+		Kind:    models.UploadKindItem,
+		OwnerId: testId,
+	}, io.NopCloser(bytes.NewReader(testFile)), nil)
+	itemUsecase.EXPECT().GetItem(ctx, testId).Return(&models.Item{Id: testId}, nil)
+	filestorage.EXPECT().PutItemImage(testId.String(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(id string, filename string, file []byte) (string, error) {
+			return "http://localhost:8000/files/items/" + id + "/" + filename, nil
+		}).Times(len(imaging.FileNames("image_large.jpeg")))
+	itemUsecase.EXPECT().UpdateItem(ctx, gomock.Any()).Return(nil)
+	uploadUsecase.EXPECT().CompleteUpload(ctx, testId2).Return(nil)
+	delivery.ConfirmUpload(c)
+	require.Equal(t, 201, w.Code)
+	var variants item.ImageVariants
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &variants))
+	require.Regexp(t, `^http://localhost:8000/files/items/`+testId.String()+`/\d+_thumb\.jpeg$`, variants.Thumb)
+	require.Regexp(t, `_large\.jpeg$`, variants.Large)
+}
@@ -0,0 +1,61 @@
+package jwtauth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// cartTokenSubject distinguishes tokens of guest carts from session tokens
+const cartTokenSubject = "cart"
+
+// CartTokenTTL is a lifetime of the token of the guest cart
+const CartTokenTTL = 30 * 24 * time.Hour
+
+// CartPayload is a payload of the token of the guest cart
+type CartPayload struct {
+	CartId uuid.UUID `json:"cartId"`
+	jwt.StandardClaims
+}
+
+// NewCartToken returns signed token of the guest cart
+func NewCartToken(cartId uuid.UUID) (string, error) {
+	key, err := NewJWTKeyConfig()
+	if err != nil {
+		return "", err
+	}
+	payload := CartPayload{
+		CartId: cartId,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   cartTokenSubject,
+			ExpiresAt: time.Now().Add(CartTokenTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &payload)
+	return token.SignedString(key.purposeKey(cartTokenSubject))
+}
+
+// ParseCartToken checks signature of the token of the guest cart
+// and returns id of the cart
+func ParseCartToken(tokenString string) (uuid.UUID, error) {
+	key, err := NewJWTKeyConfig()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	payload := &CartPayload{}
+	token, err := jwt.ParseWithClaims(tokenString, payload, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.purposeKey(cartTokenSubject), nil
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid cart token: %w", err)
+	}
+	if !token.Valid || payload.Subject != cartTokenSubject || payload.CartId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("invalid cart token")
+	}
+	return payload.CartId, nil
+}
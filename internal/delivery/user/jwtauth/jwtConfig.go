@@ -1,6 +1,8 @@
 package jwtauth
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"log"
 
 	"github.com/caarlos0/env/v6"
@@ -17,3 +19,11 @@ func NewJWTKeyConfig() (*JWTKey, error) {
 	}
 	return &cfg, nil
 }
+
+// purposeKey derives the key of tokens of the purpose from the key of
+// sessions, so tokens of links and carts are never accepted as sessions
+func (key *JWTKey) purposeKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(key.Key))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
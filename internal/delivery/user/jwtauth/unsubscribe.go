@@ -0,0 +1,65 @@
+package jwtauth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+// unsubscribeTokenSubject distinguishes tokens of unsubscribe links
+// from session tokens and tokens of guest carts
+const unsubscribeTokenSubject = "unsubscribe"
+
+// UnsubscribeTokenTTL is a lifetime of the token of the unsubscribe link,
+// every reminder carries a new link
+const UnsubscribeTokenTTL = 90 * 24 * time.Hour
+
+// UnsubscribePayload is a payload of the token of the unsubscribe link.
+// The claim of the user differs from the claim of sessions
+type UnsubscribePayload struct {
+	UserId uuid.UUID `json:"unsubscribeUserId"`
+	jwt.StandardClaims
+}
+
+// NewUnsubscribeToken returns signed token of the link to unsubscribe
+// the user from reminders
+func NewUnsubscribeToken(userId uuid.UUID) (string, error) {
+	key, err := NewJWTKeyConfig()
+	if err != nil {
+		return "", err
+	}
+	payload := UnsubscribePayload{
+		UserId: userId,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   unsubscribeTokenSubject,
+			ExpiresAt: time.Now().Add(UnsubscribeTokenTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &payload)
+	return token.SignedString(key.purposeKey(unsubscribeTokenSubject))
+}
+
+// ParseUnsubscribeToken checks signature of the token of the unsubscribe
+// link and returns id of the user
+func ParseUnsubscribeToken(tokenString string) (uuid.UUID, error) {
+	key, err := NewJWTKeyConfig()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	payload := &UnsubscribePayload{}
+	token, err := jwt.ParseWithClaims(tokenString, payload, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.purposeKey(unsubscribeTokenSubject), nil
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid unsubscribe token: %w", err)
+	}
+	if !token.Valid || payload.Subject != unsubscribeTokenSubject || payload.UserId == uuid.Nil {
+		return uuid.Nil, fmt.Errorf("invalid unsubscribe token")
+	}
+	return payload.UserId, nil
+}
@@ -138,6 +138,7 @@ func (delivery *Delivery) LoginUser(c *gin.Context) {
 			delivery.SetError(c, http.StatusNotFound, err)
 		}
 	}
+	cartId = delivery.mergeGuestCart(c, userExist.ID, cartId)
 
 	token, err := jwtauth.CreateSessionJWT(ctx, userExist)
 	if err != nil {
@@ -315,6 +316,7 @@ func (delivery *Delivery) success(c *gin.Context) http.HandlerFunc {
 				return
 			}
 		}
+		delivery.mergeGuestCart(c, u.ID, uuid.Nil)
 		token, err := jwtauth.CreateSessionJWT(c.Request.Context(), u)
 		if err != nil {
 			delivery.logger.Error(err.Error())
@@ -0,0 +1,50 @@
+package wishlist
+
+import (
+	"OnlineShopBackend/internal/delivery/item"
+	"time"
+)
+
+// ShortWishlist is a structure for creating or renaming wishlist
+type ShortWishlist struct {
+	Name string `json:"name" binding:"required,max=256" example:"Подарки"`
+}
+
+// WishlistId is a structure for result of creating wishlist
+type WishlistId struct {
+	Value string `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Wishlist is a structure for displaying wishlist, the default list of
+// favourites has nil id 00000000-0000-0000-0000-000000000000
+type Wishlist struct {
+	Id         string         `json:"id" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	Name       string         `json:"name" example:"Подарки"`
+	Default    bool           `json:"default" example:"false"`
+	ShareToken string         `json:"shareToken,omitempty" example:"3q2-7wX9..."`
+	ItemsCount int            `json:"itemsCount" example:"3"`
+	Items      []item.OutItem `json:"items,omitempty"`
+	CreatedAt  *time.Time     `json:"createdAt,omitempty" example:"2023-01-01T12:00:00Z"`
+}
+
+// WishlistsList is a structure for list of wishlists of the user
+type WishlistsList struct {
+	List []Wishlist `json:"wishlists"`
+}
+
+// MoveItem is a structure for moving item to other wishlist
+type MoveItem struct {
+	ItemId       string `json:"itemId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+	ToWishlistId string `json:"toWishlistId" binding:"required,uuid" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
+
+// Share is a structure for token of the public link of the wishlist,
+// the shared wishlist is read by /wishlists/shared/{token}
+type Share struct {
+	Token string `json:"token" example:"3q2-7wX9..."`
+}
+
+// CartId is a structure for the cart which the item is moved to
+type CartId struct {
+	Value string `json:"cartId" example:"00000000-0000-0000-0000-000000000000" format:"uuid"`
+}
@@ -0,0 +1,482 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/category"
+	"OnlineShopBackend/internal/delivery/item"
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/delivery/wishlist"
+	"OnlineShopBackend/internal/models"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateWishlist - create named wishlist of the user
+//
+//	@Summary		Method provides to create wishlist
+//	@Description	Method provides to create named wishlist of the user.
+//	@Tags			wishlists
+//	@Accept			json
+//	@Produce		json
+//	@Param			wishlist	body		wishlist.ShortWishlist	true	"Name of wishlist"
+//	@Success		201			{object}	wishlist.WishlistId
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/wishlists/create [post]
+func (delivery *Delivery) CreateWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery CreateWishlist()")
+	userId, ok := delivery.claimsUser(c)
+	if !ok {
+		return
+	}
+	var shortWishlist wishlist.ShortWishlist
+	if err := c.ShouldBindJSON(&shortWishlist); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	id, err := delivery.wishlistUsecase.CreateWishlist(c.Request.Context(), userId, shortWishlist.Name)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusCreated, wishlist.WishlistId{Value: id.String()})
+}
+
+// WishlistsList - get wishlists of the user
+//
+//	@Summary		Get wishlists of the user
+//	@Description	Method provides to get wishlists of the user without items, the first one
+//	@Description	is the default list of favourites with nil id.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Success		200	{object}	wishlist.WishlistsList
+//	@Failure		403	"Forbidden"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/list [get]
+func (delivery *Delivery) WishlistsList(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery WishlistsList()")
+	userId, ok := delivery.claimsUser(c)
+	if !ok {
+		return
+	}
+	wishlists, err := delivery.wishlistUsecase.GetWishlists(c.Request.Context(), userId)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	list := wishlist.WishlistsList{List: make([]wishlist.Wishlist, 0, len(wishlists))}
+	for i := range wishlists {
+		list.List = append(list.List, wishlistToDelivery(&wishlists[i], nil))
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetWishlist - get wishlist of the user with items
+//
+//	@Summary		Get wishlist
+//	@Description	Method provides to get wishlist of the user with items,
+//	@Description	nil id 00000000-0000-0000-0000-000000000000 is the default list of favourites.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path		string	true	"id of wishlist"
+//	@Param			currency	query		string	false	"Currency of display prices, e.g. USD"
+//	@Success		200			{object}	wishlist.Wishlist
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/wishlists/{wishlistID} [get]
+func (delivery *Delivery) GetWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetWishlist()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return
+	}
+	modelWishlist, err := delivery.wishlistUsecase.GetWishlist(c.Request.Context(), userId, id)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, wishlistToDelivery(modelWishlist, display))
+}
+
+// GetSharedWishlist - get shared wishlist by token of the public link
+//
+//	@Summary		Get shared wishlist
+//	@Description	Method provides to read the shared wishlist with items without login.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			token		path		string	true	"token of shared wishlist"
+//	@Param			currency	query		string	false	"Currency of display prices, e.g. USD"
+//	@Success		200			{object}	wishlist.Wishlist
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/wishlists/shared/{token} [get]
+func (delivery *Delivery) GetSharedWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery GetSharedWishlist()")
+	display, ok := delivery.displayCurrency(c)
+	if !ok {
+		return
+	}
+	modelWishlist, err := delivery.wishlistUsecase.GetSharedWishlist(c.Request.Context(), c.Param("token"))
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	shared := wishlistToDelivery(modelWishlist, display)
+	// The token is known by the reader, it isn't shown as the property of the owner
+	shared.ShareToken = ""
+	c.JSON(http.StatusOK, shared)
+}
+
+// RenameWishlist - change name of the wishlist
+//
+//	@Summary		Method provides to rename wishlist
+//	@Description	Method provides to change name of the named wishlist, the default list can't be renamed.
+//	@Tags			wishlists
+//	@Accept			json
+//	@Produce		json
+//	@Param			wishlistID	path	string					true	"id of wishlist"
+//	@Param			wishlist	body	wishlist.ShortWishlist	true	"New name of wishlist"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Default wishlist can't be renamed"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/update/{wishlistID} [put]
+func (delivery *Delivery) RenameWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery RenameWishlist()")
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return
+	}
+	var shortWishlist wishlist.ShortWishlist
+	if err := c.ShouldBindJSON(&shortWishlist); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	err := delivery.wishlistUsecase.RenameWishlist(c.Request.Context(), userId, id, shortWishlist.Name)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteWishlist - delete wishlist with its items
+//
+//	@Summary		Method provides to delete wishlist
+//	@Description	Method provides to delete named wishlist with its items, the default list can't be deleted.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path	string	true	"id of wishlist"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Default wishlist can't be deleted"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/delete/{wishlistID} [delete]
+func (delivery *Delivery) DeleteWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteWishlist()")
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return
+	}
+	err := delivery.wishlistUsecase.DeleteWishlist(c.Request.Context(), userId, id)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ShareWishlist - create public read-only link of the wishlist
+//
+//	@Summary		Method provides to share wishlist
+//	@Description	Method provides to create unguessable token of the public read-only link of the named
+//	@Description	wishlist, the previous token stops working. The default list can't be shared.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path		string	true	"id of wishlist"
+//	@Success		200			{object}	wishlist.Share
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Default wishlist can't be shared"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/wishlists/share/{wishlistID} [post]
+func (delivery *Delivery) ShareWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery ShareWishlist()")
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return
+	}
+	token, err := delivery.wishlistUsecase.ShareWishlist(c.Request.Context(), userId, id)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, wishlist.Share{Token: token})
+}
+
+// UnshareWishlist - stop sharing of the wishlist
+//
+//	@Summary		Method provides to stop sharing of wishlist
+//	@Description	Method provides to remove the public link of the wishlist.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path	string	true	"id of wishlist"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Default wishlist can't be shared"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/share/{wishlistID} [delete]
+func (delivery *Delivery) UnshareWishlist(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery UnshareWishlist()")
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return
+	}
+	err := delivery.wishlistUsecase.UnshareWishlist(c.Request.Context(), userId, id)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// AddWishlistItem - add item to the wishlist
+//
+//	@Summary		Method provides to add item to wishlist
+//	@Description	Method provides to add item to the wishlist, nil id is the default list of favourites.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path	string	true	"id of wishlist"
+//	@Param			itemID		path	string	true	"id of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/addItem/{wishlistID}/{itemID} [put]
+func (delivery *Delivery) AddWishlistItem(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery AddWishlistItem()")
+	userId, id, itemId, ok := delivery.userWishlistItem(c)
+	if !ok {
+		return
+	}
+	err := delivery.wishlistUsecase.AddWishlistItem(c.Request.Context(), userId, id, itemId)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteWishlistItem - delete item from the wishlist
+//
+//	@Summary		Method provides to delete item from wishlist
+//	@Description	Method provides to delete item from the wishlist, nil id is the default list of favourites.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path	string	true	"id of wishlist"
+//	@Param			itemID		path	string	true	"id of item"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/deleteItem/{wishlistID}/{itemID} [delete]
+func (delivery *Delivery) DeleteWishlistItem(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery DeleteWishlistItem()")
+	userId, id, itemId, ok := delivery.userWishlistItem(c)
+	if !ok {
+		return
+	}
+	err := delivery.wishlistUsecase.DeleteWishlistItem(c.Request.Context(), userId, id, itemId)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// MoveWishlistItem - move item to other wishlist
+//
+//	@Summary		Method provides to move item between wishlists
+//	@Description	Method provides to move item from the wishlist to other wishlist of the user,
+//	@Description	nil id is the default list of favourites.
+//	@Tags			wishlists
+//	@Accept			json
+//	@Produce		json
+//	@Param			wishlistID	path	string				true	"id of source wishlist"
+//	@Param			move		body	wishlist.MoveItem	true	"Item id and id of target wishlist"
+//	@Success		200
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		403	"Forbidden"
+//	@Failure		404	{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422	{object}	ErrorResponse	"Item is moved to the same wishlist"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/wishlists/move/{wishlistID} [put]
+func (delivery *Delivery) MoveWishlistItem(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery MoveWishlistItem()")
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return
+	}
+	var move wishlist.MoveItem
+	if err := c.ShouldBindJSON(&move); err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return
+	}
+	// Ids are validated by binding
+	itemId := uuid.MustParse(move.ItemId)
+	toId := uuid.MustParse(move.ToWishlistId)
+	err := delivery.wishlistUsecase.MoveWishlistItem(c.Request.Context(), userId, id, toId, itemId)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// MoveWishlistItemToCart - move item from the wishlist to the cart
+//
+//	@Summary		Method provides to move item from wishlist to cart
+//	@Description	Method provides to add item of the wishlist to the cart of the user and to remove it
+//	@Description	from the wishlist. The cart is created if the user has no cart.
+//	@Tags			wishlists
+//	@Produce		json
+//	@Param			wishlistID	path		string	true	"id of wishlist"
+//	@Param			itemID		path		string	true	"id of item"
+//	@Success		200			{object}	wishlist.CartId
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		403			"Forbidden"
+//	@Failure		404			{object}	ErrorResponse	"404 Not Found"
+//	@Failure		422			{object}	ErrorResponse	"Maximal quantity of the item is already in the cart"
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/wishlists/toCart/{wishlistID}/{itemID} [put]
+func (delivery *Delivery) MoveWishlistItemToCart(c *gin.Context) {
+	delivery.logger.Debug("Enter in delivery MoveWishlistItemToCart()")
+	userId, id, itemId, ok := delivery.userWishlistItem(c)
+	if !ok {
+		return
+	}
+	cartId, err := delivery.wishlistUsecase.MoveItemToCart(c.Request.Context(), userId, id, itemId)
+	if delivery.wishlistError(c, err) {
+		return
+	}
+	c.JSON(http.StatusOK, wishlist.CartId{Value: cartId.String()})
+}
+
+// claimsUser returns id of the user of the request
+// or writes error response if claims are incorrect
+func (delivery *Delivery) claimsUser(c *gin.Context) (uuid.UUID, bool) {
+	userCr, ok := c.MustGet("claims").(*jwtauth.Payload)
+	if !ok {
+		err := fmt.Errorf("incorrect claims")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return uuid.Nil, false
+	}
+	return userCr.UserId, true
+}
+
+// userWishlist returns id of the user and id of the wishlist of the request
+func (delivery *Delivery) userWishlist(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userId, ok := delivery.claimsUser(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(c.Param("wishlistID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userId, id, true
+}
+
+// userWishlistItem returns id of the user, id of the wishlist and id of the item of the request
+func (delivery *Delivery) userWishlistItem(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userId, id, ok := delivery.userWishlist(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	itemId, err := uuid.Parse(c.Param("itemID"))
+	if err != nil {
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	return userId, id, itemId, true
+}
+
+// wishlistError writes to the response error of operation with the wishlist,
+// true is returned if there was an error
+func (delivery *Delivery) wishlistError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, models.ErrorNotFound{}):
+		err = fmt.Errorf("wishlist or item not found")
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusNotFound, err)
+	case errors.Is(err, models.ErrInvalidWishlistName):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusBadRequest, err)
+	case errors.Is(err, models.ErrDefaultWishlist),
+		errors.Is(err, models.ErrSameWishlist),
+		errors.Is(err, models.ErrQuantityLimit):
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusUnprocessableEntity, err)
+	default:
+		delivery.logger.Error(err.Error())
+		delivery.SetError(c, http.StatusInternalServerError, err)
+	}
+	return true
+}
+
+// wishlistToDelivery converts wishlist, display prices of items
+// are set if display currency is requested
+func wishlistToDelivery(modelWishlist *models.Wishlist, display *displayPrices) wishlist.Wishlist {
+	out := wishlist.Wishlist{
+		Id:         modelWishlist.Id.String(),
+		Name:       modelWishlist.Name,
+		Default:    modelWishlist.IsDefault(),
+		ShareToken: modelWishlist.ShareToken,
+		ItemsCount: modelWishlist.ItemsCount,
+	}
+	if !modelWishlist.CreatedAt.IsZero() {
+		out.CreatedAt = &modelWishlist.CreatedAt
+	}
+	for _, modelsItem := range modelWishlist.Items {
+		out.Items = append(out.Items, item.OutItem{
+			Id:          modelsItem.Id.String(),
+			Title:       modelsItem.Title,
+			Description: modelsItem.Description,
+			Category: category.Category{
+				Id:          modelsItem.Category.Id.String(),
+				Name:        modelsItem.Category.Name,
+				Description: modelsItem.Category.Description,
+				Image:       modelsItem.Category.Image,
+			},
+			Price:          modelsItem.Price,
+			Currency:       modelsItem.Currency,
+			FormattedPrice: modelsItem.Money().Format(),
+			DisplayPrice:   display.price(modelsItem.Money()),
+			Vendor:         modelsItem.Vendor,
+			Images:         modelsItem.ImageURLs(),
+			ImageVariants:  itemImages(modelsItem.Images),
+			Rating:         modelsItem.Rating,
+			ReviewsCount:   modelsItem.ReviewsCount,
+			IsFavourite:    modelWishlist.IsDefault(),
+		})
+	}
+	return out
+}
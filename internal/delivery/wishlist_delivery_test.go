@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/delivery/wishlist"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/usecase/mocks"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newWishlistContext(userId uuid.UUID, params map[string]string, content interface{}, method string) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = &http.Request{
+		Header: make(http.Header),
+		URL:    &url.URL{},
+	}
+	for key, value := range params {
+		c.Params = append(c.Params, gin.Param{Key: key, Value: value})
+	}
+	c.Set("claims", &jwtauth.Payload{UserId: userId})
+	if content != nil {
+		MockJson(c, content, method)
+	}
+	return w, c
+}
+
+func TestCreateWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	wishlistUsecase := mocks.NewMockIWishlistUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Wishlist: wishlistUsecase}, zap.L(), nil, nil)
+	userId := uuid.New()
+
+	w, c := newWishlistContext(userId, nil, wishlist.ShortWishlist{}, "POST")
+	delivery.CreateWishlist(c)
+	require.Equal(t, 400, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrInvalidWishlistName, 400},
+		{fmt.Errorf("error"), 500},
+		{nil, 201},
+	}
+	id := uuid.New()
+	for _, test := range tests {
+		w, c = newWishlistContext(userId, nil, wishlist.ShortWishlist{Name: "Gifts"}, "POST")
+		wishlistUsecase.EXPECT().CreateWishlist(ctx, userId, "Gifts").Return(id, test.err)
+		delivery.CreateWishlist(c)
+		require.Equal(t, test.code, w.Code)
+	}
+	var res wishlist.WishlistId
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, id.String(), res.Value)
+}
+
+func TestGetSharedWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	wishlistUsecase := mocks.NewMockIWishlistUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Wishlist: wishlistUsecase}, zap.L(), nil, nil)
+
+	w, c := newWishlistContext(uuid.Nil, map[string]string{"token": "unknown"}, nil, "")
+	wishlistUsecase.EXPECT().GetSharedWishlist(ctx, "unknown").Return(nil, models.ErrorNotFound{})
+	delivery.GetSharedWishlist(c)
+	require.Equal(t, 404, w.Code)
+
+	id := uuid.New()
+	w, c = newWishlistContext(uuid.Nil, map[string]string{"token": "token"}, nil, "")
+	wishlistUsecase.EXPECT().GetSharedWishlist(ctx, "token").Return(&models.Wishlist{
+		Id:         id,
+		Name:       "Gifts",
+		ShareToken: "token",
+		ItemsCount: 1,
+		Items:      []models.Item{{Id: testId, Title: "Item", Price: 1000, Currency: "RUB"}},
+	}, nil)
+	delivery.GetSharedWishlist(c)
+	require.Equal(t, 200, w.Code)
+	var res wishlist.Wishlist
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, id.String(), res.Id)
+	require.Empty(t, res.ShareToken)
+	require.Len(t, res.Items, 1)
+	require.False(t, res.Items[0].IsFavourite)
+}
+
+func TestChangeWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	wishlistUsecase := mocks.NewMockIWishlistUsecase(ctrl)
+	delivery := NewDelivery(Usecases{Wishlist: wishlistUsecase}, zap.L(), nil, nil)
+	userId := uuid.New()
+	id := uuid.New()
+
+	w, c := newWishlistContext(userId, map[string]string{"wishlistID": "1"}, nil, "")
+	delivery.DeleteWishlist(c)
+	require.Equal(t, 400, w.Code)
+
+	// The default list can't be deleted
+	w, c = newWishlistContext(userId, map[string]string{"wishlistID": uuid.Nil.String()}, nil, "")
+	wishlistUsecase.EXPECT().DeleteWishlist(ctx, userId, uuid.Nil).Return(models.ErrDefaultWishlist)
+	delivery.DeleteWishlist(c)
+	require.Equal(t, 422, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"wishlistID": id.String()}, wishlist.MoveItem{ItemId: "1", ToWishlistId: uuid.Nil.String()}, "PUT")
+	delivery.MoveWishlistItem(c)
+	require.Equal(t, 400, w.Code)
+
+	w, c = newWishlistContext(userId, map[string]string{"wishlistID": id.String()}, wishlist.MoveItem{ItemId: testId.String(), ToWishlistId: uuid.Nil.String()}, "PUT")
+	wishlistUsecase.EXPECT().MoveWishlistItem(ctx, userId, id, uuid.Nil, testId).Return(nil)
+	delivery.MoveWishlistItem(c)
+	require.Equal(t, 200, w.Code)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{models.ErrorNotFound{}, 404},
+		{fmt.Errorf("%w: maximal quantity is already in the cart", models.ErrQuantityLimit), 422},
+		{fmt.Errorf("error"), 500},
+		{nil, 200},
+	}
+	cartId := uuid.New()
+	for _, test := range tests {
+		w, c = newWishlistContext(userId, map[string]string{"wishlistID": id.String(), "itemID": testId.String()}, nil, "")
+		wishlistUsecase.EXPECT().MoveItemToCart(ctx, userId, id, testId).Return(cartId, test.err)
+		delivery.MoveWishlistItemToCart(c)
+		require.Equal(t, test.code, w.Code)
+	}
+	var res wishlist.CartId
+	err := json.NewDecoder(w.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Equal(t, cartId.String(), res.Value)
+}
@@ -1,10 +1,16 @@
 package filestorage
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -17,8 +23,27 @@ type FileStorager interface {
 	DeleteCategoryImage(id string, filename string) error
 	DeleteCategoryImageById(id string) error
 	DeleteItemImagesFolderById(id string) error
+	ServeFile(w http.ResponseWriter, r *http.Request, name string) error
+	UploadURL(name string, ttl time.Duration) (string, error)
+	PutUpload(name string, r io.Reader) error
+	GetUpload(name string) (io.ReadCloser, error)
+	DeleteUpload(name string) error
+	PutInvoice(name string, file []byte) error
+	GetInvoice(name string) (io.ReadCloser, error)
+	DeleteInvoice(name string) error
+	ListFiles() ([]StoredFile, error)
+	FileName(fileURL string) (string, bool)
+	DeleteFile(name string) error
 }
 
+// uploadsFolder keeps files uploaded by clients before processing,
+// they are not served
+const uploadsFolder = "uploads"
+
+// invoicesFolder keeps invoices of orders with personal data of buyers,
+// they are not served and given only by the service
+const invoicesFolder = "invoices"
+
 type FileInStorageInfo struct {
 	Name       string `json:"Name"`
 	Path       string `json:"Path"`
@@ -26,6 +51,20 @@ type FileInStorageInfo struct {
 	ModifyDate string `json:"ModifyDate"`
 }
 
+// StoredFile is a file of images with name relative to the root of the storage
+type StoredFile struct {
+	Name    string
+	ModTime time.Time
+}
+
+// Permissions of stored files, they are read only by the service
+const (
+	dirMode  fs.FileMode = 0700
+	fileMode fs.FileMode = 0600
+)
+
+var _ FileStorager = &OnDiskLocalStorage{}
+
 type OnDiskLocalStorage struct {
 	serverURL string
 	path      string
@@ -40,16 +79,13 @@ func NewOnDiskLocalStorage(url string, path string, logger *zap.Logger) *OnDiskL
 
 func (imagestorage *OnDiskLocalStorage) PutItemImage(id string, filename string, file []byte) (filePath string, err error) {
 	imagestorage.logger.Sugar().Debugf("Enter in filestorage PutItemImage() with args: id: %s, filename: %s, file", id, filename)
-	_, err = os.Stat(imagestorage.path + "items/" + id)
-	if os.IsNotExist(err) {
-		err = os.Mkdir(imagestorage.path+"items/"+id, 0700)
-		if err != nil {
-			imagestorage.logger.Debug(fmt.Sprintf("error on create dir for save image %v", err))
-			return "", fmt.Errorf("error on create dir for save image: %w", err)
-		}
+	err = os.MkdirAll(imagestorage.path+"items/"+id, dirMode)
+	if err != nil {
+		imagestorage.logger.Debug(fmt.Sprintf("error on create dir for save image %v", err))
+		return "", fmt.Errorf("error on create dir for save image: %w", err)
 	}
 	filePath = imagestorage.path + "items/" + id + "/" + filename
-	if err := os.WriteFile(filePath, file, os.ModePerm); err != nil {
+	if err := os.WriteFile(filePath, file, fileMode); err != nil {
 		imagestorage.logger.Debug(fmt.Sprintf("error on filestorage put file: %v", err))
 		return "", fmt.Errorf("error on filestorage put file: %w", err)
 	}
@@ -60,16 +96,13 @@ func (imagestorage *OnDiskLocalStorage) PutItemImage(id string, filename string,
 
 func (imagestorage *OnDiskLocalStorage) PutCategoryImage(id string, filename string, file []byte) (filePath string, err error) {
 	imagestorage.logger.Sugar().Debugf("Enter in filestorage PutCategoryImage() with args: id: %s, filename: %s, file", id, filename)
-	_, err = os.Stat(imagestorage.path + "categories/" + id)
-	if os.IsNotExist(err) {
-		err = os.Mkdir(imagestorage.path+"categories/"+id, 0700)
-		if err != nil {
-			imagestorage.logger.Debug(fmt.Sprintf("error on create dir for save image %v", err))
-			return "", fmt.Errorf("error on create dir for save image: %w", err)
-		}
+	err = os.MkdirAll(imagestorage.path+"categories/"+id, dirMode)
+	if err != nil {
+		imagestorage.logger.Debug(fmt.Sprintf("error on create dir for save image %v", err))
+		return "", fmt.Errorf("error on create dir for save image: %w", err)
 	}
 	filePath = imagestorage.path + "categories/" + id + "/" + filename
-	if err := os.WriteFile(filePath, file, os.ModePerm); err != nil {
+	if err := os.WriteFile(filePath, file, fileMode); err != nil {
 		imagestorage.logger.Debug(fmt.Sprintf("error on filestorage put file: %v", err))
 		return "", fmt.Errorf("error on filestorage put file: %w", err)
 	}
@@ -146,3 +179,207 @@ func (imagestorage *OnDiskLocalStorage) GetFileList() ([]FileInStorageInfo, erro
 	}
 	return result, nil
 }
+
+// ServeFile writes the file from the storage to the response
+func (imagestorage *OnDiskLocalStorage) ServeFile(w http.ResponseWriter, r *http.Request, name string) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage ServeFile() with args: w, r, name: %s", name)
+	name, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+	filePath := filepath.Join(imagestorage.path, filepath.FromSlash(name))
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("error on get file info: %w", err)
+	}
+	// Folders are not listed
+	if info.IsDir() {
+		return fmt.Errorf("%s is a folder: %w", name, fs.ErrNotExist)
+	}
+	http.ServeFile(w, r, filePath)
+	return nil
+}
+
+// cleanName returns name of file relative to root of the storage, names
+// leading out of the storage, to not processed uploads or to invoices are not allowed
+func cleanName(name string) (string, error) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" || strings.Contains(name, "\\") || privateFile(name) {
+		return "", fmt.Errorf("invalid file name %q: %w", name, fs.ErrNotExist)
+	}
+	return name, nil
+}
+
+// UploadURL returns url of the service to upload the file once,
+// ttl is checked by the upload session
+func (imagestorage *OnDiskLocalStorage) UploadURL(name string, ttl time.Duration) (string, error) {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage UploadURL() with args: name: %s, ttl: %v", name, ttl)
+	return imagestorage.serverURL + "/files/" + uploadsFolder + "/" + name, nil
+}
+
+// PutUpload writes uploaded file, the file can be written only once
+func (imagestorage *OnDiskLocalStorage) PutUpload(name string, r io.Reader) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage PutUpload() with args: name: %s, r", name)
+	err := os.MkdirAll(filepath.Join(imagestorage.path, uploadsFolder), dirMode)
+	if err != nil {
+		return fmt.Errorf("error on create dir for upload: %w", err)
+	}
+	filePath := imagestorage.uploadPath(name)
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fileMode)
+	if err != nil {
+		return fmt.Errorf("error on create upload file: %w", err)
+	}
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Partially written file is removed, so the upload can be repeated
+		if removeErr := os.Remove(filePath); removeErr != nil {
+			imagestorage.logger.Sugar().Errorf("error on remove upload file: %v", removeErr)
+		}
+		return fmt.Errorf("error on write upload file: %w", err)
+	}
+	imagestorage.logger.Sugar().Infof("Upload %s put success", name)
+	return nil
+}
+
+func (imagestorage *OnDiskLocalStorage) GetUpload(name string) (io.ReadCloser, error) {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage GetUpload() with args: name: %s", name)
+	file, err := os.Open(imagestorage.uploadPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("error on open upload file: %w", err)
+	}
+	return file, nil
+}
+
+// DeleteUpload deletes uploaded file, missing file is not an error
+func (imagestorage *OnDiskLocalStorage) DeleteUpload(name string) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage DeleteUpload() with args: name: %s", name)
+	err := os.Remove(imagestorage.uploadPath(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error on delete upload file: %w", err)
+	}
+	return nil
+}
+
+func (imagestorage *OnDiskLocalStorage) uploadPath(name string) string {
+	return filepath.Join(imagestorage.path, uploadsFolder, filepath.Base(name))
+}
+
+// PutInvoice saves the invoice, the existing invoice with the name is replaced
+func (imagestorage *OnDiskLocalStorage) PutInvoice(name string, file []byte) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage PutInvoice() with args: name: %s, file", name)
+	err := os.MkdirAll(filepath.Join(imagestorage.path, invoicesFolder), dirMode)
+	if err != nil {
+		return fmt.Errorf("error on create dir for invoice: %w", err)
+	}
+	err = os.WriteFile(imagestorage.invoicePath(name), file, fileMode)
+	if err != nil {
+		return fmt.Errorf("error on write invoice file: %w", err)
+	}
+	imagestorage.logger.Sugar().Infof("Invoice %s put success", name)
+	return nil
+}
+
+func (imagestorage *OnDiskLocalStorage) GetInvoice(name string) (io.ReadCloser, error) {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage GetInvoice() with args: name: %s", name)
+	file, err := os.Open(imagestorage.invoicePath(name))
+	if err != nil {
+		return nil, fmt.Errorf("error on open invoice file: %w", err)
+	}
+	return file, nil
+}
+
+// DeleteInvoice deletes the invoice, missing file is not an error
+func (imagestorage *OnDiskLocalStorage) DeleteInvoice(name string) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage DeleteInvoice() with args: name: %s", name)
+	err := os.Remove(imagestorage.invoicePath(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error on delete invoice file: %w", err)
+	}
+	return nil
+}
+
+func (imagestorage *OnDiskLocalStorage) invoicePath(name string) string {
+	return filepath.Join(imagestorage.path, invoicesFolder, filepath.Base(name))
+}
+
+// privateFile reports whether the file is in the folder which isn't served
+// and doesn't keep images
+func privateFile(name string) bool {
+	return strings.HasPrefix(name, uploadsFolder+"/") || strings.HasPrefix(name, invoicesFolder+"/")
+}
+
+// ListFiles returns all files of images in the storage, not processed
+// uploads and invoices are not listed
+func (imagestorage *OnDiskLocalStorage) ListFiles() ([]StoredFile, error) {
+	imagestorage.logger.Debug("Enter in filestorage ListFiles()")
+	result := make([]StoredFile, 0)
+	err := filepath.WalkDir(imagestorage.path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Storage without files is empty, not broken
+			if filePath == imagestorage.path && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		name, err := filepath.Rel(imagestorage.path, filePath)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if entry.IsDir() {
+			if name == uploadsFolder || name == invoicesFolder {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		result = append(result, StoredFile{Name: name, ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error on list files: %w", err)
+	}
+	return result, nil
+}
+
+// FileName returns name of the file by its url, urls
+// of other storages are not recognized
+func (imagestorage *OnDiskLocalStorage) FileName(fileURL string) (string, bool) {
+	return fileNameByPrefix(fileURL, imagestorage.serverURL+"/files/")
+}
+
+// DeleteFile deletes the file by name relative to the root of the storage
+func (imagestorage *OnDiskLocalStorage) DeleteFile(name string) error {
+	imagestorage.logger.Sugar().Debugf("Enter in filestorage DeleteFile() with args: name: %s", name)
+	name, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(imagestorage.path, filepath.FromSlash(name)))
+	if err != nil {
+		return fmt.Errorf("error on delete file: %w", err)
+	}
+	imagestorage.logger.Sugar().Infof("File %s delete success", name)
+	return nil
+}
+
+// fileNameByPrefix returns name of the file from its url with one of the prefixes
+func fileNameByPrefix(fileURL string, prefixes ...string) (string, bool) {
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(fileURL, prefix) {
+			continue
+		}
+		name, err := cleanName(strings.TrimPrefix(fileURL, prefix))
+		if err != nil {
+			return "", false
+		}
+		return name, true
+	}
+	return "", false
+}
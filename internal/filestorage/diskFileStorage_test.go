@@ -0,0 +1,94 @@
+package filestorage
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestOnDiskLocalStorage(t *testing.T) {
+	root := t.TempDir()
+	storage := NewOnDiskLocalStorage("http://localhost:8000", root+"/", zap.L())
+
+	path, err := storage.PutItemImage("1", "test.jpeg", []byte("image"))
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8000/files/items/1/test.jpeg", path)
+	info, err := os.Stat(filepath.Join(root, "items", "1", "test.jpeg"))
+	require.NoError(t, err)
+	require.Equal(t, fileMode, info.Mode().Perm())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/files/items/1/test.jpeg", nil)
+	require.NoError(t, storage.ServeFile(w, r, "/items/1/test.jpeg"))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "image", w.Body.String())
+
+	// Files out of the storage and folders are not served
+	for _, name := range []string{"/items/1", "/../../etc/passwd", "/items/1/missing.jpeg", "/"} {
+		w = httptest.NewRecorder()
+		err = storage.ServeFile(w, r, name)
+		require.ErrorIs(t, err, fs.ErrNotExist, name)
+	}
+
+	require.NoError(t, storage.DeleteItemImage("1", "test.jpeg"))
+	require.NoError(t, storage.DeleteItemImagesFolderById("1"))
+}
+
+func TestOnDiskLocalStorageFiles(t *testing.T) {
+	root := t.TempDir()
+	storage := NewOnDiskLocalStorage("http://localhost:8000", root+"/", zap.L())
+
+	files, err := storage.ListFiles()
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	path, err := storage.PutItemImage("1", "test.jpeg", []byte("image"))
+	require.NoError(t, err)
+	_, err = storage.PutCategoryImage("2", "test.png", []byte("image"))
+	require.NoError(t, err)
+	require.NoError(t, storage.PutUpload("3", strings.NewReader("upload")))
+	require.NoError(t, storage.PutInvoice("4.pdf", []byte("invoice")))
+
+	// Not processed uploads and invoices are not files of images
+	files, err = storage.ListFiles()
+	require.NoError(t, err)
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		require.False(t, file.ModTime.IsZero())
+		names = append(names, file.Name)
+	}
+	require.ElementsMatch(t, []string{"items/1/test.jpeg", "categories/2/test.png"}, names)
+
+	name, ok := storage.FileName(path)
+	require.True(t, ok)
+	require.Equal(t, "items/1/test.jpeg", name)
+	for _, fileURL := range []string{"https://example.com/files/items/1/test.jpeg", "http://localhost:8000/files/uploads/3", ""} {
+		_, ok = storage.FileName(fileURL)
+		require.False(t, ok, fileURL)
+	}
+
+	// Invoices are given only by the service
+	w := httptest.NewRecorder()
+	err = storage.ServeFile(w, httptest.NewRequest(http.MethodGet, "/files/invoices/4.pdf", nil), "/invoices/4.pdf")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	invoice, err := storage.GetInvoice("4.pdf")
+	require.NoError(t, err)
+	data, err := io.ReadAll(invoice)
+	require.NoError(t, err)
+	require.NoError(t, invoice.Close())
+	require.Equal(t, "invoice", string(data))
+	require.NoError(t, storage.DeleteInvoice("4.pdf"))
+	require.NoError(t, storage.DeleteInvoice("4.pdf"))
+
+	require.NoError(t, storage.DeleteFile(name))
+	require.ErrorIs(t, storage.DeleteFile(name), fs.ErrNotExist)
+	require.ErrorIs(t, storage.DeleteFile("../outside"), fs.ErrNotExist)
+}
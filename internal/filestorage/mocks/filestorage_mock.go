@@ -6,7 +6,10 @@ package mocks
 
 import (
 	filestorage "OnlineShopBackend/internal/filestorage"
+	io "io"
+	http "net/http"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -62,6 +65,34 @@ func (mr *MockFileStoragerMockRecorder) DeleteCategoryImageById(id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryImageById", reflect.TypeOf((*MockFileStorager)(nil).DeleteCategoryImageById), id)
 }
 
+// DeleteFile mocks base method.
+func (m *MockFileStorager) DeleteFile(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockFileStoragerMockRecorder) DeleteFile(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileStorager)(nil).DeleteFile), name)
+}
+
+// DeleteInvoice mocks base method.
+func (m *MockFileStorager) DeleteInvoice(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvoice", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvoice indicates an expected call of DeleteInvoice.
+func (mr *MockFileStoragerMockRecorder) DeleteInvoice(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockFileStorager)(nil).DeleteInvoice), name)
+}
+
 // DeleteItemImage mocks base method.
 func (m *MockFileStorager) DeleteItemImage(id, filename string) error {
 	m.ctrl.T.Helper()
@@ -90,6 +121,35 @@ func (mr *MockFileStoragerMockRecorder) DeleteItemImagesFolderById(id interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItemImagesFolderById", reflect.TypeOf((*MockFileStorager)(nil).DeleteItemImagesFolderById), id)
 }
 
+// DeleteUpload mocks base method.
+func (m *MockFileStorager) DeleteUpload(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockFileStoragerMockRecorder) DeleteUpload(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockFileStorager)(nil).DeleteUpload), name)
+}
+
+// FileName mocks base method.
+func (m *MockFileStorager) FileName(fileURL string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileName", fileURL)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// FileName indicates an expected call of FileName.
+func (mr *MockFileStoragerMockRecorder) FileName(fileURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileName", reflect.TypeOf((*MockFileStorager)(nil).FileName), fileURL)
+}
+
 // GetFileList mocks base method.
 func (m *MockFileStorager) GetFileList() ([]filestorage.FileInStorageInfo, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +165,51 @@ func (mr *MockFileStoragerMockRecorder) GetFileList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockFileStorager)(nil).GetFileList))
 }
 
+// GetInvoice mocks base method.
+func (m *MockFileStorager) GetInvoice(name string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", name)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockFileStoragerMockRecorder) GetInvoice(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockFileStorager)(nil).GetInvoice), name)
+}
+
+// GetUpload mocks base method.
+func (m *MockFileStorager) GetUpload(name string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", name)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockFileStoragerMockRecorder) GetUpload(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockFileStorager)(nil).GetUpload), name)
+}
+
+// ListFiles mocks base method.
+func (m *MockFileStorager) ListFiles() ([]filestorage.StoredFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles")
+	ret0, _ := ret[0].([]filestorage.StoredFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockFileStoragerMockRecorder) ListFiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockFileStorager)(nil).ListFiles))
+}
+
 // PutCategoryImage mocks base method.
 func (m *MockFileStorager) PutCategoryImage(id, filename string, file []byte) (string, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +225,20 @@ func (mr *MockFileStoragerMockRecorder) PutCategoryImage(id, filename, file inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutCategoryImage", reflect.TypeOf((*MockFileStorager)(nil).PutCategoryImage), id, filename, file)
 }
 
+// PutInvoice mocks base method.
+func (m *MockFileStorager) PutInvoice(name string, file []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutInvoice", name, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutInvoice indicates an expected call of PutInvoice.
+func (mr *MockFileStoragerMockRecorder) PutInvoice(name, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutInvoice", reflect.TypeOf((*MockFileStorager)(nil).PutInvoice), name, file)
+}
+
 // PutItemImage mocks base method.
 func (m *MockFileStorager) PutItemImage(id, filename string, file []byte) (string, error) {
 	m.ctrl.T.Helper()
@@ -134,3 +253,46 @@ func (mr *MockFileStoragerMockRecorder) PutItemImage(id, filename, file interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutItemImage", reflect.TypeOf((*MockFileStorager)(nil).PutItemImage), id, filename, file)
 }
+
+// PutUpload mocks base method.
+func (m *MockFileStorager) PutUpload(name string, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutUpload", name, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutUpload indicates an expected call of PutUpload.
+func (mr *MockFileStoragerMockRecorder) PutUpload(name, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutUpload", reflect.TypeOf((*MockFileStorager)(nil).PutUpload), name, r)
+}
+
+// ServeFile mocks base method.
+func (m *MockFileStorager) ServeFile(w http.ResponseWriter, r *http.Request, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServeFile", w, r, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ServeFile indicates an expected call of ServeFile.
+func (mr *MockFileStoragerMockRecorder) ServeFile(w, r, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServeFile", reflect.TypeOf((*MockFileStorager)(nil).ServeFile), w, r, name)
+}
+
+// UploadURL mocks base method.
+func (m *MockFileStorager) UploadURL(name string, ttl time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadURL", name, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadURL indicates an expected call of UploadURL.
+func (mr *MockFileStoragerMockRecorder) UploadURL(name, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadURL", reflect.TypeOf((*MockFileStorager)(nil).UploadURL), name, ttl)
+}
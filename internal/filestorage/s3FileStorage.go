@@ -0,0 +1,535 @@
+package filestorage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+var _ FileStorager = &S3Storage{}
+
+// S3Options are settings of S3 compatible storage
+type S3Options struct {
+	// Endpoint is url of the storage, for example http://localhost:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle puts bucket in the path of url instead of the host,
+	// it is required by MinIO and other self-hosted storages
+	PathStyle bool
+	// PublicURL is url of the bucket with public read access. If it is
+	// empty, files are given by presigned urls through the service
+	PublicURL string
+	// PresignTTL is lifetime of presigned urls
+	PresignTTL time.Duration
+	// Timeout of requests to the storage
+	Timeout time.Duration
+}
+
+// S3Storage keeps files in the bucket of S3 compatible storage,
+// so files are shared by all instances of the service
+type S3Storage struct {
+	serverURL string
+	endpoint  *url.URL
+	options   S3Options
+	client    *http.Client
+	// now is used for signing requests, it is replaced in tests
+	now    func() time.Time
+	logger *zap.Logger
+}
+
+func NewS3Storage(serverURL string, options S3Options, logger *zap.Logger) (*S3Storage, error) {
+	logger.Sugar().Debugf("Enter in NewS3Storage() with args: serverURL: %s, endpoint: %s, bucket: %s, logger", serverURL, options.Endpoint, options.Bucket)
+	endpoint, err := url.Parse(options.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error on parse s3 endpoint: %w", err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %q", options.Endpoint)
+	}
+	if options.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is not set")
+	}
+	if options.Region == "" {
+		options.Region = "us-east-1"
+	}
+	return &S3Storage{
+		serverURL: serverURL,
+		endpoint:  endpoint,
+		options:   options,
+		client:    &http.Client{Timeout: options.Timeout},
+		now:       time.Now,
+		logger:    logger,
+	}, nil
+}
+
+func (storage *S3Storage) PutItemImage(id string, filename string, file []byte) (string, error) {
+	storage.logger.Sugar().Debugf("Enter in filestorage PutItemImage() with args: id: %s, filename: %s, file", id, filename)
+	return storage.put("items/"+id+"/"+filename, file)
+}
+
+func (storage *S3Storage) PutCategoryImage(id string, filename string, file []byte) (string, error) {
+	storage.logger.Sugar().Debugf("Enter in filestorage PutCategoryImage() with args: id: %s, filename: %s, file", id, filename)
+	return storage.put("categories/"+id+"/"+filename, file)
+}
+
+func (storage *S3Storage) DeleteItemImage(id string, filename string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteItemImage() with args: id: %s, filename: %s", id, filename)
+	return storage.delete("items/" + id + "/" + filename)
+}
+
+func (storage *S3Storage) DeleteCategoryImage(id string, filename string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteCategoryImage() with args: id: %s, filename: %s", id, filename)
+	return storage.delete("categories/" + id + "/" + filename)
+}
+
+func (storage *S3Storage) DeleteCategoryImageById(id string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteCategoryImageById() with args: id: %s", id)
+	return storage.deletePrefix("categories/" + id + "/")
+}
+
+func (storage *S3Storage) DeleteItemImagesFolderById(id string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteItemImagesFolderById() with args: id: %s", id)
+	return storage.deletePrefix("items/" + id + "/")
+}
+
+func (storage *S3Storage) GetFileList() ([]FileInStorageInfo, error) {
+	storage.logger.Debug("Enter in filestorage GetFileList()")
+	objects, err := storage.list("")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]FileInStorageInfo, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, FileInStorageInfo{
+			Name:       path.Base(object.Key),
+			Path:       object.Key,
+			CreateDate: object.LastModified,
+			ModifyDate: object.LastModified,
+		})
+	}
+	return result, nil
+}
+
+// ServeFile redirects to the file by presigned url, so the file
+// is downloaded from the storage without the service
+func (storage *S3Storage) ServeFile(w http.ResponseWriter, r *http.Request, name string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage ServeFile() with args: w, r, name: %s", name)
+	key, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+	fileURL := storage.Presign(http.MethodGet, key, storage.options.PresignTTL)
+	// Browsers may reuse the redirect only while the url is valid
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(storage.options.PresignTTL.Seconds()/2)))
+	http.Redirect(w, r, fileURL, http.StatusFound)
+	return nil
+}
+
+// Presign returns url which allows to do request with given method
+// to the object without credentials until ttl expires
+func (storage *S3Storage) Presign(method string, key string, ttl time.Duration) string {
+	now := storage.now().UTC()
+	objectURL := storage.objectURL(key)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", storage.options.AccessKey+"/"+storage.scope(now))
+	query.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	objectURL.RawQuery = canonicalQuery(query)
+	header := http.Header{}
+	header.Set("Host", objectURL.Host)
+	signature := storage.signature(method, objectURL, header, s3UnsignedPayload, now)
+	objectURL.RawQuery += "&X-Amz-Signature=" + signature
+	return objectURL.String()
+}
+
+// UploadURL returns presigned url to upload the file directly to the storage
+func (storage *S3Storage) UploadURL(name string, ttl time.Duration) (string, error) {
+	storage.logger.Sugar().Debugf("Enter in filestorage UploadURL() with args: name: %s, ttl: %v", name, ttl)
+	return storage.Presign(http.MethodPut, uploadsFolder+"/"+name, ttl), nil
+}
+
+func (storage *S3Storage) PutUpload(name string, r io.Reader) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage PutUpload() with args: name: %s, r", name)
+	file, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error on read upload file: %w", err)
+	}
+	_, err = storage.do(http.MethodPut, storage.objectURL(uploadsFolder+"/"+name), http.Header{}, file)
+	if err != nil {
+		return fmt.Errorf("error on put upload file: %w", err)
+	}
+	return nil
+}
+
+// GetUpload returns reader of uploaded file, the file is not read in memory
+func (storage *S3Storage) GetUpload(name string) (io.ReadCloser, error) {
+	storage.logger.Sugar().Debugf("Enter in filestorage GetUpload() with args: name: %s", name)
+	resp, err := storage.send(http.MethodGet, storage.objectURL(uploadsFolder+"/"+name), http.Header{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error on get upload file: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (storage *S3Storage) DeleteUpload(name string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteUpload() with args: name: %s", name)
+	return storage.delete(uploadsFolder + "/" + name)
+}
+
+// PutInvoice saves the invoice, the existing invoice with the name is replaced
+func (storage *S3Storage) PutInvoice(name string, file []byte) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage PutInvoice() with args: name: %s, file", name)
+	_, err := storage.do(http.MethodPut, storage.objectURL(invoicesFolder+"/"+name), http.Header{}, file)
+	if err != nil {
+		return fmt.Errorf("error on put invoice file: %w", err)
+	}
+	return nil
+}
+
+func (storage *S3Storage) GetInvoice(name string) (io.ReadCloser, error) {
+	storage.logger.Sugar().Debugf("Enter in filestorage GetInvoice() with args: name: %s", name)
+	resp, err := storage.send(http.MethodGet, storage.objectURL(invoicesFolder+"/"+name), http.Header{}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error on get invoice file: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (storage *S3Storage) DeleteInvoice(name string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteInvoice() with args: name: %s", name)
+	return storage.delete(invoicesFolder + "/" + name)
+}
+
+// ListFiles returns all files of images in the storage, not processed
+// uploads and invoices are not listed
+func (storage *S3Storage) ListFiles() ([]StoredFile, error) {
+	storage.logger.Debug("Enter in filestorage ListFiles()")
+	objects, err := storage.list("")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]StoredFile, 0, len(objects))
+	for _, object := range objects {
+		if privateFile(object.Key) {
+			continue
+		}
+		modTime, err := time.Parse(time.RFC3339, object.LastModified)
+		if err != nil {
+			return nil, fmt.Errorf("error on parse modification time of %s: %w", object.Key, err)
+		}
+		result = append(result, StoredFile{Name: object.Key, ModTime: modTime})
+	}
+	return result, nil
+}
+
+// FileName returns name of the file by its url, both public
+// urls and urls of the service are recognized
+func (storage *S3Storage) FileName(fileURL string) (string, bool) {
+	prefixes := []string{storage.serverURL + "/files/"}
+	if storage.options.PublicURL != "" {
+		prefixes = append(prefixes, strings.TrimSuffix(storage.options.PublicURL, "/")+"/")
+	}
+	return fileNameByPrefix(fileURL, prefixes...)
+}
+
+// DeleteFile deletes the file by name relative to the root of the storage
+func (storage *S3Storage) DeleteFile(name string) error {
+	storage.logger.Sugar().Debugf("Enter in filestorage DeleteFile() with args: name: %s", name)
+	key, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+	return storage.delete(key)
+}
+
+func (storage *S3Storage) put(key string, file []byte) (string, error) {
+	header := http.Header{}
+	header.Set("Content-Type", http.DetectContentType(file))
+	_, err := storage.do(http.MethodPut, storage.objectURL(key), header, file)
+	if err != nil {
+		storage.logger.Debug(fmt.Sprintf("error on filestorage put file: %v", err))
+		return "", fmt.Errorf("error on filestorage put file: %w", err)
+	}
+	urlPath := storage.fileURL(key)
+	storage.logger.Sugar().Debugf("Put image success, urlPath: %s", urlPath)
+	return urlPath, nil
+}
+
+func (storage *S3Storage) delete(key string) error {
+	_, err := storage.do(http.MethodDelete, storage.objectURL(key), http.Header{}, nil)
+	if err != nil {
+		storage.logger.Debug(fmt.Sprintf("error on delete file: %v", err))
+		return fmt.Errorf("error on delete file: %w", err)
+	}
+	storage.logger.Sugar().Infof("File %s delete success", key)
+	return nil
+}
+
+// deletePrefix deletes all objects in the folder
+func (storage *S3Storage) deletePrefix(prefix string) error {
+	objects, err := storage.list(prefix)
+	if err != nil {
+		return fmt.Errorf("error on delete folder: %w", err)
+	}
+	for _, object := range objects {
+		err := storage.delete(object.Key)
+		if err != nil {
+			return fmt.Errorf("error on delete folder: %w", err)
+		}
+	}
+	storage.logger.Sugar().Infof("Folder %s delete success", prefix)
+	return nil
+}
+
+type s3Object struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	Size         int64  `xml:"Size"`
+}
+
+type s3ListResult struct {
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+// list returns all objects with given prefix
+func (storage *S3Storage) list(prefix string) ([]s3Object, error) {
+	var objects []s3Object
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		bucketURL := storage.objectURL("")
+		bucketURL.RawQuery = canonicalQuery(query)
+		body, err := storage.do(http.MethodGet, bucketURL, http.Header{}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error on list files: %w", err)
+		}
+		result := s3ListResult{}
+		err = xml.Unmarshal(body, &result)
+		if err != nil {
+			return nil, fmt.Errorf("error on decode list of files: %w", err)
+		}
+		objects = append(objects, result.Contents...)
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do sends signed request to the storage and returns body of the response
+func (storage *S3Storage) do(method string, requestURL *url.URL, header http.Header, body []byte) ([]byte, error) {
+	resp, err := storage.send(method, requestURL, header, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// send sends signed request to the storage, error responses are returned as
+// errors. Missing objects are reported with fs.ErrNotExist
+func (storage *S3Storage) send(method string, requestURL *url.URL, header http.Header, body []byte) (*http.Response, error) {
+	now := storage.now().UTC()
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	header.Set("Host", requestURL.Host)
+	header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	header.Set("X-Amz-Content-Sha256", payloadHash)
+	signedHeaders := signedHeaderNames(header)
+	signature := storage.signature(method, requestURL, header, payloadHash, now)
+
+	req, err := http.NewRequest(method, requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		if name != "Host" {
+			req.Header[name] = values
+		}
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, storage.options.AccessKey, storage.scope(now), strings.Join(signedHeaders, ";"), signature))
+
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = fmt.Errorf("status %d", resp.StatusCode)
+	s3Err := s3Error{}
+	if xml.Unmarshal(data, &s3Err) == nil && s3Err.Code != "" {
+		err = fmt.Errorf("%s: %s", s3Err.Code, s3Err.Message)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		err = fmt.Errorf("%w: %v", fs.ErrNotExist, err)
+	}
+	return nil, fmt.Errorf("s3 %s %s: %w", method, requestURL.Path, err)
+}
+
+// objectURL returns url of the object in the storage, empty key is the bucket
+func (storage *S3Storage) objectURL(key string) *url.URL {
+	objectURL := *storage.endpoint
+	basePath := strings.TrimSuffix(objectURL.Path, "/")
+	if storage.options.PathStyle {
+		objectURL.Path = basePath + "/" + storage.options.Bucket
+		if key != "" {
+			objectURL.Path += "/" + key
+		}
+	} else {
+		objectURL.Host = storage.options.Bucket + "." + objectURL.Host
+		objectURL.Path = basePath + "/" + key
+	}
+	objectURL.RawPath = uriEncode(objectURL.Path, false)
+	return &objectURL
+}
+
+// fileURL returns url of the file for clients
+func (storage *S3Storage) fileURL(key string) string {
+	if storage.options.PublicURL != "" {
+		return strings.TrimSuffix(storage.options.PublicURL, "/") + "/" + key
+	}
+	return storage.serverURL + "/files/" + key
+}
+
+func (storage *S3Storage) scope(t time.Time) string {
+	return t.Format(s3DateFormat) + "/" + storage.options.Region + "/" + s3Service + "/aws4_request"
+}
+
+// signature calculates signature version 4 of the request,
+// all given headers are signed
+func (storage *S3Storage) signature(method string, requestURL *url.URL, header http.Header, payloadHash string, t time.Time) string {
+	names := signedHeaderNames(header)
+	canonicalHeaders := &strings.Builder{}
+	for _, name := range names {
+		values := make([]string, 0, len(header.Values(name)))
+		for _, value := range header.Values(name) {
+			values = append(values, strings.Join(strings.Fields(value), " "))
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.Join(values, ",") + "\n")
+	}
+	canonicalPath := requestURL.EscapedPath()
+	if canonicalPath == "" {
+		canonicalPath = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalPath,
+		requestURL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(names, ";"),
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3TimeFormat),
+		storage.scope(t),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+storage.options.SecretKey), t.Format(s3DateFormat))
+	key = hmacSHA256(key, storage.options.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// signedHeaderNames returns sorted lowercase names of headers
+func signedHeaderNames(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// canonicalQuery encodes query with sorted keys as signature requires
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode escapes all characters except unreserved ones of RFC 3986
+func uriEncode(s string, encodeSlash bool) string {
+	builder := &strings.Builder{}
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			builder.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
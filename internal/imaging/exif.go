@@ -0,0 +1,118 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const (
+	markerPrefix   = 0xff
+	markerSOI      = 0xd8
+	markerSOS      = 0xda
+	markerAPP1     = 0xe1
+	tagOrientation = 0x0112
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// jpegOrientation returns EXIF orientation of JPEG image or 1 if it is not set.
+// Decoded images do not contain EXIF, so the orientation is applied to pixels
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != markerPrefix || data[1] != markerSOI {
+		return 1
+	}
+	for p := 2; p+4 <= len(data); {
+		if data[p] != markerPrefix {
+			return 1
+		}
+		marker := data[p+1]
+		if marker == markerSOS {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[p+2:]))
+		if length < 2 || p+2+length > len(data) {
+			return 1
+		}
+		segment := data[p+4 : p+2+length]
+		if marker == markerAPP1 && bytes.HasPrefix(segment, exifHeader) {
+			return tiffOrientation(segment[len(exifHeader):])
+		}
+		p += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation reads orientation tag from the first IFD of TIFF structure
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + 12*i
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == tagOrientation {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value < 1 || value > 8 {
+				return 1
+			}
+			return value
+		}
+	}
+	return 1
+}
+
+// orient transforms the image to show it upright by EXIF orientation
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	// Orientations from 5 to 8 swap width and height
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Flip horizontal
+				dx, dy = width-1-x, y
+			case 3: // Rotate 180
+				dx, dy = width-1-x, height-1-y
+			case 4: // Flip vertical
+				dx, dy = x, height-1-y
+			case 5: // Transpose
+				dx, dy = y, x
+			case 6: // Rotate 90 clockwise
+				dx, dy = height-1-y, x
+			case 7: // Transverse
+				dx, dy = height-1-y, width-1-x
+			case 8: // Rotate 90 counterclockwise
+				dx, dy = y, width-1-x
+			}
+			s := img.PixOffset(x+img.Rect.Min.X, y+img.Rect.Min.Y)
+			d := dst.PixOffset(dx, dy)
+			copy(dst.Pix[d:d+4], img.Pix[s:s+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,317 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+
+	"go.uber.org/zap"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Formats of images
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+)
+
+// Names of variants of image
+const (
+	VariantThumb  = "thumb"
+	VariantMedium = "medium"
+	VariantLarge  = "large"
+	VariantWebP   = "webp"
+)
+
+const (
+	// maxPixels limits the size of decoded image, so small
+	// files can not take all memory of the service
+	maxPixels   = 50_000_000
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooLarge          = errors.New("image is too large")
+	ErrInvalidImage      = errors.New("invalid image")
+)
+
+// Variant describes resized copy of uploaded image
+type Variant struct {
+	Name string
+	// MaxSize is a max width and height, images are not enlarged
+	MaxSize int
+	// Format of the variant, empty format means JPEG for JPEG
+	// images and PNG for others to keep transparency
+	Format string
+	// Sources are formats of the large variant the variant is made for,
+	// empty means all formats
+	Sources []string
+}
+
+// madeFor reports whether the variant is made for the image with the
+// large variant in the format
+func (variant Variant) madeFor(format string) bool {
+	if len(variant.Sources) == 0 {
+		return true
+	}
+	for _, source := range variant.Sources {
+		if source == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Variants are generated for every uploaded image. The large variant is the
+// main image, names of other variants are derived from its name. WebP is
+// lossless, so it is made only for images kept in PNG, for photos it is
+// larger than JPEG
+var Variants = []Variant{
+	{Name: VariantThumb, MaxSize: 200},
+	{Name: VariantMedium, MaxSize: 600},
+	{Name: VariantLarge, MaxSize: 1200},
+	{Name: VariantWebP, MaxSize: 1200, Format: FormatWebP, Sources: []string{FormatPNG}},
+}
+
+// Image is an encoded variant of uploaded image
+type Image struct {
+	Variant     string
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+type ImageProcessor interface {
+	Process(r io.Reader, base string) ([]Image, error)
+}
+
+var _ ImageProcessor = &Processor{}
+
+type Processor struct {
+	maxSize int64
+	logger  *zap.Logger
+}
+
+func NewProcessor(maxSize int64, logger *zap.Logger) *Processor {
+	logger.Sugar().Debugf("Enter in NewProcessor() with args: maxSize: %d, logger", maxSize)
+	return &Processor{maxSize: maxSize, logger: logger}
+}
+
+// Process validates uploaded image and returns all its variants, files of
+// variants are named by base name. Format is detected by content of the file.
+// Variants are decoded and encoded again, so they contain no metadata
+func (processor *Processor) Process(r io.Reader, base string) ([]Image, error) {
+	processor.logger.Sugar().Debugf("Enter in imaging Process() with args: r, base: %s", base)
+	data, err := io.ReadAll(io.LimitReader(r, processor.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error on read image: %w", err)
+	}
+	if int64(len(data)) > processor.maxSize {
+		return nil, fmt.Errorf("%w: size is more than %d bytes", ErrTooLarge, processor.maxSize)
+	}
+	format, err := Sniff(data)
+	if err != nil {
+		return nil, err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	orientation := 1
+	defaultFormat := FormatPNG
+	if format == FormatJPEG {
+		orientation = jpegOrientation(data)
+		defaultFormat = FormatJPEG
+	}
+
+	// Each variant is resized from the previous larger one,
+	// so the source image is scaled only once
+	variants := make([]Variant, len(Variants))
+	copy(variants, Variants)
+	sort.SliceStable(variants, func(i, j int) bool {
+		return variants[i].MaxSize > variants[j].MaxSize
+	})
+	resized := make(map[string]*image.RGBA, len(variants))
+	var current image.Image = src
+	for i, variant := range variants {
+		img := fit(current, variant.MaxSize)
+		if i == 0 {
+			img = orient(img, orientation)
+		}
+		resized[variant.Name] = img
+		current = img
+	}
+
+	images := make([]Image, 0, len(Variants))
+	for _, variant := range Variants {
+		if !variant.madeFor(defaultFormat) {
+			continue
+		}
+		variantFormat := variant.Format
+		if variantFormat == "" {
+			variantFormat = defaultFormat
+		}
+		buf := &bytes.Buffer{}
+		err := encode(buf, resized[variant.Name], variantFormat)
+		if err != nil {
+			return nil, fmt.Errorf("error on encode variant %s: %w", variant.Name, err)
+		}
+		images = append(images, Image{
+			Variant:     variant.Name,
+			Name:        variantName(base, variant.Name, variantFormat),
+			ContentType: "image/" + variantFormat,
+			Data:        buf.Bytes(),
+		})
+	}
+	processor.logger.Sugar().Debugf("Image %s processed, format: %s, size: %dx%d", base, format, config.Width, config.Height)
+	return images, nil
+}
+
+// Sniff returns format of image by its first bytes
+func Sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg":
+		return FormatJPEG, nil
+	case "image/png":
+		return FormatPNG, nil
+	case "image/gif":
+		return FormatGIF, nil
+	case "image/webp":
+		return FormatWebP, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, contentType)
+	}
+}
+
+// fit scales the image down to fit in square with side maxSize
+func fit(img image.Image, maxSize int) *image.RGBA {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		if rgba, ok := img.(*image.RGBA); ok {
+			return rgba
+		}
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+		return dst
+	}
+	if width > height {
+		height = max(1, height*maxSize/width)
+		width = maxSize
+	} else {
+		width = max(1, width*maxSize/height)
+		height = maxSize
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Src, nil)
+	return dst
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	case FormatPNG:
+		return png.Encode(w, img)
+	case FormatWebP:
+		return EncodeWebP(w, img)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+func variantName(base string, variant string, format string) string {
+	// WebP variant is the large image in other format
+	if variant == VariantWebP {
+		variant = VariantLarge
+	}
+	return base + "_" + variant + "." + format
+}
+
+// largeName matches names of large variants of processed images
+var largeName = regexp.MustCompile(`^(.+)_` + VariantLarge + `\.(` + FormatJPEG + `|` + FormatPNG + `)$`)
+
+// VariantNames returns names of files of all variants made for the image by
+// the name of large variant. Images uploaded before processing was added have
+// only one file, it is returned for all variants except WebP
+func VariantNames(name string) map[string]string {
+	names := make(map[string]string, len(Variants))
+	match := largeName.FindStringSubmatch(name)
+	if match == nil {
+		for _, variant := range Variants {
+			if variant.Format == "" {
+				names[variant.Name] = name
+			}
+		}
+		return names
+	}
+	for _, variant := range Variants {
+		if !variant.madeFor(match[2]) {
+			continue
+		}
+		format := variant.Format
+		if format == "" {
+			format = match[2]
+		}
+		names[variant.Name] = variantName(match[1], variant.Name, format)
+	}
+	return names
+}
+
+// VariantURLs returns urls of all variants by url of large variant
+func VariantURLs(url string) map[string]string {
+	if url == "" {
+		return nil
+	}
+	dir, name := path.Split(url)
+	urls := VariantNames(name)
+	for variant, name := range urls {
+		urls[variant] = dir + name
+	}
+	return urls
+}
+
+// FileNames returns unique names of files of all variants by the name of large variant
+func FileNames(name string) []string {
+	variantNames := VariantNames(name)
+	names := make([]string, 0, len(Variants))
+	seen := make(map[string]bool, len(Variants))
+	for _, variant := range Variants {
+		fileName, ok := variantNames[variant.Name]
+		if ok && !seen[fileName] {
+			seen[fileName] = true
+			names = append(names, fileName)
+		}
+	}
+	return names
+}
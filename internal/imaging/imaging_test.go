@@ -0,0 +1,166 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/image/webp"
+)
+
+func testImage(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// Left half is red, right half is blue
+			c := color.NRGBA{0xff, 0, 0, 0xff}
+			if x >= width/2 {
+				c = color.NRGBA{0, 0, 0xff, 0xff}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// withOrientation inserts EXIF segment with orientation after SOI marker
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], tagOrientation)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0)
+	segment := append(append([]byte{}, exifHeader...), tiff...)
+	header := []byte{markerPrefix, markerAPP1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+	result := append([]byte{}, data[:2]...)
+	result = append(result, header...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func TestProcessJPEG(t *testing.T) {
+	processor := NewProcessor(1<<20, zap.L())
+	buf := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buf, testImage(1600, 800), nil))
+	data := withOrientation(buf.Bytes(), 6)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	// Lossless WebP of the photo is larger than JPEG, so it isn't made
+	images, err := processor.Process(bytes.NewReader(data), "20230101120000")
+	require.NoError(t, err)
+	require.Len(t, images, 3)
+	sizes := map[string]image.Point{
+		VariantThumb:  {100, 200},
+		VariantMedium: {300, 600},
+		VariantLarge:  {600, 1200},
+	}
+	names := map[string]string{}
+	for _, img := range images {
+		names[img.Variant] = img.Name
+		// Variants are encoded again, so EXIF is removed
+		require.Equal(t, 1, jpegOrientation(img.Data), img.Variant)
+		require.Equal(t, "image/jpeg", img.ContentType)
+		decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+		require.NoError(t, err, img.Variant)
+		require.Equal(t, sizes[img.Variant], decoded.Bounds().Size(), img.Variant)
+		// Image is rotated clockwise, so the red half is on the top
+		r, _, b, _ := decoded.At(decoded.Bounds().Dx()/2, 10).RGBA()
+		require.Greater(t, r, b, img.Variant)
+	}
+	require.Equal(t, map[string]string{
+		VariantThumb:  "20230101120000_thumb.jpeg",
+		VariantMedium: "20230101120000_medium.jpeg",
+		VariantLarge:  "20230101120000_large.jpeg",
+	}, names)
+}
+
+func TestProcessPNG(t *testing.T) {
+	processor := NewProcessor(1<<20, zap.L())
+	img := testImage(100, 50)
+	img.SetNRGBA(0, 0, color.NRGBA{0, 0, 0, 0})
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+
+	images, err := processor.Process(buf, "name")
+	require.NoError(t, err)
+	require.Len(t, images, 4)
+	for _, processed := range images {
+		if processed.Variant == VariantWebP {
+			require.Equal(t, "image/webp", processed.ContentType)
+			require.Equal(t, "name_large.webp", processed.Name)
+			decoded, err := webp.Decode(bytes.NewReader(processed.Data))
+			require.NoError(t, err)
+			require.Equal(t, image.Pt(100, 50), decoded.Bounds().Size())
+			continue
+		}
+		require.Equal(t, "image/png", processed.ContentType)
+		decoded, err := png.Decode(bytes.NewReader(processed.Data))
+		require.NoError(t, err)
+		// Small images are not enlarged, transparency is kept
+		require.Equal(t, image.Pt(100, 50), decoded.Bounds().Size())
+		_, _, _, a := decoded.At(0, 0).RGBA()
+		require.Zero(t, a)
+	}
+}
+
+func TestProcessInvalid(t *testing.T) {
+	processor := NewProcessor(1<<10, zap.L())
+	_, err := processor.Process(strings.NewReader("<html></html>"), "name")
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = processor.Process(bytes.NewReader(make([]byte, 1<<10+1)), "name")
+	require.ErrorIs(t, err, ErrTooLarge)
+
+	// PNG signature with broken data
+	_, err = processor.Process(strings.NewReader("\x89PNG\r\n\x1a\n broken"), "name")
+	require.ErrorIs(t, err, ErrInvalidImage)
+
+	// Header of huge image in small file
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	_, err = processor.Process(bytes.NewReader(data), "name")
+	require.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestVariantURLs(t *testing.T) {
+	require.Equal(t, map[string]string{
+		VariantThumb:  "http://localhost:8000/files/items/1/name_thumb.png",
+		VariantMedium: "http://localhost:8000/files/items/1/name_medium.png",
+		VariantLarge:  "http://localhost:8000/files/items/1/name_large.png",
+		VariantWebP:   "http://localhost:8000/files/items/1/name_large.webp",
+	}, VariantURLs("http://localhost:8000/files/items/1/name_large.png"))
+
+	// Image uploaded before variants were added
+	require.Equal(t, map[string]string{
+		VariantThumb:  "http://localhost:8000/files/items/1/20221209194557.jpeg",
+		VariantMedium: "http://localhost:8000/files/items/1/20221209194557.jpeg",
+		VariantLarge:  "http://localhost:8000/files/items/1/20221209194557.jpeg",
+	}, VariantURLs("http://localhost:8000/files/items/1/20221209194557.jpeg"))
+	require.Nil(t, VariantURLs(""))
+
+	require.Equal(t, map[string]string{
+		VariantThumb:  "name_thumb.jpeg",
+		VariantMedium: "name_medium.jpeg",
+		VariantLarge:  "name_large.jpeg",
+	}, VariantNames("name_large.jpeg"))
+
+	require.Equal(t, []string{"name_thumb.png", "name_medium.png", "name_large.png", "name_large.webp"}, FileNames("name_large.png"))
+	require.Equal(t, []string{"name_thumb.jpeg", "name_medium.jpeg", "name_large.jpeg"}, FileNames("name_large.jpeg"))
+	require.Equal(t, []string{"20221209194557.jpeg"}, FileNames("20221209194557.jpeg"))
+}
@@ -0,0 +1,481 @@
+package imaging
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+)
+
+// The encoder writes lossless WebP (VP8L) images. The bitstream uses
+// the subtract green and the predictor transforms and separate prefix
+// codes for every channel without backward references, so it is larger
+// than the output of libwebp, but does not need cgo.
+// Format is described in https://developers.google.com/speed/webp/docs/webp_lossless_bitstream_specification
+
+const (
+	vp8lSignature     = 0x2f
+	vp8lMaxDimension  = 1 << 14
+	maxCodeLength     = 15
+	maxCodeLengthCode = 7
+	// predictorBits is a log-2 size of blocks of predictor transform,
+	// all blocks use the same predictor
+	predictorBits = 9
+	// predictorGradient is ClampAddSubtractFull(L, T, TL) predictor
+	predictorGradient = 12
+
+	transformPredictor     = 0
+	transformSubtractGreen = 2
+
+	greenAlphabetSize    = 256 + 24
+	colorAlphabetSize    = 256
+	distanceAlphabetSize = 40
+)
+
+// codeLengthCodeOrder is the order of lengths of code length code in the bitstream
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// EncodeWebP writes the image in lossless WebP format
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 1 || height < 1 || width > vp8lMaxDimension || height > vp8lMaxDimension {
+		return fmt.Errorf("invalid size of webp image: %dx%d", width, height)
+	}
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Stride != 4*width || bounds.Min != (image.Point{}) {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+
+	pix := make([]byte, len(nrgba.Pix))
+	copy(pix, nrgba.Pix)
+	alphaUsed := uint32(0)
+	for p := 0; p < len(pix); p += 4 {
+		if pix[p+3] != 0xff {
+			alphaUsed = 1
+		}
+		// Subtract green transform
+		pix[p+0] -= pix[p+1]
+		pix[p+2] -= pix[p+1]
+	}
+	residuals := predictGradient(pix, width, height)
+
+	bw := &bitWriter{}
+	bw.write(vp8lSignature, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	bw.write(alphaUsed, 1)
+	bw.write(0, 3)
+
+	// Transforms are inverted by decoder in reverse order
+	bw.write(1, 1)
+	bw.write(transformSubtractGreen, 2)
+	bw.write(1, 1)
+	bw.write(transformPredictor, 2)
+	bw.write(predictorBits-2, 3)
+	writePredictorImage(bw)
+	bw.write(0, 1)
+
+	// Main image without color cache and meta prefix codes
+	bw.write(0, 1)
+	bw.write(0, 1)
+	var histograms [4][]int
+	histograms[0] = make([]int, greenAlphabetSize)
+	for i := 1; i < 4; i++ {
+		histograms[i] = make([]int, colorAlphabetSize)
+	}
+	for p := 0; p < len(residuals); p += 4 {
+		histograms[0][residuals[p+1]]++
+		histograms[1][residuals[p+0]]++
+		histograms[2][residuals[p+2]]++
+		histograms[3][residuals[p+3]]++
+	}
+	var codes [4]*prefixCode
+	for i, histogram := range histograms {
+		codes[i] = newPrefixCode(histogram, maxCodeLength)
+		codes[i].writeTo(bw)
+	}
+	writeSingleSymbolCode(bw, 0)
+	for p := 0; p < len(residuals); p += 4 {
+		codes[0].writeSymbol(bw, int(residuals[p+1]))
+		codes[1].writeSymbol(bw, int(residuals[p+0]))
+		codes[2].writeSymbol(bw, int(residuals[p+2]))
+		codes[3].writeSymbol(bw, int(residuals[p+3]))
+	}
+
+	data := bw.bytes()
+	size := len(data)
+	pad := size & 1
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+size+pad))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(size))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if pad == 1 {
+		data = append(data, 0)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// predictGradient returns residuals of pixels after predictor transform.
+// The first pixel is predicted by opaque black, the first row by the left
+// pixel, the first column by the top pixel and other pixels by gradient
+func predictGradient(pix []byte, width, height int) []byte {
+	residuals := make([]byte, len(pix))
+	stride := 4 * width
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := y*stride + 4*x
+			for c := 0; c < 4; c++ {
+				var prediction byte
+				switch {
+				case x == 0 && y == 0:
+					if c == 3 {
+						prediction = 0xff
+					}
+				case y == 0:
+					prediction = pix[p-4+c]
+				case x == 0:
+					prediction = pix[p-stride+c]
+				default:
+					prediction = clampAddSubtractFull(pix[p-4+c], pix[p-stride+c], pix[p-stride-4+c])
+				}
+				residuals[p+c] = pix[p+c] - prediction
+			}
+		}
+	}
+	return residuals
+}
+
+func clampAddSubtractFull(a, b, c byte) byte {
+	value := int(a) + int(b) - int(c)
+	if value < 0 {
+		return 0
+	}
+	if value > 255 {
+		return 255
+	}
+	return byte(value)
+}
+
+// writePredictorImage writes the image of predictor modes, all blocks use
+// the same mode, so every channel has a code with a single symbol
+// and pixels of the image take no bits
+func writePredictorImage(bw *bitWriter) {
+	bw.write(0, 1)
+	writeSingleSymbolCode(bw, predictorGradient)
+	writeSingleSymbolCode(bw, 0)
+	writeSingleSymbolCode(bw, 0)
+	writeSingleSymbolCode(bw, 0)
+	writeSingleSymbolCode(bw, 0)
+}
+
+// writeSingleSymbolCode writes a simple prefix code with one symbol
+func writeSingleSymbolCode(bw *bitWriter, symbol uint32) {
+	bw.write(1, 1)
+	bw.write(0, 1)
+	if symbol < 2 {
+		bw.write(0, 1)
+		bw.write(symbol, 1)
+		return
+	}
+	bw.write(1, 1)
+	bw.write(symbol, 8)
+}
+
+// prefixCode is a canonical Huffman code
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint32
+	// symbols of simple code, it is used if there are only
+	// one or two symbols and they are less than 256
+	simple []int
+}
+
+func newPrefixCode(histogram []int, maxLength int) *prefixCode {
+	code := &prefixCode{}
+	for symbol, count := range histogram {
+		if count > 0 {
+			code.simple = append(code.simple, symbol)
+		}
+	}
+	if len(code.simple) <= 2 && code.simple[len(code.simple)-1] < 256 {
+		code.lengths = make([]uint8, len(histogram))
+		code.codes = make([]uint32, len(histogram))
+		if len(code.simple) == 2 {
+			code.lengths[code.simple[0]] = 1
+			code.lengths[code.simple[1]] = 1
+			code.codes[code.simple[1]] = 1
+		}
+		return code
+	}
+	code.simple = nil
+	code.lengths = huffmanLengths(histogram, maxLength)
+	code.codes = canonicalCodes(code.lengths)
+	return code
+}
+
+// writeTo writes the code to the bitstream
+func (code *prefixCode) writeTo(bw *bitWriter) {
+	if code.simple != nil {
+		bw.write(1, 1)
+		bw.write(uint32(len(code.simple)-1), 1)
+		if code.simple[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(code.simple[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(code.simple[0]), 8)
+		}
+		if len(code.simple) == 2 {
+			bw.write(uint32(code.simple[1]), 8)
+		}
+		return
+	}
+	bw.write(0, 1)
+
+	tokens := codeLengthTokens(code.lengths)
+	histogram := make([]int, len(codeLengthCodeOrder))
+	for _, token := range tokens {
+		histogram[token.symbol]++
+	}
+	used := 0
+	for _, count := range histogram {
+		if count > 0 {
+			used++
+		}
+	}
+	var lengthsCode *prefixCode
+	if used == 1 {
+		// Code with one symbol takes no bits, so the second symbol
+		// is added to write lengths of code length code
+		for symbol, count := range histogram {
+			if count == 0 {
+				histogram[symbol] = 1
+				break
+			}
+		}
+	}
+	lengthsCode = &prefixCode{lengths: huffmanLengths(histogram, maxCodeLengthCode)}
+	lengthsCode.codes = canonicalCodes(lengthsCode.lengths)
+
+	count := 4
+	for i, symbol := range codeLengthCodeOrder {
+		if lengthsCode.lengths[symbol] > 0 && i+1 > count {
+			count = i + 1
+		}
+	}
+	bw.write(uint32(count-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:count] {
+		bw.write(uint32(lengthsCode.lengths[symbol]), 3)
+	}
+	// All symbols of alphabet are written
+	bw.write(0, 1)
+	for _, token := range tokens {
+		lengthsCode.writeSymbol(bw, token.symbol)
+		if token.extraBits > 0 {
+			bw.write(token.extra, token.extraBits)
+		}
+	}
+}
+
+// writeSymbol writes the code of symbol, codes are
+// written starting from the most significant bit
+func (code *prefixCode) writeSymbol(bw *bitWriter, symbol int) {
+	length := uint(code.lengths[symbol])
+	if length == 0 {
+		return
+	}
+	bw.write(reverse(code.codes[symbol], length), length)
+}
+
+// codeLengthToken is a symbol of code length code with its extra bits
+type codeLengthToken struct {
+	symbol    int
+	extra     uint32
+	extraBits uint
+}
+
+// codeLengthTokens encodes code lengths with repeat codes:
+// 16 repeats the previous non-zero length 3-6 times,
+// 17 repeats zero 3-10 times, 18 repeats zero 11-138 times
+func codeLengthTokens(lengths []uint8) []codeLengthToken {
+	tokens := make([]codeLengthToken, 0, len(lengths))
+	previous := uint8(8)
+	for i := 0; i < len(lengths); {
+		length := lengths[i]
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == length {
+			run++
+		}
+		i += run
+		if length == 0 {
+			for run >= 11 {
+				n := min(run, 138)
+				tokens = append(tokens, codeLengthToken{symbol: 18, extra: uint32(n - 11), extraBits: 7})
+				run -= n
+			}
+			if run >= 3 {
+				tokens = append(tokens, codeLengthToken{symbol: 17, extra: uint32(run - 3), extraBits: 3})
+				run = 0
+			}
+		} else {
+			if length != previous {
+				tokens = append(tokens, codeLengthToken{symbol: int(length)})
+				run--
+				previous = length
+			}
+			for run >= 3 {
+				n := min(run, 6)
+				tokens = append(tokens, codeLengthToken{symbol: 16, extra: uint32(n - 3), extraBits: 2})
+				run -= n
+			}
+		}
+		for ; run > 0; run-- {
+			tokens = append(tokens, codeLengthToken{symbol: int(length)})
+		}
+	}
+	return tokens
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// huffmanNode is a node of Huffman tree
+type huffmanNode struct {
+	count       int
+	symbol      int
+	left, right *huffmanNode
+}
+
+type huffmanHeap []*huffmanNode
+
+func (h huffmanHeap) Len() int { return len(h) }
+func (h huffmanHeap) Less(i, j int) bool {
+	if h[i].count == h[j].count {
+		return h[i].symbol < h[j].symbol
+	}
+	return h[i].count < h[j].count
+}
+func (h huffmanHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *huffmanHeap) Push(x interface{}) { *h = append(*h, x.(*huffmanNode)) }
+func (h *huffmanHeap) Pop() interface{} {
+	old := *h
+	node := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return node
+}
+
+// huffmanLengths returns lengths of Huffman codes of symbols limited by maxLength,
+// histogram must have at least two symbols. If the tree is too deep, counts are
+// flattened until it fits
+func huffmanLengths(histogram []int, maxLength int) []uint8 {
+	counts := make([]int, len(histogram))
+	copy(counts, histogram)
+	for {
+		lengths := make([]uint8, len(counts))
+		h := huffmanHeap{}
+		for symbol, count := range counts {
+			if count > 0 {
+				h = append(h, &huffmanNode{count: count, symbol: symbol})
+			}
+		}
+		heap.Init(&h)
+		for h.Len() > 1 {
+			a := heap.Pop(&h).(*huffmanNode)
+			b := heap.Pop(&h).(*huffmanNode)
+			heap.Push(&h, &huffmanNode{count: a.count + b.count, symbol: min(a.symbol, b.symbol), left: a, right: b})
+		}
+		depth := setLengths(h[0], 0, lengths)
+		if depth <= maxLength {
+			return lengths
+		}
+		for symbol, count := range counts {
+			if count > 0 {
+				counts[symbol] = count/2 + 1
+			}
+		}
+	}
+}
+
+// setLengths sets lengths of leafs of the tree and returns the max length
+func setLengths(node *huffmanNode, depth int, lengths []uint8) int {
+	if node.left == nil {
+		lengths[node.symbol] = uint8(depth)
+		return depth
+	}
+	left := setLengths(node.left, depth+1, lengths)
+	right := setLengths(node.right, depth+1, lengths)
+	if left > right {
+		return left
+	}
+	return right
+}
+
+// canonicalCodes returns canonical Huffman codes by their lengths
+func canonicalCodes(lengths []uint8) []uint32 {
+	var histogram [maxCodeLength + 1]uint32
+	for _, length := range lengths {
+		histogram[length]++
+	}
+	histogram[0] = 0
+	var next [maxCodeLength + 1]uint32
+	code := uint32(0)
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + histogram[length-1]) << 1
+		next[length] = code
+	}
+	codes := make([]uint32, len(lengths))
+	for symbol, length := range lengths {
+		if length > 0 {
+			codes[symbol] = next[length]
+			next[length]++
+		}
+	}
+	return codes
+}
+
+func reverse(code uint32, length uint) uint32 {
+	result := uint32(0)
+	for i := uint(0); i < length; i++ {
+		result = result<<1 | code&1
+		code >>= 1
+	}
+	return result
+}
+
+// bitWriter writes bits starting from the least significant bit
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nBits uint
+}
+
+func (bw *bitWriter) write(value uint32, bits uint) {
+	bw.acc |= uint64(value) << bw.nBits
+	bw.nBits += bits
+	for bw.nBits >= 8 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc >>= 8
+		bw.nBits -= 8
+	}
+}
+
+func (bw *bitWriter) bytes() []byte {
+	if bw.nBits > 0 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc, bw.nBits = 0, 0
+	}
+	return bw.buf
+}
@@ -0,0 +1,70 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+func TestEncodeWebP(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	images := map[string]*image.NRGBA{}
+
+	noise := image.NewNRGBA(image.Rect(0, 0, 67, 45))
+	random.Read(noise.Pix)
+	images["noise"] = noise
+
+	gradient := image.NewNRGBA(image.Rect(0, 0, 640, 480))
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			gradient.SetNRGBA(x, y, color.NRGBA{uint8(x), uint8(y), uint8(x + y), 0xff})
+		}
+	}
+	images["gradient"] = gradient
+
+	plain := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	plain.SetNRGBA(0, 0, color.NRGBA{10, 20, 30, 0xff})
+	images["pixel"] = plain
+
+	twoColors := image.NewNRGBA(image.Rect(0, 0, 600, 3))
+	for x := 0; x < 600; x += 2 {
+		twoColors.SetNRGBA(x, 1, color.NRGBA{0xff, 0xff, 0xff, 0x80})
+	}
+	images["two colors"] = twoColors
+
+	for name, img := range images {
+		buf := &bytes.Buffer{}
+		require.NoError(t, EncodeWebP(buf, img), name)
+		decoded, err := webp.Decode(buf)
+		require.NoError(t, err, name)
+		require.Equal(t, img.Bounds(), decoded.Bounds(), name)
+		require.Equal(t, img.Pix, decoded.(*image.NRGBA).Pix, name)
+	}
+
+	err := EncodeWebP(&bytes.Buffer{}, image.NewNRGBA(image.Rect(0, 0, 0, 10)))
+	require.Error(t, err)
+}
+
+func TestHuffmanLengths(t *testing.T) {
+	// Fibonacci counts give the deepest tree
+	histogram := make([]int, 40)
+	a, b := 1, 1
+	for i := range histogram {
+		histogram[i] = a
+		a, b = b, a+b
+	}
+	lengths := huffmanLengths(histogram, maxCodeLength)
+	kraft := 0
+	for _, length := range lengths {
+		require.LessOrEqual(t, int(length), maxCodeLength)
+		require.NotZero(t, length)
+		kraft += 1 << (maxCodeLength - length)
+	}
+	// The code is complete
+	require.Equal(t, 1<<maxCodeLength, kraft)
+}
@@ -0,0 +1,142 @@
+package invoice
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Font is a TrueType font embedded into rendered documents. Metrics are
+// in thousandths of the font size as PDF expects
+type Font struct {
+	data       []byte
+	font       *sfnt.Font
+	name       string
+	unitsPerEm fixed.Int26_6
+	ascent     int
+	descent    int
+	capHeight  int
+	bbox       [4]int
+}
+
+// glyph is a glyph of the rune with its advance width
+type glyph struct {
+	id    sfnt.GlyphIndex
+	r     rune
+	width int
+}
+
+// ParseFont parses TrueType font, the whole file is embedded into documents
+func ParseFont(data []byte) (*Font, error) {
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse font: %w", err)
+	}
+	f := &Font{
+		data:       data,
+		font:       parsed,
+		unitsPerEm: fixed.I(int(parsed.UnitsPerEm())),
+	}
+	var buf sfnt.Buffer
+	f.name, err = parsed.Name(&buf, sfnt.NameIDPostScript)
+	if err != nil || f.name == "" {
+		f.name = "Font"
+	}
+	f.name = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || strings.ContainsRune("()<>[]{}/%#", r) {
+			return -1
+		}
+		return r
+	}, f.name)
+	metrics, err := parsed.Metrics(&buf, f.unitsPerEm, font.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("can't get metrics of font: %w", err)
+	}
+	f.ascent = f.scale(metrics.Ascent)
+	f.descent = -f.scale(metrics.Descent)
+	f.capHeight = f.scale(metrics.CapHeight)
+	bounds, err := parsed.Bounds(&buf, f.unitsPerEm, font.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("can't get bounds of font: %w", err)
+	}
+	// Y axis of the font grows down, while it grows up in PDF
+	f.bbox = [4]int{f.scale(bounds.Min.X), -f.scale(bounds.Max.Y), f.scale(bounds.Max.X), -f.scale(bounds.Min.Y)}
+	return f, nil
+}
+
+// LoadFont reads TrueType font from the file
+func LoadFont(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read font: %w", err)
+	}
+	return ParseFont(data)
+}
+
+// goFonts returns regular and bold Go fonts, they cover Latin, Greek and Cyrillic
+func goFonts() (*Font, *Font, error) {
+	regular, err := ParseFont(goregular.TTF)
+	if err != nil {
+		return nil, nil, err
+	}
+	bold, err := ParseFont(gobold.TTF)
+	if err != nil {
+		return nil, nil, err
+	}
+	return regular, bold, nil
+}
+
+// scale converts value in units of the font to thousandths of the font size
+func (f *Font) scale(value fixed.Int26_6) int {
+	return int(int64(value) * 1000 / int64(f.unitsPerEm))
+}
+
+// glyphs returns glyphs of the text, runes missing in the font are shown as '?'
+func (f *Font) glyphs(text string) []glyph {
+	var buf sfnt.Buffer
+	result := make([]glyph, 0, len(text))
+	for _, r := range text {
+		id, err := f.font.GlyphIndex(&buf, r)
+		if err != nil || id == 0 {
+			r = '?'
+			id, _ = f.font.GlyphIndex(&buf, r)
+		}
+		advance, err := f.font.GlyphAdvance(&buf, id, f.unitsPerEm, font.HintingNone)
+		if err != nil {
+			advance = 0
+		}
+		result = append(result, glyph{id: id, r: r, width: f.scale(advance)})
+	}
+	return result
+}
+
+// width returns width of the text of the size in points
+func (f *Font) width(text string, size float64) float64 {
+	total := 0
+	for _, g := range f.glyphs(text) {
+		total += g.width
+	}
+	return float64(total) * size / 1000
+}
+
+// truncate shortens the text to fit the width, shortened text ends with "..."
+func (f *Font) truncate(text string, size float64, width float64) string {
+	if f.width(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		short := strings.TrimSpace(string(runes)) + "..."
+		if f.width(short, size) <= width {
+			return short
+		}
+	}
+	return ""
+}
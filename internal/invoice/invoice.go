@@ -0,0 +1,289 @@
+package invoice
+
+import (
+	"OnlineShopBackend/internal/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Layout of the page in points
+const (
+	marginLeft   = 50.0
+	marginRight  = pageWidth - 50
+	marginTop    = pageHeight - 60
+	marginBottom = 70.0
+	lineHeight   = 15.0
+	// Right edges of the columns of the table of lines
+	columnQuantity = 360.0
+	columnPrice    = 455.0
+	titleWidth     = columnQuantity - marginLeft - 50
+)
+
+// Document is the content of the invoice of the order. The order must
+// have the customer set in its user
+type Document struct {
+	Number string
+	Order  *models.Order
+}
+
+// Renderer renders invoices and receipts of the shop
+type Renderer struct {
+	shop    models.ShopDetails
+	regular *Font
+	bold    *Font
+}
+
+// NewRenderer returns renderer of invoices of the shop. The text is set
+// in Go fonts, which cover Latin and Cyrillic, unless the path of
+// TrueType font is given
+func NewRenderer(shop models.ShopDetails, fontPath string) (*Renderer, error) {
+	renderer := &Renderer{shop: shop}
+	var err error
+	if fontPath == "" {
+		renderer.regular, renderer.bold, err = goFonts()
+	} else {
+		renderer.regular, err = LoadFont(fontPath)
+		renderer.bold = renderer.regular
+	}
+	if err != nil {
+		return nil, err
+	}
+	return renderer, nil
+}
+
+// Title returns the title of the document, the paid order gets a receipt
+func Title(order *models.Order) string {
+	if order.Status.Paid() {
+		return "Receipt"
+	}
+	return "Invoice"
+}
+
+// Fingerprint returns a hash of the printed content of the document,
+// it changes when anything shown in the document changes
+func (renderer *Renderer) Fingerprint(doc *Document) string {
+	hash := sha256.New()
+	for _, p := range renderer.layout(doc) {
+		for _, t := range p.texts {
+			fmt.Fprintf(hash, "%.2f %.2f %.1f %s %q\n", t.x, t.y, t.size, t.font.name, t.s)
+		}
+		for _, r := range p.rules {
+			fmt.Fprintf(hash, "%.2f %.2f %.2f\n", r.x1, r.x2, r.y)
+		}
+		hash.Write([]byte("\f"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Render returns PDF file of the document
+func (renderer *Renderer) Render(doc *Document) ([]byte, error) {
+	data, err := writePDF(renderer.layout(doc), Title(doc.Order)+" "+doc.Number)
+	if err != nil {
+		return nil, fmt.Errorf("can't render invoice %s: %w", doc.Number, err)
+	}
+	return data, nil
+}
+
+// layout places the content of the document on pages
+type layout struct {
+	renderer *Renderer
+	pages    []page
+	y        float64
+}
+
+func (l *layout) page() *page {
+	return &l.pages[len(l.pages)-1]
+}
+
+func (l *layout) newPage() {
+	l.pages = append(l.pages, page{})
+	l.y = marginTop
+}
+
+// text places the text at the left edge x on the current line
+func (l *layout) text(x float64, size float64, bold bool, s string) {
+	if s == "" {
+		return
+	}
+	font := l.renderer.regular
+	if bold {
+		font = l.renderer.bold
+	}
+	l.page().texts = append(l.page().texts, text{x: x, y: l.y, size: size, font: font, s: s})
+}
+
+// right places the text with the right edge at x on the current line
+func (l *layout) right(x float64, size float64, bold bool, s string) {
+	font := l.renderer.regular
+	if bold {
+		font = l.renderer.bold
+	}
+	l.text(x-font.width(s, size), size, bold, s)
+}
+
+func (l *layout) rule() {
+	l.page().rules = append(l.page().rules, rule{x1: marginLeft, x2: marginRight, y: l.y})
+}
+
+// line moves to the next line, the page is broken when it is full
+func (l *layout) line(height float64) bool {
+	l.y -= height
+	if l.y < marginBottom {
+		l.newPage()
+		return true
+	}
+	return false
+}
+
+func (renderer *Renderer) layout(doc *Document) []page {
+	order := doc.Order
+	l := &layout{renderer: renderer}
+	l.newPage()
+	money := func(amount int64) string {
+		return models.Money{Amount: amount, Currency: order.Currency}.Format()
+	}
+
+	l.text(marginLeft, 20, true, strings.ToUpper(Title(order)))
+	l.right(marginRight, 10, true, doc.Number)
+	l.line(lineHeight)
+	l.right(marginRight, 10, false, "Order "+order.ID.String())
+	l.line(lineHeight)
+	l.right(marginRight, 10, false, "Date "+order.CreatedAt.UTC().Format("2006-01-02"))
+	l.line(2 * lineHeight)
+
+	// Seller and buyer are printed side by side
+	top := l.y
+	l.text(marginLeft, 10, true, "Seller")
+	for _, s := range renderer.seller() {
+		l.line(lineHeight)
+		l.text(marginLeft, 10, false, s)
+	}
+	bottom := l.y
+	l.y = top
+	buyerX := pageWidth / 2
+	l.text(buyerX, 10, true, "Bill to")
+	for _, s := range buyer(order) {
+		l.line(lineHeight)
+		l.text(buyerX, 10, false, s)
+	}
+	if bottom < l.y {
+		l.y = bottom
+	}
+	l.line(2 * lineHeight)
+	if order.ShippingMethod != "" {
+		l.text(marginLeft, 10, false, "Shipping method: "+order.ShippingMethod)
+		l.line(2 * lineHeight)
+	}
+
+	header := func() {
+		l.text(marginLeft, 10, true, "Item")
+		l.right(columnQuantity, 10, true, "Qty")
+		l.right(columnPrice, 10, true, "Unit price")
+		l.right(marginRight, 10, true, "Amount")
+		l.line(lineHeight / 2)
+		l.rule()
+		l.line(lineHeight)
+	}
+	header()
+	for i, item := range order.Items {
+		price := models.Money{Amount: item.Price, Currency: item.Currency}
+		if price.Currency == "" {
+			price.Currency = order.Currency
+		}
+		l.text(marginLeft, 10, false, renderer.regular.truncate(item.Title, 10, titleWidth))
+		l.right(columnQuantity, 10, false, strconv.Itoa(item.Quantity))
+		l.right(columnPrice, 10, false, price.Format())
+		l.right(marginRight, 10, false, price.Times(item.Quantity).Format())
+		if l.line(lineHeight) && i < len(order.Items)-1 {
+			header()
+		}
+	}
+	l.y += lineHeight / 2
+	l.rule()
+	l.line(lineHeight)
+
+	total := func(bold bool, name string, amount string) {
+		l.right(columnPrice, 10, bold, name)
+		l.right(marginRight, 10, bold, amount)
+		l.line(lineHeight)
+	}
+	total(false, "Subtotal", money(order.Subtotal))
+	for _, discount := range order.Discounts {
+		name := "Discount " + discount.Name
+		if discount.Code != "" {
+			name += " (" + discount.Code + ")"
+		}
+		total(false, renderer.regular.truncate(name, 10, columnPrice-marginLeft), money(-discount.Amount))
+	}
+	for _, tax := range order.Taxes {
+		name := fmt.Sprintf("%s %s%%", tax.Name, rate(tax.Rate))
+		if tax.Inclusive {
+			name += " (included)"
+		}
+		total(false, renderer.regular.truncate(name, 10, columnPrice-marginLeft), money(tax.Amount))
+	}
+	if order.Shipping != 0 || order.ShippingMethod != "" {
+		total(false, "Shipping", money(order.Shipping))
+	}
+	total(true, "Total", money(order.Total))
+
+	// The page broken after the last line is left empty
+	if last := l.page(); len(l.pages) > 1 && len(last.texts) == 0 && len(last.rules) == 0 {
+		l.pages = l.pages[:len(l.pages)-1]
+	}
+	for i := range l.pages {
+		l.pages[i].texts = append(l.pages[i].texts, renderer.footer(i+1, len(l.pages))...)
+	}
+	return l.pages
+}
+
+// footer returns texts at the bottom of the page with the number
+func (renderer *Renderer) footer(number int, count int) []text {
+	y := marginBottom - 30
+	texts := make([]text, 0, 2)
+	if renderer.shop.Name != "" {
+		texts = append(texts, text{x: marginLeft, y: y, size: 8, font: renderer.regular, s: renderer.shop.Name})
+	}
+	s := fmt.Sprintf("Page %d of %d", number, count)
+	return append(texts, text{x: marginRight - renderer.regular.width(s, 8), y: y, size: 8, font: renderer.regular, s: s})
+}
+
+// seller returns lines with details of the shop
+func (renderer *Renderer) seller() []string {
+	shop := renderer.shop
+	lines := make([]string, 0, 4)
+	for _, s := range []string{shop.Name, shop.Address} {
+		if s != "" {
+			lines = append(lines, s)
+		}
+	}
+	if shop.TaxId != "" {
+		lines = append(lines, "Tax ID: "+shop.TaxId)
+	}
+	if shop.Email != "" {
+		lines = append(lines, shop.Email)
+	}
+	return lines
+}
+
+// buyer returns lines with the customer and the address of the order
+func buyer(order *models.Order) []string {
+	lines := make([]string, 0, 5)
+	name := strings.TrimSpace(order.User.Firstname + " " + order.User.Lastname)
+	for _, s := range []string{name, order.User.Email, order.Address.Street,
+		strings.TrimSpace(order.Address.Zipcode + " " + order.Address.City), order.Address.Country} {
+		if s != "" {
+			lines = append(lines, s)
+		}
+	}
+	return lines
+}
+
+// rate formats the rate in hundredths of percent, e.g. 2000 is "20"
+func rate(value int64) string {
+	s := strconv.FormatFloat(float64(value)/100, 'f', 2, 64)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
@@ -0,0 +1,175 @@
+package invoice
+
+import (
+	"OnlineShopBackend/internal/models"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var testShop = models.ShopDetails{
+	Name:    "Online Shop",
+	Address: "Moscow, Tverskaya 1",
+	TaxId:   "7700000000",
+	Email:   "shop@example.com",
+}
+
+func testDocument() *Document {
+	order := &models.Order{
+		ID:        uuid.MustParse("1a2b3c4d-0000-4000-8000-000000000001"),
+		CreatedAt: time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC),
+		User:      models.User{ID: uuid.New(), Firstname: "Иван", Lastname: "Петров", Email: "ivan@example.com"},
+		Address:   models.UserAddress{Zipcode: "101000", Country: "Russia", City: "Москва", Street: "Арбат 1"},
+		Status:    models.StatusCreated,
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Id: uuid.New(), Title: "Смартфон", Price: 1000000, Currency: "RUB"}, Quantity: 1},
+			{Item: models.Item{Id: uuid.New(), Title: "Charger", Price: 150000, Currency: "RUB"}, Quantity: 2},
+		},
+		ShippingMethod: "Courier",
+		Pricing: models.Pricing{
+			Currency:  "RUB",
+			Subtotal:  1300000,
+			Discounts: []models.AppliedDiscount{{Name: "Sale", Code: "SALE10", Amount: 130000}},
+			Taxes:     []models.TaxLine{{Name: "VAT", Rate: 2000, Inclusive: true, Amount: 195000}},
+			Shipping:  50000,
+			Total:     1220000,
+		},
+	}
+	return &Document{Number: models.InvoiceNumber(order.ID, order.CreatedAt), Order: order}
+}
+
+// pdfContents returns decompressed streams of the file
+func pdfContents(t *testing.T, data []byte) []byte {
+	streams := regexp.MustCompile(`(?s)/Length (\d+) >>\nstream\n`)
+	var result []byte
+	for _, match := range streams.FindAllSubmatchIndex(data, -1) {
+		length, err := strconv.Atoi(string(data[match[2]:match[3]]))
+		require.NoError(t, err)
+		r, err := zlib.NewReader(bytes.NewReader(data[match[1] : match[1]+length]))
+		require.NoError(t, err)
+		stream, err := io.ReadAll(r)
+		require.NoError(t, err)
+		result = append(result, stream...)
+	}
+	return result
+}
+
+// glyphsHex returns the text as it is shown in content of the page
+func glyphsHex(f *Font, s string) string {
+	var b strings.Builder
+	for _, g := range f.glyphs(s) {
+		fmt.Fprintf(&b, "%04X", uint16(g.id))
+	}
+	return "<" + b.String() + ">"
+}
+
+func TestRender(t *testing.T) {
+	renderer, err := NewRenderer(testShop, "")
+	require.NoError(t, err)
+	doc := testDocument()
+	require.Equal(t, "INV-20230131-1A2B3C4D", doc.Number)
+
+	data, err := renderer.Render(doc)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	require.Contains(t, string(data), "/Count 1")
+	require.Contains(t, string(data), "/Title (Invoice INV-20230131-1A2B3C4D)")
+
+	// Cross-reference table points to the objects
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	require.NotNil(t, startxref)
+	offset, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(data[offset:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[offset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		objectOffset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(data[objectOffset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))))
+	}
+
+	contents := string(pdfContents(t, data))
+	for _, s := range []string{"INVOICE", "INV-20230131-1A2B3C4D", "Total"} {
+		require.Contains(t, contents, glyphsHex(renderer.bold, s))
+	}
+	for _, s := range []string{"Иван Петров", "Смартфон", "Online Shop", "Tax ID: 7700000000", "Discount Sale (SALE10)",
+		"VAT 20% (included)", "3000.00 RUB", "-1300.00 RUB", "12200.00 RUB", "Shipping method: Courier", "Page 1 of 1"} {
+		require.Contains(t, contents, glyphsHex(renderer.regular, s))
+	}
+	// Text can be copied from the document
+	require.Contains(t, contents, "<0418>\n")
+
+	// The paid order gets a receipt
+	doc.Order.Status = models.StatusPaid
+	data, err = renderer.Render(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(pdfContents(t, data)), glyphsHex(renderer.bold, "RECEIPT"))
+}
+
+func TestRenderPages(t *testing.T) {
+	renderer, err := NewRenderer(testShop, "")
+	require.NoError(t, err)
+	doc := testDocument()
+	for i := 0; i < 60; i++ {
+		doc.Order.Items = append(doc.Order.Items, models.ItemWithQuantity{
+			Item:     models.Item{Id: uuid.New(), Title: strings.Repeat("Very long title of the item ", 5), Price: 100, Currency: "RUB"},
+			Quantity: 1,
+		})
+	}
+	data, err := renderer.Render(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "/Count 2")
+	contents := string(pdfContents(t, data))
+	require.Contains(t, contents, glyphsHex(renderer.regular, "Page 2 of 2"))
+	require.Contains(t, contents, glyphsHex(renderer.regular, "Very long title of the item Very long title of the item Very..."))
+}
+
+func TestFingerprint(t *testing.T) {
+	renderer, err := NewRenderer(testShop, "")
+	require.NoError(t, err)
+	doc := testDocument()
+	fingerprint := renderer.Fingerprint(doc)
+	require.Len(t, fingerprint, 64)
+	require.Equal(t, fingerprint, renderer.Fingerprint(doc))
+
+	// Status isn't printed while the order stays unpaid
+	doc.Order.Status = models.StatusPaymentFailed
+	require.Equal(t, fingerprint, renderer.Fingerprint(doc))
+	doc.Order.Status = models.StatusPaid
+	paid := renderer.Fingerprint(doc)
+	require.NotEqual(t, fingerprint, paid)
+	doc.Order.Status = models.StatusProcessing
+	require.Equal(t, paid, renderer.Fingerprint(doc))
+
+	doc.Order.Items[1].Quantity = 3
+	require.NotEqual(t, paid, renderer.Fingerprint(doc))
+
+	other, err := NewRenderer(models.ShopDetails{Name: "Other Shop"}, "")
+	require.NoError(t, err)
+	require.NotEqual(t, renderer.Fingerprint(doc), other.Fingerprint(doc))
+}
+
+func TestNewRendererFont(t *testing.T) {
+	_, err := NewRenderer(testShop, "not-found.ttf")
+	require.Error(t, err)
+	_, err = ParseFont([]byte("not a font"))
+	require.Error(t, err)
+}
+
+func TestRate(t *testing.T) {
+	require.Equal(t, "20", rate(2000))
+	require.Equal(t, "18.5", rate(1850))
+	require.Equal(t, "0.25", rate(25))
+	require.Equal(t, "0", rate(0))
+}
@@ -0,0 +1,220 @@
+package invoice
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// Size of A4 page in points
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// text is a line of text placed at the baseline
+type text struct {
+	x    float64
+	y    float64
+	size float64
+	font *Font
+	s    string
+}
+
+// rule is a horizontal line
+type rule struct {
+	x1 float64
+	x2 float64
+	y  float64
+}
+
+type page struct {
+	texts []text
+	rules []rule
+}
+
+// pdfFile collects objects of PDF file, numbers of objects start from one
+type pdfFile struct {
+	objects [][]byte
+}
+
+// reserve returns number of the object which is set later
+func (file *pdfFile) reserve() int {
+	file.objects = append(file.objects, nil)
+	return len(file.objects)
+}
+
+func (file *pdfFile) set(number int, body string) {
+	file.objects[number-1] = []byte(body)
+}
+
+func (file *pdfFile) add(body string) int {
+	number := file.reserve()
+	file.set(number, body)
+	return number
+}
+
+// stream adds compressed stream with the entries of the dictionary
+func (file *pdfFile) stream(entries string, data []byte) (int, error) {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "<< %s /Filter /FlateDecode /Length %d >>\nstream\n", entries, compressed.Len())
+	body.Write(compressed.Bytes())
+	body.WriteString("\nendstream")
+	number := file.reserve()
+	file.objects[number-1] = body.Bytes()
+	return number, nil
+}
+
+// bytes returns the file with the catalog and the information dictionary
+func (file *pdfFile) bytes(catalog int, info int) []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(file.objects))
+	for i, object := range file.objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i+1)
+		out.Write(object)
+		out.WriteString("\nendobj\n")
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(file.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(file.objects)+1, catalog, info, xref)
+	return out.Bytes()
+}
+
+// pdfString returns literal string of the text, it is used for ASCII metadata
+func pdfString(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", "", "\n", " ")
+	return "(" + replacer.Replace(s) + ")"
+}
+
+// usedFont is a font of the document with glyphs used in the text
+type usedFont struct {
+	font   *Font
+	name   string
+	glyphs map[sfnt.GlyphIndex]glyph
+}
+
+// writePDF renders pages into PDF file with the title in metadata. Fonts
+// are embedded as CID fonts, so any glyph of the font can be shown
+func writePDF(pages []page, title string) ([]byte, error) {
+	file := &pdfFile{}
+	catalog := file.reserve()
+	pagesTree := file.reserve()
+	fonts := make([]*usedFont, 0, 2)
+	fontOf := func(f *Font) *usedFont {
+		for _, used := range fonts {
+			if used.font == f {
+				return used
+			}
+		}
+		used := &usedFont{font: f, name: fmt.Sprintf("F%d", len(fonts)+1), glyphs: make(map[sfnt.GlyphIndex]glyph)}
+		fonts = append(fonts, used)
+		return used
+	}
+	contents := make([]int, 0, len(pages))
+	for _, p := range pages {
+		var content bytes.Buffer
+		for _, r := range p.rules {
+			fmt.Fprintf(&content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", r.x1, r.y, r.x2, r.y)
+		}
+		for _, t := range p.texts {
+			used := fontOf(t.font)
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td <", used.name, t.size, t.x, t.y)
+			for _, g := range t.font.glyphs(t.s) {
+				used.glyphs[g.id] = g
+				fmt.Fprintf(&content, "%04X", uint16(g.id))
+			}
+			content.WriteString("> Tj ET\n")
+		}
+		number, err := file.stream("", content.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("can't write content of page: %w", err)
+		}
+		contents = append(contents, number)
+	}
+	resources := make([]string, 0, len(fonts))
+	for _, used := range fonts {
+		number, err := writeFont(file, used)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, fmt.Sprintf("/%s %d 0 R", used.name, number))
+	}
+	kids := make([]string, 0, len(pages))
+	for _, content := range contents {
+		number := file.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pagesTree, pageWidth, pageHeight, strings.Join(resources, " "), content))
+		kids = append(kids, fmt.Sprintf("%d 0 R", number))
+	}
+	file.set(pagesTree, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	file.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesTree))
+	info := file.add(fmt.Sprintf("<< /Title %s /Producer (OnlineShopBackend) >>", pdfString(title)))
+	return file.bytes(catalog, info), nil
+}
+
+// writeFont embeds the font file and returns number of the font dictionary
+func writeFont(file *pdfFile, used *usedFont) (int, error) {
+	f := used.font
+	fontFile, err := file.stream(fmt.Sprintf("/Length1 %d", len(f.data)), f.data)
+	if err != nil {
+		return 0, fmt.Errorf("can't embed font: %w", err)
+	}
+	descriptor := file.add(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.bbox[0], f.bbox[1], f.bbox[2], f.bbox[3], f.ascent, f.descent, f.capHeight, fontFile))
+	ids := make([]int, 0, len(used.glyphs))
+	for id := range used.glyphs {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	var widths, cmap strings.Builder
+	for _, id := range ids {
+		fmt.Fprintf(&widths, "%d [%d] ", id, used.glyphs[sfnt.GlyphIndex(id)].width)
+	}
+	cidFont := file.add(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>",
+		f.name, descriptor, strings.TrimSpace(widths.String())))
+	// ToUnicode map lets viewers copy and search the text
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(ids); start += 100 {
+		end := start + 100
+		if end > len(ids) {
+			end = len(ids)
+		}
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", end-start)
+		for _, id := range ids[start:end] {
+			fmt.Fprintf(&cmap, "<%04X> <", id)
+			for _, unit := range utf16.Encode([]rune{used.glyphs[sfnt.GlyphIndex(id)].r}) {
+				fmt.Fprintf(&cmap, "%04X", unit)
+			}
+			cmap.WriteString(">\n")
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	toUnicode, err := file.stream("", []byte(cmap.String()))
+	if err != nil {
+		return 0, fmt.Errorf("can't write unicode map of font: %w", err)
+	}
+	return file.add(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cidFont, toUnicode)), nil
+}
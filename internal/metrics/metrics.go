@@ -37,6 +37,34 @@ var ItemsMetrics = struct {
 	}),
 }
 
+var CartsMetrics = struct {
+	GuestCartsPurged        prometheus.Counter
+	AbandonedCarts          prometheus.Counter
+	CartRemindersSent       prometheus.Counter
+	AbandonedCartsRecovered prometheus.Counter
+}{
+	GuestCartsPurged: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "guest_carts_purged_total",
+		Help:      "Expired guest carts deleted by the cleanup worker",
+	}),
+	AbandonedCarts: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "abandoned_carts_total",
+		Help:      "Carts of users idle with items in them recorded as abandoned",
+	}),
+	CartRemindersSent: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "cart_reminders_sent_total",
+		Help:      "Reminders about abandoned carts sent to users",
+	}),
+	AbandonedCartsRecovered: promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "shop",
+		Name:      "abandoned_carts_recovered_total",
+		Help:      "Abandoned carts which became orders",
+	}),
+}
+
 func init() { // 2
 	DeliveryMetrics.FinishDeliveryTotal.Inc()
 	DeliveryMetrics.NewDeliveryTotal.Inc()
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CartAbandonment is an event of the cart of the user idle for a long
+// time with items in it. It is recorded once per idle period of the cart
+type CartAbandonment struct {
+	Id         uuid.UUID
+	CartId     uuid.UUID
+	UserId     uuid.UUID
+	Email      string
+	Name       string
+	ItemsCount int
+	// ActiveAt is the time of the last activity in the cart
+	ActiveAt    time.Time
+	CreatedAt   time.Time
+	NotifiedAt  *time.Time
+	RecoveredAt *time.Time
+}
+
+// NotificationKind is a kind of notification sent to the user
+type NotificationKind string
+
+const (
+	NotificationCartReminder NotificationKind = "cart_reminder"
+)
+
+// Notification is a message to the user
+type Notification struct {
+	Kind    NotificationKind
+	UserId  uuid.UUID
+	Email   string
+	Subject string
+	Text    string
+	// UnsubscribeURL is a link to stop notifications of this kind
+	UnsubscribeURL string
+}
@@ -10,14 +10,127 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// CartTTL are lifetimes of carts since the last activity with them.
+// Expired guest carts are deleted, expiry of carts of users is only
+// informational and they are kept till next logins
+type CartTTL struct {
+	Guest time.Duration
+	User  time.Duration
+}
+
+// ExpireAt returns expiry of the cart of the user after activity at the given
+// time, cart with nil user id is a guest cart
+func (ttl CartTTL) ExpireAt(userId uuid.UUID, now time.Time) time.Time {
+	if userId == uuid.Nil {
+		return now.Add(ttl.Guest)
+	}
+	return now.Add(ttl.User)
+}
+
 type Cart struct {
 	Id       uuid.UUID
 	UserId   uuid.UUID
 	Items    []ItemWithQuantity
 	ExpireAt time.Time
+	// PromoCode is applied to the cart by the user
+	PromoCode string
+	// Lines are states of the items of the cart lines in the catalogue by item id
+	Lines map[uuid.UUID]CartLine
+	// Warnings tell the user about changes of the items since they were added
+	Warnings []CartWarning
+	Pricing
 }
+
+// CartLine is a state of the item of the cart line in the catalogue
+type CartLine struct {
+	// AddedPrice is the price of the item when it was added to the cart,
+	// it is nil for lines added before prices were stored
+	AddedPrice *Money
+	// Deleted is true if the item is deleted from the catalogue
+	Deleted bool
+	// Stock is nil if the stock of the item isn't tracked
+	Stock *int
+}
+
+type CartWarningKind string
+
+const (
+	// WarningPriceChanged - price of the item differs from the price when it was added
+	WarningPriceChanged CartWarningKind = "price_changed"
+	// WarningUnavailable - item is deleted or out of stock, it is removed from the cart
+	WarningUnavailable CartWarningKind = "unavailable"
+	// WarningQuantityReduced - quantity of the line is reduced to the stock of the item
+	WarningQuantityReduced CartWarningKind = "quantity_reduced"
+)
+
+// CartWarning is a change of the item of the cart line since it was added
+type CartWarning struct {
+	Kind   CartWarningKind
+	ItemId uuid.UUID
+	Title  string
+	// OldPrice and NewPrice are set for changed price
+	OldPrice Money
+	NewPrice Money
+	// Requested and Available are set for reduced quantity
+	Requested int
+	Available int
+}
+
+// Revalidate compares the cart lines with the current state of the items
+// and collects warnings. Unavailable items are removed from the items of
+// the cart and quantities are reduced to the stock, stored lines aren't changed
+func (cart *Cart) Revalidate() {
+	cart.Warnings = nil
+	items := make([]ItemWithQuantity, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		line, ok := cart.Lines[item.Id]
+		if !ok {
+			items = append(items, item)
+			continue
+		}
+		if line.Deleted || (line.Stock != nil && *line.Stock == 0) {
+			cart.Warnings = append(cart.Warnings, CartWarning{
+				Kind:   WarningUnavailable,
+				ItemId: item.Id,
+				Title:  item.Title,
+			})
+			continue
+		}
+		if line.Stock != nil && item.Quantity > *line.Stock {
+			cart.Warnings = append(cart.Warnings, CartWarning{
+				Kind:      WarningQuantityReduced,
+				ItemId:    item.Id,
+				Title:     item.Title,
+				Requested: item.Quantity,
+				Available: *line.Stock,
+			})
+			item.Quantity = *line.Stock
+		}
+		if line.AddedPrice != nil && *line.AddedPrice != item.Money() {
+			cart.Warnings = append(cart.Warnings, CartWarning{
+				Kind:     WarningPriceChanged,
+				ItemId:   item.Id,
+				Title:    item.Title,
+				OldPrice: *line.AddedPrice,
+				NewPrice: item.Money(),
+			})
+		}
+		items = append(items, item)
+	}
+	cart.Items = items
+}
+
+var (
+	// ErrNotGuestCart is returned when a cart of a user is used as a guest cart
+	ErrNotGuestCart = errors.New("cart isn't a guest cart")
+	// ErrInvalidQuantity is returned when the quantity or decrement of the cart line is negative
+	ErrInvalidQuantity = errors.New("invalid quantity")
+	// ErrQuantityLimit is returned when the quantity of the cart line is out of limits of the item
+	ErrQuantityLimit = errors.New("quantity is out of limits of the item")
+)
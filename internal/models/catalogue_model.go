@@ -0,0 +1,37 @@
+package models
+
+// CatalogueRecord is a row of imported or exported catalogue. Row without
+// sku describes a category, other rows describe items
+type CatalogueRecord struct {
+	Sku                 string
+	Title               string
+	Description         string
+	Price               int64
+	Currency            string
+	Category            string
+	CategoryDescription string
+	Vendor              string
+	Images              []string
+}
+
+// IsCategory reports whether the record describes a category without items
+func (record *CatalogueRecord) IsCategory() bool {
+	return record.Sku == ""
+}
+
+// ImportRowError is an error of import of one row of catalogue
+type ImportRowError struct {
+	Line  int
+	Sku   string
+	Error string
+}
+
+// ImportReport is a result of import of catalogue
+type ImportReport struct {
+	DryRun  bool
+	Total   int
+	Created int
+	Updated int
+	Failed  int
+	Errors  []ImportRowError
+}
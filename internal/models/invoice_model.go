@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShopDetails is the seller printed on invoices and receipts
+type ShopDetails struct {
+	Name    string
+	Address string
+	TaxId   string
+	Email   string
+}
+
+// Invoice is the rendered invoice of the order kept in the file storage
+type Invoice struct {
+	OrderId  uuid.UUID
+	Number   string
+	FileName string
+	// Fingerprint is a hash of the printed content, the file is
+	// rendered again when the content of the order changes
+	Fingerprint string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// InvoiceNumber returns the number of the invoice of the order issued at
+// the time, e.g. "INV-20240131-1A2B3C4D"
+func InvoiceNumber(orderId uuid.UUID, at time.Time) string {
+	return fmt.Sprintf("INV-%s-%s", at.UTC().Format("20060102"),
+		strings.ToUpper(strings.ReplaceAll(orderId.String(), "-", "")[:8]))
+}
+
+// Paid reports whether the order in the status has been paid,
+// the document of the paid order is a receipt
+func (status Status) Paid() bool {
+	switch status {
+	case StatusPaid, StatusProcessing, StatusProcessed, StatusReady, StatusCourier, StatusShipped, StatusRefunded:
+		return true
+	}
+	return false
+}
+
+// Dispatched reports whether the order in the status has been handed to
+// the carrier, the invoice of the dispatched order isn't rendered again
+func (status Status) Dispatched() bool {
+	return status == StatusCourier || status == StatusShipped
+}
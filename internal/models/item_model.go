@@ -9,19 +9,135 @@
 
 package models
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidStock is returned when the stock of the item is negative
+	ErrInvalidStock = errors.New("stock can't be negative")
+	// ErrInvalidLimits is returned when quantity limits of the item are inconsistent
+	ErrInvalidLimits = errors.New("invalid quantity limits")
+	// ErrItemUnavailable is returned when the ordered item is deleted or out of stock
+	ErrItemUnavailable = errors.New("item is unavailable")
+)
+
+// QuantityLimits are limits of the quantity of the item in a cart line,
+// zero Max means that the quantity isn't limited
+type QuantityLimits struct {
+	Min int
+	Max int
+}
+
+// Validate checks that minimum is at least one and maximum isn't less than minimum
+func (limits QuantityLimits) Validate() error {
+	if limits.Min < 1 || limits.Max < 0 || (limits.Max != 0 && limits.Max < limits.Min) {
+		return fmt.Errorf("%w: min: %d, max: %d", ErrInvalidLimits, limits.Min, limits.Max)
+	}
+	return nil
+}
+
+// Check returns error if the quantity of the cart line is out of limits
+func (limits QuantityLimits) Check(quantity int) error {
+	if quantity < limits.Min {
+		return fmt.Errorf("%w: quantity can't be less than %d", ErrQuantityLimit, limits.Min)
+	}
+	if limits.Max != 0 && quantity > limits.Max {
+		return fmt.Errorf("%w: quantity can't be greater than %d", ErrQuantityLimit, limits.Max)
+	}
+	return nil
+}
+
+// Item is a product of the shop, its price is in minor units of the currency
 type Item struct {
 	Id          uuid.UUID
 	Title       string
 	Description string
-	Price       int32
+	Price       int64
+	Currency    string
 	Category    Category
 	Vendor      string
-	Images      []string
+	Images      []ItemImage
+	Sku         string
+	// Rating is an average rating of approved reviews of the item
+	Rating       float64
+	ReviewsCount int
+}
+
+// Money returns the price of the item with its currency
+func (item *Item) Money() Money {
+	return Money{Amount: item.Price, Currency: item.Currency}
 }
 
 type ItemWithQuantity struct {
 	Item
 	Quantity int
-}
\ No newline at end of file
+}
+
+// ItemImage is an image of the item. Images are shown in order of
+// their positions, the primary image represents the item in lists
+type ItemImage struct {
+	Id       uuid.UUID
+	URL      string
+	Alt      string
+	Position int
+	Primary  bool
+}
+
+// ImagesFromURLs returns images with given urls in the same order,
+// nil list of urls means images are not given
+func ImagesFromURLs(urls []string) []ItemImage {
+	if urls == nil {
+		return nil
+	}
+	images := make([]ItemImage, 0, len(urls))
+	for _, url := range urls {
+		images = append(images, ItemImage{URL: url})
+	}
+	return NormalizeImages(images)
+}
+
+// NormalizeImages drops empty and repeated urls, numbers positions of images
+// in order of the list and leaves exactly one primary image
+func NormalizeImages(images []ItemImage) []ItemImage {
+	result := make([]ItemImage, 0, len(images))
+	seen := make(map[string]bool, len(images))
+	primary := -1
+	for _, image := range images {
+		image.URL = strings.TrimSpace(image.URL)
+		if image.URL == "" || seen[image.URL] {
+			continue
+		}
+		seen[image.URL] = true
+		image.Position = len(result)
+		if image.Primary {
+			if primary >= 0 {
+				image.Primary = false
+			} else {
+				primary = image.Position
+			}
+		}
+		result = append(result, image)
+	}
+	if primary < 0 && len(result) > 0 {
+		result[0].Primary = true
+	}
+	return result
+}
+
+// ImageURLs returns urls of images of the item, the primary image is first
+func (item *Item) ImageURLs() []string {
+	urls := make([]string, 0, len(item.Images))
+	for _, image := range item.Images {
+		if image.Primary {
+			urls = append([]string{image.URL}, urls...)
+		} else {
+			urls = append(urls, image.URL)
+		}
+	}
+	return urls
+}
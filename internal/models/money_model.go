@@ -0,0 +1,142 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownCurrency     = errors.New("unknown currency")
+	ErrNoExchangeRate      = errors.New("exchange rate not found")
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+)
+
+// currencies maps supported ISO 4217 codes to the number of digits of minor units
+var currencies = map[string]int{
+	"RUB": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CNY": 2,
+	"KZT": 2,
+	"BYN": 2,
+	"AMD": 2,
+	"TRY": 2,
+	"CHF": 2,
+	"JPY": 0,
+}
+
+// Money is an amount in minor units of the currency, e.g. kopecks or cents
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NormalizeCurrency returns currency code in the form it is stored
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// ValidCurrency checks that the currency is supported
+func ValidCurrency(currency string) error {
+	if _, ok := currencies[currency]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return nil
+}
+
+// Exponent returns the number of digits of minor units of the currency,
+// unknown currency has two digits
+func Exponent(currency string) int {
+	exponent, ok := currencies[currency]
+	if !ok {
+		return 2
+	}
+	return exponent
+}
+
+// Times returns the amount multiplied by quantity
+func (money Money) Times(quantity int) Money {
+	return Money{Amount: money.Amount * int64(quantity), Currency: money.Currency}
+}
+
+// Format returns the amount in major units with the code of currency,
+// e.g. "1990.00 RUB"
+func (money Money) Format() string {
+	exponent := Exponent(money.Currency)
+	amount := money.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	result := sign + digits
+	if exponent > 0 {
+		if len(digits) <= exponent {
+			digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+		}
+		result = sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+	if money.Currency == "" {
+		return result
+	}
+	return result + " " + money.Currency
+}
+
+func (money Money) String() string {
+	return money.Format()
+}
+
+// ExchangeRate is a number of units of the currency for one unit of the base currency
+type ExchangeRate struct {
+	Currency  string
+	Rate      float64
+	UpdatedAt time.Time
+}
+
+// ExchangeRates is a table of rates relative to the base currency
+type ExchangeRates struct {
+	Base  string
+	Rates map[string]float64
+}
+
+// NewExchangeRates returns table of the given rates, rate of the base
+// currency is always one
+func NewExchangeRates(base string, rates []ExchangeRate) *ExchangeRates {
+	table := &ExchangeRates{Base: base, Rates: make(map[string]float64, len(rates)+1)}
+	for _, rate := range rates {
+		table.Rates[rate.Currency] = rate.Rate
+	}
+	table.Rates[base] = 1
+	return table
+}
+
+// Has reports whether the amounts can be converted to and from the currency
+func (rates *ExchangeRates) Has(currency string) bool {
+	_, ok := rates.Rates[currency]
+	return ok
+}
+
+// Convert converts the amount to the currency through the base currency,
+// the result is rounded to minor units
+func (rates *ExchangeRates) Convert(money Money, currency string) (Money, error) {
+	if money.Currency == currency {
+		return money, nil
+	}
+	from, ok := rates.Rates[money.Currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrNoExchangeRate, money.Currency)
+	}
+	to, ok := rates.Rates[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrNoExchangeRate, currency)
+	}
+	major := float64(money.Amount) / math.Pow10(Exponent(money.Currency))
+	amount := math.Round(major / from * to * math.Pow10(Exponent(currency)))
+	return Money{Amount: int64(amount), Currency: currency}, nil
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,11 +16,24 @@ const (
 	StatusReady      Status = "ready for shipment"
 	StatusCourier    Status = "picked by courier"
 	StatusShipped    Status = "delivered"
+	// Statuses of payment of the order, they are changed by the payment
+	StatusPaymentAuthorized Status = "payment authorized"
+	StatusPaid              Status = "order paid"
+	StatusPaymentFailed     Status = "payment failed"
+	StatusRefunded          Status = "order refunded"
 
 	StandardShipmentPeriod  time.Duration = 24 * 3 * time.Hour
 	ProlongedShipmentPeriod time.Duration = 24 * 7 * time.Hour
 )
 
+// ErrOrderNotChangeable is returned when the order is changed after
+// its payment is started
+var ErrOrderNotChangeable = errors.New("order can't be changed after its payment is started")
+
+// ErrOrderNotDeletable is returned when the order with payments or returns
+// is deleted, they are kept for reconciliation
+var ErrOrderNotDeletable = errors.New("order with payments or returns can't be deleted")
+
 type Order struct {
 	ID           uuid.UUID
 	CreatedAt    time.Time
@@ -28,4 +42,8 @@ type Order struct {
 	Address      UserAddress
 	Status       Status
 	Items        []ItemWithQuantity
+	// Name of the method is copied so it is kept when the method is changed
+	ShippingMethodId uuid.UUID
+	ShippingMethod   string
+	Pricing
 }
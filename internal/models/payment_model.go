@@ -0,0 +1,162 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentStatus string
+
+const (
+	// PaymentPending is a status of payment waiting for answer of the provider
+	PaymentPending PaymentStatus = "pending"
+	// PaymentAuthorized is a status of payment with money reserved on the account of the buyer
+	PaymentAuthorized PaymentStatus = "authorized"
+	// PaymentCaptured is a status of payment with money charged
+	PaymentCaptured PaymentStatus = "captured"
+	// PaymentFailed is a status of declined or cancelled payment
+	PaymentFailed PaymentStatus = "failed"
+	// PaymentRefunded is a status of payment with money fully returned
+	PaymentRefunded PaymentStatus = "refunded"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature of webhook")
+	// ErrPaymentNotAllowed is returned when the order or the payment is
+	// in the status which doesn't allow the operation
+	ErrPaymentNotAllowed = errors.New("operation is not allowed in current status of payment")
+	// ErrPaymentProvider is returned when the provider can't handle the request
+	ErrPaymentProvider = errors.New("payment provider error")
+	// ErrPaymentExists is returned when the order already has a payment
+	// in progress or done
+	ErrPaymentExists = errors.New("order already has active payment")
+	// ErrPaymentChanged is returned when the payment or its order was
+	// changed since it was read
+	ErrPaymentChanged = errors.New("payment is changed concurrently")
+)
+
+// Payment is a payment of the order through the provider, amounts are in
+// minor units of the currency of the order
+type Payment struct {
+	Id      uuid.UUID
+	OrderId uuid.UUID
+	// Provider is a name of the provider and ProviderPaymentId is id of
+	// the payment in the provider
+	Provider          string
+	ProviderPaymentId string
+	Amount            int64
+	Currency          string
+	Status            PaymentStatus
+	// Refunded is a sum of refunds of captured payment
+	Refunded int64
+	// RedirectURL is a page of the provider where the buyer confirms
+	// the payment, empty if confirmation is not needed
+	RedirectURL   string
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// PaymentEvent is a change of the payment reported by the provider, Id is
+// unique among events of the provider
+type PaymentEvent struct {
+	Id                string
+	ProviderPaymentId string
+	Status            PaymentStatus
+	// Amount is the refunded amount for refunds
+	Amount int64
+	Reason string
+}
+
+// PaymentUpdate is a new state of the payment and of its order with the
+// state they were read in. The update is saved only if they were not
+// changed since then
+type PaymentUpdate struct {
+	Payment *Payment
+	// FromStatus and FromRefunded are the state of the payment when it was read
+	FromStatus   PaymentStatus
+	FromRefunded int64
+	// OrderStatus is a new status of the order, empty keeps it.
+	// FromOrderStatus is the status of the order when it was read
+	OrderStatus     Status
+	FromOrderStatus Status
+}
+
+// Active reports whether the payment is in progress or done, so the order
+// must not be paid again
+func (payment *Payment) Active() bool {
+	switch payment.Status {
+	case PaymentPending, PaymentAuthorized, PaymentCaptured:
+		return true
+	}
+	return false
+}
+
+// Apply changes the payment by the event. False is returned if the event
+// doesn't change the payment, e.g. it is late or repeated
+func (payment *Payment) Apply(event PaymentEvent) (bool, error) {
+	switch event.Status {
+	case PaymentAuthorized:
+		if payment.Status != PaymentPending {
+			return false, nil
+		}
+	case PaymentCaptured:
+		if payment.Status != PaymentPending && payment.Status != PaymentAuthorized {
+			return false, nil
+		}
+	case PaymentFailed:
+		if payment.Status != PaymentPending && payment.Status != PaymentAuthorized {
+			return false, nil
+		}
+		payment.FailureReason = event.Reason
+	case PaymentRefunded:
+		if payment.Status != PaymentCaptured {
+			return false, nil
+		}
+		if event.Amount <= 0 || event.Amount > payment.Amount-payment.Refunded {
+			return false, fmt.Errorf("%w: refund %d of payment %d refunded %d", ErrPaymentNotAllowed,
+				event.Amount, payment.Amount, payment.Refunded)
+		}
+		payment.Refunded += event.Amount
+		// Partially refunded payment stays captured
+		if payment.Refunded < payment.Amount {
+			return true, nil
+		}
+	default:
+		return false, fmt.Errorf("%w: unknown status %q", ErrPaymentNotAllowed, event.Status)
+	}
+	payment.Status = event.Status
+	return true, nil
+}
+
+// OrderStatus returns status of the order with the payment. The status is
+// changed only while the order waits for payment, except refund which
+// changes status of the order at any stage
+func (payment *Payment) OrderStatus(current Status) (Status, bool) {
+	var status Status
+	switch payment.Status {
+	case PaymentAuthorized:
+		status = StatusPaymentAuthorized
+	case PaymentCaptured:
+		status = StatusPaid
+	case PaymentFailed:
+		status = StatusPaymentFailed
+	case PaymentRefunded:
+		return StatusRefunded, current != StatusRefunded
+	default:
+		return current, false
+	}
+	switch current {
+	case StatusCreated, StatusPaymentAuthorized, StatusPaymentFailed:
+		return status, current != status
+	}
+	return current, false
+}
+
+// Payable reports whether the order in the status can be paid
+func (status Status) Payable() bool {
+	return status == StatusCreated || status == StatusPaymentFailed
+}
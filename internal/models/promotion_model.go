@@ -0,0 +1,218 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DiscountKind string
+
+const (
+	// DiscountPercent takes the percent of the price of discounted items
+	DiscountPercent DiscountKind = "percent"
+	// DiscountFixed takes the fixed amount, but not more than the price of discounted items
+	DiscountFixed DiscountKind = "fixed"
+)
+
+type DiscountScope string
+
+const (
+	// ScopeCart discounts the whole cart
+	ScopeCart DiscountScope = "cart"
+	// ScopeItem discounts only the item with id of the scope
+	ScopeItem DiscountScope = "item"
+	// ScopeCategory discounts only items of the category with id of the scope
+	ScopeCategory DiscountScope = "category"
+)
+
+var (
+	ErrInvalidPromotion = errors.New("invalid promotion")
+	// ErrPromoCode is wrapped by all errors of applying the promo code
+	ErrPromoCode          = errors.New("promo code can't be applied")
+	ErrPromoNotActive     = fmt.Errorf("%w: promo code is not active", ErrPromoCode)
+	ErrPromoExpired       = fmt.Errorf("%w: promo code is expired", ErrPromoCode)
+	ErrPromoLimitReached  = fmt.Errorf("%w: usage limit of promo code is reached", ErrPromoCode)
+	ErrPromoMinOrder      = fmt.Errorf("%w: order amount is less than minimum of promo code", ErrPromoCode)
+	ErrPromoNotApplicable = fmt.Errorf("%w: cart has no items discounted by promo code", ErrPromoCode)
+)
+
+// Promotion is a discount applied by the promo code or, if the code is
+// empty, automatically to every cart matching its conditions
+type Promotion struct {
+	Id   uuid.UUID
+	Code string
+	Name string
+	Kind DiscountKind
+	// Value is a percent or a fixed amount, fixed amount and minimum
+	// of order are in minor units of the base currency
+	Value int64
+	Scope DiscountScope
+	// ScopeId is id of the item or category, it is empty for the whole cart
+	ScopeId  uuid.UUID
+	MinOrder int64
+	// Limits of usages in orders, zero means unlimited
+	UsageLimit   int
+	PerUserLimit int
+	Used         int
+	// Zero time means the promotion is not limited in time
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Active    bool
+	CreatedAt time.Time
+}
+
+// AppliedDiscount is a discount of the promotion in the cart or order
+type AppliedDiscount struct {
+	PromotionId uuid.UUID
+	Code        string
+	Name        string
+	Amount      int64
+}
+
+// Pricing is the amount of the cart or order with applied discounts,
+// taxes and delivery, amounts are in minor units of the currency. The
+// total includes exclusive taxes and the price of delivery
+type Pricing struct {
+	Currency  string
+	Subtotal  int64
+	Discounts []AppliedDiscount
+	Taxes     []TaxLine
+	Shipping  int64
+	Total     int64
+}
+
+// NormalizeCode returns promo code in the form it is stored
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Automatic reports whether the promotion is applied without promo code
+func (promotion *Promotion) Automatic() bool {
+	return promotion.Code == ""
+}
+
+// Validate checks settings of the promotion
+func (promotion *Promotion) Validate() error {
+	if strings.TrimSpace(promotion.Name) == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidPromotion)
+	}
+	switch promotion.Kind {
+	case DiscountPercent:
+		if promotion.Value < 1 || promotion.Value > 100 {
+			return fmt.Errorf("%w: percent must be from 1 to 100", ErrInvalidPromotion)
+		}
+	case DiscountFixed:
+		if promotion.Value < 1 {
+			return fmt.Errorf("%w: fixed discount must be positive", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind of discount %q", ErrInvalidPromotion, promotion.Kind)
+	}
+	switch promotion.Scope {
+	case ScopeCart:
+		if promotion.ScopeId != uuid.Nil {
+			return fmt.Errorf("%w: discount of the whole cart can't have scope id", ErrInvalidPromotion)
+		}
+	case ScopeItem, ScopeCategory:
+		if promotion.ScopeId == uuid.Nil {
+			return fmt.Errorf("%w: empty scope id", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown scope %q", ErrInvalidPromotion, promotion.Scope)
+	}
+	if promotion.MinOrder < 0 || promotion.UsageLimit < 0 || promotion.PerUserLimit < 0 {
+		return fmt.Errorf("%w: minimum of order and limits can't be negative", ErrInvalidPromotion)
+	}
+	if !promotion.StartsAt.IsZero() && !promotion.EndsAt.IsZero() && !promotion.EndsAt.After(promotion.StartsAt) {
+		return fmt.Errorf("%w: promotion ends before it starts", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+// Available checks whether the promotion can be used at the given time
+func (promotion *Promotion) Available(now time.Time) error {
+	if !promotion.Active || (!promotion.StartsAt.IsZero() && now.Before(promotion.StartsAt)) {
+		return ErrPromoNotActive
+	}
+	if !promotion.EndsAt.IsZero() && !now.Before(promotion.EndsAt) {
+		return ErrPromoExpired
+	}
+	if promotion.UsageLimit > 0 && promotion.Used >= promotion.UsageLimit {
+		return ErrPromoLimitReached
+	}
+	return nil
+}
+
+// Check checks whether the promotion discounts the given items
+func (promotion *Promotion) Check(items []ItemWithQuantity) error {
+	if Subtotal(items) < promotion.MinOrder {
+		return ErrPromoMinOrder
+	}
+	if promotion.Discount(items) == 0 {
+		return ErrPromoNotApplicable
+	}
+	return nil
+}
+
+// Discount returns amount of the discount of the given items
+func (promotion *Promotion) Discount(items []ItemWithQuantity) int64 {
+	if Subtotal(items) < promotion.MinOrder {
+		return 0
+	}
+	var base int64
+	for _, item := range items {
+		switch {
+		case promotion.Scope == ScopeCart,
+			promotion.Scope == ScopeItem && item.Id == promotion.ScopeId,
+			promotion.Scope == ScopeCategory && item.Category.Id == promotion.ScopeId:
+			base += item.Price * int64(item.Quantity)
+		}
+	}
+	if promotion.Kind == DiscountPercent {
+		return base * promotion.Value / 100
+	}
+	if promotion.Value < base {
+		return promotion.Value
+	}
+	return base
+}
+
+// Subtotal returns amount of the items without discounts
+func Subtotal(items []ItemWithQuantity) int64 {
+	var subtotal int64
+	for _, item := range items {
+		subtotal += item.Price * int64(item.Quantity)
+	}
+	return subtotal
+}
+
+// ApplyPromotions applies promotions to the items in the given order.
+// Discounts are summed up, but the total is never less than zero
+func ApplyPromotions(items []ItemWithQuantity, promotions []Promotion) Pricing {
+	pricing := Pricing{
+		Subtotal:  Subtotal(items),
+		Discounts: make([]AppliedDiscount, 0, len(promotions)),
+	}
+	pricing.Total = pricing.Subtotal
+	for _, promotion := range promotions {
+		amount := promotion.Discount(items)
+		if amount > pricing.Total {
+			amount = pricing.Total
+		}
+		if amount <= 0 {
+			continue
+		}
+		pricing.Total -= amount
+		pricing.Discounts = append(pricing.Discounts, AppliedDiscount{
+			PromotionId: promotion.Id,
+			Code:        promotion.Code,
+			Name:        promotion.Name,
+			Amount:      amount,
+		})
+	}
+	return pricing
+}
@@ -0,0 +1,72 @@
+package models
+
+import "github.com/google/uuid"
+
+// ItemAvailability is a state of the item in the catalogue
+// for adding it to the cart
+type ItemAvailability struct {
+	Deleted bool
+	// Stock is nil if the stock of the item isn't tracked
+	Stock  *int
+	Limits QuantityLimits
+}
+
+// ReorderSkipReason tells why the line of the order isn't copied to the cart
+type ReorderSkipReason string
+
+const (
+	// ReorderDeleted - item is deleted from the catalogue
+	ReorderDeleted ReorderSkipReason = "deleted"
+	// ReorderUnavailable - item is out of stock
+	ReorderUnavailable ReorderSkipReason = "unavailable"
+	// ReorderQuantityLimit - the cart already has the maximal quantity of the item
+	ReorderQuantityLimit ReorderSkipReason = "quantity_limit"
+)
+
+// ReorderLine is a line of the order copied to the cart. Added can be less
+// than Requested if the quantity is limited by the stock or limits of the item
+type ReorderLine struct {
+	ItemId    uuid.UUID
+	Title     string
+	Requested int
+	Added     int
+	Reason    ReorderSkipReason
+}
+
+// Reorder is a result of copying items of the order to the cart of the user
+type Reorder struct {
+	CartId  uuid.UUID
+	Added   []ReorderLine
+	Skipped []ReorderLine
+}
+
+// Addable returns the quantity of the item which can be added to the
+// cart line with inCart quantity instead of requested one. The line is
+// kept within limits and stock of the item, so the result can differ
+// from requested. Zero quantity is returned with the reason of skipping
+func (availability ItemAvailability) Addable(inCart int, requested int) (int, ReorderSkipReason) {
+	if availability.Deleted {
+		return 0, ReorderDeleted
+	}
+	stock := availability.Stock
+	if stock != nil && *stock <= 0 {
+		return 0, ReorderUnavailable
+	}
+	quantity := inCart + requested
+	if limits := availability.Limits; limits.Max != 0 && quantity > limits.Max {
+		quantity = limits.Max
+	}
+	if quantity < availability.Limits.Min {
+		quantity = availability.Limits.Min
+	}
+	if stock != nil && quantity > *stock {
+		quantity = *stock
+		if quantity < availability.Limits.Min {
+			return 0, ReorderUnavailable
+		}
+	}
+	if quantity <= inCart {
+		return 0, ReorderQuantityLimit
+	}
+	return quantity - inCart, ""
+}
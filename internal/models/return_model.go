@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnStatus string
+
+const (
+	// ReturnRequested is a status of the return waiting for approval
+	ReturnRequested ReturnStatus = "requested"
+	// ReturnApproved is a status of the return approved by the admin,
+	// the buyer sends the items back
+	ReturnApproved ReturnStatus = "approved"
+	// ReturnRejected is a status of the return rejected by the admin
+	ReturnRejected ReturnStatus = "rejected"
+	// ReturnReceived is a status of the return with items received and restocked
+	ReturnReceived ReturnStatus = "received"
+	// ReturnRefunding is a status of the received return which money is
+	// being refunded, it isn't refunded again meanwhile
+	ReturnRefunding ReturnStatus = "refunding"
+	// ReturnRefunded is a status of the return with money refunded
+	ReturnRefunded ReturnStatus = "refunded"
+)
+
+// MaxReturnReason is a maximal length of the reason of the return
+const MaxReturnReason = 1024
+
+var (
+	// ErrInvalidReturn is returned when the return request is invalid
+	ErrInvalidReturn = errors.New("invalid return request")
+	// ErrReturnNotAllowed is returned when the order or the return is
+	// in the status which doesn't allow the operation
+	ErrReturnNotAllowed = errors.New("operation is not allowed in current status of return")
+)
+
+// Return is a request to return the quantity of the line of the order
+type Return struct {
+	Id       uuid.UUID
+	OrderId  uuid.UUID
+	UserId   uuid.UUID
+	ItemId   uuid.UUID
+	Title    string
+	Quantity int
+	Reason   string
+	Status   ReturnStatus
+	// Comment is a note of the admin on approval or rejection
+	Comment   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Open reports whether the return isn't rejected,
+// so its quantity can't be returned again
+func (ret *Return) Open() bool {
+	return ret.Status != ReturnRejected
+}
+
+// RefundLimit returns the most that can be refunded for the quantity of
+// the item returned from the order. It is the price of the quantity with
+// its share of discounts and exclusive taxes of the order, shared in
+// proportion to prices like in ApplyTaxes. Shipping isn't refunded
+func (order *Order) RefundLimit(itemId uuid.UUID, quantity int) int64 {
+	goods := order.Total - order.Shipping
+	if order.Subtotal <= 0 || goods <= 0 || quantity <= 0 {
+		return 0
+	}
+	for _, item := range order.Items {
+		if item.Id == itemId {
+			amount := mulDiv(item.Price, int64(quantity), 1, false)
+			return mulDiv(amount, goods, order.Subtotal, false)
+		}
+	}
+	return 0
+}
+
+// Refund is a refund of the order for reconciliation, the amount is in minor
+// units of the currency of the order. PaymentId is nil for refunds made
+// outside of the payment provider
+type Refund struct {
+	Id        uuid.UUID
+	OrderId   uuid.UUID
+	ReturnId  uuid.UUID
+	PaymentId uuid.UUID
+	Amount    int64
+	Currency  string
+	CreatedAt time.Time
+}
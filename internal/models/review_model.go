@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReviewStatus string
+
+const (
+	// ReviewPending is a status of review waiting for moderation
+	ReviewPending ReviewStatus = "pending"
+	// ReviewApproved is a status of review shown to everyone
+	ReviewApproved ReviewStatus = "approved"
+	// ReviewRejected is a status of review hidden by moderator
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// Limits of the rating of review
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+var (
+	ErrNotBuyer          = errors.New("only users who ordered the item can review it")
+	ErrInvalidRating     = errors.New("rating must be from 1 to 5")
+	ErrInvalidModeration = errors.New("review can be only approved or rejected")
+)
+
+// Review is a feedback of the user about ordered item. Every user has only
+// one review of the item, changed review is moderated again
+type Review struct {
+	Id        uuid.UUID
+	ItemId    uuid.UUID
+	UserId    uuid.UUID
+	UserName  string
+	Rating    int
+	Text      string
+	Status    ReviewStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShipmentStatus string
+
+const (
+	// ShipmentPending is a status of the shipment being packed
+	ShipmentPending ShipmentStatus = "pending"
+	// ShipmentShipped is a status of the shipment handed to the carrier
+	ShipmentShipped ShipmentStatus = "shipped"
+	// ShipmentInTransit is a status of the shipment on the way to the buyer
+	ShipmentInTransit ShipmentStatus = "in_transit"
+	// ShipmentDelivered is a status of the shipment received by the buyer
+	ShipmentDelivered ShipmentStatus = "delivered"
+	// ShipmentFailed is a status of the shipment the carrier couldn't deliver,
+	// its lines can be sent again by other shipment
+	ShipmentFailed ShipmentStatus = "failed"
+)
+
+var (
+	// ErrInvalidShipment is returned when lines or status of the shipment are invalid
+	ErrInvalidShipment = errors.New("invalid shipment")
+	// ErrShipmentNotAllowed is returned when the order or the shipment is
+	// in the status which doesn't allow the operation
+	ErrShipmentNotAllowed = errors.New("operation is not allowed in current status of shipment")
+	// ErrCarrier is returned when the carrier fails to handle the request
+	ErrCarrier = errors.New("carrier error")
+)
+
+// Shipment is a parcel with the part of lines of the order
+type Shipment struct {
+	Id             uuid.UUID
+	OrderId        uuid.UUID
+	Carrier        string
+	TrackingNumber string
+	Status         ShipmentStatus
+	Lines          []ShipmentLine
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// ShippedAt and DeliveredAt are zero until the shipment gets these statuses
+	ShippedAt   time.Time
+	DeliveredAt time.Time
+}
+
+// ShipmentLine is a quantity of the item of the order in the shipment
+type ShipmentLine struct {
+	ItemId   uuid.UUID
+	Title    string
+	Quantity int
+}
+
+// Active reports whether the shipment isn't failed, so its lines
+// can't be sent again
+func (shipment *Shipment) Active() bool {
+	return shipment.Status != ShipmentFailed
+}
+
+// Tracked reports whether the carrier still reports changes of the shipment
+func (shipment *Shipment) Tracked() bool {
+	return shipment.Status == ShipmentShipped || shipment.Status == ShipmentInTransit
+}
+
+// Apply changes status of the shipment to the status reported by the carrier
+// or set by the admin, false is returned if the status is the same. Shipment
+// can't go back to pending and can't leave final statuses
+func (shipment *Shipment) Apply(status ShipmentStatus, at time.Time) (bool, error) {
+	switch status {
+	case ShipmentShipped, ShipmentInTransit, ShipmentDelivered, ShipmentFailed:
+	default:
+		return false, ErrInvalidShipment
+	}
+	if status == shipment.Status {
+		return false, nil
+	}
+	if shipment.Status == ShipmentDelivered || shipment.Status == ShipmentFailed {
+		return false, ErrShipmentNotAllowed
+	}
+	if shipment.ShippedAt.IsZero() {
+		shipment.ShippedAt = at
+	}
+	if status == ShipmentDelivered {
+		shipment.DeliveredAt = at
+	}
+	shipment.Status = status
+	return true, nil
+}
+
+// Unshipped returns quantities of the lines of the order which are not in
+// active shipments
+func (order *Order) Unshipped(shipments []Shipment) map[uuid.UUID]int {
+	rest := make(map[uuid.UUID]int, len(order.Items))
+	for _, item := range order.Items {
+		rest[item.Id] += item.Quantity
+	}
+	for _, shipment := range shipments {
+		if !shipment.Active() {
+			continue
+		}
+		for _, line := range shipment.Lines {
+			rest[line.ItemId] -= line.Quantity
+		}
+	}
+	for itemId, quantity := range rest {
+		if quantity <= 0 {
+			delete(rest, itemId)
+		}
+	}
+	return rest
+}
+
+// ShipmentsStatus returns status of the order derived from its shipments.
+// The order is picked by courier while any shipment is on the way and
+// delivered when all lines are delivered. Orders without shipments on the
+// way and refunded orders keep their status
+func (order *Order) ShipmentsStatus(shipments []Shipment) (Status, bool) {
+	if order.Status == StatusRefunded {
+		return order.Status, false
+	}
+	sent, delivered := false, true
+	for _, shipment := range shipments {
+		switch shipment.Status {
+		case ShipmentFailed:
+		case ShipmentPending:
+			delivered = false
+		default:
+			sent = true
+			delivered = delivered && shipment.Status == ShipmentDelivered
+		}
+	}
+	if !sent {
+		return order.Status, false
+	}
+	status := StatusCourier
+	if delivered && len(order.Unshipped(shipments)) == 0 {
+		status = StatusShipped
+	}
+	return status, status != order.Status
+}
+
+// Shippable reports whether shipments can be created for the order with
+// the payments. Status of the order can be changed by hand, so the order
+// is shipped only with authorized or captured payment, unpaid and
+// refunded orders aren't shipped
+func Shippable(payments []Payment) bool {
+	for _, payment := range payments {
+		if payment.Status == PaymentAuthorized || payment.Status == PaymentCaptured {
+			return true
+		}
+	}
+	return false
+}
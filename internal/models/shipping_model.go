@@ -0,0 +1,192 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShippingKind string
+
+const (
+	ShippingCourier  ShippingKind = "courier"
+	ShippingPickup   ShippingKind = "pickup"
+	ShippingStandard ShippingKind = "standard"
+	ShippingExpress  ShippingKind = "express"
+)
+
+var (
+	ErrInvalidShippingMethod = errors.New("invalid shipping method")
+	// ErrShippingUnavailable is returned when the method can't deliver
+	// the items to the address
+	ErrShippingUnavailable = errors.New("shipping method is not available")
+)
+
+// ShippingZone is a country or a region of the country where the method
+// delivers, region is compared with the city of the address. Surcharge is
+// added to the price of delivery to the zone
+type ShippingZone struct {
+	Country   string
+	Region    string
+	Surcharge int64
+}
+
+// ShippingMethod is a way of delivery of orders, amounts are in minor
+// units of the base currency and weights are in grams
+type ShippingMethod struct {
+	Id    uuid.UUID
+	Name  string
+	Kind  ShippingKind
+	Price int64
+	// PricePerKg is added for every started kilogram of the order
+	PricePerKg int64
+	// FreeFrom is the order total from which delivery is free, zero
+	// means delivery is never free
+	FreeFrom int64
+	// MaxWeight of the order, zero means unlimited
+	MaxWeight int64
+	// Delivery takes from MinDays to MaxDays days
+	MinDays int
+	MaxDays int
+	// Zones of delivery, empty zones mean everywhere
+	Zones     []ShippingZone
+	Active    bool
+	CreatedAt time.Time
+}
+
+// ShippingQuote is a price and estimated dates of delivery by the method
+type ShippingQuote struct {
+	Method        ShippingMethod
+	Price         int64
+	EstimatedFrom time.Time
+	EstimatedTo   time.Time
+}
+
+// Normalize brings countries and regions of zones to the form they are compared
+func (method *ShippingMethod) Normalize() {
+	for i := range method.Zones {
+		method.Zones[i].Country = NormalizePlace(method.Zones[i].Country)
+		method.Zones[i].Region = NormalizePlace(method.Zones[i].Region)
+	}
+}
+
+// Validate checks settings of the shipping method
+func (method *ShippingMethod) Validate() error {
+	if strings.TrimSpace(method.Name) == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidShippingMethod)
+	}
+	switch method.Kind {
+	case ShippingCourier, ShippingPickup, ShippingStandard, ShippingExpress:
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidShippingMethod, method.Kind)
+	}
+	if method.Price < 0 || method.PricePerKg < 0 || method.FreeFrom < 0 || method.MaxWeight < 0 {
+		return fmt.Errorf("%w: prices and weight can't be negative", ErrInvalidShippingMethod)
+	}
+	if method.MinDays < 0 || method.MaxDays < method.MinDays {
+		return fmt.Errorf("%w: invalid days of delivery", ErrInvalidShippingMethod)
+	}
+	for _, zone := range method.Zones {
+		if zone.Country == "" {
+			return fmt.Errorf("%w: zone without country", ErrInvalidShippingMethod)
+		}
+		if zone.Surcharge < 0 {
+			return fmt.Errorf("%w: surcharge can't be negative", ErrInvalidShippingMethod)
+		}
+	}
+	return nil
+}
+
+// zoneFor returns zone of the address, the zone of the region is preferred
+// to the zone of the whole country. Method without zones delivers everywhere
+func (method *ShippingMethod) zoneFor(address UserAddress) (ShippingZone, bool) {
+	if len(method.Zones) == 0 {
+		return ShippingZone{}, true
+	}
+	country := NormalizePlace(address.Country)
+	region := NormalizePlace(address.City)
+	var result ShippingZone
+	found := false
+	for _, zone := range method.Zones {
+		if zone.Country != country {
+			continue
+		}
+		if zone.Region == region && region != "" {
+			return zone, true
+		}
+		if zone.Region == "" {
+			result = zone
+			found = true
+		}
+	}
+	return result, found
+}
+
+// Quote returns price and estimated dates of delivery of the order with
+// the given weight and total to the address
+func (method *ShippingMethod) Quote(weight, total int64, address UserAddress, now time.Time) (ShippingQuote, error) {
+	if !method.Active {
+		return ShippingQuote{}, fmt.Errorf("%w: method is not active", ErrShippingUnavailable)
+	}
+	zone, ok := method.zoneFor(address)
+	if !ok {
+		return ShippingQuote{}, fmt.Errorf("%w: no delivery to the address", ErrShippingUnavailable)
+	}
+	if method.MaxWeight > 0 && weight > method.MaxWeight {
+		return ShippingQuote{}, fmt.Errorf("%w: order is too heavy", ErrShippingUnavailable)
+	}
+	price := method.Price + method.PricePerKg*((weight+999)/1000) + zone.Surcharge
+	if method.FreeFrom > 0 && total >= method.FreeFrom {
+		price = 0
+	}
+	return ShippingQuote{
+		Method:        *method,
+		Price:         price,
+		EstimatedFrom: now.AddDate(0, 0, method.MinDays),
+		EstimatedTo:   now.AddDate(0, 0, method.MaxDays),
+	}, nil
+}
+
+// ShippingQuotes returns quotes of methods which can deliver the order
+// to the address, cheapest and fastest first
+func ShippingQuotes(methods []ShippingMethod, weight, total int64, address UserAddress, now time.Time) []ShippingQuote {
+	quotes := make([]ShippingQuote, 0, len(methods))
+	for i := range methods {
+		quote, err := methods[i].Quote(weight, total, address, now)
+		if err != nil {
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if quotes[i].Price != quotes[j].Price {
+			return quotes[i].Price < quotes[j].Price
+		}
+		return quotes[i].EstimatedTo.Before(quotes[j].EstimatedTo)
+	})
+	return quotes
+}
+
+// Weight returns weight of the items in grams, weights map ids of items
+// to their weights, items without weight weigh nothing
+func Weight(items []ItemWithQuantity, weights map[uuid.UUID]int64) int64 {
+	var weight int64
+	for _, item := range items {
+		weight += weights[item.Id] * int64(item.Quantity)
+	}
+	return weight
+}
+
+// Discounted returns amount of the items after discounts without taxes
+// and delivery
+func (pricing *Pricing) Discounted() int64 {
+	amount := pricing.Subtotal
+	for _, discount := range pricing.Discounts {
+		amount -= discount.Amount
+	}
+	return amount
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImageRef is a reference to the image from the item or category
+type ImageRef struct {
+	// Kind is UploadKindItem or UploadKindCategory
+	Kind    string
+	OwnerId uuid.UUID
+	// Category is a name of category of the item or the category itself,
+	// it is needed to invalidate cached lists
+	Category string
+	URL      string
+}
+
+// StorageReport is a result of consistency check of the file storage
+// and references to images in the database
+type StorageReport struct {
+	DryRun    bool
+	CheckedAt time.Time
+	Files     int
+	Refs      int
+	// OrphanFiles are files not referenced by any item or category
+	OrphanFiles []string
+	// DanglingRefs are references to missing files
+	DanglingRefs []ImageRef
+	DeletedFiles int
+	RemovedRefs  int
+	// Errors of deleting, the check continues after them
+	Errors []string
+}
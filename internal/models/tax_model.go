@@ -0,0 +1,171 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaxClassStandard is the tax class of items and categories without class
+const TaxClassStandard = "standard"
+
+// MaxTaxRate is 100% in hundredths of percent
+const MaxTaxRate = 10000
+
+var ErrInvalidTaxRule = errors.New("invalid tax rule")
+
+// TaxRule is a tax of items of the tax class shipped to the country or
+// the region of the country. The rule of the region overrides the rule
+// of the whole country
+type TaxRule struct {
+	Id      uuid.UUID
+	Name    string
+	Country string
+	// Region is compared with the city of the address, empty region
+	// means the whole country
+	Region   string
+	TaxClass string
+	// Rate is in hundredths of percent, e.g. 2000 is 20%
+	Rate int64
+	// Inclusive tax is already included in prices, it is only shown in
+	// the breakdown. Exclusive tax is added to the total
+	Inclusive bool
+	CreatedAt time.Time
+}
+
+// TaxLine is an amount of the tax rule in the cart or order
+type TaxLine struct {
+	TaxRuleId uuid.UUID
+	Name      string
+	TaxClass  string
+	Rate      int64
+	Inclusive bool
+	Amount    int64
+}
+
+// NormalizePlace returns country or region in the form it is stored and compared
+func NormalizePlace(place string) string {
+	return strings.ToUpper(strings.TrimSpace(place))
+}
+
+// NormalizeTaxClass returns tax class in the form it is stored
+func NormalizeTaxClass(class string) string {
+	return strings.ToLower(strings.TrimSpace(class))
+}
+
+// Normalize brings country, region and class of the rule to the form they
+// are stored, empty class is the standard one
+func (rule *TaxRule) Normalize() {
+	rule.Country = NormalizePlace(rule.Country)
+	rule.Region = NormalizePlace(rule.Region)
+	rule.TaxClass = NormalizeTaxClass(rule.TaxClass)
+	if rule.TaxClass == "" {
+		rule.TaxClass = TaxClassStandard
+	}
+}
+
+// Validate checks settings of the tax rule
+func (rule *TaxRule) Validate() error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidTaxRule)
+	}
+	if rule.Country == "" {
+		return fmt.Errorf("%w: empty country", ErrInvalidTaxRule)
+	}
+	if rule.Rate < 0 || rule.Rate > MaxTaxRate {
+		return fmt.Errorf("%w: rate must be from 0 to %d", ErrInvalidTaxRule, MaxTaxRate)
+	}
+	return nil
+}
+
+// taxRuleFor returns the rule of the tax class for the address,
+// the rule of the region is preferred to the rule of the whole country
+func taxRuleFor(rules []TaxRule, class string, address UserAddress) *TaxRule {
+	country := NormalizePlace(address.Country)
+	region := NormalizePlace(address.City)
+	var result *TaxRule
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Country != country || rule.TaxClass != class {
+			continue
+		}
+		if rule.Region == region && region != "" {
+			return rule
+		}
+		if rule.Region == "" {
+			result = rule
+		}
+	}
+	return result
+}
+
+// ApplyTaxes adds tax lines of the items shipped to the address to the
+// pricing. Classes map ids of items to their tax classes, items without
+// class are standard. Discounts reduce the taxed amount of every item in
+// proportion to its price. Exclusive taxes are added to the total
+func ApplyTaxes(pricing *Pricing, items []ItemWithQuantity, classes map[uuid.UUID]string, rules []TaxRule, address UserAddress) {
+	pricing.Taxes = nil
+	if pricing.Subtotal <= 0 {
+		return
+	}
+	bases := make(map[uuid.UUID]int64, len(rules))
+	for _, item := range items {
+		class, ok := classes[item.Id]
+		if !ok {
+			class = TaxClassStandard
+		}
+		rule := taxRuleFor(rules, class, address)
+		if rule == nil {
+			continue
+		}
+		bases[rule.Id] += item.Price * int64(item.Quantity)
+	}
+	total := pricing.Total
+	for _, rule := range rules {
+		base, ok := bases[rule.Id]
+		if !ok {
+			continue
+		}
+		base = mulDiv(base, total, pricing.Subtotal, false)
+		var amount int64
+		if rule.Inclusive {
+			amount = mulDiv(base, rule.Rate, MaxTaxRate+rule.Rate, true)
+		} else {
+			amount = mulDiv(base, rule.Rate, MaxTaxRate, true)
+			pricing.Total += amount
+		}
+		pricing.Taxes = append(pricing.Taxes, TaxLine{
+			TaxRuleId: rule.Id,
+			Name:      rule.Name,
+			TaxClass:  rule.TaxClass,
+			Rate:      rule.Rate,
+			Inclusive: rule.Inclusive,
+			Amount:    amount,
+		})
+	}
+}
+
+// mulDiv returns a*b/c of non-negative values rounded half up or truncated.
+// The product is calculated in 128 bits, so it doesn't overflow, and the
+// result is capped by math.MaxInt64
+func mulDiv(a int64, b int64, c int64, round bool) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if round {
+		var carry uint64
+		lo, carry = bits.Add64(lo, uint64(c)/2, 0)
+		hi += carry
+	}
+	if hi >= uint64(c) {
+		return math.MaxInt64
+	}
+	quotient, _ := bits.Div64(hi, lo, uint64(c))
+	if quotient > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(quotient)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadStatus string
+
+const (
+	// UploadPending is a status of session waiting for the file
+	UploadPending UploadStatus = "pending"
+	// UploadUploaded is a status of session with the file uploaded to the service
+	UploadUploaded UploadStatus = "uploaded"
+	// UploadProcessing is a status of session which file is processed now
+	UploadProcessing UploadStatus = "processing"
+	// UploadCompleted is a status of session which image is attached to its owner
+	UploadCompleted UploadStatus = "completed"
+)
+
+// Kinds of owners of uploaded images
+const (
+	UploadKindItem     = "item"
+	UploadKindCategory = "category"
+)
+
+var (
+	ErrUploadExpired   = errors.New("upload session is expired")
+	ErrUploadCompleted = errors.New("upload session is already completed")
+	ErrUploadTooLarge  = errors.New("uploaded file is too large")
+)
+
+// UploadSession allows client to upload the image directly to the file
+// storage, the image is attached to the item or category after confirmation
+type UploadSession struct {
+	Id        uuid.UUID
+	Kind      string
+	OwnerId   uuid.UUID
+	Status    UploadStatus
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	// UploadURL is the url to upload the file by PUT request,
+	// it is not stored
+	UploadURL string
+}
+
+// IsExpired reports whether the file can not be uploaded or confirmed anymore
+func (session *UploadSession) IsExpired(now time.Time) bool {
+	return !now.Before(session.ExpiresAt)
+}
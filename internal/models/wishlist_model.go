@@ -0,0 +1,39 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWishlistName is a name of the list of favourites of the user
+const DefaultWishlistName = "Favourites"
+
+// MaxWishlistName is a maximal length of the name of wishlist
+const MaxWishlistName = 256
+
+var (
+	ErrDefaultWishlist     = errors.New("default wishlist can't be renamed, deleted or shared")
+	ErrInvalidWishlistName = errors.New("name of wishlist must be from 1 to 256 characters")
+	ErrSameWishlist        = errors.New("item is already in this wishlist")
+)
+
+// Wishlist is a named list of items of the user. Favourites of the user
+// are the default list with nil id
+type Wishlist struct {
+	Id     uuid.UUID
+	UserId uuid.UUID
+	Name   string
+	// ShareToken is a token of the public read-only link,
+	// it is empty if the list isn't shared
+	ShareToken string
+	ItemsCount int
+	Items      []Item
+	CreatedAt  time.Time
+}
+
+// IsDefault reports whether the wishlist is the list of favourites
+func (wishlist *Wishlist) IsDefault() bool {
+	return wishlist.Id == uuid.Nil
+}
@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// FileMessage is a line of the file of the file notifier
+type FileMessage struct {
+	Kind           string    `json:"kind"`
+	UserId         string    `json:"userId"`
+	Email          string    `json:"email"`
+	Subject        string    `json:"subject"`
+	Text           string    `json:"text"`
+	UnsubscribeURL string    `json:"unsubscribeURL,omitempty"`
+	SentAt         time.Time `json:"sentAt"`
+}
+
+var _ Notifier = &FileNotifier{}
+
+// FileNotifier appends notifications to the file as JSON lines,
+// for local use and tests of sent messages
+type FileNotifier struct {
+	path   string
+	mu     sync.Mutex
+	logger *zap.Logger
+}
+
+func NewFileNotifier(path string, logger *zap.Logger) *FileNotifier {
+	logger.Debug("Enter in notification NewFileNotifier()")
+	return &FileNotifier{path: path, logger: logger}
+}
+
+func (notifier *FileNotifier) Name() string {
+	return "file"
+}
+
+func (notifier *FileNotifier) Notify(ctx context.Context, notification *models.Notification) error {
+	notifier.logger.Sugar().Debugf("Enter in notification FileNotifier Notify() with args: ctx, notification: %v", notification.Kind)
+	line, err := json.Marshal(FileMessage{
+		Kind:           string(notification.Kind),
+		UserId:         notification.UserId.String(),
+		Email:          notification.Email,
+		Subject:        notification.Subject,
+		Text:           notification.Text,
+		UnsubscribeURL: notification.UnsubscribeURL,
+		SentAt:         time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("error on marshal notification: %w", err)
+	}
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	file, err := os.OpenFile(notifier.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error on open file of notifications: %w", err)
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error on write notification: %w", err)
+	}
+	return nil
+}
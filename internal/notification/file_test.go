@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"OnlineShopBackend/internal/models"
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileNotifier(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+	notifier := NewFileNotifier(path, zap.L())
+	userId := uuid.New()
+
+	for _, subject := range []string{"first", "second"} {
+		err := notifier.Notify(ctx, &models.Notification{
+			Kind:           models.NotificationCartReminder,
+			UserId:         userId,
+			Email:          "user@example.com",
+			Subject:        subject,
+			Text:           "text",
+			UnsubscribeURL: "http://localhost/unsubscribe",
+		})
+		require.NoError(t, err)
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var messages []FileMessage
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message FileMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &message))
+		messages = append(messages, message)
+	}
+	require.NoError(t, scanner.Err())
+	// Notifications are appended
+	require.Len(t, messages, 2)
+	require.Equal(t, "first", messages[0].Subject)
+	require.Equal(t, "second", messages[1].Subject)
+	require.Equal(t, string(models.NotificationCartReminder), messages[1].Kind)
+	require.Equal(t, userId.String(), messages[1].UserId)
+	require.Equal(t, "http://localhost/unsubscribe", messages[1].UnsubscribeURL)
+}
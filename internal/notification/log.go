@@ -0,0 +1,37 @@
+package notification
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+var _ Notifier = &LogNotifier{}
+
+// LogNotifier writes notifications to the log, for local use
+type LogNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) *LogNotifier {
+	logger.Debug("Enter in notification NewLogNotifier()")
+	return &LogNotifier{logger: logger}
+}
+
+func (notifier *LogNotifier) Name() string {
+	return "log"
+}
+
+func (notifier *LogNotifier) Notify(ctx context.Context, notification *models.Notification) error {
+	notifier.logger.Info("notification",
+		zap.String("kind", string(notification.Kind)),
+		zap.String("userId", notification.UserId.String()),
+		zap.String("email", notification.Email),
+		zap.String("subject", notification.Subject),
+		zap.String("text", notification.Text),
+		// The link isn't logged, its token unsubscribes the user
+		zap.Bool("unsubscribeLink", notification.UnsubscribeURL != ""),
+	)
+	return nil
+}
@@ -0,0 +1,12 @@
+package notification
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+)
+
+// Notifier delivers notifications to users, e.g. by email
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, notification *models.Notification) error
+}
@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// FakeSignatureHeader is a header with hex encoded HMAC-SHA256 of body of
+// the webhook signed by the secret
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeWebhook is a body of webhook of the fake provider
+type FakeWebhook struct {
+	Id        string `json:"id"`
+	PaymentId string `json:"paymentId"`
+	Status    string `json:"status"`
+	Amount    int64  `json:"amount,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+var _ PaymentProvider = &FakeProvider{}
+
+// FakeProvider is a provider for local testing, it approves all requests
+// at once. Declines and other changes are sent by webhooks signed by the secret
+type FakeProvider struct {
+	secret []byte
+	logger *zap.Logger
+}
+
+func NewFakeProvider(secret string, logger *zap.Logger) *FakeProvider {
+	logger.Debug("Enter in payment NewFakeProvider()")
+	return &FakeProvider{secret: []byte(secret), logger: logger}
+}
+
+func (provider *FakeProvider) Name() string {
+	return "fake"
+}
+
+// Authorize reserves money of the payment
+func (provider *FakeProvider) Authorize(ctx context.Context, payment *models.Payment) (*Result, error) {
+	provider.logger.Sugar().Debugf("Enter in payment FakeProvider Authorize() with args: ctx, payment: %v", payment)
+	if payment.Amount <= 0 {
+		return &Result{Status: models.PaymentFailed, Reason: "invalid amount"}, nil
+	}
+	return &Result{
+		ProviderPaymentId: "fake_" + uuid.NewString(),
+		Status:            models.PaymentAuthorized,
+	}, nil
+}
+
+// Capture charges reserved money
+func (provider *FakeProvider) Capture(ctx context.Context, providerPaymentId string, amount int64) (*Result, error) {
+	provider.logger.Sugar().Debugf("Enter in payment FakeProvider Capture() with args: ctx, providerPaymentId: %s, amount: %d", providerPaymentId, amount)
+	return &Result{ProviderPaymentId: providerPaymentId, Status: models.PaymentCaptured}, nil
+}
+
+// Refund returns charged money
+func (provider *FakeProvider) Refund(ctx context.Context, providerPaymentId string, amount int64) (*Result, error) {
+	provider.logger.Sugar().Debugf("Enter in payment FakeProvider Refund() with args: ctx, providerPaymentId: %s, amount: %d", providerPaymentId, amount)
+	return &Result{ProviderPaymentId: providerPaymentId, Status: models.PaymentRefunded}, nil
+}
+
+// ParseWebhook checks signature of the webhook and decodes it
+func (provider *FakeProvider) ParseWebhook(payload []byte, header http.Header) (*models.PaymentEvent, error) {
+	provider.logger.Debug("Enter in payment FakeProvider ParseWebhook()")
+	signature, err := hex.DecodeString(header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, provider.sign(payload)) {
+		return nil, models.ErrInvalidSignature
+	}
+	var webhook FakeWebhook
+	err = json.Unmarshal(payload, &webhook)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode webhook: %w", err)
+	}
+	if webhook.Id == "" || webhook.PaymentId == "" {
+		return nil, fmt.Errorf("webhook without id of event or payment")
+	}
+	return &models.PaymentEvent{
+		Id:                webhook.Id,
+		ProviderPaymentId: webhook.PaymentId,
+		Status:            models.PaymentStatus(webhook.Status),
+		Amount:            webhook.Amount,
+		Reason:            webhook.Reason,
+	}, nil
+}
+
+// Sign returns signature of the payload for the header FakeSignatureHeader
+func (provider *FakeProvider) Sign(payload []byte) string {
+	return hex.EncodeToString(provider.sign(payload))
+}
+
+func (provider *FakeProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, provider.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
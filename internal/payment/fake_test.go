@@ -0,0 +1,54 @@
+package payment
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFakeProvider(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider("secret", zap.L())
+
+	result, err := provider.Authorize(ctx, &models.Payment{Amount: 1000, Currency: "RUB"})
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentAuthorized, result.Status)
+	require.NotEmpty(t, result.ProviderPaymentId)
+
+	result, err = provider.Authorize(ctx, &models.Payment{Amount: 0, Currency: "RUB"})
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentFailed, result.Status)
+}
+
+func TestFakeProviderParseWebhook(t *testing.T) {
+	provider := NewFakeProvider("secret", zap.L())
+	payload := []byte(`{"id":"evt_1","paymentId":"fake_1","status":"refunded","amount":500}`)
+
+	header := http.Header{}
+	_, err := provider.ParseWebhook(payload, header)
+	require.ErrorIs(t, err, models.ErrInvalidSignature)
+
+	// Signature of another secret
+	header.Set(FakeSignatureHeader, NewFakeProvider("other", zap.L()).Sign(payload))
+	_, err = provider.ParseWebhook(payload, header)
+	require.ErrorIs(t, err, models.ErrInvalidSignature)
+
+	header.Set(FakeSignatureHeader, provider.Sign(payload))
+	event, err := provider.ParseWebhook(payload, header)
+	require.NoError(t, err)
+	require.Equal(t, &models.PaymentEvent{
+		Id:                "evt_1",
+		ProviderPaymentId: "fake_1",
+		Status:            models.PaymentRefunded,
+		Amount:            500,
+	}, event)
+
+	payload = []byte(`{"paymentId":"fake_1","status":"captured"}`)
+	header.Set(FakeSignatureHeader, provider.Sign(payload))
+	_, err = provider.ParseWebhook(payload, header)
+	require.Error(t, err)
+}
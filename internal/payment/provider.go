@@ -0,0 +1,33 @@
+package payment
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"net/http"
+)
+
+// PaymentProvider is a payment system which charges buyers. Money is
+// reserved by authorization and charged by capture, captured money can
+// be refunded. Result of any request can come later by webhook
+type PaymentProvider interface {
+	// Name is saved with payments to find them by webhooks
+	Name() string
+	Authorize(ctx context.Context, pay *models.Payment) (*Result, error)
+	Capture(ctx context.Context, providerPaymentId string, amount int64) (*Result, error)
+	Refund(ctx context.Context, providerPaymentId string, amount int64) (*Result, error)
+	// ParseWebhook verifies signature of the webhook and returns its event,
+	// models.ErrInvalidSignature is returned for forged webhooks
+	ParseWebhook(payload []byte, header http.Header) (*models.PaymentEvent, error)
+}
+
+// Result is an answer of the provider. Status is pending if the result
+// will come by webhook
+type Result struct {
+	ProviderPaymentId string
+	Status            models.PaymentStatus
+	RedirectURL       string
+	Reason            string
+	// EventId is id of the webhook the provider sends about the same
+	// change, so the change is not applied twice
+	EventId string
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// abandonedCartRepo stores events of abandoned carts of users, the idle
+// period of the cart is identified by the time of its last activity
+type abandonedCartRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ AbandonedCartStore = (*abandonedCartRepo)(nil)
+
+func NewAbandonedCartRepo(store *PGres, log *zap.SugaredLogger) AbandonedCartStore {
+	return &abandonedCartRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// RecordAbandonedCarts records events of carts of users with items, which
+// are idle since given time and have no event of the current idle period.
+// Returns the number of recorded events
+func (repo *abandonedCartRepo) RecordAbandonedCarts(ctx context.Context, idleBefore time.Time) (int, error) {
+	repo.logger.Debugf("Enter in repository RecordAbandonedCarts() with args: ctx, idleBefore: %v", idleBefore)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `
+	INSERT INTO cart_abandonments (cart_id, user_id, items_count, active_at)
+	SELECT c.id, c.user_id, SUM(ci.item_quantity), c.active_at
+	FROM carts c
+	INNER JOIN cart_items ci ON ci.cart_id = c.id
+	WHERE c.user_id IS NOT NULL AND c.active_at < $1
+	GROUP BY c.id
+	ON CONFLICT (cart_id, active_at) DO NOTHING`, idleBefore)
+	if err != nil {
+		repo.logger.Errorf("can't record abandoned carts: %s", err)
+		return 0, fmt.Errorf("can't record abandoned carts: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// GetUnsentReminders returns events of abandoned carts of users subscribed
+// to reminders, which weren't notified yet. Carts which are active again,
+// emptied or deleted after the event are skipped
+func (repo *abandonedCartRepo) GetUnsentReminders(ctx context.Context) ([]models.CartAbandonment, error) {
+	repo.logger.Debug("Enter in repository GetUnsentReminders() with args: ctx")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT a.id, a.cart_id, a.user_id, u.email, u.name, a.items_count, a.active_at, a.created_at
+	FROM cart_abandonments a
+	INNER JOIN users u ON u.id = a.user_id
+	INNER JOIN carts c ON c.id = a.cart_id AND c.active_at = a.active_at
+	WHERE a.notified_at IS NULL AND a.recovered_at IS NULL AND u.cart_reminders
+	AND EXISTS (SELECT 1 FROM cart_items ci WHERE ci.cart_id = a.cart_id)
+	ORDER BY a.created_at`)
+	if err != nil {
+		repo.logger.Errorf("can't select unsent reminders: %s", err)
+		return nil, fmt.Errorf("can't select unsent reminders: %w", err)
+	}
+	defer rows.Close()
+	var abandonments []models.CartAbandonment
+	for rows.Next() {
+		var abandonment models.CartAbandonment
+		err := rows.Scan(
+			&abandonment.Id,
+			&abandonment.CartId,
+			&abandonment.UserId,
+			&abandonment.Email,
+			&abandonment.Name,
+			&abandonment.ItemsCount,
+			&abandonment.ActiveAt,
+			&abandonment.CreatedAt,
+		)
+		if err != nil {
+			repo.logger.Error(err.Error())
+			return nil, err
+		}
+		abandonments = append(abandonments, abandonment)
+	}
+	return abandonments, rows.Err()
+}
+
+// MarkReminderSent saves the time of the reminder about the abandoned cart
+func (repo *abandonedCartRepo) MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error {
+	repo.logger.Debugf("Enter in repository MarkReminderSent() with args: ctx, id: %v, at: %v", id, at)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE cart_abandonments SET notified_at = $2 WHERE id = $1`, id, at)
+	if err != nil {
+		repo.logger.Errorf("can't mark reminder sent: %s", err)
+		return fmt.Errorf("can't mark reminder sent: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// RecoverAbandonedCart marks the latest unrecovered event of the cart as
+// recovered by the order. Returns false if the cart wasn't abandoned
+func (repo *abandonedCartRepo) RecoverAbandonedCart(ctx context.Context, cartId uuid.UUID, at time.Time) (bool, error) {
+	repo.logger.Debugf("Enter in repository RecoverAbandonedCart() with args: ctx, cartId: %v, at: %v", cartId, at)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `
+	UPDATE cart_abandonments SET recovered_at = $2
+	WHERE id = (SELECT id FROM cart_abandonments WHERE cart_id = $1 AND recovered_at IS NULL
+	ORDER BY created_at DESC LIMIT 1)`, cartId, at)
+	if err != nil {
+		repo.logger.Errorf("can't recover abandoned cart: %s", err)
+		return false, fmt.Errorf("can't recover abandoned cart: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetCartReminders subscribes or unsubscribes the user from reminders
+// about abandoned carts
+func (repo *abandonedCartRepo) SetCartReminders(ctx context.Context, userId uuid.UUID, enabled bool) error {
+	repo.logger.Debugf("Enter in repository SetCartReminders() with args: ctx, userId: %v, enabled: %t", userId, enabled)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE users SET cart_reminders = $2 WHERE id = $1`, userId, enabled)
+	if err != nil {
+		repo.logger.Errorf("can't set cart reminders: %s", err)
+		return fmt.Errorf("can't set cart reminders: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
@@ -25,7 +26,8 @@ func NewCartStore(storage *PGres, logger *zap.SugaredLogger) CartStore {
 	}
 }
 
-// Create Shall we add items at the moment we create cart
+// Create Shall we add items at the moment we create cart.
+// Cart with nil user id is a guest cart
 func (c *cart) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error) {
 	c.logger.Debugf("Enter in repository cart Create() with args: ctx, userId: %v", userId)
 	select {
@@ -35,7 +37,7 @@ func (c *cart) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error)
 		pool := c.storage.GetPool()
 		var cartId uuid.UUID
 		row := pool.QueryRow(ctx, `INSERT INTO carts (user_id) VALUES ($1) RETURNING id`,
-			userId)
+			nullUUID(userId))
 		err := row.Scan(&cartId)
 		if err != nil {
 			c.logger.Error(err)
@@ -46,7 +48,8 @@ func (c *cart) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error)
 	}
 }
 
-// AddItemToCart Maybe add to item
+// AddItemToCart adds one item to the cart line, new line gets minimal
+// quantity of the item. Line isn't changed if it has maximal quantity
 func (c *cart) AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error {
 	c.logger.Debugf("Enter in repository cart AddItemToCart() with args: ctx, cartId: %v, itemId: %v", cartId, itemId)
 	select {
@@ -55,24 +58,25 @@ func (c *cart) AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.
 		return fmt.Errorf("context closed")
 	default:
 		pool := c.storage.GetPool()
-		row := pool.QueryRow(ctx, `SELECT item_id from cart_items where item_id=$1 and cart_id=$2`, itemId, cartId)
-		var checkId uuid.UUID
-		err := row.Scan(&checkId)
+		// Upsert is atomic, so concurrent requests don't lose increments.
+		// The line keeps the price the user has seen on first adding, so
+		// later changes of the price are shown as warnings of the cart.
+		// Lines added before prices were stored get the current price
+		tag, err := pool.Exec(ctx, `
+		INSERT INTO cart_items (cart_id, item_id, item_quantity, price, currency)
+		VALUES ($1, $2, COALESCE((SELECT min_quantity FROM items WHERE id = $2), 1),
+			(SELECT price FROM items WHERE id = $2), (SELECT currency FROM items WHERE id = $2))
+		ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = cart_items.item_quantity + 1,
+			price = COALESCE(cart_items.price, EXCLUDED.price),
+			currency = CASE WHEN cart_items.price IS NULL THEN EXCLUDED.currency ELSE cart_items.currency END
+		WHERE NOT EXISTS (SELECT 1 FROM items WHERE id = $2 AND max_quantity != 0 AND max_quantity <= cart_items.item_quantity)`,
+			cartId, itemId)
 		if err != nil {
-			c.logger.Errorf("error on row.Scan: %s", err)
+			c.logger.Errorf("can't add item to cart: %s", err)
+			return fmt.Errorf("can't add item to cart: %w", err)
 		}
-		if checkId == uuid.Nil {
-			_, err := pool.Exec(ctx, `INSERT INTO cart_items (cart_id, item_id, item_quantity) VALUES ($1, $2, $3)`, cartId, itemId, 1)
-			if err != nil {
-				c.logger.Errorf("can't add item to cart: %s", err)
-				return fmt.Errorf("can't add item to cart: %w", err)
-			}
-		} else {
-			_, err := pool.Exec(ctx, `UPDATE cart_items SET item_quantity = item_quantity + 1 WHERE cart_id=$1 and item_id=$2`, cartId, itemId)
-			if err != nil {
-				c.logger.Errorf("can't add item to cart: %s", err)
-				return fmt.Errorf("can't add item to cart: %w", err)
-			}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: maximal quantity is already in the cart", models.ErrQuantityLimit)
 		}
 	}
 	return nil
@@ -118,33 +122,21 @@ func (c *cart) DeleteCart(ctx context.Context, cartId uuid.UUID) error {
 		return nil
 	}
 }
+
+// DeleteItemFromCart decreases quantity of the cart line by one, the line with
+// the last item is removed. It is a decrement, which update locks the line,
+// so concurrent requests don't lose each other
 func (c *cart) DeleteItemFromCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error {
 	c.logger.Debug("Enter in repository cart DeleteItemFromCart() with args: ctx, cartId: %v, itemId: %v", cartId, itemId)
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("context closed")
 	default:
-		pool := c.storage.GetPool()
-		row := pool.QueryRow(ctx, `SELECT item_quantity from cart_items where item_id=$1 and cart_id=$2`, itemId, cartId)
-		var quantity int
-		err := row.Scan(&quantity)
+		_, err := c.DecrementItem(ctx, cartId, itemId, 1, 1)
 		if err != nil {
-			c.logger.Errorf("error on row.Scan: %s", err)
+			c.logger.Errorf("can't delete item from cart: %s", err)
 			return err
 		}
-		if quantity > 1 {
-			_, err := pool.Exec(ctx, `UPDATE cart_items SET item_quantity = item_quantity - 1 WHERE cart_id=$1 and item_id=$2`, cartId, itemId)
-			if err != nil {
-				c.logger.Errorf("can't delete item from cart: %s", err)
-				return fmt.Errorf("can't delete item from cart: %w", err)
-			}
-		} else if quantity == 1 {
-			_, err := pool.Exec(ctx, `DELETE FROM cart_items WHERE item_id=$1 AND cart_id=$2`, itemId, cartId)
-			if err != nil {
-				c.logger.Errorf("can't delete item from cart: %s", err)
-				return fmt.Errorf("can't delete item from cart: %w", err)
-			}
-		}
 		c.logger.Info("Delete item from cart success")
 		return nil
 	}
@@ -158,8 +150,10 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 	default:
 		pool := c.storage.GetPool()
 		var userId uuid.UUID
-		row := pool.QueryRow(ctx, `SELECT user_id FROM carts WHERE id = $1`, cartId)
-		err := row.Scan(&userId)
+		var promoCode string
+		var expireAt time.Time
+		row := pool.QueryRow(ctx, `SELECT user_id, COALESCE(promo_code, ''), expire_at FROM carts WHERE id = $1`, cartId)
+		err := row.Scan(&userId, &promoCode, &expireAt)
 		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 			c.logger.Error(err.Error())
 			return nil, models.ErrorNotFound{}
@@ -171,7 +165,8 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 		c.logger.Debug("read user id success: %v", userId)
 		item := models.ItemWithQuantity{}
 		rows, err := pool.Query(ctx, `
-		SELECT 	i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.vendor, i.pictures, c.item_quantity
+		SELECT 	i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.currency, i.vendor, `+itemImagesColumn("i")+`, c.item_quantity,
+			c.price, c.currency, i.deleted_at IS NOT NULL, i.stock
 		FROM cart_items c, items i, categories cat
 		WHERE c.cart_id=$1 and i.id = c.item_id and cat.id = i.category`, cartId)
 		if err != nil {
@@ -181,7 +176,11 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 		defer rows.Close()
 		c.logger.Debug("read info from db in pool.Query success")
 		items := make([]models.ItemWithQuantity, 0, 100)
+		lines := make(map[uuid.UUID]models.CartLine)
 		for rows.Next() {
+			var addedPrice *int64
+			var addedCurrency *string
+			line := models.CartLine{}
 			err := rows.Scan(
 				&item.Id,
 				&item.Title,
@@ -191,9 +190,14 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 				&item.Category.Description,
 				&item.Category.Image,
 				&item.Price,
+				&item.Currency,
 				&item.Vendor,
-				&item.Images,
+				itemImages{&item.Images},
 				&item.Quantity,
+				&addedPrice,
+				&addedCurrency,
+				&line.Deleted,
+				&line.Stock,
 			)
 			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 				c.logger.Error(err.Error())
@@ -204,14 +208,21 @@ func (c *cart) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, err
 				return nil, err
 			}
 
+			if addedPrice != nil && addedCurrency != nil {
+				line.AddedPrice = &models.Money{Amount: *addedPrice, Currency: *addedCurrency}
+			}
 			items = append(items, item)
+			lines[item.Id] = line
 		}
 		c.logger.Info("Select items from cart success")
 		c.logger.Info("Get cart success")
 		return &models.Cart{
-			Id:     cartId,
-			UserId: userId,
-			Items:  items,
+			Id:        cartId,
+			UserId:    userId,
+			Items:     items,
+			ExpireAt:  expireAt,
+			PromoCode: promoCode,
+			Lines:     lines,
 		}, nil
 	}
 }
@@ -224,8 +235,10 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 	default:
 		pool := c.storage.GetPool()
 		var cartId uuid.UUID
-		row := pool.QueryRow(ctx, `SELECT id FROM carts WHERE user_id = $1`, userId)
-		err := row.Scan(&cartId)
+		var promoCode string
+		var expireAt time.Time
+		row := pool.QueryRow(ctx, `SELECT id, COALESCE(promo_code, ''), expire_at FROM carts WHERE user_id = $1`, userId)
+		err := row.Scan(&cartId, &promoCode, &expireAt)
 		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 			c.logger.Error(err.Error())
 			return nil, models.ErrorNotFound{}
@@ -237,7 +250,8 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 		c.logger.Debug("read cart id success: %v", userId)
 		item := models.ItemWithQuantity{}
 		rows, err := pool.Query(ctx, `
-		SELECT i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.vendor, i.pictures, c.item_quantity
+		SELECT i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture, i.price, i.currency, i.vendor, `+itemImagesColumn("i")+`, c.item_quantity,
+			c.price, c.currency, i.deleted_at IS NOT NULL, i.stock
 		FROM cart_items c, items i, categories cat
 		WHERE c.cart_id=$1 and i.id = c.item_id and cat.id = i.category`, cartId)
 		if err != nil {
@@ -247,7 +261,11 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 		defer rows.Close()
 		c.logger.Debug("read info from db in pool.Query success")
 		items := make([]models.ItemWithQuantity, 0, 100)
+		lines := make(map[uuid.UUID]models.CartLine)
 		for rows.Next() {
+			var addedPrice *int64
+			var addedCurrency *string
+			line := models.CartLine{}
 			err := rows.Scan(
 				&item.Id,
 				&item.Title,
@@ -257,9 +275,14 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 				&item.Category.Description,
 				&item.Category.Image,
 				&item.Price,
+				&item.Currency,
 				&item.Vendor,
-				&item.Images,
+				itemImages{&item.Images},
 				&item.Quantity,
+				&addedPrice,
+				&addedCurrency,
+				&line.Deleted,
+				&line.Stock,
 			)
 			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 				c.logger.Error(err.Error())
@@ -270,14 +293,314 @@ func (c *cart) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.C
 				return nil, err
 			}
 
+			if addedPrice != nil && addedCurrency != nil {
+				line.AddedPrice = &models.Money{Amount: *addedPrice, Currency: *addedCurrency}
+			}
 			items = append(items, item)
+			lines[item.Id] = line
 		}
 		c.logger.Info("Select items from cart success")
 		c.logger.Info("Get cart success")
 		return &models.Cart{
-			Id:     cartId,
-			UserId: userId,
-			Items:  items,
+			Id:        cartId,
+			UserId:    userId,
+			Items:     items,
+			ExpireAt:  expireAt,
+			PromoCode: promoCode,
+			Lines:     lines,
 		}, nil
 	}
 }
+
+// SetPromoCode applies promo code to the cart, empty code removes it
+func (c *cart) SetPromoCode(ctx context.Context, cartId uuid.UUID, code string) error {
+	c.logger.Debugf("Enter in repository cart SetPromoCode() with args: ctx, cartId: %v, code: %s", cartId, code)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context closed")
+	default:
+		pool := c.storage.GetPool()
+		tag, err := pool.Exec(ctx, `UPDATE carts SET promo_code = $1 WHERE id = $2`, nullString(code), cartId)
+		if err != nil {
+			c.logger.Errorf("can't set promo code of cart: %s", err)
+			return fmt.Errorf("can't set promo code of cart: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return models.ErrorNotFound{}
+		}
+		c.logger.Info("Set promo code of cart success")
+		return nil
+	}
+}
+
+// MergeCarts moves items of the cart fromCartId into the cart toCartId summing
+// quantities, merged quantities are limited by the stock and by the maximal
+// quantity of the items. Lines limited below the minimal quantity are removed.
+// Promo code of the source cart is kept if the target cart has none,
+// the source cart is deleted
+func (c *cart) MergeCarts(ctx context.Context, fromCartId uuid.UUID, toCartId uuid.UUID) error {
+	c.logger.Debugf("Enter in repository cart MergeCarts() with args: ctx, fromCartId: %v, toCartId: %v", fromCartId, toCartId)
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO cart_items (cart_id, item_id, item_quantity, price, currency)
+	SELECT $2, item_id, item_quantity, price, currency FROM cart_items WHERE cart_id = $1
+	ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = cart_items.item_quantity + EXCLUDED.item_quantity`,
+		fromCartId, toCartId)
+	if err != nil {
+		c.logger.Errorf("can't merge items of carts: %s", err)
+		return fmt.Errorf("can't merge items of carts: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE cart_items c SET item_quantity = LEAST(COALESCE(i.stock, c.item_quantity), NULLIF(i.max_quantity, 0))
+	FROM items i
+	WHERE c.cart_id = $2 AND i.id = c.item_id
+	AND (c.item_quantity > i.stock OR (i.max_quantity != 0 AND c.item_quantity > i.max_quantity))
+	AND c.item_id IN (SELECT item_id FROM cart_items WHERE cart_id = $1)`,
+		fromCartId, toCartId)
+	if err != nil {
+		c.logger.Errorf("can't limit quantities of merged items: %s", err)
+		return fmt.Errorf("can't limit quantities of merged items: %w", err)
+	}
+	// Like on decrement, lines limited below the minimal quantity are removed
+	_, err = tx.Exec(ctx, `
+	DELETE FROM cart_items c USING items i
+	WHERE c.cart_id = $2 AND i.id = c.item_id AND c.item_quantity < i.min_quantity
+	AND c.item_id IN (SELECT item_id FROM cart_items WHERE cart_id = $1)`,
+		fromCartId, toCartId)
+	if err != nil {
+		c.logger.Errorf("can't delete items out of stock: %s", err)
+		return fmt.Errorf("can't delete items out of stock: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE carts SET promo_code = (SELECT promo_code FROM carts WHERE id = $1)
+	WHERE id = $2 AND promo_code IS NULL`, fromCartId, toCartId)
+	if err != nil {
+		c.logger.Errorf("can't merge promo code of carts: %s", err)
+		return fmt.Errorf("can't merge promo code of carts: %w", err)
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM cart_items WHERE cart_id = $1`, fromCartId)
+	if err != nil {
+		c.logger.Errorf("can't delete items of merged cart: %s", err)
+		return fmt.Errorf("can't delete items of merged cart: %w", err)
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM carts WHERE id = $1`, fromCartId)
+	if err != nil {
+		c.logger.Errorf("can't delete merged cart: %s", err)
+		return fmt.Errorf("can't delete merged cart: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		c.logger.Errorf("can't commit merge of carts: %s", err)
+		return fmt.Errorf("can't commit merge of carts: %w", err)
+	}
+	c.logger.Infof("Cart %v merged into cart %v", fromCartId, toCartId)
+	return nil
+}
+
+// SetItemQuantity sets quantity of the cart line by atomic upsert,
+// zero quantity removes the line
+func (c *cart) SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error {
+	c.logger.Debugf("Enter in repository cart SetItemQuantity() with args: ctx, cartId: %v, itemId: %v, quantity: %d", cartId, itemId, quantity)
+	pool := c.storage.GetPool()
+	if quantity == 0 {
+		_, err := pool.Exec(ctx, `DELETE FROM cart_items WHERE cart_id = $1 AND item_id = $2`, cartId, itemId)
+		if err != nil {
+			c.logger.Errorf("can't delete item from cart: %s", err)
+			return fmt.Errorf("can't delete item from cart: %w", err)
+		}
+		return nil
+	}
+	_, err := pool.Exec(ctx, `
+	INSERT INTO cart_items (cart_id, item_id, item_quantity, price, currency)
+	VALUES ($1, $2, $3, (SELECT price FROM items WHERE id = $2), (SELECT currency FROM items WHERE id = $2))
+	ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = EXCLUDED.item_quantity,
+		price = EXCLUDED.price, currency = EXCLUDED.currency`,
+		cartId, itemId, quantity)
+	if err != nil {
+		c.logger.Errorf("can't set quantity of item in cart: %s", err)
+		return fmt.Errorf("can't set quantity of item in cart: %w", err)
+	}
+	return nil
+}
+
+// DecrementItem decreases quantity of the cart line and returns the new quantity.
+// The line is removed if the quantity becomes less than minQuantity, then zero is returned
+func (c *cart) DecrementItem(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, decrement int, minQuantity int) (int, error) {
+	c.logger.Debugf("Enter in repository cart DecrementItem() with args: ctx, cartId: %v, itemId: %v, decrement: %d, minQuantity: %d", cartId, itemId, decrement, minQuantity)
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return 0, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	// Update locks the line until the end of the transaction
+	var quantity int
+	row := tx.QueryRow(ctx, `
+	UPDATE cart_items SET item_quantity = item_quantity - $3
+	WHERE cart_id = $1 AND item_id = $2 RETURNING item_quantity`,
+		cartId, itemId, decrement)
+	err = row.Scan(&quantity)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return 0, models.ErrorNotFound{}
+	}
+	if err != nil {
+		c.logger.Errorf("can't decrement item in cart: %s", err)
+		return 0, fmt.Errorf("can't decrement item in cart: %w", err)
+	}
+	if quantity < minQuantity {
+		quantity = 0
+		_, err = tx.Exec(ctx, `DELETE FROM cart_items WHERE cart_id = $1 AND item_id = $2`, cartId, itemId)
+		if err != nil {
+			c.logger.Errorf("can't delete item from cart: %s", err)
+			return 0, fmt.Errorf("can't delete item from cart: %w", err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		c.logger.Errorf("can't commit decrement of item: %s", err)
+		return 0, fmt.Errorf("can't commit decrement of item: %w", err)
+	}
+	return quantity, nil
+}
+
+// GetQuantityLimits returns limits of the quantity of the item in a cart line
+func (c *cart) GetQuantityLimits(ctx context.Context, itemId uuid.UUID) (models.QuantityLimits, error) {
+	c.logger.Debugf("Enter in repository cart GetQuantityLimits() with args: ctx, itemId: %v", itemId)
+	pool := c.storage.GetPool()
+	var limits models.QuantityLimits
+	row := pool.QueryRow(ctx, `SELECT min_quantity, max_quantity FROM items WHERE id = $1`, itemId)
+	err := row.Scan(&limits.Min, &limits.Max)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return limits, models.ErrorNotFound{}
+	}
+	if err != nil {
+		c.logger.Errorf("can't get quantity limits of item: %s", err)
+		return limits, fmt.Errorf("can't get quantity limits of item: %w", err)
+	}
+	return limits, nil
+}
+
+// GetItemsAvailability returns states of the items in the catalogue by item
+// id, items which don't exist are absent in the result
+func (c *cart) GetItemsAvailability(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]models.ItemAvailability, error) {
+	c.logger.Debugf("Enter in repository cart GetItemsAvailability() with args: ctx, itemIds: %v", itemIds)
+	pool := c.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT id, deleted_at IS NOT NULL, stock, min_quantity, max_quantity FROM items WHERE id = ANY($1)`, itemIds)
+	if err != nil {
+		c.logger.Errorf("can't select availability of items: %s", err)
+		return nil, fmt.Errorf("can't select availability of items: %w", err)
+	}
+	defer rows.Close()
+	availability := make(map[uuid.UUID]models.ItemAvailability, len(itemIds))
+	for rows.Next() {
+		var id uuid.UUID
+		var item models.ItemAvailability
+		err := rows.Scan(&id, &item.Deleted, &item.Stock, &item.Limits.Min, &item.Limits.Max)
+		if err != nil {
+			c.logger.Error(err.Error())
+			return nil, err
+		}
+		availability[id] = item
+	}
+	return availability, rows.Err()
+}
+
+// AddItemsToCart adds given quantities of the items to the cart in one
+// transaction, quantities of the items already in the cart are increased
+func (c *cart) AddItemsToCart(ctx context.Context, cartId uuid.UUID, quantities map[uuid.UUID]int) error {
+	c.logger.Debugf("Enter in repository cart AddItemsToCart() with args: ctx, cartId: %v, quantities: %v", cartId, quantities)
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	for itemId, quantity := range quantities {
+		_, err = tx.Exec(ctx, `
+		INSERT INTO cart_items (cart_id, item_id, item_quantity, price, currency)
+		VALUES ($1, $2, $3, (SELECT price FROM items WHERE id = $2), (SELECT currency FROM items WHERE id = $2))
+		ON CONFLICT (cart_id, item_id) DO UPDATE SET item_quantity = cart_items.item_quantity + EXCLUDED.item_quantity,
+			price = COALESCE(cart_items.price, EXCLUDED.price),
+			currency = CASE WHEN cart_items.price IS NULL THEN EXCLUDED.currency ELSE cart_items.currency END`,
+			cartId, itemId, quantity)
+		if err != nil {
+			c.logger.Errorf("can't add item to cart: %s", err)
+			return fmt.Errorf("can't add item to cart: %w", err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		c.logger.Errorf("can't commit adding items to cart: %s", err)
+		return fmt.Errorf("can't commit adding items to cart: %w", err)
+	}
+	return nil
+}
+
+// RefreshCartExpiry prolongs the cart after activity, guest carts
+// and carts of users have different lifetimes. The time of activity
+// starts new idle period of the cart. Expired guest cart isn't prolonged,
+// ErrorNotFound is returned for it
+func (c *cart) RefreshCartExpiry(ctx context.Context, cartId uuid.UUID, guestExpireAt time.Time, userExpireAt time.Time) error {
+	c.logger.Debugf("Enter in repository cart RefreshCartExpiry() with args: ctx, cartId: %v, guestExpireAt: %v, userExpireAt: %v", cartId, guestExpireAt, userExpireAt)
+	pool := c.storage.GetPool()
+	tag, err := pool.Exec(ctx, `
+	UPDATE carts SET expire_at = CASE WHEN user_id IS NULL THEN $2::timestamptz ELSE $3::timestamptz END,
+	active_at = now()
+	WHERE id = $1 AND (user_id IS NOT NULL OR expire_at >= now())`, cartId, guestExpireAt, userExpireAt)
+	if err != nil {
+		c.logger.Errorf("can't refresh expiry of cart: %s", err)
+		return fmt.Errorf("can't refresh expiry of cart: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// DeleteExpiredCarts deletes guest carts expired before given time with their
+// items. Carts of users are kept for next logins. Returns the number of
+// deleted carts
+func (c *cart) DeleteExpiredCarts(ctx context.Context, before time.Time) (int, error) {
+	c.logger.Debugf("Enter in repository cart DeleteExpiredCarts() with args: ctx, before: %v", before)
+	pool := c.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		c.logger.Errorf("can't create transaction: %s", err)
+		return 0, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	_, err = tx.Exec(ctx, `
+	DELETE FROM cart_items WHERE cart_id IN (SELECT id FROM carts WHERE user_id IS NULL AND expire_at < $1)`, before)
+	if err != nil {
+		c.logger.Errorf("can't delete items of expired guest carts: %s", err)
+		return 0, fmt.Errorf("can't delete items of expired guest carts: %w", err)
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM carts WHERE user_id IS NULL AND expire_at < $1`, before)
+	if err != nil {
+		c.logger.Errorf("can't delete expired guest carts: %s", err)
+		return 0, fmt.Errorf("can't delete expired guest carts: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		c.logger.Errorf("can't commit deletion of expired carts: %s", err)
+		return 0, fmt.Errorf("can't commit deletion of expired carts: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -7,7 +7,24 @@ import (
 	"github.com/google/uuid"
 )
 
+type ITagsCash interface {
+	GetGenerations(ctx context.Context, tags ...string) ([]int64, error)
+	InvalidateTags(ctx context.Context, tags ...string) error
+}
+
+// ICashStorage is a key-value storage with expiration of
+// values, it is a backend of caches of items and categories
+type ICashStorage interface {
+	ITagsCash
+	Exists(ctx context.Context, key string) (bool, error)
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
+	ShutDown(timeout int) error
+}
+
 type IItemsCash interface {
+	ITagsCash
 	CheckCash(ctx context.Context, key string) bool
 	CreateItemsCash(ctx context.Context, res []models.Item, key string) error
 	CreateItemsQuantityCash(ctx context.Context, value int, key string) error
@@ -18,6 +35,7 @@ type IItemsCash interface {
 }
 
 type ICategoriesCash interface {
+	ITagsCash
 	CheckCash(ctx context.Context, key string) bool
 	CreateCategoriesListCash(ctx context.Context, categories []models.Category, key string) error
 	GetCategoriesListCash(ctx context.Context, key string) ([]models.Category, error)
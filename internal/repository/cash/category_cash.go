@@ -4,16 +4,16 @@ import (
 	"OnlineShopBackend/internal/models"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
-	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
 )
 
 var _ ICategoriesCash = &CategoriesCash{}
 
 type CategoriesCash struct {
-	*RedisCash
+	ICashStorage
 	logger *zap.Logger
 }
 
@@ -21,23 +21,22 @@ type categoriesData struct {
 	Categories []models.Category `json:"categories"`
 }
 
-func NewCategoriesCash(cash *RedisCash, logger *zap.Logger) ICategoriesCash {
+func NewCategoriesCash(storage ICashStorage, logger *zap.Logger) ICategoriesCash {
 	logger.Debug("Enter in cash NewCategoriesCash()")
-	return &CategoriesCash{cash, logger}
+	return &CategoriesCash{storage, logger}
 }
 
 // CheckCash checks for data in the cache
 func (cash *CategoriesCash) CheckCash(ctx context.Context, key string) bool {
 	cash.logger.Sugar().Debugf("Enter in cash CheckCash() with args: ctx, key: %s", key)
-	check := cash.Exists(ctx, key)
-	result, err := check.Result()
+	result, err := cash.Exists(ctx, key)
 	if err != nil {
 		cash.logger.Error(fmt.Errorf("error on check cash: %w", err).Error())
 		return false
 	}
 	cash.logger.Debug(fmt.Sprintf("Check cash with key: %s is %v", key, result))
-	if result == 0 {
-		cash.logger.Debug(fmt.Sprintf("Redis: key %s not exist", key))
+	if !result {
+		cash.logger.Debug(fmt.Sprintf("Cash: key %s not exist", key))
 		return false
 	} else {
 		cash.logger.Debug(fmt.Sprintf("Key %s in cash found success", key))
@@ -57,12 +56,12 @@ func (cash *CategoriesCash) CreateCategoriesListCash(ctx context.Context, catego
 		return fmt.Errorf("marshal unknown category: %w", err)
 	}
 
-	err = cash.Set(ctx, key, bytesData, cash.TTL).Err()
+	err = cash.Set(ctx, key, bytesData)
 	if err != nil {
 		cash.logger.Sugar().Warnf("Error on set cash with key: %s, error: %v", key, err)
 		return fmt.Errorf("error on set cash with key: %v, error: %w", key, err)
 	}
-	cash.logger.Debug(fmt.Sprintf("Cash with key %s write in redis success", key))
+	cash.logger.Debug(fmt.Sprintf("Cash with key %s write success", key))
 	return nil
 }
 
@@ -70,8 +69,8 @@ func (cash *CategoriesCash) CreateCategoriesListCash(ctx context.Context, catego
 func (cash *CategoriesCash) GetCategoriesListCash(ctx context.Context, key string) ([]models.Category, error) {
 	cash.logger.Sugar().Debugf("Enter in cash GetCategoriesListCash() with args: ctx, key: %s", key)
 	categories := categoriesData{}
-	bytesData, err := cash.Get(ctx, key).Bytes()
-	if err == redis.Nil {
+	bytesData, err := cash.Get(ctx, key)
+	if errors.Is(err, ErrCashMiss) {
 		// we got empty result, it's not an error
 		cash.logger.Debug("Success get nil result")
 		return nil, nil
@@ -91,7 +90,7 @@ func (cash *CategoriesCash) GetCategoriesListCash(ctx context.Context, key strin
 // DeleteCash deleted cash by key
 func (cash *CategoriesCash) DeleteCash(ctx context.Context, key string) error {
 	cash.logger.Debug(fmt.Sprintf("Enter in cash DeleteCash with args: ctx, key: %s", key))
-	err := cash.Del(ctx, key).Err()
+	err := cash.Delete(ctx, key)
 	if err != nil {
 		cash.logger.Sugar().Warnf("Error on delete cash with key: %s", key)
 		return err
@@ -0,0 +1,11 @@
+package cash
+
+import "errors"
+
+var (
+	// ErrCashMiss is returned when there is no value with given key in the cash
+	ErrCashMiss = errors.New("cash: key not found")
+	// ErrCashUnavailable is returned without a request to the cash server
+	// while it is considered down after a connection error
+	ErrCashUnavailable = errors.New("cash: storage is unavailable")
+)
@@ -0,0 +1,203 @@
+package cash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// eventsChannel is a redis channel of invalidation events
+const eventsChannel = keyPrefix + ":events"
+
+// resubscribeDelay is a delay before the next try to subscribe to events
+const resubscribeDelay = time.Second
+
+type EventType string
+
+// Types of invalidation events
+const (
+	ItemEvent      EventType = "item"
+	CategoryEvent  EventType = "category"
+	FavouriteEvent EventType = "favourite"
+)
+
+// Event is a message about invalidation of tags by one of instances of the service
+type Event struct {
+	Type   EventType `json:"type"`
+	Tags   []string  `json:"tags"`
+	Source string    `json:"source"`
+}
+
+// NewEvent returns event about invalidation of tags, type of event is
+// defined by tags: the list of categories changes with categories only,
+// and tags of favourites are invalidated without other tags
+func NewEvent(tags ...string) Event {
+	event := Event{Type: FavouriteEvent, Tags: tags}
+	for _, tag := range tags {
+		if tag == TagCategories {
+			event.Type = CategoryEvent
+			break
+		}
+		if !strings.HasPrefix(tag, favouritesTagPrefix) {
+			event.Type = ItemEvent
+		}
+	}
+	return event
+}
+
+// ILocalCash is a cash which keeps values in memory of the instance
+type ILocalCash interface {
+	InvalidateLocal(tags ...string)
+}
+
+type IEventBus interface {
+	Publish(ctx context.Context, event Event) error
+	Subscribe(ctx context.Context, handler func(Event)) error
+	Close(timeout int) error
+}
+
+var _ IEventBus = &RedisEventBus{}
+
+// RedisEventBus delivers invalidation events between instances of the service via redis pub/sub
+type RedisEventBus struct {
+	redis  *RedisCash
+	source string
+	logger *zap.Logger
+}
+
+// NewRedisEventBus initialize event bus, every bus has unique id to skip
+// events published by the instance itself. Bus closes redis on Close
+func NewRedisEventBus(redis *RedisCash, logger *zap.Logger) *RedisEventBus {
+	logger.Debug("Enter in NewRedisEventBus()")
+	return &RedisEventBus{redis: redis, source: uuid.NewString(), logger: logger}
+}
+
+// Publish sends event to all instances of the service
+func (bus *RedisEventBus) Publish(ctx context.Context, event Event) error {
+	bus.logger.Sugar().Debugf("Enter in cash Publish() with args: ctx, event: %v", event)
+	event.Source = bus.source
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error on marshal event: %w", err)
+	}
+	err = bus.redis.client.Publish(ctx, eventsChannel, data).Err()
+	if err != nil {
+		return fmt.Errorf("redis: error on publish event: %w", err)
+	}
+	return nil
+}
+
+// Close closes redis connection of the bus
+func (bus *RedisEventBus) Close(timeout int) error {
+	return bus.redis.ShutDown(timeout)
+}
+
+// Subscribe calls handler for events of other instances until ctx is done,
+// subscription is restored if redis is unavailable
+func (bus *RedisEventBus) Subscribe(ctx context.Context, handler func(Event)) error {
+	bus.logger.Debug("Enter in cash Subscribe()")
+	for {
+		err := bus.subscribe(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		bus.logger.Sugar().Warnf("error on subscribe to events: %v, retry in %v", err, resubscribeDelay)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (bus *RedisEventBus) subscribe(ctx context.Context, handler func(Event)) error {
+	pubsub := bus.redis.client.Subscribe(ctx, eventsChannel)
+	defer pubsub.Close()
+	// Wait for confirmation of subscription, after that
+	// the channel of messages reconnects by itself
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		return err
+	}
+	bus.logger.Info("Subscribe to cash events success")
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return fmt.Errorf("channel of events is closed")
+			}
+			event := Event{}
+			err := json.Unmarshal([]byte(message.Payload), &event)
+			if err != nil {
+				bus.logger.Sugar().Warnf("Can't json unmarshal event: %s", message.Payload)
+				continue
+			}
+			if event.Source == bus.source {
+				continue
+			}
+			bus.logger.Sugar().Debugf("Got cash event: %v", event)
+			handler(event)
+		}
+	}
+}
+
+var _ ICashStorage = &EventsCash{}
+
+// EventsCash publishes event after invalidation of tags,
+// so other instances drop their local values of these tags
+type EventsCash struct {
+	ICashStorage
+	bus    IEventBus
+	logger *zap.Logger
+}
+
+// NewEventsCash initialize cash which publishes invalidation events to bus
+func NewEventsCash(storage ICashStorage, bus IEventBus, logger *zap.Logger) *EventsCash {
+	logger.Debug("Enter in NewEventsCash()")
+	return &EventsCash{ICashStorage: storage, bus: bus, logger: logger}
+}
+
+// InvalidateTags invalidates tags in the cash and publishes event about it
+func (cash *EventsCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	err := cash.ICashStorage.InvalidateTags(ctx, tags...)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	err = cash.bus.Publish(ctx, NewEvent(tags...))
+	if err != nil {
+		return fmt.Errorf("error on publish invalidation of tags %v: %w", tags, err)
+	}
+	return nil
+}
+
+// ShutDown shuts down the cash and its event bus
+func (cash *EventsCash) ShutDown(timeout int) error {
+	err := cash.bus.Close(timeout)
+	if err != nil {
+		cash.logger.Sugar().Warnf("error on close event bus: %v", err)
+	}
+	return cash.ICashStorage.ShutDown(timeout)
+}
+
+// Listen applies events of other instances to the local values of the cash until ctx is done
+func (cash *EventsCash) Listen(ctx context.Context) error {
+	cash.logger.Debug("Enter in cash Listen()")
+	local, ok := cash.ICashStorage.(ILocalCash)
+	if !ok {
+		// Values are kept in the shared cash only,
+		// there is nothing to drop on events
+		return nil
+	}
+	return cash.bus.Subscribe(ctx, func(event Event) {
+		local.InvalidateLocal(event.Tags...)
+		cash.logger.Sugar().Infof("Local cash of %s event with tags %v invalidated success", event.Type, event.Tags)
+	})
+}
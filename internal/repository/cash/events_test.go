@@ -0,0 +1,162 @@
+package cash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewEvent(t *testing.T) {
+	userId := uuid.New()
+	require.Equal(t, ItemEvent, NewEvent(TagItems, CategoryTag("name")).Type)
+	require.Equal(t, CategoryEvent, NewEvent(TagCategories).Type)
+	require.Equal(t, CategoryEvent, NewEvent(TagItems, TagCategories, CategoryTag("name")).Type)
+	require.Equal(t, FavouriteEvent, NewEvent(FavouritesTag(userId)).Type)
+	require.Equal(t, []string{FavouritesTag(userId)}, NewEvent(FavouritesTag(userId)).Tags)
+}
+
+func TestKeyDependsOn(t *testing.T) {
+	userId := uuid.New()
+	generations := []int64{1}
+	itemsList := Key(ItemsListNamespace, generations, "name", "asc")
+	inCategory := Key(ItemsCategoryNamespace, generations, "name", "name", "asc")
+	categories := Key(CategoriesListNamespace, generations)
+	favourites := Key(ItemsFavouriteNamespace, []int64{1, 1}, userId.String(), "name", "asc")
+	favouriteIds := Key(FavouriteIdsNamespace, generations, userId.String())
+
+	require.True(t, keyDependsOn(itemsList, TagItems))
+	require.True(t, keyDependsOn(favourites, TagItems))
+	require.False(t, keyDependsOn(inCategory, TagItems))
+	require.False(t, keyDependsOn(categories, TagItems))
+
+	require.True(t, keyDependsOn(categories, TagCategories))
+	require.False(t, keyDependsOn(itemsList, TagCategories))
+
+	require.True(t, keyDependsOn(inCategory, CategoryTag("name")))
+	require.False(t, keyDependsOn(inCategory, CategoryTag("other")))
+	require.False(t, keyDependsOn(itemsList, CategoryTag("name")))
+
+	require.True(t, keyDependsOn(favourites, FavouritesTag(userId)))
+	require.True(t, keyDependsOn(favouriteIds, FavouritesTag(userId)))
+	require.False(t, keyDependsOn(favouriteIds, FavouritesTag(uuid.New())))
+}
+
+// listen starts listening to events of cash and waits for subscription
+func listen(t *testing.T, server *miniredis.Miniredis, cash *EventsCash) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	subscribers := server.PubSubNumSub(eventsChannel)[eventsChannel]
+	go func() {
+		defer close(done)
+		require.NoError(t, cash.Listen(ctx))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	require.Eventually(t, func() bool {
+		return server.PubSubNumSub(eventsChannel)[eventsChannel] > subscribers
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestEventsCashMemory(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	newInstance := func() (*MemoryCash, *EventsCash) {
+		local := NewMemoryCash(time.Hour, 0, zap.L())
+		bus := NewRedisEventBus(NewRedisCash(server.Host(), server.Port(), 1, zap.L()), zap.L())
+		cash := NewEventsCash(local, bus, zap.L())
+		t.Cleanup(func() { _ = cash.ShutDown(1) })
+		return local, cash
+	}
+	localA, cashA := newInstance()
+	localB, cashB := newInstance()
+	listen(t, server, cashA)
+	listen(t, server, cashB)
+
+	key := Key(ItemsListNamespace, []int64{0}, "name", "asc")
+	require.NoError(t, cashB.Set(ctx, key, []byte("items")))
+
+	// Instance A changes items, instance B drops its list of items
+	require.NoError(t, cashA.InvalidateTags(ctx, TagItems))
+	require.Eventually(t, func() bool {
+		generations, err := localB.GetGenerations(ctx, TagItems)
+		return err == nil && generations[0] == 1
+	}, time.Second, 10*time.Millisecond)
+	ok, err := cashB.Exists(ctx, key)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Own events are not applied twice
+	generations, err := localA.GetGenerations(ctx, TagItems)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, generations)
+}
+
+func TestEventsCashTiered(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	newInstance := func() (*MemoryCash, *EventsCash) {
+		local := NewMemoryCash(time.Hour, 0, zap.L())
+		shared := NewRedisCash(server.Host(), server.Port(), 1, zap.L())
+		bus := NewRedisEventBus(NewRedisCash(server.Host(), server.Port(), 1, zap.L()), zap.L())
+		cash := NewEventsCash(NewTieredCash(local, shared, zap.L()), bus, zap.L())
+		t.Cleanup(func() { _ = cash.ShutDown(1) })
+		return local, cash
+	}
+	_, cashA := newInstance()
+	localB, cashB := newInstance()
+	listen(t, server, cashB)
+
+	userId := uuid.New()
+	key := Key(FavouriteIdsNamespace, []int64{0}, userId.String())
+	require.NoError(t, cashB.Set(ctx, key, []byte("ids")))
+
+	require.NoError(t, cashA.InvalidateTags(ctx, FavouritesTag(userId)))
+	require.Eventually(t, func() bool {
+		ok, err := localB.Exists(ctx, key)
+		return err == nil && !ok
+	}, time.Second, 10*time.Millisecond)
+
+	// Generations are shared, instance B reads new generation from redis
+	generations, err := cashB.GetGenerations(ctx, FavouritesTag(userId))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, generations)
+}
+
+func TestEventBusResubscribe(t *testing.T) {
+	server := miniredis.RunT(t)
+	addr, host, port := server.Addr(), server.Host(), server.Port()
+	server.Close()
+
+	local := NewMemoryCash(time.Hour, 0, zap.L())
+	bus := NewRedisEventBus(NewRedisCash(host, port, 1, zap.L()), zap.L())
+	cash := NewEventsCash(local, bus, zap.L())
+	t.Cleanup(func() { _ = cash.ShutDown(1) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, cash.Listen(ctx))
+	}()
+
+	// Redis starts after the instance, subscription is restored
+	require.NoError(t, server.StartAddr(addr))
+	require.Eventually(t, func() bool {
+		return server.PubSubNumSub(eventsChannel)[eventsChannel] == 1
+	}, 3*time.Second, 50*time.Millisecond)
+	server.Publish(eventsChannel, `{"type":"category","tags":["categories"],"source":"other"}`)
+	require.Eventually(t, func() bool {
+		generations, err := local.GetGenerations(context.Background(), TagCategories)
+		return err == nil && generations[0] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -4,9 +4,10 @@ import (
 	"OnlineShopBackend/internal/models"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -14,7 +15,7 @@ import (
 var _ IItemsCash = &ItemsCash{}
 
 type ItemsCash struct {
-	*RedisCash
+	ICashStorage
 	logger *zap.Logger
 }
 
@@ -22,23 +23,22 @@ type results struct {
 	Responses []models.Item
 }
 
-func NewItemsCash(cash *RedisCash, logger *zap.Logger) IItemsCash {
+func NewItemsCash(storage ICashStorage, logger *zap.Logger) IItemsCash {
 	logger.Debug("Enter in cash NewItemsCash")
-	return &ItemsCash{cash, logger}
+	return &ItemsCash{storage, logger}
 }
 
 // CheckCash checks for data in the cache
 func (cash *ItemsCash) CheckCash(ctx context.Context, key string) bool {
 	cash.logger.Sugar().Debugf("Enter in cash CheckCash() with args: ctx, key: %s", key)
-	check := cash.Exists(ctx, key)
-	result, err := check.Result()
+	result, err := cash.Exists(ctx, key)
 	if err != nil {
 		cash.logger.Error(fmt.Errorf("error on check cash: %w", err).Error())
 		return false
 	}
 	cash.logger.Debug(fmt.Sprintf("Check Cash with key: %s is %v", key, result))
-	if result == 0 {
-		cash.logger.Debug(fmt.Sprintf("Redis: get record %s not exist", key))
+	if !result {
+		cash.logger.Debug(fmt.Sprintf("Cash: get record %s not exist", key))
 		return false
 	} else {
 		cash.logger.Debug(fmt.Sprintf("Key %s in cash found success", key))
@@ -57,9 +57,9 @@ func (cash *ItemsCash) CreateItemsCash(ctx context.Context, res []models.Item, k
 		return fmt.Errorf("error on marshal items cash: %w", err)
 	}
 
-	err = cash.Set(ctx, key, data, cash.TTL).Err()
+	err = cash.Set(ctx, key, data)
 	if err != nil {
-		return fmt.Errorf("redis: error on set key %s: %w", key, err)
+		return fmt.Errorf("error on set key %s: %w", key, err)
 	}
 	cash.logger.Info(fmt.Sprintf("Cash with key: %s create success", key))
 	return nil
@@ -72,9 +72,9 @@ func (cash *ItemsCash) CreateFavouriteItemsIdCash(ctx context.Context, res map[u
 	if err != nil {
 		return fmt.Errorf("error on marshal favourite items id cash: %w", err)
 	}
-	err = cash.Set(ctx, key, data, cash.TTL).Err()
+	err = cash.Set(ctx, key, data)
 	if err != nil {
-		return fmt.Errorf("redis: error on set key: %s: %w", key, err)
+		return fmt.Errorf("error on set key: %s: %w", key, err)
 	}
 	return nil
 }
@@ -82,9 +82,9 @@ func (cash *ItemsCash) CreateFavouriteItemsIdCash(ctx context.Context, res map[u
 // CreateItemsQuantityCash create cash for items quantity
 func (cash *ItemsCash) CreateItemsQuantityCash(ctx context.Context, value int, key string) error {
 	cash.logger.Sugar().Debugf("Enter in cash CreateItemsQuantityCash() with args: ctx, value: %d, key: %s", value, key)
-	err := cash.Set(ctx, key, value, cash.TTL).Err()
+	err := cash.Set(ctx, key, []byte(strconv.Itoa(value)))
 	if err != nil {
-		return fmt.Errorf("redis: error on set key %q: %w", key, err)
+		return fmt.Errorf("error on set key %q: %w", key, err)
 	}
 	cash.logger.Info(fmt.Sprintf("Cash with key: %s create success", key))
 	return nil
@@ -94,8 +94,8 @@ func (cash *ItemsCash) CreateItemsQuantityCash(ctx context.Context, value int, k
 func (cash *ItemsCash) GetItemsCash(ctx context.Context, key string) ([]models.Item, error) {
 	cash.logger.Sugar().Debugf("Enter in cash GetItemsCash() with args: ctx, key: %s", key)
 	res := results{}
-	data, err := cash.Get(ctx, key).Bytes()
-	if err == redis.Nil {
+	data, err := cash.Get(ctx, key)
+	if errors.Is(err, ErrCashMiss) {
 		// we got empty result, it's not an error
 		cash.logger.Debug("Success get nil result")
 		return nil, nil
@@ -115,21 +115,26 @@ func (cash *ItemsCash) GetItemsCash(ctx context.Context, key string) ([]models.I
 // GetItemsQuantityCash retrieves data from the cache
 func (cash *ItemsCash) GetItemsQuantityCash(ctx context.Context, key string) (int, error) {
 	cash.logger.Sugar().Debugf("Enter in cash GetItemsQuantityCash() with args: ctx, key: %s", key)
-	data, err := cash.Get(ctx, key).Int()
+	data, err := cash.Get(ctx, key)
 	if err != nil {
 		cash.logger.Sugar().Errorf("Error on get cash: %v", err)
-		return data, err
+		return 0, err
+	}
+	value, err := strconv.Atoi(string(data))
+	if err != nil {
+		cash.logger.Sugar().Warnf("Can't parse quantity: %s", data)
+		return 0, err
 	}
 	cash.logger.Debug("Get cash success")
-	return data, nil
+	return value, nil
 }
 
 // GetItemsQuantityCash retrieves data from the cache
 func (cash *ItemsCash) GetFavouriteItemsIdCash(ctx context.Context, key string) (*map[uuid.UUID]uuid.UUID, error) {
 	cash.logger.Sugar().Debugf("Enter in cash GetFavouriteItemsIdCash() with args: ctx, key: %s", key)
 	res := make(map[uuid.UUID]uuid.UUID)
-	data, err := cash.Get(ctx, key).Bytes()
-	if err == redis.Nil {
+	data, err := cash.Get(ctx, key)
+	if errors.Is(err, ErrCashMiss) {
 		// we got empty result, it's not an error
 		cash.logger.Debug("Success get nil result")
 		return nil, nil
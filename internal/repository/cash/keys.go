@@ -0,0 +1,93 @@
+package cash
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// keyPrefix is a common prefix of all cache keys of the shop, version
+// is increased when the format of cached values changes
+const keyPrefix = "shop:v4"
+
+// Namespaces of cache keys
+const (
+	ItemsListNamespace      = "items:list"
+	ItemsCategoryNamespace  = "items:category"
+	ItemsSearchNamespace    = "items:search"
+	ItemsFavouriteNamespace = "items:favourite"
+	FavouriteIdsNamespace   = "favourite:ids"
+	CategoriesListNamespace = "categories:list"
+	QuantitySuffix          = "quantity"
+)
+
+// Tags of cached data. Every cache key is built with current generations of
+// tags it depends on, so bumping the generation of a tag makes all keys
+// built with the old generation unreachable and they expire by TTL
+const (
+	// TagItems is a tag of all lists which contain items from any category
+	TagItems = "items"
+	// TagCategories is a tag of the list of categories
+	TagCategories = "categories"
+
+	categoryTagPrefix   = "category:"
+	favouritesTagPrefix = "favourites:"
+)
+
+// CategoryTag returns tag of lists of items in category with given name
+func CategoryTag(name string) string {
+	return categoryTagPrefix + name
+}
+
+// FavouritesTag returns tag of lists of favourite items of user with given id
+func FavouritesTag(userId uuid.UUID) string {
+	return favouritesTagPrefix + userId.String()
+}
+
+// generationKey returns key of generation counter of tag
+func generationKey(tag string) string {
+	return keyPrefix + ":gen:" + tag
+}
+
+// Key builds namespaced cache key from generations of tags and key parts
+func Key(namespace string, generations []int64, parts ...string) string {
+	var builder strings.Builder
+	builder.WriteString(keyPrefix)
+	builder.WriteString(":")
+	builder.WriteString(namespace)
+	for _, generation := range generations {
+		builder.WriteString(fmt.Sprintf(":g%d", generation))
+	}
+	for _, part := range parts {
+		builder.WriteString(":")
+		builder.WriteString(part)
+	}
+	return builder.String()
+}
+
+// keyDependsOn reports whether value with given key may depend on tag,
+// it is used to drop local values of tags invalidated by other instances
+func keyDependsOn(key, tag string) bool {
+	inNamespace := func(namespaces ...string) bool {
+		for _, namespace := range namespaces {
+			if strings.HasPrefix(key, keyPrefix+":"+namespace+":") {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case tag == TagItems:
+		return inNamespace(ItemsListNamespace, ItemsSearchNamespace, ItemsFavouriteNamespace)
+	case tag == TagCategories:
+		return inNamespace(CategoriesListNamespace)
+	case strings.HasPrefix(tag, categoryTagPrefix):
+		name := strings.TrimPrefix(tag, categoryTagPrefix)
+		return inNamespace(ItemsCategoryNamespace) && strings.Contains(key+":", ":"+name+":")
+	case strings.HasPrefix(tag, favouritesTagPrefix):
+		userId := strings.TrimPrefix(tag, favouritesTagPrefix)
+		return inNamespace(ItemsFavouriteNamespace, FavouriteIdsNamespace) && strings.Contains(key, userId)
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package cash
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ ICashStorage = &MemoryCash{}
+
+// MemoryCash is an in-process cash which keeps at most maxEntries values,
+// the least recently used value is evicted when the cash is full
+type MemoryCash struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	maxEntries  int
+	entries     map[string]*list.Element
+	order       *list.List
+	generations map[string]int64
+	now         func() time.Time
+	logger      *zap.Logger
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCash initialize in-process cash with given TTL and max number of entries
+func NewMemoryCash(ttl time.Duration, maxEntries int, logger *zap.Logger) *MemoryCash {
+	logger.Sugar().Debugf("Enter in NewMemoryCash() with args: ttl: %v, maxEntries: %d, logger", ttl, maxEntries)
+	return &MemoryCash{
+		ttl:         ttl,
+		maxEntries:  maxEntries,
+		entries:     make(map[string]*list.Element),
+		order:       list.New(),
+		generations: make(map[string]int64),
+		now:         time.Now,
+		logger:      logger,
+	}
+}
+
+// Exists checks for not expired value with given key
+func (cash *MemoryCash) Exists(ctx context.Context, key string) (bool, error) {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	return cash.get(key) != nil, nil
+}
+
+// Get returns value with given key or ErrCashMiss if it does not exist
+func (cash *MemoryCash) Get(ctx context.Context, key string) ([]byte, error) {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	entry := cash.get(key)
+	if entry == nil {
+		return nil, ErrCashMiss
+	}
+	return entry.value, nil
+}
+
+// Set writes value with given key, evicting the least recently used values if needed
+func (cash *MemoryCash) Set(ctx context.Context, key string, value []byte) error {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	expiresAt := cash.now().Add(cash.ttl)
+	if element, ok := cash.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		cash.order.MoveToFront(element)
+		return nil
+	}
+	cash.entries[key] = cash.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for cash.maxEntries > 0 && cash.order.Len() > cash.maxEntries {
+		cash.remove(cash.order.Back())
+	}
+	return nil
+}
+
+// Delete deletes value with given key
+func (cash *MemoryCash) Delete(ctx context.Context, key string) error {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	if element, ok := cash.entries[key]; ok {
+		cash.remove(element)
+	}
+	return nil
+}
+
+// GetGenerations returns current generations of tags
+func (cash *MemoryCash) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	generations := make([]int64, len(tags))
+	for i, tag := range tags {
+		generations[i] = cash.generations[tag]
+	}
+	return generations, nil
+}
+
+// InvalidateTags increments generations of tags and drops values which depend on them
+func (cash *MemoryCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	cash.InvalidateLocal(tags...)
+	return nil
+}
+
+// InvalidateLocal increments generations of tags and drops values which depend on them
+func (cash *MemoryCash) InvalidateLocal(tags ...string) {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	for _, tag := range tags {
+		cash.generations[tag]++
+	}
+	cash.dropDependent(tags)
+}
+
+// DropLocal drops values which depend on tags, generations of tags are not changed
+func (cash *MemoryCash) DropLocal(tags ...string) {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	cash.dropDependent(tags)
+}
+
+// Len returns number of values in the cash including expired ones
+func (cash *MemoryCash) Len() int {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	return cash.order.Len()
+}
+
+// ShutDown drops all values of the cash
+func (cash *MemoryCash) ShutDown(timeout int) error {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	cash.entries = make(map[string]*list.Element)
+	cash.order.Init()
+	return nil
+}
+
+// get returns not expired entry and marks it as recently used,
+// expired entry is removed. Must be called with locked mutex
+func (cash *MemoryCash) get(key string) *memoryEntry {
+	element, ok := cash.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !cash.now().Before(entry.expiresAt) {
+		cash.remove(element)
+		return nil
+	}
+	cash.order.MoveToFront(element)
+	return entry
+}
+
+// dropDependent removes values which depend on any of tags. Must be called with locked mutex
+func (cash *MemoryCash) dropDependent(tags []string) {
+	for key, element := range cash.entries {
+		for _, tag := range tags {
+			if keyDependsOn(key, tag) {
+				cash.remove(element)
+				break
+			}
+		}
+	}
+}
+
+func (cash *MemoryCash) remove(element *list.Element) {
+	cash.order.Remove(element)
+	delete(cash.entries, element.Value.(*memoryEntry).key)
+}
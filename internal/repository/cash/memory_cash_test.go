@@ -0,0 +1,76 @@
+package cash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemoryCashLRU(t *testing.T) {
+	ctx := context.Background()
+	cash := NewMemoryCash(time.Hour, 2, zap.L())
+
+	require.NoError(t, cash.Set(ctx, "first", []byte("1")))
+	require.NoError(t, cash.Set(ctx, "second", []byte("2")))
+	// Reading makes the first value recently used
+	res, err := cash.Get(ctx, "first")
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), res)
+
+	require.NoError(t, cash.Set(ctx, "third", []byte("3")))
+	require.Equal(t, 2, cash.Len())
+	_, err = cash.Get(ctx, "second")
+	require.ErrorIs(t, err, ErrCashMiss)
+	ok, err := cash.Exists(ctx, "first")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, cash.Set(ctx, "first", []byte("one")))
+	res, err = cash.Get(ctx, "first")
+	require.NoError(t, err)
+	require.Equal(t, []byte("one"), res)
+	require.Equal(t, 2, cash.Len())
+
+	require.NoError(t, cash.Delete(ctx, "first"))
+	ok, err = cash.Exists(ctx, "first")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestMemoryCashTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cash := NewMemoryCash(time.Minute, 0, zap.L())
+	cash.now = func() time.Time { return now }
+
+	require.NoError(t, cash.Set(ctx, "key", []byte("value")))
+	ok, err := cash.Exists(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+	ok, err = cash.Exists(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, err = cash.Get(ctx, "key")
+	require.ErrorIs(t, err, ErrCashMiss)
+	require.Equal(t, 0, cash.Len())
+}
+
+func TestMemoryCashGenerations(t *testing.T) {
+	ctx := context.Background()
+	cash := NewMemoryCash(time.Hour, 0, zap.L())
+
+	res, err := cash.GetGenerations(ctx, TagItems, TagCategories)
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 0}, res)
+
+	require.NoError(t, cash.InvalidateTags(ctx, TagItems))
+	require.NoError(t, cash.InvalidateTags(ctx, TagItems, TagCategories))
+	res, err = cash.GetGenerations(ctx, TagItems, TagCategories)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 1}, res)
+}
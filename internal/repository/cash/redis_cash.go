@@ -2,51 +2,319 @@ package cash
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
 )
 
+var _ ICashStorage = &RedisCash{}
+
+// defaultCooldown is a time during which redis is not requested after a connection error
+const defaultCooldown = 5 * time.Second
+
 type RedisCash struct {
-	*redis.Client
-	TTL    time.Duration
-	logger *zap.Logger
+	client   *redis.Client
+	TTL      time.Duration
+	Cooldown time.Duration
+	logger   *zap.Logger
+
+	mu        sync.RWMutex
+	downUntil time.Time
+	// pending are tags which generations were not incremented, with
+	// numbers of their failed invalidations
+	pending  map[string]uint64
+	failures uint64
+	retrying bool
+	done     chan struct{}
 }
 
-// NewRedisCash initialize redis client
-func NewRedisCash(host, port string, ttl time.Duration, logger *zap.Logger) (*RedisCash, error) {
+// NewRedisCash initialize redis client. Unreachable redis is not an error:
+// requests to the cash fail fast until redis comes back, so the service
+// works with the database only
+func NewRedisCash(host, port string, ttl time.Duration, logger *zap.Logger) *RedisCash {
 	logger.Sugar().Debugf("Enter in NewRedisCash() with args: host: %s, port: %s, ttl: %v, logger", host, port, ttl)
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", host, port),
 		Password: "", // no password set
 		DB:       0,  // use default DB
 	})
+	cashTTL := ttl * time.Hour
+	c := &RedisCash{
+		client:   client,
+		TTL:      cashTTL,
+		Cooldown: defaultCooldown,
+		logger:   logger,
+		done:     make(chan struct{}),
+	}
 	err := client.Ping(context.Background()).Err()
 	if err != nil {
-		return nil, fmt.Errorf("try to ping to redis: %w", err)
+		logger.Sugar().Warnf("try to ping to redis: %v, cash is disabled until redis is available", err)
+		c.markDown()
+		return c
 	}
 	logger.Debug("Redis Client ping success")
-	cashTTL := ttl * time.Hour
-	c := &RedisCash{
-		Client: client,
-		TTL:    cashTTL,
-		logger: logger,
-	}
-	return c, nil
+	return c
+}
+
+// Client returns redis client of the cash
+func (cash *RedisCash) Client() *redis.Client {
+	return cash.client
 }
 
 // ShutDown is func for graceful shutdown redis connection
 func (cash *RedisCash) ShutDown(timeout int) error {
 	cash.logger.Sugar().Debugf("Enter in cash ShutDown() with args: timeout: %d", timeout)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
-	status := cash.Shutdown(ctx)
-	result, err := status.Result()
+	if cash.done != nil {
+		close(cash.done)
+	}
+	err := cash.client.Close()
+	if err != nil {
+		return fmt.Errorf("redis: error on close connection: %w", err)
+	}
+	return nil
+}
+
+// Available reports whether redis is considered reachable
+func (cash *RedisCash) Available() bool {
+	return !cash.isDown()
+}
+
+// Exists checks for key in redis
+func (cash *RedisCash) Exists(ctx context.Context, key string) (bool, error) {
+	cash.logger.Sugar().Debugf("Enter in cash Exists() with args: ctx, key: %s", key)
+	if cash.isDown() {
+		return false, ErrCashUnavailable
+	}
+	result, err := cash.client.Exists(ctx, key).Result()
+	if err != nil {
+		cash.check(err)
+		return false, fmt.Errorf("redis: error on check key %s: %w", key, err)
+	}
+	return result != 0, nil
+}
+
+// Get returns value with given key or ErrCashMiss if it does not exist
+func (cash *RedisCash) Get(ctx context.Context, key string) ([]byte, error) {
+	cash.logger.Sugar().Debugf("Enter in cash Get() with args: ctx, key: %s", key)
+	if cash.isDown() {
+		return nil, ErrCashUnavailable
+	}
+	data, err := cash.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCashMiss
+	}
 	if err != nil {
+		cash.check(err)
+		return nil, fmt.Errorf("redis: error on get key %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// Set writes value with given key for TTL of the cash
+func (cash *RedisCash) Set(ctx context.Context, key string, value []byte) error {
+	cash.logger.Sugar().Debugf("Enter in cash Set() with args: ctx, key: %s, value", key)
+	if cash.isDown() {
+		return ErrCashUnavailable
+	}
+	err := cash.client.Set(ctx, key, value, cash.TTL).Err()
+	if err != nil {
+		cash.check(err)
+		return fmt.Errorf("redis: error on set key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Delete deletes value with given key
+func (cash *RedisCash) Delete(ctx context.Context, key string) error {
+	cash.logger.Sugar().Debugf("Enter in cash Delete() with args: ctx, key: %s", key)
+	if cash.isDown() {
+		return ErrCashUnavailable
+	}
+	err := cash.client.Del(ctx, key).Err()
+	if err != nil {
+		cash.check(err)
+		return fmt.Errorf("redis: error on delete key %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetGenerations returns current generations of tags, generation
+// of tag which was never invalidated is zero. Generations are not
+// returned until failed invalidations are done, so stale values
+// are not read
+func (cash *RedisCash) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	cash.logger.Sugar().Debugf("Enter in cash GetGenerations() with args: ctx, tags: %v", tags)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	if cash.isDown() {
+		return nil, ErrCashUnavailable
+	}
+	if !cash.invalidatePending(ctx) {
+		return nil, ErrCashUnavailable
+	}
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, generationKey(tag))
+	}
+	values, err := cash.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		cash.check(err)
+		return nil, fmt.Errorf("redis: error on get generations of tags %v: %w", tags, err)
+	}
+	generations := make([]int64, len(tags))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("redis: unexpected generation value %v of tag %s", value, tags[i])
+		}
+		generations[i], err = strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: error on parse generation of tag %s: %w", tags[i], err)
+		}
+	}
+	return generations, nil
+}
+
+// InvalidateTags increments generations of tags, so all cache
+// keys built with previous generations become unreachable. Tags
+// which are not invalidated are invalidated again in background
+// until redis is available
+func (cash *RedisCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	cash.logger.Sugar().Debugf("Enter in cash InvalidateTags() with args: ctx, tags: %v", tags)
+	if len(tags) == 0 {
+		return nil
+	}
+	err := cash.incr(ctx, tags...)
+	if err != nil {
+		cash.retry(tags...)
 		return err
 	}
-	cash.logger.Info(result)
+	cash.markUp()
+	cash.logger.Sugar().Infof("Tags %v invalidated success", tags)
 	return nil
 }
+
+// incr increments generations of tags. Generations are incremented even
+// if redis is considered down, because lost invalidation leaves stale
+// data in the cash
+func (cash *RedisCash) incr(ctx context.Context, tags ...string) error {
+	pipe := cash.client.TxPipeline()
+	for _, tag := range tags {
+		pipe.Incr(ctx, generationKey(tag))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		cash.check(err)
+		return fmt.Errorf("redis: error on invalidate tags %v: %w", tags, err)
+	}
+	return nil
+}
+
+// retry keeps tags which are not invalidated and starts
+// to invalidate them in background
+func (cash *RedisCash) retry(tags ...string) {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	if cash.pending == nil {
+		cash.pending = make(map[string]uint64)
+	}
+	cash.failures++
+	for _, tag := range tags {
+		cash.pending[tag] = cash.failures
+	}
+	if cash.retrying {
+		return
+	}
+	cash.retrying = true
+	go func() {
+		for {
+			select {
+			case <-cash.done:
+				return
+			case <-time.After(cash.Cooldown):
+			}
+			if cash.invalidatePending(context.Background()) {
+				return
+			}
+		}
+	}()
+}
+
+// invalidatePending increments generations of tags which were not
+// invalidated, it reports whether all tags are invalidated
+func (cash *RedisCash) invalidatePending(ctx context.Context) bool {
+	cash.mu.RLock()
+	pending := make(map[string]uint64, len(cash.pending))
+	for tag, failure := range cash.pending {
+		pending[tag] = failure
+	}
+	cash.mu.RUnlock()
+	if len(pending) == 0 {
+		return true
+	}
+	tags := make([]string, 0, len(pending))
+	for tag := range pending {
+		tags = append(tags, tag)
+	}
+	err := cash.incr(ctx, tags...)
+	if err != nil {
+		cash.logger.Sugar().Warnf("error on invalidate pending tags: %v", err)
+		return false
+	}
+	cash.logger.Sugar().Infof("Pending tags %v invalidated success", tags)
+
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	// Tags which failed again meanwhile are kept
+	for tag, failure := range pending {
+		if cash.pending[tag] == failure {
+			delete(cash.pending, tag)
+		}
+	}
+	if len(cash.pending) > 0 {
+		return false
+	}
+	cash.retrying = false
+	return true
+}
+
+// check marks redis as down if err is a connection error
+func (cash *RedisCash) check(err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		// Redis replied with an error, so it is reachable
+		return
+	}
+	cash.logger.Sugar().Warnf("redis is unavailable: %v, cash is disabled for %v", err, cash.Cooldown)
+	cash.markDown()
+}
+
+func (cash *RedisCash) markDown() {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	cash.downUntil = time.Now().Add(cash.Cooldown)
+}
+
+func (cash *RedisCash) markUp() {
+	cash.mu.Lock()
+	defer cash.mu.Unlock()
+	cash.downUntil = time.Time{}
+}
+
+func (cash *RedisCash) isDown() bool {
+	cash.mu.RLock()
+	defer cash.mu.RUnlock()
+	return time.Now().Before(cash.downUntil)
+}
@@ -0,0 +1,28 @@
+package cash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisCashInvalidateRetry(t *testing.T) {
+	ctx := context.Background()
+	redis, server := newTestRedisCash(t)
+	redis.Cooldown = 10 * time.Millisecond
+
+	server.Close()
+	require.Error(t, redis.InvalidateTags(ctx, TagItems, TagCategories))
+	// Generations aren't read until the tags are invalidated
+	redis.markUp()
+	_, err := redis.GetGenerations(ctx, TagItems)
+	require.ErrorIs(t, err, ErrCashUnavailable)
+
+	require.NoError(t, server.Restart())
+	require.Eventually(t, func() bool {
+		generations, err := redis.GetGenerations(ctx, TagItems, TagCategories)
+		return err == nil && generations[0] > 0 && generations[1] > 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,99 @@
+package cash
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+var _ ICashStorage = &TieredCash{}
+
+// TieredCash is a two-level cash: values are read from the local
+// in-process cash first and from the shared cash on local miss.
+// Generations of tags are always read from the shared cash, so
+// invalidation made by any instance of the service is seen by all
+// of them, and keys of local values contain the shared generations
+type TieredCash struct {
+	local  *MemoryCash
+	shared ICashStorage
+	logger *zap.Logger
+}
+
+// NewTieredCash initialize two-level cash with local cash in front of shared one
+func NewTieredCash(local *MemoryCash, shared ICashStorage, logger *zap.Logger) *TieredCash {
+	logger.Debug("Enter in NewTieredCash()")
+	return &TieredCash{local: local, shared: shared, logger: logger}
+}
+
+// Exists checks for key in the local cash and then in the shared one
+func (cash *TieredCash) Exists(ctx context.Context, key string) (bool, error) {
+	ok, err := cash.local.Exists(ctx, key)
+	if err == nil && ok {
+		return true, nil
+	}
+	return cash.shared.Exists(ctx, key)
+}
+
+// Get returns value from the local cash or from the shared one,
+// value found in the shared cash is saved in the local cash
+func (cash *TieredCash) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := cash.local.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	value, err = cash.shared.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	err = cash.local.Set(ctx, key, value)
+	if err != nil {
+		cash.logger.Sugar().Warnf("error on set local cash with key: %s, error: %v", key, err)
+	}
+	return value, nil
+}
+
+// Set writes value in both cashes, value stays in the local
+// cash even if the shared cash is unavailable
+func (cash *TieredCash) Set(ctx context.Context, key string, value []byte) error {
+	err := cash.local.Set(ctx, key, value)
+	if err != nil {
+		cash.logger.Sugar().Warnf("error on set local cash with key: %s, error: %v", key, err)
+	}
+	return cash.shared.Set(ctx, key, value)
+}
+
+// Delete deletes value from both cashes
+func (cash *TieredCash) Delete(ctx context.Context, key string) error {
+	err := cash.local.Delete(ctx, key)
+	if err != nil {
+		cash.logger.Sugar().Warnf("error on delete local cash with key: %s, error: %v", key, err)
+	}
+	return cash.shared.Delete(ctx, key)
+}
+
+// GetGenerations returns generations of tags from the shared cash
+func (cash *TieredCash) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	return cash.shared.GetGenerations(ctx, tags...)
+}
+
+// InvalidateTags increments generations of tags in the shared cash,
+// local values which depend on tags are dropped as they become unreachable
+func (cash *TieredCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	cash.local.DropLocal(tags...)
+	return cash.shared.InvalidateTags(ctx, tags...)
+}
+
+// InvalidateLocal drops local values which depend on tags
+// invalidated in the shared cash by other instance
+func (cash *TieredCash) InvalidateLocal(tags ...string) {
+	cash.local.DropLocal(tags...)
+}
+
+// ShutDown shuts down both cashes
+func (cash *TieredCash) ShutDown(timeout int) error {
+	err := cash.local.ShutDown(timeout)
+	if err != nil {
+		cash.logger.Sugar().Warnf("error on shutdown local cash: %v", err)
+	}
+	return cash.shared.ShutDown(timeout)
+}
@@ -0,0 +1,99 @@
+package cash
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestRedisCash(t *testing.T) (*RedisCash, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	redis := NewRedisCash(server.Host(), server.Port(), 1, zap.L())
+	t.Cleanup(func() { _ = redis.ShutDown(1) })
+	return redis, server
+}
+
+func TestTieredCash(t *testing.T) {
+	ctx := context.Background()
+	redis, server := newTestRedisCash(t)
+	local := NewMemoryCash(time.Minute, 10, zap.L())
+	cash := NewTieredCash(local, redis, zap.L())
+
+	require.NoError(t, cash.Set(ctx, "key", []byte("value")))
+	require.True(t, server.Exists("key"))
+	ok, err := local.Exists(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Value written by another instance is read from redis and saved locally
+	require.NoError(t, server.Set("other", "value"))
+	res, err := cash.Get(ctx, "other")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), res)
+	ok, err = local.Exists(ctx, "other")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Generations are shared between instances
+	require.NoError(t, cash.InvalidateTags(ctx, TagItems))
+	generations, err := redis.GetGenerations(ctx, TagItems)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, generations)
+	generations, err = local.GetGenerations(ctx, TagItems)
+	require.NoError(t, err)
+	require.Equal(t, []int64{0}, generations)
+
+	require.NoError(t, cash.Delete(ctx, "key"))
+	require.False(t, server.Exists("key"))
+	_, err = cash.Get(ctx, "key")
+	require.ErrorIs(t, err, ErrCashMiss)
+}
+
+func TestRedisCashDegrade(t *testing.T) {
+	ctx := context.Background()
+	redis, server := newTestRedisCash(t)
+	redis.Cooldown = time.Hour
+	itemsCash := NewItemsCash(redis, zap.L())
+	items := []models.Item{{Title: "title"}}
+
+	require.NoError(t, itemsCash.CreateItemsCash(ctx, items, "items"))
+	require.True(t, itemsCash.CheckCash(ctx, "items"))
+	require.True(t, redis.Available())
+
+	// Redis goes down, the first failed request disables the cash
+	server.Close()
+	require.False(t, itemsCash.CheckCash(ctx, "items"))
+	require.False(t, redis.Available())
+	_, err := itemsCash.GetItemsCash(ctx, "items")
+	require.ErrorIs(t, err, ErrCashUnavailable)
+	_, err = itemsCash.GetGenerations(ctx, TagItems)
+	require.ErrorIs(t, err, ErrCashUnavailable)
+
+	// Redis comes back, the cash is enabled when the cooldown expires
+	require.NoError(t, server.Restart())
+	redis.downUntil = time.Now()
+	require.True(t, itemsCash.CheckCash(ctx, "items"))
+	res, err := itemsCash.GetItemsCash(ctx, "items")
+	require.NoError(t, err)
+	require.Equal(t, items, res)
+}
+
+func TestRedisCashUnreachable(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	host, port := server.Host(), server.Port()
+	server.Close()
+
+	// Unreachable redis does not prevent start of the service
+	redis := NewRedisCash(host, port, 1, zap.L())
+	require.False(t, redis.Available())
+	categoriesCash := NewCategoriesCash(redis, zap.L())
+	require.False(t, categoriesCash.CheckCash(ctx, "categories"))
+	_, err := categoriesCash.GetCategoriesListCash(ctx, "categories")
+	require.ErrorIs(t, err, ErrCashUnavailable)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type currencyRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ CurrencyStore = (*currencyRepo)(nil)
+
+func NewCurrencyRepo(store *PGres, log *zap.SugaredLogger) CurrencyStore {
+	return &currencyRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// GetRates returns all exchange rates ordered by currency
+func (repo *currencyRepo) GetRates(ctx context.Context) ([]models.ExchangeRate, error) {
+	repo.logger.Debug("Enter in repository GetRates()")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT currency, rate::float8, updated_at FROM exchange_rates ORDER BY currency`)
+	if err != nil {
+		repo.logger.Errorf("can't get exchange rates: %s", err)
+		return nil, fmt.Errorf("can't get exchange rates: %w", err)
+	}
+	defer rows.Close()
+	rates := make([]models.ExchangeRate, 0)
+	for rows.Next() {
+		rate := models.ExchangeRate{}
+		err = rows.Scan(&rate.Currency, &rate.Rate, &rate.UpdatedAt)
+		if err != nil {
+			repo.logger.Errorf("can't scan exchange rate: %s", err)
+			return nil, fmt.Errorf("can't scan exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	if err = rows.Err(); err != nil {
+		repo.logger.Errorf("can't read exchange rates: %s", err)
+		return nil, fmt.Errorf("can't read exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+// SetRate creates or replaces exchange rate of the currency
+func (repo *currencyRepo) SetRate(ctx context.Context, rate *models.ExchangeRate) error {
+	repo.logger.Debugf("Enter in repository SetRate() with args: ctx, rate: %v", rate)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `INSERT INTO exchange_rates (currency, rate, updated_at) VALUES ($1, $2, now())
+	ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at
+	RETURNING updated_at`, rate.Currency, rate.Rate)
+	err := row.Scan(&rate.UpdatedAt)
+	if err != nil {
+		repo.logger.Errorf("can't set exchange rate: %s", err)
+		return fmt.Errorf("can't set exchange rate: %w", err)
+	}
+	repo.logger.Infof("Exchange rate of %s set to %v", rate.Currency, rate.Rate)
+	return nil
+}
+
+// DeleteRate deletes exchange rate of the currency
+func (repo *currencyRepo) DeleteRate(ctx context.Context, currency string) error {
+	repo.logger.Debugf("Enter in repository DeleteRate() with args: ctx, currency: %s", currency)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM exchange_rates WHERE currency = $1`, currency)
+	if err != nil {
+		repo.logger.Errorf("can't delete exchange rate: %s", err)
+		return fmt.Errorf("can't delete exchange rate: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Infof("Exchange rate of %s deleted", currency)
+	return nil
+}
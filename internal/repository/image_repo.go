@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type imageRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ImageStore = (*imageRepo)(nil)
+
+func NewImageRepo(store *PGres, log *zap.SugaredLogger) ImageStore {
+	return &imageRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// GetImageRefs returns references to images of all items and categories,
+// deleted ones too, because their images are shown in orders. Unlike other
+// lists the references are returned as a whole, a partial list would make
+// referenced files look orphaned
+func (repo *imageRepo) GetImageRefs(ctx context.Context) ([]models.ImageRef, error) {
+	repo.logger.Debug("Enter in repository GetImageRefs() with args: ctx")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT $1::text, items.id, COALESCE(categories.name, ''), item_images.url
+	FROM item_images
+	INNER JOIN items ON items.id = item_images.item_id
+	LEFT JOIN categories ON categories.id = items.category
+	UNION ALL
+	SELECT $2::text, id, name, picture FROM categories
+	WHERE picture IS NOT NULL AND picture <> ''`,
+		models.UploadKindItem, models.UploadKindCategory)
+	if err != nil {
+		repo.logger.Errorf("can't get image references: %s", err)
+		return nil, fmt.Errorf("can't get image references: %w", err)
+	}
+	defer rows.Close()
+	refs := make([]models.ImageRef, 0)
+	for rows.Next() {
+		ref := models.ImageRef{}
+		err := rows.Scan(
+			&ref.Kind,
+			&ref.OwnerId,
+			&ref.Category,
+			&ref.URL,
+		)
+		if err != nil {
+			repo.logger.Errorf("can't scan image reference: %s", err)
+			return nil, fmt.Errorf("can't scan image reference: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get image references: %w", err)
+	}
+	return refs, nil
+}
+
+// DeleteImageRef removes the reference from the item or category,
+// other images of the owner are not changed
+func (repo *imageRepo) DeleteImageRef(ctx context.Context, ref models.ImageRef) error {
+	repo.logger.Debugf("Enter in repository DeleteImageRef() with args: ctx, ref: %v", ref)
+	pool := repo.storage.GetPool()
+	var query string
+	switch ref.Kind {
+	case models.UploadKindItem:
+		// If the primary image is removed, the first of the rest becomes primary
+		query = `WITH deleted AS (
+			DELETE FROM item_images WHERE url = $1 AND item_id = $2 RETURNING is_primary
+		)
+		UPDATE item_images SET is_primary = true
+		WHERE id = (SELECT id FROM item_images WHERE item_id = $2 AND url <> $1 ORDER BY position LIMIT 1)
+		AND EXISTS (SELECT 1 FROM deleted WHERE is_primary)`
+	case models.UploadKindCategory:
+		query = `UPDATE categories SET picture = '' WHERE picture = $1 AND id = $2`
+	default:
+		return fmt.Errorf("unknown kind of image reference: %q", ref.Kind)
+	}
+	_, err := pool.Exec(ctx, query, ref.URL, ref.OwnerId)
+	if err != nil {
+		repo.logger.Errorf("can't delete image reference: %s", err)
+		return fmt.Errorf("can't delete image reference: %w", err)
+	}
+	repo.logger.Infof("Image reference %s of %s %v deleted", ref.URL, ref.Kind, ref.OwnerId)
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type invoiceRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ InvoiceStore = (*invoiceRepo)(nil)
+
+func NewInvoiceRepo(store *PGres, log *zap.SugaredLogger) InvoiceStore {
+	return &invoiceRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// GetInvoice returns the rendered invoice of the order
+func (repo *invoiceRepo) GetInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, error) {
+	repo.logger.Debugf("Enter in repository GetInvoice() with args: ctx, orderId: %v", orderId)
+	pool := repo.storage.GetPool()
+	invoice := models.Invoice{}
+	row := pool.QueryRow(ctx, `SELECT order_id, number, file_name, fingerprint, created_at, updated_at
+	FROM invoices WHERE order_id = $1`, orderId)
+	err := row.Scan(&invoice.OrderId, &invoice.Number, &invoice.FileName, &invoice.Fingerprint,
+		&invoice.CreatedAt, &invoice.UpdatedAt)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get invoice: %s", err)
+		return nil, fmt.Errorf("can't get invoice: %w", err)
+	}
+	return &invoice, nil
+}
+
+// SaveInvoice saves the rendered invoice of the order, the number and
+// the time of creation of the existing invoice are kept
+func (repo *invoiceRepo) SaveInvoice(ctx context.Context, invoice *models.Invoice) error {
+	repo.logger.Debugf("Enter in repository SaveInvoice() with args: ctx, invoice: %v", invoice)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `INSERT INTO invoices (order_id, number, file_name, fingerprint)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (order_id) DO UPDATE SET file_name = EXCLUDED.file_name,
+		fingerprint = EXCLUDED.fingerprint, updated_at = now()
+	RETURNING number, created_at, updated_at`,
+		invoice.OrderId,
+		invoice.Number,
+		invoice.FileName,
+		invoice.Fingerprint,
+	)
+	err := row.Scan(&invoice.Number, &invoice.CreatedAt, &invoice.UpdatedAt)
+	if err != nil {
+		repo.logger.Errorf("can't save invoice: %s", err)
+		return fmt.Errorf("can't save invoice: %w", err)
+	}
+	return nil
+}
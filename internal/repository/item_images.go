@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// itemImagesColumn returns expression selecting images of the item
+// with given alias of items table as json array ordered by position
+func itemImagesColumn(alias string) string {
+	return `(SELECT COALESCE(json_agg(json_build_object(
+		'Id', img.id, 'URL', img.url, 'Alt', img.alt, 'Position', img.position, 'Primary', img.is_primary)
+		ORDER BY img.position), '[]')
+		FROM item_images img WHERE img.item_id = ` + alias + `.id)`
+}
+
+// itemImages scans images selected by itemImagesColumn. Every scan allocates
+// a new slice, so items scanned in the same variable do not share images
+type itemImages struct {
+	images *[]models.ItemImage
+}
+
+func (dst itemImages) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*dst.images = nil
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("can't scan images of item from %T", src)
+	}
+	images := make([]models.ItemImage, 0)
+	err := json.Unmarshal(data, &images)
+	if err != nil {
+		return fmt.Errorf("can't decode images of item: %w", err)
+	}
+	*dst.images = images
+	return nil
+}
+
+// saveItemImages replaces images of the item with given ones in the
+// transaction. Images are matched by url, so ids of kept images do not change
+func saveItemImages(ctx context.Context, tx pgx.Tx, itemId uuid.UUID, images []models.ItemImage) error {
+	images = models.NormalizeImages(images)
+	urls := make([]string, len(images))
+	for i, image := range images {
+		urls[i] = image.URL
+	}
+	// The primary flag is reset first, so the unique index
+	// of primary images is not violated by the changed order
+	_, err := tx.Exec(ctx, `UPDATE item_images SET is_primary = false WHERE item_id = $1 AND is_primary`, itemId)
+	if err != nil {
+		return fmt.Errorf("can't reset primary image: %w", err)
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM item_images WHERE item_id = $1 AND NOT (url = ANY($2))`, itemId, urls)
+	if err != nil {
+		return fmt.Errorf("can't delete images: %w", err)
+	}
+	for _, image := range images {
+		_, err = tx.Exec(ctx, `INSERT INTO item_images (item_id, url, alt, position, is_primary)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (item_id, url) DO UPDATE SET
+		alt = EXCLUDED.alt,
+		position = EXCLUDED.position,
+		is_primary = EXCLUDED.is_primary`,
+			itemId,
+			image.URL,
+			image.Alt,
+			image.Position,
+			image.Primary,
+		)
+		if err != nil {
+			return fmt.Errorf("can't save image %s: %w", image.URL, err)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"OnlineShopBackend/internal/models"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -55,14 +56,14 @@ func (repo *itemRepo) CreateItem(ctx context.Context, item *models.Item) (uuid.U
 		}
 	}()
 	var id uuid.UUID
-	row := tx.QueryRow(ctx, `INSERT INTO items(name, category, description, price, vendor, pictures, deleted_at)
+	row := tx.QueryRow(ctx, `INSERT INTO items(name, category, description, price, currency, vendor, deleted_at)
 	values ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
 		item.Title,
 		item.Category.Id,
 		item.Description,
 		item.Price,
+		item.Currency,
 		item.Vendor,
-		item.Images,
 		nil,
 	)
 	err = row.Scan(&id)
@@ -70,12 +71,18 @@ func (repo *itemRepo) CreateItem(ctx context.Context, item *models.Item) (uuid.U
 		repo.logger.Errorf("can't create item %s", err)
 		return uuid.Nil, fmt.Errorf("can't create item %w", err)
 	}
+	err = saveItemImages(ctx, tx, id, item.Images)
+	if err != nil {
+		repo.logger.Errorf("can't create item images %s", err)
+		return uuid.Nil, fmt.Errorf("can't create item images %w", err)
+	}
 	repo.logger.Info("Item create success")
 	repo.logger.Debugf("id is %v\n", id)
 	return id, nil
 }
 
-// UpdateItem сhanges the existing item
+// UpdateItem сhanges the existing item, images of the item
+// are replaced with given ones if they are not nil
 func (repo *itemRepo) UpdateItem(ctx context.Context, item *models.Item) error {
 	repo.logger.Debugf("Enter in repository UpdateItem() with args: ctx, item: %v", item)
 
@@ -102,13 +109,13 @@ func (repo *itemRepo) UpdateItem(ctx context.Context, item *models.Item) error {
 		}
 	}()
 
-	_, err = tx.Exec(ctx, `UPDATE items SET name=$1, category=$2, description=$3, price=$4, vendor=$5, pictures = $6 WHERE id=$7`,
+	_, err = tx.Exec(ctx, `UPDATE items SET name=$1, category=$2, description=$3, price=$4, currency=$5, vendor=$6 WHERE id=$7`,
 		item.Title,
 		item.Category.Id,
 		item.Description,
 		item.Price,
+		item.Currency,
 		item.Vendor,
-		item.Images,
 		item.Id)
 	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
@@ -117,6 +124,13 @@ func (repo *itemRepo) UpdateItem(ctx context.Context, item *models.Item) error {
 		repo.logger.Errorf("Error on update item %s: %s", item.Id, err)
 		return fmt.Errorf("error on update item %s: %w", item.Id, err)
 	}
+	if item.Images != nil {
+		err = saveItemImages(ctx, tx, item.Id, item.Images)
+		if err != nil {
+			repo.logger.Errorf("Error on update images of item %s: %s", item.Id, err)
+			return fmt.Errorf("error on update images of item %s: %w", item.Id, err)
+		}
+	}
 	repo.logger.Infof("Item %s successfully updated", item.Id)
 	return nil
 }
@@ -138,8 +152,10 @@ func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item,
 	categories.picture, 
 	items.description, 
 	price, 
+	currency, 
 	vendor, 
-	pictures 
+	`+itemImagesColumn("items")+`, `+itemRatingColumns("items")+`,
+	COALESCE(sku, items.id::text)
 	FROM items 
 	INNER JOIN categories 
 	ON category=categories.id 
@@ -156,8 +172,12 @@ func (repo *itemRepo) GetItem(ctx context.Context, id uuid.UUID) (*models.Item,
 		&item.Category.Image,
 		&item.Description,
 		&item.Price,
+		&item.Currency,
 		&item.Vendor,
-		&item.Images,
+		itemImages{&item.Images},
+		&item.Rating,
+		&item.ReviewsCount,
+		&item.Sku,
 	)
 	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 		repo.logger.Errorf("Error in rows scan get item by id: %s", err)
@@ -190,8 +210,10 @@ func (repo *itemRepo) ItemsList(ctx context.Context) (chan models.Item, error) {
 		categories.picture, 
 		items.description, 
 		price, 
+		currency, 
 		vendor, 
-		pictures 
+		`+itemImagesColumn("items")+`, `+itemRatingColumns("items")+`,
+		COALESCE(sku, items.id::text)
 		FROM items 
 		INNER JOIN categories 
 		ON category=categories.id 
@@ -215,8 +237,12 @@ func (repo *itemRepo) ItemsList(ctx context.Context) (chan models.Item, error) {
 				&item.Category.Image,
 				&item.Description,
 				&item.Price,
+				&item.Currency,
 				&item.Vendor,
-				&item.Images,
+				itemImages{&item.Images},
+				&item.Rating,
+				&item.ReviewsCount,
+				&item.Sku,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -246,8 +272,9 @@ func (repo *itemRepo) SearchLine(ctx context.Context, param string) (chan models
 		categories.picture, 
 		items.description, 
 		price, 
+		currency, 
 		vendor, 
-		pictures 
+		`+itemImagesColumn("items")+`, `+itemRatingColumns("items")+` 
 		FROM items 
 		INNER JOIN categories 
 		ON category=categories.id 
@@ -275,8 +302,11 @@ func (repo *itemRepo) SearchLine(ctx context.Context, param string) (chan models
 				&item.Category.Image,
 				&item.Description,
 				&item.Price,
+				&item.Currency,
 				&item.Vendor,
-				&item.Images,
+				itemImages{&item.Images},
+				&item.Rating,
+				&item.ReviewsCount,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -305,8 +335,9 @@ func (repo *itemRepo) GetItemsByCategory(ctx context.Context, categoryName strin
 		categories.picture, 
 		items.description, 
 		price, 
+		currency, 
 		vendor, 
-		pictures FROM items 
+		`+itemImagesColumn("items")+`, `+itemRatingColumns("items")+` FROM items 
 		INNER JOIN categories ON category=categories.id 
 		WHERE items.deleted_at is null 
 		AND categories.deleted_at is null 
@@ -329,8 +360,11 @@ func (repo *itemRepo) GetItemsByCategory(ctx context.Context, categoryName strin
 				&item.Category.Image,
 				&item.Description,
 				&item.Price,
+				&item.Currency,
 				&item.Vendor,
-				&item.Images,
+				itemImages{&item.Images},
+				&item.Rating,
+				&item.ReviewsCount,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -399,7 +433,7 @@ func (repo *itemRepo) AddFavouriteItem(ctx context.Context, userId uuid.UUID, it
 func (repo *itemRepo) DeleteFavouriteItem(ctx context.Context, userId uuid.UUID, itemId uuid.UUID) error {
 	repo.logger.Debug("Enter in repository DeleteFavouriteItem() with args: ctx, userid: %v, itemId: %v", userId, itemId)
 	pool := repo.storage.GetPool()
-	_, err := pool.Exec(ctx, `DELETE FROM favourite_items WHERE user_id=$1 AND item_id=$2`, userId, itemId)
+	_, err := pool.Exec(ctx, `DELETE FROM favourite_items WHERE user_id=$1 AND item_id=$2 AND wishlist_id IS NULL`, userId, itemId)
 	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
 		repo.logger.Errorf("can't delete item from favourite: %s", err)
 		return models.ErrorNotFound{}
@@ -431,10 +465,12 @@ func (repo *itemRepo) GetFavouriteItems(ctx context.Context, userId uuid.UUID) (
 		cat.description, 
 		cat.picture, 
 		i.price, 
+		i.currency, 
 		i.vendor, 
-		i.pictures
+		`+itemImagesColumn("i")+`, `+itemRatingColumns("i")+`
 		FROM favourite_items f, items i, categories cat
 		WHERE f.user_id=$1 
+		AND f.wishlist_id IS NULL
 		AND i.id = f.item_id 
 		AND cat.id = i.category
 		AND i.deleted_at IS NULL
@@ -455,8 +491,11 @@ func (repo *itemRepo) GetFavouriteItems(ctx context.Context, userId uuid.UUID) (
 				&item.Category.Description,
 				&item.Category.Image,
 				&item.Price,
+				&item.Currency,
 				&item.Vendor,
-				&item.Images,
+				itemImages{&item.Images},
+				&item.Rating,
+				&item.ReviewsCount,
 			); err != nil {
 				repo.logger.Error(err.Error())
 				return
@@ -477,7 +516,7 @@ func (repo *itemRepo) GetFavouriteItemsId(ctx context.Context, userId uuid.UUID)
 	result := make(map[uuid.UUID]uuid.UUID)
 	item := models.Item{}
 	rows, err := pool.Query(ctx, `
-		SELECT 	i.id FROM favourite_items f, items i WHERE f.user_id=$1 and i.id = f.item_id`, userId)
+		SELECT 	i.id FROM favourite_items f, items i WHERE f.user_id=$1 and f.wishlist_id IS NULL and i.id = f.item_id`, userId)
 	if err != nil {
 		repo.logger.Errorf("can't select items from favourite_items: %s", err)
 		return nil, err
@@ -572,6 +611,7 @@ func (repo *itemRepo) ItemsInFavouriteQuantity(ctx context.Context, userId uuid.
 	SELECT COUNT(1) 
 	FROM favourite_items f, items i
 	WHERE f.user_id=$1 
+	AND f.wishlist_id IS NULL
 	AND i.id = f.item_id
 	AND i.deleted_at IS NULL
 	`, userId)
@@ -583,3 +623,127 @@ func (repo *itemRepo) ItemsInFavouriteQuantity(ctx context.Context, userId uuid.
 	repo.logger.Info("Request for ItemsInFavouriteQuantity success")
 	return quantity, nil
 }
+
+// GetItemIdBySku returns id of item with given sku or error
+func (repo *itemRepo) GetItemIdBySku(ctx context.Context, sku string) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository GetItemIdBySku() with args: ctx, sku: %s", sku)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	// Items created without sku are identified by id
+	row := pool.QueryRow(ctx, `SELECT id FROM items WHERE sku = $1 OR (sku IS NULL AND id::text = $1)`, sku)
+	err := row.Scan(&id)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("Error in row.Scan get item id by sku: %s", err)
+		return uuid.Nil, fmt.Errorf("error in row.Scan get item id by sku: %w", err)
+	}
+	return id, nil
+}
+
+// UpsertItemBySku creates item or updates item with the same sku,
+// deleted item is restored. Returns id of item and true if item is created.
+// Images of existing item are kept if item has no images
+func (repo *itemRepo) UpsertItemBySku(ctx context.Context, item *models.Item) (uuid.UUID, bool, error) {
+	repo.logger.Debugf("Enter in repository UpsertItemBySku() with args: ctx, item: %v", item)
+
+	pool := repo.storage.GetPool()
+
+	// Recording operations need transaction
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("Can't create transaction: %s", err)
+		return uuid.Nil, false, fmt.Errorf("can't create transaction: %w", err)
+	}
+	repo.logger.Debug("Transaction begin success")
+	defer func() {
+		if err != nil {
+			repo.logger.Errorf("Transaction rolled back")
+			if err = tx.Rollback(ctx); err != nil {
+				repo.logger.Errorf("Can't rollback %s", err)
+			}
+		} else {
+			repo.logger.Info("Transaction commited")
+			if err != tx.Commit(ctx) {
+				repo.logger.Errorf("Can't commit %s", err)
+			}
+		}
+	}()
+
+	// Item created without sku is exported with its id as sku,
+	// so the id becomes its sku when the item is imported back
+	_, err = tx.Exec(ctx, `UPDATE items SET sku = $1 WHERE sku IS NULL AND id::text = $1`, item.Sku)
+	if err != nil {
+		repo.logger.Errorf("Error on set sku %s: %s", item.Sku, err)
+		return uuid.Nil, false, fmt.Errorf("error on set sku %s: %w", item.Sku, err)
+	}
+	var id uuid.UUID
+	var created bool
+	row := tx.QueryRow(ctx, `
+	INSERT INTO items(sku, name, category, description, price, currency, vendor, deleted_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, null)
+	ON CONFLICT (sku) DO UPDATE SET
+	name = EXCLUDED.name,
+	category = EXCLUDED.category,
+	description = EXCLUDED.description,
+	price = EXCLUDED.price,
+	currency = EXCLUDED.currency,
+	vendor = EXCLUDED.vendor,
+	deleted_at = null
+	RETURNING id, (xmax = 0)
+	`,
+		item.Sku,
+		item.Title,
+		item.Category.Id,
+		item.Description,
+		item.Price,
+		item.Currency,
+		item.Vendor,
+	)
+	err = row.Scan(&id, &created)
+	if err != nil {
+		repo.logger.Errorf("Error on upsert item with sku %s: %s", item.Sku, err)
+		return uuid.Nil, false, fmt.Errorf("error on upsert item with sku %s: %w", item.Sku, err)
+	}
+	if item.Images != nil {
+		err = saveItemImages(ctx, tx, id, item.Images)
+		if err != nil {
+			repo.logger.Errorf("Error on save images of item with sku %s: %s", item.Sku, err)
+			return uuid.Nil, false, fmt.Errorf("error on save images of item with sku %s: %w", item.Sku, err)
+		}
+	}
+	repo.logger.Infof("Item with sku %s upsert success", item.Sku)
+	return id, created, nil
+}
+
+// SetItemStock sets stock of the item, nil stock means that the stock isn't tracked
+func (repo *itemRepo) SetItemStock(ctx context.Context, id uuid.UUID, stock *int) error {
+	repo.logger.Debugf("Enter in repository SetItemStock() with args: ctx, id: %v, stock: %v", id, stock)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE items SET stock = $1 WHERE id = $2 AND deleted_at IS NULL`, stock, id)
+	if err != nil {
+		repo.logger.Errorf("can't set stock of item: %s", err)
+		return fmt.Errorf("can't set stock of item: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// SetQuantityLimits sets limits of the quantity of the item in a cart line
+func (repo *itemRepo) SetQuantityLimits(ctx context.Context, id uuid.UUID, limits models.QuantityLimits) error {
+	repo.logger.Debugf("Enter in repository SetQuantityLimits() with args: ctx, id: %v, limits: %v", id, limits)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE items SET min_quantity = $1, max_quantity = $2 WHERE id = $3 AND deleted_at IS NULL`,
+		limits.Min, limits.Max, id)
+	if err != nil {
+		repo.logger.Errorf("can't set quantity limits of item: %s", err)
+		return fmt.Errorf("can't set quantity limits of item: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
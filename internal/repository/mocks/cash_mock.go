@@ -13,6 +13,202 @@ import (
 	uuid "github.com/google/uuid"
 )
 
+// MockITagsCash is a mock of ITagsCash interface.
+type MockITagsCash struct {
+	ctrl     *gomock.Controller
+	recorder *MockITagsCashMockRecorder
+}
+
+// MockITagsCashMockRecorder is the mock recorder for MockITagsCash.
+type MockITagsCashMockRecorder struct {
+	mock *MockITagsCash
+}
+
+// NewMockITagsCash creates a new mock instance.
+func NewMockITagsCash(ctrl *gomock.Controller) *MockITagsCash {
+	mock := &MockITagsCash{ctrl: ctrl}
+	mock.recorder = &MockITagsCashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITagsCash) EXPECT() *MockITagsCashMockRecorder {
+	return m.recorder
+}
+
+// GetGenerations mocks base method.
+func (m *MockITagsCash) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGenerations", varargs...)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenerations indicates an expected call of GetGenerations.
+func (mr *MockITagsCashMockRecorder) GetGenerations(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenerations", reflect.TypeOf((*MockITagsCash)(nil).GetGenerations), varargs...)
+}
+
+// InvalidateTags mocks base method.
+func (m *MockITagsCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvalidateTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTags indicates an expected call of InvalidateTags.
+func (mr *MockITagsCashMockRecorder) InvalidateTags(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTags", reflect.TypeOf((*MockITagsCash)(nil).InvalidateTags), varargs...)
+}
+
+// MockICashStorage is a mock of ICashStorage interface.
+type MockICashStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockICashStorageMockRecorder
+}
+
+// MockICashStorageMockRecorder is the mock recorder for MockICashStorage.
+type MockICashStorageMockRecorder struct {
+	mock *MockICashStorage
+}
+
+// NewMockICashStorage creates a new mock instance.
+func NewMockICashStorage(ctrl *gomock.Controller) *MockICashStorage {
+	mock := &MockICashStorage{ctrl: ctrl}
+	mock.recorder = &MockICashStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICashStorage) EXPECT() *MockICashStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockICashStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockICashStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICashStorage)(nil).Delete), ctx, key)
+}
+
+// Exists mocks base method.
+func (m *MockICashStorage) Exists(ctx context.Context, key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockICashStorageMockRecorder) Exists(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockICashStorage)(nil).Exists), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockICashStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockICashStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockICashStorage)(nil).Get), ctx, key)
+}
+
+// GetGenerations mocks base method.
+func (m *MockICashStorage) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGenerations", varargs...)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenerations indicates an expected call of GetGenerations.
+func (mr *MockICashStorageMockRecorder) GetGenerations(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenerations", reflect.TypeOf((*MockICashStorage)(nil).GetGenerations), varargs...)
+}
+
+// InvalidateTags mocks base method.
+func (m *MockICashStorage) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvalidateTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTags indicates an expected call of InvalidateTags.
+func (mr *MockICashStorageMockRecorder) InvalidateTags(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTags", reflect.TypeOf((*MockICashStorage)(nil).InvalidateTags), varargs...)
+}
+
+// Set mocks base method.
+func (m *MockICashStorage) Set(ctx context.Context, key string, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockICashStorageMockRecorder) Set(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockICashStorage)(nil).Set), ctx, key, value)
+}
+
+// ShutDown mocks base method.
+func (m *MockICashStorage) ShutDown(timeout int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShutDown", timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShutDown indicates an expected call of ShutDown.
+func (mr *MockICashStorageMockRecorder) ShutDown(timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShutDown", reflect.TypeOf((*MockICashStorage)(nil).ShutDown), timeout)
+}
+
 // MockIItemsCash is a mock of IItemsCash interface.
 type MockIItemsCash struct {
 	ctrl     *gomock.Controller
@@ -107,6 +303,26 @@ func (mr *MockIItemsCashMockRecorder) GetFavouriteItemsIdCash(ctx, key interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavouriteItemsIdCash", reflect.TypeOf((*MockIItemsCash)(nil).GetFavouriteItemsIdCash), ctx, key)
 }
 
+// GetGenerations mocks base method.
+func (m *MockIItemsCash) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGenerations", varargs...)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenerations indicates an expected call of GetGenerations.
+func (mr *MockIItemsCashMockRecorder) GetGenerations(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenerations", reflect.TypeOf((*MockIItemsCash)(nil).GetGenerations), varargs...)
+}
+
 // GetItemsCash mocks base method.
 func (m *MockIItemsCash) GetItemsCash(ctx context.Context, key string) ([]models.Item, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +353,25 @@ func (mr *MockIItemsCashMockRecorder) GetItemsQuantityCash(ctx, key interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsQuantityCash", reflect.TypeOf((*MockIItemsCash)(nil).GetItemsQuantityCash), ctx, key)
 }
 
+// InvalidateTags mocks base method.
+func (m *MockIItemsCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvalidateTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTags indicates an expected call of InvalidateTags.
+func (mr *MockIItemsCashMockRecorder) InvalidateTags(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTags", reflect.TypeOf((*MockIItemsCash)(nil).InvalidateTags), varargs...)
+}
+
 // MockICategoriesCash is a mock of ICategoriesCash interface.
 type MockICategoriesCash struct {
 	ctrl     *gomock.Controller
@@ -216,3 +451,42 @@ func (mr *MockICategoriesCashMockRecorder) GetCategoriesListCash(ctx, key interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesListCash", reflect.TypeOf((*MockICategoriesCash)(nil).GetCategoriesListCash), ctx, key)
 }
+
+// GetGenerations mocks base method.
+func (m *MockICategoriesCash) GetGenerations(ctx context.Context, tags ...string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGenerations", varargs...)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenerations indicates an expected call of GetGenerations.
+func (mr *MockICategoriesCashMockRecorder) GetGenerations(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenerations", reflect.TypeOf((*MockICategoriesCash)(nil).GetGenerations), varargs...)
+}
+
+// InvalidateTags mocks base method.
+func (m *MockICategoriesCash) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvalidateTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTags indicates an expected call of InvalidateTags.
+func (mr *MockICategoriesCashMockRecorder) InvalidateTags(ctx interface{}, tags ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTags", reflect.TypeOf((*MockICategoriesCash)(nil).InvalidateTags), varargs...)
+}
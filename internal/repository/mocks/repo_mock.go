@@ -8,6 +8,7 @@ import (
 	models "OnlineShopBackend/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -138,6 +139,21 @@ func (mr *MockItemStoreMockRecorder) GetItem(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemStore)(nil).GetItem), ctx, id)
 }
 
+// GetItemIdBySku mocks base method.
+func (m *MockItemStore) GetItemIdBySku(ctx context.Context, sku string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemIdBySku", ctx, sku)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemIdBySku indicates an expected call of GetItemIdBySku.
+func (mr *MockItemStoreMockRecorder) GetItemIdBySku(ctx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemIdBySku", reflect.TypeOf((*MockItemStore)(nil).GetItemIdBySku), ctx, sku)
+}
+
 // GetItemsByCategory mocks base method.
 func (m *MockItemStore) GetItemsByCategory(ctx context.Context, categoryName string) (chan models.Item, error) {
 	m.ctrl.T.Helper()
@@ -168,204 +184,1608 @@ func (mr *MockItemStoreMockRecorder) ItemsByCategoryQuantity(ctx, categoryName i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsByCategoryQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsByCategoryQuantity), ctx, categoryName)
 }
 
-// ItemsInFavouriteQuantity mocks base method.
-func (m *MockItemStore) ItemsInFavouriteQuantity(ctx context.Context, userId uuid.UUID) (int, error) {
+// ItemsInFavouriteQuantity mocks base method.
+func (m *MockItemStore) ItemsInFavouriteQuantity(ctx context.Context, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItemsInFavouriteQuantity", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ItemsInFavouriteQuantity indicates an expected call of ItemsInFavouriteQuantity.
+func (mr *MockItemStoreMockRecorder) ItemsInFavouriteQuantity(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsInFavouriteQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsInFavouriteQuantity), ctx, userId)
+}
+
+// ItemsInSearchQuantity mocks base method.
+func (m *MockItemStore) ItemsInSearchQuantity(ctx context.Context, searchRequest string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItemsInSearchQuantity", ctx, searchRequest)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ItemsInSearchQuantity indicates an expected call of ItemsInSearchQuantity.
+func (mr *MockItemStoreMockRecorder) ItemsInSearchQuantity(ctx, searchRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsInSearchQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsInSearchQuantity), ctx, searchRequest)
+}
+
+// ItemsList mocks base method.
+func (m *MockItemStore) ItemsList(ctx context.Context) (chan models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItemsList", ctx)
+	ret0, _ := ret[0].(chan models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ItemsList indicates an expected call of ItemsList.
+func (mr *MockItemStoreMockRecorder) ItemsList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsList", reflect.TypeOf((*MockItemStore)(nil).ItemsList), ctx)
+}
+
+// ItemsListQuantity mocks base method.
+func (m *MockItemStore) ItemsListQuantity(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItemsListQuantity", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ItemsListQuantity indicates an expected call of ItemsListQuantity.
+func (mr *MockItemStoreMockRecorder) ItemsListQuantity(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsListQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsListQuantity), ctx)
+}
+
+// SearchLine mocks base method.
+func (m *MockItemStore) SearchLine(ctx context.Context, param string) (chan models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchLine", ctx, param)
+	ret0, _ := ret[0].(chan models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchLine indicates an expected call of SearchLine.
+func (mr *MockItemStoreMockRecorder) SearchLine(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchLine", reflect.TypeOf((*MockItemStore)(nil).SearchLine), ctx, param)
+}
+
+// SetItemStock mocks base method.
+func (m *MockItemStore) SetItemStock(ctx context.Context, id uuid.UUID, stock *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemStock", ctx, id, stock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemStock indicates an expected call of SetItemStock.
+func (mr *MockItemStoreMockRecorder) SetItemStock(ctx, id, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemStock", reflect.TypeOf((*MockItemStore)(nil).SetItemStock), ctx, id, stock)
+}
+
+// SetQuantityLimits mocks base method.
+func (m *MockItemStore) SetQuantityLimits(ctx context.Context, id uuid.UUID, limits models.QuantityLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQuantityLimits", ctx, id, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetQuantityLimits indicates an expected call of SetQuantityLimits.
+func (mr *MockItemStoreMockRecorder) SetQuantityLimits(ctx, id, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQuantityLimits", reflect.TypeOf((*MockItemStore)(nil).SetQuantityLimits), ctx, id, limits)
+}
+
+// UpdateItem mocks base method.
+func (m *MockItemStore) UpdateItem(ctx context.Context, item *models.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockItemStoreMockRecorder) UpdateItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemStore)(nil).UpdateItem), ctx, item)
+}
+
+// UpsertItemBySku mocks base method.
+func (m *MockItemStore) UpsertItemBySku(ctx context.Context, item *models.Item) (uuid.UUID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertItemBySku", ctx, item)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpsertItemBySku indicates an expected call of UpsertItemBySku.
+func (mr *MockItemStoreMockRecorder) UpsertItemBySku(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertItemBySku", reflect.TypeOf((*MockItemStore)(nil).UpsertItemBySku), ctx, item)
+}
+
+// MockWishlistStore is a mock of WishlistStore interface.
+type MockWishlistStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistStoreMockRecorder
+}
+
+// MockWishlistStoreMockRecorder is the mock recorder for MockWishlistStore.
+type MockWishlistStoreMockRecorder struct {
+	mock *MockWishlistStore
+}
+
+// NewMockWishlistStore creates a new mock instance.
+func NewMockWishlistStore(ctrl *gomock.Controller) *MockWishlistStore {
+	mock := &MockWishlistStore{ctrl: ctrl}
+	mock.recorder = &MockWishlistStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistStore) EXPECT() *MockWishlistStoreMockRecorder {
+	return m.recorder
+}
+
+// AddWishlistItem mocks base method.
+func (m *MockWishlistStore) AddWishlistItem(ctx context.Context, userId, id, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWishlistItem", ctx, userId, id, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWishlistItem indicates an expected call of AddWishlistItem.
+func (mr *MockWishlistStoreMockRecorder) AddWishlistItem(ctx, userId, id, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWishlistItem", reflect.TypeOf((*MockWishlistStore)(nil).AddWishlistItem), ctx, userId, id, itemId)
+}
+
+// CreateWishlist mocks base method.
+func (m *MockWishlistStore) CreateWishlist(ctx context.Context, wishlist *models.Wishlist) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWishlist", ctx, wishlist)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWishlist indicates an expected call of CreateWishlist.
+func (mr *MockWishlistStoreMockRecorder) CreateWishlist(ctx, wishlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWishlist", reflect.TypeOf((*MockWishlistStore)(nil).CreateWishlist), ctx, wishlist)
+}
+
+// DeleteWishlist mocks base method.
+func (m *MockWishlistStore) DeleteWishlist(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlist", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWishlist indicates an expected call of DeleteWishlist.
+func (mr *MockWishlistStoreMockRecorder) DeleteWishlist(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlist", reflect.TypeOf((*MockWishlistStore)(nil).DeleteWishlist), ctx, userId, id)
+}
+
+// DeleteWishlistItem mocks base method.
+func (m *MockWishlistStore) DeleteWishlistItem(ctx context.Context, userId, id, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlistItem", ctx, userId, id, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWishlistItem indicates an expected call of DeleteWishlistItem.
+func (mr *MockWishlistStoreMockRecorder) DeleteWishlistItem(ctx, userId, id, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlistItem", reflect.TypeOf((*MockWishlistStore)(nil).DeleteWishlistItem), ctx, userId, id, itemId)
+}
+
+// GetWishlist mocks base method.
+func (m *MockWishlistStore) GetWishlist(ctx context.Context, userId, id uuid.UUID) (*models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", ctx, userId, id)
+	ret0, _ := ret[0].(*models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockWishlistStoreMockRecorder) GetWishlist(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockWishlistStore)(nil).GetWishlist), ctx, userId, id)
+}
+
+// GetWishlistByToken mocks base method.
+func (m *MockWishlistStore) GetWishlistByToken(ctx context.Context, token string) (*models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlistByToken", ctx, token)
+	ret0, _ := ret[0].(*models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlistByToken indicates an expected call of GetWishlistByToken.
+func (mr *MockWishlistStoreMockRecorder) GetWishlistByToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlistByToken", reflect.TypeOf((*MockWishlistStore)(nil).GetWishlistByToken), ctx, token)
+}
+
+// GetWishlistItems mocks base method.
+func (m *MockWishlistStore) GetWishlistItems(ctx context.Context, userId, id uuid.UUID) ([]models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlistItems", ctx, userId, id)
+	ret0, _ := ret[0].([]models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlistItems indicates an expected call of GetWishlistItems.
+func (mr *MockWishlistStoreMockRecorder) GetWishlistItems(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlistItems", reflect.TypeOf((*MockWishlistStore)(nil).GetWishlistItems), ctx, userId, id)
+}
+
+// GetWishlists mocks base method.
+func (m *MockWishlistStore) GetWishlists(ctx context.Context, userId uuid.UUID) ([]models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlists", ctx, userId)
+	ret0, _ := ret[0].([]models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlists indicates an expected call of GetWishlists.
+func (mr *MockWishlistStoreMockRecorder) GetWishlists(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlists", reflect.TypeOf((*MockWishlistStore)(nil).GetWishlists), ctx, userId)
+}
+
+// HasWishlistItem mocks base method.
+func (m *MockWishlistStore) HasWishlistItem(ctx context.Context, userId, id, itemId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasWishlistItem", ctx, userId, id, itemId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasWishlistItem indicates an expected call of HasWishlistItem.
+func (mr *MockWishlistStoreMockRecorder) HasWishlistItem(ctx, userId, id, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasWishlistItem", reflect.TypeOf((*MockWishlistStore)(nil).HasWishlistItem), ctx, userId, id, itemId)
+}
+
+// MoveWishlistItem mocks base method.
+func (m *MockWishlistStore) MoveWishlistItem(ctx context.Context, userId, fromId, toId, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveWishlistItem", ctx, userId, fromId, toId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveWishlistItem indicates an expected call of MoveWishlistItem.
+func (mr *MockWishlistStoreMockRecorder) MoveWishlistItem(ctx, userId, fromId, toId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWishlistItem", reflect.TypeOf((*MockWishlistStore)(nil).MoveWishlistItem), ctx, userId, fromId, toId, itemId)
+}
+
+// RenameWishlist mocks base method.
+func (m *MockWishlistStore) RenameWishlist(ctx context.Context, userId, id uuid.UUID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameWishlist", ctx, userId, id, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameWishlist indicates an expected call of RenameWishlist.
+func (mr *MockWishlistStoreMockRecorder) RenameWishlist(ctx, userId, id, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameWishlist", reflect.TypeOf((*MockWishlistStore)(nil).RenameWishlist), ctx, userId, id, name)
+}
+
+// SetShareToken mocks base method.
+func (m *MockWishlistStore) SetShareToken(ctx context.Context, userId, id uuid.UUID, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShareToken", ctx, userId, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetShareToken indicates an expected call of SetShareToken.
+func (mr *MockWishlistStoreMockRecorder) SetShareToken(ctx, userId, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShareToken", reflect.TypeOf((*MockWishlistStore)(nil).SetShareToken), ctx, userId, id, token)
+}
+
+// MockCategoryStore is a mock of CategoryStore interface.
+type MockCategoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryStoreMockRecorder
+}
+
+// MockCategoryStoreMockRecorder is the mock recorder for MockCategoryStore.
+type MockCategoryStoreMockRecorder struct {
+	mock *MockCategoryStore
+}
+
+// NewMockCategoryStore creates a new mock instance.
+func NewMockCategoryStore(ctrl *gomock.Controller) *MockCategoryStore {
+	mock := &MockCategoryStore{ctrl: ctrl}
+	mock.recorder = &MockCategoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryStore) CreateCategory(ctx context.Context, category *models.Category) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryStoreMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryStore)(nil).CreateCategory), ctx, category)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryStore) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryStoreMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryStore)(nil).DeleteCategory), ctx, id)
+}
+
+// GetCategory mocks base method.
+func (m *MockCategoryStore) GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", ctx, id)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockCategoryStoreMockRecorder) GetCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategoryStore)(nil).GetCategory), ctx, id)
+}
+
+// GetCategoryByName mocks base method.
+func (m *MockCategoryStore) GetCategoryByName(ctx context.Context, name string) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByName", ctx, name)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByName indicates an expected call of GetCategoryByName.
+func (mr *MockCategoryStoreMockRecorder) GetCategoryByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByName", reflect.TypeOf((*MockCategoryStore)(nil).GetCategoryByName), ctx, name)
+}
+
+// GetCategoryList mocks base method.
+func (m *MockCategoryStore) GetCategoryList(ctx context.Context) (chan models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryList", ctx)
+	ret0, _ := ret[0].(chan models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryList indicates an expected call of GetCategoryList.
+func (mr *MockCategoryStoreMockRecorder) GetCategoryList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryList", reflect.TypeOf((*MockCategoryStore)(nil).GetCategoryList), ctx)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryStoreMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryStore)(nil).UpdateCategory), ctx, category)
+}
+
+// MockUploadStore is a mock of UploadStore interface.
+type MockUploadStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadStoreMockRecorder
+}
+
+// MockUploadStoreMockRecorder is the mock recorder for MockUploadStore.
+type MockUploadStoreMockRecorder struct {
+	mock *MockUploadStore
+}
+
+// NewMockUploadStore creates a new mock instance.
+func NewMockUploadStore(ctrl *gomock.Controller) *MockUploadStore {
+	mock := &MockUploadStore{ctrl: ctrl}
+	mock.recorder = &MockUploadStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadStore) EXPECT() *MockUploadStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateUpload mocks base method.
+func (m *MockUploadStore) CreateUpload(ctx context.Context, session *models.UploadSession) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, session)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUploadStoreMockRecorder) CreateUpload(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUploadStore)(nil).CreateUpload), ctx, session)
+}
+
+// DeleteExpiredUploads mocks base method.
+func (m *MockUploadStore) DeleteExpiredUploads(ctx context.Context, before time.Time) ([]models.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUploads", ctx, before)
+	ret0, _ := ret[0].([]models.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredUploads indicates an expected call of DeleteExpiredUploads.
+func (mr *MockUploadStoreMockRecorder) DeleteExpiredUploads(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUploads", reflect.TypeOf((*MockUploadStore)(nil).DeleteExpiredUploads), ctx, before)
+}
+
+// GetUpload mocks base method.
+func (m *MockUploadStore) GetUpload(ctx context.Context, id uuid.UUID) (*models.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, id)
+	ret0, _ := ret[0].(*models.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUploadStoreMockRecorder) GetUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUploadStore)(nil).GetUpload), ctx, id)
+}
+
+// UpdateUploadStatus mocks base method.
+func (m *MockUploadStore) UpdateUploadStatus(ctx context.Context, id uuid.UUID, from []models.UploadStatus, to models.UploadStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUploadStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUploadStatus indicates an expected call of UpdateUploadStatus.
+func (mr *MockUploadStoreMockRecorder) UpdateUploadStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUploadStatus", reflect.TypeOf((*MockUploadStore)(nil).UpdateUploadStatus), ctx, id, from, to)
+}
+
+// MockImageStore is a mock of ImageStore interface.
+type MockImageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageStoreMockRecorder
+}
+
+// MockImageStoreMockRecorder is the mock recorder for MockImageStore.
+type MockImageStoreMockRecorder struct {
+	mock *MockImageStore
+}
+
+// NewMockImageStore creates a new mock instance.
+func NewMockImageStore(ctrl *gomock.Controller) *MockImageStore {
+	mock := &MockImageStore{ctrl: ctrl}
+	mock.recorder = &MockImageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageStore) EXPECT() *MockImageStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteImageRef mocks base method.
+func (m *MockImageStore) DeleteImageRef(ctx context.Context, ref models.ImageRef) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImageRef", ctx, ref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImageRef indicates an expected call of DeleteImageRef.
+func (mr *MockImageStoreMockRecorder) DeleteImageRef(ctx, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImageRef", reflect.TypeOf((*MockImageStore)(nil).DeleteImageRef), ctx, ref)
+}
+
+// GetImageRefs mocks base method.
+func (m *MockImageStore) GetImageRefs(ctx context.Context) ([]models.ImageRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageRefs", ctx)
+	ret0, _ := ret[0].([]models.ImageRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageRefs indicates an expected call of GetImageRefs.
+func (mr *MockImageStoreMockRecorder) GetImageRefs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageRefs", reflect.TypeOf((*MockImageStore)(nil).GetImageRefs), ctx)
+}
+
+// MockReviewStore is a mock of ReviewStore interface.
+type MockReviewStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewStoreMockRecorder
+}
+
+// MockReviewStoreMockRecorder is the mock recorder for MockReviewStore.
+type MockReviewStoreMockRecorder struct {
+	mock *MockReviewStore
+}
+
+// NewMockReviewStore creates a new mock instance.
+func NewMockReviewStore(ctrl *gomock.Controller) *MockReviewStore {
+	mock := &MockReviewStore{ctrl: ctrl}
+	mock.recorder = &MockReviewStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewStore) EXPECT() *MockReviewStoreMockRecorder {
+	return m.recorder
+}
+
+// ChangeReviewStatus mocks base method.
+func (m *MockReviewStore) ChangeReviewStatus(ctx context.Context, id uuid.UUID, status models.ReviewStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReviewStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeReviewStatus indicates an expected call of ChangeReviewStatus.
+func (mr *MockReviewStoreMockRecorder) ChangeReviewStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReviewStatus", reflect.TypeOf((*MockReviewStore)(nil).ChangeReviewStatus), ctx, id, status)
+}
+
+// GetItemReviews mocks base method.
+func (m *MockReviewStore) GetItemReviews(ctx context.Context, itemId uuid.UUID, status models.ReviewStatus, offset, limit int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemReviews", ctx, itemId, status, offset, limit)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemReviews indicates an expected call of GetItemReviews.
+func (mr *MockReviewStoreMockRecorder) GetItemReviews(ctx, itemId, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemReviews", reflect.TypeOf((*MockReviewStore)(nil).GetItemReviews), ctx, itemId, status, offset, limit)
+}
+
+// GetReview mocks base method.
+func (m *MockReviewStore) GetReview(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, id)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockReviewStoreMockRecorder) GetReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockReviewStore)(nil).GetReview), ctx, id)
+}
+
+// GetReviewsByStatus mocks base method.
+func (m *MockReviewStore) GetReviewsByStatus(ctx context.Context, status models.ReviewStatus, offset, limit int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByStatus", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewsByStatus indicates an expected call of GetReviewsByStatus.
+func (mr *MockReviewStoreMockRecorder) GetReviewsByStatus(ctx, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByStatus", reflect.TypeOf((*MockReviewStore)(nil).GetReviewsByStatus), ctx, status, offset, limit)
+}
+
+// IsBuyer mocks base method.
+func (m *MockReviewStore) IsBuyer(ctx context.Context, userId, itemId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBuyer", ctx, userId, itemId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBuyer indicates an expected call of IsBuyer.
+func (mr *MockReviewStoreMockRecorder) IsBuyer(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBuyer", reflect.TypeOf((*MockReviewStore)(nil).IsBuyer), ctx, userId, itemId)
+}
+
+// SaveReview mocks base method.
+func (m *MockReviewStore) SaveReview(ctx context.Context, review *models.Review) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReview", ctx, review)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveReview indicates an expected call of SaveReview.
+func (mr *MockReviewStoreMockRecorder) SaveReview(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReview", reflect.TypeOf((*MockReviewStore)(nil).SaveReview), ctx, review)
+}
+
+// MockPromotionStore is a mock of PromotionStore interface.
+type MockPromotionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionStoreMockRecorder
+}
+
+// MockPromotionStoreMockRecorder is the mock recorder for MockPromotionStore.
+type MockPromotionStoreMockRecorder struct {
+	mock *MockPromotionStore
+}
+
+// NewMockPromotionStore creates a new mock instance.
+func NewMockPromotionStore(ctrl *gomock.Controller) *MockPromotionStore {
+	mock := &MockPromotionStore{ctrl: ctrl}
+	mock.recorder = &MockPromotionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionStore) EXPECT() *MockPromotionStoreMockRecorder {
+	return m.recorder
+}
+
+// CountUserUsages mocks base method.
+func (m *MockPromotionStore) CountUserUsages(ctx context.Context, promotionId, userId uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserUsages", ctx, promotionId, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserUsages indicates an expected call of CountUserUsages.
+func (mr *MockPromotionStoreMockRecorder) CountUserUsages(ctx, promotionId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserUsages", reflect.TypeOf((*MockPromotionStore)(nil).CountUserUsages), ctx, promotionId, userId)
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionStore) CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, promotion)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionStoreMockRecorder) CreatePromotion(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionStore)(nil).CreatePromotion), ctx, promotion)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionStore) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionStoreMockRecorder) DeletePromotion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionStore)(nil).DeletePromotion), ctx, id)
+}
+
+// GetAutomaticPromotions mocks base method.
+func (m *MockPromotionStore) GetAutomaticPromotions(ctx context.Context) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutomaticPromotions", ctx)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutomaticPromotions indicates an expected call of GetAutomaticPromotions.
+func (mr *MockPromotionStoreMockRecorder) GetAutomaticPromotions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutomaticPromotions", reflect.TypeOf((*MockPromotionStore)(nil).GetAutomaticPromotions), ctx)
+}
+
+// GetPromotion mocks base method.
+func (m *MockPromotionStore) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", ctx, id)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockPromotionStoreMockRecorder) GetPromotion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockPromotionStore)(nil).GetPromotion), ctx, id)
+}
+
+// GetPromotionByCode mocks base method.
+func (m *MockPromotionStore) GetPromotionByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionByCode", ctx, code)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionByCode indicates an expected call of GetPromotionByCode.
+func (mr *MockPromotionStoreMockRecorder) GetPromotionByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionByCode", reflect.TypeOf((*MockPromotionStore)(nil).GetPromotionByCode), ctx, code)
+}
+
+// GetPromotions mocks base method.
+func (m *MockPromotionStore) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", ctx)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockPromotionStoreMockRecorder) GetPromotions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockPromotionStore)(nil).GetPromotions), ctx)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockPromotionStore) UpdatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", ctx, promotion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockPromotionStoreMockRecorder) UpdatePromotion(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockPromotionStore)(nil).UpdatePromotion), ctx, promotion)
+}
+
+// MockCurrencyStore is a mock of CurrencyStore interface.
+type MockCurrencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyStoreMockRecorder
+}
+
+// MockCurrencyStoreMockRecorder is the mock recorder for MockCurrencyStore.
+type MockCurrencyStoreMockRecorder struct {
+	mock *MockCurrencyStore
+}
+
+// NewMockCurrencyStore creates a new mock instance.
+func NewMockCurrencyStore(ctrl *gomock.Controller) *MockCurrencyStore {
+	mock := &MockCurrencyStore{ctrl: ctrl}
+	mock.recorder = &MockCurrencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyStore) EXPECT() *MockCurrencyStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteRate mocks base method.
+func (m *MockCurrencyStore) DeleteRate(ctx context.Context, currency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockCurrencyStoreMockRecorder) DeleteRate(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockCurrencyStore)(nil).DeleteRate), ctx, currency)
+}
+
+// GetRates mocks base method.
+func (m *MockCurrencyStore) GetRates(ctx context.Context) ([]models.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", ctx)
+	ret0, _ := ret[0].([]models.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockCurrencyStoreMockRecorder) GetRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockCurrencyStore)(nil).GetRates), ctx)
+}
+
+// SetRate mocks base method.
+func (m *MockCurrencyStore) SetRate(ctx context.Context, rate *models.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate.
+func (mr *MockCurrencyStoreMockRecorder) SetRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockCurrencyStore)(nil).SetRate), ctx, rate)
+}
+
+// MockTaxStore is a mock of TaxStore interface.
+type MockTaxStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxStoreMockRecorder
+}
+
+// MockTaxStoreMockRecorder is the mock recorder for MockTaxStore.
+type MockTaxStoreMockRecorder struct {
+	mock *MockTaxStore
+}
+
+// NewMockTaxStore creates a new mock instance.
+func NewMockTaxStore(ctrl *gomock.Controller) *MockTaxStore {
+	mock := &MockTaxStore{ctrl: ctrl}
+	mock.recorder = &MockTaxStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxStore) EXPECT() *MockTaxStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRule mocks base method.
+func (m *MockTaxStore) CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRule", ctx, rule)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRule indicates an expected call of CreateTaxRule.
+func (mr *MockTaxStoreMockRecorder) CreateTaxRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRule", reflect.TypeOf((*MockTaxStore)(nil).CreateTaxRule), ctx, rule)
+}
+
+// DeleteTaxRule mocks base method.
+func (m *MockTaxStore) DeleteTaxRule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRule indicates an expected call of DeleteTaxRule.
+func (mr *MockTaxStoreMockRecorder) DeleteTaxRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRule", reflect.TypeOf((*MockTaxStore)(nil).DeleteTaxRule), ctx, id)
+}
+
+// GetTaxClasses mocks base method.
+func (m *MockTaxStore) GetTaxClasses(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxClasses", ctx, itemIds)
+	ret0, _ := ret[0].(map[uuid.UUID]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxClasses indicates an expected call of GetTaxClasses.
+func (mr *MockTaxStoreMockRecorder) GetTaxClasses(ctx, itemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxClasses", reflect.TypeOf((*MockTaxStore)(nil).GetTaxClasses), ctx, itemIds)
+}
+
+// GetTaxRules mocks base method.
+func (m *MockTaxStore) GetTaxRules(ctx context.Context) ([]models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRules", ctx)
+	ret0, _ := ret[0].([]models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRules indicates an expected call of GetTaxRules.
+func (mr *MockTaxStoreMockRecorder) GetTaxRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRules", reflect.TypeOf((*MockTaxStore)(nil).GetTaxRules), ctx)
+}
+
+// GetTaxRulesByCountry mocks base method.
+func (m *MockTaxStore) GetTaxRulesByCountry(ctx context.Context, country string) ([]models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRulesByCountry", ctx, country)
+	ret0, _ := ret[0].([]models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRulesByCountry indicates an expected call of GetTaxRulesByCountry.
+func (mr *MockTaxStoreMockRecorder) GetTaxRulesByCountry(ctx, country interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRulesByCountry", reflect.TypeOf((*MockTaxStore)(nil).GetTaxRulesByCountry), ctx, country)
+}
+
+// SetCategoryTaxClass mocks base method.
+func (m *MockTaxStore) SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryTaxClass", ctx, categoryId, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCategoryTaxClass indicates an expected call of SetCategoryTaxClass.
+func (mr *MockTaxStoreMockRecorder) SetCategoryTaxClass(ctx, categoryId, class interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryTaxClass", reflect.TypeOf((*MockTaxStore)(nil).SetCategoryTaxClass), ctx, categoryId, class)
+}
+
+// SetItemTaxClass mocks base method.
+func (m *MockTaxStore) SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemTaxClass", ctx, itemId, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemTaxClass indicates an expected call of SetItemTaxClass.
+func (mr *MockTaxStoreMockRecorder) SetItemTaxClass(ctx, itemId, class interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemTaxClass", reflect.TypeOf((*MockTaxStore)(nil).SetItemTaxClass), ctx, itemId, class)
+}
+
+// UpdateTaxRule mocks base method.
+func (m *MockTaxStore) UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaxRule indicates an expected call of UpdateTaxRule.
+func (mr *MockTaxStoreMockRecorder) UpdateTaxRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRule", reflect.TypeOf((*MockTaxStore)(nil).UpdateTaxRule), ctx, rule)
+}
+
+// MockShippingStore is a mock of ShippingStore interface.
+type MockShippingStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockShippingStoreMockRecorder
+}
+
+// MockShippingStoreMockRecorder is the mock recorder for MockShippingStore.
+type MockShippingStoreMockRecorder struct {
+	mock *MockShippingStore
+}
+
+// NewMockShippingStore creates a new mock instance.
+func NewMockShippingStore(ctrl *gomock.Controller) *MockShippingStore {
+	mock := &MockShippingStore{ctrl: ctrl}
+	mock.recorder = &MockShippingStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShippingStore) EXPECT() *MockShippingStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateShippingMethod mocks base method.
+func (m *MockShippingStore) CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShippingMethod", ctx, method)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShippingMethod indicates an expected call of CreateShippingMethod.
+func (mr *MockShippingStoreMockRecorder) CreateShippingMethod(ctx, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShippingMethod", reflect.TypeOf((*MockShippingStore)(nil).CreateShippingMethod), ctx, method)
+}
+
+// DeleteShippingMethod mocks base method.
+func (m *MockShippingStore) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShippingMethod", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShippingMethod indicates an expected call of DeleteShippingMethod.
+func (mr *MockShippingStoreMockRecorder) DeleteShippingMethod(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShippingMethod", reflect.TypeOf((*MockShippingStore)(nil).DeleteShippingMethod), ctx, id)
+}
+
+// GetActiveShippingMethods mocks base method.
+func (m *MockShippingStore) GetActiveShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveShippingMethods", ctx)
+	ret0, _ := ret[0].([]models.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveShippingMethods indicates an expected call of GetActiveShippingMethods.
+func (mr *MockShippingStoreMockRecorder) GetActiveShippingMethods(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveShippingMethods", reflect.TypeOf((*MockShippingStore)(nil).GetActiveShippingMethods), ctx)
+}
+
+// GetItemWeights mocks base method.
+func (m *MockShippingStore) GetItemWeights(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemWeights", ctx, itemIds)
+	ret0, _ := ret[0].(map[uuid.UUID]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemWeights indicates an expected call of GetItemWeights.
+func (mr *MockShippingStoreMockRecorder) GetItemWeights(ctx, itemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemWeights", reflect.TypeOf((*MockShippingStore)(nil).GetItemWeights), ctx, itemIds)
+}
+
+// GetShippingMethod mocks base method.
+func (m *MockShippingStore) GetShippingMethod(ctx context.Context, id uuid.UUID) (*models.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingMethod", ctx, id)
+	ret0, _ := ret[0].(*models.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingMethod indicates an expected call of GetShippingMethod.
+func (mr *MockShippingStoreMockRecorder) GetShippingMethod(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingMethod", reflect.TypeOf((*MockShippingStore)(nil).GetShippingMethod), ctx, id)
+}
+
+// GetShippingMethods mocks base method.
+func (m *MockShippingStore) GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingMethods", ctx)
+	ret0, _ := ret[0].([]models.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingMethods indicates an expected call of GetShippingMethods.
+func (mr *MockShippingStoreMockRecorder) GetShippingMethods(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingMethods", reflect.TypeOf((*MockShippingStore)(nil).GetShippingMethods), ctx)
+}
+
+// SetItemWeight mocks base method.
+func (m *MockShippingStore) SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemWeight", ctx, itemId, weight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemWeight indicates an expected call of SetItemWeight.
+func (mr *MockShippingStoreMockRecorder) SetItemWeight(ctx, itemId, weight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemWeight", reflect.TypeOf((*MockShippingStore)(nil).SetItemWeight), ctx, itemId, weight)
+}
+
+// UpdateShippingMethod mocks base method.
+func (m *MockShippingStore) UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShippingMethod", ctx, method)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateShippingMethod indicates an expected call of UpdateShippingMethod.
+func (mr *MockShippingStoreMockRecorder) UpdateShippingMethod(ctx, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShippingMethod", reflect.TypeOf((*MockShippingStore)(nil).UpdateShippingMethod), ctx, method)
+}
+
+// MockPaymentStore is a mock of PaymentStore interface.
+type MockPaymentStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentStoreMockRecorder
+}
+
+// MockPaymentStoreMockRecorder is the mock recorder for MockPaymentStore.
+type MockPaymentStoreMockRecorder struct {
+	mock *MockPaymentStore
+}
+
+// NewMockPaymentStore creates a new mock instance.
+func NewMockPaymentStore(ctrl *gomock.Controller) *MockPaymentStore {
+	mock := &MockPaymentStore{ctrl: ctrl}
+	mock.recorder = &MockPaymentStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentStore) EXPECT() *MockPaymentStoreMockRecorder {
+	return m.recorder
+}
+
+// CreatePayment mocks base method.
+func (m *MockPaymentStore) CreatePayment(ctx context.Context, payment *models.Payment) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, payment)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockPaymentStoreMockRecorder) CreatePayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockPaymentStore)(nil).CreatePayment), ctx, payment)
+}
+
+// GetOrderForPayment mocks base method.
+func (m *MockPaymentStore) GetOrderForPayment(ctx context.Context, orderId uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderForPayment", ctx, orderId)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderForPayment indicates an expected call of GetOrderForPayment.
+func (mr *MockPaymentStoreMockRecorder) GetOrderForPayment(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderForPayment", reflect.TypeOf((*MockPaymentStore)(nil).GetOrderForPayment), ctx, orderId)
+}
+
+// GetOrderPayments mocks base method.
+func (m *MockPaymentStore) GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPayments", ctx, orderId)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPayments indicates an expected call of GetOrderPayments.
+func (mr *MockPaymentStoreMockRecorder) GetOrderPayments(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPayments", reflect.TypeOf((*MockPaymentStore)(nil).GetOrderPayments), ctx, orderId)
+}
+
+// GetPayment mocks base method.
+func (m *MockPaymentStore) GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", ctx, id)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockPaymentStoreMockRecorder) GetPayment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockPaymentStore)(nil).GetPayment), ctx, id)
+}
+
+// GetPaymentByProviderId mocks base method.
+func (m *MockPaymentStore) GetPaymentByProviderId(ctx context.Context, provider, providerPaymentId string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentByProviderId", ctx, provider, providerPaymentId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentByProviderId indicates an expected call of GetPaymentByProviderId.
+func (mr *MockPaymentStoreMockRecorder) GetPaymentByProviderId(ctx, provider, providerPaymentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentByProviderId", reflect.TypeOf((*MockPaymentStore)(nil).GetPaymentByProviderId), ctx, provider, providerPaymentId)
+}
+
+// SavePaymentEvent mocks base method.
+func (m *MockPaymentStore) SavePaymentEvent(ctx context.Context, event models.PaymentEvent, update models.PaymentUpdate) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePaymentEvent", ctx, event, update)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePaymentEvent indicates an expected call of SavePaymentEvent.
+func (mr *MockPaymentStoreMockRecorder) SavePaymentEvent(ctx, event, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePaymentEvent", reflect.TypeOf((*MockPaymentStore)(nil).SavePaymentEvent), ctx, event, update)
+}
+
+// UpdatePayment mocks base method.
+func (m *MockPaymentStore) UpdatePayment(ctx context.Context, update models.PaymentUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayment", ctx, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayment indicates an expected call of UpdatePayment.
+func (mr *MockPaymentStoreMockRecorder) UpdatePayment(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayment", reflect.TypeOf((*MockPaymentStore)(nil).UpdatePayment), ctx, update)
+}
+
+// MockReturnStore is a mock of ReturnStore interface.
+type MockReturnStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnStoreMockRecorder
+}
+
+// MockReturnStoreMockRecorder is the mock recorder for MockReturnStore.
+type MockReturnStoreMockRecorder struct {
+	mock *MockReturnStore
+}
+
+// NewMockReturnStore creates a new mock instance.
+func NewMockReturnStore(ctrl *gomock.Controller) *MockReturnStore {
+	mock := &MockReturnStore{ctrl: ctrl}
+	mock.recorder = &MockReturnStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnStore) EXPECT() *MockReturnStoreMockRecorder {
+	return m.recorder
+}
+
+// ChangeReturnStatus mocks base method.
+func (m *MockReturnStore) ChangeReturnStatus(ctx context.Context, id uuid.UUID, from, to models.ReturnStatus, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReturnStatus", ctx, id, from, to, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeReturnStatus indicates an expected call of ChangeReturnStatus.
+func (mr *MockReturnStoreMockRecorder) ChangeReturnStatus(ctx, id, from, to, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReturnStatus", reflect.TypeOf((*MockReturnStore)(nil).ChangeReturnStatus), ctx, id, from, to, comment)
+}
+
+// CreateRefund mocks base method.
+func (m *MockReturnStore) CreateRefund(ctx context.Context, refund *models.Refund) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefund", ctx, refund)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefund indicates an expected call of CreateRefund.
+func (mr *MockReturnStoreMockRecorder) CreateRefund(ctx, refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefund", reflect.TypeOf((*MockReturnStore)(nil).CreateRefund), ctx, refund)
+}
+
+// CreateReturn mocks base method.
+func (m *MockReturnStore) CreateReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ItemsInFavouriteQuantity", ctx, userId)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
+	ret_2 := m.ctrl.Call(m, "CreateReturn", ctx, ret)
+	ret0, _ := ret_2[0].(uuid.UUID)
+	ret1, _ := ret_2[1].(error)
 	return ret0, ret1
 }
 
-// ItemsInFavouriteQuantity indicates an expected call of ItemsInFavouriteQuantity.
-func (mr *MockItemStoreMockRecorder) ItemsInFavouriteQuantity(ctx, userId interface{}) *gomock.Call {
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockReturnStoreMockRecorder) CreateReturn(ctx, ret interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsInFavouriteQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsInFavouriteQuantity), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockReturnStore)(nil).CreateReturn), ctx, ret)
 }
 
-// ItemsInSearchQuantity mocks base method.
-func (m *MockItemStore) ItemsInSearchQuantity(ctx context.Context, searchRequest string) (int, error) {
+// GetOrderRefunds mocks base method.
+func (m *MockReturnStore) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]models.Refund, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ItemsInSearchQuantity", ctx, searchRequest)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetOrderRefunds", ctx, orderId)
+	ret0, _ := ret[0].([]models.Refund)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ItemsInSearchQuantity indicates an expected call of ItemsInSearchQuantity.
-func (mr *MockItemStoreMockRecorder) ItemsInSearchQuantity(ctx, searchRequest interface{}) *gomock.Call {
+// GetOrderRefunds indicates an expected call of GetOrderRefunds.
+func (mr *MockReturnStoreMockRecorder) GetOrderRefunds(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsInSearchQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsInSearchQuantity), ctx, searchRequest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefunds", reflect.TypeOf((*MockReturnStore)(nil).GetOrderRefunds), ctx, orderId)
 }
 
-// ItemsList mocks base method.
-func (m *MockItemStore) ItemsList(ctx context.Context) (chan models.Item, error) {
+// GetOrderReturns mocks base method.
+func (m *MockReturnStore) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ItemsList", ctx)
-	ret0, _ := ret[0].(chan models.Item)
+	ret := m.ctrl.Call(m, "GetOrderReturns", ctx, orderId)
+	ret0, _ := ret[0].([]models.Return)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ItemsList indicates an expected call of ItemsList.
-func (mr *MockItemStoreMockRecorder) ItemsList(ctx interface{}) *gomock.Call {
+// GetOrderReturns indicates an expected call of GetOrderReturns.
+func (mr *MockReturnStoreMockRecorder) GetOrderReturns(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsList", reflect.TypeOf((*MockItemStore)(nil).ItemsList), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderReturns", reflect.TypeOf((*MockReturnStore)(nil).GetOrderReturns), ctx, orderId)
 }
 
-// ItemsListQuantity mocks base method.
-func (m *MockItemStore) ItemsListQuantity(ctx context.Context) (int, error) {
+// GetReturn mocks base method.
+func (m *MockReturnStore) GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ItemsListQuantity", ctx)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetReturn", ctx, id)
+	ret0, _ := ret[0].(*models.Return)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ItemsListQuantity indicates an expected call of ItemsListQuantity.
-func (mr *MockItemStoreMockRecorder) ItemsListQuantity(ctx interface{}) *gomock.Call {
+// GetReturn indicates an expected call of GetReturn.
+func (mr *MockReturnStoreMockRecorder) GetReturn(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemsListQuantity", reflect.TypeOf((*MockItemStore)(nil).ItemsListQuantity), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturn", reflect.TypeOf((*MockReturnStore)(nil).GetReturn), ctx, id)
 }
 
-// SearchLine mocks base method.
-func (m *MockItemStore) SearchLine(ctx context.Context, param string) (chan models.Item, error) {
+// GetReturns mocks base method.
+func (m *MockReturnStore) GetReturns(ctx context.Context, status models.ReturnStatus) ([]models.Return, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchLine", ctx, param)
-	ret0, _ := ret[0].(chan models.Item)
+	ret := m.ctrl.Call(m, "GetReturns", ctx, status)
+	ret0, _ := ret[0].([]models.Return)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchLine indicates an expected call of SearchLine.
-func (mr *MockItemStoreMockRecorder) SearchLine(ctx, param interface{}) *gomock.Call {
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockReturnStoreMockRecorder) GetReturns(ctx, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchLine", reflect.TypeOf((*MockItemStore)(nil).SearchLine), ctx, param)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockReturnStore)(nil).GetReturns), ctx, status)
 }
 
-// UpdateItem mocks base method.
-func (m *MockItemStore) UpdateItem(ctx context.Context, item *models.Item) error {
+// ReceiveReturn mocks base method.
+func (m *MockReturnStore) ReceiveReturn(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItem", ctx, item)
+	ret := m.ctrl.Call(m, "ReceiveReturn", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateItem indicates an expected call of UpdateItem.
-func (mr *MockItemStoreMockRecorder) UpdateItem(ctx, item interface{}) *gomock.Call {
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockReturnStoreMockRecorder) ReceiveReturn(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemStore)(nil).UpdateItem), ctx, item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockReturnStore)(nil).ReceiveReturn), ctx, id)
 }
 
-// MockCategoryStore is a mock of CategoryStore interface.
-type MockCategoryStore struct {
+// MockShipmentStore is a mock of ShipmentStore interface.
+type MockShipmentStore struct {
 	ctrl     *gomock.Controller
-	recorder *MockCategoryStoreMockRecorder
+	recorder *MockShipmentStoreMockRecorder
 }
 
-// MockCategoryStoreMockRecorder is the mock recorder for MockCategoryStore.
-type MockCategoryStoreMockRecorder struct {
-	mock *MockCategoryStore
+// MockShipmentStoreMockRecorder is the mock recorder for MockShipmentStore.
+type MockShipmentStoreMockRecorder struct {
+	mock *MockShipmentStore
 }
 
-// NewMockCategoryStore creates a new mock instance.
-func NewMockCategoryStore(ctrl *gomock.Controller) *MockCategoryStore {
-	mock := &MockCategoryStore{ctrl: ctrl}
-	mock.recorder = &MockCategoryStoreMockRecorder{mock}
+// NewMockShipmentStore creates a new mock instance.
+func NewMockShipmentStore(ctrl *gomock.Controller) *MockShipmentStore {
+	mock := &MockShipmentStore{ctrl: ctrl}
+	mock.recorder = &MockShipmentStoreMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCategoryStore) EXPECT() *MockCategoryStoreMockRecorder {
+func (m *MockShipmentStore) EXPECT() *MockShipmentStoreMockRecorder {
 	return m.recorder
 }
 
-// CreateCategory mocks base method.
-func (m *MockCategoryStore) CreateCategory(ctx context.Context, category *models.Category) (uuid.UUID, error) {
+// CreateShipment mocks base method.
+func (m *MockShipmentStore) CreateShipment(ctx context.Context, shipment *models.Shipment) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, shipment)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockCategoryStoreMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockShipmentStoreMockRecorder) CreateShipment(ctx, shipment interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryStore)(nil).CreateCategory), ctx, category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockShipmentStore)(nil).CreateShipment), ctx, shipment)
 }
 
-// DeleteCategory mocks base method.
-func (m *MockCategoryStore) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+// DeleteShipment mocks base method.
+func (m *MockShipmentStore) DeleteShipment(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteShipment", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockCategoryStoreMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+// DeleteShipment indicates an expected call of DeleteShipment.
+func (mr *MockShipmentStoreMockRecorder) DeleteShipment(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryStore)(nil).DeleteCategory), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShipment", reflect.TypeOf((*MockShipmentStore)(nil).DeleteShipment), ctx, id)
 }
 
-// GetCategory mocks base method.
-func (m *MockCategoryStore) GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+// GetOrderShipments mocks base method.
+func (m *MockShipmentStore) GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]models.Shipment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", ctx, id)
-	ret0, _ := ret[0].(*models.Category)
+	ret := m.ctrl.Call(m, "GetOrderShipments", ctx, orderId)
+	ret0, _ := ret[0].([]models.Shipment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockCategoryStoreMockRecorder) GetCategory(ctx, id interface{}) *gomock.Call {
+// GetOrderShipments indicates an expected call of GetOrderShipments.
+func (mr *MockShipmentStoreMockRecorder) GetOrderShipments(ctx, orderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategoryStore)(nil).GetCategory), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderShipments", reflect.TypeOf((*MockShipmentStore)(nil).GetOrderShipments), ctx, orderId)
 }
 
-// GetCategoryByName mocks base method.
-func (m *MockCategoryStore) GetCategoryByName(ctx context.Context, name string) (*models.Category, error) {
+// GetShipment mocks base method.
+func (m *MockShipmentStore) GetShipment(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryByName", ctx, name)
-	ret0, _ := ret[0].(*models.Category)
+	ret := m.ctrl.Call(m, "GetShipment", ctx, id)
+	ret0, _ := ret[0].(*models.Shipment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategoryByName indicates an expected call of GetCategoryByName.
-func (mr *MockCategoryStoreMockRecorder) GetCategoryByName(ctx, name interface{}) *gomock.Call {
+// GetShipment indicates an expected call of GetShipment.
+func (mr *MockShipmentStoreMockRecorder) GetShipment(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByName", reflect.TypeOf((*MockCategoryStore)(nil).GetCategoryByName), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipment", reflect.TypeOf((*MockShipmentStore)(nil).GetShipment), ctx, id)
 }
 
-// GetCategoryList mocks base method.
-func (m *MockCategoryStore) GetCategoryList(ctx context.Context) (chan models.Category, error) {
+// GetTrackedShipments mocks base method.
+func (m *MockShipmentStore) GetTrackedShipments(ctx context.Context, carrier string) ([]models.Shipment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryList", ctx)
-	ret0, _ := ret[0].(chan models.Category)
+	ret := m.ctrl.Call(m, "GetTrackedShipments", ctx, carrier)
+	ret0, _ := ret[0].([]models.Shipment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategoryList indicates an expected call of GetCategoryList.
-func (mr *MockCategoryStoreMockRecorder) GetCategoryList(ctx interface{}) *gomock.Call {
+// GetTrackedShipments indicates an expected call of GetTrackedShipments.
+func (mr *MockShipmentStoreMockRecorder) GetTrackedShipments(ctx, carrier interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryList", reflect.TypeOf((*MockCategoryStore)(nil).GetCategoryList), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackedShipments", reflect.TypeOf((*MockShipmentStore)(nil).GetTrackedShipments), ctx, carrier)
 }
 
-// UpdateCategory mocks base method.
-func (m *MockCategoryStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+// UpdateShipment mocks base method.
+func (m *MockShipmentStore) UpdateShipment(ctx context.Context, shipment *models.Shipment, orderStatus models.Status) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
+	ret := m.ctrl.Call(m, "UpdateShipment", ctx, shipment, orderStatus)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateCategory indicates an expected call of UpdateCategory.
-func (mr *MockCategoryStoreMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
+// UpdateShipment indicates an expected call of UpdateShipment.
+func (mr *MockShipmentStoreMockRecorder) UpdateShipment(ctx, shipment, orderStatus interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryStore)(nil).UpdateCategory), ctx, category)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShipment", reflect.TypeOf((*MockShipmentStore)(nil).UpdateShipment), ctx, shipment, orderStatus)
+}
+
+// MockInvoiceStore is a mock of InvoiceStore interface.
+type MockInvoiceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvoiceStoreMockRecorder
+}
+
+// MockInvoiceStoreMockRecorder is the mock recorder for MockInvoiceStore.
+type MockInvoiceStoreMockRecorder struct {
+	mock *MockInvoiceStore
+}
+
+// NewMockInvoiceStore creates a new mock instance.
+func NewMockInvoiceStore(ctrl *gomock.Controller) *MockInvoiceStore {
+	mock := &MockInvoiceStore{ctrl: ctrl}
+	mock.recorder = &MockInvoiceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvoiceStore) EXPECT() *MockInvoiceStoreMockRecorder {
+	return m.recorder
+}
+
+// GetInvoice mocks base method.
+func (m *MockInvoiceStore) GetInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, orderId)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockInvoiceStoreMockRecorder) GetInvoice(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceStore)(nil).GetInvoice), ctx, orderId)
+}
+
+// SaveInvoice mocks base method.
+func (m *MockInvoiceStore) SaveInvoice(ctx context.Context, invoice *models.Invoice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvoice", ctx, invoice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInvoice indicates an expected call of SaveInvoice.
+func (mr *MockInvoiceStoreMockRecorder) SaveInvoice(ctx, invoice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvoice", reflect.TypeOf((*MockInvoiceStore)(nil).SaveInvoice), ctx, invoice)
 }
 
 // MockUserStore is a mock of UserStore interface.
@@ -466,6 +1886,21 @@ func (mr *MockUserStoreMockRecorder) GetUserByEmail(ctx, email interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserStore)(nil).GetUserByEmail), ctx, email)
 }
 
+// GetUserById mocks base method.
+func (m *MockUserStore) GetUserById(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUserStoreMockRecorder) GetUserById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserStore)(nil).GetUserById), ctx, id)
+}
+
 // SaveSession mocks base method.
 func (m *MockUserStore) SaveSession(ctx context.Context, token string, t int64) error {
 	m.ctrl.T.Helper()
@@ -546,6 +1981,20 @@ func (mr *MockCartStoreMockRecorder) AddItemToCart(ctx, cartId, itemId interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToCart", reflect.TypeOf((*MockCartStore)(nil).AddItemToCart), ctx, cartId, itemId)
 }
 
+// AddItemsToCart mocks base method.
+func (m *MockCartStore) AddItemsToCart(ctx context.Context, cartId uuid.UUID, quantities map[uuid.UUID]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItemsToCart", ctx, cartId, quantities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItemsToCart indicates an expected call of AddItemsToCart.
+func (mr *MockCartStoreMockRecorder) AddItemsToCart(ctx, cartId, quantities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsToCart", reflect.TypeOf((*MockCartStore)(nil).AddItemsToCart), ctx, cartId, quantities)
+}
+
 // Create mocks base method.
 func (m *MockCartStore) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -561,6 +2010,21 @@ func (mr *MockCartStoreMockRecorder) Create(ctx, userId interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartStore)(nil).Create), ctx, userId)
 }
 
+// DecrementItem mocks base method.
+func (m *MockCartStore) DecrementItem(ctx context.Context, cartId, itemId uuid.UUID, decrement, minQuantity int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementItem", ctx, cartId, itemId, decrement, minQuantity)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementItem indicates an expected call of DecrementItem.
+func (mr *MockCartStoreMockRecorder) DecrementItem(ctx, cartId, itemId, decrement, minQuantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementItem", reflect.TypeOf((*MockCartStore)(nil).DecrementItem), ctx, cartId, itemId, decrement, minQuantity)
+}
+
 // DeleteCart mocks base method.
 func (m *MockCartStore) DeleteCart(ctx context.Context, cartId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -575,6 +2039,21 @@ func (mr *MockCartStoreMockRecorder) DeleteCart(ctx, cartId interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCart", reflect.TypeOf((*MockCartStore)(nil).DeleteCart), ctx, cartId)
 }
 
+// DeleteExpiredCarts mocks base method.
+func (m *MockCartStore) DeleteExpiredCarts(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredCarts", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredCarts indicates an expected call of DeleteExpiredCarts.
+func (mr *MockCartStoreMockRecorder) DeleteExpiredCarts(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredCarts", reflect.TypeOf((*MockCartStore)(nil).DeleteExpiredCarts), ctx, before)
+}
+
 // DeleteItemFromCart mocks base method.
 func (m *MockCartStore) DeleteItemFromCart(ctx context.Context, cartId, itemId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -619,6 +2098,188 @@ func (mr *MockCartStoreMockRecorder) GetCartByUserId(ctx, userId interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserId", reflect.TypeOf((*MockCartStore)(nil).GetCartByUserId), ctx, userId)
 }
 
+// GetItemsAvailability mocks base method.
+func (m *MockCartStore) GetItemsAvailability(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]models.ItemAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsAvailability", ctx, itemIds)
+	ret0, _ := ret[0].(map[uuid.UUID]models.ItemAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsAvailability indicates an expected call of GetItemsAvailability.
+func (mr *MockCartStoreMockRecorder) GetItemsAvailability(ctx, itemIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsAvailability", reflect.TypeOf((*MockCartStore)(nil).GetItemsAvailability), ctx, itemIds)
+}
+
+// GetQuantityLimits mocks base method.
+func (m *MockCartStore) GetQuantityLimits(ctx context.Context, itemId uuid.UUID) (models.QuantityLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuantityLimits", ctx, itemId)
+	ret0, _ := ret[0].(models.QuantityLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuantityLimits indicates an expected call of GetQuantityLimits.
+func (mr *MockCartStoreMockRecorder) GetQuantityLimits(ctx, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuantityLimits", reflect.TypeOf((*MockCartStore)(nil).GetQuantityLimits), ctx, itemId)
+}
+
+// MergeCarts mocks base method.
+func (m *MockCartStore) MergeCarts(ctx context.Context, fromCartId, toCartId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCarts", ctx, fromCartId, toCartId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCarts indicates an expected call of MergeCarts.
+func (mr *MockCartStoreMockRecorder) MergeCarts(ctx, fromCartId, toCartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCarts", reflect.TypeOf((*MockCartStore)(nil).MergeCarts), ctx, fromCartId, toCartId)
+}
+
+// RefreshCartExpiry mocks base method.
+func (m *MockCartStore) RefreshCartExpiry(ctx context.Context, cartId uuid.UUID, guestExpireAt, userExpireAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshCartExpiry", ctx, cartId, guestExpireAt, userExpireAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshCartExpiry indicates an expected call of RefreshCartExpiry.
+func (mr *MockCartStoreMockRecorder) RefreshCartExpiry(ctx, cartId, guestExpireAt, userExpireAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshCartExpiry", reflect.TypeOf((*MockCartStore)(nil).RefreshCartExpiry), ctx, cartId, guestExpireAt, userExpireAt)
+}
+
+// SetItemQuantity mocks base method.
+func (m *MockCartStore) SetItemQuantity(ctx context.Context, cartId, itemId uuid.UUID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemQuantity", ctx, cartId, itemId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemQuantity indicates an expected call of SetItemQuantity.
+func (mr *MockCartStoreMockRecorder) SetItemQuantity(ctx, cartId, itemId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemQuantity", reflect.TypeOf((*MockCartStore)(nil).SetItemQuantity), ctx, cartId, itemId, quantity)
+}
+
+// SetPromoCode mocks base method.
+func (m *MockCartStore) SetPromoCode(ctx context.Context, cartId uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPromoCode", ctx, cartId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPromoCode indicates an expected call of SetPromoCode.
+func (mr *MockCartStoreMockRecorder) SetPromoCode(ctx, cartId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPromoCode", reflect.TypeOf((*MockCartStore)(nil).SetPromoCode), ctx, cartId, code)
+}
+
+// MockAbandonedCartStore is a mock of AbandonedCartStore interface.
+type MockAbandonedCartStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbandonedCartStoreMockRecorder
+}
+
+// MockAbandonedCartStoreMockRecorder is the mock recorder for MockAbandonedCartStore.
+type MockAbandonedCartStoreMockRecorder struct {
+	mock *MockAbandonedCartStore
+}
+
+// NewMockAbandonedCartStore creates a new mock instance.
+func NewMockAbandonedCartStore(ctrl *gomock.Controller) *MockAbandonedCartStore {
+	mock := &MockAbandonedCartStore{ctrl: ctrl}
+	mock.recorder = &MockAbandonedCartStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbandonedCartStore) EXPECT() *MockAbandonedCartStoreMockRecorder {
+	return m.recorder
+}
+
+// GetUnsentReminders mocks base method.
+func (m *MockAbandonedCartStore) GetUnsentReminders(ctx context.Context) ([]models.CartAbandonment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsentReminders", ctx)
+	ret0, _ := ret[0].([]models.CartAbandonment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnsentReminders indicates an expected call of GetUnsentReminders.
+func (mr *MockAbandonedCartStoreMockRecorder) GetUnsentReminders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsentReminders", reflect.TypeOf((*MockAbandonedCartStore)(nil).GetUnsentReminders), ctx)
+}
+
+// MarkReminderSent mocks base method.
+func (m *MockAbandonedCartStore) MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockAbandonedCartStoreMockRecorder) MarkReminderSent(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockAbandonedCartStore)(nil).MarkReminderSent), ctx, id, at)
+}
+
+// RecordAbandonedCarts mocks base method.
+func (m *MockAbandonedCartStore) RecordAbandonedCarts(ctx context.Context, idleBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAbandonedCarts", ctx, idleBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordAbandonedCarts indicates an expected call of RecordAbandonedCarts.
+func (mr *MockAbandonedCartStoreMockRecorder) RecordAbandonedCarts(ctx, idleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAbandonedCarts", reflect.TypeOf((*MockAbandonedCartStore)(nil).RecordAbandonedCarts), ctx, idleBefore)
+}
+
+// RecoverAbandonedCart mocks base method.
+func (m *MockAbandonedCartStore) RecoverAbandonedCart(ctx context.Context, cartId uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverAbandonedCart", ctx, cartId, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverAbandonedCart indicates an expected call of RecoverAbandonedCart.
+func (mr *MockAbandonedCartStoreMockRecorder) RecoverAbandonedCart(ctx, cartId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverAbandonedCart", reflect.TypeOf((*MockAbandonedCartStore)(nil).RecoverAbandonedCart), ctx, cartId, at)
+}
+
+// SetCartReminders mocks base method.
+func (m *MockAbandonedCartStore) SetCartReminders(ctx context.Context, userId uuid.UUID, enabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCartReminders", ctx, userId, enabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCartReminders indicates an expected call of SetCartReminders.
+func (mr *MockAbandonedCartStoreMockRecorder) SetCartReminders(ctx, userId, enabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCartReminders", reflect.TypeOf((*MockAbandonedCartStore)(nil).SetCartReminders), ctx, userId, enabled)
+}
+
 // MockOrderStore is a mock of OrderStore interface.
 type MockOrderStore struct {
 	ctrl     *gomock.Controller
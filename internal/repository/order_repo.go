@@ -3,6 +3,7 @@ package repository
 import (
 	"OnlineShopBackend/internal/models"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,11 @@ import (
 	"go.uber.org/zap"
 )
 
+// orderItemPriceColumns selects price and currency of the order line, lines
+// of orders placed before prices were kept have the current price of the item
+const orderItemPriceColumns = `COALESCE(order_items.price, items.price),
+	CASE WHEN order_items.price IS NULL THEN items.currency ELSE orders.currency END`
+
 type order struct {
 	storage *PGres
 	logger  *zap.SugaredLogger
@@ -51,18 +57,20 @@ func (o *order) Create(ctx context.Context, order *models.Order) (*models.Order,
 				}
 			}
 		}()
-		row := tx.QueryRow(ctx, `INSERT INTO orders (created_at, shipment_time, user_id, status, address) 
-		VALUES ($1, $2, $3, $4, $5) RETURNING id`, order.CreatedAt, order.ShipmentTime, order.User.ID, order.Status,
-			fmt.Sprintf("%s -> %s -> %s -> %s", order.Address.Zipcode, order.Address.Country, order.Address.City, order.Address.Street))
+		row := tx.QueryRow(ctx, `INSERT INTO orders (created_at, shipment_time, user_id, status, address, subtotal, total, currency,
+		shipping_method_id, shipping_method, shipping_price) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`, order.CreatedAt, order.ShipmentTime, order.User.ID, order.Status,
+			fmt.Sprintf("%s -> %s -> %s -> %s", order.Address.Zipcode, order.Address.Country, order.Address.City, order.Address.Street),
+			order.Subtotal, order.Total, order.Currency, nullUUID(order.ShippingMethodId), order.ShippingMethod, order.Shipping)
 		err = row.Scan(&order.ID)
 		if err != nil {
 			o.logger.Errorf("can't add new order: %w", err)
 			return nil, fmt.Errorf("can't add new order: %w", err)
 		}
-		query := `INSERT INTO order_items (order_id, item_id, item_quantity) VALUES`
+		query := `INSERT INTO order_items (order_id, item_id, item_quantity, price) VALUES`
 		itemsString := ""
 		for _, item := range order.Items {
-			itemsString += fmt.Sprintf("('%s', '%s', '%d'),", order.ID.String(), item.Id.String(), item.Quantity)
+			itemsString += fmt.Sprintf("('%s', '%s', '%d', '%d'),", order.ID.String(), item.Id.String(), item.Quantity, item.Price)
 		}
 		itemsString = itemsString[:len(itemsString)-1]
 		_, err = tx.Exec(ctx, fmt.Sprintf("%s %s;", query, itemsString))
@@ -70,10 +78,49 @@ func (o *order) Create(ctx context.Context, order *models.Order) (*models.Order,
 			o.logger.Errorf("can't add items to order: %s", err)
 			return nil, fmt.Errorf("can't add items to order: %w", err)
 		}
+		err = takeStock(ctx, tx, order)
+		if err != nil {
+			o.logger.Errorf("can't take items of order from stock: %s", err)
+			return nil, fmt.Errorf("can't take items of order from stock: %w", err)
+		}
+		err = usePromotions(ctx, tx, order)
+		if err != nil {
+			o.logger.Errorf("can't use promotions of order: %s", err)
+			return nil, fmt.Errorf("can't use promotions of order: %w", err)
+		}
+		err = saveOrderDiscounts(ctx, tx, order)
+		if err != nil {
+			o.logger.Errorf("can't add discounts to order: %s", err)
+			return nil, fmt.Errorf("can't add discounts to order: %w", err)
+		}
+		err = saveOrderTaxes(ctx, tx, order)
+		if err != nil {
+			o.logger.Errorf("can't add taxes to order: %s", err)
+			return nil, fmt.Errorf("can't add taxes to order: %w", err)
+		}
 		return order, nil
 	}
 }
 
+// takeStock decreases the stock of ordered items in the transaction. The
+// stock is checked by the update, which locks the item until the end of
+// transaction, so concurrent orders can't take more than the stock.
+// Untracked stock isn't changed, ErrItemUnavailable is returned for the
+// deleted item or the item without enough stock
+func takeStock(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	for _, item := range order.Items {
+		result, err := tx.Exec(ctx, `UPDATE items SET stock = stock - $2
+		WHERE id = $1 AND deleted_at IS NULL AND (stock IS NULL OR stock >= $2)`, item.Id, item.Quantity)
+		if err != nil {
+			return fmt.Errorf("can't update stock of item %v: %w", item.Id, err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("item %v: %w", item.Id, models.ErrItemUnavailable)
+		}
+	}
+	return nil
+}
+
 func (o *order) DeleteOrder(ctx context.Context, order *models.Order) error {
 	o.logger.Debug("Enter in repository DeleteOrder() with args: ctx, order: %v", order)
 	select {
@@ -96,6 +143,28 @@ func (o *order) DeleteOrder(ctx context.Context, order *models.Order) error {
 				}
 			}
 		}()
+		// The order is locked, so a payment or a return can't be added until it is deleted
+		var kept bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM payments WHERE payments.order_id = orders.id)
+			OR EXISTS (SELECT 1 FROM returns WHERE returns.order_id = orders.id)
+			OR EXISTS (SELECT 1 FROM refunds WHERE refunds.order_id = orders.id)
+		FROM orders WHERE id=$1 FOR UPDATE`, order.ID).Scan(&kept)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			o.logger.Errorf("can't get payments and returns of order: %s", err)
+			return fmt.Errorf("can't get payments and returns of order: %w", err)
+		}
+		if kept {
+			err = models.ErrOrderNotDeletable
+			return err
+		}
+		// Items of the deleted order are returned to the stock
+		_, err = tx.Exec(ctx, `UPDATE items SET stock = stock + ordered.quantity
+		FROM (SELECT item_id, SUM(item_quantity) AS quantity FROM order_items WHERE order_id=$1 GROUP BY item_id) AS ordered
+		WHERE items.id = ordered.item_id AND items.stock IS NOT NULL`, order.ID)
+		if err != nil {
+			o.logger.Errorf("can't return items of order to stock: %s", err)
+			return fmt.Errorf("can't return items of order to stock: %w", err)
+		}
 		_, err = tx.Exec(ctx, `DELETE FROM order_items WHERE order_id=$1`, order.ID)
 		if err != nil {
 			o.logger.Errorf("can't delete order items from order: %s", err)
@@ -109,6 +178,10 @@ func (o *order) DeleteOrder(ctx context.Context, order *models.Order) error {
 		return nil
 	}
 }
+
+// ChangeAddress saves the address of the order with the pricing and the
+// shipping of the order calculated for it. The order which payment is
+// started isn't changed, ErrOrderNotChangeable is returned for it
 func (o *order) ChangeAddress(ctx context.Context, order *models.Order, address models.UserAddress) error {
 	o.logger.Debug("Enter in repository order ChangeAddress() with args: ctx, order: %v, address: %v", order, address)
 	select {
@@ -116,12 +189,41 @@ func (o *order) ChangeAddress(ctx context.Context, order *models.Order, address
 		return fmt.Errorf("context closed")
 	default:
 		pool := o.storage.GetPool()
-		_, err := pool.Exec(ctx, `UPDATE orders SET address=$1 WHERE id=$2`,
-			fmt.Sprintf("%s -> %s -> %s -> %s", address.Zipcode, address.Country, address.City, address.Street), order.ID)
+		tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			o.logger.Errorf("can't create transaction: %s", err)
+			return fmt.Errorf("can't create transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+		result, err := tx.Exec(ctx, `UPDATE orders SET address=$1, total=$2, shipping_price=$3, shipping_method=$4, shipment_time=$5
+		WHERE id=$6 AND status IN ($7, $8) AND NOT EXISTS (
+			SELECT 1 FROM payments WHERE payments.order_id = orders.id AND payments.status IN ($9, $10, $11))`,
+			fmt.Sprintf("%s -> %s -> %s -> %s", address.Zipcode, address.Country, address.City, address.Street),
+			order.Total, order.Shipping, order.ShippingMethod, order.ShipmentTime, order.ID,
+			models.StatusCreated, models.StatusPaymentFailed,
+			models.PaymentPending, models.PaymentAuthorized, models.PaymentCaptured)
 		if err != nil {
 			o.logger.Errorf("can't update address: %s", err)
 			return fmt.Errorf("can't update address: %w", err)
 		}
+		if result.RowsAffected() == 0 {
+			return models.ErrOrderNotChangeable
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM order_taxes WHERE order_id=$1`, order.ID)
+		if err != nil {
+			o.logger.Errorf("can't delete taxes of order: %s", err)
+			return fmt.Errorf("can't delete taxes of order: %w", err)
+		}
+		err = saveOrderTaxes(ctx, tx, order)
+		if err != nil {
+			o.logger.Errorf("can't add taxes to order: %s", err)
+			return fmt.Errorf("can't add taxes to order: %w", err)
+		}
+		err = tx.Commit(ctx)
+		if err != nil {
+			o.logger.Errorf("can't commit: %s", err)
+			return fmt.Errorf("can't commit: %w", err)
+		}
 		return nil
 	}
 }
@@ -152,8 +254,9 @@ func (o *order) GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, e
 			Items: make([]models.ItemWithQuantity, 0),
 		}
 		rows, err := pool.Query(ctx, `SELECT items.id, items.name, categories.id, categories.name, categories.description, categories.picture,
-				items.description, items.price, items.vendor, items.pictures, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
-				orders.status, orders.address, order_items.item_quantity from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
+				items.description, `+orderItemPriceColumns+`, items.vendor, `+itemImagesColumn("items")+`, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
+				orders.status, orders.address, orders.currency, orders.subtotal, orders.total, COALESCE(orders.shipping_method_id, '00000000-0000-0000-0000-000000000000'),
+				orders.shipping_method, orders.shipping_price, `+orderDiscountsColumn("orders")+`, `+orderTaxesColumn("orders")+`, order_items.item_quantity from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
 				items.id=order_items.item_id INNER JOIN orders ON orders.id=order_items.order_id and orders.id = $1 ORDER BY order_id ASC`, id)
 		if err != nil {
 			o.logger.Errorf("can't get order from db: %s", err)
@@ -164,12 +267,16 @@ func (o *order) GetOrderByID(ctx context.Context, id uuid.UUID) (models.Order, e
 		for rows.Next() {
 			item := models.ItemWithQuantity{}
 			if err := rows.Scan(&item.Id, &item.Title, &item.Category.Id, &item.Category.Name, &item.Category.Description, &item.Category.Image,
-				&item.Description, &item.Price, &item.Vendor, &item.Images, &ordr.ID, &ordr.User.ID, &ordr.Status, &ordr.CreatedAt, &ordr.ShipmentTime, &ordr.Status, &address, &item.Quantity); err != nil {
+				&item.Description, &item.Price, &item.Currency, &item.Vendor, itemImages{&item.Images}, &ordr.ID, &ordr.User.ID, &ordr.Status, &ordr.CreatedAt, &ordr.ShipmentTime, &ordr.Status, &address,
+				&ordr.Currency, &ordr.Subtotal, &ordr.Total, &ordr.ShippingMethodId, &ordr.ShippingMethod, &ordr.Shipping, orderDiscounts{&ordr.Discounts}, orderTaxes{&ordr.Taxes}, &item.Quantity); err != nil {
 				o.logger.Errorf("can't scan data to order object: %w", err)
 				return models.Order{}, err
 			}
 			ordr.Items = append(ordr.Items, item)
 		}
+		if ordr.ID == uuid.Nil {
+			return models.Order{}, models.ErrorNotFound{}
+		}
 		o.logger.Debug(address)
 		splitted := strings.Split(address, " -> ")
 		ordr.Address = models.UserAddress{
@@ -195,8 +302,9 @@ func (o *order) GetOrdersForUser(ctx context.Context, user *models.User) (chan m
 		go func() {
 			defer close(resChan)
 			rows, err := pool.Query(ctx, `SELECT items.id, items.name, categories.id, categories.name, categories.description, categories.picture,
-			items.description, items.price, items.vendor, items.pictures, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
-			orders.status, orders.address, order_items.item_quantity from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
+			items.description, `+orderItemPriceColumns+`, items.vendor, `+itemImagesColumn("items")+`, orders.id, orders.user_id, orders.status, orders.created_at, orders.shipment_time,
+			orders.status, orders.address, orders.currency, orders.subtotal, orders.total, COALESCE(orders.shipping_method_id, '00000000-0000-0000-0000-000000000000'),
+			orders.shipping_method, orders.shipping_price, `+orderDiscountsColumn("orders")+`, `+orderTaxesColumn("orders")+`, order_items.item_quantity from items INNER JOIN categories ON categories.id=category  INNER JOIN order_items ON
 			items.id=order_items.item_id INNER JOIN orders ON orders.id=order_items.order_id and orders.user_id = $1 ORDER BY order_id ASC`, user.ID)
 			if err != nil {
 				o.logger.Errorf("can't get order from db: %s", err)
@@ -211,7 +319,8 @@ func (o *order) GetOrdersForUser(ctx context.Context, user *models.User) (chan m
 				item := models.ItemWithQuantity{}
 				order := models.Order{}
 				if err := rows.Scan(&item.Id, &item.Title, &item.Category.Id, &item.Category.Name, &item.Category.Description, &item.Category.Image,
-					&item.Description, &item.Price, &item.Vendor, &item.Images, &order.ID, &order.User.ID, &order.Status, &order.CreatedAt, &order.ShipmentTime, &order.Status, &address, &item.Quantity); err != nil {
+					&item.Description, &item.Price, &item.Currency, &item.Vendor, itemImages{&item.Images}, &order.ID, &order.User.ID, &order.Status, &order.CreatedAt, &order.ShipmentTime, &order.Status, &address,
+					&order.Currency, &order.Subtotal, &order.Total, &order.ShippingMethodId, &order.ShippingMethod, &order.Shipping, orderDiscounts{&order.Discounts}, orderTaxes{&order.Taxes}, &item.Quantity); err != nil {
 					o.logger.Errorf("can't scan data to order object: %w", err)
 					return
 				}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type paymentRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ PaymentStore = (*paymentRepo)(nil)
+
+func NewPaymentRepo(store *PGres, log *zap.SugaredLogger) PaymentStore {
+	return &paymentRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+const paymentColumns = `id, order_id, provider, provider_payment_id, amount, currency, status, refunded,
+	redirect_url, failure_reason, created_at, updated_at`
+
+// CreatePayment saves new payment of the order. ErrPaymentExists is returned
+// if the order already has a payment in progress or done
+func (repo *paymentRepo) CreatePayment(ctx context.Context, payment *models.Payment) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreatePayment() with args: ctx, payment: %v", payment)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO payments (order_id, provider, provider_payment_id, amount, currency, status)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (order_id) WHERE status IN ('pending', 'authorized', 'captured') DO NOTHING RETURNING id`,
+		payment.OrderId,
+		payment.Provider,
+		payment.ProviderPaymentId,
+		payment.Amount,
+		payment.Currency,
+		payment.Status,
+	)
+	err := row.Scan(&id)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return uuid.Nil, models.ErrPaymentExists
+	}
+	if err != nil {
+		repo.logger.Errorf("can't create payment: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create payment: %w", err)
+	}
+	repo.logger.Info("Payment create success")
+	return id, nil
+}
+
+// UpdatePayment saves state of the payment and changes status of the order
+// in one transaction. ErrPaymentChanged is returned if the payment or the
+// order was changed since they were read
+func (repo *paymentRepo) UpdatePayment(ctx context.Context, update models.PaymentUpdate) error {
+	repo.logger.Debugf("Enter in repository UpdatePayment() with args: ctx, update: %v", update)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	err = updatePayment(ctx, tx, update)
+	if err != nil {
+		repo.logger.Error(err.Error())
+		return err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		repo.logger.Errorf("can't commit payment: %s", err)
+		return fmt.Errorf("can't commit payment: %w", err)
+	}
+	return nil
+}
+
+// SavePaymentEvent saves the event of the provider with state of the payment
+// and status of the order it leads to in one transaction. False is returned
+// if the event was already handled, then nothing is changed. ErrPaymentChanged
+// is returned if the payment or the order was changed since they were read
+func (repo *paymentRepo) SavePaymentEvent(ctx context.Context, event models.PaymentEvent, update models.PaymentUpdate) (bool, error) {
+	repo.logger.Debugf("Enter in repository SavePaymentEvent() with args: ctx, event: %v, update: %v", event, update)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return false, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	tag, err := tx.Exec(ctx, `INSERT INTO payment_events (provider, event_id, payment_id, status, amount)
+	VALUES ($1, $2, $3, $4, $5) ON CONFLICT DO NOTHING`,
+		update.Payment.Provider, event.Id, update.Payment.Id, event.Status, event.Amount)
+	if err != nil {
+		repo.logger.Errorf("can't save payment event: %s", err)
+		return false, fmt.Errorf("can't save payment event: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	err = updatePayment(ctx, tx, update)
+	if err != nil {
+		repo.logger.Error(err.Error())
+		return false, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		repo.logger.Errorf("can't commit payment event: %s", err)
+		return false, fmt.Errorf("can't commit payment event: %w", err)
+	}
+	return true, nil
+}
+
+// GetPayment returns payment by id
+func (repo *paymentRepo) GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	repo.logger.Debugf("Enter in repository GetPayment() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+paymentColumns+` FROM payments WHERE id = $1`, id)
+	return repo.getPayment(row)
+}
+
+// GetPaymentByProviderId returns payment by its id in the provider
+func (repo *paymentRepo) GetPaymentByProviderId(ctx context.Context, provider string, providerPaymentId string) (*models.Payment, error) {
+	repo.logger.Debugf("Enter in repository GetPaymentByProviderId() with args: ctx, provider: %s, providerPaymentId: %s", provider, providerPaymentId)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+paymentColumns+` FROM payments WHERE provider = $1 AND provider_payment_id = $2`,
+		provider, providerPaymentId)
+	return repo.getPayment(row)
+}
+
+// GetOrderPayments returns payments of the order, newest first
+func (repo *paymentRepo) GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error) {
+	repo.logger.Debugf("Enter in repository GetOrderPayments() with args: ctx, orderId: %v", orderId)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+paymentColumns+` FROM payments WHERE order_id = $1
+	ORDER BY created_at DESC, id`, orderId)
+	if err != nil {
+		repo.logger.Errorf("can't get payments: %s", err)
+		return nil, fmt.Errorf("can't get payments: %w", err)
+	}
+	defer rows.Close()
+	payments := make([]models.Payment, 0)
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			repo.logger.Errorf("can't scan payment: %s", err)
+			return nil, fmt.Errorf("can't scan payment: %w", err)
+		}
+		payments = append(payments, *payment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get payments: %w", err)
+	}
+	return payments, nil
+}
+
+// GetOrderForPayment returns user, status, total and currency of the order
+// without its items
+func (repo *paymentRepo) GetOrderForPayment(ctx context.Context, orderId uuid.UUID) (*models.Order, error) {
+	repo.logger.Debugf("Enter in repository GetOrderForPayment() with args: ctx, orderId: %v", orderId)
+	pool := repo.storage.GetPool()
+	order := models.Order{}
+	row := pool.QueryRow(ctx, `SELECT id, user_id, status, total, currency FROM orders WHERE id = $1`, orderId)
+	err := row.Scan(&order.ID, &order.User.ID, &order.Status, &order.Total, &order.Currency)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get order: %s", err)
+		return nil, fmt.Errorf("can't get order: %w", err)
+	}
+	return &order, nil
+}
+
+func (repo *paymentRepo) getPayment(row pgx.Row) (*models.Payment, error) {
+	payment, err := scanPayment(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get payment: %s", err)
+		return nil, fmt.Errorf("can't get payment: %w", err)
+	}
+	return payment, nil
+}
+
+// updatePayment saves the update if the payment and the order are still
+// in the state they were read in
+func updatePayment(ctx context.Context, tx pgx.Tx, update models.PaymentUpdate) error {
+	payment := update.Payment
+	tag, err := tx.Exec(ctx, `UPDATE payments SET provider_payment_id = $1, status = $2, refunded = $3,
+	redirect_url = $4, failure_reason = $5, updated_at = now() WHERE id = $6 AND status = $7 AND refunded = $8`,
+		payment.ProviderPaymentId,
+		payment.Status,
+		payment.Refunded,
+		payment.RedirectURL,
+		payment.FailureReason,
+		payment.Id,
+		update.FromStatus,
+		update.FromRefunded,
+	)
+	if err != nil {
+		return fmt.Errorf("can't update payment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPaymentChanged
+	}
+	if update.OrderStatus == "" {
+		return nil
+	}
+	tag, err = tx.Exec(ctx, `UPDATE orders SET status = $1 WHERE id = $2 AND status = $3`,
+		update.OrderStatus, payment.OrderId, update.FromOrderStatus)
+	if err != nil {
+		return fmt.Errorf("can't update status of order: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPaymentChanged
+	}
+	return nil
+}
+
+func scanPayment(row promotionScanner) (*models.Payment, error) {
+	payment := models.Payment{}
+	err := row.Scan(
+		&payment.Id,
+		&payment.OrderId,
+		&payment.Provider,
+		&payment.ProviderPaymentId,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.Status,
+		&payment.Refunded,
+		&payment.RedirectURL,
+		&payment.FailureReason,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type promotionRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ PromotionStore = (*promotionRepo)(nil)
+
+func NewPromotionRepo(store *PGres, log *zap.SugaredLogger) PromotionStore {
+	return &promotionRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+const promotionColumns = `id, COALESCE(code, ''), name, kind, value, scope, COALESCE(scope_id, '00000000-0000-0000-0000-000000000000'),
+	min_order, usage_limit, per_user_limit, used, starts_at, ends_at, active, created_at`
+
+// CreatePromotion saves new promotion
+func (repo *promotionRepo) CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreatePromotion() with args: ctx, promotion: %v", promotion)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO promotions (code, name, kind, value, scope, scope_id, min_order,
+	usage_limit, per_user_limit, starts_at, ends_at, active)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`,
+		nullString(promotion.Code),
+		promotion.Name,
+		promotion.Kind,
+		promotion.Value,
+		promotion.Scope,
+		nullUUID(promotion.ScopeId),
+		promotion.MinOrder,
+		promotion.UsageLimit,
+		promotion.PerUserLimit,
+		nullTime(promotion.StartsAt),
+		nullTime(promotion.EndsAt),
+		promotion.Active,
+	)
+	err := row.Scan(&id)
+	if err != nil {
+		repo.logger.Errorf("can't create promotion: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create promotion: %w", err)
+	}
+	repo.logger.Info("Promotion create success")
+	return id, nil
+}
+
+// UpdatePromotion changes settings of the promotion, the number of usages is kept
+func (repo *promotionRepo) UpdatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	repo.logger.Debugf("Enter in repository UpdatePromotion() with args: ctx, promotion: %v", promotion)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE promotions SET code = $1, name = $2, kind = $3, value = $4, scope = $5, scope_id = $6,
+	min_order = $7, usage_limit = $8, per_user_limit = $9, starts_at = $10, ends_at = $11, active = $12
+	WHERE id = $13`,
+		nullString(promotion.Code),
+		promotion.Name,
+		promotion.Kind,
+		promotion.Value,
+		promotion.Scope,
+		nullUUID(promotion.ScopeId),
+		promotion.MinOrder,
+		promotion.UsageLimit,
+		promotion.PerUserLimit,
+		nullTime(promotion.StartsAt),
+		nullTime(promotion.EndsAt),
+		promotion.Active,
+		promotion.Id,
+	)
+	if err != nil {
+		repo.logger.Errorf("can't update promotion: %s", err)
+		return fmt.Errorf("can't update promotion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Info("Promotion update success")
+	return nil
+}
+
+// GetPromotion returns promotion by id
+func (repo *promotionRepo) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	repo.logger.Debugf("Enter in repository GetPromotion() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+promotionColumns+` FROM promotions WHERE id = $1`, id)
+	promotion, err := scanPromotion(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get promotion: %s", err)
+		return nil, fmt.Errorf("can't get promotion: %w", err)
+	}
+	return promotion, nil
+}
+
+// GetPromotionByCode returns promotion by promo code
+func (repo *promotionRepo) GetPromotionByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	repo.logger.Debugf("Enter in repository GetPromotionByCode() with args: ctx, code: %s", code)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+promotionColumns+` FROM promotions WHERE code = $1`, code)
+	promotion, err := scanPromotion(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get promotion: %s", err)
+		return nil, fmt.Errorf("can't get promotion: %w", err)
+	}
+	return promotion, nil
+}
+
+// GetPromotions returns all promotions, newest first
+func (repo *promotionRepo) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	repo.logger.Debug("Enter in repository GetPromotions()")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+promotionColumns+` FROM promotions ORDER BY created_at DESC, id`)
+	if err != nil {
+		repo.logger.Errorf("can't get promotions: %s", err)
+		return nil, fmt.Errorf("can't get promotions: %w", err)
+	}
+	return repo.scanPromotions(rows)
+}
+
+// GetAutomaticPromotions returns active promotions without promo code in
+// order they were created. Time and limits are checked by the caller
+func (repo *promotionRepo) GetAutomaticPromotions(ctx context.Context) ([]models.Promotion, error) {
+	repo.logger.Debug("Enter in repository GetAutomaticPromotions()")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+promotionColumns+` FROM promotions
+	WHERE code IS NULL AND active ORDER BY created_at, id`)
+	if err != nil {
+		repo.logger.Errorf("can't get automatic promotions: %s", err)
+		return nil, fmt.Errorf("can't get automatic promotions: %w", err)
+	}
+	return repo.scanPromotions(rows)
+}
+
+// DeletePromotion deletes promotion, discounts of placed orders are kept
+func (repo *promotionRepo) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeletePromotion() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM promotions WHERE id = $1`, id)
+	if err != nil {
+		repo.logger.Errorf("can't delete promotion: %s", err)
+		return fmt.Errorf("can't delete promotion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Infof("Promotion %v deleted", id)
+	return nil
+}
+
+// CountUserUsages returns the number of orders of the user with the promotion
+func (repo *promotionRepo) CountUserUsages(ctx context.Context, promotionId uuid.UUID, userId uuid.UUID) (int, error) {
+	repo.logger.Debugf("Enter in repository CountUserUsages() with args: ctx, promotionId: %v, userId: %v", promotionId, userId)
+	pool := repo.storage.GetPool()
+	var count int
+	row := pool.QueryRow(ctx, `SELECT COUNT(*) FROM promotion_usages WHERE promotion_id = $1 AND user_id = $2`, promotionId, userId)
+	err := row.Scan(&count)
+	if err != nil {
+		repo.logger.Errorf("can't count usages of promotion: %s", err)
+		return 0, fmt.Errorf("can't count usages of promotion: %w", err)
+	}
+	return count, nil
+}
+
+// usePromotions saves usages of discounts of the order in the transaction.
+// The usage limit is checked by the update, which locks the promotion
+// until the end of transaction, so the same promotion is not overused
+// by concurrent orders
+func usePromotions(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	for _, discount := range order.Discounts {
+		var perUserLimit int
+		row := tx.QueryRow(ctx, `UPDATE promotions SET used = used + 1
+		WHERE id = $1 AND (usage_limit = 0 OR used < usage_limit)
+		RETURNING per_user_limit`, discount.PromotionId)
+		err := row.Scan(&perUserLimit)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			return models.ErrPromoLimitReached
+		}
+		if err != nil {
+			return fmt.Errorf("can't use promotion %v: %w", discount.PromotionId, err)
+		}
+		if perUserLimit > 0 {
+			var count int
+			row = tx.QueryRow(ctx, `SELECT COUNT(*) FROM promotion_usages WHERE promotion_id = $1 AND user_id = $2`,
+				discount.PromotionId, order.User.ID)
+			err = row.Scan(&count)
+			if err != nil {
+				return fmt.Errorf("can't count usages of promotion %v: %w", discount.PromotionId, err)
+			}
+			if count >= perUserLimit {
+				return models.ErrPromoLimitReached
+			}
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO promotion_usages (promotion_id, order_id, user_id) VALUES ($1, $2, $3)`,
+			discount.PromotionId, order.ID, order.User.ID)
+		if err != nil {
+			return fmt.Errorf("can't save usage of promotion %v: %w", discount.PromotionId, err)
+		}
+	}
+	return nil
+}
+
+// saveOrderDiscounts saves the discount breakdown of the order in the transaction
+func saveOrderDiscounts(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	for position, discount := range order.Discounts {
+		_, err := tx.Exec(ctx, `INSERT INTO order_discounts (order_id, promotion_id, code, name, amount, position)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+			order.ID,
+			nullUUID(discount.PromotionId),
+			discount.Code,
+			discount.Name,
+			discount.Amount,
+			position,
+		)
+		if err != nil {
+			return fmt.Errorf("can't save discount %s of order: %w", discount.Name, err)
+		}
+	}
+	return nil
+}
+
+// orderDiscountsColumn returns expression selecting discounts of the order
+// with given alias of orders table as json array in order they were applied
+func orderDiscountsColumn(alias string) string {
+	return `(SELECT COALESCE(json_agg(json_build_object(
+		'PromotionId', COALESCE(od.promotion_id, '00000000-0000-0000-0000-000000000000'),
+		'Code', od.code, 'Name', od.name, 'Amount', od.amount)
+		ORDER BY od.position), '[]')
+		FROM order_discounts od WHERE od.order_id = ` + alias + `.id)`
+}
+
+// orderDiscounts scans discounts selected by orderDiscountsColumn
+type orderDiscounts struct {
+	discounts *[]models.AppliedDiscount
+}
+
+func (dst orderDiscounts) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*dst.discounts = nil
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("can't scan discounts of order from %T", src)
+	}
+	discounts := make([]models.AppliedDiscount, 0)
+	err := json.Unmarshal(data, &discounts)
+	if err != nil {
+		return fmt.Errorf("can't decode discounts of order: %w", err)
+	}
+	*dst.discounts = discounts
+	return nil
+}
+
+// promotionScanner is implemented by both pgx.Row and pgx.Rows
+type promotionScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPromotion(row promotionScanner) (*models.Promotion, error) {
+	promotion := models.Promotion{}
+	var startsAt, endsAt *time.Time
+	err := row.Scan(
+		&promotion.Id,
+		&promotion.Code,
+		&promotion.Name,
+		&promotion.Kind,
+		&promotion.Value,
+		&promotion.Scope,
+		&promotion.ScopeId,
+		&promotion.MinOrder,
+		&promotion.UsageLimit,
+		&promotion.PerUserLimit,
+		&promotion.Used,
+		&startsAt,
+		&endsAt,
+		&promotion.Active,
+		&promotion.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if startsAt != nil {
+		promotion.StartsAt = *startsAt
+	}
+	if endsAt != nil {
+		promotion.EndsAt = *endsAt
+	}
+	return &promotion, nil
+}
+
+func (repo *promotionRepo) scanPromotions(rows pgx.Rows) ([]models.Promotion, error) {
+	defer rows.Close()
+	promotions := make([]models.Promotion, 0)
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			repo.logger.Errorf("can't scan promotion: %s", err)
+			return nil, fmt.Errorf("can't scan promotion: %w", err)
+		}
+		promotions = append(promotions, *promotion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get promotions: %w", err)
+	}
+	return promotions, nil
+}
+
+func nullString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func nullUUID(value uuid.UUID) *uuid.UUID {
+	if value == uuid.Nil {
+		return nil
+	}
+	return &value
+}
+
+func nullTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
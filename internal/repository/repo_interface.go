@@ -3,6 +3,7 @@ package repository
 import (
 	"OnlineShopBackend/internal/models"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -23,6 +24,25 @@ type ItemStore interface {
 	ItemsByCategoryQuantity(ctx context.Context, categoryName string) (int, error)
 	ItemsInSearchQuantity(ctx context.Context, searchRequest string) (int, error)
 	ItemsInFavouriteQuantity(ctx context.Context, userId uuid.UUID) (int, error)
+	GetItemIdBySku(ctx context.Context, sku string) (uuid.UUID, error)
+	UpsertItemBySku(ctx context.Context, item *models.Item) (uuid.UUID, bool, error)
+	SetItemStock(ctx context.Context, id uuid.UUID, stock *int) error
+	SetQuantityLimits(ctx context.Context, id uuid.UUID, limits models.QuantityLimits) error
+}
+
+type WishlistStore interface {
+	CreateWishlist(ctx context.Context, wishlist *models.Wishlist) (uuid.UUID, error)
+	GetWishlists(ctx context.Context, userId uuid.UUID) ([]models.Wishlist, error)
+	GetWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Wishlist, error)
+	GetWishlistByToken(ctx context.Context, token string) (*models.Wishlist, error)
+	RenameWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID, name string) error
+	DeleteWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	SetShareToken(ctx context.Context, userId uuid.UUID, id uuid.UUID, token string) error
+	GetWishlistItems(ctx context.Context, userId uuid.UUID, id uuid.UUID) ([]models.Item, error)
+	HasWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) (bool, error)
+	AddWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error
+	DeleteWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error
+	MoveWishlistItem(ctx context.Context, userId uuid.UUID, fromId uuid.UUID, toId uuid.UUID, itemId uuid.UUID) error
 }
 
 type CategoryStore interface {
@@ -34,9 +54,105 @@ type CategoryStore interface {
 	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
 }
 
+type UploadStore interface {
+	CreateUpload(ctx context.Context, session *models.UploadSession) (uuid.UUID, error)
+	GetUpload(ctx context.Context, id uuid.UUID) (*models.UploadSession, error)
+	UpdateUploadStatus(ctx context.Context, id uuid.UUID, from []models.UploadStatus, to models.UploadStatus) error
+	DeleteExpiredUploads(ctx context.Context, before time.Time) ([]models.UploadSession, error)
+}
+
+type ImageStore interface {
+	GetImageRefs(ctx context.Context) ([]models.ImageRef, error)
+	DeleteImageRef(ctx context.Context, ref models.ImageRef) error
+}
+
+type ReviewStore interface {
+	IsBuyer(ctx context.Context, userId uuid.UUID, itemId uuid.UUID) (bool, error)
+	SaveReview(ctx context.Context, review *models.Review) (uuid.UUID, error)
+	GetReview(ctx context.Context, id uuid.UUID) (*models.Review, error)
+	GetItemReviews(ctx context.Context, itemId uuid.UUID, status models.ReviewStatus, offset, limit int) ([]models.Review, error)
+	GetReviewsByStatus(ctx context.Context, status models.ReviewStatus, offset, limit int) ([]models.Review, error)
+	ChangeReviewStatus(ctx context.Context, id uuid.UUID, status models.ReviewStatus) error
+}
+
+type PromotionStore interface {
+	CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error)
+	UpdatePromotion(ctx context.Context, promotion *models.Promotion) error
+	GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error)
+	GetPromotionByCode(ctx context.Context, code string) (*models.Promotion, error)
+	GetPromotions(ctx context.Context) ([]models.Promotion, error)
+	GetAutomaticPromotions(ctx context.Context) ([]models.Promotion, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+	CountUserUsages(ctx context.Context, promotionId uuid.UUID, userId uuid.UUID) (int, error)
+}
+
+type CurrencyStore interface {
+	GetRates(ctx context.Context) ([]models.ExchangeRate, error)
+	SetRate(ctx context.Context, rate *models.ExchangeRate) error
+	DeleteRate(ctx context.Context, currency string) error
+}
+
+type TaxStore interface {
+	CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error)
+	UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error
+	GetTaxRules(ctx context.Context) ([]models.TaxRule, error)
+	GetTaxRulesByCountry(ctx context.Context, country string) ([]models.TaxRule, error)
+	DeleteTaxRule(ctx context.Context, id uuid.UUID) error
+	SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error
+	SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error
+	GetTaxClasses(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]string, error)
+}
+
+type ShippingStore interface {
+	CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error)
+	UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error
+	GetShippingMethod(ctx context.Context, id uuid.UUID) (*models.ShippingMethod, error)
+	GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error)
+	GetActiveShippingMethods(ctx context.Context) ([]models.ShippingMethod, error)
+	DeleteShippingMethod(ctx context.Context, id uuid.UUID) error
+	SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error
+	GetItemWeights(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]int64, error)
+}
+
+type PaymentStore interface {
+	CreatePayment(ctx context.Context, payment *models.Payment) (uuid.UUID, error)
+	UpdatePayment(ctx context.Context, update models.PaymentUpdate) error
+	SavePaymentEvent(ctx context.Context, event models.PaymentEvent, update models.PaymentUpdate) (bool, error)
+	GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error)
+	GetPaymentByProviderId(ctx context.Context, provider string, providerPaymentId string) (*models.Payment, error)
+	GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error)
+	GetOrderForPayment(ctx context.Context, orderId uuid.UUID) (*models.Order, error)
+}
+
+type ReturnStore interface {
+	CreateReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error)
+	GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error)
+	GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error)
+	GetReturns(ctx context.Context, status models.ReturnStatus) ([]models.Return, error)
+	ChangeReturnStatus(ctx context.Context, id uuid.UUID, from models.ReturnStatus, to models.ReturnStatus, comment string) error
+	ReceiveReturn(ctx context.Context, id uuid.UUID) error
+	CreateRefund(ctx context.Context, refund *models.Refund) (uuid.UUID, error)
+	GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]models.Refund, error)
+}
+
+type ShipmentStore interface {
+	CreateShipment(ctx context.Context, shipment *models.Shipment) (uuid.UUID, error)
+	GetShipment(ctx context.Context, id uuid.UUID) (*models.Shipment, error)
+	GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]models.Shipment, error)
+	GetTrackedShipments(ctx context.Context, carrier string) ([]models.Shipment, error)
+	UpdateShipment(ctx context.Context, shipment *models.Shipment, orderStatus models.Status) error
+	DeleteShipment(ctx context.Context, id uuid.UUID) error
+}
+
+type InvoiceStore interface {
+	GetInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, error)
+	SaveInvoice(ctx context.Context, invoice *models.Invoice) error
+}
+
 type UserStore interface {
 	Create(ctx context.Context, user *models.User) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserById(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetRightsId(ctx context.Context, name string) (models.Rights, error)
 	UpdateUserData(ctx context.Context, id uuid.UUID, user *models.User) (*models.User, error)
 	SaveSession(ctx context.Context, token string, t int64) error
@@ -52,6 +168,23 @@ type CartStore interface {
 	DeleteItemFromCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error
 	GetCart(ctx context.Context, cartId uuid.UUID) (*models.Cart, error)
 	GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.Cart, error)
+	SetPromoCode(ctx context.Context, cartId uuid.UUID, code string) error
+	MergeCarts(ctx context.Context, fromCartId uuid.UUID, toCartId uuid.UUID) error
+	SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error
+	DecrementItem(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, decrement int, minQuantity int) (int, error)
+	GetQuantityLimits(ctx context.Context, itemId uuid.UUID) (models.QuantityLimits, error)
+	RefreshCartExpiry(ctx context.Context, cartId uuid.UUID, guestExpireAt time.Time, userExpireAt time.Time) error
+	DeleteExpiredCarts(ctx context.Context, before time.Time) (int, error)
+	GetItemsAvailability(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]models.ItemAvailability, error)
+	AddItemsToCart(ctx context.Context, cartId uuid.UUID, quantities map[uuid.UUID]int) error
+}
+
+type AbandonedCartStore interface {
+	RecordAbandonedCarts(ctx context.Context, idleBefore time.Time) (int, error)
+	GetUnsentReminders(ctx context.Context) ([]models.CartAbandonment, error)
+	MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error
+	RecoverAbandonedCart(ctx context.Context, cartId uuid.UUID, at time.Time) (bool, error)
+	SetCartReminders(ctx context.Context, userId uuid.UUID, enabled bool) error
 }
 
 type OrderStore interface {
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type returnRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ReturnStore = (*returnRepo)(nil)
+
+func NewReturnRepo(store *PGres, log *zap.SugaredLogger) ReturnStore {
+	return &returnRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+const returnColumns = `r.id, r.order_id, r.user_id, r.item_id, i.name, r.quantity, r.reason, r.status,
+	r.comment, r.created_at, r.updated_at`
+
+const refundColumns = `id, order_id, COALESCE(return_id, '00000000-0000-0000-0000-000000000000'),
+	COALESCE(payment_id, '00000000-0000-0000-0000-000000000000'), amount, currency, created_at`
+
+// CreateReturn saves new return request of the line of the order. The order
+// is locked until the request is saved and the quantity is checked by the
+// insert, so concurrent requests can't return more than was ordered,
+// ErrReturnNotAllowed is returned then
+func (repo *returnRepo) CreateReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateReturn() with args: ctx, ret: %v", ret)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	_, err = tx.Exec(ctx, `SELECT id FROM orders WHERE id = $1 FOR UPDATE`, ret.OrderId)
+	if err != nil {
+		repo.logger.Errorf("can't lock order of return: %s", err)
+		return uuid.Nil, fmt.Errorf("can't lock order of return: %w", err)
+	}
+	var id uuid.UUID
+	row := tx.QueryRow(ctx, `INSERT INTO returns (order_id, user_id, item_id, quantity, reason, status)
+	SELECT $1, $2, $3, $4, $5, $6
+	WHERE $4 <= (SELECT COALESCE(SUM(item_quantity), 0) FROM order_items WHERE order_id = $1 AND item_id = $3)
+		- (SELECT COALESCE(SUM(quantity), 0) FROM returns WHERE order_id = $1 AND item_id = $3 AND status <> $7)
+	RETURNING id`,
+		ret.OrderId,
+		ret.UserId,
+		ret.ItemId,
+		ret.Quantity,
+		ret.Reason,
+		ret.Status,
+		models.ReturnRejected,
+	)
+	err = row.Scan(&id)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return uuid.Nil, fmt.Errorf("%w: quantity is already returned", models.ErrReturnNotAllowed)
+	}
+	if err != nil {
+		repo.logger.Errorf("can't create return: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create return: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		repo.logger.Errorf("can't commit return: %s", err)
+		return uuid.Nil, fmt.Errorf("can't commit return: %w", err)
+	}
+	repo.logger.Info("Return create success")
+	return id, nil
+}
+
+// GetReturn returns the return request by id
+func (repo *returnRepo) GetReturn(ctx context.Context, id uuid.UUID) (*models.Return, error) {
+	repo.logger.Debugf("Enter in repository GetReturn() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+returnColumns+` FROM returns r
+	INNER JOIN items i ON i.id = r.item_id WHERE r.id = $1`, id)
+	ret, err := scanReturn(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get return: %s", err)
+		return nil, fmt.Errorf("can't get return: %w", err)
+	}
+	return ret, nil
+}
+
+// GetOrderReturns returns return requests of the order in order of creation
+func (repo *returnRepo) GetOrderReturns(ctx context.Context, orderId uuid.UUID) ([]models.Return, error) {
+	repo.logger.Debugf("Enter in repository GetOrderReturns() with args: ctx, orderId: %v", orderId)
+	return repo.getReturns(ctx, `SELECT `+returnColumns+` FROM returns r
+	INNER JOIN items i ON i.id = r.item_id WHERE r.order_id = $1
+	ORDER BY r.created_at, r.id`, orderId)
+}
+
+// GetReturns returns return requests in the status, oldest first.
+// All requests are returned for empty status
+func (repo *returnRepo) GetReturns(ctx context.Context, status models.ReturnStatus) ([]models.Return, error) {
+	repo.logger.Debugf("Enter in repository GetReturns() with args: ctx, status: %s", status)
+	return repo.getReturns(ctx, `SELECT `+returnColumns+` FROM returns r
+	INNER JOIN items i ON i.id = r.item_id WHERE $1 = '' OR r.status = $1
+	ORDER BY r.created_at, r.id`, string(status))
+}
+
+// ChangeReturnStatus changes status of the return if it is in the from status,
+// otherwise models.ErrReturnNotAllowed is returned
+func (repo *returnRepo) ChangeReturnStatus(ctx context.Context, id uuid.UUID, from models.ReturnStatus, to models.ReturnStatus, comment string) error {
+	repo.logger.Debugf("Enter in repository ChangeReturnStatus() with args: ctx, id: %v, from: %s, to: %s, comment: %s", id, from, to, comment)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE returns SET status = $3, comment = $4, updated_at = now()
+	WHERE id = $1 AND status = $2`, id, from, to, comment)
+	if err != nil {
+		repo.logger.Errorf("can't change status of return: %s", err)
+		return fmt.Errorf("can't change status of return: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: return isn't %s", models.ErrReturnNotAllowed, from)
+	}
+	return nil
+}
+
+// ReceiveReturn marks the approved return as received and adds returned
+// quantity to the stock of the item, untracked stock isn't changed
+func (repo *returnRepo) ReceiveReturn(ctx context.Context, id uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository ReceiveReturn() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	var itemId uuid.UUID
+	var quantity int
+	row := tx.QueryRow(ctx, `UPDATE returns SET status = $2, updated_at = now()
+	WHERE id = $1 AND status = $3 RETURNING item_id, quantity`, id, models.ReturnReceived, models.ReturnApproved)
+	err = row.Scan(&itemId, &quantity)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return fmt.Errorf("%w: return isn't %s", models.ErrReturnNotAllowed, models.ReturnApproved)
+	}
+	if err != nil {
+		repo.logger.Errorf("can't receive return: %s", err)
+		return fmt.Errorf("can't receive return: %w", err)
+	}
+	_, err = tx.Exec(ctx, `UPDATE items SET stock = stock + $2 WHERE id = $1 AND stock IS NOT NULL`, itemId, quantity)
+	if err != nil {
+		repo.logger.Errorf("can't restock returned item: %s", err)
+		return fmt.Errorf("can't restock returned item: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		repo.logger.Errorf("can't commit receiving of return: %s", err)
+		return fmt.Errorf("can't commit receiving of return: %w", err)
+	}
+	return nil
+}
+
+// CreateRefund saves the refund of the return being refunded and marks
+// the return as refunded, time of creation is set to the refund
+func (repo *returnRepo) CreateRefund(ctx context.Context, refund *models.Refund) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateRefund() with args: ctx, refund: %v", refund)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	tag, err := tx.Exec(ctx, `UPDATE returns SET status = $2, updated_at = now()
+	WHERE id = $1 AND status = $3`, refund.ReturnId, models.ReturnRefunded, models.ReturnRefunding)
+	if err != nil {
+		repo.logger.Errorf("can't refund return: %s", err)
+		return uuid.Nil, fmt.Errorf("can't refund return: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return uuid.Nil, fmt.Errorf("%w: return isn't %s", models.ErrReturnNotAllowed, models.ReturnRefunding)
+	}
+	var id uuid.UUID
+	row := tx.QueryRow(ctx, `INSERT INTO refunds (order_id, return_id, payment_id, amount, currency)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`,
+		refund.OrderId,
+		refund.ReturnId,
+		nullUUID(refund.PaymentId),
+		refund.Amount,
+		refund.Currency,
+	)
+	if err = row.Scan(&id, &refund.CreatedAt); err != nil {
+		repo.logger.Errorf("can't create refund: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create refund: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		repo.logger.Errorf("can't commit refund: %s", err)
+		return uuid.Nil, fmt.Errorf("can't commit refund: %w", err)
+	}
+	repo.logger.Info("Refund create success")
+	return id, nil
+}
+
+// GetOrderRefunds returns refunds of the order in order of creation
+func (repo *returnRepo) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]models.Refund, error) {
+	repo.logger.Debugf("Enter in repository GetOrderRefunds() with args: ctx, orderId: %v", orderId)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+refundColumns+` FROM refunds WHERE order_id = $1
+	ORDER BY created_at, id`, orderId)
+	if err != nil {
+		repo.logger.Errorf("can't get refunds: %s", err)
+		return nil, fmt.Errorf("can't get refunds: %w", err)
+	}
+	defer rows.Close()
+	refunds := make([]models.Refund, 0)
+	for rows.Next() {
+		var refund models.Refund
+		err := rows.Scan(
+			&refund.Id,
+			&refund.OrderId,
+			&refund.ReturnId,
+			&refund.PaymentId,
+			&refund.Amount,
+			&refund.Currency,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			repo.logger.Errorf("can't scan refund: %s", err)
+			return nil, fmt.Errorf("can't scan refund: %w", err)
+		}
+		refunds = append(refunds, refund)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get refunds: %w", err)
+	}
+	return refunds, nil
+}
+
+func (repo *returnRepo) getReturns(ctx context.Context, query string, args ...interface{}) ([]models.Return, error) {
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		repo.logger.Errorf("can't get returns: %s", err)
+		return nil, fmt.Errorf("can't get returns: %w", err)
+	}
+	defer rows.Close()
+	returns := make([]models.Return, 0)
+	for rows.Next() {
+		ret, err := scanReturn(rows)
+		if err != nil {
+			repo.logger.Errorf("can't scan return: %s", err)
+			return nil, fmt.Errorf("can't scan return: %w", err)
+		}
+		returns = append(returns, *ret)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get returns: %w", err)
+	}
+	return returns, nil
+}
+
+func scanReturn(row promotionScanner) (*models.Return, error) {
+	ret := models.Return{}
+	err := row.Scan(
+		&ret.Id,
+		&ret.OrderId,
+		&ret.UserId,
+		&ret.ItemId,
+		&ret.Title,
+		&ret.Quantity,
+		&ret.Reason,
+		&ret.Status,
+		&ret.Comment,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
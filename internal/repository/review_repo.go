@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type reviewRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ReviewStore = (*reviewRepo)(nil)
+
+func NewReviewRepo(store *PGres, log *zap.SugaredLogger) ReviewStore {
+	return &reviewRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// itemRatingColumns returns expressions selecting average rating and quantity
+// of approved reviews of the item with given alias of items table
+func itemRatingColumns(alias string) string {
+	return `(SELECT COALESCE(AVG(rv.rating), 0)::float8 FROM reviews rv WHERE rv.item_id = ` + alias + `.id AND rv.status = 'approved'),
+		(SELECT COUNT(*) FROM reviews rv WHERE rv.item_id = ` + alias + `.id AND rv.status = 'approved')`
+}
+
+// IsBuyer checks whether the user has an order containing the item
+func (repo *reviewRepo) IsBuyer(ctx context.Context, userId uuid.UUID, itemId uuid.UUID) (bool, error) {
+	repo.logger.Debugf("Enter in repository IsBuyer() with args: ctx, userId: %v, itemId: %v", userId, itemId)
+	pool := repo.storage.GetPool()
+	var isBuyer bool
+	row := pool.QueryRow(ctx, `SELECT EXISTS (
+		SELECT 1 FROM order_items
+		INNER JOIN orders ON orders.id = order_items.order_id
+		WHERE orders.user_id = $1 AND order_items.item_id = $2)`, userId, itemId)
+	err := row.Scan(&isBuyer)
+	if err != nil {
+		repo.logger.Errorf("can't check orders of user: %s", err)
+		return false, fmt.Errorf("can't check orders of user: %w", err)
+	}
+	return isBuyer, nil
+}
+
+// SaveReview creates review of the item or replaces the previous review of
+// the same user, the saved review waits for moderation again
+func (repo *reviewRepo) SaveReview(ctx context.Context, review *models.Review) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository SaveReview() with args: ctx, review: %v", review)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO reviews (item_id, user_id, rating, text, status)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (item_id, user_id) DO UPDATE SET
+	rating = EXCLUDED.rating,
+	text = EXCLUDED.text,
+	status = EXCLUDED.status,
+	updated_at = now()
+	RETURNING id`,
+		review.ItemId,
+		review.UserId,
+		review.Rating,
+		review.Text,
+		review.Status,
+	)
+	err := row.Scan(&id)
+	if err != nil {
+		repo.logger.Errorf("can't save review: %s", err)
+		return uuid.Nil, fmt.Errorf("can't save review: %w", err)
+	}
+	repo.logger.Info("Review save success")
+	return id, nil
+}
+
+// GetReview returns review by id
+func (repo *reviewRepo) GetReview(ctx context.Context, id uuid.UUID) (*models.Review, error) {
+	repo.logger.Debugf("Enter in repository GetReview() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT reviews.id, item_id, user_id, users.name, rating, text, status, created_at, updated_at
+	FROM reviews INNER JOIN users ON users.id = reviews.user_id
+	WHERE reviews.id = $1`, id)
+	review, err := scanReview(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get review: %s", err)
+		return nil, fmt.Errorf("can't get review: %w", err)
+	}
+	return review, nil
+}
+
+// GetItemReviews returns reviews of the item with given status, newest first
+func (repo *reviewRepo) GetItemReviews(ctx context.Context, itemId uuid.UUID, status models.ReviewStatus, offset, limit int) ([]models.Review, error) {
+	repo.logger.Debugf("Enter in repository GetItemReviews() with args: ctx, itemId: %v, status: %s, offset: %d, limit: %d", itemId, status, offset, limit)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT reviews.id, item_id, user_id, users.name, rating, text, status, created_at, updated_at
+	FROM reviews INNER JOIN users ON users.id = reviews.user_id
+	WHERE item_id = $1 AND status = $2
+	ORDER BY created_at DESC, reviews.id
+	OFFSET $3 LIMIT $4`, itemId, status, offset, limit)
+	if err != nil {
+		repo.logger.Errorf("can't get reviews of item: %s", err)
+		return nil, fmt.Errorf("can't get reviews of item: %w", err)
+	}
+	return repo.scanReviews(rows)
+}
+
+// GetReviewsByStatus returns reviews of all items with given status, oldest
+// first, so the moderator sees reviews in order they were written
+func (repo *reviewRepo) GetReviewsByStatus(ctx context.Context, status models.ReviewStatus, offset, limit int) ([]models.Review, error) {
+	repo.logger.Debugf("Enter in repository GetReviewsByStatus() with args: ctx, status: %s, offset: %d, limit: %d", status, offset, limit)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT reviews.id, item_id, user_id, users.name, rating, text, status, created_at, updated_at
+	FROM reviews INNER JOIN users ON users.id = reviews.user_id
+	WHERE status = $1
+	ORDER BY updated_at, reviews.id
+	OFFSET $2 LIMIT $3`, status, offset, limit)
+	if err != nil {
+		repo.logger.Errorf("can't get reviews: %s", err)
+		return nil, fmt.Errorf("can't get reviews: %w", err)
+	}
+	return repo.scanReviews(rows)
+}
+
+// ChangeReviewStatus changes status of the review
+func (repo *reviewRepo) ChangeReviewStatus(ctx context.Context, id uuid.UUID, status models.ReviewStatus) error {
+	repo.logger.Debugf("Enter in repository ChangeReviewStatus() with args: ctx, id: %v, status: %s", id, status)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE reviews SET status = $1 WHERE id = $2`, status, id)
+	if err != nil {
+		repo.logger.Errorf("can't change status of review: %s", err)
+		return fmt.Errorf("can't change status of review: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Infof("Status of review %v changed to %s", id, status)
+	return nil
+}
+
+// reviewScanner is implemented by both pgx.Row and pgx.Rows
+type reviewScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row reviewScanner) (*models.Review, error) {
+	review := models.Review{}
+	err := row.Scan(
+		&review.Id,
+		&review.ItemId,
+		&review.UserId,
+		&review.UserName,
+		&review.Rating,
+		&review.Text,
+		&review.Status,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (repo *reviewRepo) scanReviews(rows pgx.Rows) ([]models.Review, error) {
+	defer rows.Close()
+	reviews := make([]models.Review, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			repo.logger.Errorf("can't scan review: %s", err)
+			return nil, fmt.Errorf("can't scan review: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get reviews: %w", err)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type shipmentRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ShipmentStore = (*shipmentRepo)(nil)
+
+func NewShipmentRepo(store *PGres, log *zap.SugaredLogger) ShipmentStore {
+	return &shipmentRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+const shipmentColumns = `s.id, s.order_id, s.carrier, s.tracking_number, s.status, s.created_at, s.updated_at,
+	s.shipped_at, s.delivered_at, (SELECT COALESCE(json_agg(json_build_object(
+		'ItemId', si.item_id, 'Title', i.name, 'Quantity', si.quantity) ORDER BY i.name), '[]')
+		FROM shipment_items si INNER JOIN items i ON i.id = si.item_id WHERE si.shipment_id = s.id)`
+
+// CreateShipment saves new shipment with its lines
+func (repo *shipmentRepo) CreateShipment(ctx context.Context, shipment *models.Shipment) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateShipment() with args: ctx, shipment: %v", shipment)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	var id uuid.UUID
+	row := tx.QueryRow(ctx, `INSERT INTO shipments (order_id, carrier, status) VALUES ($1, $2, $3) RETURNING id`,
+		shipment.OrderId,
+		shipment.Carrier,
+		shipment.Status,
+	)
+	if err = row.Scan(&id); err != nil {
+		repo.logger.Errorf("can't create shipment: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create shipment: %w", err)
+	}
+	for _, line := range shipment.Lines {
+		_, err = tx.Exec(ctx, `INSERT INTO shipment_items (shipment_id, item_id, quantity) VALUES ($1, $2, $3)`,
+			id, line.ItemId, line.Quantity)
+		if err != nil {
+			repo.logger.Errorf("can't add line to shipment: %s", err)
+			return uuid.Nil, fmt.Errorf("can't add line to shipment: %w", err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		repo.logger.Errorf("can't commit shipment: %s", err)
+		return uuid.Nil, fmt.Errorf("can't commit shipment: %w", err)
+	}
+	repo.logger.Info("Shipment create success")
+	return id, nil
+}
+
+// GetShipment returns the shipment with its lines by id
+func (repo *shipmentRepo) GetShipment(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	repo.logger.Debugf("Enter in repository GetShipment() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+shipmentColumns+` FROM shipments s WHERE s.id = $1`, id)
+	shipment, err := scanShipment(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get shipment: %s", err)
+		return nil, fmt.Errorf("can't get shipment: %w", err)
+	}
+	return shipment, nil
+}
+
+// GetOrderShipments returns shipments of the order in order of creation
+func (repo *shipmentRepo) GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]models.Shipment, error) {
+	repo.logger.Debugf("Enter in repository GetOrderShipments() with args: ctx, orderId: %v", orderId)
+	return repo.getShipments(ctx, `SELECT `+shipmentColumns+` FROM shipments s
+	WHERE s.order_id = $1 ORDER BY s.created_at`, orderId)
+}
+
+// GetTrackedShipments returns shipments of the carrier which are on the way
+func (repo *shipmentRepo) GetTrackedShipments(ctx context.Context, carrier string) ([]models.Shipment, error) {
+	repo.logger.Debugf("Enter in repository GetTrackedShipments() with args: ctx, carrier: %s", carrier)
+	return repo.getShipments(ctx, `SELECT `+shipmentColumns+` FROM shipments s
+	WHERE s.carrier = $1 AND s.status IN ($2, $3) ORDER BY s.updated_at`,
+		carrier, models.ShipmentShipped, models.ShipmentInTransit)
+}
+
+// UpdateShipment saves tracking number and status of the shipment with
+// status of the order it leads to in one transaction. Empty status
+// of the order isn't saved
+func (repo *shipmentRepo) UpdateShipment(ctx context.Context, shipment *models.Shipment, orderStatus models.Status) error {
+	repo.logger.Debugf("Enter in repository UpdateShipment() with args: ctx, shipment: %v, orderStatus: %v", shipment, orderStatus)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	tag, err := tx.Exec(ctx, `UPDATE shipments SET tracking_number = $1, status = $2, shipped_at = $3,
+	delivered_at = $4, updated_at = now() WHERE id = $5`,
+		shipment.TrackingNumber,
+		shipment.Status,
+		nullTime(shipment.ShippedAt),
+		nullTime(shipment.DeliveredAt),
+		shipment.Id,
+	)
+	if err != nil {
+		repo.logger.Errorf("can't update shipment: %s", err)
+		return fmt.Errorf("can't update shipment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	if orderStatus != "" {
+		_, err = tx.Exec(ctx, `UPDATE orders SET status = $1 WHERE id = $2`, orderStatus, shipment.OrderId)
+		if err != nil {
+			repo.logger.Errorf("can't update status of order: %s", err)
+			return fmt.Errorf("can't update status of order: %w", err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		repo.logger.Errorf("can't commit shipment: %s", err)
+		return fmt.Errorf("can't commit shipment: %w", err)
+	}
+	return nil
+}
+
+// DeleteShipment deletes the shipment which isn't handed to the carrier yet
+func (repo *shipmentRepo) DeleteShipment(ctx context.Context, id uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeleteShipment() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM shipments WHERE id = $1 AND status = $2`, id, models.ShipmentPending)
+	if err != nil {
+		repo.logger.Errorf("can't delete shipment: %s", err)
+		return fmt.Errorf("can't delete shipment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: shipment isn't %s", models.ErrShipmentNotAllowed, models.ShipmentPending)
+	}
+	return nil
+}
+
+func (repo *shipmentRepo) getShipments(ctx context.Context, query string, args ...interface{}) ([]models.Shipment, error) {
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		repo.logger.Errorf("can't get shipments: %s", err)
+		return nil, fmt.Errorf("can't get shipments: %w", err)
+	}
+	defer rows.Close()
+	shipments := make([]models.Shipment, 0)
+	for rows.Next() {
+		shipment, err := scanShipment(rows)
+		if err != nil {
+			repo.logger.Errorf("can't scan shipment: %s", err)
+			return nil, fmt.Errorf("can't scan shipment: %w", err)
+		}
+		shipments = append(shipments, *shipment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get shipments: %w", err)
+	}
+	return shipments, nil
+}
+
+func scanShipment(row promotionScanner) (*models.Shipment, error) {
+	shipment := models.Shipment{}
+	var shippedAt, deliveredAt *time.Time
+	err := row.Scan(
+		&shipment.Id,
+		&shipment.OrderId,
+		&shipment.Carrier,
+		&shipment.TrackingNumber,
+		&shipment.Status,
+		&shipment.CreatedAt,
+		&shipment.UpdatedAt,
+		&shippedAt,
+		&deliveredAt,
+		shipmentLines{&shipment.Lines},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if shippedAt != nil {
+		shipment.ShippedAt = *shippedAt
+	}
+	if deliveredAt != nil {
+		shipment.DeliveredAt = *deliveredAt
+	}
+	return &shipment, nil
+}
+
+// shipmentLines scans lines selected by shipmentColumns
+type shipmentLines struct {
+	lines *[]models.ShipmentLine
+}
+
+func (dst shipmentLines) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*dst.lines = nil
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("can't scan lines of shipment from %T", src)
+	}
+	lines := make([]models.ShipmentLine, 0)
+	err := json.Unmarshal(data, &lines)
+	if err != nil {
+		return fmt.Errorf("can't decode lines of shipment: %w", err)
+	}
+	*dst.lines = lines
+	return nil
+}
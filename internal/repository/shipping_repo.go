@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type shippingRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ ShippingStore = (*shippingRepo)(nil)
+
+func NewShippingRepo(store *PGres, log *zap.SugaredLogger) ShippingStore {
+	return &shippingRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+const shippingMethodColumns = `id, name, kind, price, price_per_kg, free_from, max_weight, min_days, max_days,
+	zones, active, created_at`
+
+// CreateShippingMethod saves new shipping method
+func (repo *shippingRepo) CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateShippingMethod() with args: ctx, method: %v", method)
+	zones, err := json.Marshal(method.Zones)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("can't encode zones of shipping method: %w", err)
+	}
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO shipping_methods (name, kind, price, price_per_kg, free_from, max_weight,
+	min_days, max_days, zones, active)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb, $10) RETURNING id`,
+		method.Name,
+		method.Kind,
+		method.Price,
+		method.PricePerKg,
+		method.FreeFrom,
+		method.MaxWeight,
+		method.MinDays,
+		method.MaxDays,
+		string(zones),
+		method.Active,
+	)
+	err = row.Scan(&id)
+	if err != nil {
+		repo.logger.Errorf("can't create shipping method: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create shipping method: %w", err)
+	}
+	repo.logger.Info("Shipping method create success")
+	return id, nil
+}
+
+// UpdateShippingMethod changes settings of the shipping method
+func (repo *shippingRepo) UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error {
+	repo.logger.Debugf("Enter in repository UpdateShippingMethod() with args: ctx, method: %v", method)
+	zones, err := json.Marshal(method.Zones)
+	if err != nil {
+		return fmt.Errorf("can't encode zones of shipping method: %w", err)
+	}
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE shipping_methods SET name = $1, kind = $2, price = $3, price_per_kg = $4,
+	free_from = $5, max_weight = $6, min_days = $7, max_days = $8, zones = $9::jsonb, active = $10
+	WHERE id = $11`,
+		method.Name,
+		method.Kind,
+		method.Price,
+		method.PricePerKg,
+		method.FreeFrom,
+		method.MaxWeight,
+		method.MinDays,
+		method.MaxDays,
+		string(zones),
+		method.Active,
+		method.Id,
+	)
+	if err != nil {
+		repo.logger.Errorf("can't update shipping method: %s", err)
+		return fmt.Errorf("can't update shipping method: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Info("Shipping method update success")
+	return nil
+}
+
+// GetShippingMethod returns shipping method by id
+func (repo *shippingRepo) GetShippingMethod(ctx context.Context, id uuid.UUID) (*models.ShippingMethod, error) {
+	repo.logger.Debugf("Enter in repository GetShippingMethod() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT `+shippingMethodColumns+` FROM shipping_methods WHERE id = $1`, id)
+	method, err := scanShippingMethod(row)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get shipping method: %s", err)
+		return nil, fmt.Errorf("can't get shipping method: %w", err)
+	}
+	return method, nil
+}
+
+// GetShippingMethods returns all shipping methods in order they were created
+func (repo *shippingRepo) GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	repo.logger.Debug("Enter in repository GetShippingMethods()")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+shippingMethodColumns+` FROM shipping_methods ORDER BY created_at, id`)
+	if err != nil {
+		repo.logger.Errorf("can't get shipping methods: %s", err)
+		return nil, fmt.Errorf("can't get shipping methods: %w", err)
+	}
+	return repo.scanShippingMethods(rows)
+}
+
+// GetActiveShippingMethods returns active shipping methods in order they were created
+func (repo *shippingRepo) GetActiveShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	repo.logger.Debug("Enter in repository GetActiveShippingMethods()")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+shippingMethodColumns+` FROM shipping_methods WHERE active
+	ORDER BY created_at, id`)
+	if err != nil {
+		repo.logger.Errorf("can't get shipping methods: %s", err)
+		return nil, fmt.Errorf("can't get shipping methods: %w", err)
+	}
+	return repo.scanShippingMethods(rows)
+}
+
+// DeleteShippingMethod deletes shipping method, orders keep its name
+func (repo *shippingRepo) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeleteShippingMethod() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM shipping_methods WHERE id = $1`, id)
+	if err != nil {
+		repo.logger.Errorf("can't delete shipping method: %s", err)
+		return fmt.Errorf("can't delete shipping method: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Infof("Shipping method %v deleted", id)
+	return nil
+}
+
+// SetItemWeight sets weight of the item in grams
+func (repo *shippingRepo) SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error {
+	repo.logger.Debugf("Enter in repository SetItemWeight() with args: ctx, itemId: %v, weight: %d", itemId, weight)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE items SET weight = $1 WHERE id = $2`, weight, itemId)
+	if err != nil {
+		repo.logger.Errorf("can't set weight of item: %s", err)
+		return fmt.Errorf("can't set weight of item: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// GetItemWeights returns weights of the items in grams
+func (repo *shippingRepo) GetItemWeights(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]int64, error) {
+	repo.logger.Debugf("Enter in repository GetItemWeights() with args: ctx, itemIds: %v", itemIds)
+	pool := repo.storage.GetPool()
+	ids := make([]string, 0, len(itemIds))
+	for _, id := range itemIds {
+		ids = append(ids, id.String())
+	}
+	rows, err := pool.Query(ctx, `SELECT id, weight FROM items WHERE id = ANY($1::uuid[])`, ids)
+	if err != nil {
+		repo.logger.Errorf("can't get weights of items: %s", err)
+		return nil, fmt.Errorf("can't get weights of items: %w", err)
+	}
+	defer rows.Close()
+	weights := make(map[uuid.UUID]int64, len(itemIds))
+	for rows.Next() {
+		var id uuid.UUID
+		var weight int64
+		err = rows.Scan(&id, &weight)
+		if err != nil {
+			repo.logger.Errorf("can't scan weight of item: %s", err)
+			return nil, fmt.Errorf("can't scan weight of item: %w", err)
+		}
+		weights[id] = weight
+	}
+	if err = rows.Err(); err != nil {
+		repo.logger.Errorf("can't read weights of items: %s", err)
+		return nil, fmt.Errorf("can't read weights of items: %w", err)
+	}
+	return weights, nil
+}
+
+func scanShippingMethod(row promotionScanner) (*models.ShippingMethod, error) {
+	method := models.ShippingMethod{}
+	var zones []byte
+	err := row.Scan(
+		&method.Id,
+		&method.Name,
+		&method.Kind,
+		&method.Price,
+		&method.PricePerKg,
+		&method.FreeFrom,
+		&method.MaxWeight,
+		&method.MinDays,
+		&method.MaxDays,
+		&zones,
+		&method.Active,
+		&method.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(zones, &method.Zones)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode zones of shipping method: %w", err)
+	}
+	return &method, nil
+}
+
+func (repo *shippingRepo) scanShippingMethods(rows pgx.Rows) ([]models.ShippingMethod, error) {
+	defer rows.Close()
+	methods := make([]models.ShippingMethod, 0)
+	for rows.Next() {
+		method, err := scanShippingMethod(rows)
+		if err != nil {
+			repo.logger.Errorf("can't scan shipping method: %s", err)
+			return nil, fmt.Errorf("can't scan shipping method: %w", err)
+		}
+		methods = append(methods, *method)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't get shipping methods: %w", err)
+	}
+	return methods, nil
+}
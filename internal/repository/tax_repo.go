@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+type taxRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ TaxStore = (*taxRepo)(nil)
+
+func NewTaxRepo(store *PGres, log *zap.SugaredLogger) TaxStore {
+	return &taxRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+const taxRuleColumns = `id, name, country, region, tax_class, rate, inclusive, created_at`
+
+// CreateTaxRule saves new tax rule
+func (repo *taxRepo) CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateTaxRule() with args: ctx, rule: %v", rule)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO tax_rules (name, country, region, tax_class, rate, inclusive)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		rule.Name,
+		rule.Country,
+		rule.Region,
+		rule.TaxClass,
+		rule.Rate,
+		rule.Inclusive,
+	)
+	err := row.Scan(&id)
+	if err != nil {
+		repo.logger.Errorf("can't create tax rule: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create tax rule: %w", err)
+	}
+	repo.logger.Info("Tax rule create success")
+	return id, nil
+}
+
+// UpdateTaxRule changes settings of the tax rule
+func (repo *taxRepo) UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error {
+	repo.logger.Debugf("Enter in repository UpdateTaxRule() with args: ctx, rule: %v", rule)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE tax_rules SET name = $1, country = $2, region = $3, tax_class = $4,
+	rate = $5, inclusive = $6 WHERE id = $7`,
+		rule.Name,
+		rule.Country,
+		rule.Region,
+		rule.TaxClass,
+		rule.Rate,
+		rule.Inclusive,
+		rule.Id,
+	)
+	if err != nil {
+		repo.logger.Errorf("can't update tax rule: %s", err)
+		return fmt.Errorf("can't update tax rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Info("Tax rule update success")
+	return nil
+}
+
+// GetTaxRules returns all tax rules ordered by country, region and class
+func (repo *taxRepo) GetTaxRules(ctx context.Context) ([]models.TaxRule, error) {
+	repo.logger.Debug("Enter in repository GetTaxRules()")
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+taxRuleColumns+` FROM tax_rules ORDER BY country, region, tax_class`)
+	if err != nil {
+		repo.logger.Errorf("can't get tax rules: %s", err)
+		return nil, fmt.Errorf("can't get tax rules: %w", err)
+	}
+	return repo.scanTaxRules(rows)
+}
+
+// GetTaxRulesByCountry returns tax rules of the country and all its regions
+func (repo *taxRepo) GetTaxRulesByCountry(ctx context.Context, country string) ([]models.TaxRule, error) {
+	repo.logger.Debugf("Enter in repository GetTaxRulesByCountry() with args: ctx, country: %s", country)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `SELECT `+taxRuleColumns+` FROM tax_rules WHERE country = $1
+	ORDER BY region, tax_class`, country)
+	if err != nil {
+		repo.logger.Errorf("can't get tax rules: %s", err)
+		return nil, fmt.Errorf("can't get tax rules: %w", err)
+	}
+	return repo.scanTaxRules(rows)
+}
+
+// DeleteTaxRule deletes tax rule, taxes of placed orders are kept
+func (repo *taxRepo) DeleteTaxRule(ctx context.Context, id uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeleteTaxRule() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM tax_rules WHERE id = $1`, id)
+	if err != nil {
+		repo.logger.Errorf("can't delete tax rule: %s", err)
+		return fmt.Errorf("can't delete tax rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Infof("Tax rule %v deleted", id)
+	return nil
+}
+
+// SetItemTaxClass sets tax class of the item, empty class means
+// the class of the category
+func (repo *taxRepo) SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error {
+	repo.logger.Debugf("Enter in repository SetItemTaxClass() with args: ctx, itemId: %v, class: %s", itemId, class)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE items SET tax_class = $1 WHERE id = $2`, nullString(class), itemId)
+	if err != nil {
+		repo.logger.Errorf("can't set tax class of item: %s", err)
+		return fmt.Errorf("can't set tax class of item: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// SetCategoryTaxClass sets tax class of the category, empty class
+// means the standard class
+func (repo *taxRepo) SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error {
+	repo.logger.Debugf("Enter in repository SetCategoryTaxClass() with args: ctx, categoryId: %v, class: %s", categoryId, class)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE categories SET tax_class = $1 WHERE id = $2`, nullString(class), categoryId)
+	if err != nil {
+		repo.logger.Errorf("can't set tax class of category: %s", err)
+		return fmt.Errorf("can't set tax class of category: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// GetTaxClasses returns tax classes of the items, the class of the
+// item overrides the class of its category
+func (repo *taxRepo) GetTaxClasses(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]string, error) {
+	repo.logger.Debugf("Enter in repository GetTaxClasses() with args: ctx, itemIds: %v", itemIds)
+	pool := repo.storage.GetPool()
+	ids := make([]string, 0, len(itemIds))
+	for _, id := range itemIds {
+		ids = append(ids, id.String())
+	}
+	rows, err := pool.Query(ctx, `SELECT items.id, COALESCE(items.tax_class, categories.tax_class, $2)
+	FROM items LEFT JOIN categories ON categories.id = items.category WHERE items.id = ANY($1::uuid[])`,
+		ids, models.TaxClassStandard)
+	if err != nil {
+		repo.logger.Errorf("can't get tax classes: %s", err)
+		return nil, fmt.Errorf("can't get tax classes: %w", err)
+	}
+	defer rows.Close()
+	classes := make(map[uuid.UUID]string, len(itemIds))
+	for rows.Next() {
+		var id uuid.UUID
+		var class string
+		err = rows.Scan(&id, &class)
+		if err != nil {
+			repo.logger.Errorf("can't scan tax class: %s", err)
+			return nil, fmt.Errorf("can't scan tax class: %w", err)
+		}
+		classes[id] = class
+	}
+	if err = rows.Err(); err != nil {
+		repo.logger.Errorf("can't read tax classes: %s", err)
+		return nil, fmt.Errorf("can't read tax classes: %w", err)
+	}
+	return classes, nil
+}
+
+func (repo *taxRepo) scanTaxRules(rows pgx.Rows) ([]models.TaxRule, error) {
+	defer rows.Close()
+	rules := make([]models.TaxRule, 0)
+	for rows.Next() {
+		rule := models.TaxRule{}
+		err := rows.Scan(&rule.Id, &rule.Name, &rule.Country, &rule.Region, &rule.TaxClass, &rule.Rate,
+			&rule.Inclusive, &rule.CreatedAt)
+		if err != nil {
+			repo.logger.Errorf("can't scan tax rule: %s", err)
+			return nil, fmt.Errorf("can't scan tax rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		repo.logger.Errorf("can't read tax rules: %s", err)
+		return nil, fmt.Errorf("can't read tax rules: %w", err)
+	}
+	return rules, nil
+}
+
+// saveOrderTaxes saves tax lines of the order in the transaction
+func saveOrderTaxes(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	for position, tax := range order.Taxes {
+		_, err := tx.Exec(ctx, `INSERT INTO order_taxes (order_id, tax_rule_id, name, tax_class, rate, inclusive, amount, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			order.ID,
+			nullUUID(tax.TaxRuleId),
+			tax.Name,
+			tax.TaxClass,
+			tax.Rate,
+			tax.Inclusive,
+			tax.Amount,
+			position,
+		)
+		if err != nil {
+			return fmt.Errorf("can't save tax %s of order: %w", tax.Name, err)
+		}
+	}
+	return nil
+}
+
+// orderTaxesColumn returns expression selecting taxes of the order
+// with given alias of orders table as json array
+func orderTaxesColumn(alias string) string {
+	return `(SELECT COALESCE(json_agg(json_build_object(
+		'TaxRuleId', COALESCE(ot.tax_rule_id, '00000000-0000-0000-0000-000000000000'),
+		'Name', ot.name, 'TaxClass', ot.tax_class, 'Rate', ot.rate, 'Inclusive', ot.inclusive, 'Amount', ot.amount)
+		ORDER BY ot.position), '[]')
+		FROM order_taxes ot WHERE ot.order_id = ` + alias + `.id)`
+}
+
+// orderTaxes scans taxes selected by orderTaxesColumn
+type orderTaxes struct {
+	taxes *[]models.TaxLine
+}
+
+func (dst orderTaxes) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*dst.taxes = nil
+		return nil
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return fmt.Errorf("can't scan taxes of order from %T", src)
+	}
+	taxes := make([]models.TaxLine, 0)
+	err := json.Unmarshal(data, &taxes)
+	if err != nil {
+		return fmt.Errorf("can't decode taxes of order: %w", err)
+	}
+	*dst.taxes = taxes
+	return nil
+}
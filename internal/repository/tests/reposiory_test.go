@@ -217,25 +217,31 @@ func TestItemGet(t *testing.T) {
 		Price:       300,
 		Category:    cat,
 		Vendor:      "vendor",
-		Images:      []string{"1.jpg"},
+		Images:      []models.ItemImage{{URL: "1.jpg", Alt: "alt", Primary: true}},
 	}
-	row = store.GetPool().QueryRow(context.Background(), `INSERT INTO items(name, category, description, price, vendor, pictures)
-	values ($1, $2, $3, $4, $5, $6) RETURNING id`,
+	row = store.GetPool().QueryRow(context.Background(), `INSERT INTO items(name, category, description, price, vendor)
+	values ($1, $2, $3, $4, $5) RETURNING id`,
 		item.Title,
 		item.Category.Id,
 		item.Description,
 		item.Price,
 		item.Vendor,
-		item.Images,
 	)
 	row.Scan(&item.Id)
 	defer store.GetPool().Exec(context.Background(), `DELETE FROM items`)
+	_, err = store.GetPool().Exec(context.Background(), `INSERT INTO item_images(item_id, url, alt, position, is_primary)
+	values ($1, $2, $3, 0, true)`, item.Id, item.Images[0].URL, item.Images[0].Alt)
+	assert.NoError(t, err)
 
 	itm := repository.NewItemRepo(store, logger)
 	res, err := itm.GetItem(context.TODO(), item.Id)
 	require.NoError(t, err)
 	require.Equal(t, item.Id, res.Id)
 	require.Equal(t, item.Title, res.Title)
+	require.Len(t, res.Images, 1)
+	require.Equal(t, item.Images[0].URL, res.Images[0].URL)
+	require.Equal(t, item.Images[0].Alt, res.Images[0].Alt)
+	require.True(t, res.Images[0].Primary)
 }
 
 func TestItemSearchLine(t *testing.T) {
@@ -1015,7 +1021,7 @@ func TestOrderChangeAddres(t *testing.T) {
 		ShipmentTime: time.Now().Add(2 * time.Hour),
 		User:         user,
 		Address:      user.Address,
-		Status:       models.StatusProcessed,
+		Status:       models.StatusCreated,
 		Items:        []models.ItemWithQuantity{{Item: item1, Quantity: 1}, {Item: item2, Quantity: 1}},
 	}
 
@@ -1042,6 +1048,11 @@ func TestOrderChangeAddres(t *testing.T) {
 	row.Scan(&addr)
 	assert.Contains(t, addr, "Bishkek")
 
+	// Processed order can't be changed
+	_, err = store.GetPool().Exec(context.Background(), `UPDATE orders SET status=$1 WHERE id=$2`, models.StatusProcessed, order.ID)
+	require.NoError(t, err)
+	err = rdrRp.ChangeAddress(context.Background(), &order, models.UserAddress{City: "Osh"})
+	require.ErrorIs(t, err, models.ErrOrderNotChangeable)
 }
 
 func TestOrderChangeStatus(t *testing.T) {
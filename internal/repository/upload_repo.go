@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type uploadRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ UploadStore = (*uploadRepo)(nil)
+
+func NewUploadRepo(store *PGres, log *zap.SugaredLogger) UploadStore {
+	return &uploadRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// CreateUpload creates new upload session in database
+func (repo *uploadRepo) CreateUpload(ctx context.Context, session *models.UploadSession) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateUpload() with args: ctx, session: %v", session)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO upload_sessions (kind, owner_id, status, expires_at)
+	VALUES ($1, $2, $3, $4) RETURNING id`,
+		session.Kind,
+		session.OwnerId,
+		session.Status,
+		session.ExpiresAt,
+	)
+	err := row.Scan(&id)
+	if err != nil {
+		repo.logger.Errorf("can't create upload session: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create upload session: %w", err)
+	}
+	repo.logger.Info("Upload session create success")
+	return id, nil
+}
+
+// GetUpload returns upload session by id
+func (repo *uploadRepo) GetUpload(ctx context.Context, id uuid.UUID) (*models.UploadSession, error) {
+	repo.logger.Debugf("Enter in repository GetUpload() with args: ctx, id: %v", id)
+	pool := repo.storage.GetPool()
+	session := models.UploadSession{}
+	row := pool.QueryRow(ctx, `SELECT id, kind, owner_id, status, expires_at, created_at
+	FROM upload_sessions WHERE id = $1`, id)
+	err := row.Scan(
+		&session.Id,
+		&session.Kind,
+		&session.OwnerId,
+		&session.Status,
+		&session.ExpiresAt,
+		&session.CreatedAt,
+	)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't get upload session: %s", err)
+		return nil, fmt.Errorf("can't get upload session: %w", err)
+	}
+	return &session, nil
+}
+
+// UpdateUploadStatus changes status of the session if its current status is
+// one of given, otherwise it returns ErrorNotFound. It allows only one request
+// to take the session for processing
+func (repo *uploadRepo) UpdateUploadStatus(ctx context.Context, id uuid.UUID, from []models.UploadStatus, to models.UploadStatus) error {
+	repo.logger.Debugf("Enter in repository UpdateUploadStatus() with args: ctx, id: %v, from: %v, to: %s", id, from, to)
+	pool := repo.storage.GetPool()
+	statuses := make([]string, len(from))
+	for i, status := range from {
+		statuses[i] = string(status)
+	}
+	tag, err := pool.Exec(ctx, `UPDATE upload_sessions SET status = $1 WHERE id = $2 AND status = ANY($3)`,
+		to, id, statuses)
+	if err != nil {
+		repo.logger.Errorf("can't update upload session status: %s", err)
+		return fmt.Errorf("can't update upload session status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	repo.logger.Infof("Upload session %v status changed to %s", id, to)
+	return nil
+}
+
+// DeleteExpiredUploads deletes sessions expired before given time and returns
+// them, so every session is returned only to one instance of the service
+func (repo *uploadRepo) DeleteExpiredUploads(ctx context.Context, before time.Time) ([]models.UploadSession, error) {
+	repo.logger.Debugf("Enter in repository DeleteExpiredUploads() with args: ctx, before: %v", before)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `DELETE FROM upload_sessions WHERE expires_at < $1
+	RETURNING id, kind, owner_id, status, expires_at, created_at`, before)
+	if err != nil {
+		repo.logger.Errorf("can't delete expired upload sessions: %s", err)
+		return nil, fmt.Errorf("can't delete expired upload sessions: %w", err)
+	}
+	defer rows.Close()
+	sessions := make([]models.UploadSession, 0)
+	for rows.Next() {
+		session := models.UploadSession{}
+		err := rows.Scan(
+			&session.Id,
+			&session.Kind,
+			&session.OwnerId,
+			&session.Status,
+			&session.ExpiresAt,
+			&session.CreatedAt,
+		)
+		if err != nil {
+			repo.logger.Errorf("can't scan upload session: %s", err)
+			return nil, fmt.Errorf("can't scan upload session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't delete expired upload sessions: %w", err)
+	}
+	return sessions, nil
+}
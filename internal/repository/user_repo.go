@@ -4,6 +4,7 @@ import (
 	"OnlineShopBackend/internal/models"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
@@ -65,6 +66,29 @@ func (u *user) GetUserByEmail(ctx context.Context, email string) (*models.User,
 	}
 }
 
+// GetUserById returns the user by id
+func (u *user) GetUserById(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	u.logger.Debugf("Enter in repository GetUserById() with args: ctx, id: %v", id)
+	select {
+	case <-ctx.Done():
+		return &models.User{}, fmt.Errorf("context is closed")
+	default:
+		pool := u.storage.GetPool()
+		row := pool.QueryRow(ctx, `SELECT users.id, users.name, lastname, password, email, rights.id, zipcode, country, city, street,
+		rights.name, rights.rules FROM users INNER JOIN rights ON users.id=$1 and rights.id=users.rights`, id)
+		var user = models.User{}
+		err := row.Scan(&user.ID, &user.Firstname, &user.Lastname, &user.Password, &user.Email, &user.Rights.ID,
+			&user.Address.Zipcode, &user.Address.Country, &user.Address.City, &user.Address.Street, &user.Rights.Name, &user.Rights.Rules)
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			return &models.User{}, models.ErrorNotFound{}
+		}
+		if err != nil {
+			return &models.User{}, fmt.Errorf("can't get user from database: %w", err)
+		}
+		return &user, nil
+	}
+}
+
 func (u *user) UpdateUserData(ctx context.Context, id uuid.UUID, user *models.User) (*models.User, error) {
 	u.logger.Debug("Enter in repository UpdateUserData()")
 	select {
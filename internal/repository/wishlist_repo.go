@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"OnlineShopBackend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// wishlistRepo stores items of wishlists in favourite_items, items of the
+// default list (favourites) have no wishlist, so the nil id of the wishlist
+// is passed as NULL and compared by IS NOT DISTINCT FROM
+type wishlistRepo struct {
+	storage *PGres
+	logger  *zap.SugaredLogger
+}
+
+var _ WishlistStore = (*wishlistRepo)(nil)
+
+func NewWishlistRepo(store *PGres, log *zap.SugaredLogger) WishlistStore {
+	return &wishlistRepo{
+		storage: store,
+		logger:  log,
+	}
+}
+
+// CreateWishlist saves new named wishlist of the user
+func (repo *wishlistRepo) CreateWishlist(ctx context.Context, wishlist *models.Wishlist) (uuid.UUID, error) {
+	repo.logger.Debugf("Enter in repository CreateWishlist() with args: ctx, wishlist: %v", wishlist)
+	pool := repo.storage.GetPool()
+	var id uuid.UUID
+	row := pool.QueryRow(ctx, `INSERT INTO wishlists (user_id, name) VALUES ($1, $2) RETURNING id`,
+		wishlist.UserId, wishlist.Name)
+	err := row.Scan(&id)
+	if err != nil {
+		repo.logger.Errorf("can't create wishlist: %s", err)
+		return uuid.Nil, fmt.Errorf("can't create wishlist: %w", err)
+	}
+	repo.logger.Info("Wishlist create success")
+	return id, nil
+}
+
+// GetWishlists returns named wishlists of the user with quantities of
+// their items in order of creation
+func (repo *wishlistRepo) GetWishlists(ctx context.Context, userId uuid.UUID) ([]models.Wishlist, error) {
+	repo.logger.Debugf("Enter in repository GetWishlists() with args: ctx, userId: %v", userId)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT w.id, w.user_id, w.name, COALESCE(w.share_token, ''), w.created_at, COUNT(i.id)
+	FROM wishlists w
+	LEFT JOIN favourite_items f ON f.wishlist_id = w.id
+	LEFT JOIN items i ON i.id = f.item_id AND i.deleted_at IS NULL
+	WHERE w.user_id = $1
+	GROUP BY w.id
+	ORDER BY w.created_at`, userId)
+	if err != nil {
+		repo.logger.Errorf("can't select wishlists: %s", err)
+		return nil, fmt.Errorf("can't select wishlists: %w", err)
+	}
+	defer rows.Close()
+	var wishlists []models.Wishlist
+	for rows.Next() {
+		var wishlist models.Wishlist
+		err := rows.Scan(
+			&wishlist.Id,
+			&wishlist.UserId,
+			&wishlist.Name,
+			&wishlist.ShareToken,
+			&wishlist.CreatedAt,
+			&wishlist.ItemsCount,
+		)
+		if err != nil {
+			repo.logger.Error(err.Error())
+			return nil, err
+		}
+		wishlists = append(wishlists, wishlist)
+	}
+	return wishlists, rows.Err()
+}
+
+// GetWishlist returns named wishlist of the user without items
+func (repo *wishlistRepo) GetWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Wishlist, error) {
+	repo.logger.Debugf("Enter in repository GetWishlist() with args: ctx, userId: %v, id: %v", userId, id)
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT id, user_id, name, COALESCE(share_token, ''), created_at
+	FROM wishlists WHERE id = $1 AND user_id = $2`, id, userId)
+	return repo.scanWishlist(row)
+}
+
+// GetWishlistByToken returns shared wishlist without items
+func (repo *wishlistRepo) GetWishlistByToken(ctx context.Context, token string) (*models.Wishlist, error) {
+	repo.logger.Debug("Enter in repository GetWishlistByToken() with args: ctx, token")
+	pool := repo.storage.GetPool()
+	row := pool.QueryRow(ctx, `SELECT id, user_id, name, COALESCE(share_token, ''), created_at
+	FROM wishlists WHERE share_token = $1`, token)
+	return repo.scanWishlist(row)
+}
+
+func (repo *wishlistRepo) scanWishlist(row pgx.Row) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	err := row.Scan(
+		&wishlist.Id,
+		&wishlist.UserId,
+		&wishlist.Name,
+		&wishlist.ShareToken,
+		&wishlist.CreatedAt,
+	)
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return nil, models.ErrorNotFound{}
+	}
+	if err != nil {
+		repo.logger.Errorf("can't read wishlist: %s", err)
+		return nil, fmt.Errorf("can't read wishlist: %w", err)
+	}
+	return &wishlist, nil
+}
+
+// RenameWishlist changes name of the named wishlist of the user
+func (repo *wishlistRepo) RenameWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID, name string) error {
+	repo.logger.Debugf("Enter in repository RenameWishlist() with args: ctx, userId: %v, id: %v, name: %s", userId, id, name)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE wishlists SET name = $1 WHERE id = $2 AND user_id = $3`, name, id, userId)
+	if err != nil {
+		repo.logger.Errorf("can't rename wishlist: %s", err)
+		return fmt.Errorf("can't rename wishlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// DeleteWishlist deletes named wishlist of the user with its items
+func (repo *wishlistRepo) DeleteWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeleteWishlist() with args: ctx, userId: %v, id: %v", userId, id)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM wishlists WHERE id = $1 AND user_id = $2`, id, userId)
+	if err != nil {
+		repo.logger.Errorf("can't delete wishlist: %s", err)
+		return fmt.Errorf("can't delete wishlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// SetShareToken sets token of the public link of the wishlist,
+// empty token stops sharing
+func (repo *wishlistRepo) SetShareToken(ctx context.Context, userId uuid.UUID, id uuid.UUID, token string) error {
+	repo.logger.Debugf("Enter in repository SetShareToken() with args: ctx, userId: %v, id: %v", userId, id)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `UPDATE wishlists SET share_token = $1 WHERE id = $2 AND user_id = $3`,
+		nullString(token), id, userId)
+	if err != nil {
+		repo.logger.Errorf("can't set share token of wishlist: %s", err)
+		return fmt.Errorf("can't set share token of wishlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// GetWishlistItems returns not deleted items of the wishlist of the user
+// in order of adding, nil id is the default list
+func (repo *wishlistRepo) GetWishlistItems(ctx context.Context, userId uuid.UUID, id uuid.UUID) ([]models.Item, error) {
+	repo.logger.Debugf("Enter in repository GetWishlistItems() with args: ctx, userId: %v, id: %v", userId, id)
+	pool := repo.storage.GetPool()
+	rows, err := pool.Query(ctx, `
+	SELECT i.id, i.name, i.description, i.category, cat.name, cat.description, cat.picture,
+		i.price, i.currency, i.vendor, `+itemImagesColumn("i")+`, `+itemRatingColumns("i")+`
+	FROM favourite_items f, items i, categories cat
+	WHERE f.user_id = $1
+	AND f.wishlist_id IS NOT DISTINCT FROM $2::uuid
+	AND i.id = f.item_id
+	AND cat.id = i.category
+	AND i.deleted_at IS NULL
+	ORDER BY f.added_at`, userId, nullUUID(id))
+	if err != nil {
+		repo.logger.Errorf("can't select items of wishlist: %s", err)
+		return nil, fmt.Errorf("can't select items of wishlist: %w", err)
+	}
+	defer rows.Close()
+	var items []models.Item
+	for rows.Next() {
+		var item models.Item
+		err := rows.Scan(
+			&item.Id,
+			&item.Title,
+			&item.Description,
+			&item.Category.Id,
+			&item.Category.Name,
+			&item.Category.Description,
+			&item.Category.Image,
+			&item.Price,
+			&item.Currency,
+			&item.Vendor,
+			itemImages{&item.Images},
+			&item.Rating,
+			&item.ReviewsCount,
+		)
+		if err != nil {
+			repo.logger.Error(err.Error())
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// HasWishlistItem checks whether the item is in the wishlist of the user
+func (repo *wishlistRepo) HasWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) (bool, error) {
+	repo.logger.Debugf("Enter in repository HasWishlistItem() with args: ctx, userId: %v, id: %v, itemId: %v", userId, id, itemId)
+	pool := repo.storage.GetPool()
+	var exists bool
+	row := pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM favourite_items
+	WHERE user_id = $1 AND wishlist_id IS NOT DISTINCT FROM $2::uuid AND item_id = $3)`,
+		userId, nullUUID(id), itemId)
+	err := row.Scan(&exists)
+	if err != nil {
+		repo.logger.Errorf("can't check item of wishlist: %s", err)
+		return false, fmt.Errorf("can't check item of wishlist: %w", err)
+	}
+	return exists, nil
+}
+
+// AddWishlistItem adds item to the wishlist of the user, the item
+// which is already in the list isn't added again
+func (repo *wishlistRepo) AddWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository AddWishlistItem() with args: ctx, userId: %v, id: %v, itemId: %v", userId, id, itemId)
+	pool := repo.storage.GetPool()
+	_, err := pool.Exec(ctx, `INSERT INTO favourite_items (user_id, item_id, wishlist_id) VALUES ($1, $2, $3)
+	ON CONFLICT DO NOTHING`, userId, itemId, nullUUID(id))
+	if err != nil {
+		repo.logger.Errorf("can't add item to wishlist: %s", err)
+		return fmt.Errorf("can't add item to wishlist: %w", err)
+	}
+	return nil
+}
+
+// DeleteWishlistItem deletes item from the wishlist of the user
+func (repo *wishlistRepo) DeleteWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository DeleteWishlistItem() with args: ctx, userId: %v, id: %v, itemId: %v", userId, id, itemId)
+	pool := repo.storage.GetPool()
+	tag, err := pool.Exec(ctx, `DELETE FROM favourite_items
+	WHERE user_id = $1 AND wishlist_id IS NOT DISTINCT FROM $2::uuid AND item_id = $3`,
+		userId, nullUUID(id), itemId)
+	if err != nil {
+		repo.logger.Errorf("can't delete item from wishlist: %s", err)
+		return fmt.Errorf("can't delete item from wishlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	return nil
+}
+
+// MoveWishlistItem moves item between wishlists of the user,
+// the item which is already in the target list is only removed from the source
+func (repo *wishlistRepo) MoveWishlistItem(ctx context.Context, userId uuid.UUID, fromId uuid.UUID, toId uuid.UUID, itemId uuid.UUID) error {
+	repo.logger.Debugf("Enter in repository MoveWishlistItem() with args: ctx, userId: %v, fromId: %v, toId: %v, itemId: %v", userId, fromId, toId, itemId)
+	pool := repo.storage.GetPool()
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		repo.logger.Errorf("can't create transaction: %s", err)
+		return fmt.Errorf("can't create transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	tag, err := tx.Exec(ctx, `DELETE FROM favourite_items
+	WHERE user_id = $1 AND wishlist_id IS NOT DISTINCT FROM $2::uuid AND item_id = $3`,
+		userId, nullUUID(fromId), itemId)
+	if err != nil {
+		repo.logger.Errorf("can't delete item from wishlist: %s", err)
+		return fmt.Errorf("can't delete item from wishlist: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrorNotFound{}
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO favourite_items (user_id, item_id, wishlist_id) VALUES ($1, $2, $3)
+	ON CONFLICT DO NOTHING`, userId, itemId, nullUUID(toId))
+	if err != nil {
+		repo.logger.Errorf("can't add item to wishlist: %s", err)
+		return fmt.Errorf("can't add item to wishlist: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		repo.logger.Errorf("can't commit move of item: %s", err)
+		return fmt.Errorf("can't commit move of item: %w", err)
+	}
+	repo.logger.Infof("Item %v moved from wishlist %v to wishlist %v", itemId, fromId, toId)
+	return nil
+}
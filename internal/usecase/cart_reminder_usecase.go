@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/notification"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ ICartReminderUsecase = &CartReminderUsecase{}
+
+// UnsubscribePath is a path of the link to unsubscribe from
+// reminders, the token of the user is added to it
+const UnsubscribePath = "/cart/reminders/unsubscribe/"
+
+type CartReminderUsecase struct {
+	store     repository.AbandonedCartStore
+	notifier  notification.Notifier
+	idle      time.Duration
+	serverURL string
+	logger    *zap.Logger
+}
+
+func NewCartReminderUsecase(store repository.AbandonedCartStore, notifier notification.Notifier, idle time.Duration, serverURL string, logger *zap.Logger) ICartReminderUsecase {
+	logger.Debug("Enter in usecase NewCartReminderUsecase()")
+	return &CartReminderUsecase{
+		store:     store,
+		notifier:  notifier,
+		idle:      idle,
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		logger:    logger,
+	}
+}
+
+// RemindAbandonedCarts records events of carts of users idle for the
+// configured period and reminds subscribed users about them. Reminders
+// which failed are sent on the next run. Returns the number of recorded
+// events and of sent reminders
+func (usecase *CartReminderUsecase) RemindAbandonedCarts(ctx context.Context) (int, int, error) {
+	usecase.logger.Debug("Enter in usecase RemindAbandonedCarts() with args: ctx")
+	recorded, err := usecase.store.RecordAbandonedCarts(ctx, time.Now().Add(-usecase.idle))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error on record abandoned carts: %w", err)
+	}
+	abandonments, err := usecase.store.GetUnsentReminders(ctx)
+	if err != nil {
+		return recorded, 0, fmt.Errorf("error on get unsent reminders: %w", err)
+	}
+	sent := 0
+	for i := range abandonments {
+		abandonment := &abandonments[i]
+		message, err := usecase.reminder(abandonment)
+		if err != nil {
+			return recorded, sent, err
+		}
+		err = usecase.notifier.Notify(ctx, message)
+		if err != nil {
+			usecase.logger.Sugar().Errorf("error on notify user %v about abandoned cart %v: %v", abandonment.UserId, abandonment.CartId, err)
+			continue
+		}
+		err = usecase.store.MarkReminderSent(ctx, abandonment.Id, time.Now())
+		if err != nil {
+			return recorded, sent, fmt.Errorf("error on mark reminder sent: %w", err)
+		}
+		sent++
+	}
+	return recorded, sent, nil
+}
+
+// RecoverCart marks the abandoned cart as recovered when it becomes
+// an order. Returns false if the cart wasn't abandoned
+func (usecase *CartReminderUsecase) RecoverCart(ctx context.Context, cartId uuid.UUID) (bool, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase RecoverCart() with args: ctx, cartId: %v", cartId)
+	return usecase.store.RecoverAbandonedCart(ctx, cartId, time.Now())
+}
+
+// Unsubscribe stops reminders about abandoned carts of the user
+func (usecase *CartReminderUsecase) Unsubscribe(ctx context.Context, userId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase Unsubscribe() with args: ctx, userId: %v", userId)
+	return usecase.store.SetCartReminders(ctx, userId, false)
+}
+
+// reminder returns the notification about the abandoned cart
+// with the link to unsubscribe from reminders
+func (usecase *CartReminderUsecase) reminder(abandonment *models.CartAbandonment) (*models.Notification, error) {
+	token, err := jwtauth.NewUnsubscribeToken(abandonment.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("error on create unsubscribe token: %w", err)
+	}
+	return &models.Notification{
+		Kind:           models.NotificationCartReminder,
+		UserId:         abandonment.UserId,
+		Email:          abandonment.Email,
+		Subject:        "You left items in your cart",
+		Text:           fmt.Sprintf("%s, %d items are still waiting for you in your cart.", abandonment.Name, abandonment.ItemsCount),
+		UnsubscribeURL: usecase.serverURL + UnsubscribePath + token,
+	}, nil
+}
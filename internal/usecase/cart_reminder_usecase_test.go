@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/delivery/user/jwtauth"
+	"OnlineShopBackend/internal/models"
+	notifierMocks "OnlineShopBackend/internal/notification/mocks"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRemindAbandonedCarts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mocks.NewMockAbandonedCartStore(ctrl)
+	notifier := notifierMocks.NewMockNotifier(ctrl)
+	usecase := NewCartReminderUsecase(store, notifier, time.Hour, "http://localhost:8000/", zap.L())
+	ctx := context.Background()
+
+	failed := models.CartAbandonment{Id: uuid.New(), CartId: uuid.New(), UserId: uuid.New(), Email: "failed@mail.ru", ItemsCount: 1}
+	reminded := models.CartAbandonment{Id: uuid.New(), CartId: uuid.New(), UserId: testId, Email: "user@mail.ru", Name: "User", ItemsCount: 3}
+
+	// Carts are idle for the configured period
+	store.EXPECT().RecordAbandonedCarts(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, idleBefore time.Time) (int, error) {
+			require.WithinDuration(t, time.Now().Add(-time.Hour), idleBefore, time.Minute)
+			return 2, nil
+		})
+	store.EXPECT().GetUnsentReminders(ctx).Return([]models.CartAbandonment{failed, reminded}, nil)
+	// Failed reminder isn't marked, so it is sent on the next run
+	notifier.EXPECT().Notify(ctx, gomock.Any()).Return(fmt.Errorf("error"))
+	var message *models.Notification
+	notifier.EXPECT().Notify(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, notification *models.Notification) error {
+			message = notification
+			return nil
+		})
+	store.EXPECT().MarkReminderSent(ctx, reminded.Id, gomock.Any()).Return(nil)
+
+	recorded, sent, err := usecase.RemindAbandonedCarts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, recorded)
+	require.Equal(t, 1, sent)
+	require.Equal(t, models.NotificationCartReminder, message.Kind)
+	require.Equal(t, reminded.Email, message.Email)
+	require.Contains(t, message.Text, "3 items")
+
+	// The link unsubscribes the reminded user
+	prefix := "http://localhost:8000" + UnsubscribePath
+	require.True(t, strings.HasPrefix(message.UnsubscribeURL, prefix))
+	userId, err := jwtauth.ParseUnsubscribeToken(strings.TrimPrefix(message.UnsubscribeURL, prefix))
+	require.NoError(t, err)
+	require.Equal(t, testId, userId)
+
+	store.EXPECT().SetCartReminders(ctx, userId, false).Return(nil)
+	err = usecase.Unsubscribe(ctx, userId)
+	require.NoError(t, err)
+}
+
+func TestRemindAbandonedCartsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mocks.NewMockAbandonedCartStore(ctrl)
+	usecase := NewCartReminderUsecase(store, nil, time.Hour, "", zap.L())
+	ctx := context.Background()
+
+	store.EXPECT().RecordAbandonedCarts(ctx, gomock.Any()).Return(0, fmt.Errorf("error"))
+	_, _, err := usecase.RemindAbandonedCarts(ctx)
+	require.Error(t, err)
+
+	store.EXPECT().RecordAbandonedCarts(ctx, gomock.Any()).Return(1, nil)
+	store.EXPECT().GetUnsentReminders(ctx).Return(nil, fmt.Errorf("error"))
+	recorded, _, err := usecase.RemindAbandonedCarts(ctx)
+	require.Error(t, err)
+	// Recorded events are counted anyway
+	require.Equal(t, 1, recorded)
+}
@@ -4,6 +4,9 @@ import (
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository"
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -12,13 +15,26 @@ import (
 var _ ICartUsecase = &CartUseCase{}
 
 type CartUseCase struct {
-	store  repository.CartStore
-	logger *zap.Logger
+	store          repository.CartStore
+	promotionStore repository.PromotionStore
+	currencyStore  repository.CurrencyStore
+	taxStore       repository.TaxStore
+	baseCurrency   string
+	ttl            models.CartTTL
+	logger         *zap.Logger
 }
 
-func NewCartUseCase(store repository.CartStore, logger *zap.Logger) ICartUsecase {
+func NewCartUseCase(store repository.CartStore, promotionStore repository.PromotionStore, currencyStore repository.CurrencyStore, taxStore repository.TaxStore, baseCurrency string, ttl models.CartTTL, logger *zap.Logger) ICartUsecase {
 	logger.Debug("Enter in usecase NewCartUseCase()")
-	cart := &CartUseCase{store: store, logger: logger}
+	cart := &CartUseCase{
+		store:          store,
+		promotionStore: promotionStore,
+		currencyStore:  currencyStore,
+		taxStore:       taxStore,
+		baseCurrency:   baseCurrency,
+		ttl:            ttl,
+		logger:         logger,
+	}
 	return cart
 }
 
@@ -29,6 +45,14 @@ func (c *CartUseCase) GetCart(ctx context.Context, cartId uuid.UUID) (*models.Ca
 	if err != nil {
 		return nil, err
 	}
+	err = c.touch(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	err = c.priceCart(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
 	return cart, nil
 }
 
@@ -39,6 +63,14 @@ func (c *CartUseCase) GetCartByUserId(ctx context.Context, userId uuid.UUID) (*m
 	if err != nil {
 		return nil, err
 	}
+	err = c.touch(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	err = c.priceCart(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
 	return cart, nil
 }
 
@@ -49,6 +81,7 @@ func (c *CartUseCase) DeleteItemFromCart(ctx context.Context, cartId uuid.UUID,
 	if err != nil {
 		return err
 	}
+	c.refresh(ctx, cartId)
 	return nil
 }
 
@@ -59,6 +92,7 @@ func (c *CartUseCase) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID,
 	if err != nil {
 		return cartId, err
 	}
+	c.refresh(ctx, cartId)
 	return cartId, nil
 }
 
@@ -69,6 +103,7 @@ func (c *CartUseCase) AddItemToCart(ctx context.Context, cartId uuid.UUID, itemI
 	if err != nil {
 		return err
 	}
+	c.refresh(ctx, cartId)
 	return nil
 }
 
@@ -81,3 +116,275 @@ func (c *CartUseCase) DeleteCart(ctx context.Context, cartId uuid.UUID) error {
 	}
 	return nil
 }
+
+// MergeGuestCart merges the guest cart into the cart of the user and returns
+// id of the cart of the user, the cart is created if the user has no cart
+func (c *CartUseCase) MergeGuestCart(ctx context.Context, guestCartId uuid.UUID, userId uuid.UUID) (uuid.UUID, error) {
+	c.logger.Sugar().Debugf("Enter in usecase MergeGuestCart() with args: ctx, guestCartId: %v, userId: %v", guestCartId, userId)
+	guestCart, err := c.store.GetCart(ctx, guestCartId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if c.expired(ctx, guestCart) {
+		return uuid.Nil, models.ErrorNotFound{}
+	}
+	if guestCart.UserId == userId {
+		return guestCartId, nil
+	}
+	if guestCart.UserId != uuid.Nil {
+		return uuid.Nil, models.ErrNotGuestCart
+	}
+	var cartId uuid.UUID
+	userCart, err := c.store.GetCartByUserId(ctx, userId)
+	switch {
+	case err == nil:
+		cartId = userCart.Id
+	case errors.Is(err, models.ErrorNotFound{}):
+		cartId, err = c.store.Create(ctx, userId)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	default:
+		return uuid.Nil, err
+	}
+	err = c.store.MergeCarts(ctx, guestCartId, cartId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	c.refresh(ctx, cartId)
+	return cartId, nil
+}
+
+// SetItemQuantity sets quantity of the item in the cart checking limits
+// of the item, zero quantity removes the item from the cart
+func (c *CartUseCase) SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error {
+	c.logger.Sugar().Debugf("Enter in usecase SetItemQuantity() with args: ctx, cartId: %v, itemId: %v, quantity: %d", cartId, itemId, quantity)
+	if quantity < 0 {
+		return fmt.Errorf("%w: quantity can't be negative", models.ErrInvalidQuantity)
+	}
+	if quantity > 0 {
+		limits, err := c.store.GetQuantityLimits(ctx, itemId)
+		if err != nil {
+			return err
+		}
+		err = limits.Check(quantity)
+		if err != nil {
+			return err
+		}
+	}
+	err := c.store.SetItemQuantity(ctx, cartId, itemId, quantity)
+	if err != nil {
+		return err
+	}
+	c.refresh(ctx, cartId)
+	return nil
+}
+
+// DecrementItem decreases quantity of the item in the cart and returns the new
+// quantity. The item is removed from the cart if its quantity becomes less than
+// minimal quantity of the item, then zero is returned
+func (c *CartUseCase) DecrementItem(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, decrement int) (int, error) {
+	c.logger.Sugar().Debugf("Enter in usecase DecrementItem() with args: ctx, cartId: %v, itemId: %v, decrement: %d", cartId, itemId, decrement)
+	if decrement < 1 {
+		return 0, fmt.Errorf("%w: decrement must be positive", models.ErrInvalidQuantity)
+	}
+	limits, err := c.store.GetQuantityLimits(ctx, itemId)
+	if err != nil {
+		return 0, err
+	}
+	quantity, err := c.store.DecrementItem(ctx, cartId, itemId, decrement, limits.Min)
+	if err != nil {
+		return 0, err
+	}
+	c.refresh(ctx, cartId)
+	return quantity, nil
+}
+
+// Reorder copies items and quantities of the previous order of the user
+// into the cart of the user, the cart is created if the user has no cart.
+// Lines of deleted and unavailable items are skipped and reported
+func (c *CartUseCase) Reorder(ctx context.Context, userId uuid.UUID, order *models.Order) (*models.Reorder, error) {
+	c.logger.Sugar().Debugf("Enter in usecase Reorder() with args: ctx, userId: %v, orderId: %v", userId, order.ID)
+	// Orders of other users are not shown
+	if order.User.ID != userId {
+		return nil, models.ErrorNotFound{}
+	}
+	cart, err := c.store.GetCartByUserId(ctx, userId)
+	switch {
+	case err == nil:
+	case errors.Is(err, models.ErrorNotFound{}):
+		cartId, err := c.store.Create(ctx, userId)
+		if err != nil {
+			return nil, fmt.Errorf("error on create cart: %w", err)
+		}
+		cart = &models.Cart{Id: cartId, UserId: userId}
+	default:
+		return nil, fmt.Errorf("error on get cart: %w", err)
+	}
+	inCart := make(map[uuid.UUID]int, len(cart.Items))
+	for _, item := range cart.Items {
+		inCart[item.Id] = item.Quantity
+	}
+	itemIds := make([]uuid.UUID, 0, len(order.Items))
+	for _, item := range order.Items {
+		itemIds = append(itemIds, item.Id)
+	}
+	availability, err := c.store.GetItemsAvailability(ctx, itemIds)
+	if err != nil {
+		return nil, fmt.Errorf("error on get availability of items: %w", err)
+	}
+	reorder := &models.Reorder{CartId: cart.Id}
+	quantities := make(map[uuid.UUID]int)
+	for _, item := range order.Items {
+		line := models.ReorderLine{ItemId: item.Id, Title: item.Title, Requested: item.Quantity}
+		state, ok := availability[item.Id]
+		if !ok {
+			state.Deleted = true
+		}
+		line.Added, line.Reason = state.Addable(inCart[item.Id], item.Quantity)
+		if line.Added == 0 {
+			reorder.Skipped = append(reorder.Skipped, line)
+			continue
+		}
+		inCart[item.Id] += line.Added
+		quantities[item.Id] += line.Added
+		reorder.Added = append(reorder.Added, line)
+	}
+	if len(quantities) > 0 {
+		err = c.store.AddItemsToCart(ctx, cart.Id, quantities)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.refresh(ctx, cart.Id)
+	return reorder, nil
+}
+
+// ApplyPromoCode applies promo code to the cart if the code discounts the cart
+// now and returns the cart with discounts
+func (c *CartUseCase) ApplyPromoCode(ctx context.Context, cartId uuid.UUID, code string) (*models.Cart, error) {
+	c.logger.Sugar().Debugf("Enter in usecase ApplyPromoCode() with args: ctx, cartId: %v, code: %s", cartId, code)
+	cart, err := c.store.GetCart(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+	items, err := baseItems(ctx, c.currencyStore, c.baseCurrency, cart.Items)
+	if err != nil {
+		return nil, err
+	}
+	promotion, err := codePromotion(ctx, c.promotionStore, code, items, cart.UserId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	err = c.store.SetPromoCode(ctx, cartId, promotion.Code)
+	if err != nil {
+		return nil, err
+	}
+	cart.PromoCode = promotion.Code
+	c.touch(ctx, cart)
+	err = c.priceCart(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// RemovePromoCode removes promo code from the cart
+func (c *CartUseCase) RemovePromoCode(ctx context.Context, cartId uuid.UUID) error {
+	c.logger.Sugar().Debugf("Enter in usecase RemovePromoCode() with args: ctx, cartId: %v", cartId)
+	return c.store.SetPromoCode(ctx, cartId, "")
+}
+
+// ApplyTaxes adds taxes of shipping to the address to the priced cart,
+// it shows the cart as it would be ordered
+func (c *CartUseCase) ApplyTaxes(ctx context.Context, cart *models.Cart, address models.UserAddress) error {
+	c.logger.Sugar().Debugf("Enter in usecase ApplyTaxes() with args: ctx, cartId: %v, address: %v", cart.Id, address)
+	items, err := baseItems(ctx, c.currencyStore, c.baseCurrency, cart.Items)
+	if err != nil {
+		return err
+	}
+	return applyTaxes(ctx, c.taxStore, &cart.Pricing, items, address)
+}
+
+// PurgeExpiredCarts deletes expired guest carts, it returns the number
+// of deleted carts
+func (c *CartUseCase) PurgeExpiredCarts(ctx context.Context) (int, error) {
+	c.logger.Debug("Enter in usecase PurgeExpiredCarts()")
+	guests, err := c.store.DeleteExpiredCarts(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error on delete expired carts: %w", err)
+	}
+	if guests > 0 {
+		c.logger.Sugar().Infof("%d expired guest carts deleted", guests)
+	}
+	return guests, nil
+}
+
+// refresh prolongs the cart after activity. Errors are only logged,
+// because the cart is still kept till its previous expiry
+func (c *CartUseCase) refresh(ctx context.Context, cartId uuid.UUID) bool {
+	now := time.Now()
+	err := c.store.RefreshCartExpiry(ctx, cartId, now.Add(c.ttl.Guest), now.Add(c.ttl.User))
+	if err != nil {
+		c.logger.Sugar().Warnf("error on refresh expiry of cart %v: %v", cartId, err)
+		return false
+	}
+	return true
+}
+
+// touch prolongs the loaded cart after activity and updates its expiry.
+// Expired guest cart isn't prolonged, it is deleted and ErrorNotFound is returned
+func (c *CartUseCase) touch(ctx context.Context, cart *models.Cart) error {
+	if c.expired(ctx, cart) {
+		return models.ErrorNotFound{}
+	}
+	if c.refresh(ctx, cart.Id) {
+		cart.ExpireAt = c.ttl.ExpireAt(cart.UserId, time.Now())
+	}
+	return nil
+}
+
+// expired reports whether the loaded cart is an expired guest cart, which
+// is deleted then without waiting for the purge. Expiry of carts of users
+// is informational, they are kept till next logins
+func (c *CartUseCase) expired(ctx context.Context, cart *models.Cart) bool {
+	if cart.UserId != uuid.Nil || !cart.ExpireAt.Before(time.Now()) {
+		return false
+	}
+	err := c.store.DeleteCart(ctx, cart.Id)
+	if err != nil {
+		c.logger.Sugar().Warnf("error on delete expired cart %v: %v", cart.Id, err)
+	}
+	return true
+}
+
+// priceCart calculates subtotal, discounts and total of the cart in the base
+// currency. The promo code which can't be applied anymore is shown in the
+// cart without discount
+func (c *CartUseCase) priceCart(ctx context.Context, cart *models.Cart) error {
+	// Unavailable items aren't priced and the user is warned about changes
+	cart.Revalidate()
+	items, err := baseItems(ctx, c.currencyStore, c.baseCurrency, cart.Items)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	promotions, err := automaticPromotions(ctx, c.promotionStore, cart.UserId, now)
+	if err != nil {
+		return err
+	}
+	if cart.PromoCode != "" {
+		promotion, err := codePromotion(ctx, c.promotionStore, cart.PromoCode, items, cart.UserId, now)
+		switch {
+		case err == nil:
+			promotions = append(promotions, *promotion)
+		case errors.Is(err, models.ErrPromoCode), errors.Is(err, models.ErrorNotFound{}):
+			c.logger.Sugar().Warnf("promo code %s of cart %v is not applied: %v", cart.PromoCode, cart.Id, err)
+		default:
+			return err
+		}
+	}
+	cart.Pricing = models.ApplyPromotions(items, promotions)
+	cart.Pricing.Currency = c.baseCurrency
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository/mocks"
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -14,8 +16,9 @@ import (
 
 var (
 	testModelsCart = &models.Cart{
-		Id:    testId,
-		Items: testItems,
+		Id:     testId,
+		UserId: testId,
+		Items:  testItems,
 	}
 	testItem = models.ItemWithQuantity{
 		Quantity: 1,
@@ -33,8 +36,11 @@ func TestGetCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, promotionRepo, nil, nil, "RUB", models.CartTTL{}, logger)
 	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	cartRepo.EXPECT().GetCart(ctx, testId).Return(nil, err)
 	res, err := usecase.GetCart(ctx, testId)
@@ -42,6 +48,7 @@ func TestGetCart(t *testing.T) {
 	require.Nil(t, res)
 
 	cartRepo.EXPECT().GetCart(ctx, testId).Return(testModelsCart, nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
 	res, err = usecase.GetCart(ctx, testId)
 	require.NoError(t, err)
 	require.NotNil(t, res)
@@ -53,8 +60,11 @@ func TestGetCartByUserId(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, promotionRepo, nil, nil, "RUB", models.CartTTL{}, logger)
 	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	cartRepo.EXPECT().GetCartByUserId(ctx, testId).Return(nil, err)
 	res, err := usecase.GetCartByUserId(ctx, testId)
@@ -62,6 +72,7 @@ func TestGetCartByUserId(t *testing.T) {
 	require.Nil(t, res)
 
 	cartRepo.EXPECT().GetCartByUserId(ctx, testId).Return(testModelsCart, nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
 	res, err = usecase.GetCartByUserId(ctx, testId)
 	require.NoError(t, err)
 	require.NotNil(t, res)
@@ -73,8 +84,10 @@ func TestDeleteItemFromCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
 	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	cartRepo.EXPECT().DeleteItemFromCart(ctx, testId, testId).Return(err)
 	err := usecase.DeleteItemFromCart(ctx, testId, testId)
@@ -90,8 +103,10 @@ func TestCreate(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
 	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	cartRepo.EXPECT().Create(ctx, testId).Return(uuid.Nil, err)
 	res, err := usecase.Create(ctx, testId)
@@ -109,8 +124,10 @@ func TestAddItemToCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
 	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId).Return(err)
 	err := usecase.AddItemToCart(ctx, testId, testId)
@@ -126,7 +143,7 @@ func TestDeleteCart(t *testing.T) {
 	defer ctrl.Finish()
 	logger := zap.L()
 	cartRepo := mocks.NewMockCartStore(ctrl)
-	usecase := NewCartUseCase(cartRepo, logger)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
 	ctx := context.Background()
 
 	cartRepo.EXPECT().DeleteCart(ctx, testId).Return(err)
@@ -137,3 +154,418 @@ func TestDeleteCart(t *testing.T) {
 	err = usecase.DeleteCart(ctx, testId)
 	require.NoError(t, err)
 }
+
+func TestGetCartDiscounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, promotionRepo, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	categoryId := uuid.New()
+	userId := uuid.New()
+	newCart := func(code string) *models.Cart {
+		return &models.Cart{
+			Id:     testId,
+			UserId: userId,
+			Items: []models.ItemWithQuantity{
+				{Item: models.Item{Id: testId, Price: 1000, Category: models.Category{Id: categoryId}}, Quantity: 2},
+				{Item: models.Item{Id: uuid.New(), Price: 500}, Quantity: 1},
+			},
+			PromoCode: code,
+		}
+	}
+	automatic := []models.Promotion{
+		{Id: uuid.New(), Name: "Category", Kind: models.DiscountPercent, Value: 10, Scope: models.ScopeCategory, ScopeId: categoryId, Active: true},
+		{Id: uuid.New(), Name: "Big order", Kind: models.DiscountFixed, Value: 300, Scope: models.ScopeCart, MinOrder: 3000, Active: true},
+		{Id: uuid.New(), Name: "Once", Kind: models.DiscountFixed, Value: 50, Scope: models.ScopeCart, PerUserLimit: 1, Active: true},
+		{Id: uuid.New(), Name: "Future", Kind: models.DiscountFixed, Value: 50, Scope: models.ScopeCart, Active: true,
+			StartsAt: time.Now().Add(time.Hour)},
+	}
+	code := &models.Promotion{Id: uuid.New(), Code: "ITEM", Name: "Item", Kind: models.DiscountFixed, Value: 5000,
+		Scope: models.ScopeItem, ScopeId: testId, UsageLimit: 10, Used: 3, Active: true}
+
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(newCart(""), nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(automatic, nil)
+	promotionRepo.EXPECT().CountUserUsages(ctx, automatic[2].Id, userId).Return(1, nil)
+	res, err := usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, models.Pricing{
+		Currency:  "RUB",
+		Subtotal:  2500,
+		Discounts: []models.AppliedDiscount{{PromotionId: automatic[0].Id, Name: "Category", Amount: 200}},
+		Total:     2300,
+	}, res.Pricing)
+
+	// The fixed discount is limited by price of discounted items
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(newCart("item"), nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(automatic[:1], nil)
+	promotionRepo.EXPECT().GetPromotionByCode(ctx, "ITEM").Return(code, nil)
+	res, err = usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, []models.AppliedDiscount{
+		{PromotionId: automatic[0].Id, Name: "Category", Amount: 200},
+		{PromotionId: code.Id, Code: "ITEM", Name: "Item", Amount: 2000},
+	}, res.Discounts)
+	require.Equal(t, int64(300), res.Total)
+
+	// Expired promo code is shown without discount
+	expired := *code
+	expired.EndsAt = time.Now().Add(-time.Minute)
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(newCart("ITEM"), nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
+	promotionRepo.EXPECT().GetPromotionByCode(ctx, "ITEM").Return(&expired, nil)
+	res, err = usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, "ITEM", res.PromoCode)
+	require.Empty(t, res.Discounts)
+	require.Equal(t, int64(2500), res.Total)
+
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(newCart(""), nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, fmt.Errorf("error"))
+	_, err = usecase.GetCart(ctx, testId)
+	require.Error(t, err)
+}
+
+func TestApplyPromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, promotionRepo, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	newCart := func() *models.Cart {
+		return &models.Cart{
+			Id:       testId,
+			Items:    []models.ItemWithQuantity{{Item: models.Item{Id: testId, Price: 1000}, Quantity: 1}},
+			ExpireAt: time.Now().Add(time.Hour),
+		}
+	}
+	promotion := models.Promotion{Id: uuid.New(), Code: "SALE", Name: "Sale", Kind: models.DiscountPercent, Value: 20,
+		Scope: models.ScopeCart, Active: true}
+
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(nil, models.ErrorNotFound{})
+	_, err := usecase.ApplyPromoCode(ctx, testId, "sale")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	tests := []struct {
+		change func(promotion *models.Promotion)
+		err    error
+	}{
+		{func(promotion *models.Promotion) { promotion.Active = false }, models.ErrPromoNotActive},
+		{func(promotion *models.Promotion) { promotion.UsageLimit = 1; promotion.Used = 1 }, models.ErrPromoLimitReached},
+		{func(promotion *models.Promotion) { promotion.MinOrder = 5000 }, models.ErrPromoMinOrder},
+		{func(promotion *models.Promotion) {
+			promotion.Scope = models.ScopeCategory
+			promotion.ScopeId = uuid.New()
+		}, models.ErrPromoNotApplicable},
+	}
+	for _, test := range tests {
+		invalid := promotion
+		test.change(&invalid)
+		cartRepo.EXPECT().GetCart(ctx, testId).Return(newCart(), nil)
+		promotionRepo.EXPECT().GetPromotionByCode(ctx, "SALE").Return(&invalid, nil)
+		_, err = usecase.ApplyPromoCode(ctx, testId, "sale")
+		require.ErrorIs(t, err, test.err)
+		require.ErrorIs(t, err, models.ErrPromoCode)
+	}
+
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(newCart(), nil)
+	promotionRepo.EXPECT().GetPromotionByCode(ctx, "SALE").Return(&promotion, nil).Times(2)
+	cartRepo.EXPECT().SetPromoCode(ctx, testId, "SALE").Return(nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
+	res, err := usecase.ApplyPromoCode(ctx, testId, " sale ")
+	require.NoError(t, err)
+	require.Equal(t, "SALE", res.PromoCode)
+	require.Equal(t, int64(800), res.Total)
+
+	cartRepo.EXPECT().SetPromoCode(ctx, testId, "").Return(nil)
+	require.NoError(t, usecase.RemovePromoCode(ctx, testId))
+}
+
+func TestMergeGuestCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	guestCartId := uuid.New()
+	userCartId := uuid.New()
+	userId := uuid.New()
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(nil, models.ErrorNotFound{})
+	_, err := usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(&models.Cart{Id: guestCartId, UserId: uuid.New()}, nil)
+	_, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.ErrorIs(t, err, models.ErrNotGuestCart)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(&models.Cart{Id: guestCartId, ExpireAt: time.Now().Add(time.Hour)}, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(&models.Cart{Id: userCartId, UserId: userId}, nil)
+	cartRepo.EXPECT().MergeCarts(ctx, guestCartId, userCartId).Return(nil)
+	res, err := usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.NoError(t, err)
+	require.Equal(t, userCartId, res)
+
+	cartRepo.EXPECT().GetCart(ctx, guestCartId).Return(&models.Cart{Id: guestCartId, ExpireAt: time.Now().Add(time.Hour)}, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(nil, models.ErrorNotFound{})
+	cartRepo.EXPECT().Create(ctx, userId).Return(userCartId, nil)
+	cartRepo.EXPECT().MergeCarts(ctx, guestCartId, userCartId).Return(fmt.Errorf("error"))
+	_, err = usecase.MergeGuestCart(ctx, guestCartId, userId)
+	require.Error(t, err)
+
+	cartRepo.EXPECT().GetCart(ctx, userCartId).Return(&models.Cart{Id: userCartId, UserId: userId}, nil)
+	res, err = usecase.MergeGuestCart(ctx, userCartId, userId)
+	require.NoError(t, err)
+	require.Equal(t, userCartId, res)
+}
+
+func TestSetItemQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	itemId := uuid.New()
+	limits := models.QuantityLimits{Min: 2, Max: 10}
+
+	err := usecase.SetItemQuantity(ctx, testId, itemId, -1)
+	require.ErrorIs(t, err, models.ErrInvalidQuantity)
+
+	cartRepo.EXPECT().GetQuantityLimits(ctx, itemId).Return(limits, nil)
+	err = usecase.SetItemQuantity(ctx, testId, itemId, 1)
+	require.ErrorIs(t, err, models.ErrQuantityLimit)
+
+	cartRepo.EXPECT().GetQuantityLimits(ctx, itemId).Return(limits, nil)
+	err = usecase.SetItemQuantity(ctx, testId, itemId, 11)
+	require.ErrorIs(t, err, models.ErrQuantityLimit)
+
+	cartRepo.EXPECT().GetQuantityLimits(ctx, itemId).Return(limits, nil)
+	cartRepo.EXPECT().SetItemQuantity(ctx, testId, itemId, 10).Return(nil)
+	err = usecase.SetItemQuantity(ctx, testId, itemId, 10)
+	require.NoError(t, err)
+
+	// Zero quantity removes the item without check of limits
+	cartRepo.EXPECT().SetItemQuantity(ctx, testId, itemId, 0).Return(nil)
+	err = usecase.SetItemQuantity(ctx, testId, itemId, 0)
+	require.NoError(t, err)
+}
+
+func TestDecrementItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	itemId := uuid.New()
+
+	_, err := usecase.DecrementItem(ctx, testId, itemId, 0)
+	require.ErrorIs(t, err, models.ErrInvalidQuantity)
+
+	cartRepo.EXPECT().GetQuantityLimits(ctx, itemId).Return(models.QuantityLimits{}, models.ErrorNotFound{})
+	_, err = usecase.DecrementItem(ctx, testId, itemId, 1)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	cartRepo.EXPECT().GetQuantityLimits(ctx, itemId).Return(models.QuantityLimits{Min: 2}, nil)
+	cartRepo.EXPECT().DecrementItem(ctx, testId, itemId, 1, 2).Return(0, nil)
+	res, err := usecase.DecrementItem(ctx, testId, itemId, 1)
+	require.NoError(t, err)
+	require.Equal(t, 0, res)
+}
+
+func TestCartExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	ttl := models.CartTTL{Guest: time.Hour, User: 24 * time.Hour}
+	usecase := NewCartUseCase(cartRepo, promotionRepo, nil, nil, "RUB", ttl, logger)
+	ctx := context.Background()
+
+	before := time.Now()
+	cartRepo.EXPECT().AddItemToCart(ctx, testId, testId).Return(nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, cartId uuid.UUID, guestExpireAt time.Time, userExpireAt time.Time) error {
+			require.WithinDuration(t, before.Add(time.Hour), guestExpireAt, time.Second)
+			require.WithinDuration(t, before.Add(24*time.Hour), userExpireAt, time.Second)
+			return nil
+		})
+	err := usecase.AddItemToCart(ctx, testId, testId)
+	require.NoError(t, err)
+
+	// Error of refresh doesn't fail the activity
+	expireAt := time.Now().Add(time.Minute)
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(&models.Cart{Id: testId, ExpireAt: expireAt}, nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
+	res, err := usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, expireAt, res.ExpireAt)
+
+	// Expired guest cart isn't prolonged, it is deleted
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(&models.Cart{Id: testId, ExpireAt: time.Now().Add(-time.Minute)}, nil)
+	cartRepo.EXPECT().DeleteCart(ctx, testId).Return(nil)
+	_, err = usecase.GetCart(ctx, testId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Expiry of the cart of the user is informational
+	cartRepo.EXPECT().GetCartByUserId(ctx, testId).Return(&models.Cart{Id: testId, UserId: testId, ExpireAt: time.Now().Add(-time.Minute)}, nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).Return(nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
+	_, err = usecase.GetCartByUserId(ctx, testId)
+	require.NoError(t, err)
+
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(&models.Cart{Id: testId, UserId: testId}, nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).Return(nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
+	res, err = usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), res.ExpireAt, time.Second)
+}
+
+func TestGetCartWarnings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, promotionRepo, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+
+	deleted := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Deleted", Price: 1000, Currency: "RUB"}, Quantity: 1}
+	reduced := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Reduced", Price: 1000, Currency: "RUB"}, Quantity: 3}
+	changed := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Changed", Price: 1500, Currency: "RUB"}, Quantity: 1}
+	unchanged := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Unchanged", Price: 500, Currency: "RUB"}, Quantity: 1}
+	stock := 2
+	oldPrice := models.Money{Amount: 1000, Currency: "RUB"}
+	unchangedPrice := unchanged.Money()
+	cart := &models.Cart{
+		Id:       testId,
+		Items:    []models.ItemWithQuantity{deleted, reduced, changed, unchanged},
+		ExpireAt: time.Now().Add(time.Hour),
+		Lines: map[uuid.UUID]models.CartLine{
+			deleted.Id:   {Deleted: true},
+			reduced.Id:   {Stock: &stock},
+			changed.Id:   {AddedPrice: &oldPrice},
+			unchanged.Id: {AddedPrice: &unchangedPrice},
+		},
+	}
+	cartRepo.EXPECT().GetCart(ctx, testId).Return(cart, nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).Return(nil)
+	promotionRepo.EXPECT().GetAutomaticPromotions(ctx).Return(nil, nil)
+	res, err := usecase.GetCart(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, []models.CartWarning{
+		{Kind: models.WarningUnavailable, ItemId: deleted.Id, Title: "Deleted"},
+		{Kind: models.WarningQuantityReduced, ItemId: reduced.Id, Title: "Reduced", Requested: 3, Available: 2},
+		{Kind: models.WarningPriceChanged, ItemId: changed.Id, Title: "Changed", OldPrice: oldPrice, NewPrice: changed.Money()},
+	}, res.Warnings)
+	// Unavailable item isn't priced, reduced quantity is priced
+	require.Len(t, res.Items, 3)
+	require.Equal(t, int64(2*1000+1500+500), res.Subtotal)
+}
+
+func TestPurgeExpiredCarts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+
+	cartRepo.EXPECT().DeleteExpiredCarts(ctx, gomock.Any()).Return(0, err)
+	_, err := usecase.PurgeExpiredCarts(ctx)
+	require.Error(t, err)
+
+	cartRepo.EXPECT().DeleteExpiredCarts(ctx, gomock.Any()).Return(3, nil)
+	guests, err := usecase.PurgeExpiredCarts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, guests)
+}
+
+func TestReorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	usecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
+	ctx := context.Background()
+	userId := uuid.New()
+
+	available := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Available"}, Quantity: 2}
+	reduced := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Reduced"}, Quantity: 3}
+	deleted := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Deleted"}, Quantity: 1}
+	missing := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Missing"}, Quantity: 1}
+	soldOut := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Title: "Sold out"}, Quantity: 1}
+	order := &models.Order{
+		ID:    uuid.New(),
+		User:  models.User{ID: userId},
+		Items: []models.ItemWithQuantity{available, reduced, deleted, missing, soldOut},
+	}
+
+	// Orders of other users are not found
+	_, err := usecase.Reorder(ctx, uuid.New(), order)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	stock := 4
+	empty := 0
+	limits := models.QuantityLimits{Min: 1}
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(&models.Cart{
+		Id:    testId,
+		Items: []models.ItemWithQuantity{{Item: models.Item{Id: reduced.Id}, Quantity: 2}},
+	}, nil)
+	cartRepo.EXPECT().GetItemsAvailability(ctx, []uuid.UUID{available.Id, reduced.Id, deleted.Id, missing.Id, soldOut.Id}).Return(
+		map[uuid.UUID]models.ItemAvailability{
+			available.Id: {Limits: limits},
+			reduced.Id:   {Stock: &stock, Limits: limits},
+			deleted.Id:   {Deleted: true, Limits: limits},
+			soldOut.Id:   {Stock: &empty, Limits: limits},
+		}, nil)
+	// Quantity in the cart is limited by the stock
+	cartRepo.EXPECT().AddItemsToCart(ctx, testId, map[uuid.UUID]int{available.Id: 2, reduced.Id: 2}).Return(nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).Return(nil)
+	res, err := usecase.Reorder(ctx, userId, order)
+	require.NoError(t, err)
+	require.Equal(t, &models.Reorder{
+		CartId: testId,
+		Added: []models.ReorderLine{
+			{ItemId: available.Id, Title: "Available", Requested: 2, Added: 2},
+			{ItemId: reduced.Id, Title: "Reduced", Requested: 3, Added: 2},
+		},
+		Skipped: []models.ReorderLine{
+			{ItemId: deleted.Id, Title: "Deleted", Requested: 1, Reason: models.ReorderDeleted},
+			{ItemId: missing.Id, Title: "Missing", Requested: 1, Reason: models.ReorderDeleted},
+			{ItemId: soldOut.Id, Title: "Sold out", Requested: 1, Reason: models.ReorderUnavailable},
+		},
+	}, res)
+
+	// The cart is created for the user without cart, nothing is added
+	// if all items are unavailable
+	order.Items = []models.ItemWithQuantity{soldOut}
+	cartRepo.EXPECT().GetCartByUserId(ctx, userId).Return(nil, models.ErrorNotFound{})
+	cartRepo.EXPECT().Create(ctx, userId).Return(testId, nil)
+	cartRepo.EXPECT().GetItemsAvailability(ctx, []uuid.UUID{soldOut.Id}).Return(
+		map[uuid.UUID]models.ItemAvailability{soldOut.Id: {Stock: &empty, Limits: limits}}, nil)
+	cartRepo.EXPECT().RefreshCartExpiry(ctx, testId, gomock.Any(), gomock.Any()).Return(nil)
+	res, err = usecase.Reorder(ctx, userId, order)
+	require.NoError(t, err)
+	require.Empty(t, res.Added)
+	require.Len(t, res.Skipped, 1)
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/catalogue"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/repository/cash"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ ICatalogueUsecase = &CatalogueUsecase{}
+
+// maxImportErrors limits the number of row errors kept in the import
+// report, the rest of failed rows are only counted
+const maxImportErrors = 1000
+
+// maxSkuLength is a size of sku column in the database
+const maxSkuLength = 256
+
+type CatalogueUsecase struct {
+	itemStore     repository.ItemStore
+	categoryStore repository.CategoryStore
+	cash          cash.ITagsCash
+	baseCurrency  string
+	logger        *zap.Logger
+}
+
+func NewCatalogueUsecase(itemStore repository.ItemStore, categoryStore repository.CategoryStore, cash cash.ITagsCash, baseCurrency string, logger *zap.Logger) ICatalogueUsecase {
+	logger.Debug("Enter in usecase NewCatalogueUsecase()")
+	return &CatalogueUsecase{itemStore: itemStore, categoryStore: categoryStore, cash: cash, baseCurrency: baseCurrency, logger: logger}
+}
+
+// importState keeps data shared by rows of one import
+type importState struct {
+	dryRun bool
+	report *models.ImportReport
+	// categories maps names of categories to their ids,
+	// in dry run not yet created categories have nil id
+	categories map[string]uuid.UUID
+	// skus contains skus already imported in dry run
+	skus map[string]bool
+	// tags of cache invalidated after import
+	tags map[string]bool
+}
+
+// Import reads catalogue in given format and creates or updates items by
+// their sku. Rows without sku create categories or update their description.
+// In dry run the database is not changed, but the report is the same.
+// Invalid rows are reported and skipped, other errors stop the import
+func (usecase *CatalogueUsecase) Import(ctx context.Context, r io.Reader, format string, dryRun bool) (*models.ImportReport, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Import() with args: ctx, r, format: %s, dryRun: %t", format, dryRun)
+	reader, err := catalogue.NewReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+	state := &importState{
+		dryRun:     dryRun,
+		report:     &models.ImportReport{DryRun: dryRun, Errors: []models.ImportRowError{}},
+		categories: make(map[string]uuid.UUID),
+		skus:       make(map[string]bool),
+		tags:       make(map[string]bool),
+	}
+	// Invalidate cache of all imported rows also if the import is stopped
+	defer usecase.invalidate(state)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return state.report, err
+		}
+		record, line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *catalogue.RowError
+		if errors.As(err, &rowErr) {
+			state.report.Total++
+			state.addError(rowErr.Line, "", rowErr.Err)
+			continue
+		}
+		if err != nil {
+			return state.report, fmt.Errorf("error on read catalogue: %w", err)
+		}
+		state.report.Total++
+		err = usecase.importRecord(ctx, state, record)
+		if err != nil {
+			state.addError(line, record.Sku, err)
+		}
+	}
+	usecase.logger.Sugar().Infof("Import of catalogue finished: total: %d, created: %d, updated: %d, failed: %d",
+		state.report.Total, state.report.Created, state.report.Updated, state.report.Failed)
+	return state.report, nil
+}
+
+// importRecord imports one row of catalogue
+func (usecase *CatalogueUsecase) importRecord(ctx context.Context, state *importState, record *models.CatalogueRecord) error {
+	err := validateRecord(record)
+	if err != nil {
+		return err
+	}
+	categoryId, created, err := usecase.importCategory(ctx, state, record)
+	if err != nil {
+		return err
+	}
+	if record.IsCategory() {
+		if created {
+			state.report.Created++
+		} else {
+			state.report.Updated++
+		}
+		return nil
+	}
+	// Price without currency is in the base currency
+	err = defaultCurrency(&record.Currency, usecase.baseCurrency)
+	if err != nil {
+		return err
+	}
+
+	if state.dryRun {
+		exists := state.skus[record.Sku]
+		if !exists {
+			_, err = usecase.itemStore.GetItemIdBySku(ctx, record.Sku)
+			if err != nil && !errors.Is(err, models.ErrorNotFound{}) {
+				return fmt.Errorf("error on get item by sku: %w", err)
+			}
+			exists = err == nil
+		}
+		state.skus[record.Sku] = true
+		if exists {
+			state.report.Updated++
+		} else {
+			state.report.Created++
+		}
+		return nil
+	}
+
+	item := &models.Item{
+		Sku:         record.Sku,
+		Title:       record.Title,
+		Description: record.Description,
+		Price:       record.Price,
+		Currency:    record.Currency,
+		Vendor:      record.Vendor,
+		Images:      models.ImagesFromURLs(record.Images),
+		Category:    models.Category{Id: categoryId, Name: record.Category},
+	}
+	_, created, err = usecase.itemStore.UpsertItemBySku(ctx, item)
+	if err != nil {
+		return fmt.Errorf("error on upsert item: %w", err)
+	}
+	state.tags[cash.TagItems] = true
+	state.tags[cash.CategoryTag(record.Category)] = true
+	if created {
+		state.report.Created++
+	} else {
+		state.report.Updated++
+	}
+	return nil
+}
+
+// importCategory returns id of category of the record, missing category is
+// created. Category row updates description of existing category
+func (usecase *CatalogueUsecase) importCategory(ctx context.Context, state *importState, record *models.CatalogueRecord) (uuid.UUID, bool, error) {
+	id, ok := state.categories[record.Category]
+	if ok && !record.IsCategory() {
+		return id, false, nil
+	}
+	category, err := usecase.categoryStore.GetCategoryByName(ctx, record.Category)
+	if err != nil && !errors.Is(err, models.ErrorNotFound{}) {
+		return uuid.Nil, false, fmt.Errorf("error on get category: %w", err)
+	}
+	if err != nil {
+		if ok {
+			// Category is created earlier in dry run
+			return id, false, nil
+		}
+		if !state.dryRun {
+			id, err = usecase.categoryStore.CreateCategory(ctx, &models.Category{
+				Name:        record.Category,
+				Description: record.CategoryDescription,
+			})
+			if err != nil {
+				return uuid.Nil, false, fmt.Errorf("error on create category: %w", err)
+			}
+			state.tags[cash.TagCategories] = true
+		}
+		state.categories[record.Category] = id
+		return id, true, nil
+	}
+
+	state.categories[record.Category] = category.Id
+	if record.IsCategory() && record.CategoryDescription != "" &&
+		record.CategoryDescription != category.Description && !state.dryRun {
+		category.Description = record.CategoryDescription
+		err = usecase.categoryStore.UpdateCategory(ctx, category)
+		if err != nil {
+			return uuid.Nil, false, fmt.Errorf("error on update category: %w", err)
+		}
+		// Items lists contain data of their categories
+		state.tags[cash.TagCategories] = true
+		state.tags[cash.TagItems] = true
+		state.tags[cash.CategoryTag(category.Name)] = true
+	}
+	return category.Id, false, nil
+}
+
+// validateRecord checks required fields of the record
+func validateRecord(record *models.CatalogueRecord) error {
+	if record.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if record.IsCategory() {
+		if record.Title != "" {
+			return fmt.Errorf("sku is required for item")
+		}
+		return nil
+	}
+	if len(record.Sku) > maxSkuLength {
+		return fmt.Errorf("sku is longer than %d", maxSkuLength)
+	}
+	if record.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if record.Price <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+	return nil
+}
+
+// addError adds error of the row to the report
+func (state *importState) addError(line int, sku string, err error) {
+	state.report.Failed++
+	if len(state.report.Errors) < maxImportErrors {
+		state.report.Errors = append(state.report.Errors, models.ImportRowError{
+			Line:  line,
+			Sku:   sku,
+			Error: err.Error(),
+		})
+	}
+}
+
+// invalidate bumps generations of tags changed by import
+func (usecase *CatalogueUsecase) invalidate(state *importState) {
+	if len(state.tags) == 0 {
+		return
+	}
+	tags := make([]string, 0, len(state.tags))
+	for tag := range state.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	// Import may be stopped by cancelled context, but cache
+	// must be invalidated anyway
+	ctx, cancel := context.WithTimeout(context.Background(), cashTimeout)
+	defer cancel()
+	err := usecase.cash.InvalidateTags(ctx, tags...)
+	if err != nil {
+		usecase.logger.Sugar().Errorf("error on invalidate cash tags: %v, error: %v", tags, err)
+		return
+	}
+	usecase.logger.Sugar().Debugf("Cash tags: %v invalidated success", tags)
+}
+
+// Export writes all categories and items in given format,
+// categories are written first as rows without sku
+func (usecase *CatalogueUsecase) Export(ctx context.Context, w io.Writer, format string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase Export() with args: ctx, w, format: %s", format)
+	writer, err := catalogue.NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+	categories, err := usecase.categoryStore.GetCategoryList(ctx)
+	if err != nil {
+		return fmt.Errorf("error on get categories: %w", err)
+	}
+	for category := range categories {
+		if err != nil {
+			// Drain the channel to release the database connection
+			continue
+		}
+		err = writer.Write(&models.CatalogueRecord{
+			Category:            category.Name,
+			CategoryDescription: category.Description,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error on write category: %w", err)
+	}
+
+	items, err := usecase.itemStore.ItemsList(ctx)
+	if err != nil {
+		return fmt.Errorf("error on get items: %w", err)
+	}
+	for item := range items {
+		if err != nil {
+			continue
+		}
+		err = writer.Write(&models.CatalogueRecord{
+			Sku:         item.Sku,
+			Title:       item.Title,
+			Description: item.Description,
+			Price:       item.Price,
+			Currency:    item.Currency,
+			Category:    item.Category.Name,
+			Vendor:      item.Vendor,
+			Images:      item.ImageURLs(),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error on write item: %w", err)
+	}
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("error on flush catalogue: %w", err)
+	}
+	usecase.logger.Info("Export of catalogue success")
+	return nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/catalogue"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testCatalogue = `sku,title,description,price,category,category_description,vendor,images
+,,,,Tools,Tools for home,,
+SKU-1,Hammer,Steel hammer,1200,Tools,,Acme,
+SKU-2,Saw,,900,Garden,,,
+SKU-3,Nail,,0,Tools,,,
+SKU-1,Hammer,Steel hammer,1300,Tools,,Acme,
+`
+
+func TestImportCatalogueDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	categoryRepo := mocks.NewMockCategoryStore(ctrl)
+	cash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewCatalogueUsecase(itemRepo, categoryRepo, cash, "RUB", zap.L())
+
+	tools := &models.Category{Id: uuid.New(), Name: "Tools", Description: "Tools"}
+	categoryRepo.EXPECT().GetCategoryByName(ctx, "Tools").Return(tools, nil)
+	categoryRepo.EXPECT().GetCategoryByName(ctx, "Garden").Return(&models.Category{}, models.ErrorNotFound{})
+	itemRepo.EXPECT().GetItemIdBySku(ctx, "SKU-1").Return(uuid.New(), nil)
+	itemRepo.EXPECT().GetItemIdBySku(ctx, "SKU-2").Return(uuid.Nil, models.ErrorNotFound{})
+
+	report, err := usecase.Import(ctx, strings.NewReader(testCatalogue), catalogue.FormatCSV, true)
+	require.NoError(t, err)
+	require.Equal(t, &models.ImportReport{
+		DryRun:  true,
+		Total:   5,
+		Created: 1,
+		Updated: 3,
+		Failed:  1,
+		Errors:  []models.ImportRowError{{Line: 5, Sku: "SKU-3", Error: "price must be positive"}},
+	}, report)
+}
+
+func TestImportCatalogue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	categoryRepo := mocks.NewMockCategoryStore(ctrl)
+	cash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewCatalogueUsecase(itemRepo, categoryRepo, cash, "RUB", zap.L())
+
+	tools := &models.Category{Id: uuid.New(), Name: "Tools", Description: "Tools"}
+	gardenId := uuid.New()
+	categoryRepo.EXPECT().GetCategoryByName(ctx, "Tools").Return(tools, nil)
+	categoryRepo.EXPECT().UpdateCategory(ctx, &models.Category{Id: tools.Id, Name: "Tools", Description: "Tools for home"}).Return(nil)
+	categoryRepo.EXPECT().GetCategoryByName(ctx, "Garden").Return(&models.Category{}, models.ErrorNotFound{})
+	categoryRepo.EXPECT().CreateCategory(ctx, &models.Category{Name: "Garden"}).Return(gardenId, nil)
+	hammer := &models.Item{
+		Sku:         "SKU-1",
+		Title:       "Hammer",
+		Description: "Steel hammer",
+		Price:       1200,
+		Currency:    "RUB",
+		Vendor:      "Acme",
+		Category:    models.Category{Id: tools.Id, Name: "Tools"},
+	}
+	itemRepo.EXPECT().UpsertItemBySku(ctx, hammer).Return(uuid.New(), false, nil)
+	itemRepo.EXPECT().UpsertItemBySku(ctx, &models.Item{
+		Sku:      "SKU-2",
+		Title:    "Saw",
+		Price:    900,
+		Currency: "RUB",
+		Category: models.Category{Id: gardenId, Name: "Garden"},
+	}).Return(uuid.New(), true, nil)
+	hammerNewPrice := *hammer
+	hammerNewPrice.Price = 1300
+	itemRepo.EXPECT().UpsertItemBySku(ctx, &hammerNewPrice).Return(uuid.Nil, false, fmt.Errorf("error"))
+	cash.EXPECT().InvalidateTags(gomock.Any(), "categories", "category:Garden", "category:Tools", "items").Return(nil)
+
+	report, err := usecase.Import(ctx, strings.NewReader(testCatalogue), catalogue.FormatCSV, false)
+	require.NoError(t, err)
+	require.Equal(t, 5, report.Total)
+	require.Equal(t, 1, report.Created)
+	require.Equal(t, 2, report.Updated)
+	require.Equal(t, 2, report.Failed)
+	require.Len(t, report.Errors, 2)
+	require.Equal(t, 6, report.Errors[1].Line)
+	require.Equal(t, "SKU-1", report.Errors[1].Sku)
+
+	_, err = usecase.Import(ctx, strings.NewReader(""), "xml", false)
+	require.ErrorIs(t, err, catalogue.ErrUnsupportedFormat)
+
+	// Database is unavailable, the row is reported and nothing is invalidated
+	categoryRepo.EXPECT().GetCategoryByName(ctx, "Tools").Return(nil, fmt.Errorf("error"))
+	report, err = usecase.Import(ctx, strings.NewReader(`{"sku":"SKU-1","title":"Hammer","price":1,"category":"Tools"}`), catalogue.FormatJSONL, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Failed)
+}
+
+func TestExportCatalogue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	categoryRepo := mocks.NewMockCategoryStore(ctrl)
+	cash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewCatalogueUsecase(itemRepo, categoryRepo, cash, "RUB", zap.L())
+
+	categoriesChan := make(chan models.Category, 1)
+	categoriesChan <- models.Category{Id: uuid.New(), Name: "Tools", Description: "Tools for home"}
+	close(categoriesChan)
+	itemsChan := make(chan models.Item, 1)
+	itemsChan <- models.Item{
+		Sku:      "SKU-1",
+		Title:    "Hammer",
+		Price:    1200,
+		Currency: "USD",
+		Category: models.Category{Name: "Tools"},
+		Images:   models.ImagesFromURLs([]string{"1.jpeg", "2.jpeg"}),
+	}
+	close(itemsChan)
+	categoryRepo.EXPECT().GetCategoryList(ctx).Return(categoriesChan, nil)
+	itemRepo.EXPECT().ItemsList(ctx).Return(itemsChan, nil)
+
+	buf := &bytes.Buffer{}
+	err := usecase.Export(ctx, buf, catalogue.FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, `sku,title,description,price,currency,category,category_description,vendor,images
+,,,,,Tools,Tools for home,,
+SKU-1,Hammer,,1200,USD,Tools,,,1.jpeg|2.jpeg
+`, buf.String())
+
+	categoryRepo.EXPECT().GetCategoryList(ctx).Return(nil, fmt.Errorf("error"))
+	err = usecase.Export(ctx, buf, catalogue.FormatJSONL)
+	require.Error(t, err)
+
+	err = usecase.Export(ctx, buf, "xml")
+	require.ErrorIs(t, err, catalogue.ErrUnsupportedFormat)
+}
@@ -6,23 +6,20 @@ import (
 	"OnlineShopBackend/internal/repository/cash"
 	"context"
 	"fmt"
-	"sort"
-	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 var _ ICategoryUsecase = &CategoryUsecase{}
 
-var (
-	categoriesListKey = "CategoriesList"
-)
-
 type CategoryUsecase struct {
 	categoryStore  repository.CategoryStore
 	categoriesCash cash.ICategoriesCash
 	logger         *zap.Logger
+	// group merges concurrent database requests for the same missing cache key
+	group singleflight.Group
 }
 
 func NewCategoryUsecase(store repository.CategoryStore, cash cash.ICategoriesCash, logger *zap.Logger) ICategoryUsecase {
@@ -37,28 +34,27 @@ func (usecase *CategoryUsecase) CreateCategory(ctx context.Context, category *mo
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("error on create category: %w", err)
 	}
-	err = usecase.UpdateCash(ctx, id, "create")
-	if err != nil {
-		usecase.logger.Error(fmt.Sprintf("error on update cash: %v", err))
-	} else {
-		usecase.logger.Info("Update cash success")
-	}
+	usecase.invalidate(ctx, cash.TagCategories)
 	return id, nil
 }
 
 // UpdateCategory call database method to update category and returns error or nil
 func (usecase *CategoryUsecase) UpdateCategory(ctx context.Context, category *models.Category) error {
 	usecase.logger.Sugar().Debugf("Enter in usecase UpdateCategory() with args: ctx, category: %v", category)
-	err := usecase.categoryStore.UpdateCategory(ctx, category)
+	// Items lists contain data of their categories, so they
+	// are invalidated together with the list of categories
+	tags := []string{cash.TagCategories, cash.TagItems, cash.CategoryTag(category.Name)}
+	before, err := usecase.categoryStore.GetCategory(ctx, category.Id)
 	if err != nil {
-		return fmt.Errorf("error on update category: %w", err)
+		usecase.logger.Sugar().Warnf("error on get category before update: %v", err)
+	} else if before.Name != category.Name {
+		tags = append(tags, cash.CategoryTag(before.Name))
 	}
-	err = usecase.UpdateCash(ctx, category.Id, "update")
+	err = usecase.categoryStore.UpdateCategory(ctx, category)
 	if err != nil {
-		usecase.logger.Error(fmt.Sprintf("error on update cash: %v", err))
-	} else {
-		usecase.logger.Info("Update cash success")
+		return fmt.Errorf("error on update category: %w", err)
 	}
+	usecase.invalidate(ctx, tags...)
 	return nil
 }
 
@@ -77,11 +73,29 @@ func (usecase *CategoryUsecase) GetCategoryList(ctx context.Context) ([]models.C
 	usecase.logger.Debug("Enter in usecase GetCategoryList() with args: ctx")
 
 	// Context with timeout so as not to wait for an answer from the cache for too long
-	ctxT, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	ctxT, cancel := context.WithTimeout(ctx, cashTimeout)
 	defer cancel()
 
+	useCash := true
+	generations, err := usecase.categoriesCash.GetGenerations(ctxT, cash.TagCategories)
+	if err != nil {
+		usecase.logger.Sugar().Warnf("error on get generations of tag: %s, error: %v", cash.TagCategories, err)
+		useCash = false
+	}
+	key := cash.Key(cash.CategoriesListNamespace, generations)
+
 	// Сheck whether there is a cache with a list of categories
-	if ok := usecase.categoriesCash.CheckCash(ctxT, categoriesListKey); !ok {
+	if useCash && usecase.categoriesCash.CheckCash(ctxT, key) {
+		// Get a list of categories from cache
+		categories, err := usecase.categoriesCash.GetCategoriesListCash(ctxT, key)
+		if err == nil && categories != nil {
+			usecase.logger.Info("Get category list from cash success")
+			return categories, nil
+		}
+		usecase.logger.Sugar().Warnf("error on get cash with key: %s, err: %v", key, err)
+	}
+
+	res, err, _ := usecase.group.Do(key, func() (interface{}, error) {
 		// If cache does not exist, request a list of categories from the database
 		categoryIncomingChan, err := usecase.categoryStore.GetCategoryList(ctx)
 		if err != nil {
@@ -91,47 +105,43 @@ func (usecase *CategoryUsecase) GetCategoryList(ctx context.Context) ([]models.C
 		for category := range categoryIncomingChan {
 			categories = append(categories, category)
 		}
+		if !useCash {
+			return categories, nil
+		}
+		ctxT, cancel := context.WithTimeout(ctx, cashTimeout)
+		defer cancel()
 		// Create a cache with a list of categories
-		err = usecase.categoriesCash.CreateCategoriesListCash(ctxT, categories, categoriesListKey)
+		err = usecase.categoriesCash.CreateCategoriesListCash(ctxT, categories, key)
 		if err != nil {
-			usecase.logger.Sugar().Warnf("error on create categories list cash with key: %s, error: %v", categoriesListKey, err)
+			usecase.logger.Sugar().Warnf("error on create categories list cash with key: %s, error: %v", key, err)
 		} else {
-			usecase.logger.Sugar().Infof("Create categories list cash with key: %s success", categoriesListKey)
+			usecase.logger.Sugar().Infof("Create categories list cash with key: %s success", key)
 		}
-	}
-
-	// Get a list of categories from cache
-	categories, err := usecase.categoriesCash.GetCategoriesListCash(ctxT, categoriesListKey)
-	if err != nil {
-		usecase.logger.Sugar().Warnf("error on get cash with key: %s, err: %v", categoriesListKey, err)
-		// If error on get cache, request a list of categories from the database
-		categoryIncomingChan, err := usecase.categoryStore.GetCategoryList(ctx)
-		if err != nil {
-			return nil, err
-		}
-		dbCategories := make([]models.Category, 0, 100)
-		for category := range categoryIncomingChan {
-			dbCategories = append(dbCategories, category)
-			categories = dbCategories
-		}
-		usecase.logger.Info("Get category list from db success")
 		return categories, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	usecase.logger.Info("Get category list from cash success")
-	return categories, nil
+	usecase.logger.Info("Get category list from db success")
+	return res.([]models.Category), nil
 }
 
 // DeleteCategory call database method for deleting category
 func (usecase *CategoryUsecase) DeleteCategory(ctx context.Context, id uuid.UUID) error {
 	usecase.logger.Sugar().Debugf("Enter in usecase DeleteCategory() with args: ctx, id: %v", id)
-	err := usecase.categoryStore.DeleteCategory(ctx, id)
+	tags := []string{cash.TagCategories, cash.TagItems}
+	// Get the deleted category to know its name
+	deleted, err := usecase.categoryStore.GetCategory(ctx, id)
 	if err != nil {
-		return err
+		usecase.logger.Sugar().Warnf("error on get deleted category: %v", err)
+	} else {
+		tags = append(tags, cash.CategoryTag(deleted.Name))
 	}
-	err = usecase.UpdateCash(ctx, id, "delete")
+	err = usecase.categoryStore.DeleteCategory(ctx, id)
 	if err != nil {
-		usecase.logger.Error(fmt.Sprintf("error on update cash: %v", err))
+		return err
 	}
+	usecase.invalidate(ctx, tags...)
 	usecase.logger.Info("Delete category success")
 	return nil
 }
@@ -147,80 +157,15 @@ func (usecase *CategoryUsecase) GetCategoryByName(ctx context.Context, name stri
 	return category, nil
 }
 
-// UpdateCash updating cash when creating or updating category
-func (usecase *CategoryUsecase) UpdateCash(ctx context.Context, id uuid.UUID, op string) error {
-	usecase.logger.Sugar().Debugf("Enter in usecase UpdateCash() with args: ctx, id: %v, op: %s", id, op)
-	// If the cache with such a key does not exist, we return the error, there is nothing to update
-	if !usecase.categoriesCash.CheckCash(ctx, categoriesListKey) {
-		return fmt.Errorf("cash is not exists")
-	}
-
-	// Get a category from the database for updating in the cache
-	newCategory, err := usecase.categoryStore.GetCategory(ctx, id)
-	if err != nil {
-		// If the error returned and the cache is updated in connection
-		// with the removal of the category, we use an empty category with the Id
-		if op == "delete" {
-			newCategory = &models.Category{Id: id}
-		} else {
-			return fmt.Errorf("error on get category: %w", err)
-		}
-	}
-	// Get a list of categories from cache
-	categories, err := usecase.categoriesCash.GetCategoriesListCash(ctx, categoriesListKey)
-	if err != nil {
-		return fmt.Errorf("error on get cash: %w", err)
-	}
-	// Change list of categories for update the cache
-	if op == "update" {
-		for i, category := range categories {
-			if category.Id == id {
-				categories[i] = *newCategory
-				break
-			}
-		}
-	}
-	if op == "create" {
-		categories = append(categories, *newCategory)
-	}
-	if op == "delete" {
-		for i, category := range categories {
-			if category.Id == id {
-				categories = append(categories[:i], categories[i+1:]...)
-				break
-			}
-		}
-	}
-	// Sort list of categories by name in alphabetical order
-	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
-	// Create new cache with list of categories
-	err = usecase.categoriesCash.CreateCategoriesListCash(ctx, categories, categoriesListKey)
-	if err != nil {
-		return err
-	}
-	usecase.logger.Info("Category cash update success")
-	return nil
-}
-
-// DeleteCategoryCash deleted cash after deleting categories
-func (usecase *CategoryUsecase) DeleteCategoryCash(ctx context.Context, name string) error {
-	usecase.logger.Debug(fmt.Sprintf("Enter in usecase DeleteCategoryCash() with args: ctx, name: %s", name))
-	// keys is a list of cache keys with items in deleted category sorting by name and price
-	keys := []string{name + "nameasc", name + "namedesc", name + "priceasc", name + "pricedesc"}
-	for _, key := range keys {
-		// For each key from list delete cache
-		err := usecase.categoriesCash.DeleteCash(ctx, key)
-		if err != nil {
-			usecase.logger.Error(fmt.Sprintf("error on delete cash with key: %s, error is %v", key, err))
-			return err
-		}
-	}
-	// Delete cache with quantity of items in deleted category
-	err := usecase.categoriesCash.DeleteCash(ctx, name+"Quantity")
+// invalidate bumps generations of tags, so the next
+// reads of dependent lists go to the database. The write is
+// already done, so the error is only logged: the cash retries
+// the invalidation and doesn't serve lists until it succeeds
+func (usecase *CategoryUsecase) invalidate(ctx context.Context, tags ...string) {
+	err := usecase.categoriesCash.InvalidateTags(ctx, tags...)
 	if err != nil {
-		usecase.logger.Error(fmt.Sprintf("error on delete cash with key: %s, error is %v", name, err))
-		return err
+		usecase.logger.Sugar().Errorf("error on invalidate cash tags: %v, error: %v", tags, err)
+		return
 	}
-	usecase.logger.Info("Category cash deleted success")
-	return nil
+	usecase.logger.Sugar().Debugf("Cash tags: %v invalidated success", tags)
 }
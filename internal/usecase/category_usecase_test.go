@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
 	"OnlineShopBackend/internal/repository/mocks"
 	"context"
 	"fmt"
@@ -23,9 +24,10 @@ var (
 	}
 	emptyCategory = &models.Category{}
 	testId        = uuid.New()
-	testChan      = make(chan models.Category, 2)
 
 	categories = []models.Category{*testModelCategoryWithId}
+
+	categoriesListKey = cash.Key(cash.CategoriesListNamespace, testGenerations)
 )
 
 func TestCreateCategory(t *testing.T) {
@@ -38,22 +40,16 @@ func TestCreateCategory(t *testing.T) {
 	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
 
 	categoryRepo.EXPECT().CreateCategory(ctx, testModelCategory).Return(testId, nil)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
+	cash.EXPECT().InvalidateTags(ctx, "categories").Return(nil)
 	res, err := usecase.CreateCategory(ctx, testModelCategory)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, testId)
 
-	emptyCategories := make([]models.Category, 0)
-	categories := []models.Category{*testModelCategoryWithId}
 	categoryRepo.EXPECT().CreateCategory(ctx, testModelCategory).Return(testId, nil)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
-	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(emptyCategories, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(nil)
+	cash.EXPECT().InvalidateTags(ctx, "categories").Return(fmt.Errorf("error"))
 	res, err = usecase.CreateCategory(ctx, testModelCategory)
 	require.NoError(t, err)
-	require.NotNil(t, res)
 	require.Equal(t, res, testId)
 
 	err = fmt.Errorf("error on create category")
@@ -72,20 +68,21 @@ func TestUpdateCategory(t *testing.T) {
 	cash := mocks.NewMockICategoriesCash(ctrl)
 	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
 
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
 	categoryRepo.EXPECT().UpdateCategory(ctx, testModelCategoryWithId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
+	cash.EXPECT().InvalidateTags(ctx, "categories", "items", "category:test name").Return(nil)
 	err := usecase.UpdateCategory(ctx, testModelCategoryWithId)
 	require.NoError(t, err)
 
-	categories := []models.Category{*testModelCategoryWithId}
-	categoryRepo.EXPECT().UpdateCategory(ctx, testModelCategoryWithId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
+	// Category renamed, lists of items with old name are invalidated too
+	renamed := &models.Category{Id: testId, Name: "new name"}
 	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(nil)
-	err = usecase.UpdateCategory(ctx, testModelCategoryWithId)
+	categoryRepo.EXPECT().UpdateCategory(ctx, renamed).Return(nil)
+	cash.EXPECT().InvalidateTags(ctx, "categories", "items", "category:new name", "category:test name").Return(nil)
+	err = usecase.UpdateCategory(ctx, renamed)
 	require.NoError(t, err)
 
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(nil, fmt.Errorf("error"))
 	categoryRepo.EXPECT().UpdateCategory(ctx, testModelCategoryWithId).Return(fmt.Errorf("error on update"))
 	err = usecase.UpdateCategory(ctx, testModelCategoryWithId)
 	require.Error(t, err)
@@ -120,6 +117,7 @@ func TestGetCategoryList(t *testing.T) {
 	cash := mocks.NewMockICategoriesCash(ctrl)
 	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
 
+	cash.EXPECT().GetGenerations(ctx, "categories").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
 	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
 	res, err := usecase.GetCategoryList(context.Background())
@@ -129,6 +127,7 @@ func TestGetCategoryList(t *testing.T) {
 	testChan0 := make(chan models.Category, 1)
 	testChan0 <- *testModelCategoryWithId
 	close(testChan0)
+	cash.EXPECT().GetGenerations(ctx, "categories").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
 	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(nil, fmt.Errorf("error"))
 	categoryRepo.EXPECT().GetCategoryList(ctx).Return(testChan0, fmt.Errorf("error"))
@@ -136,78 +135,35 @@ func TestGetCategoryList(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, res)
 
+	cash.EXPECT().GetGenerations(ctx, "categories").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
 	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(nil, fmt.Errorf("error"))
 	categoryRepo.EXPECT().GetCategoryList(ctx).Return(testChan0, nil)
+	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(nil)
 	res, err = usecase.GetCategoryList(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, res, categories)
 
-	testChan <- *testModelCategoryWithId
-	close(testChan)
+	testChan1 := make(chan models.Category, 1)
+	testChan1 <- *testModelCategoryWithId
+	close(testChan1)
+	cash.EXPECT().GetGenerations(ctx, "categories").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
-	categoryRepo.EXPECT().GetCategoryList(ctx).Return(testChan, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
+	categoryRepo.EXPECT().GetCategoryList(ctx).Return(testChan1, nil)
+	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(fmt.Errorf("error"))
 	res, err = usecase.GetCategoryList(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, res, categories)
 
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
-	categoryRepo.EXPECT().GetCategoryList(ctx).Return(testChan, fmt.Errorf("error"))
-	res, err = usecase.GetCategoryList(context.Background())
-	require.Error(t, err)
-	require.Nil(t, res)
-
 	testChan2 := make(chan models.Category, 1)
 	testChan2 <- *testModelCategoryWithId
 	close(testChan2)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
+	// Generations are not available, the cache is bypassed
+	cash.EXPECT().GetGenerations(ctx, "categories").Return(nil, fmt.Errorf("error"))
 	categoryRepo.EXPECT().GetCategoryList(ctx).Return(testChan2, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(fmt.Errorf("error"))
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
 	res, err = usecase.GetCategoryList(context.Background())
 	require.NoError(t, err)
-	require.NotNil(t, res)
-}
-
-func TestUpdateCategoryCash(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	ctx := context.Background()
-	logger := zap.L()
-	categoryRepo := mocks.NewMockCategoryStore(ctrl)
-	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
-
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
-	err := usecase.UpdateCash(ctx, testId, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
-	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(nil, fmt.Errorf("error"))
-	err = usecase.UpdateCash(ctx, testId, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
-	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(nil, fmt.Errorf("error"))
-	err = usecase.UpdateCash(ctx, testId, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
-	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(fmt.Errorf("error"))
-	err = usecase.UpdateCash(ctx, testId, "update")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
-	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, categories, categoriesListKey).Return(nil)
-	err = usecase.UpdateCash(ctx, testId, "update")
-	require.NoError(t, err)
+	require.Equal(t, res, categories)
 }
 
 func TestDeleteCategory(t *testing.T) {
@@ -219,20 +175,20 @@ func TestDeleteCategory(t *testing.T) {
 	cash := mocks.NewMockICategoriesCash(ctrl)
 	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
 
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
 	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(fmt.Errorf("error"))
 	err := usecase.DeleteCategory(ctx, testId)
 	require.Error(t, err)
 
-	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(true)
 	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(testModelCategoryWithId, nil)
-	cash.EXPECT().GetCategoriesListCash(ctx, categoriesListKey).Return(categories, nil)
-	cash.EXPECT().CreateCategoriesListCash(ctx, []models.Category{}, categoriesListKey).Return(nil)
+	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(nil)
+	cash.EXPECT().InvalidateTags(ctx, "categories", "items", "category:test name").Return(nil)
 	err = usecase.DeleteCategory(ctx, testId)
 	require.NoError(t, err)
 
+	categoryRepo.EXPECT().GetCategory(ctx, testId).Return(nil, fmt.Errorf("error"))
 	categoryRepo.EXPECT().DeleteCategory(ctx, testId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, categoriesListKey).Return(false)
+	cash.EXPECT().InvalidateTags(ctx, "categories", "items").Return(nil)
 	err = usecase.DeleteCategory(ctx, testId)
 	require.NoError(t, err)
 }
@@ -257,33 +213,3 @@ func TestGetCategoryByName(t *testing.T) {
 	require.NotNil(t, res)
 	require.Equal(t, res, testModelCategoryWithId)
 }
-
-func TestDeleteCategoryCash(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	ctx := context.Background()
-	logger := zap.L()
-	categoryRepo := mocks.NewMockCategoryStore(ctrl)
-	cash := mocks.NewMockICategoriesCash(ctrl)
-	usecase := NewCategoryUsecase(categoryRepo, cash, logger)
-
-	cash.EXPECT().DeleteCash(ctx, "testNamenameasc").Return(err)
-	err := usecase.DeleteCategoryCash(ctx, "testName")
-	require.Error(t, err)
-
-	cash.EXPECT().DeleteCash(ctx, "testNamenameasc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNamenamedesc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNamepriceasc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNamepricedesc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNameQuantity").Return(err)
-	err = usecase.DeleteCategoryCash(ctx, "testName")
-	require.Error(t, err)
-
-	cash.EXPECT().DeleteCash(ctx, "testNamenameasc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNamenamedesc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNamepriceasc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNamepricedesc").Return(nil)
-	cash.EXPECT().DeleteCash(ctx, "testNameQuantity").Return(nil)
-	err = usecase.DeleteCategoryCash(ctx, "testName")
-	require.NoError(t, err)
-}
\ No newline at end of file
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+var _ ICurrencyUsecase = &CurrencyUsecase{}
+
+type CurrencyUsecase struct {
+	store        repository.CurrencyStore
+	baseCurrency string
+	logger       *zap.Logger
+}
+
+func NewCurrencyUsecase(store repository.CurrencyStore, baseCurrency string, logger *zap.Logger) ICurrencyUsecase {
+	logger.Debug("Enter in usecase NewCurrencyUsecase()")
+	return &CurrencyUsecase{store: store, baseCurrency: baseCurrency, logger: logger}
+}
+
+// BaseCurrency returns the currency of totals of carts and orders
+func (usecase *CurrencyUsecase) BaseCurrency() string {
+	return usecase.baseCurrency
+}
+
+// GetRates returns table of exchange rates for conversion of prices
+func (usecase *CurrencyUsecase) GetRates(ctx context.Context) (*models.ExchangeRates, error) {
+	usecase.logger.Debug("Enter in usecase GetRates()")
+	return exchangeRates(ctx, usecase.store, usecase.baseCurrency)
+}
+
+// GetRatesList returns all exchange rates set by administrator
+func (usecase *CurrencyUsecase) GetRatesList(ctx context.Context) ([]models.ExchangeRate, error) {
+	usecase.logger.Debug("Enter in usecase GetRatesList()")
+	return usecase.store.GetRates(ctx)
+}
+
+// SetRate checks the currency and sets its exchange rate,
+// rate of the base currency can't be changed
+func (usecase *CurrencyUsecase) SetRate(ctx context.Context, rate *models.ExchangeRate) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetRate() with args: ctx, rate: %v", rate)
+	rate.Currency = models.NormalizeCurrency(rate.Currency)
+	err := models.ValidCurrency(rate.Currency)
+	if err != nil {
+		return err
+	}
+	if rate.Currency == usecase.baseCurrency {
+		return fmt.Errorf("%w: rate of the base currency %s is always 1", models.ErrInvalidExchangeRate, rate.Currency)
+	}
+	if rate.Rate <= 0 {
+		return fmt.Errorf("%w: rate must be positive", models.ErrInvalidExchangeRate)
+	}
+	return usecase.store.SetRate(ctx, rate)
+}
+
+// DeleteRate deletes exchange rate of the currency
+func (usecase *CurrencyUsecase) DeleteRate(ctx context.Context, currency string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteRate() with args: ctx, currency: %s", currency)
+	return usecase.store.DeleteRate(ctx, models.NormalizeCurrency(currency))
+}
+
+// exchangeRates returns table of exchange rates relative to the base currency
+func exchangeRates(ctx context.Context, store repository.CurrencyStore, base string) (*models.ExchangeRates, error) {
+	rates, err := store.GetRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error on get exchange rates: %w", err)
+	}
+	return models.NewExchangeRates(base, rates), nil
+}
+
+// baseItems returns copies of items with prices in the base currency,
+// exchange rates are loaded only if some item has other currency.
+// Price without currency is in the base currency
+func baseItems(ctx context.Context, store repository.CurrencyStore, base string, items []models.ItemWithQuantity) ([]models.ItemWithQuantity, error) {
+	var rates *models.ExchangeRates
+	result := make([]models.ItemWithQuantity, len(items))
+	for i, item := range items {
+		result[i] = item
+		if item.Currency == base || item.Currency == "" {
+			result[i].Currency = base
+			continue
+		}
+		if rates == nil {
+			var err error
+			rates, err = exchangeRates(ctx, store, base)
+			if err != nil {
+				return nil, err
+			}
+		}
+		price, err := rates.Convert(item.Money(), base)
+		if err != nil {
+			return nil, fmt.Errorf("error on convert price of item %v: %w", item.Id, err)
+		}
+		result[i].Price = price.Amount
+		result[i].Currency = price.Currency
+	}
+	return result, nil
+}
+
+// defaultCurrency sets the base currency to the price without currency
+// and checks the currency is supported
+func defaultCurrency(currency *string, base string) error {
+	*currency = models.NormalizeCurrency(*currency)
+	if *currency == "" {
+		*currency = base
+	}
+	return models.ValidCurrency(*currency)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSetRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	currencyRepo := mocks.NewMockCurrencyStore(ctrl)
+	usecase := NewCurrencyUsecase(currencyRepo, "RUB", zap.L())
+
+	err := usecase.SetRate(ctx, &models.ExchangeRate{Currency: "XXX", Rate: 1})
+	require.ErrorIs(t, err, models.ErrUnknownCurrency)
+	err = usecase.SetRate(ctx, &models.ExchangeRate{Currency: "rub", Rate: 2})
+	require.ErrorIs(t, err, models.ErrInvalidExchangeRate)
+	err = usecase.SetRate(ctx, &models.ExchangeRate{Currency: "USD", Rate: 0})
+	require.ErrorIs(t, err, models.ErrInvalidExchangeRate)
+
+	currencyRepo.EXPECT().SetRate(ctx, &models.ExchangeRate{Currency: "USD", Rate: 0.0125}).Return(nil)
+	err = usecase.SetRate(ctx, &models.ExchangeRate{Currency: " usd", Rate: 0.0125})
+	require.NoError(t, err)
+}
+
+func TestBaseItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	currencyRepo := mocks.NewMockCurrencyStore(ctrl)
+	items := []models.ItemWithQuantity{
+		{Item: models.Item{Title: "Base", Price: 1000}, Quantity: 1},
+		{Item: models.Item{Title: "Dollar", Price: 250, Currency: "USD"}, Quantity: 2},
+		{Item: models.Item{Title: "Yen", Price: 100, Currency: "JPY"}, Quantity: 1},
+	}
+
+	// Rates are not loaded for items in the base currency
+	result, err := baseItems(ctx, currencyRepo, "RUB", items[:1])
+	require.NoError(t, err)
+	require.Equal(t, "RUB", result[0].Currency)
+
+	currencyRepo.EXPECT().GetRates(ctx).Return([]models.ExchangeRate{{Currency: "USD", Rate: 0.0125}}, nil)
+	_, err = baseItems(ctx, currencyRepo, "RUB", items)
+	require.ErrorIs(t, err, models.ErrNoExchangeRate)
+
+	currencyRepo.EXPECT().GetRates(ctx).Return([]models.ExchangeRate{
+		{Currency: "USD", Rate: 0.0125},
+		{Currency: "JPY", Rate: 2},
+	}, nil)
+	result, err = baseItems(ctx, currencyRepo, "RUB", items)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), result[0].Price)
+	require.Equal(t, models.Money{Amount: 20000, Currency: "RUB"}, result[1].Money())
+	require.Equal(t, models.Money{Amount: 5000, Currency: "RUB"}, result[2].Money())
+	// Prices of the given items are kept
+	require.Equal(t, "USD", items[1].Currency)
+
+	currencyRepo.EXPECT().GetRates(ctx).Return(nil, fmt.Errorf("error"))
+	_, err = baseItems(ctx, currencyRepo, "RUB", items)
+	require.Error(t, err)
+}
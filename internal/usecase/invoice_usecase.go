@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/invoice"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IInvoiceUsecase = &InvoiceUsecase{}
+
+type InvoiceUsecase struct {
+	store       repository.InvoiceStore
+	orderStore  repository.OrderStore
+	userStore   repository.UserStore
+	filestorage filestorage.FileStorager
+	renderer    *invoice.Renderer
+	logger      *zap.Logger
+}
+
+func NewInvoiceUsecase(store repository.InvoiceStore, orderStore repository.OrderStore, userStore repository.UserStore, filestorage filestorage.FileStorager, renderer *invoice.Renderer, logger *zap.Logger) IInvoiceUsecase {
+	logger.Debug("Enter in usecase NewInvoiceUsecase()")
+	return &InvoiceUsecase{
+		store:       store,
+		orderStore:  orderStore,
+		userStore:   userStore,
+		filestorage: filestorage,
+		renderer:    renderer,
+		logger:      logger,
+	}
+}
+
+// GetInvoice returns the invoice of the order with its PDF file
+func (usecase *InvoiceUsecase) GetInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, []byte, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetInvoice() with args: ctx, orderId: %v", orderId)
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return usecase.invoice(ctx, &order)
+}
+
+// GetUserInvoice returns the invoice of the order of the user with its
+// PDF file, orders of other users are not found
+func (usecase *InvoiceUsecase) GetUserInvoice(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) (*models.Invoice, []byte, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetUserInvoice() with args: ctx, userId: %v, orderId: %v", userId, orderId)
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if order.User.ID != userId {
+		return nil, nil, models.ErrorNotFound{}
+	}
+	return usecase.invoice(ctx, &order)
+}
+
+// invoice returns the stored invoice of the order or renders it when the
+// order has changed. The invoice of the dispatched order is kept as it was
+// when the goods were handed to the carrier
+func (usecase *InvoiceUsecase) invoice(ctx context.Context, order *models.Order) (*models.Invoice, []byte, error) {
+	stored, err := usecase.store.GetInvoice(ctx, order.ID)
+	if err != nil && !errors.Is(err, models.ErrorNotFound{}) {
+		return nil, nil, fmt.Errorf("error on get invoice: %w", err)
+	}
+	if stored != nil && order.Status.Dispatched() {
+		data, err := usecase.read(stored)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stored, data, nil
+	}
+	customer, err := usecase.userStore.GetUserById(ctx, order.User.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error on get customer of order: %w", err)
+	}
+	order.User = *customer
+	doc := &invoice.Document{Number: models.InvoiceNumber(order.ID, order.CreatedAt), Order: order}
+	if stored != nil {
+		doc.Number = stored.Number
+	}
+	fingerprint := usecase.renderer.Fingerprint(doc)
+	if stored != nil && stored.Fingerprint == fingerprint {
+		data, err := usecase.read(stored)
+		if err == nil {
+			return stored, data, nil
+		}
+		// The lost file is rendered again
+		usecase.logger.Sugar().Warnf("Invoice %s of order %v is rendered again: %s", stored.Number, order.ID, err)
+	}
+
+	data, err := usecase.renderer.Render(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &models.Invoice{
+		OrderId:     order.ID,
+		Number:      doc.Number,
+		FileName:    fmt.Sprintf("%s-%s.pdf", order.ID, fingerprint[:8]),
+		Fingerprint: fingerprint,
+	}
+	err = usecase.filestorage.PutInvoice(result.FileName, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error on put invoice file: %w", err)
+	}
+	replaced := stored != nil && stored.FileName != result.FileName
+	err = usecase.store.SaveInvoice(ctx, result)
+	if err != nil {
+		if stored == nil || replaced {
+			usecase.deleteFile(result.FileName)
+		}
+		return nil, nil, fmt.Errorf("error on save invoice: %w", err)
+	}
+	if replaced {
+		usecase.deleteFile(stored.FileName)
+	}
+	usecase.logger.Sugar().Infof("Invoice %s of order %v rendered to %s", result.Number, order.ID, result.FileName)
+	return result, data, nil
+}
+
+// read returns the file of the invoice
+func (usecase *InvoiceUsecase) read(stored *models.Invoice) ([]byte, error) {
+	file, err := usecase.filestorage.GetInvoice(stored.FileName)
+	if err != nil {
+		return nil, fmt.Errorf("error on get invoice file: %w", err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("error on read invoice file: %w", err)
+	}
+	return data, nil
+}
+
+// deleteFile deletes the file which isn't referenced by the invoice,
+// the error is only logged as the file is just left in the storage
+func (usecase *InvoiceUsecase) deleteFile(name string) {
+	if err := usecase.filestorage.DeleteInvoice(name); err != nil {
+		usecase.logger.Sugar().Warnf("Can't delete invoice file %s: %s", name, err)
+	}
+}
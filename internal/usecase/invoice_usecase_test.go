@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/invoice"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testInvoiceUserId, testInvoiceItemId = uuid.New(), uuid.New()
+
+func testInvoiceOrder() models.Order {
+	return models.Order{
+		ID:        uuid.MustParse("1a2b3c4d-0000-4000-8000-000000000001"),
+		CreatedAt: time.Date(2023, 1, 31, 12, 0, 0, 0, time.UTC),
+		User:      models.User{ID: testInvoiceUserId},
+		Address:   models.UserAddress{Zipcode: "101000", Country: "Russia", City: "Moscow", Street: "Arbat 1"},
+		Status:    models.StatusCreated,
+		Items: []models.ItemWithQuantity{
+			{Item: models.Item{Id: testInvoiceItemId, Title: "Smartphone", Price: 1000000, Currency: "RUB"}, Quantity: 1},
+		},
+		Pricing: models.Pricing{Currency: "RUB", Subtotal: 1000000, Total: 1000000},
+	}
+}
+
+func TestGetInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	invoiceRepo := mocks.NewMockInvoiceStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	userRepo := mocks.NewMockUserStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	renderer, err := invoice.NewRenderer(models.ShopDetails{Name: "Online Shop"}, "")
+	require.NoError(t, err)
+	usecase := NewInvoiceUsecase(invoiceRepo, orderRepo, userRepo, filestorage, renderer, zap.L())
+	order := testInvoiceOrder()
+	customer := &models.User{ID: order.User.ID, Firstname: "Ivan", Lastname: "Petrov", Email: "ivan@example.com"}
+	userRepo.EXPECT().GetUserById(gomock.Any(), order.User.ID).Return(customer, nil).AnyTimes()
+	files := make(map[string][]byte)
+	filestorage.EXPECT().PutInvoice(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, data []byte) error {
+		files[name] = data
+		return nil
+	}).AnyTimes()
+	filestorage.EXPECT().GetInvoice(gomock.Any()).DoAndReturn(func(name string) (io.ReadCloser, error) {
+		data, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("file not found")
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}).AnyTimes()
+	filestorage.EXPECT().DeleteInvoice(gomock.Any()).DoAndReturn(func(name string) error {
+		delete(files, name)
+		return nil
+	}).AnyTimes()
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(models.Order{}, models.ErrorNotFound{})
+	_, _, err = usecase.GetInvoice(ctx, order.ID)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// The first invoice is rendered and saved
+	var saved *models.Invoice
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(nil, models.ErrorNotFound{})
+	invoiceRepo.EXPECT().SaveInvoice(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, inv *models.Invoice) error {
+		saved = inv
+		return nil
+	})
+	result, data, err := usecase.GetInvoice(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, "INV-20230131-1A2B3C4D", result.Number)
+	require.Equal(t, saved, result)
+	require.Equal(t, files[result.FileName], data)
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	first := *result
+
+	// Unchanged order gets the stored file
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(&first, nil)
+	result, data, err = usecase.GetInvoice(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, &first, result)
+	require.Equal(t, files[first.FileName], data)
+
+	// Changed order is rendered again with the same number
+	changed := testInvoiceOrder()
+	changed.Address.Street = "Tverskaya 1"
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(changed, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(&first, nil)
+	invoiceRepo.EXPECT().SaveInvoice(ctx, gomock.Any()).Return(nil)
+	result, data, err = usecase.GetInvoice(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, first.Number, result.Number)
+	require.NotEqual(t, first.Fingerprint, result.Fingerprint)
+	require.NotEqual(t, first.FileName, result.FileName)
+	require.Equal(t, files[result.FileName], data)
+	require.NotContains(t, files, first.FileName)
+	second := *result
+
+	// Invoice of the dispatched order isn't changed anymore
+	changed.Status = models.StatusCourier
+	changed.Address.Street = "Lenina 1"
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(changed, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(&second, nil)
+	result, data, err = usecase.GetInvoice(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, &second, result)
+	require.Equal(t, files[second.FileName], data)
+
+	// Lost file is rendered again
+	delete(files, second.FileName)
+	changed = testInvoiceOrder()
+	changed.Address.Street = "Tverskaya 1"
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(changed, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(&second, nil)
+	invoiceRepo.EXPECT().SaveInvoice(ctx, gomock.Any()).Return(nil)
+	result, data, err = usecase.GetInvoice(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, second.FileName, result.FileName)
+	require.Equal(t, files[second.FileName], data)
+
+	// File of the invoice which isn't saved is deleted
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(nil, models.ErrorNotFound{})
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	invoiceRepo.EXPECT().SaveInvoice(ctx, gomock.Any()).Return(fmt.Errorf("test error"))
+	_, _, err = usecase.GetInvoice(ctx, order.ID)
+	require.Error(t, err)
+	require.NotContains(t, files, first.FileName)
+}
+
+func TestGetUserInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	invoiceRepo := mocks.NewMockInvoiceStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	userRepo := mocks.NewMockUserStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	renderer, err := invoice.NewRenderer(models.ShopDetails{Name: "Online Shop"}, "")
+	require.NoError(t, err)
+	usecase := NewInvoiceUsecase(invoiceRepo, orderRepo, userRepo, filestorage, renderer, zap.L())
+	order := testInvoiceOrder()
+	order.Status = models.StatusShipped
+	stored := &models.Invoice{OrderId: order.ID, Number: "INV-20230131-1A2B3C4D", FileName: "invoice.pdf"}
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	_, _, err = usecase.GetUserInvoice(ctx, uuid.New(), order.ID)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(stored, nil)
+	filestorage.EXPECT().GetInvoice("invoice.pdf").Return(io.NopCloser(bytes.NewReader([]byte("%PDF-1.4"))), nil)
+	result, data, err := usecase.GetUserInvoice(ctx, order.User.ID, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, stored, result)
+	require.Equal(t, []byte("%PDF-1.4"), data)
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	invoiceRepo.EXPECT().GetInvoice(ctx, order.ID).Return(nil, fmt.Errorf("test error"))
+	_, _, err = usecase.GetUserInvoice(ctx, order.User.ID, order.ID)
+	require.Error(t, err)
+}
@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
 	"OnlineShopBackend/internal/repository/mocks"
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -69,6 +72,21 @@ var (
 	testFavUids = map[uuid.UUID]uuid.UUID{
 		testItemId: testId,
 	}
+	testGenerations        = []int64{1}
+	testFavGenerations     = []int64{1, 1}
+	testCategoryItem       = models.Item{Id: testItemId, Category: models.Category{Name: testCategoryName}}
+	testMovedItem          = models.Item{Id: testItemId, Category: models.Category{Name: "movedName"}}
+	itemsListKeyNameAsc    = cash.Key(cash.ItemsListNamespace, testGenerations, "name", "asc")
+	itemsQuantityKey       = cash.Key(cash.ItemsListNamespace, testGenerations, cash.QuantitySuffix)
+	searchKeyNameAsc       = cash.Key(cash.ItemsSearchNamespace, testGenerations, param, "name", "asc")
+	searchQuantityKey      = cash.Key(cash.ItemsSearchNamespace, testGenerations, testSearch, cash.QuantitySuffix)
+	categoryKeyNameAsc     = cash.Key(cash.ItemsCategoryNamespace, testGenerations, param, "name", "asc")
+	categoryQuantityKey    = cash.Key(cash.ItemsCategoryNamespace, testGenerations, param, cash.QuantitySuffix)
+	favouriteKeyNameAsc    = cash.Key(cash.ItemsFavouriteNamespace, testFavGenerations, testId.String(), "name", "asc")
+	favouriteQuantityKey   = cash.Key(cash.ItemsFavouriteNamespace, testGenerations, testId.String(), cash.QuantitySuffix)
+	favouriteIdsKey        = cash.Key(cash.FavouriteIdsNamespace, testGenerations, testId.String())
+	searchParamQuantityKey = cash.Key(cash.ItemsSearchNamespace, testGenerations, param, cash.QuantitySuffix)
+	categoryInSearchQntKey = cash.Key(cash.ItemsCategoryNamespace, testGenerations, testCategoryName, cash.QuantitySuffix)
 )
 
 func TestCreateItem(t *testing.T) {
@@ -77,7 +95,7 @@ func TestCreateItem(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := context.Background()
 
 	itemRepo.EXPECT().CreateItem(ctx, &testModelItem).Return(uuid.Nil, err)
@@ -86,10 +104,15 @@ func TestCreateItem(t *testing.T) {
 	require.Equal(t, res, uuid.Nil)
 
 	itemRepo.EXPECT().CreateItem(ctx, &testModelItem).Return(testId, nil)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameDesc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceAsc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceDesc).Return(false)
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(&testCategoryItem, nil)
+	cash.EXPECT().InvalidateTags(ctx, "items", "category:"+testCategoryName).Return(nil)
+	res, err = usecase.CreateItem(ctx, &testModelItem)
+	require.NoError(t, err)
+	require.Equal(t, res, testId)
+
+	itemRepo.EXPECT().CreateItem(ctx, &testModelItem).Return(testId, nil)
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(nil, fmt.Errorf("error"))
+	cash.EXPECT().InvalidateTags(ctx, "items", "category:").Return(fmt.Errorf("error"))
 	res, err = usecase.CreateItem(ctx, &testModelItem)
 	require.NoError(t, err)
 	require.Equal(t, res, testId)
@@ -101,18 +124,33 @@ func TestUpdateItem(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := context.Background()
 
-	itemRepo.EXPECT().UpdateItem(ctx, &testModelItem).Return(err)
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(&testCategoryItem, nil)
+	itemRepo.EXPECT().UpdateItem(ctx, &testModelItem).Return(fmt.Errorf("error"))
 	err := usecase.UpdateItem(ctx, &testModelItem)
 	require.Error(t, err)
 
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(&testCategoryItem, nil)
 	itemRepo.EXPECT().UpdateItem(ctx, &testModelItem).Return(nil)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameDesc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceAsc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceDesc).Return(false)
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(&testCategoryItem, nil)
+	cash.EXPECT().InvalidateTags(ctx, "items", "category:"+testCategoryName).Return(nil)
+	err = usecase.UpdateItem(ctx, &testModelItem)
+	require.NoError(t, err)
+
+	// Item moved to other category, lists of both categories are invalidated
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(&testCategoryItem, nil)
+	itemRepo.EXPECT().UpdateItem(ctx, &testModelItem).Return(nil)
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(&testMovedItem, nil)
+	cash.EXPECT().InvalidateTags(ctx, "items", "category:"+testCategoryName, "category:movedName").Return(nil)
+	err = usecase.UpdateItem(ctx, &testModelItem)
+	require.NoError(t, err)
+
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(nil, fmt.Errorf("error"))
+	itemRepo.EXPECT().UpdateItem(ctx, &testModelItem).Return(nil)
+	itemRepo.EXPECT().GetItem(ctx, testModelItem.Id).Return(&testMovedItem, nil)
+	cash.EXPECT().InvalidateTags(ctx, "items", "category:movedName").Return(fmt.Errorf("error"))
 	err = usecase.UpdateItem(ctx, &testModelItem)
 	require.NoError(t, err)
 }
@@ -123,7 +161,7 @@ func TestGetItem(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := context.Background()
 
 	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(&testItemWithId, nil)
@@ -145,46 +183,49 @@ func TestItemsList(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
 	testItemChan := make(chan models.Item, 1)
 	testItemChan <- testItemWithId
 	close(testItemChan)
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(false)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
 	itemRepo.EXPECT().ItemsList(ctx).Return(testItemChan, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKey+"nameasc").Return(nil)
+	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKeyNameAsc).Return(nil)
 	cash.EXPECT().CreateItemsQuantityCash(ctx, len(items), itemsQuantityKey).Return(nil)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKey+"nameasc").Return(items, nil)
-
 	res, err := usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKey+"nameasc").Return(items, nil)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(items, nil)
 	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKey+"nameasc").Return(items2, nil)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(items2, nil)
 	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKey+"nameasc").Return(items, nil)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(items, nil)
 	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList2, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
 
 	err = fmt.Errorf("error on itemslist()")
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(false)
-	itemRepo.EXPECT().ItemsList(ctx).Return(testItemChan, err)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
+	itemRepo.EXPECT().ItemsList(ctx).Return(nil, err)
 	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
@@ -193,43 +234,66 @@ func TestItemsList(t *testing.T) {
 	testChan2 <- testItemWithId
 	close(testChan2)
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(false)
+	// Error on get cache, list is requested from the database
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(nil, fmt.Errorf("error"))
 	itemRepo.EXPECT().ItemsList(ctx).Return(testChan2, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKey+"nameasc").Return(err)
+	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKeyNameAsc).Return(err)
 	cash.EXPECT().CreateItemsQuantityCash(ctx, len(items), itemsQuantityKey).Return(err)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(items, nil)
 	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
-	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
 	testChan3 := make(chan models.Item, 1)
 	testChan3 <- testItemWithId
 	close(testChan3)
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(false)
+	// Generations are not available, the cache is bypassed
+	cash.EXPECT().GetGenerations(ctx, "items").Return(nil, fmt.Errorf("error"))
 	itemRepo.EXPECT().ItemsList(ctx).Return(testChan3, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKey+"nameasc").Return(err)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(items), itemsQuantityKey).Return(err)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsList(ctx).Return(testChan3, fmt.Errorf("error"))
 	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.Error(t, err)
-	require.Nil(t, res)
+	require.NoError(t, err)
+	require.Equal(t, res, items)
+}
 
-	testChan4 := make(chan models.Item, 1)
-	testChan4 <- testItemWithId
-	close(testChan4)
+func TestItemsListSingleflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	cash := mocks.NewMockIItemsCash(ctrl)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
+	ctx := gomock.Any()
+	requests := 10
 
-	cash.EXPECT().CheckCash(ctx, itemsListKey+"nameasc").Return(false)
-	itemRepo.EXPECT().ItemsList(ctx).Return(testChan4, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKey+"nameasc").Return(err)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(items), itemsQuantityKey).Return(err)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsList(ctx).Return(testChan4, nil)
-	res, err = usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
+	testItemChan := make(chan models.Item, 1)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil).Times(requests)
+	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false).Times(requests)
+	// The database is requested once for all concurrent requests
+	itemRepo.EXPECT().ItemsList(ctx).DoAndReturn(func(_ context.Context) (chan models.Item, error) {
+		go func() {
+			// Hold the request until all concurrent requests miss the cache
+			time.Sleep(50 * time.Millisecond)
+			testItemChan <- testItemWithId
+			close(testItemChan)
+		}()
+		return testItemChan, nil
+	}).Times(1)
+	cash.EXPECT().CreateItemsCash(ctx, items, itemsListKeyNameAsc).Return(nil).Times(1)
+	cash.EXPECT().CreateItemsQuantityCash(ctx, len(items), itemsQuantityKey).Return(nil).Times(1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := usecase.ItemsList(context.Background(), testLimitOptionsItemsList, testSortOptionsItemsList)
+			require.NoError(t, err)
+			require.Equal(t, res, items)
+		}()
+	}
+	wg.Wait()
 }
 
 func TestSearchLine(t *testing.T) {
@@ -238,91 +302,45 @@ func TestSearchLine(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
 
 	testItemChan := make(chan models.Item, 1)
 	testItemChan <- testItemWithId
 	close(testItemChan)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, searchKeyNameAsc).Return(false)
 	itemRepo.EXPECT().SearchLine(ctx, param).Return(testItemChan, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
+	cash.EXPECT().CreateItemsCash(ctx, items, searchKeyNameAsc).Return(nil)
+	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, searchParamQuantityKey).Return(nil)
 	res, err := usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, searchKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, searchKeyNameAsc).Return(items2, nil)
 	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items2, nil)
-	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
-	require.Equal(t, res, items)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, searchKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, searchKeyNameAsc).Return(items, nil)
 	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList2, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
 
 	err = fmt.Errorf("error on search()")
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().SearchLine(ctx, param).Return(testItemChan, err)
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, searchKeyNameAsc).Return(false)
+	itemRepo.EXPECT().SearchLine(ctx, param).Return(nil, err)
 	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
-
-	testChan2 := make(chan models.Item, 1)
-	testChan2 <- testItemWithId
-	close(testChan2)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().SearchLine(ctx, param).Return(testChan2, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(err)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(err)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
-	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
-	require.Equal(t, res, items)
-
-	testChan3 := make(chan models.Item, 1)
-	testChan3 <- testItemWithId
-	close(testChan3)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().SearchLine(ctx, param).Return(testChan3, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().SearchLine(ctx, param).Return(testChan3, fmt.Errorf("error"))
-	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.Error(t, err)
-	require.Nil(t, res)
-
-	testChan4 := make(chan models.Item, 1)
-	testChan4 <- testItemWithId
-	close(testChan4)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().SearchLine(ctx, param).Return(testChan4, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().SearchLine(ctx, param).Return(testChan4, nil)
-	res, err = usecase.SearchLine(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
 }
 
 func TestGetItemsByCategory(t *testing.T) {
@@ -331,91 +349,44 @@ func TestGetItemsByCategory(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
 
 	testItemChan := make(chan models.Item, 1)
 	testItemChan <- testItemWithId
 	close(testItemChan)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
+	cash.EXPECT().GetGenerations(ctx, "category:"+param).Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, categoryKeyNameAsc).Return(false)
 	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testItemChan, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity")
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
+	cash.EXPECT().CreateItemsCash(ctx, items, categoryKeyNameAsc).Return(nil)
+	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, categoryQuantityKey).Return(nil)
 	res, err := usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	testItemChan = make(chan models.Item, 1)
-	testItemChan <- testItemWithId
-	close(testItemChan)
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testItemChan, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(nil, fmt.Errorf("error"))
-	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.Error(t, err)
-	require.Nil(t, res)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
-	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
-	require.Equal(t, res, items)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items2, nil)
+	cash.EXPECT().GetGenerations(ctx, "category:"+param).Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, categoryKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, categoryKeyNameAsc).Return(items, nil)
 	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
-	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
+	cash.EXPECT().GetGenerations(ctx, "category:"+param).Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, categoryKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, categoryKeyNameAsc).Return(items, nil)
 	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList2, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
 
-	err = fmt.Errorf("error")
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testItemChan, err)
+	cash.EXPECT().GetGenerations(ctx, "category:"+param).Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, categoryKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, categoryKeyNameAsc).Return(nil, fmt.Errorf("error"))
+	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(nil, fmt.Errorf("error"))
 	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
-
-	testChan2 := make(chan models.Item, 1)
-	testChan2 <- testItemWithId
-	close(testChan2)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testChan2, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testChan2, nil)
-	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
-
-	testChan3 := make(chan models.Item, 1)
-	testChan3 <- testItemWithId
-	close(testChan3)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testChan3, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(fmt.Errorf("error"))
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetItemsByCategory(ctx, param).Return(testChan3, nil)
-	res, err = usecase.GetItemsByCategory(context.Background(), param, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
-
 }
 
 func TestItemsQuantity(t *testing.T) {
@@ -424,15 +395,17 @@ func TestItemsQuantity(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
 
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, itemsQuantityKey).Return(false)
 	itemRepo.EXPECT().ItemsListQuantity(ctx).Return(-1, fmt.Errorf("error"))
 	res, err := usecase.ItemsQuantity(context.Background())
 	require.Error(t, err)
 	require.Equal(t, res, -1)
 
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, itemsQuantityKey).Return(false)
 	itemRepo.EXPECT().ItemsListQuantity(ctx).Return(1, nil)
 	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, itemsQuantityKey).Return(fmt.Errorf("error"))
@@ -440,25 +413,27 @@ func TestItemsQuantity(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, res, 1)
 
-	cash.EXPECT().CheckCash(ctx, itemsQuantityKey).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, itemsQuantityKey).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsListQuantity(ctx).Return(-1, fmt.Errorf("error"))
-	res, err = usecase.ItemsQuantity(context.Background())
-	require.Error(t, err)
-	require.Equal(t, res, -1)
-
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, itemsQuantityKey).Return(true)
 	cash.EXPECT().GetItemsQuantityCash(ctx, itemsQuantityKey).Return(-1, fmt.Errorf("error"))
 	itemRepo.EXPECT().ItemsListQuantity(ctx).Return(1, nil)
+	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, itemsQuantityKey).Return(nil)
 	res, err = usecase.ItemsQuantity(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, res, 1)
 
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, itemsQuantityKey).Return(true)
 	cash.EXPECT().GetItemsQuantityCash(ctx, itemsQuantityKey).Return(1, nil)
 	res, err = usecase.ItemsQuantity(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, res, 1)
+
+	cash.EXPECT().GetGenerations(ctx, "items").Return(nil, fmt.Errorf("error"))
+	itemRepo.EXPECT().ItemsListQuantity(ctx).Return(1, nil)
+	res, err = usecase.ItemsQuantity(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, res, 1)
 }
 
 func TestItemsQuantityInCategory(t *testing.T) {
@@ -467,16 +442,18 @@ func TestItemsQuantityInCategory(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
-	key := testCategoryName + "Quantity"
-	
+	key := categoryInSearchQntKey
+
+	cash.EXPECT().GetGenerations(ctx, "category:"+testCategoryName).Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(false)
 	itemRepo.EXPECT().ItemsByCategoryQuantity(ctx, testCategoryName).Return(-1, fmt.Errorf("error"))
 	res, err := usecase.ItemsQuantityInCategory(context.Background(), testCategoryName)
 	require.Error(t, err)
 	require.Equal(t, res, -1)
 
+	cash.EXPECT().GetGenerations(ctx, "category:"+testCategoryName).Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(false)
 	itemRepo.EXPECT().ItemsByCategoryQuantity(ctx, testCategoryName).Return(1, nil)
 	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, key).Return(fmt.Errorf("error"))
@@ -484,20 +461,7 @@ func TestItemsQuantityInCategory(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, res, 1)
 
-	cash.EXPECT().CheckCash(ctx, key).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsByCategoryQuantity(ctx, testCategoryName).Return(-1, fmt.Errorf("error"))
-	res, err = usecase.ItemsQuantityInCategory(context.Background(), testCategoryName)
-	require.Error(t, err)
-	require.Equal(t, res, -1)
-
-	cash.EXPECT().CheckCash(ctx, key).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsByCategoryQuantity(ctx, testCategoryName).Return(1, nil)
-	res, err = usecase.ItemsQuantityInCategory(context.Background(), testCategoryName)
-	require.NoError(t, err)
-	require.Equal(t, res, 1)
-
+	cash.EXPECT().GetGenerations(ctx, "category:"+testCategoryName).Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(true)
 	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(1, nil)
 	res, err = usecase.ItemsQuantityInCategory(context.Background(), testCategoryName)
@@ -511,38 +475,26 @@ func TestItemsQuantityInSearch(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
-	key := testSearch+"Quantity"
-
+	key := searchQuantityKey
 
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(false)
 	itemRepo.EXPECT().ItemsInSearchQuantity(ctx, testSearch).Return(-1, fmt.Errorf("error"))
 	res, err := usecase.ItemsQuantityInSearch(context.Background(), testSearch)
 	require.Error(t, err)
 	require.Equal(t, res, -1)
 
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(false)
 	itemRepo.EXPECT().ItemsInSearchQuantity(ctx, testSearch).Return(1, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, key).Return(fmt.Errorf("error"))
-	res, err = usecase.ItemsQuantityInSearch(context.Background(), testSearch)
-	require.NoError(t, err)
-	require.Equal(t, res, 1)
-
-	cash.EXPECT().CheckCash(ctx, key).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsInSearchQuantity(ctx, testSearch).Return(-1, fmt.Errorf("error"))
-	res, err = usecase.ItemsQuantityInSearch(context.Background(), testSearch)
-	require.Error(t, err)
-	require.Equal(t, res, -1)
-
-	cash.EXPECT().CheckCash(ctx, key).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsInSearchQuantity(ctx, testSearch).Return(1, nil)
+	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, key).Return(nil)
 	res, err = usecase.ItemsQuantityInSearch(context.Background(), testSearch)
 	require.NoError(t, err)
 	require.Equal(t, res, 1)
 
+	cash.EXPECT().GetGenerations(ctx, "items").Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(true)
 	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(1, nil)
 	res, err = usecase.ItemsQuantityInSearch(context.Background(), testSearch)
@@ -550,151 +502,29 @@ func TestItemsQuantityInSearch(t *testing.T) {
 	require.Equal(t, res, 1)
 }
 
-func TestUpdateCash(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	logger := zap.L()
-	itemRepo := mocks.NewMockItemStore(ctrl)
-	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
-	ctx := context.Background()
-
-	cash.EXPECT().CheckCash(ctx, "ItemsList"+"nameasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, "ItemsList"+"namedesc").Return(false)
-	cash.EXPECT().CheckCash(ctx, "ItemsList"+"priceasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, "ItemsList"+"pricedesc").Return(false)
-	err := usecase.UpdateCash(ctx, uuid.New(), "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(nil, err)
-	err = usecase.UpdateCash(ctx, testId, "create")
-	require.Error(t, err)
-
-	cashResults := make([]models.Item, 0, 1)
-	cashResults = append(cashResults, cashItem)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testId).Return(nil, err)
-	err = usecase.UpdateCash(ctx, testId, "create")
-	require.Error(t, err)
-
-	cashResults = make([]models.Item, 0, 1)
-	cashResults = append(cashResults, cashItem)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testId).Return(&testItemWithId, nil)
-	cash.EXPECT().CreateItemsCash(ctx, cashResults, itemsListKeyNameAsc).Return(err)
-	err = usecase.UpdateCash(ctx, testId, "update")
-	require.Error(t, err)
-
-	cashResults = make([]models.Item, 0, 1)
-	cashResults = append(cashResults, cashItem)
-	updateResults := append(cashResults, cashItem)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(true)
-	cash.EXPECT().GetItemsCash(ctx, itemsListKeyNameAsc).Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testId).Return(&testItemWithId, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(updateResults), itemsQuantityKey).Return(err)
-	err = usecase.UpdateCash(ctx, testId, "create")
-	require.Error(t, err)
-}
-
-func TestUpdateItemsInCategoryCash(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	logger := zap.L()
-	itemRepo := mocks.NewMockItemStore(ctrl)
-	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
-	ctx := context.Background()
-
-	cashResults := make([]models.Item, 0, 1)
-	cashResults = append(cashResults, cashItem)
-	updateResults := make([]models.Item, 0, 2)
-	updateResults = append(updateResults, *newItem)
-	updateResults = append(updateResults, *newItem)
-
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"nameasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"namedesc").Return(false)
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"priceasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"pricedesc").Return(false)
-	err := usecase.UpdateItemsInCategoryCash(ctx, newItem, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"nameasc").Return(nil, fmt.Errorf("error"))
-	err = usecase.UpdateItemsInCategoryCash(ctx, newItem, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"nameasc").Return(cashResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(updateResults), newItem.Category.Name+"Quantity").Return(fmt.Errorf("error"))
-	err = usecase.UpdateItemsInCategoryCash(ctx, newItem, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"nameasc").Return(cashResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(updateResults), newItem.Category.Name+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, updateResults, newItem.Category.Name+"nameasc").Return(fmt.Errorf("error"))
-	err = usecase.UpdateItemsInCategoryCash(ctx, newItem, "create")
-	require.Error(t, err)
-
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"nameasc").Return(cashResults, nil)
-	cash.EXPECT().CreateItemsCash(ctx, cashResults, newItem.Category.Name+"nameasc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"namedesc").Return(cashResults, nil)
-	cash.EXPECT().CreateItemsCash(ctx, cashResults, newItem.Category.Name+"namedesc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"priceasc").Return(cashResults, nil)
-	cash.EXPECT().CreateItemsCash(ctx, cashResults, newItem.Category.Name+"priceasc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"pricedesc").Return(cashResults, nil)
-	cash.EXPECT().CreateItemsCash(ctx, cashResults, newItem.Category.Name+"pricedesc").Return(nil)
-
-	err = usecase.UpdateItemsInCategoryCash(ctx, newItem, "update")
-	require.NoError(t, err)
-
-	deletedResults := []models.Item{testItemWithId}
-	deleteResults := []models.Item{}
-	cash.EXPECT().CheckCash(ctx, newItem.Category.Name+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"nameasc").Return(deletedResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(deleteResults), testItemWithId.Category.Name+"Quantity").Return(err)
-	cash.EXPECT().CreateItemsCash(ctx, deleteResults, newItem.Category.Name+"nameasc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"namedesc").Return(deletedResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(deleteResults), testItemWithId.Category.Name+"Quantity").Return(err)
-	cash.EXPECT().CreateItemsCash(ctx, deleteResults, newItem.Category.Name+"namedesc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"priceasc").Return(deletedResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(deleteResults), testItemWithId.Category.Name+"Quantity").Return(err)
-	cash.EXPECT().CreateItemsCash(ctx, deleteResults, newItem.Category.Name+"priceasc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, newItem.Category.Name+"pricedesc").Return(deletedResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, len(deleteResults), testItemWithId.Category.Name+"Quantity").Return(err)
-	cash.EXPECT().CreateItemsCash(ctx, deleteResults, newItem.Category.Name+"pricedesc").Return(nil)
-	err = usecase.UpdateItemsInCategoryCash(ctx, newItem, "delete")
-	require.NoError(t, err)
-}
-
 func TestDeleteItem(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := context.Background()
 
-	itemRepo.EXPECT().DeleteItem(ctx, testId).Return(err)
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(&testCategoryItem, nil)
+	itemRepo.EXPECT().DeleteItem(ctx, testId).Return(fmt.Errorf("error"))
 	err := usecase.DeleteItem(ctx, testId)
 	require.Error(t, err)
 
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(&testCategoryItem, nil)
 	itemRepo.EXPECT().DeleteItem(ctx, testId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameAsc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyNameDesc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceAsc).Return(false)
-	cash.EXPECT().CheckCash(ctx, itemsListKeyPriceDesc).Return(false)
+	cash.EXPECT().InvalidateTags(ctx, "items", "category:"+testCategoryName).Return(nil)
+	err = usecase.DeleteItem(ctx, testId)
+	require.NoError(t, err)
+
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(nil, fmt.Errorf("error"))
+	itemRepo.EXPECT().DeleteItem(ctx, testId).Return(nil)
+	cash.EXPECT().InvalidateTags(ctx, "items").Return(nil)
 	err = usecase.DeleteItem(ctx, testId)
 	require.NoError(t, err)
 }
@@ -705,20 +535,15 @@ func TestAddFavouriteItem(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := context.Background()
 
-	itemRepo.EXPECT().AddFavouriteItem(ctx, testId, testItemId).Return(err)
+	itemRepo.EXPECT().AddFavouriteItem(ctx, testId, testItemId).Return(fmt.Errorf("error"))
 	err := usecase.AddFavouriteItem(ctx, testId, testItemId)
 	require.Error(t, err)
 
 	itemRepo.EXPECT().AddFavouriteItem(ctx, testId, testItemId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"namedesc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"priceasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"pricedesc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(true)
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(nil, err)
+	cash.EXPECT().InvalidateTags(ctx, "favourites:"+testId.String()).Return(nil)
 	err = usecase.AddFavouriteItem(ctx, testId, testItemId)
 	require.NoError(t, err)
 }
@@ -729,20 +554,15 @@ func TestDeleteFavouriteItem(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := context.Background()
 
-	itemRepo.EXPECT().DeleteFavouriteItem(ctx, testId, testItemId).Return(err)
+	itemRepo.EXPECT().DeleteFavouriteItem(ctx, testId, testItemId).Return(fmt.Errorf("error"))
 	err := usecase.DeleteFavouriteItem(ctx, testId, testItemId)
 	require.Error(t, err)
 
 	itemRepo.EXPECT().DeleteFavouriteItem(ctx, testId, testItemId).Return(nil)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"namedesc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"priceasc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"pricedesc").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(true)
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(nil, err)
+	cash.EXPECT().InvalidateTags(ctx, "favourites:"+testId.String()).Return(fmt.Errorf("error"))
 	err = usecase.DeleteFavouriteItem(ctx, testId, testItemId)
 	require.NoError(t, err)
 }
@@ -753,77 +573,47 @@ func TestGetFavouriteItems(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
-	param = testId.String()
-	paramns := testId
+	favTag := "favourites:" + testId.String()
+
 	testItemChan := make(chan models.Item, 1)
 	testItemChan <- testItemWithId
 	close(testItemChan)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetFavouriteItems(ctx, paramns).Return(testItemChan, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(nil)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
-	res, err := usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList, testSortOptionsItemsList)
+	cash.EXPECT().GetGenerations(ctx, favTag, "items").Return(testFavGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, favouriteKeyNameAsc).Return(false)
+	itemRepo.EXPECT().GetFavouriteItems(ctx, testId).Return(testItemChan, nil)
+	cash.EXPECT().CreateItemsCash(ctx, items, favouriteKeyNameAsc).Return(nil)
+	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, favouriteQuantityKey).Return(nil)
+	res, err := usecase.GetFavouriteItems(context.Background(), testId, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	testItemChan2 := make(chan models.Item, 1)
-	testItemChan2 <- testItemWithId
-	close(testItemChan2)
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetFavouriteItems(ctx, paramns).Return(testItemChan2, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(fmt.Errorf("error"))
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetFavouriteItems(ctx, paramns).Return(testItemChan2, fmt.Errorf("error"))
-	res, err = usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.Error(t, err)
-	require.Nil(t, res)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
-	res, err = usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList, testSortOptionsItemsList)
+	cash.EXPECT().GetGenerations(ctx, favTag, "items").Return(testFavGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, favouriteKeyNameAsc).Return(true)
+	cash.EXPECT().GetItemsCash(ctx, favouriteKeyNameAsc).Return(items2, nil)
+	res, err = usecase.GetFavouriteItems(context.Background(), testId, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
-	require.NotNil(t, res)
-	require.Equal(t, res, items)
-
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items2, nil)
-	res, err = usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList, testSortOptionsItemsList)
-	require.NoError(t, err)
-	require.NotNil(t, res)
 	require.Equal(t, res, items)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(items, nil)
-	res, err = usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList2, testSortOptionsItemsList)
-	require.Error(t, err)
-	require.Nil(t, res)
-
-	err = fmt.Errorf("error")
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetFavouriteItems(ctx, paramns).Return(testItemChan, err)
-	res, err = usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList, testSortOptionsItemsList)
+	cash.EXPECT().GetGenerations(ctx, favTag, "items").Return(testFavGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, favouriteKeyNameAsc).Return(false)
+	itemRepo.EXPECT().GetFavouriteItems(ctx, testId).Return(nil, fmt.Errorf("error"))
+	res, err = usecase.GetFavouriteItems(context.Background(), testId, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.Error(t, err)
 	require.Nil(t, res)
 
-	testChan2 := make(chan models.Item, 1)
-	testChan2 <- testItemWithId
-	close(testChan2)
+	testItemChan2 := make(chan models.Item, 1)
+	testItemChan2 <- testItemWithId
+	close(testItemChan2)
 
-	cash.EXPECT().CheckCash(ctx, param+"nameasc").Return(false)
-	itemRepo.EXPECT().GetFavouriteItems(ctx, paramns).Return(testChan2, nil)
-	cash.EXPECT().CreateItemsCash(ctx, items, param+"nameasc").Return(fmt.Errorf("error"))
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, param+"Quantity").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetItemsCash(ctx, param+"nameasc").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetFavouriteItems(ctx, paramns).Return(testChan2, nil)
-	res, err = usecase.GetFavouriteItems(context.Background(), paramns, testLimitOptionsItemsList, testSortOptionsItemsList)
+	cash.EXPECT().GetGenerations(ctx, favTag, "items").Return(nil, fmt.Errorf("error"))
+	itemRepo.EXPECT().GetFavouriteItems(ctx, testId).Return(testItemChan2, nil)
+	res, err = usecase.GetFavouriteItems(context.Background(), testId, testLimitOptionsItemsList, testSortOptionsItemsList)
 	require.NoError(t, err)
-	require.NotNil(t, res)
+	require.Equal(t, res, items)
 }
 
 func TestItemsQuantityInFavourite(t *testing.T) {
@@ -832,18 +622,19 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
-	testFav := testId.String()
-	key := testFav+"Quantity"
-
+	favTag := "favourites:" + testId.String()
+	key := favouriteQuantityKey
 
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(false)
 	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(-1, fmt.Errorf("error"))
 	res, err := usecase.ItemsQuantityInFavourite(context.Background(), testId)
 	require.Error(t, err)
 	require.Equal(t, res, -1)
 
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(false)
 	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(1, nil)
 	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, key).Return(fmt.Errorf("error"))
@@ -851,20 +642,7 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, res, 1)
 
-	cash.EXPECT().CheckCash(ctx, key).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(-1, fmt.Errorf("error"))
-	res, err = usecase.ItemsQuantityInFavourite(context.Background(), testId)
-	require.Error(t, err)
-	require.Equal(t, res, -1)
-
-	cash.EXPECT().CheckCash(ctx, key).Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(-1, fmt.Errorf("error"))
-	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(1, nil)
-	res, err = usecase.ItemsQuantityInFavourite(context.Background(), testId)
-	require.NoError(t, err)
-	require.Equal(t, res, 1)
-
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil)
 	cash.EXPECT().CheckCash(ctx, key).Return(true)
 	cash.EXPECT().GetItemsQuantityCash(ctx, key).Return(1, nil)
 	res, err = usecase.ItemsQuantityInFavourite(context.Background(), testId)
@@ -872,89 +650,13 @@ func TestItemsQuantityInFavourite(t *testing.T) {
 	require.Equal(t, res, 1)
 }
 
-func TestUpdateFavouriteItemsCash(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	logger := zap.L()
-	itemRepo := mocks.NewMockItemStore(ctrl)
-	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
-	ctx := context.Background()
-
-	cashResults := make([]models.Item, 0, 1)
-	cashResults = append(cashResults, cashItem)
-	updateResults := make([]models.Item, 0, 2)
-	updateResults = append(updateResults, *newItem)
-	updateResults = append(updateResults, *newItem)
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(nil, err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(nil, err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(nil, err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(&testItem1, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 2, testId.String()+"Quantity").Return(err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(newItem, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 2, testId.String()+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, updateResults, testId.String()+"nameasc").Return(err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(newItem, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 2, testId.String()+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, updateResults, testId.String()+"nameasc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"namedesc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(newItem, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 2, testId.String()+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, updateResults, testId.String()+"namedesc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"priceasc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(newItem, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 2, testId.String()+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, updateResults, testId.String()+"priceasc").Return(nil)
-
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"pricedesc").Return(cashResults, nil)
-	itemRepo.EXPECT().GetItem(ctx, testItemId).Return(newItem, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 2, testId.String()+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, updateResults, testId.String()+"pricedesc").Return(nil)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(updateResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, testId.String()+"Quantity").Return(nil)
-	cash.EXPECT().CreateItemsCash(ctx, cashResults, testId.String()+"nameasc").Return(err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "delete")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"nameasc").Return(true)
-	cash.EXPECT().GetItemsCash(ctx, testId.String()+"nameasc").Return(updateResults, nil)
-	cash.EXPECT().CreateItemsQuantityCash(ctx, 1, testId.String()+"Quantity").Return(err)
-	usecase.UpdateFavouriteItemsCash(ctx, testId, testItemId, "delete")
-}
-
 func TestSortItems(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 
 	testItems := []models.Item{
 		{Title: "A"},
@@ -991,6 +693,26 @@ func TestSortItems(t *testing.T) {
 		{Price: 20},
 		{Price: 10},
 	})
+	testItems3 := []models.Item{
+		{Rating: 4.5, ReviewsCount: 2},
+		{Rating: 0},
+		{Rating: 4.5, ReviewsCount: 10},
+		{Rating: 5, ReviewsCount: 1},
+	}
+	usecase.SortItems(testItems3, "rating", "desc")
+	require.Equal(t, testItems3, []models.Item{
+		{Rating: 5, ReviewsCount: 1},
+		{Rating: 4.5, ReviewsCount: 10},
+		{Rating: 4.5, ReviewsCount: 2},
+		{Rating: 0},
+	})
+	usecase.SortItems(testItems3, "rating", "asc")
+	require.Equal(t, testItems3, []models.Item{
+		{Rating: 0},
+		{Rating: 4.5, ReviewsCount: 2},
+		{Rating: 4.5, ReviewsCount: 10},
+		{Rating: 5, ReviewsCount: 1},
+	})
 	usecase.SortItems(testItems, "pricee", "desc")
 }
 
@@ -1000,113 +722,61 @@ func TestGetFavouriteItemsId(t *testing.T) {
 	logger := zap.L()
 	itemRepo := mocks.NewMockItemStore(ctrl)
 	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
+	usecase := NewItemUsecase(itemRepo, cash, "RUB", logger)
 	ctx := gomock.Any()
+	favTag := "favourites:" + testId.String()
 
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(-1, err)
-	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(-1, fmt.Errorf("error"))
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil).Times(2)
+	cash.EXPECT().CheckCash(ctx, favouriteIdsKey).Return(false)
+	cash.EXPECT().CheckCash(ctx, favouriteQuantityKey).Return(true)
+	cash.EXPECT().GetItemsQuantityCash(ctx, favouriteQuantityKey).Return(0, nil)
 	res, err := usecase.GetFavouriteItemsId(context.Background(), testId)
 	require.Error(t, err)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
 	require.Nil(t, res)
 
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(0, nil)
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil).Times(2)
+	cash.EXPECT().CheckCash(ctx, favouriteIdsKey).Return(false)
+	cash.EXPECT().CheckCash(ctx, favouriteQuantityKey).Return(false)
+	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(-1, fmt.Errorf("error"))
 	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
 	require.Error(t, err)
-	require.ErrorIs(t, err, models.ErrorNotFound{})
 	require.Nil(t, res)
 
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(1, nil)
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil).Times(2)
+	cash.EXPECT().CheckCash(ctx, favouriteIdsKey).Return(false)
+	cash.EXPECT().CheckCash(ctx, favouriteQuantityKey).Return(true)
+	cash.EXPECT().GetItemsQuantityCash(ctx, favouriteQuantityKey).Return(1, nil)
 	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(nil, models.ErrorNotFound{})
 	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
 	require.Error(t, err)
 	require.ErrorIs(t, err, models.ErrorNotFound{})
 	require.Nil(t, res)
 
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(1, nil)
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(nil, err)
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil).Times(2)
+	cash.EXPECT().CheckCash(ctx, favouriteIdsKey).Return(false)
+	cash.EXPECT().CheckCash(ctx, favouriteQuantityKey).Return(true)
+	cash.EXPECT().GetItemsQuantityCash(ctx, favouriteQuantityKey).Return(1, nil)
+	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(nil, fmt.Errorf("error"))
 	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
 	require.Error(t, err)
 	require.Nil(t, res)
 
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(1, nil)
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(&testFavUids, nil)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(nil, fmt.Errorf("error"))
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil).Times(2)
+	cash.EXPECT().CheckCash(ctx, favouriteIdsKey).Return(true)
+	cash.EXPECT().GetFavouriteItemsIdCash(ctx, favouriteIdsKey).Return(nil, fmt.Errorf("error"))
+	cash.EXPECT().CheckCash(ctx, favouriteQuantityKey).Return(true)
+	cash.EXPECT().GetItemsQuantityCash(ctx, favouriteQuantityKey).Return(1, nil)
 	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(&testFavUids, nil)
+	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, favouriteIdsKey).Return(fmt.Errorf("error"))
 	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
 	require.NoError(t, err)
 	require.Equal(t, res, &testFavUids)
 
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(1, nil)
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(&testFavUids, nil)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(fmt.Errorf("error"))
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(nil, fmt.Errorf("error"))
-	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
-	require.Error(t, err)
-	require.Nil(t, res)
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(1, nil)
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(nil, fmt.Errorf("error"))
+	cash.EXPECT().GetGenerations(ctx, favTag).Return(testGenerations, nil)
+	cash.EXPECT().CheckCash(ctx, favouriteIdsKey).Return(true)
+	cash.EXPECT().GetFavouriteItemsIdCash(ctx, favouriteIdsKey).Return(&testFavUids, nil)
 	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
-	require.Error(t, err)
-	require.Nil(t, res)
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Quantity").Return(true)
-	cash.EXPECT().GetItemsQuantityCash(ctx, testId.String()+"Quantity").Return(1, nil)
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(&testFavUids, nil)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(nil)
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(nil, fmt.Errorf("error"))
-	itemRepo.EXPECT().GetFavouriteItemsId(ctx, testId).Return(nil, models.ErrorNotFound{})
-	res, err = usecase.GetFavouriteItemsId(context.Background(), testId)
-	require.Error(t, err)
-	require.ErrorIs(t, err, models.ErrorNotFound{})
-	require.Nil(t, res)
-}
-
-func TestUpdateFavIdsCash(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	logger := zap.L()
-	itemRepo := mocks.NewMockItemStore(ctrl)
-	cash := mocks.NewMockIItemsCash(ctrl)
-	usecase := NewItemUsecase(itemRepo, cash, logger)
-	ctx := context.Background()
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(err)
-	usecase.UpdateFavIdsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(false)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(nil)
-	usecase.UpdateFavIdsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(true)
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(nil, err)
-	usecase.UpdateFavIdsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(true)
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(&testFavUids, nil)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(err)
-	usecase.UpdateFavIdsCash(ctx, testId, testItemId, "add")
-
-	cash.EXPECT().CheckCash(ctx, testId.String()+"Fav").Return(true)
-	cash.EXPECT().GetFavouriteItemsIdCash(ctx, testId.String()+"Fav").Return(&testFavUids, nil)
-	cash.EXPECT().CreateFavouriteItemsIdCash(ctx, testFavUids, testId.String()+"Fav").Return(nil)
-	usecase.UpdateFavIdsCash(ctx, testId, testItemId, "delete")
+	require.NoError(t, err)
+	require.Equal(t, res, &testFavUids)
 }
@@ -8,6 +8,8 @@ import (
 	user "OnlineShopBackend/internal/delivery/user"
 	models "OnlineShopBackend/internal/models"
 	context "context"
+	io "io"
+	http "net/http"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -244,54 +246,44 @@ func (mr *MockIItemUsecaseMockRecorder) SearchLine(ctx, param, limitOptions, sor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchLine", reflect.TypeOf((*MockIItemUsecase)(nil).SearchLine), ctx, param, limitOptions, sortOptions)
 }
 
-// SortItems mocks base method.
-func (m *MockIItemUsecase) SortItems(items []models.Item, sortType, sortOrder string) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SortItems", items, sortType, sortOrder)
-}
-
-// SortItems indicates an expected call of SortItems.
-func (mr *MockIItemUsecaseMockRecorder) SortItems(items, sortType, sortOrder interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SortItems", reflect.TypeOf((*MockIItemUsecase)(nil).SortItems), items, sortType, sortOrder)
-}
-
-// UpdateCash mocks base method.
-func (m *MockIItemUsecase) UpdateCash(ctx context.Context, id uuid.UUID, op string) error {
+// SetItemStock mocks base method.
+func (m *MockIItemUsecase) SetItemStock(ctx context.Context, id uuid.UUID, stock *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCash", ctx, id, op)
+	ret := m.ctrl.Call(m, "SetItemStock", ctx, id, stock)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateCash indicates an expected call of UpdateCash.
-func (mr *MockIItemUsecaseMockRecorder) UpdateCash(ctx, id, op interface{}) *gomock.Call {
+// SetItemStock indicates an expected call of SetItemStock.
+func (mr *MockIItemUsecaseMockRecorder) SetItemStock(ctx, id, stock interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCash", reflect.TypeOf((*MockIItemUsecase)(nil).UpdateCash), ctx, id, op)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemStock", reflect.TypeOf((*MockIItemUsecase)(nil).SetItemStock), ctx, id, stock)
 }
 
-// UpdateFavIdsCash mocks base method.
-func (m *MockIItemUsecase) UpdateFavIdsCash(ctx context.Context, userId, itemId uuid.UUID, op string) {
+// SetQuantityLimits mocks base method.
+func (m *MockIItemUsecase) SetQuantityLimits(ctx context.Context, id uuid.UUID, limits models.QuantityLimits) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "UpdateFavIdsCash", ctx, userId, itemId, op)
+	ret := m.ctrl.Call(m, "SetQuantityLimits", ctx, id, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateFavIdsCash indicates an expected call of UpdateFavIdsCash.
-func (mr *MockIItemUsecaseMockRecorder) UpdateFavIdsCash(ctx, userId, itemId, op interface{}) *gomock.Call {
+// SetQuantityLimits indicates an expected call of SetQuantityLimits.
+func (mr *MockIItemUsecaseMockRecorder) SetQuantityLimits(ctx, id, limits interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavIdsCash", reflect.TypeOf((*MockIItemUsecase)(nil).UpdateFavIdsCash), ctx, userId, itemId, op)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQuantityLimits", reflect.TypeOf((*MockIItemUsecase)(nil).SetQuantityLimits), ctx, id, limits)
 }
 
-// UpdateFavouriteItemsCash mocks base method.
-func (m *MockIItemUsecase) UpdateFavouriteItemsCash(ctx context.Context, userId, itemId uuid.UUID, op string) {
+// SortItems mocks base method.
+func (m *MockIItemUsecase) SortItems(items []models.Item, sortType, sortOrder string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "UpdateFavouriteItemsCash", ctx, userId, itemId, op)
+	m.ctrl.Call(m, "SortItems", items, sortType, sortOrder)
 }
 
-// UpdateFavouriteItemsCash indicates an expected call of UpdateFavouriteItemsCash.
-func (mr *MockIItemUsecaseMockRecorder) UpdateFavouriteItemsCash(ctx, userId, itemId, op interface{}) *gomock.Call {
+// SortItems indicates an expected call of SortItems.
+func (mr *MockIItemUsecaseMockRecorder) SortItems(items, sortType, sortOrder interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavouriteItemsCash", reflect.TypeOf((*MockIItemUsecase)(nil).UpdateFavouriteItemsCash), ctx, userId, itemId, op)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SortItems", reflect.TypeOf((*MockIItemUsecase)(nil).SortItems), items, sortType, sortOrder)
 }
 
 // UpdateItem mocks base method.
@@ -308,20 +300,6 @@ func (mr *MockIItemUsecaseMockRecorder) UpdateItem(ctx, item interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockIItemUsecase)(nil).UpdateItem), ctx, item)
 }
 
-// UpdateItemsInCategoryCash mocks base method.
-func (m *MockIItemUsecase) UpdateItemsInCategoryCash(ctx context.Context, newItem *models.Item, op string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItemsInCategoryCash", ctx, newItem, op)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateItemsInCategoryCash indicates an expected call of UpdateItemsInCategoryCash.
-func (mr *MockIItemUsecaseMockRecorder) UpdateItemsInCategoryCash(ctx, newItem, op interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemsInCategoryCash", reflect.TypeOf((*MockIItemUsecase)(nil).UpdateItemsInCategoryCash), ctx, newItem, op)
-}
-
 // MockICategoryUsecase is a mock of ICategoryUsecase interface.
 type MockICategoryUsecase struct {
 	ctrl     *gomock.Controller
@@ -374,20 +352,6 @@ func (mr *MockICategoryUsecaseMockRecorder) DeleteCategory(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockICategoryUsecase)(nil).DeleteCategory), ctx, id)
 }
 
-// DeleteCategoryCash mocks base method.
-func (m *MockICategoryUsecase) DeleteCategoryCash(ctx context.Context, name string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategoryCash", ctx, name)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteCategoryCash indicates an expected call of DeleteCategoryCash.
-func (mr *MockICategoryUsecaseMockRecorder) DeleteCategoryCash(ctx, name interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryCash", reflect.TypeOf((*MockICategoryUsecase)(nil).DeleteCategoryCash), ctx, name)
-}
-
 // GetCategory mocks base method.
 func (m *MockICategoryUsecase) GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error) {
 	m.ctrl.T.Helper()
@@ -433,20 +397,6 @@ func (mr *MockICategoryUsecaseMockRecorder) GetCategoryList(ctx interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryList", reflect.TypeOf((*MockICategoryUsecase)(nil).GetCategoryList), ctx)
 }
 
-// UpdateCash mocks base method.
-func (m *MockICategoryUsecase) UpdateCash(ctx context.Context, id uuid.UUID, op string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCash", ctx, id, op)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateCash indicates an expected call of UpdateCash.
-func (mr *MockICategoryUsecaseMockRecorder) UpdateCash(ctx, id, op interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCash", reflect.TypeOf((*MockICategoryUsecase)(nil).UpdateCash), ctx, id, op)
-}
-
 // UpdateCategory mocks base method.
 func (m *MockICategoryUsecase) UpdateCategory(ctx context.Context, category *models.Category) error {
 	m.ctrl.T.Helper()
@@ -557,18 +507,18 @@ func (mr *MockIOrderUsecaseMockRecorder) GetOrdersForUser(ctx, user interface{})
 }
 
 // PlaceOrder mocks base method.
-func (m *MockIOrderUsecase) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress) (*models.Order, error) {
+func (m *MockIOrderUsecase) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress, shippingMethodId uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PlaceOrder", ctx, cart, user, address)
+	ret := m.ctrl.Call(m, "PlaceOrder", ctx, cart, user, address, shippingMethodId)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PlaceOrder indicates an expected call of PlaceOrder.
-func (mr *MockIOrderUsecaseMockRecorder) PlaceOrder(ctx, cart, user, address interface{}) *gomock.Call {
+func (mr *MockIOrderUsecaseMockRecorder) PlaceOrder(ctx, cart, user, address, shippingMethodId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockIOrderUsecase)(nil).PlaceOrder), ctx, cart, user, address)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockIOrderUsecase)(nil).PlaceOrder), ctx, cart, user, address, shippingMethodId)
 }
 
 // MockICartUsecase is a mock of ICartUsecase interface.
@@ -608,6 +558,35 @@ func (mr *MockICartUsecaseMockRecorder) AddItemToCart(ctx, cartId, itemId interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToCart", reflect.TypeOf((*MockICartUsecase)(nil).AddItemToCart), ctx, cartId, itemId)
 }
 
+// ApplyPromoCode mocks base method.
+func (m *MockICartUsecase) ApplyPromoCode(ctx context.Context, cartId uuid.UUID, code string) (*models.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromoCode", ctx, cartId, code)
+	ret0, _ := ret[0].(*models.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPromoCode indicates an expected call of ApplyPromoCode.
+func (mr *MockICartUsecaseMockRecorder) ApplyPromoCode(ctx, cartId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromoCode", reflect.TypeOf((*MockICartUsecase)(nil).ApplyPromoCode), ctx, cartId, code)
+}
+
+// ApplyTaxes mocks base method.
+func (m *MockICartUsecase) ApplyTaxes(ctx context.Context, cart *models.Cart, address models.UserAddress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTaxes", ctx, cart, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyTaxes indicates an expected call of ApplyTaxes.
+func (mr *MockICartUsecaseMockRecorder) ApplyTaxes(ctx, cart, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTaxes", reflect.TypeOf((*MockICartUsecase)(nil).ApplyTaxes), ctx, cart, address)
+}
+
 // Create mocks base method.
 func (m *MockICartUsecase) Create(ctx context.Context, userId uuid.UUID) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -623,6 +602,21 @@ func (mr *MockICartUsecaseMockRecorder) Create(ctx, userId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICartUsecase)(nil).Create), ctx, userId)
 }
 
+// DecrementItem mocks base method.
+func (m *MockICartUsecase) DecrementItem(ctx context.Context, cartId, itemId uuid.UUID, decrement int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementItem", ctx, cartId, itemId, decrement)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementItem indicates an expected call of DecrementItem.
+func (mr *MockICartUsecaseMockRecorder) DecrementItem(ctx, cartId, itemId, decrement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementItem", reflect.TypeOf((*MockICartUsecase)(nil).DecrementItem), ctx, cartId, itemId, decrement)
+}
+
 // DeleteCart mocks base method.
 func (m *MockICartUsecase) DeleteCart(ctx context.Context, cartId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -681,6 +675,147 @@ func (mr *MockICartUsecaseMockRecorder) GetCartByUserId(ctx, userId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartByUserId", reflect.TypeOf((*MockICartUsecase)(nil).GetCartByUserId), ctx, userId)
 }
 
+// MergeGuestCart mocks base method.
+func (m *MockICartUsecase) MergeGuestCart(ctx context.Context, guestCartId, userId uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeGuestCart", ctx, guestCartId, userId)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeGuestCart indicates an expected call of MergeGuestCart.
+func (mr *MockICartUsecaseMockRecorder) MergeGuestCart(ctx, guestCartId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeGuestCart", reflect.TypeOf((*MockICartUsecase)(nil).MergeGuestCart), ctx, guestCartId, userId)
+}
+
+// PurgeExpiredCarts mocks base method.
+func (m *MockICartUsecase) PurgeExpiredCarts(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredCarts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredCarts indicates an expected call of PurgeExpiredCarts.
+func (mr *MockICartUsecaseMockRecorder) PurgeExpiredCarts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredCarts", reflect.TypeOf((*MockICartUsecase)(nil).PurgeExpiredCarts), ctx)
+}
+
+// RemovePromoCode mocks base method.
+func (m *MockICartUsecase) RemovePromoCode(ctx context.Context, cartId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePromoCode", ctx, cartId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePromoCode indicates an expected call of RemovePromoCode.
+func (mr *MockICartUsecaseMockRecorder) RemovePromoCode(ctx, cartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePromoCode", reflect.TypeOf((*MockICartUsecase)(nil).RemovePromoCode), ctx, cartId)
+}
+
+// Reorder mocks base method.
+func (m *MockICartUsecase) Reorder(ctx context.Context, userId uuid.UUID, order *models.Order) (*models.Reorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, userId, order)
+	ret0, _ := ret[0].(*models.Reorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockICartUsecaseMockRecorder) Reorder(ctx, userId, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockICartUsecase)(nil).Reorder), ctx, userId, order)
+}
+
+// SetItemQuantity mocks base method.
+func (m *MockICartUsecase) SetItemQuantity(ctx context.Context, cartId, itemId uuid.UUID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemQuantity", ctx, cartId, itemId, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemQuantity indicates an expected call of SetItemQuantity.
+func (mr *MockICartUsecaseMockRecorder) SetItemQuantity(ctx, cartId, itemId, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemQuantity", reflect.TypeOf((*MockICartUsecase)(nil).SetItemQuantity), ctx, cartId, itemId, quantity)
+}
+
+// MockICartReminderUsecase is a mock of ICartReminderUsecase interface.
+type MockICartReminderUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICartReminderUsecaseMockRecorder
+}
+
+// MockICartReminderUsecaseMockRecorder is the mock recorder for MockICartReminderUsecase.
+type MockICartReminderUsecaseMockRecorder struct {
+	mock *MockICartReminderUsecase
+}
+
+// NewMockICartReminderUsecase creates a new mock instance.
+func NewMockICartReminderUsecase(ctrl *gomock.Controller) *MockICartReminderUsecase {
+	mock := &MockICartReminderUsecase{ctrl: ctrl}
+	mock.recorder = &MockICartReminderUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICartReminderUsecase) EXPECT() *MockICartReminderUsecaseMockRecorder {
+	return m.recorder
+}
+
+// RecoverCart mocks base method.
+func (m *MockICartReminderUsecase) RecoverCart(ctx context.Context, cartId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverCart", ctx, cartId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverCart indicates an expected call of RecoverCart.
+func (mr *MockICartReminderUsecaseMockRecorder) RecoverCart(ctx, cartId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverCart", reflect.TypeOf((*MockICartReminderUsecase)(nil).RecoverCart), ctx, cartId)
+}
+
+// RemindAbandonedCarts mocks base method.
+func (m *MockICartReminderUsecase) RemindAbandonedCarts(ctx context.Context) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemindAbandonedCarts", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemindAbandonedCarts indicates an expected call of RemindAbandonedCarts.
+func (mr *MockICartReminderUsecaseMockRecorder) RemindAbandonedCarts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemindAbandonedCarts", reflect.TypeOf((*MockICartReminderUsecase)(nil).RemindAbandonedCarts), ctx)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockICartReminderUsecase) Unsubscribe(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockICartReminderUsecaseMockRecorder) Unsubscribe(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockICartReminderUsecase)(nil).Unsubscribe), ctx, userId)
+}
+
 // MockIUserUsecase is a mock of IUserUsecase interface.
 type MockIUserUsecase struct {
 	ctrl     *gomock.Controller
@@ -807,3 +942,1340 @@ func (mr *MockIUserUsecaseMockRecorder) UpdateUserRole(ctx, roleId, email interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockIUserUsecase)(nil).UpdateUserRole), ctx, roleId, email)
 }
+
+// MockICatalogueUsecase is a mock of ICatalogueUsecase interface.
+type MockICatalogueUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICatalogueUsecaseMockRecorder
+}
+
+// MockICatalogueUsecaseMockRecorder is the mock recorder for MockICatalogueUsecase.
+type MockICatalogueUsecaseMockRecorder struct {
+	mock *MockICatalogueUsecase
+}
+
+// NewMockICatalogueUsecase creates a new mock instance.
+func NewMockICatalogueUsecase(ctrl *gomock.Controller) *MockICatalogueUsecase {
+	mock := &MockICatalogueUsecase{ctrl: ctrl}
+	mock.recorder = &MockICatalogueUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICatalogueUsecase) EXPECT() *MockICatalogueUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockICatalogueUsecase) Export(ctx context.Context, w io.Writer, format string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockICatalogueUsecaseMockRecorder) Export(ctx, w, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockICatalogueUsecase)(nil).Export), ctx, w, format)
+}
+
+// Import mocks base method.
+func (m *MockICatalogueUsecase) Import(ctx context.Context, r io.Reader, format string, dryRun bool) (*models.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, r, format, dryRun)
+	ret0, _ := ret[0].(*models.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockICatalogueUsecaseMockRecorder) Import(ctx, r, format, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockICatalogueUsecase)(nil).Import), ctx, r, format, dryRun)
+}
+
+// MockIUploadUsecase is a mock of IUploadUsecase interface.
+type MockIUploadUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUploadUsecaseMockRecorder
+}
+
+// MockIUploadUsecaseMockRecorder is the mock recorder for MockIUploadUsecase.
+type MockIUploadUsecaseMockRecorder struct {
+	mock *MockIUploadUsecase
+}
+
+// NewMockIUploadUsecase creates a new mock instance.
+func NewMockIUploadUsecase(ctrl *gomock.Controller) *MockIUploadUsecase {
+	mock := &MockIUploadUsecase{ctrl: ctrl}
+	mock.recorder = &MockIUploadUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUploadUsecase) EXPECT() *MockIUploadUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CompleteUpload mocks base method.
+func (m *MockIUploadUsecase) CompleteUpload(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockIUploadUsecaseMockRecorder) CompleteUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockIUploadUsecase)(nil).CompleteUpload), ctx, id)
+}
+
+// CreateUpload mocks base method.
+func (m *MockIUploadUsecase) CreateUpload(ctx context.Context, kind string, ownerId uuid.UUID) (*models.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, kind, ownerId)
+	ret0, _ := ret[0].(*models.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockIUploadUsecaseMockRecorder) CreateUpload(ctx, kind, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockIUploadUsecase)(nil).CreateUpload), ctx, kind, ownerId)
+}
+
+// DeleteExpiredUploads mocks base method.
+func (m *MockIUploadUsecase) DeleteExpiredUploads(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUploads", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredUploads indicates an expected call of DeleteExpiredUploads.
+func (mr *MockIUploadUsecaseMockRecorder) DeleteExpiredUploads(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUploads", reflect.TypeOf((*MockIUploadUsecase)(nil).DeleteExpiredUploads), ctx)
+}
+
+// FailUpload mocks base method.
+func (m *MockIUploadUsecase) FailUpload(ctx context.Context, id uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FailUpload", ctx, id)
+}
+
+// FailUpload indicates an expected call of FailUpload.
+func (mr *MockIUploadUsecaseMockRecorder) FailUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailUpload", reflect.TypeOf((*MockIUploadUsecase)(nil).FailUpload), ctx, id)
+}
+
+// PutUploadFile mocks base method.
+func (m *MockIUploadUsecase) PutUploadFile(ctx context.Context, id uuid.UUID, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutUploadFile", ctx, id, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutUploadFile indicates an expected call of PutUploadFile.
+func (mr *MockIUploadUsecaseMockRecorder) PutUploadFile(ctx, id, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutUploadFile", reflect.TypeOf((*MockIUploadUsecase)(nil).PutUploadFile), ctx, id, r)
+}
+
+// StartUpload mocks base method.
+func (m *MockIUploadUsecase) StartUpload(ctx context.Context, id uuid.UUID) (*models.UploadSession, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartUpload", ctx, id)
+	ret0, _ := ret[0].(*models.UploadSession)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StartUpload indicates an expected call of StartUpload.
+func (mr *MockIUploadUsecaseMockRecorder) StartUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartUpload", reflect.TypeOf((*MockIUploadUsecase)(nil).StartUpload), ctx, id)
+}
+
+// MockIStorageUsecase is a mock of IStorageUsecase interface.
+type MockIStorageUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStorageUsecaseMockRecorder
+}
+
+// MockIStorageUsecaseMockRecorder is the mock recorder for MockIStorageUsecase.
+type MockIStorageUsecaseMockRecorder struct {
+	mock *MockIStorageUsecase
+}
+
+// NewMockIStorageUsecase creates a new mock instance.
+func NewMockIStorageUsecase(ctrl *gomock.Controller) *MockIStorageUsecase {
+	mock := &MockIStorageUsecase{ctrl: ctrl}
+	mock.recorder = &MockIStorageUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStorageUsecase) EXPECT() *MockIStorageUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CheckStorage mocks base method.
+func (m *MockIStorageUsecase) CheckStorage(ctx context.Context, dryRun bool) (*models.StorageReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckStorage", ctx, dryRun)
+	ret0, _ := ret[0].(*models.StorageReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckStorage indicates an expected call of CheckStorage.
+func (mr *MockIStorageUsecaseMockRecorder) CheckStorage(ctx, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStorage", reflect.TypeOf((*MockIStorageUsecase)(nil).CheckStorage), ctx, dryRun)
+}
+
+// MockIReviewUsecase is a mock of IReviewUsecase interface.
+type MockIReviewUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReviewUsecaseMockRecorder
+}
+
+// MockIReviewUsecaseMockRecorder is the mock recorder for MockIReviewUsecase.
+type MockIReviewUsecaseMockRecorder struct {
+	mock *MockIReviewUsecase
+}
+
+// NewMockIReviewUsecase creates a new mock instance.
+func NewMockIReviewUsecase(ctrl *gomock.Controller) *MockIReviewUsecase {
+	mock := &MockIReviewUsecase{ctrl: ctrl}
+	mock.recorder = &MockIReviewUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReviewUsecase) EXPECT() *MockIReviewUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockIReviewUsecase) CreateReview(ctx context.Context, review *models.Review) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, review)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockIReviewUsecaseMockRecorder) CreateReview(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockIReviewUsecase)(nil).CreateReview), ctx, review)
+}
+
+// GetItemReviews mocks base method.
+func (m *MockIReviewUsecase) GetItemReviews(ctx context.Context, itemId uuid.UUID, offset, limit int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemReviews", ctx, itemId, offset, limit)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemReviews indicates an expected call of GetItemReviews.
+func (mr *MockIReviewUsecaseMockRecorder) GetItemReviews(ctx, itemId, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemReviews", reflect.TypeOf((*MockIReviewUsecase)(nil).GetItemReviews), ctx, itemId, offset, limit)
+}
+
+// GetReviews mocks base method.
+func (m *MockIReviewUsecase) GetReviews(ctx context.Context, status models.ReviewStatus, offset, limit int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviews", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviews indicates an expected call of GetReviews.
+func (mr *MockIReviewUsecaseMockRecorder) GetReviews(ctx, status, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviews", reflect.TypeOf((*MockIReviewUsecase)(nil).GetReviews), ctx, status, offset, limit)
+}
+
+// ModerateReview mocks base method.
+func (m *MockIReviewUsecase) ModerateReview(ctx context.Context, id uuid.UUID, status models.ReviewStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockIReviewUsecaseMockRecorder) ModerateReview(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockIReviewUsecase)(nil).ModerateReview), ctx, id, status)
+}
+
+// MockIPromotionUsecase is a mock of IPromotionUsecase interface.
+type MockIPromotionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPromotionUsecaseMockRecorder
+}
+
+// MockIPromotionUsecaseMockRecorder is the mock recorder for MockIPromotionUsecase.
+type MockIPromotionUsecaseMockRecorder struct {
+	mock *MockIPromotionUsecase
+}
+
+// NewMockIPromotionUsecase creates a new mock instance.
+func NewMockIPromotionUsecase(ctrl *gomock.Controller) *MockIPromotionUsecase {
+	mock := &MockIPromotionUsecase{ctrl: ctrl}
+	mock.recorder = &MockIPromotionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPromotionUsecase) EXPECT() *MockIPromotionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockIPromotionUsecase) CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, promotion)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockIPromotionUsecaseMockRecorder) CreatePromotion(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockIPromotionUsecase)(nil).CreatePromotion), ctx, promotion)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockIPromotionUsecase) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockIPromotionUsecaseMockRecorder) DeletePromotion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockIPromotionUsecase)(nil).DeletePromotion), ctx, id)
+}
+
+// GetPromotion mocks base method.
+func (m *MockIPromotionUsecase) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", ctx, id)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockIPromotionUsecaseMockRecorder) GetPromotion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockIPromotionUsecase)(nil).GetPromotion), ctx, id)
+}
+
+// GetPromotions mocks base method.
+func (m *MockIPromotionUsecase) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", ctx)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockIPromotionUsecaseMockRecorder) GetPromotions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockIPromotionUsecase)(nil).GetPromotions), ctx)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockIPromotionUsecase) UpdatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", ctx, promotion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockIPromotionUsecaseMockRecorder) UpdatePromotion(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockIPromotionUsecase)(nil).UpdatePromotion), ctx, promotion)
+}
+
+// MockICurrencyUsecase is a mock of ICurrencyUsecase interface.
+type MockICurrencyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockICurrencyUsecaseMockRecorder
+}
+
+// MockICurrencyUsecaseMockRecorder is the mock recorder for MockICurrencyUsecase.
+type MockICurrencyUsecaseMockRecorder struct {
+	mock *MockICurrencyUsecase
+}
+
+// NewMockICurrencyUsecase creates a new mock instance.
+func NewMockICurrencyUsecase(ctrl *gomock.Controller) *MockICurrencyUsecase {
+	mock := &MockICurrencyUsecase{ctrl: ctrl}
+	mock.recorder = &MockICurrencyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICurrencyUsecase) EXPECT() *MockICurrencyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockICurrencyUsecase) BaseCurrency() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockICurrencyUsecaseMockRecorder) BaseCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockICurrencyUsecase)(nil).BaseCurrency))
+}
+
+// DeleteRate mocks base method.
+func (m *MockICurrencyUsecase) DeleteRate(ctx context.Context, currency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockICurrencyUsecaseMockRecorder) DeleteRate(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockICurrencyUsecase)(nil).DeleteRate), ctx, currency)
+}
+
+// GetRates mocks base method.
+func (m *MockICurrencyUsecase) GetRates(ctx context.Context) (*models.ExchangeRates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", ctx)
+	ret0, _ := ret[0].(*models.ExchangeRates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockICurrencyUsecaseMockRecorder) GetRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockICurrencyUsecase)(nil).GetRates), ctx)
+}
+
+// GetRatesList mocks base method.
+func (m *MockICurrencyUsecase) GetRatesList(ctx context.Context) ([]models.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatesList", ctx)
+	ret0, _ := ret[0].([]models.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatesList indicates an expected call of GetRatesList.
+func (mr *MockICurrencyUsecaseMockRecorder) GetRatesList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatesList", reflect.TypeOf((*MockICurrencyUsecase)(nil).GetRatesList), ctx)
+}
+
+// SetRate mocks base method.
+func (m *MockICurrencyUsecase) SetRate(ctx context.Context, rate *models.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRate indicates an expected call of SetRate.
+func (mr *MockICurrencyUsecaseMockRecorder) SetRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockICurrencyUsecase)(nil).SetRate), ctx, rate)
+}
+
+// MockITaxUsecase is a mock of ITaxUsecase interface.
+type MockITaxUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaxUsecaseMockRecorder
+}
+
+// MockITaxUsecaseMockRecorder is the mock recorder for MockITaxUsecase.
+type MockITaxUsecaseMockRecorder struct {
+	mock *MockITaxUsecase
+}
+
+// NewMockITaxUsecase creates a new mock instance.
+func NewMockITaxUsecase(ctrl *gomock.Controller) *MockITaxUsecase {
+	mock := &MockITaxUsecase{ctrl: ctrl}
+	mock.recorder = &MockITaxUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaxUsecase) EXPECT() *MockITaxUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRule mocks base method.
+func (m *MockITaxUsecase) CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRule", ctx, rule)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRule indicates an expected call of CreateTaxRule.
+func (mr *MockITaxUsecaseMockRecorder) CreateTaxRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRule", reflect.TypeOf((*MockITaxUsecase)(nil).CreateTaxRule), ctx, rule)
+}
+
+// DeleteTaxRule mocks base method.
+func (m *MockITaxUsecase) DeleteTaxRule(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRule indicates an expected call of DeleteTaxRule.
+func (mr *MockITaxUsecaseMockRecorder) DeleteTaxRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRule", reflect.TypeOf((*MockITaxUsecase)(nil).DeleteTaxRule), ctx, id)
+}
+
+// GetTaxRules mocks base method.
+func (m *MockITaxUsecase) GetTaxRules(ctx context.Context) ([]models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRules", ctx)
+	ret0, _ := ret[0].([]models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRules indicates an expected call of GetTaxRules.
+func (mr *MockITaxUsecaseMockRecorder) GetTaxRules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRules", reflect.TypeOf((*MockITaxUsecase)(nil).GetTaxRules), ctx)
+}
+
+// SetCategoryTaxClass mocks base method.
+func (m *MockITaxUsecase) SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryTaxClass", ctx, categoryId, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCategoryTaxClass indicates an expected call of SetCategoryTaxClass.
+func (mr *MockITaxUsecaseMockRecorder) SetCategoryTaxClass(ctx, categoryId, class interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryTaxClass", reflect.TypeOf((*MockITaxUsecase)(nil).SetCategoryTaxClass), ctx, categoryId, class)
+}
+
+// SetItemTaxClass mocks base method.
+func (m *MockITaxUsecase) SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemTaxClass", ctx, itemId, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemTaxClass indicates an expected call of SetItemTaxClass.
+func (mr *MockITaxUsecaseMockRecorder) SetItemTaxClass(ctx, itemId, class interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemTaxClass", reflect.TypeOf((*MockITaxUsecase)(nil).SetItemTaxClass), ctx, itemId, class)
+}
+
+// UpdateTaxRule mocks base method.
+func (m *MockITaxUsecase) UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaxRule indicates an expected call of UpdateTaxRule.
+func (mr *MockITaxUsecaseMockRecorder) UpdateTaxRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRule", reflect.TypeOf((*MockITaxUsecase)(nil).UpdateTaxRule), ctx, rule)
+}
+
+// MockIShippingUsecase is a mock of IShippingUsecase interface.
+type MockIShippingUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShippingUsecaseMockRecorder
+}
+
+// MockIShippingUsecaseMockRecorder is the mock recorder for MockIShippingUsecase.
+type MockIShippingUsecaseMockRecorder struct {
+	mock *MockIShippingUsecase
+}
+
+// NewMockIShippingUsecase creates a new mock instance.
+func NewMockIShippingUsecase(ctrl *gomock.Controller) *MockIShippingUsecase {
+	mock := &MockIShippingUsecase{ctrl: ctrl}
+	mock.recorder = &MockIShippingUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShippingUsecase) EXPECT() *MockIShippingUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateShippingMethod mocks base method.
+func (m *MockIShippingUsecase) CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShippingMethod", ctx, method)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShippingMethod indicates an expected call of CreateShippingMethod.
+func (mr *MockIShippingUsecaseMockRecorder) CreateShippingMethod(ctx, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShippingMethod", reflect.TypeOf((*MockIShippingUsecase)(nil).CreateShippingMethod), ctx, method)
+}
+
+// DeleteShippingMethod mocks base method.
+func (m *MockIShippingUsecase) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShippingMethod", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShippingMethod indicates an expected call of DeleteShippingMethod.
+func (mr *MockIShippingUsecaseMockRecorder) DeleteShippingMethod(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShippingMethod", reflect.TypeOf((*MockIShippingUsecase)(nil).DeleteShippingMethod), ctx, id)
+}
+
+// GetShippingMethods mocks base method.
+func (m *MockIShippingUsecase) GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShippingMethods", ctx)
+	ret0, _ := ret[0].([]models.ShippingMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShippingMethods indicates an expected call of GetShippingMethods.
+func (mr *MockIShippingUsecaseMockRecorder) GetShippingMethods(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShippingMethods", reflect.TypeOf((*MockIShippingUsecase)(nil).GetShippingMethods), ctx)
+}
+
+// QuoteShipping mocks base method.
+func (m *MockIShippingUsecase) QuoteShipping(ctx context.Context, cart *models.Cart, address models.UserAddress) ([]models.ShippingQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteShipping", ctx, cart, address)
+	ret0, _ := ret[0].([]models.ShippingQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteShipping indicates an expected call of QuoteShipping.
+func (mr *MockIShippingUsecaseMockRecorder) QuoteShipping(ctx, cart, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteShipping", reflect.TypeOf((*MockIShippingUsecase)(nil).QuoteShipping), ctx, cart, address)
+}
+
+// SetItemWeight mocks base method.
+func (m *MockIShippingUsecase) SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemWeight", ctx, itemId, weight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItemWeight indicates an expected call of SetItemWeight.
+func (mr *MockIShippingUsecaseMockRecorder) SetItemWeight(ctx, itemId, weight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemWeight", reflect.TypeOf((*MockIShippingUsecase)(nil).SetItemWeight), ctx, itemId, weight)
+}
+
+// UpdateShippingMethod mocks base method.
+func (m *MockIShippingUsecase) UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShippingMethod", ctx, method)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateShippingMethod indicates an expected call of UpdateShippingMethod.
+func (mr *MockIShippingUsecaseMockRecorder) UpdateShippingMethod(ctx, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShippingMethod", reflect.TypeOf((*MockIShippingUsecase)(nil).UpdateShippingMethod), ctx, method)
+}
+
+// MockIPaymentUsecase is a mock of IPaymentUsecase interface.
+type MockIPaymentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentUsecaseMockRecorder
+}
+
+// MockIPaymentUsecaseMockRecorder is the mock recorder for MockIPaymentUsecase.
+type MockIPaymentUsecaseMockRecorder struct {
+	mock *MockIPaymentUsecase
+}
+
+// NewMockIPaymentUsecase creates a new mock instance.
+func NewMockIPaymentUsecase(ctrl *gomock.Controller) *MockIPaymentUsecase {
+	mock := &MockIPaymentUsecase{ctrl: ctrl}
+	mock.recorder = &MockIPaymentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentUsecase) EXPECT() *MockIPaymentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m *MockIPaymentUsecase) Capture(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, id)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockIPaymentUsecaseMockRecorder) Capture(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockIPaymentUsecase)(nil).Capture), ctx, id)
+}
+
+// GetOrderPayments mocks base method.
+func (m *MockIPaymentUsecase) GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderPayments", ctx, orderId)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderPayments indicates an expected call of GetOrderPayments.
+func (mr *MockIPaymentUsecaseMockRecorder) GetOrderPayments(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderPayments", reflect.TypeOf((*MockIPaymentUsecase)(nil).GetOrderPayments), ctx, orderId)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockIPaymentUsecase) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", ctx, payload, header)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockIPaymentUsecaseMockRecorder) HandleWebhook(ctx, payload, header interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockIPaymentUsecase)(nil).HandleWebhook), ctx, payload, header)
+}
+
+// Pay mocks base method.
+func (m *MockIPaymentUsecase) Pay(ctx context.Context, orderId, userId uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, orderId, userId)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockIPaymentUsecaseMockRecorder) Pay(ctx, orderId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockIPaymentUsecase)(nil).Pay), ctx, orderId, userId)
+}
+
+// Refund mocks base method.
+func (m *MockIPaymentUsecase) Refund(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, id, amount)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockIPaymentUsecaseMockRecorder) Refund(ctx, id, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockIPaymentUsecase)(nil).Refund), ctx, id, amount)
+}
+
+// MockIReturnUsecase is a mock of IReturnUsecase interface.
+type MockIReturnUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReturnUsecaseMockRecorder
+}
+
+// MockIReturnUsecaseMockRecorder is the mock recorder for MockIReturnUsecase.
+type MockIReturnUsecaseMockRecorder struct {
+	mock *MockIReturnUsecase
+}
+
+// NewMockIReturnUsecase creates a new mock instance.
+func NewMockIReturnUsecase(ctrl *gomock.Controller) *MockIReturnUsecase {
+	mock := &MockIReturnUsecase{ctrl: ctrl}
+	mock.recorder = &MockIReturnUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReturnUsecase) EXPECT() *MockIReturnUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApproveReturn mocks base method.
+func (m *MockIReturnUsecase) ApproveReturn(ctx context.Context, id uuid.UUID, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReturn", ctx, id, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveReturn indicates an expected call of ApproveReturn.
+func (mr *MockIReturnUsecaseMockRecorder) ApproveReturn(ctx, id, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).ApproveReturn), ctx, id, comment)
+}
+
+// GetOrderRefunds mocks base method.
+func (m *MockIReturnUsecase) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRefunds", ctx, orderId)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRefunds indicates an expected call of GetOrderRefunds.
+func (mr *MockIReturnUsecaseMockRecorder) GetOrderRefunds(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRefunds", reflect.TypeOf((*MockIReturnUsecase)(nil).GetOrderRefunds), ctx, orderId)
+}
+
+// GetOrderReturns mocks base method.
+func (m *MockIReturnUsecase) GetOrderReturns(ctx context.Context, userId, orderId uuid.UUID) ([]models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderReturns", ctx, userId, orderId)
+	ret0, _ := ret[0].([]models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderReturns indicates an expected call of GetOrderReturns.
+func (mr *MockIReturnUsecaseMockRecorder) GetOrderReturns(ctx, userId, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderReturns", reflect.TypeOf((*MockIReturnUsecase)(nil).GetOrderReturns), ctx, userId, orderId)
+}
+
+// GetReturns mocks base method.
+func (m *MockIReturnUsecase) GetReturns(ctx context.Context, status models.ReturnStatus) ([]models.Return, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturns", ctx, status)
+	ret0, _ := ret[0].([]models.Return)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockIReturnUsecaseMockRecorder) GetReturns(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockIReturnUsecase)(nil).GetReturns), ctx, status)
+}
+
+// ReceiveReturn mocks base method.
+func (m *MockIReturnUsecase) ReceiveReturn(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveReturn", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReceiveReturn indicates an expected call of ReceiveReturn.
+func (mr *MockIReturnUsecaseMockRecorder) ReceiveReturn(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).ReceiveReturn), ctx, id)
+}
+
+// RefundReturn mocks base method.
+func (m *MockIReturnUsecase) RefundReturn(ctx context.Context, id uuid.UUID, amount int64, offline bool) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundReturn", ctx, id, amount, offline)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundReturn indicates an expected call of RefundReturn.
+func (mr *MockIReturnUsecaseMockRecorder) RefundReturn(ctx, id, amount, offline interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).RefundReturn), ctx, id, amount, offline)
+}
+
+// RejectReturn mocks base method.
+func (m *MockIReturnUsecase) RejectReturn(ctx context.Context, id uuid.UUID, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReturn", ctx, id, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectReturn indicates an expected call of RejectReturn.
+func (mr *MockIReturnUsecaseMockRecorder) RejectReturn(ctx, id, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).RejectReturn), ctx, id, comment)
+}
+
+// RequestReturn mocks base method.
+func (m *MockIReturnUsecase) RequestReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "RequestReturn", ctx, ret)
+	ret0, _ := ret_2[0].(uuid.UUID)
+	ret1, _ := ret_2[1].(error)
+	return ret0, ret1
+}
+
+// RequestReturn indicates an expected call of RequestReturn.
+func (mr *MockIReturnUsecaseMockRecorder) RequestReturn(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReturn", reflect.TypeOf((*MockIReturnUsecase)(nil).RequestReturn), ctx, ret)
+}
+
+// MockIShipmentUsecase is a mock of IShipmentUsecase interface.
+type MockIShipmentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShipmentUsecaseMockRecorder
+}
+
+// MockIShipmentUsecaseMockRecorder is the mock recorder for MockIShipmentUsecase.
+type MockIShipmentUsecaseMockRecorder struct {
+	mock *MockIShipmentUsecase
+}
+
+// NewMockIShipmentUsecase creates a new mock instance.
+func NewMockIShipmentUsecase(ctrl *gomock.Controller) *MockIShipmentUsecase {
+	mock := &MockIShipmentUsecase{ctrl: ctrl}
+	mock.recorder = &MockIShipmentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShipmentUsecase) EXPECT() *MockIShipmentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockIShipmentUsecase) CreateShipment(ctx context.Context, orderId uuid.UUID, lines []models.ShipmentLine) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, orderId, lines)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockIShipmentUsecaseMockRecorder) CreateShipment(ctx, orderId, lines interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockIShipmentUsecase)(nil).CreateShipment), ctx, orderId, lines)
+}
+
+// DeleteShipment mocks base method.
+func (m *MockIShipmentUsecase) DeleteShipment(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShipment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShipment indicates an expected call of DeleteShipment.
+func (mr *MockIShipmentUsecaseMockRecorder) DeleteShipment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShipment", reflect.TypeOf((*MockIShipmentUsecase)(nil).DeleteShipment), ctx, id)
+}
+
+// GetOrderShipments mocks base method.
+func (m *MockIShipmentUsecase) GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderShipments", ctx, orderId)
+	ret0, _ := ret[0].([]models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderShipments indicates an expected call of GetOrderShipments.
+func (mr *MockIShipmentUsecaseMockRecorder) GetOrderShipments(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderShipments", reflect.TypeOf((*MockIShipmentUsecase)(nil).GetOrderShipments), ctx, orderId)
+}
+
+// GetShipment mocks base method.
+func (m *MockIShipmentUsecase) GetShipment(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShipment", ctx, id)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShipment indicates an expected call of GetShipment.
+func (mr *MockIShipmentUsecaseMockRecorder) GetShipment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShipment", reflect.TypeOf((*MockIShipmentUsecase)(nil).GetShipment), ctx, id)
+}
+
+// GetUserOrderShipments mocks base method.
+func (m *MockIShipmentUsecase) GetUserOrderShipments(ctx context.Context, userId, orderId uuid.UUID) ([]models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrderShipments", ctx, userId, orderId)
+	ret0, _ := ret[0].([]models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrderShipments indicates an expected call of GetUserOrderShipments.
+func (mr *MockIShipmentUsecaseMockRecorder) GetUserOrderShipments(ctx, userId, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrderShipments", reflect.TypeOf((*MockIShipmentUsecase)(nil).GetUserOrderShipments), ctx, userId, orderId)
+}
+
+// SetStatus mocks base method.
+func (m *MockIShipmentUsecase) SetStatus(ctx context.Context, id uuid.UUID, status models.ShipmentStatus) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockIShipmentUsecaseMockRecorder) SetStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockIShipmentUsecase)(nil).SetStatus), ctx, id, status)
+}
+
+// Ship mocks base method.
+func (m *MockIShipmentUsecase) Ship(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ship", ctx, id)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Ship indicates an expected call of Ship.
+func (mr *MockIShipmentUsecaseMockRecorder) Ship(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ship", reflect.TypeOf((*MockIShipmentUsecase)(nil).Ship), ctx, id)
+}
+
+// Track mocks base method.
+func (m *MockIShipmentUsecase) Track(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", ctx, id)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockIShipmentUsecaseMockRecorder) Track(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockIShipmentUsecase)(nil).Track), ctx, id)
+}
+
+// TrackShipments mocks base method.
+func (m *MockIShipmentUsecase) TrackShipments(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackShipments", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackShipments indicates an expected call of TrackShipments.
+func (mr *MockIShipmentUsecaseMockRecorder) TrackShipments(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackShipments", reflect.TypeOf((*MockIShipmentUsecase)(nil).TrackShipments), ctx)
+}
+
+// MockIInvoiceUsecase is a mock of IInvoiceUsecase interface.
+type MockIInvoiceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIInvoiceUsecaseMockRecorder
+}
+
+// MockIInvoiceUsecaseMockRecorder is the mock recorder for MockIInvoiceUsecase.
+type MockIInvoiceUsecaseMockRecorder struct {
+	mock *MockIInvoiceUsecase
+}
+
+// NewMockIInvoiceUsecase creates a new mock instance.
+func NewMockIInvoiceUsecase(ctrl *gomock.Controller) *MockIInvoiceUsecase {
+	mock := &MockIInvoiceUsecase{ctrl: ctrl}
+	mock.recorder = &MockIInvoiceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIInvoiceUsecase) EXPECT() *MockIInvoiceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetInvoice mocks base method.
+func (m *MockIInvoiceUsecase) GetInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, orderId)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockIInvoiceUsecaseMockRecorder) GetInvoice(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockIInvoiceUsecase)(nil).GetInvoice), ctx, orderId)
+}
+
+// GetUserInvoice mocks base method.
+func (m *MockIInvoiceUsecase) GetUserInvoice(ctx context.Context, userId, orderId uuid.UUID) (*models.Invoice, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserInvoice", ctx, userId, orderId)
+	ret0, _ := ret[0].(*models.Invoice)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserInvoice indicates an expected call of GetUserInvoice.
+func (mr *MockIInvoiceUsecaseMockRecorder) GetUserInvoice(ctx, userId, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInvoice", reflect.TypeOf((*MockIInvoiceUsecase)(nil).GetUserInvoice), ctx, userId, orderId)
+}
+
+// MockIWishlistUsecase is a mock of IWishlistUsecase interface.
+type MockIWishlistUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWishlistUsecaseMockRecorder
+}
+
+// MockIWishlistUsecaseMockRecorder is the mock recorder for MockIWishlistUsecase.
+type MockIWishlistUsecaseMockRecorder struct {
+	mock *MockIWishlistUsecase
+}
+
+// NewMockIWishlistUsecase creates a new mock instance.
+func NewMockIWishlistUsecase(ctrl *gomock.Controller) *MockIWishlistUsecase {
+	mock := &MockIWishlistUsecase{ctrl: ctrl}
+	mock.recorder = &MockIWishlistUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWishlistUsecase) EXPECT() *MockIWishlistUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddWishlistItem mocks base method.
+func (m *MockIWishlistUsecase) AddWishlistItem(ctx context.Context, userId, id, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWishlistItem", ctx, userId, id, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWishlistItem indicates an expected call of AddWishlistItem.
+func (mr *MockIWishlistUsecaseMockRecorder) AddWishlistItem(ctx, userId, id, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWishlistItem", reflect.TypeOf((*MockIWishlistUsecase)(nil).AddWishlistItem), ctx, userId, id, itemId)
+}
+
+// CreateWishlist mocks base method.
+func (m *MockIWishlistUsecase) CreateWishlist(ctx context.Context, userId uuid.UUID, name string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWishlist", ctx, userId, name)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWishlist indicates an expected call of CreateWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) CreateWishlist(ctx, userId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).CreateWishlist), ctx, userId, name)
+}
+
+// DeleteWishlist mocks base method.
+func (m *MockIWishlistUsecase) DeleteWishlist(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlist", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWishlist indicates an expected call of DeleteWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) DeleteWishlist(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).DeleteWishlist), ctx, userId, id)
+}
+
+// DeleteWishlistItem mocks base method.
+func (m *MockIWishlistUsecase) DeleteWishlistItem(ctx context.Context, userId, id, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlistItem", ctx, userId, id, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWishlistItem indicates an expected call of DeleteWishlistItem.
+func (mr *MockIWishlistUsecaseMockRecorder) DeleteWishlistItem(ctx, userId, id, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlistItem", reflect.TypeOf((*MockIWishlistUsecase)(nil).DeleteWishlistItem), ctx, userId, id, itemId)
+}
+
+// GetSharedWishlist mocks base method.
+func (m *MockIWishlistUsecase) GetSharedWishlist(ctx context.Context, token string) (*models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWishlist", ctx, token)
+	ret0, _ := ret[0].(*models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWishlist indicates an expected call of GetSharedWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) GetSharedWishlist(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).GetSharedWishlist), ctx, token)
+}
+
+// GetWishlist mocks base method.
+func (m *MockIWishlistUsecase) GetWishlist(ctx context.Context, userId, id uuid.UUID) (*models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", ctx, userId, id)
+	ret0, _ := ret[0].(*models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) GetWishlist(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).GetWishlist), ctx, userId, id)
+}
+
+// GetWishlists mocks base method.
+func (m *MockIWishlistUsecase) GetWishlists(ctx context.Context, userId uuid.UUID) ([]models.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlists", ctx, userId)
+	ret0, _ := ret[0].([]models.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlists indicates an expected call of GetWishlists.
+func (mr *MockIWishlistUsecaseMockRecorder) GetWishlists(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlists", reflect.TypeOf((*MockIWishlistUsecase)(nil).GetWishlists), ctx, userId)
+}
+
+// MoveItemToCart mocks base method.
+func (m *MockIWishlistUsecase) MoveItemToCart(ctx context.Context, userId, id, itemId uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItemToCart", ctx, userId, id, itemId)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItemToCart indicates an expected call of MoveItemToCart.
+func (mr *MockIWishlistUsecaseMockRecorder) MoveItemToCart(ctx, userId, id, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItemToCart", reflect.TypeOf((*MockIWishlistUsecase)(nil).MoveItemToCart), ctx, userId, id, itemId)
+}
+
+// MoveWishlistItem mocks base method.
+func (m *MockIWishlistUsecase) MoveWishlistItem(ctx context.Context, userId, fromId, toId, itemId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveWishlistItem", ctx, userId, fromId, toId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveWishlistItem indicates an expected call of MoveWishlistItem.
+func (mr *MockIWishlistUsecaseMockRecorder) MoveWishlistItem(ctx, userId, fromId, toId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWishlistItem", reflect.TypeOf((*MockIWishlistUsecase)(nil).MoveWishlistItem), ctx, userId, fromId, toId, itemId)
+}
+
+// RenameWishlist mocks base method.
+func (m *MockIWishlistUsecase) RenameWishlist(ctx context.Context, userId, id uuid.UUID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameWishlist", ctx, userId, id, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameWishlist indicates an expected call of RenameWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) RenameWishlist(ctx, userId, id, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).RenameWishlist), ctx, userId, id, name)
+}
+
+// ShareWishlist mocks base method.
+func (m *MockIWishlistUsecase) ShareWishlist(ctx context.Context, userId, id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareWishlist", ctx, userId, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareWishlist indicates an expected call of ShareWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) ShareWishlist(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).ShareWishlist), ctx, userId, id)
+}
+
+// UnshareWishlist mocks base method.
+func (m *MockIWishlistUsecase) UnshareWishlist(ctx context.Context, userId, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareWishlist", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareWishlist indicates an expected call of UnshareWishlist.
+func (mr *MockIWishlistUsecaseMockRecorder) UnshareWishlist(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareWishlist", reflect.TypeOf((*MockIWishlistUsecase)(nil).UnshareWishlist), ctx, userId, id)
+}
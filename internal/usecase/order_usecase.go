@@ -4,6 +4,7 @@ import (
 	"OnlineShopBackend/internal/models"
 	"OnlineShopBackend/internal/repository"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,20 +13,32 @@ import (
 )
 
 type order struct {
-	orderStore repository.OrderStore
-	logger     *zap.SugaredLogger
+	orderStore     repository.OrderStore
+	itemStore      repository.ItemStore
+	promotionStore repository.PromotionStore
+	currencyStore  repository.CurrencyStore
+	taxStore       repository.TaxStore
+	shippingStore  repository.ShippingStore
+	baseCurrency   string
+	logger         *zap.SugaredLogger
 }
 
 var _ IOrderUsecase = (*order)(nil)
 
-func NewOrderUsecase(orderStore repository.OrderStore, logger *zap.SugaredLogger) IOrderUsecase {
+func NewOrderUsecase(orderStore repository.OrderStore, itemStore repository.ItemStore, promotionStore repository.PromotionStore, currencyStore repository.CurrencyStore, taxStore repository.TaxStore, shippingStore repository.ShippingStore, baseCurrency string, logger *zap.SugaredLogger) IOrderUsecase {
 	return &order{
-		orderStore: orderStore,
-		logger:     logger,
+		orderStore:     orderStore,
+		itemStore:      itemStore,
+		promotionStore: promotionStore,
+		currencyStore:  currencyStore,
+		taxStore:       taxStore,
+		shippingStore:  shippingStore,
+		baseCurrency:   baseCurrency,
+		logger:         logger,
 	}
 }
 
-func (o *order) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress) (*models.Order, error) {
+func (o *order) PlaceOrder(ctx context.Context, cart *models.Cart, user models.User, address models.UserAddress, shippingMethodId uuid.UUID) (*models.Order, error) {
 	select {
 	case <-ctx.Done():
 		o.logger.Error("context closed")
@@ -36,9 +49,66 @@ func (o *order) PlaceOrder(ctx context.Context, cart *models.Cart, user models.U
 			Address:      address,
 			Status:       models.StatusCreated,
 			CreatedAt:    time.Now(),
-			ShipmentTime: time.Now().Add(models.ProlongedShipmentPeriod),
-			Items:        append([]models.ItemWithQuantity{}[:0:0], cart.Items...),
+			ShipmentTime: time.Now().Add(models.StandardShipmentPeriod),
 		}
+		// Prices of the request aren't trusted, lines are priced by the catalogue
+		lines, err := catalogueItems(ctx, o.itemStore, cart.Items)
+		if err != nil {
+			o.logger.Errorf("can't get items of order: %s", err)
+			return nil, fmt.Errorf("can't get items of order: %w", err)
+		}
+		ordr.Items = lines
+		for i := range ordr.Items {
+			err := defaultCurrency(&ordr.Items[i].Currency, o.baseCurrency)
+			if err != nil {
+				o.logger.Errorf("can't price item %v of order: %s", ordr.Items[i].Id, err)
+				return nil, fmt.Errorf("can't price item %v of order: %w", ordr.Items[i].Id, err)
+			}
+		}
+		// Amounts of the order are in the base currency
+		items, err := baseItems(ctx, o.currencyStore, o.baseCurrency, ordr.Items)
+		if err != nil {
+			o.logger.Errorf("can't convert prices of order: %s", err)
+			return nil, fmt.Errorf("can't convert prices of order: %w", err)
+		}
+		now := time.Now()
+		promotions, err := automaticPromotions(ctx, o.promotionStore, user.ID, now)
+		if err != nil {
+			o.logger.Errorf("can't get promotions for order: %s", err)
+			return nil, fmt.Errorf("can't get promotions for order: %w", err)
+		}
+		// The promo code of the cart must be still valid, otherwise the user
+		// would pay more than the cart showed
+		if cart.PromoCode != "" {
+			promotion, err := codePromotion(ctx, o.promotionStore, cart.PromoCode, items, user.ID, now)
+			if err != nil {
+				o.logger.Errorf("can't apply promo code %s to order: %s", cart.PromoCode, err)
+				return nil, fmt.Errorf("can't apply promo code %s to order: %w", cart.PromoCode, err)
+			}
+			promotions = append(promotions, *promotion)
+		}
+		ordr.Pricing = models.ApplyPromotions(items, promotions)
+		err = applyTaxes(ctx, o.taxStore, &ordr.Pricing, items, address)
+		if err != nil {
+			o.logger.Errorf("can't calculate taxes of order: %s", err)
+			return nil, fmt.Errorf("can't calculate taxes of order: %w", err)
+		}
+		// Without shipping method the order is shipped in the standard period
+		if shippingMethodId != uuid.Nil {
+			quote, err := shippingQuote(ctx, o.shippingStore, shippingMethodId, ordr.Items, ordr.Pricing.Discounted(), address, now)
+			if err != nil {
+				o.logger.Errorf("can't calculate shipping of order: %s", err)
+				return nil, fmt.Errorf("can't calculate shipping of order: %w", err)
+			}
+			ordr.ShippingMethodId = quote.Method.Id
+			ordr.ShippingMethod = quote.Method.Name
+			ordr.ShipmentTime = quote.EstimatedTo
+			ordr.Shipping = quote.Price
+			ordr.Total += quote.Price
+		}
+		ordr.Currency = o.baseCurrency
+		// Lines are kept with prices in the currency of the order
+		ordr.Items = items
 		res, err := o.orderStore.Create(ctx, &ordr)
 		if err != nil {
 			o.logger.Errorf("can't add order to db %s", err)
@@ -103,10 +173,25 @@ func (o *order) ChangeAddress(ctx context.Context, order *models.Order, newAddre
 		o.logger.Error("context closed")
 		return fmt.Errorf("context closed")
 	default:
-		if newAddress == order.Address {
+		current, err := o.orderStore.GetOrderByID(ctx, order.ID)
+		if err != nil {
+			o.logger.Errorf("can't get order: %s", err)
+			return fmt.Errorf("can't get order: %w", err)
+		}
+		if newAddress == current.Address {
 			return nil
 		}
-		if err := o.orderStore.ChangeAddress(ctx, order, newAddress); err != nil {
+		// Taxes and shipping depend on the address, so the order is
+		// priced again and can't be changed after its payment is started
+		if !current.Status.Payable() {
+			return models.ErrOrderNotChangeable
+		}
+		err = o.reprice(ctx, &current, newAddress)
+		if err != nil {
+			o.logger.Errorf("can't price order for address: %s", err)
+			return fmt.Errorf("can't price order for address: %w", err)
+		}
+		if err := o.orderStore.ChangeAddress(ctx, &current, newAddress); err != nil {
 			o.logger.Errorf("can't change address %s: ", err)
 			return fmt.Errorf("can't change address %w: ", err)
 		}
@@ -114,6 +199,29 @@ func (o *order) ChangeAddress(ctx context.Context, order *models.Order, newAddre
 	}
 }
 
+// reprice calculates taxes and shipping of the order shipped to the
+// address, discounts of the order are kept
+func (o *order) reprice(ctx context.Context, ordr *models.Order, address models.UserAddress) error {
+	ordr.Shipping = 0
+	ordr.Total = ordr.Discounted()
+	err := applyTaxes(ctx, o.taxStore, &ordr.Pricing, ordr.Items, address)
+	if err != nil {
+		return err
+	}
+	if ordr.ShippingMethodId == uuid.Nil {
+		return nil
+	}
+	quote, err := shippingQuote(ctx, o.shippingStore, ordr.ShippingMethodId, ordr.Items, ordr.Discounted(), address, time.Now())
+	if err != nil {
+		return err
+	}
+	ordr.ShippingMethod = quote.Method.Name
+	ordr.ShipmentTime = quote.EstimatedTo
+	ordr.Shipping = quote.Price
+	ordr.Total += quote.Price
+	return nil
+}
+
 func (o *order) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, error) {
 	select {
 	case <-ctx.Done():
@@ -129,3 +237,23 @@ func (o *order) GetOrder(ctx context.Context, id uuid.UUID) (*models.Order, erro
 	}
 
 }
+
+// catalogueItems returns lines with the current titles, prices and currencies
+// of the items in the catalogue, only item ids and quantities of the lines are used
+func catalogueItems(ctx context.Context, store repository.ItemStore, lines []models.ItemWithQuantity) ([]models.ItemWithQuantity, error) {
+	items := make([]models.ItemWithQuantity, 0, len(lines))
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity of item %v: %w", line.Id, models.ErrInvalidQuantity)
+		}
+		item, err := store.GetItem(ctx, line.Id)
+		if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+			return nil, fmt.Errorf("item %v: %w", line.Id, models.ErrItemUnavailable)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, models.ItemWithQuantity{Item: *item, Quantity: line.Quantity})
+	}
+	return items, nil
+}
@@ -39,20 +39,22 @@ var (
 	}
 
 	testItem11 = models.Item{
+		Id:          uuid.New(),
 		Title:       "testItem11",
 		Description: "Awesome chinese item",
 		Price:       300,
 		Category:    testCategory,
 		Vendor:      "chinese factory",
-		Images:      []string{},
+		Images:      []models.ItemImage{},
 	}
 	testItem2 = models.Item{
+		Id:          uuid.New(),
 		Title:       "testItem2",
 		Description: "Awesome chinese item",
 		Price:       500,
 		Category:    testCategory,
 		Vendor:      "russian factory",
-		Images:      []string{},
+		Images:      []models.ItemImage{},
 	}
 
 	testOrder = models.Order{
@@ -70,6 +72,8 @@ var (
 
 type orderRepoMock struct {
 	err error
+	// changed is the order saved with the new address
+	changed *models.Order
 }
 
 var _ repository.OrderStore = (*orderRepoMock)(nil)
@@ -83,6 +87,7 @@ func (orMock *orderRepoMock) DeleteOrder(ctx context.Context, order *models.Orde
 }
 func (orMock *orderRepoMock) ChangeAddress(ctx context.Context, order *models.Order, address models.UserAddress) error {
 	order.Address = address
+	orMock.changed = order
 	return orMock.err
 }
 func (orMock *orderRepoMock) ChangeStatus(ctx context.Context, order *models.Order, status models.Status) error {
@@ -107,8 +112,61 @@ func (orMock *orderRepoMock) GetOrdersForUser(ctx context.Context, user *models.
 	return res, orMock.err
 }
 
+// itemRepoMock is the catalogue of the items, other methods of the store
+// aren't used by orders
+type itemRepoMock struct {
+	repository.ItemStore
+	items []models.Item
+}
+
+func (irMock *itemRepoMock) GetItem(ctx context.Context, id uuid.UUID) (*models.Item, error) {
+	for _, item := range irMock.items {
+		if item.Id == id {
+			return &item, nil
+		}
+	}
+	return &models.Item{}, models.ErrorNotFound{}
+}
+
+type promotionRepoMock struct {
+	automatic []models.Promotion
+	code      *models.Promotion
+	used      int
+}
+
+var _ repository.PromotionStore = (*promotionRepoMock)(nil)
+
+func (prMock *promotionRepoMock) CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error) {
+	return uuid.New(), nil
+}
+func (prMock *promotionRepoMock) UpdatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	return nil
+}
+func (prMock *promotionRepoMock) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	return nil, models.ErrorNotFound{}
+}
+func (prMock *promotionRepoMock) GetPromotionByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	if prMock.code == nil || prMock.code.Code != code {
+		return nil, models.ErrorNotFound{}
+	}
+	promotion := *prMock.code
+	return &promotion, nil
+}
+func (prMock *promotionRepoMock) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	return nil, nil
+}
+func (prMock *promotionRepoMock) GetAutomaticPromotions(ctx context.Context) ([]models.Promotion, error) {
+	return prMock.automatic, nil
+}
+func (prMock *promotionRepoMock) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+func (prMock *promotionRepoMock) CountUserUsages(ctx context.Context, promotionId uuid.UUID, userId uuid.UUID) (int, error) {
+	return prMock.used, nil
+}
+
 func TestPlaceOrder(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{}, &itemRepoMock{items: []models.Item{testItem11, testItem2}}, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	cartID, _ := uuid.NewRandom()
 	userID, _ := uuid.NewRandom()
 	cart := models.Cart{
@@ -120,14 +178,20 @@ func TestPlaceOrder(t *testing.T) {
 		},
 		ExpireAt: time.Now().Add(2 * time.Hour),
 	}
-	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address)
+	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.Nil)
 	require.NoError(t, err)
 	assert.Equal(t, testUser.Address, res.Address)
-	assert.Equal(t, cart.Items, res.Items)
+	// Items without currency are priced in the base currency
+	items := append([]models.ItemWithQuantity{}, cart.Items...)
+	for i := range items {
+		items[i].Currency = "RUB"
+	}
+	assert.Equal(t, items, res.Items)
+	assert.Equal(t, "RUB", res.Currency)
 }
 
 func TestPlaceOrderDBError(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, &itemRepoMock{items: []models.Item{testItem11, testItem2}}, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	cartID, _ := uuid.NewRandom()
 	userID, _ := uuid.NewRandom()
 	cart := models.Cart{
@@ -139,13 +203,39 @@ func TestPlaceOrderDBError(t *testing.T) {
 		},
 		ExpireAt: time.Now().Add(2 * time.Hour),
 	}
-	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address)
+	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.Nil)
 	require.Error(t, err)
 	assert.Nil(t, res)
 }
 
+func TestPlaceOrderCataloguePrices(t *testing.T) {
+	uscs := NewOrderUsecase(&orderRepoMock{}, &itemRepoMock{items: []models.Item{testItem11}}, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
+	cheap := testItem11
+	cheap.Price = 1
+	cart := models.Cart{
+		Id:     uuid.New(),
+		UserId: testUser.ID,
+		Items:  []models.ItemWithQuantity{{Item: cheap, Quantity: 2}},
+	}
+
+	// The price of the request is ignored
+	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), res.Items[0].Price)
+	assert.Equal(t, int64(600), res.Total)
+
+	cart.Items[0].Quantity = -1
+	_, err = uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrInvalidQuantity)
+
+	// Deleted item isn't in the catalogue
+	cart.Items = []models.ItemWithQuantity{{Item: testItem2, Quantity: 1}}
+	_, err = uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrItemUnavailable)
+}
+
 func TestChangeStatus(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{}, nil, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	err := uscs.ChangeStatus(context.Background(), &testOrder, models.StatusProcessed)
 	defer func() {
 		testOrder.Status = models.StatusCreated
@@ -156,7 +246,7 @@ func TestChangeStatus(t *testing.T) {
 }
 
 func TestChangeStatusError(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, nil, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	err := uscs.ChangeStatus(context.Background(), &testOrder, models.StatusProcessed)
 	defer func() {
 		testOrder.Status = models.StatusCreated
@@ -165,7 +255,7 @@ func TestChangeStatusError(t *testing.T) {
 }
 
 func TestChangeAddress(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{}, nil, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	oldAddress := testOrder.Address
 	err := uscs.ChangeAddress(context.Background(), &testOrder, models.UserAddress{
 		Street:  "הלל 49",
@@ -179,8 +269,52 @@ func TestChangeAddress(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestChangeAddressPricing(t *testing.T) {
+	taxes := &taxRepoMock{
+		rules: []models.TaxRule{
+			{Id: uuid.New(), Name: "VAT", Country: "ISRAEL", TaxClass: models.TaxClassStandard, Rate: 1700, Inclusive: true},
+			{Id: uuid.New(), Name: "Other VAT", Country: "RUSSIA", TaxClass: models.TaxClassStandard, Rate: 2000},
+		},
+	}
+	method := models.ShippingMethod{Id: uuid.New(), Name: "Courier", Kind: models.ShippingCourier, Price: 300, MinDays: 1, MaxDays: 2, Active: true}
+	orders := &orderRepoMock{}
+	uscs := NewOrderUsecase(orders, nil, &promotionRepoMock{}, nil, taxes, &shippingRepoMock{methods: []models.ShippingMethod{method}}, "RUB", lgr)
+	oldOrder := testOrder
+	defer func() {
+		testOrder = oldOrder
+	}()
+	testOrder.ShippingMethodId = method.Id
+	testOrder.Pricing = models.Pricing{
+		Currency:  "RUB",
+		Subtotal:  1100,
+		Discounts: []models.AppliedDiscount{{Name: "Sale", Amount: 100}},
+		Taxes:     []models.TaxLine{{TaxRuleId: taxes.rules[0].Id, Name: "VAT", Rate: 1700, Inclusive: true, Amount: 145}},
+		Total:     1000,
+	}
+
+	// Taxes and shipping are calculated for the new address, discounts are kept
+	address := models.UserAddress{Zipcode: "101000", Country: "Russia", City: "Moscow", Street: "Arbat 1"}
+	err := uscs.ChangeAddress(context.Background(), &models.Order{ID: uuid.New()}, address)
+	require.NoError(t, err)
+	require.NotNil(t, orders.changed)
+	assert.Equal(t, address, orders.changed.Address)
+	assert.Equal(t, testOrder.Discounts, orders.changed.Discounts)
+	assert.Equal(t, []models.TaxLine{
+		{TaxRuleId: taxes.rules[1].Id, Name: "Other VAT", TaxClass: models.TaxClassStandard, Rate: 2000, Amount: 200},
+	}, orders.changed.Taxes)
+	assert.Equal(t, int64(300), orders.changed.Shipping)
+	assert.Equal(t, int64(1500), orders.changed.Total)
+
+	// Paid order isn't priced again
+	orders.changed = nil
+	testOrder.Status = models.StatusPaid
+	err = uscs.ChangeAddress(context.Background(), &models.Order{ID: uuid.New()}, address)
+	require.ErrorIs(t, err, models.ErrOrderNotChangeable)
+	assert.Nil(t, orders.changed)
+}
+
 func TestChangeAddressError(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{err: fmt.Errorf("test error")}, nil, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	oldAddress := testOrder.Address
 	err := uscs.ChangeAddress(context.Background(), &testOrder, models.UserAddress{
 		Street:  "הלל 49",
@@ -195,16 +329,216 @@ func TestChangeAddressError(t *testing.T) {
 }
 
 func TestDeleteOrder(t *testing.T) {
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{}, nil, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	err := uscs.DeleteOrder(context.Background(), &testOrder)
 	require.NoError(t, err)
 }
 
 func TestGetOrder(t *testing.T) {
 	id, _ := uuid.NewRandom()
-	uscs := NewOrderUsecase(&orderRepoMock{}, lgr)
+	uscs := NewOrderUsecase(&orderRepoMock{}, nil, &promotionRepoMock{}, nil, &taxRepoMock{}, nil, "RUB", lgr)
 	order, err := uscs.GetOrder(context.Background(), id)
 	require.NoError(t, err)
 	assert.Equal(t, testOrder.User.Firstname, order.User.Firstname)
 	assert.Equal(t, testOrder.ShipmentTime, order.ShipmentTime)
 }
+
+type taxRepoMock struct {
+	rules   []models.TaxRule
+	classes map[uuid.UUID]string
+}
+
+var _ repository.TaxStore = (*taxRepoMock)(nil)
+
+func (trMock *taxRepoMock) CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error) {
+	return uuid.New(), nil
+}
+func (trMock *taxRepoMock) UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error {
+	return nil
+}
+func (trMock *taxRepoMock) GetTaxRules(ctx context.Context) ([]models.TaxRule, error) {
+	return trMock.rules, nil
+}
+func (trMock *taxRepoMock) GetTaxRulesByCountry(ctx context.Context, country string) ([]models.TaxRule, error) {
+	rules := make([]models.TaxRule, 0)
+	for _, rule := range trMock.rules {
+		if rule.Country == country {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+func (trMock *taxRepoMock) DeleteTaxRule(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+func (trMock *taxRepoMock) SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error {
+	return nil
+}
+func (trMock *taxRepoMock) SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error {
+	return nil
+}
+func (trMock *taxRepoMock) GetTaxClasses(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]string, error) {
+	return trMock.classes, nil
+}
+
+func TestPlaceOrderWithDiscounts(t *testing.T) {
+	promotions := &promotionRepoMock{
+		automatic: []models.Promotion{
+			{Id: uuid.New(), Name: "Sale", Kind: models.DiscountFixed, Value: 100, Scope: models.ScopeCart, Active: true},
+			{Id: uuid.New(), Name: "Finished", Kind: models.DiscountFixed, Value: 100, Scope: models.ScopeCart, Active: true,
+				EndsAt: time.Now().Add(-time.Hour)},
+		},
+		code: &models.Promotion{Id: uuid.New(), Code: "SALE10", Name: "Ten percent", Kind: models.DiscountPercent, Value: 10,
+			Scope: models.ScopeCart, PerUserLimit: 1, Active: true},
+	}
+	uscs := NewOrderUsecase(&orderRepoMock{}, &itemRepoMock{items: []models.Item{testItem11, testItem2}}, promotions, nil, &taxRepoMock{}, nil, "RUB", lgr)
+	user := testUser
+	user.ID = uuid.New()
+	cart := models.Cart{
+		Id:     uuid.New(),
+		UserId: user.ID,
+		Items: []models.ItemWithQuantity{
+			{Item: testItem11, Quantity: 2},
+			{Item: testItem2, Quantity: 1},
+		},
+		PromoCode: "sale10",
+	}
+	res, err := uscs.PlaceOrder(context.Background(), &cart, user, testOrder.Address, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1100), res.Subtotal)
+	assert.Equal(t, []models.AppliedDiscount{
+		{PromotionId: promotions.automatic[0].Id, Name: "Sale", Amount: 100},
+		{PromotionId: promotions.code.Id, Code: "SALE10", Name: "Ten percent", Amount: 110},
+	}, res.Discounts)
+	assert.Equal(t, int64(890), res.Total)
+
+	// The user has already used the promo code
+	promotions.used = 1
+	res, err = uscs.PlaceOrder(context.Background(), &cart, user, testOrder.Address, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrPromoLimitReached)
+	assert.Nil(t, res)
+
+	cart.PromoCode = "unknown"
+	_, err = uscs.PlaceOrder(context.Background(), &cart, user, testOrder.Address, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+}
+
+func TestPlaceOrderWithTaxes(t *testing.T) {
+	book := testItem11
+	book.Id = uuid.New()
+	phone := testItem2
+	phone.Id = uuid.New()
+	taxes := &taxRepoMock{
+		rules: []models.TaxRule{
+			{Id: uuid.New(), Name: "VAT", Country: "ISRAEL", TaxClass: models.TaxClassStandard, Rate: 1700, Inclusive: true},
+			{Id: uuid.New(), Name: "Reduced VAT", Country: "ISRAEL", TaxClass: "reduced", Rate: 1000, Inclusive: true},
+			{Id: uuid.New(), Name: "Haifa VAT", Country: "ISRAEL", Region: "HAIFA", TaxClass: "reduced", Rate: 500},
+			{Id: uuid.New(), Name: "Other VAT", Country: "RUSSIA", TaxClass: models.TaxClassStandard, Rate: 2000},
+		},
+		classes: map[uuid.UUID]string{book.Id: "reduced"},
+	}
+	promotions := &promotionRepoMock{
+		automatic: []models.Promotion{
+			{Id: uuid.New(), Name: "Sale", Kind: models.DiscountPercent, Value: 50, Scope: models.ScopeCart, Active: true},
+		},
+	}
+	uscs := NewOrderUsecase(&orderRepoMock{}, &itemRepoMock{items: []models.Item{book, phone}}, promotions, nil, taxes, nil, "RUB", lgr)
+	cart := models.Cart{
+		Id:     uuid.New(),
+		UserId: testUser.ID,
+		Items: []models.ItemWithQuantity{
+			{Item: book, Quantity: 2},
+			{Item: phone, Quantity: 1},
+		},
+	}
+
+	// The rule of the region overrides the rule of the country,
+	// taxes are calculated on discounted prices
+	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TaxLine{
+		{TaxRuleId: taxes.rules[0].Id, Name: "VAT", TaxClass: models.TaxClassStandard, Rate: 1700, Inclusive: true, Amount: 36},
+		{TaxRuleId: taxes.rules[2].Id, Name: "Haifa VAT", TaxClass: "reduced", Rate: 500, Amount: 15},
+	}, res.Taxes)
+	assert.Equal(t, int64(1100), res.Subtotal)
+	assert.Equal(t, int64(565), res.Total)
+
+	// Without rules of the country nothing is taxed
+	address := testOrder.Address
+	address.Country = "Japan"
+	res, err = uscs.PlaceOrder(context.Background(), &cart, testUser, address, uuid.Nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Taxes)
+	assert.Equal(t, int64(550), res.Total)
+}
+
+type shippingRepoMock struct {
+	methods []models.ShippingMethod
+	weights map[uuid.UUID]int64
+}
+
+var _ repository.ShippingStore = (*shippingRepoMock)(nil)
+
+func (srMock *shippingRepoMock) CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error) {
+	return uuid.New(), nil
+}
+func (srMock *shippingRepoMock) UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error {
+	return nil
+}
+func (srMock *shippingRepoMock) GetShippingMethod(ctx context.Context, id uuid.UUID) (*models.ShippingMethod, error) {
+	for _, method := range srMock.methods {
+		if method.Id == id {
+			return &method, nil
+		}
+	}
+	return nil, models.ErrorNotFound{}
+}
+func (srMock *shippingRepoMock) GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	return srMock.methods, nil
+}
+func (srMock *shippingRepoMock) GetActiveShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	return srMock.methods, nil
+}
+func (srMock *shippingRepoMock) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+func (srMock *shippingRepoMock) SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error {
+	return nil
+}
+func (srMock *shippingRepoMock) GetItemWeights(ctx context.Context, itemIds []uuid.UUID) (map[uuid.UUID]int64, error) {
+	return srMock.weights, nil
+}
+
+func TestPlaceOrderWithShipping(t *testing.T) {
+	book := testItem11
+	book.Id = uuid.New()
+	shipping := &shippingRepoMock{
+		methods: []models.ShippingMethod{
+			{Id: uuid.New(), Name: "Courier", Kind: models.ShippingCourier, Price: 300, PricePerKg: 50, MinDays: 1, MaxDays: 3,
+				Zones: []models.ShippingZone{{Country: "ISRAEL"}, {Country: "ISRAEL", Region: "HAIFA", Surcharge: 20}}, Active: true},
+			{Id: uuid.New(), Name: "Post", Kind: models.ShippingStandard, Price: 100, MaxWeight: 1000, Active: true},
+		},
+		weights: map[uuid.UUID]int64{book.Id: 600},
+	}
+	uscs := NewOrderUsecase(&orderRepoMock{}, &itemRepoMock{items: []models.Item{book}}, &promotionRepoMock{}, nil, &taxRepoMock{}, shipping, "RUB", lgr)
+	cart := models.Cart{
+		Id:     uuid.New(),
+		UserId: testUser.ID,
+		Items:  []models.ItemWithQuantity{{Item: book, Quantity: 2}},
+	}
+
+	// Two kilograms are started, the surcharge of the region is added
+	res, err := uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, shipping.methods[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Courier", res.ShippingMethod)
+	assert.Equal(t, int64(420), res.Shipping)
+	assert.Equal(t, int64(1020), res.Total)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 3), res.ShipmentTime, time.Minute)
+
+	// The order is too heavy for the post
+	_, err = uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, shipping.methods[1].Id)
+	require.ErrorIs(t, err, models.ErrShippingUnavailable)
+
+	_, err = uscs.PlaceOrder(context.Background(), &cart, testUser, testOrder.Address, uuid.New())
+	require.ErrorIs(t, err, models.ErrShippingUnavailable)
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IPaymentUsecase = &PaymentUsecase{}
+
+// maxPaymentAttempts limits attempts to save the change of the payment
+// which is changed concurrently by webhooks and requests
+const maxPaymentAttempts = 3
+
+type PaymentUsecase struct {
+	store    repository.PaymentStore
+	provider payment.PaymentProvider
+	logger   *zap.Logger
+}
+
+func NewPaymentUsecase(store repository.PaymentStore, provider payment.PaymentProvider, logger *zap.Logger) IPaymentUsecase {
+	logger.Debug("Enter in usecase NewPaymentUsecase()")
+	return &PaymentUsecase{store: store, provider: provider, logger: logger}
+}
+
+// Pay starts payment of the order by the user. If the order already has
+// a payment in progress, it is returned instead of the new one
+func (usecase *PaymentUsecase) Pay(ctx context.Context, orderId uuid.UUID, userId uuid.UUID) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Pay() with args: ctx, orderId: %v, userId: %v", orderId, userId)
+	order, err := usecase.store.GetOrderForPayment(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	// Orders of other users are not shown
+	if order.User.ID != userId {
+		return nil, models.ErrorNotFound{}
+	}
+	active, err := usecase.activePayment(ctx, orderId)
+	if err != nil || active != nil {
+		return active, err
+	}
+	if !order.Status.Payable() {
+		return nil, fmt.Errorf("%w: order is %s", models.ErrPaymentNotAllowed, order.Status)
+	}
+	pay := &models.Payment{
+		OrderId:  order.ID,
+		Provider: usecase.provider.Name(),
+		Amount:   order.Total,
+		Currency: order.Currency,
+		Status:   models.PaymentPending,
+	}
+	pay.Id, err = usecase.store.CreatePayment(ctx, pay)
+	if errors.Is(err, models.ErrPaymentExists) {
+		// The payment is created by the concurrent request
+		active, err = usecase.activePayment(ctx, orderId)
+		if err == nil && active == nil {
+			err = fmt.Errorf("error on create payment: %w", models.ErrPaymentExists)
+		}
+		return active, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error on create payment: %w", err)
+	}
+	result, err := usecase.provider.Authorize(ctx, pay)
+	if err != nil {
+		// The payment is failed, so the order can be paid again
+		result = &payment.Result{Status: models.PaymentFailed, Reason: err.Error()}
+		err = fmt.Errorf("%w: %v", models.ErrPaymentProvider, err)
+	}
+	pay, updateErr := usecase.applyResult(ctx, pay, order.Status, result, 0)
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pay, nil
+}
+
+// activePayment returns the payment of the order in progress or done,
+// nil is returned if the order has no such payment
+func (usecase *PaymentUsecase) activePayment(ctx context.Context, orderId uuid.UUID) (*models.Payment, error) {
+	payments, err := usecase.store.GetOrderPayments(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get payments of order: %w", err)
+	}
+	for i := range payments {
+		if payments[i].Active() {
+			return &payments[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Capture charges money reserved by the authorized payment
+func (usecase *PaymentUsecase) Capture(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Capture() with args: ctx, id: %v", id)
+	pay, err := usecase.store.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pay.Status != models.PaymentAuthorized {
+		return nil, fmt.Errorf("%w: payment is %s", models.ErrPaymentNotAllowed, pay.Status)
+	}
+	order, err := usecase.store.GetOrderForPayment(ctx, pay.OrderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get order of payment: %w", err)
+	}
+	result, err := usecase.provider.Capture(ctx, pay.ProviderPaymentId, pay.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrPaymentProvider, err)
+	}
+	return usecase.applyResult(ctx, pay, order.Status, result, 0)
+}
+
+// Refund returns the amount of the captured payment, zero amount
+// returns everything not refunded yet
+func (usecase *PaymentUsecase) Refund(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Refund() with args: ctx, id: %v, amount: %d", id, amount)
+	pay, err := usecase.store.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pay.Status != models.PaymentCaptured {
+		return nil, fmt.Errorf("%w: payment is %s", models.ErrPaymentNotAllowed, pay.Status)
+	}
+	if amount == 0 {
+		amount = pay.Amount - pay.Refunded
+	}
+	if amount < 0 || amount > pay.Amount-pay.Refunded {
+		return nil, fmt.Errorf("%w: only %d can be refunded", models.ErrPaymentNotAllowed, pay.Amount-pay.Refunded)
+	}
+	order, err := usecase.store.GetOrderForPayment(ctx, pay.OrderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get order of payment: %w", err)
+	}
+	result, err := usecase.provider.Refund(ctx, pay.ProviderPaymentId, amount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrPaymentProvider, err)
+	}
+	return usecase.applyResult(ctx, pay, order.Status, result, amount)
+}
+
+// GetOrderPayments returns payments of the order, newest first
+func (usecase *PaymentUsecase) GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetOrderPayments() with args: ctx, orderId: %v", orderId)
+	return usecase.store.GetOrderPayments(ctx, orderId)
+}
+
+// HandleWebhook applies the event of the provider to the payment and its
+// order. Repeated and late events are skipped, so the provider can send
+// them again safely
+func (usecase *PaymentUsecase) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	usecase.logger.Debug("Enter in usecase HandleWebhook()")
+	event, err := usecase.provider.ParseWebhook(payload, header)
+	if err != nil {
+		return err
+	}
+	pay, err := usecase.store.GetPaymentByProviderId(ctx, usecase.provider.Name(), event.ProviderPaymentId)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		order, err := usecase.store.GetOrderForPayment(ctx, pay.OrderId)
+		if err != nil {
+			return fmt.Errorf("error on get order of payment: %w", err)
+		}
+		updated := *pay
+		_, err = updated.Apply(*event)
+		if err != nil && errors.Is(err, models.ErrPaymentNotAllowed) {
+			usecase.logger.Sugar().Warnf("event %s of payment %v is skipped: %s", event.Id, pay.Id, err)
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+		saved, err := usecase.save(ctx, *event, pay, &updated, order.Status)
+		if errors.Is(err, models.ErrPaymentChanged) && attempt < maxPaymentAttempts {
+			pay, err = usecase.store.GetPayment(ctx, pay.Id)
+			if err != nil {
+				return fmt.Errorf("error on get payment: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !saved {
+			usecase.logger.Sugar().Infof("event %s of payment %v is already handled", event.Id, pay.Id)
+		}
+		return nil
+	}
+}
+
+// applyResult changes the payment by the answer of the provider and saves
+// it with the status of the order it leads to. Amount is the refunded amount
+// for refunds. If the provider has already reported the change by webhook,
+// the saved payment is returned. The payment changed concurrently is read
+// again and the answer is applied to it
+func (usecase *PaymentUsecase) applyResult(ctx context.Context, pay *models.Payment, current models.Status, result *payment.Result, amount int64) (*models.Payment, error) {
+	event := models.PaymentEvent{
+		Id:     result.EventId,
+		Status: result.Status,
+		Amount: amount,
+		Reason: result.Reason,
+	}
+	for attempt := 1; ; attempt++ {
+		updated := *pay
+		// New payment gets its id in the provider with the first answer
+		if updated.ProviderPaymentId == "" {
+			updated.ProviderPaymentId = result.ProviderPaymentId
+			updated.RedirectURL = result.RedirectURL
+		}
+		event.ProviderPaymentId = updated.ProviderPaymentId
+		if event.Status != models.PaymentPending {
+			_, err := updated.Apply(event)
+			if err != nil {
+				return nil, err
+			}
+		}
+		saved, err := usecase.save(ctx, event, pay, &updated, current)
+		if errors.Is(err, models.ErrPaymentChanged) && attempt < maxPaymentAttempts {
+			pay, err = usecase.store.GetPayment(ctx, pay.Id)
+			if err != nil {
+				return nil, fmt.Errorf("error on get payment: %w", err)
+			}
+			order, err := usecase.store.GetOrderForPayment(ctx, pay.OrderId)
+			if err != nil {
+				return nil, fmt.Errorf("error on get order of payment: %w", err)
+			}
+			current = order.Status
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !saved {
+			return usecase.store.GetPayment(ctx, pay.Id)
+		}
+		return &updated, nil
+	}
+}
+
+// save saves the payment read as from and changed to pay with the status
+// of the order it leads to. The event is saved with them unless it has no
+// id, false is returned if the event was already handled
+func (usecase *PaymentUsecase) save(ctx context.Context, event models.PaymentEvent, from *models.Payment, pay *models.Payment, current models.Status) (bool, error) {
+	update := models.PaymentUpdate{
+		Payment:         pay,
+		FromStatus:      from.Status,
+		FromRefunded:    from.Refunded,
+		FromOrderStatus: current,
+	}
+	if orderStatus, changed := pay.OrderStatus(current); changed {
+		update.OrderStatus = orderStatus
+	}
+	if event.Id == "" {
+		err := usecase.store.UpdatePayment(ctx, update)
+		if err != nil {
+			return false, fmt.Errorf("error on update payment: %w", err)
+		}
+		return true, nil
+	}
+	saved, err := usecase.store.SavePaymentEvent(ctx, event, update)
+	if err != nil {
+		return false, fmt.Errorf("error on save payment event: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
+	paymentMocks "OnlineShopBackend/internal/payment/mocks"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	provider := paymentMocks.NewMockPaymentProvider(ctrl)
+	provider.EXPECT().Name().Return("fake").AnyTimes()
+	usecase := NewPaymentUsecase(paymentRepo, provider, zap.L())
+	userId := uuid.New()
+	order := &models.Order{ID: uuid.New(), User: models.User{ID: userId}, Status: models.StatusCreated,
+		Pricing: models.Pricing{Currency: "RUB", Total: 1000}}
+
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(nil, models.ErrorNotFound{})
+	_, err := usecase.Pay(ctx, order.ID, userId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Orders of other users can't be paid
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	_, err = usecase.Pay(ctx, order.ID, uuid.New())
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Payment in progress is returned again
+	active := models.Payment{Id: uuid.New(), OrderId: order.ID, Status: models.PaymentAuthorized}
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return([]models.Payment{
+		{Id: uuid.New(), OrderId: order.ID, Status: models.PaymentFailed}, active}, nil)
+	result, err := usecase.Pay(ctx, order.ID, userId)
+	require.NoError(t, err)
+	require.Equal(t, &active, result)
+
+	delivered := *order
+	delivered.Status = models.StatusShipped
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(&delivered, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return([]models.Payment{}, nil)
+	_, err = usecase.Pay(ctx, order.ID, userId)
+	require.ErrorIs(t, err, models.ErrPaymentNotAllowed)
+
+	paymentId := uuid.New()
+	pending := &models.Payment{Id: paymentId, OrderId: order.ID, Provider: "fake", Amount: 1000, Currency: "RUB",
+		Status: models.PaymentPending}
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return([]models.Payment{}, nil)
+	paymentRepo.EXPECT().CreatePayment(ctx, &models.Payment{OrderId: order.ID, Provider: "fake", Amount: 1000,
+		Currency: "RUB", Status: models.PaymentPending}).Return(paymentId, nil)
+	provider.EXPECT().Authorize(ctx, pending).Return(&payment.Result{ProviderPaymentId: "fake_1",
+		Status: models.PaymentAuthorized}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, models.PaymentUpdate{Payment: &models.Payment{Id: paymentId, OrderId: order.ID,
+		Provider: "fake", ProviderPaymentId: "fake_1", Amount: 1000, Currency: "RUB", Status: models.PaymentAuthorized},
+		FromStatus: models.PaymentPending, OrderStatus: models.StatusPaymentAuthorized, FromOrderStatus: models.StatusCreated}).Return(nil)
+	result, err = usecase.Pay(ctx, order.ID, userId)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentAuthorized, result.Status)
+
+	// Failed provider fails the payment, so the order can be paid again
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return([]models.Payment{}, nil)
+	paymentRepo.EXPECT().CreatePayment(ctx, gomock.Any()).Return(paymentId, nil)
+	provider.EXPECT().Authorize(ctx, gomock.Any()).Return(nil, fmt.Errorf("timeout"))
+	paymentRepo.EXPECT().UpdatePayment(ctx, models.PaymentUpdate{Payment: &models.Payment{Id: paymentId, OrderId: order.ID,
+		Provider: "fake", Amount: 1000, Currency: "RUB", Status: models.PaymentFailed, FailureReason: "timeout"},
+		FromStatus: models.PaymentPending, OrderStatus: models.StatusPaymentFailed, FromOrderStatus: models.StatusCreated}).Return(nil)
+	_, err = usecase.Pay(ctx, order.ID, userId)
+	require.ErrorIs(t, err, models.ErrPaymentProvider)
+
+	// Payment created by the concurrent request is returned
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return([]models.Payment{}, nil)
+	paymentRepo.EXPECT().CreatePayment(ctx, gomock.Any()).Return(uuid.Nil, models.ErrPaymentExists)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return([]models.Payment{active}, nil)
+	result, err = usecase.Pay(ctx, order.ID, userId)
+	require.NoError(t, err)
+	require.Equal(t, &active, result)
+}
+
+func TestRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	provider := paymentMocks.NewMockPaymentProvider(ctrl)
+	usecase := NewPaymentUsecase(paymentRepo, provider, zap.L())
+	order := &models.Order{ID: uuid.New(), Status: models.StatusShipped}
+	newPayment := func() *models.Payment {
+		return &models.Payment{Id: uuid.New(), OrderId: order.ID, Provider: "fake", ProviderPaymentId: "fake_1",
+			Amount: 1000, Currency: "RUB", Status: models.PaymentCaptured, Refunded: 300}
+	}
+
+	authorized := newPayment()
+	authorized.Status = models.PaymentAuthorized
+	paymentRepo.EXPECT().GetPayment(ctx, authorized.Id).Return(authorized, nil)
+	_, err := usecase.Refund(ctx, authorized.Id, 100)
+	require.ErrorIs(t, err, models.ErrPaymentNotAllowed)
+
+	captured := newPayment()
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(captured, nil)
+	_, err = usecase.Refund(ctx, captured.Id, 800)
+	require.ErrorIs(t, err, models.ErrPaymentNotAllowed)
+
+	// Partial refund keeps status of the order
+	captured = newPayment()
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(captured, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	provider.EXPECT().Refund(ctx, "fake_1", int64(200)).Return(&payment.Result{ProviderPaymentId: "fake_1",
+		Status: models.PaymentRefunded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, update models.PaymentUpdate) error {
+		require.Equal(t, int64(300), update.FromRefunded)
+		require.Equal(t, models.Status(""), update.OrderStatus)
+		return nil
+	})
+	result, err := usecase.Refund(ctx, captured.Id, 200)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentCaptured, result.Status)
+	require.Equal(t, int64(500), result.Refunded)
+
+	// Zero amount refunds the rest
+	captured = newPayment()
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(captured, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	provider.EXPECT().Refund(ctx, "fake_1", int64(700)).Return(&payment.Result{ProviderPaymentId: "fake_1",
+		Status: models.PaymentRefunded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, update models.PaymentUpdate) error {
+		require.Equal(t, models.StatusRefunded, update.OrderStatus)
+		return nil
+	})
+	result, err = usecase.Refund(ctx, captured.Id, 0)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentRefunded, result.Status)
+	require.Equal(t, int64(1000), result.Refunded)
+
+	// Refund made concurrently is counted when the payment is saved again
+	captured = newPayment()
+	concurrent := newPayment()
+	concurrent.Id = captured.Id
+	concurrent.Refunded = 500
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(captured, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	provider.EXPECT().Refund(ctx, "fake_1", int64(200)).Return(&payment.Result{ProviderPaymentId: "fake_1",
+		Status: models.PaymentRefunded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).Return(models.ErrPaymentChanged)
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(concurrent, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, update models.PaymentUpdate) error {
+		require.Equal(t, int64(500), update.FromRefunded)
+		require.Equal(t, int64(700), update.Payment.Refunded)
+		return nil
+	})
+	result, err = usecase.Refund(ctx, captured.Id, 200)
+	require.NoError(t, err)
+	require.Equal(t, int64(700), result.Refunded)
+}
+
+func TestHandleWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	provider := paymentMocks.NewMockPaymentProvider(ctrl)
+	provider.EXPECT().Name().Return("fake").AnyTimes()
+	usecase := NewPaymentUsecase(paymentRepo, provider, zap.L())
+	payload := []byte("{}")
+	header := http.Header{}
+	order := &models.Order{ID: uuid.New(), Status: models.StatusPaymentAuthorized}
+	newPayment := func() *models.Payment {
+		return &models.Payment{Id: uuid.New(), OrderId: order.ID, Provider: "fake", ProviderPaymentId: "fake_1",
+			Amount: 1000, Currency: "RUB", Status: models.PaymentAuthorized}
+	}
+
+	provider.EXPECT().ParseWebhook(payload, header).Return(nil, models.ErrInvalidSignature)
+	err := usecase.HandleWebhook(ctx, payload, header)
+	require.ErrorIs(t, err, models.ErrInvalidSignature)
+
+	event := &models.PaymentEvent{Id: "evt_1", ProviderPaymentId: "fake_1", Status: models.PaymentCaptured}
+	provider.EXPECT().ParseWebhook(payload, header).Return(event, nil)
+	paymentRepo.EXPECT().GetPaymentByProviderId(ctx, "fake", "fake_1").Return(nil, models.ErrorNotFound{})
+	err = usecase.HandleWebhook(ctx, payload, header)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Captured payment makes the order paid, repeated event is skipped by the store
+	for _, saved := range []bool{true, false} {
+		pay := newPayment()
+		captured := *pay
+		captured.Status = models.PaymentCaptured
+		provider.EXPECT().ParseWebhook(payload, header).Return(event, nil)
+		paymentRepo.EXPECT().GetPaymentByProviderId(ctx, "fake", "fake_1").Return(pay, nil)
+		paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+		paymentRepo.EXPECT().SavePaymentEvent(ctx, *event, models.PaymentUpdate{Payment: &captured,
+			FromStatus: models.PaymentAuthorized, OrderStatus: models.StatusPaid, FromOrderStatus: order.Status}).Return(saved, nil)
+		err = usecase.HandleWebhook(ctx, payload, header)
+		require.NoError(t, err)
+	}
+
+	// Late failure doesn't change the shipped order
+	shipped := *order
+	shipped.Status = models.StatusShipped
+	pay := newPayment()
+	failed := *pay
+	failed.Status = models.PaymentFailed
+	failed.FailureReason = "expired"
+	event = &models.PaymentEvent{Id: "evt_2", ProviderPaymentId: "fake_1", Status: models.PaymentFailed, Reason: "expired"}
+	provider.EXPECT().ParseWebhook(payload, header).Return(event, nil)
+	paymentRepo.EXPECT().GetPaymentByProviderId(ctx, "fake", "fake_1").Return(pay, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(&shipped, nil)
+	paymentRepo.EXPECT().SavePaymentEvent(ctx, *event, models.PaymentUpdate{Payment: &failed,
+		FromStatus: models.PaymentAuthorized, FromOrderStatus: shipped.Status}).Return(true, nil)
+	err = usecase.HandleWebhook(ctx, payload, header)
+	require.NoError(t, err)
+
+	// Refund of not captured payment is recorded without changes
+	pay = newPayment()
+	unchanged := *pay
+	event = &models.PaymentEvent{Id: "evt_3", ProviderPaymentId: "fake_1", Status: models.PaymentRefunded, Amount: 100}
+	provider.EXPECT().ParseWebhook(payload, header).Return(event, nil)
+	paymentRepo.EXPECT().GetPaymentByProviderId(ctx, "fake", "fake_1").Return(pay, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().SavePaymentEvent(ctx, *event, models.PaymentUpdate{Payment: &unchanged,
+		FromStatus: models.PaymentAuthorized, FromOrderStatus: order.Status}).Return(true, nil)
+	err = usecase.HandleWebhook(ctx, payload, header)
+	require.NoError(t, err)
+
+	// Payment captured concurrently is read again and the event is applied to it
+	pay = newPayment()
+	captured := *pay
+	captured.Status = models.PaymentCaptured
+	paid := *order
+	paid.Status = models.StatusPaid
+	event = &models.PaymentEvent{Id: "evt_4", ProviderPaymentId: "fake_1", Status: models.PaymentFailed, Reason: "expired"}
+	provider.EXPECT().ParseWebhook(payload, header).Return(event, nil)
+	paymentRepo.EXPECT().GetPaymentByProviderId(ctx, "fake", "fake_1").Return(pay, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(order, nil)
+	paymentRepo.EXPECT().SavePaymentEvent(ctx, *event, gomock.Any()).Return(false, models.ErrPaymentChanged)
+	paymentRepo.EXPECT().GetPayment(ctx, pay.Id).Return(&captured, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(&paid, nil)
+	unchanged = captured
+	paymentRepo.EXPECT().SavePaymentEvent(ctx, *event, models.PaymentUpdate{Payment: &unchanged,
+		FromStatus: models.PaymentCaptured, FromOrderStatus: models.StatusPaid}).Return(true, nil)
+	err = usecase.HandleWebhook(ctx, payload, header)
+	require.NoError(t, err)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IPromotionUsecase = &PromotionUsecase{}
+
+type PromotionUsecase struct {
+	store  repository.PromotionStore
+	logger *zap.Logger
+}
+
+func NewPromotionUsecase(store repository.PromotionStore, logger *zap.Logger) IPromotionUsecase {
+	logger.Debug("Enter in usecase NewPromotionUsecase()")
+	return &PromotionUsecase{store: store, logger: logger}
+}
+
+// CreatePromotion checks settings of the promotion and saves it
+func (usecase *PromotionUsecase) CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreatePromotion() with args: ctx, promotion: %v", promotion)
+	promotion.Code = models.NormalizeCode(promotion.Code)
+	err := promotion.Validate()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := usecase.store.CreatePromotion(ctx, promotion)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on create promotion: %w", err)
+	}
+	return id, nil
+}
+
+// UpdatePromotion checks settings of the promotion and updates it
+func (usecase *PromotionUsecase) UpdatePromotion(ctx context.Context, promotion *models.Promotion) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase UpdatePromotion() with args: ctx, promotion: %v", promotion)
+	promotion.Code = models.NormalizeCode(promotion.Code)
+	err := promotion.Validate()
+	if err != nil {
+		return err
+	}
+	return usecase.store.UpdatePromotion(ctx, promotion)
+}
+
+// GetPromotion returns promotion by id
+func (usecase *PromotionUsecase) GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetPromotion() with args: ctx, id: %v", id)
+	return usecase.store.GetPromotion(ctx, id)
+}
+
+// GetPromotions returns all promotions
+func (usecase *PromotionUsecase) GetPromotions(ctx context.Context) ([]models.Promotion, error) {
+	usecase.logger.Debug("Enter in usecase GetPromotions()")
+	return usecase.store.GetPromotions(ctx)
+}
+
+// DeletePromotion deletes promotion by id
+func (usecase *PromotionUsecase) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeletePromotion() with args: ctx, id: %v", id)
+	return usecase.store.DeletePromotion(ctx, id)
+}
+
+// automaticPromotions returns automatic promotions available for the user
+// at the given time. Promotions not discounting the items are kept, they
+// are skipped on pricing
+func automaticPromotions(ctx context.Context, store repository.PromotionStore, userId uuid.UUID, now time.Time) ([]models.Promotion, error) {
+	promotions, err := store.GetAutomaticPromotions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error on get automatic promotions: %w", err)
+	}
+	available := make([]models.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.Available(now) != nil {
+			continue
+		}
+		err = checkUserLimit(ctx, store, &promotion, userId)
+		if errors.Is(err, models.ErrPromoLimitReached) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		available = append(available, promotion)
+	}
+	return available, nil
+}
+
+// codePromotion returns promotion of the promo code if it can be
+// applied to the items of the user at the given time
+func codePromotion(ctx context.Context, store repository.PromotionStore, code string, items []models.ItemWithQuantity, userId uuid.UUID, now time.Time) (*models.Promotion, error) {
+	promotion, err := store.GetPromotionByCode(ctx, models.NormalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	err = promotion.Available(now)
+	if err != nil {
+		return nil, err
+	}
+	err = checkUserLimit(ctx, store, promotion, userId)
+	if err != nil {
+		return nil, err
+	}
+	err = promotion.Check(items)
+	if err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// checkUserLimit checks the number of usages of the promotion by the user
+func checkUserLimit(ctx context.Context, store repository.PromotionStore, promotion *models.Promotion, userId uuid.UUID) error {
+	if promotion.PerUserLimit == 0 || userId == uuid.Nil {
+		return nil
+	}
+	used, err := store.CountUserUsages(ctx, promotion.Id, userId)
+	if err != nil {
+		return fmt.Errorf("error on count usages of promotion: %w", err)
+	}
+	if used >= promotion.PerUserLimit {
+		return models.ErrPromoLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewPromotionUsecase(promotionRepo, zap.L())
+	newPromotion := func() *models.Promotion {
+		return &models.Promotion{Code: " spring10 ", Name: "Spring", Kind: models.DiscountPercent, Value: 10, Scope: models.ScopeCart, Active: true}
+	}
+
+	invalid := []func(promotion *models.Promotion){
+		func(promotion *models.Promotion) { promotion.Name = " " },
+		func(promotion *models.Promotion) { promotion.Value = 101 },
+		func(promotion *models.Promotion) { promotion.Kind = "gift" },
+		func(promotion *models.Promotion) { promotion.ScopeId = testId },
+		func(promotion *models.Promotion) { promotion.Scope = models.ScopeCategory },
+		func(promotion *models.Promotion) { promotion.UsageLimit = -1 },
+		func(promotion *models.Promotion) {
+			promotion.StartsAt = time.Now()
+			promotion.EndsAt = promotion.StartsAt.Add(-time.Hour)
+		},
+	}
+	for _, change := range invalid {
+		promotion := newPromotion()
+		change(promotion)
+		_, err := usecase.CreatePromotion(ctx, promotion)
+		require.ErrorIs(t, err, models.ErrInvalidPromotion)
+	}
+
+	promotionRepo.EXPECT().CreatePromotion(ctx, gomock.Any()).Return(uuid.Nil, fmt.Errorf("error"))
+	_, err := usecase.CreatePromotion(ctx, newPromotion())
+	require.Error(t, err)
+
+	expected := newPromotion()
+	expected.Code = "SPRING10"
+	promotionRepo.EXPECT().CreatePromotion(ctx, expected).Return(testId, nil)
+	id, err := usecase.CreatePromotion(ctx, newPromotion())
+	require.NoError(t, err)
+	require.Equal(t, testId, id)
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	promotionRepo := mocks.NewMockPromotionStore(ctrl)
+	usecase := NewPromotionUsecase(promotionRepo, zap.L())
+
+	err := usecase.UpdatePromotion(ctx, &models.Promotion{Id: testId, Name: "Fixed", Kind: models.DiscountFixed, Scope: models.ScopeCart})
+	require.ErrorIs(t, err, models.ErrInvalidPromotion)
+
+	promotion := &models.Promotion{Id: testId, Name: "Fixed", Kind: models.DiscountFixed, Value: 500, Scope: models.ScopeItem, ScopeId: testId}
+	promotionRepo.EXPECT().UpdatePromotion(ctx, promotion).Return(models.ErrorNotFound{})
+	err = usecase.UpdatePromotion(ctx, promotion)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+}
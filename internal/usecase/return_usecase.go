@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IReturnUsecase = &ReturnUsecase{}
+
+type ReturnUsecase struct {
+	store          repository.ReturnStore
+	orderStore     repository.OrderStore
+	paymentUsecase IPaymentUsecase
+	logger         *zap.Logger
+}
+
+func NewReturnUsecase(store repository.ReturnStore, orderStore repository.OrderStore, paymentUsecase IPaymentUsecase, logger *zap.Logger) IReturnUsecase {
+	logger.Debug("Enter in usecase NewReturnUsecase()")
+	return &ReturnUsecase{
+		store:          store,
+		orderStore:     orderStore,
+		paymentUsecase: paymentUsecase,
+		logger:         logger,
+	}
+}
+
+// RequestReturn creates return request of the line of the delivered order
+// of the user. Quantity of the line can be returned by several requests,
+// rejected requests don't count
+func (usecase *ReturnUsecase) RequestReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase RequestReturn() with args: ctx, ret: %v", ret)
+	ret.Reason = strings.TrimSpace(ret.Reason)
+	if ret.Reason == "" || utf8.RuneCountInString(ret.Reason) > models.MaxReturnReason {
+		return uuid.Nil, fmt.Errorf("%w: reason must be from 1 to %d characters", models.ErrInvalidReturn, models.MaxReturnReason)
+	}
+	if ret.Quantity < 1 {
+		return uuid.Nil, fmt.Errorf("%w: quantity must be positive", models.ErrInvalidReturn)
+	}
+	order, err := usecase.userOrder(ctx, ret.UserId, ret.OrderId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if order.Status != models.StatusShipped {
+		return uuid.Nil, fmt.Errorf("%w: order is %s", models.ErrReturnNotAllowed, order.Status)
+	}
+	ordered := 0
+	for _, item := range order.Items {
+		if item.Id == ret.ItemId {
+			ordered += item.Quantity
+		}
+	}
+	if ordered == 0 {
+		return uuid.Nil, fmt.Errorf("%w: item isn't in the order", models.ErrInvalidReturn)
+	}
+	returns, err := usecase.store.GetOrderReturns(ctx, ret.OrderId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on get returns of order: %w", err)
+	}
+	for _, other := range returns {
+		if other.ItemId == ret.ItemId && other.Open() {
+			ordered -= other.Quantity
+		}
+	}
+	if ret.Quantity > ordered {
+		return uuid.Nil, fmt.Errorf("%w: only %d can be returned", models.ErrReturnNotAllowed, ordered)
+	}
+	ret.Status = models.ReturnRequested
+	return usecase.store.CreateReturn(ctx, ret)
+}
+
+// GetOrderReturns returns return requests of the order of the user
+func (usecase *ReturnUsecase) GetOrderReturns(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) ([]models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetOrderReturns() with args: ctx, userId: %v, orderId: %v", userId, orderId)
+	if _, err := usecase.userOrder(ctx, userId, orderId); err != nil {
+		return nil, err
+	}
+	return usecase.store.GetOrderReturns(ctx, orderId)
+}
+
+// GetReturns returns return requests in the status, all requests
+// are returned for empty status
+func (usecase *ReturnUsecase) GetReturns(ctx context.Context, status models.ReturnStatus) ([]models.Return, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetReturns() with args: ctx, status: %s", status)
+	switch status {
+	case "", models.ReturnRequested, models.ReturnApproved, models.ReturnRejected, models.ReturnReceived, models.ReturnRefunding, models.ReturnRefunded:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", models.ErrInvalidReturn, status)
+	}
+	return usecase.store.GetReturns(ctx, status)
+}
+
+// ApproveReturn approves requested return, the buyer sends the items back
+func (usecase *ReturnUsecase) ApproveReturn(ctx context.Context, id uuid.UUID, comment string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase ApproveReturn() with args: ctx, id: %v, comment: %s", id, comment)
+	return usecase.changeStatus(ctx, id, models.ReturnRequested, models.ReturnApproved, comment)
+}
+
+// RejectReturn rejects requested return, its quantity can be requested again
+func (usecase *ReturnUsecase) RejectReturn(ctx context.Context, id uuid.UUID, comment string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase RejectReturn() with args: ctx, id: %v, comment: %s", id, comment)
+	return usecase.changeStatus(ctx, id, models.ReturnRequested, models.ReturnRejected, comment)
+}
+
+// ReceiveReturn marks items of the approved return as received
+// and returns them to the stock
+func (usecase *ReturnUsecase) ReceiveReturn(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase ReceiveReturn() with args: ctx, id: %v", id)
+	if _, err := usecase.store.GetReturn(ctx, id); err != nil {
+		return err
+	}
+	return usecase.store.ReceiveReturn(ctx, id)
+}
+
+// RefundReturn refunds the amount for the received return through the
+// captured payment of the order which has enough money. Offline refunds,
+// e.g. made by the bank transfer, are only recorded. The refund can't exceed
+// the paid amount of the returned quantity, and refunds of the order with
+// refunds of its payments can't exceed its total. The return is
+// claimed before the money is refunded, so it is refunded only once
+func (usecase *ReturnUsecase) RefundReturn(ctx context.Context, id uuid.UUID, amount int64, offline bool) (*models.Refund, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase RefundReturn() with args: ctx, id: %v, amount: %d, offline: %t", id, amount, offline)
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", models.ErrInvalidReturn)
+	}
+	ret, err := usecase.store.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != models.ReturnReceived {
+		return nil, fmt.Errorf("%w: return is %s", models.ErrReturnNotAllowed, ret.Status)
+	}
+	order, err := usecase.orderStore.GetOrderByID(ctx, ret.OrderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get order: %w", err)
+	}
+	// The return is refunded for its quantity, not more than was paid for it
+	limit := order.RefundLimit(ret.ItemId, ret.Quantity)
+	if amount > limit {
+		return nil, fmt.Errorf("%w: only %d can be refunded for the return", models.ErrReturnNotAllowed, limit)
+	}
+	refunds, err := usecase.store.GetOrderRefunds(ctx, ret.OrderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get refunds of order: %w", err)
+	}
+	payments, err := usecase.paymentUsecase.GetOrderPayments(ctx, ret.OrderId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get payments of order: %w", err)
+	}
+	// Refunds through the provider are counted by payments, as payments
+	// are also refunded without returns
+	refundable := order.Total
+	for _, refund := range refunds {
+		if refund.PaymentId == uuid.Nil {
+			refundable -= refund.Amount
+		}
+	}
+	for _, pay := range payments {
+		refundable -= pay.Refunded
+	}
+	if amount > refundable {
+		return nil, fmt.Errorf("%w: only %d can be refunded", models.ErrReturnNotAllowed, refundable)
+	}
+	var pay *models.Payment
+	if !offline {
+		for i := range payments {
+			if payments[i].Status == models.PaymentCaptured && payments[i].Amount-payments[i].Refunded >= amount {
+				pay = &payments[i]
+				break
+			}
+		}
+		if pay == nil {
+			return nil, fmt.Errorf("%w: no captured payment can refund %d, the refund can be made offline", models.ErrReturnNotAllowed, amount)
+		}
+	}
+	err = usecase.store.ChangeReturnStatus(ctx, ret.Id, models.ReturnReceived, models.ReturnRefunding, ret.Comment)
+	if err != nil {
+		return nil, err
+	}
+	refund := &models.Refund{
+		OrderId:  ret.OrderId,
+		ReturnId: ret.Id,
+		Amount:   amount,
+		Currency: order.Currency,
+	}
+	if pay != nil {
+		_, err = usecase.paymentUsecase.Refund(ctx, pay.Id, amount)
+		if err != nil {
+			// Nothing is refunded, so the return can be refunded again
+			releaseErr := usecase.store.ChangeReturnStatus(ctx, ret.Id, models.ReturnRefunding, models.ReturnReceived, ret.Comment)
+			if releaseErr != nil {
+				usecase.logger.Sugar().Errorf("return %v is left refunding: %v", ret.Id, releaseErr)
+			}
+			return nil, err
+		}
+		refund.PaymentId = pay.Id
+	}
+	refund.Id, err = usecase.store.CreateRefund(ctx, refund)
+	if err != nil {
+		// Money is already returned, so the refund must be recorded by hand,
+		// the return stays refunding meanwhile
+		usecase.logger.Sugar().Errorf("refund of return %v by payment %v isn't recorded: %v", ret.Id, refund.PaymentId, err)
+		return nil, err
+	}
+	return refund, nil
+}
+
+// GetOrderRefunds returns refunds of the order for reconciliation
+func (usecase *ReturnUsecase) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]models.Refund, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetOrderRefunds() with args: ctx, orderId: %v", orderId)
+	return usecase.store.GetOrderRefunds(ctx, orderId)
+}
+
+// userOrder returns the order of the user, orders of other users are not found
+func (usecase *ReturnUsecase) userOrder(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) (*models.Order, error) {
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.User.ID != userId {
+		return nil, models.ErrorNotFound{}
+	}
+	return &order, nil
+}
+
+// changeStatus changes status of the existing return
+func (usecase *ReturnUsecase) changeStatus(ctx context.Context, id uuid.UUID, from models.ReturnStatus, to models.ReturnStatus, comment string) error {
+	if _, err := usecase.store.GetReturn(ctx, id); err != nil {
+		return err
+	}
+	return usecase.store.ChangeReturnStatus(ctx, id, from, to, strings.TrimSpace(comment))
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/payment"
+	paymentMocks "OnlineShopBackend/internal/payment/mocks"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRequestReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	returnRepo := mocks.NewMockReturnStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	usecase := NewReturnUsecase(returnRepo, orderRepo, nil, zap.L())
+	userId := uuid.New()
+	itemId := uuid.New()
+	order := models.Order{ID: uuid.New(), User: models.User{ID: userId}, Status: models.StatusShipped,
+		Items: []models.ItemWithQuantity{{Item: models.Item{Id: itemId}, Quantity: 3}}}
+	newReturn := func(quantity int, reason string) *models.Return {
+		return &models.Return{OrderId: order.ID, UserId: userId, ItemId: itemId, Quantity: quantity, Reason: reason}
+	}
+
+	_, err := usecase.RequestReturn(ctx, newReturn(1, "  "))
+	require.ErrorIs(t, err, models.ErrInvalidReturn)
+	_, err = usecase.RequestReturn(ctx, newReturn(1, strings.Repeat("a", models.MaxReturnReason+1)))
+	require.ErrorIs(t, err, models.ErrInvalidReturn)
+	_, err = usecase.RequestReturn(ctx, newReturn(0, "broken"))
+	require.ErrorIs(t, err, models.ErrInvalidReturn)
+
+	// Orders of other users are not found
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	other := newReturn(1, "broken")
+	other.UserId = uuid.New()
+	_, err = usecase.RequestReturn(ctx, other)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	created := order
+	created.Status = models.StatusCreated
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(created, nil)
+	_, err = usecase.RequestReturn(ctx, newReturn(1, "broken"))
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	missing := newReturn(1, "broken")
+	missing.ItemId = uuid.New()
+	_, err = usecase.RequestReturn(ctx, missing)
+	require.ErrorIs(t, err, models.ErrInvalidReturn)
+
+	// Rejected returns don't count
+	existing := []models.Return{
+		{ItemId: itemId, Quantity: 2, Status: models.ReturnApproved},
+		{ItemId: itemId, Quantity: 1, Status: models.ReturnRejected},
+	}
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	returnRepo.EXPECT().GetOrderReturns(ctx, order.ID).Return(existing, nil)
+	_, err = usecase.RequestReturn(ctx, newReturn(2, "broken"))
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	id := uuid.New()
+	expected := newReturn(1, "broken")
+	expected.Status = models.ReturnRequested
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	returnRepo.EXPECT().GetOrderReturns(ctx, order.ID).Return(existing, nil)
+	returnRepo.EXPECT().CreateReturn(ctx, expected).Return(id, nil)
+	result, err := usecase.RequestReturn(ctx, newReturn(1, " broken "))
+	require.NoError(t, err)
+	require.Equal(t, id, result)
+}
+
+func TestChangeReturnStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	returnRepo := mocks.NewMockReturnStore(ctrl)
+	usecase := NewReturnUsecase(returnRepo, nil, nil, zap.L())
+	ret := &models.Return{Id: uuid.New(), Status: models.ReturnRequested}
+
+	returnRepo.EXPECT().GetReturn(ctx, ret.Id).Return(nil, models.ErrorNotFound{})
+	err := usecase.ApproveReturn(ctx, ret.Id, "")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	returnRepo.EXPECT().GetReturn(ctx, ret.Id).Return(ret, nil)
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnRequested, models.ReturnApproved, "send it back").Return(nil)
+	err = usecase.ApproveReturn(ctx, ret.Id, " send it back ")
+	require.NoError(t, err)
+
+	returnRepo.EXPECT().GetReturn(ctx, ret.Id).Return(ret, nil)
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnRequested, models.ReturnRejected, "").
+		Return(models.ErrReturnNotAllowed)
+	err = usecase.RejectReturn(ctx, ret.Id, "")
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	_, err = usecase.GetReturns(ctx, "lost")
+	require.ErrorIs(t, err, models.ErrInvalidReturn)
+}
+
+func TestRefundReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	returnRepo := mocks.NewMockReturnStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	provider := paymentMocks.NewMockPaymentProvider(ctrl)
+	usecase := NewReturnUsecase(returnRepo, orderRepo, NewPaymentUsecase(paymentRepo, provider, zap.L()), zap.L())
+	item := models.Item{Id: uuid.New(), Price: 300}
+	order := models.Order{ID: uuid.New(), Status: models.StatusShipped, Items: []models.ItemWithQuantity{{Item: item, Quantity: 4}},
+		Pricing: models.Pricing{Currency: "RUB", Subtotal: 1200, Discounts: []models.AppliedDiscount{{Amount: 250}}, Shipping: 50, Total: 1000}}
+	ret := &models.Return{Id: uuid.New(), OrderId: order.ID, ItemId: item.Id, Quantity: 2, Status: models.ReturnReceived,
+		Comment: "Send by courier"}
+	previous := []models.Refund{{OrderId: order.ID, Amount: 600, Currency: "RUB"}}
+	failed := models.Payment{Id: uuid.New(), OrderId: order.ID, Status: models.PaymentFailed, Amount: 1000}
+	captured := models.Payment{Id: uuid.New(), OrderId: order.ID, ProviderPaymentId: "fake_1", Amount: 1000,
+		Refunded: 600, Currency: "RUB", Status: models.PaymentCaptured}
+	expect := func(refunds []models.Refund, payments []models.Payment) {
+		returnRepo.EXPECT().GetReturn(ctx, ret.Id).Return(ret, nil)
+		orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+		returnRepo.EXPECT().GetOrderRefunds(ctx, order.ID).Return(refunds, nil)
+		paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return(payments, nil)
+	}
+
+	_, err := usecase.RefundReturn(ctx, ret.Id, 0, false)
+	require.ErrorIs(t, err, models.ErrInvalidReturn)
+
+	approved := *ret
+	approved.Status = models.ReturnApproved
+	returnRepo.EXPECT().GetReturn(ctx, ret.Id).Return(&approved, nil)
+	_, err = usecase.RefundReturn(ctx, ret.Id, 100, false)
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	// The return is refunded for its quantity with its share of the discount,
+	// shipping isn't refunded
+	require.Equal(t, int64(475), order.RefundLimit(item.Id, 2))
+	returnRepo.EXPECT().GetReturn(ctx, ret.Id).Return(ret, nil)
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	_, err = usecase.RefundReturn(ctx, ret.Id, 476, false)
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	// Refunds can't exceed total of the order
+	expect(previous, []models.Payment{failed})
+	_, err = usecase.RefundReturn(ctx, ret.Id, 450, true)
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	// Refunds of payments made without returns are counted
+	expect(nil, []models.Payment{captured})
+	_, err = usecase.RefundReturn(ctx, ret.Id, 450, false)
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	// Without captured payment the refund must be made offline
+	expect(previous, []models.Payment{failed})
+	_, err = usecase.RefundReturn(ctx, ret.Id, 400, false)
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	// Offline refund is only recorded
+	refundId := uuid.New()
+	expect(previous, []models.Payment{failed})
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnReceived, models.ReturnRefunding, ret.Comment).Return(nil)
+	returnRepo.EXPECT().CreateRefund(ctx, &models.Refund{OrderId: order.ID, ReturnId: ret.Id, Amount: 400,
+		Currency: "RUB"}).Return(refundId, nil)
+	refund, err := usecase.RefundReturn(ctx, ret.Id, 400, true)
+	require.NoError(t, err)
+	require.Equal(t, refundId, refund.Id)
+	require.Equal(t, uuid.Nil, refund.PaymentId)
+
+	// Captured payment is refunded through the provider, its refunds of
+	// returns are counted once
+	refunded := captured
+	expect([]models.Refund{{OrderId: order.ID, PaymentId: captured.Id, Amount: 600, Currency: "RUB"}}, []models.Payment{captured})
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnReceived, models.ReturnRefunding, ret.Comment).Return(nil)
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(&refunded, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(&order, nil)
+	provider.EXPECT().Refund(ctx, "fake_1", int64(400)).Return(&payment.Result{ProviderPaymentId: "fake_1",
+		Status: models.PaymentRefunded}, nil)
+	paymentRepo.EXPECT().UpdatePayment(ctx, gomock.Any()).Return(nil)
+	returnRepo.EXPECT().CreateRefund(ctx, &models.Refund{OrderId: order.ID, ReturnId: ret.Id, PaymentId: captured.Id,
+		Amount: 400, Currency: "RUB"}).Return(refundId, nil)
+	refund, err = usecase.RefundReturn(ctx, ret.Id, 400, false)
+	require.NoError(t, err)
+	require.Equal(t, captured.Id, refund.PaymentId)
+
+	// Return claimed by the concurrent request isn't refunded again
+	expect(nil, []models.Payment{captured})
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnReceived, models.ReturnRefunding, ret.Comment).
+		Return(models.ErrReturnNotAllowed)
+	_, err = usecase.RefundReturn(ctx, ret.Id, 100, false)
+	require.ErrorIs(t, err, models.ErrReturnNotAllowed)
+
+	// Errors of the provider are returned, nothing is recorded and the return can be refunded again
+	expect(nil, []models.Payment{captured})
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnReceived, models.ReturnRefunding, ret.Comment).Return(nil)
+	paymentRepo.EXPECT().GetPayment(ctx, captured.Id).Return(&captured, nil)
+	paymentRepo.EXPECT().GetOrderForPayment(ctx, order.ID).Return(&order, nil)
+	provider.EXPECT().Refund(ctx, "fake_1", int64(100)).Return(nil, fmt.Errorf("timeout"))
+	returnRepo.EXPECT().ChangeReturnStatus(ctx, ret.Id, models.ReturnRefunding, models.ReturnReceived, ret.Comment).Return(nil)
+	_, err = usecase.RefundReturn(ctx, ret.Id, 100, false)
+	require.ErrorIs(t, err, models.ErrPaymentProvider)
+}
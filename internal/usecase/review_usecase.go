@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/repository/cash"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IReviewUsecase = &ReviewUsecase{}
+
+type ReviewUsecase struct {
+	reviewStore repository.ReviewStore
+	itemStore   repository.ItemStore
+	cash        cash.ITagsCash
+	logger      *zap.Logger
+}
+
+func NewReviewUsecase(reviewStore repository.ReviewStore, itemStore repository.ItemStore, cash cash.ITagsCash, logger *zap.Logger) IReviewUsecase {
+	logger.Debug("Enter in usecase NewReviewUsecase()")
+	return &ReviewUsecase{reviewStore: reviewStore, itemStore: itemStore, cash: cash, logger: logger}
+}
+
+// CreateReview saves review of the user about the item if the user ordered
+// it. The previous review of the user is replaced, the review is shown
+// only after approval by moderator
+func (usecase *ReviewUsecase) CreateReview(ctx context.Context, review *models.Review) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateReview() with args: ctx, review: %v", review)
+	if review.Rating < models.MinRating || review.Rating > models.MaxRating {
+		return uuid.Nil, models.ErrInvalidRating
+	}
+	item, err := usecase.itemStore.GetItem(ctx, review.ItemId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on get item: %w", err)
+	}
+	isBuyer, err := usecase.reviewStore.IsBuyer(ctx, review.UserId, review.ItemId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on check orders of user: %w", err)
+	}
+	if !isBuyer {
+		return uuid.Nil, models.ErrNotBuyer
+	}
+	review.Text = strings.TrimSpace(review.Text)
+	review.Status = models.ReviewPending
+	id, err := usecase.reviewStore.SaveReview(ctx, review)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on save review: %w", err)
+	}
+	// Replaced review could be approved and counted in the rating
+	usecase.invalidate(ctx, item.Category.Name)
+	return id, nil
+}
+
+// GetItemReviews returns approved reviews of the item
+func (usecase *ReviewUsecase) GetItemReviews(ctx context.Context, itemId uuid.UUID, offset, limit int) ([]models.Review, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetItemReviews() with args: ctx, itemId: %v, offset: %d, limit: %d", itemId, offset, limit)
+	reviews, err := usecase.reviewStore.GetItemReviews(ctx, itemId, models.ReviewApproved, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error on get reviews of item: %w", err)
+	}
+	return reviews, nil
+}
+
+// GetReviews returns reviews of all items with given status for moderation
+func (usecase *ReviewUsecase) GetReviews(ctx context.Context, status models.ReviewStatus, offset, limit int) ([]models.Review, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetReviews() with args: ctx, status: %s, offset: %d, limit: %d", status, offset, limit)
+	reviews, err := usecase.reviewStore.GetReviewsByStatus(ctx, status, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error on get reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// ModerateReview approves or rejects the review and invalidates
+// cached lists of items, because the rating of the item changes
+func (usecase *ReviewUsecase) ModerateReview(ctx context.Context, id uuid.UUID, status models.ReviewStatus) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase ModerateReview() with args: ctx, id: %v, status: %s", id, status)
+	if status != models.ReviewApproved && status != models.ReviewRejected {
+		return models.ErrInvalidModeration
+	}
+	review, err := usecase.reviewStore.GetReview(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error on get review: %w", err)
+	}
+	if review.Status == status {
+		return nil
+	}
+	err = usecase.reviewStore.ChangeReviewStatus(ctx, id, status)
+	if err != nil {
+		return fmt.Errorf("error on change status of review: %w", err)
+	}
+	categoryName := ""
+	item, err := usecase.itemStore.GetItem(ctx, review.ItemId)
+	if err != nil {
+		usecase.logger.Sugar().Warnf("error on get reviewed item: %v", err)
+	} else {
+		categoryName = item.Category.Name
+	}
+	usecase.invalidate(ctx, categoryName)
+	return nil
+}
+
+// invalidate bumps generations of lists of items, lists of items
+// in category are invalidated if the name of category is known
+func (usecase *ReviewUsecase) invalidate(ctx context.Context, categoryName string) {
+	tags := []string{cash.TagItems}
+	if categoryName != "" {
+		tags = append(tags, cash.CategoryTag(categoryName))
+	}
+	err := usecase.cash.InvalidateTags(ctx, tags...)
+	if err != nil {
+		usecase.logger.Sugar().Errorf("error on invalidate cash tags: %v, error: %v", tags, err)
+	}
+}
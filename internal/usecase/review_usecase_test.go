@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	reviewRepo := mocks.NewMockReviewStore(ctrl)
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	tagsCash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewReviewUsecase(reviewRepo, itemRepo, tagsCash, zap.L())
+	userId := uuid.New()
+	newReview := func(rating int) *models.Review {
+		return &models.Review{ItemId: testId, UserId: userId, Rating: rating, Text: " Good one "}
+	}
+
+	_, err := usecase.CreateReview(ctx, newReview(0))
+	require.ErrorIs(t, err, models.ErrInvalidRating)
+	_, err = usecase.CreateReview(ctx, newReview(6))
+	require.ErrorIs(t, err, models.ErrInvalidRating)
+
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(nil, models.ErrorNotFound{})
+	_, err = usecase.CreateReview(ctx, newReview(5))
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(&models.Item{Id: testId, Category: models.Category{Name: "Tools"}}, nil).AnyTimes()
+	reviewRepo.EXPECT().IsBuyer(ctx, userId, testId).Return(false, fmt.Errorf("error"))
+	_, err = usecase.CreateReview(ctx, newReview(5))
+	require.Error(t, err)
+
+	reviewRepo.EXPECT().IsBuyer(ctx, userId, testId).Return(false, nil)
+	_, err = usecase.CreateReview(ctx, newReview(5))
+	require.ErrorIs(t, err, models.ErrNotBuyer)
+
+	reviewRepo.EXPECT().IsBuyer(ctx, userId, testId).Return(true, nil).Times(2)
+	reviewRepo.EXPECT().SaveReview(ctx, gomock.Any()).Return(uuid.Nil, fmt.Errorf("error"))
+	_, err = usecase.CreateReview(ctx, newReview(5))
+	require.Error(t, err)
+
+	reviewId := uuid.New()
+	reviewRepo.EXPECT().SaveReview(ctx, &models.Review{
+		ItemId: testId,
+		UserId: userId,
+		Rating: 5,
+		Text:   "Good one",
+		Status: models.ReviewPending,
+	}).Return(reviewId, nil)
+	tagsCash.EXPECT().InvalidateTags(ctx, cash.TagItems, cash.CategoryTag("Tools")).Return(nil)
+	id, err := usecase.CreateReview(ctx, newReview(5))
+	require.NoError(t, err)
+	require.Equal(t, reviewId, id)
+}
+
+func TestGetReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	reviewRepo := mocks.NewMockReviewStore(ctrl)
+	usecase := NewReviewUsecase(reviewRepo, nil, nil, zap.L())
+	reviews := []models.Review{{Id: uuid.New(), ItemId: testId, Rating: 4}}
+
+	reviewRepo.EXPECT().GetItemReviews(ctx, testId, models.ReviewApproved, 0, 10).Return(nil, fmt.Errorf("error"))
+	_, err := usecase.GetItemReviews(ctx, testId, 0, 10)
+	require.Error(t, err)
+
+	reviewRepo.EXPECT().GetItemReviews(ctx, testId, models.ReviewApproved, 0, 10).Return(reviews, nil)
+	res, err := usecase.GetItemReviews(ctx, testId, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, reviews, res)
+
+	reviewRepo.EXPECT().GetReviewsByStatus(ctx, models.ReviewPending, 10, 10).Return(nil, fmt.Errorf("error"))
+	_, err = usecase.GetReviews(ctx, models.ReviewPending, 10, 10)
+	require.Error(t, err)
+
+	reviewRepo.EXPECT().GetReviewsByStatus(ctx, models.ReviewPending, 0, 10).Return(reviews, nil)
+	res, err = usecase.GetReviews(ctx, models.ReviewPending, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, reviews, res)
+}
+
+func TestModerateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	reviewRepo := mocks.NewMockReviewStore(ctrl)
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	tagsCash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewReviewUsecase(reviewRepo, itemRepo, tagsCash, zap.L())
+	reviewId := uuid.New()
+	pending := &models.Review{Id: reviewId, ItemId: testId, Rating: 4, Status: models.ReviewPending}
+
+	err := usecase.ModerateReview(ctx, reviewId, models.ReviewPending)
+	require.ErrorIs(t, err, models.ErrInvalidModeration)
+
+	reviewRepo.EXPECT().GetReview(ctx, reviewId).Return(nil, models.ErrorNotFound{})
+	err = usecase.ModerateReview(ctx, reviewId, models.ReviewApproved)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Nothing is changed
+	reviewRepo.EXPECT().GetReview(ctx, reviewId).Return(&models.Review{Id: reviewId, Status: models.ReviewRejected}, nil)
+	err = usecase.ModerateReview(ctx, reviewId, models.ReviewRejected)
+	require.NoError(t, err)
+
+	reviewRepo.EXPECT().GetReview(ctx, reviewId).Return(pending, nil)
+	reviewRepo.EXPECT().ChangeReviewStatus(ctx, reviewId, models.ReviewApproved).Return(fmt.Errorf("error"))
+	err = usecase.ModerateReview(ctx, reviewId, models.ReviewApproved)
+	require.Error(t, err)
+
+	reviewRepo.EXPECT().GetReview(ctx, reviewId).Return(pending, nil)
+	reviewRepo.EXPECT().ChangeReviewStatus(ctx, reviewId, models.ReviewApproved).Return(nil)
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(&models.Item{Id: testId, Category: models.Category{Name: "Tools"}}, nil)
+	tagsCash.EXPECT().InvalidateTags(ctx, cash.TagItems, cash.CategoryTag("Tools")).Return(nil)
+	err = usecase.ModerateReview(ctx, reviewId, models.ReviewApproved)
+	require.NoError(t, err)
+
+	// Lists of all items are invalidated even if the item is deleted
+	reviewRepo.EXPECT().GetReview(ctx, reviewId).Return(pending, nil)
+	reviewRepo.EXPECT().ChangeReviewStatus(ctx, reviewId, models.ReviewRejected).Return(nil)
+	itemRepo.EXPECT().GetItem(ctx, testId).Return(nil, models.ErrorNotFound{})
+	tagsCash.EXPECT().InvalidateTags(ctx, cash.TagItems).Return(fmt.Errorf("error"))
+	err = usecase.ModerateReview(ctx, reviewId, models.ReviewRejected)
+	require.NoError(t, err)
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/carrier"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IShipmentUsecase = &ShipmentUsecase{}
+
+type ShipmentUsecase struct {
+	store        repository.ShipmentStore
+	orderStore   repository.OrderStore
+	paymentStore repository.PaymentStore
+	carrier      carrier.Carrier
+	logger       *zap.Logger
+}
+
+func NewShipmentUsecase(store repository.ShipmentStore, orderStore repository.OrderStore, paymentStore repository.PaymentStore, carrier carrier.Carrier, logger *zap.Logger) IShipmentUsecase {
+	logger.Debug("Enter in usecase NewShipmentUsecase()")
+	return &ShipmentUsecase{
+		store:        store,
+		orderStore:   orderStore,
+		paymentStore: paymentStore,
+		carrier:      carrier,
+		logger:       logger,
+	}
+}
+
+// CreateShipment creates pending shipment of the lines of the paid order, lines
+// can't exceed quantities not sent by other shipments. Without lines all
+// unsent lines are added to the shipment
+func (usecase *ShipmentUsecase) CreateShipment(ctx context.Context, orderId uuid.UUID, lines []models.ShipmentLine) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateShipment() with args: ctx, orderId: %v, lines: %v", orderId, lines)
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	payments, err := usecase.paymentStore.GetOrderPayments(ctx, orderId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on get payments of order: %w", err)
+	}
+	if !models.Shippable(payments) {
+		return uuid.Nil, fmt.Errorf("%w: order isn't paid", models.ErrShipmentNotAllowed)
+	}
+	shipments, err := usecase.store.GetOrderShipments(ctx, orderId)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on get shipments of order: %w", err)
+	}
+	unshipped := order.Unshipped(shipments)
+	if len(unshipped) == 0 {
+		return uuid.Nil, fmt.Errorf("%w: all lines of order are shipped", models.ErrShipmentNotAllowed)
+	}
+	shipment := &models.Shipment{
+		OrderId: orderId,
+		Carrier: usecase.carrier.Name(),
+		Status:  models.ShipmentPending,
+	}
+	if len(lines) == 0 {
+		for _, item := range order.Items {
+			if quantity := unshipped[item.Id]; quantity > 0 {
+				shipment.Lines = append(shipment.Lines, models.ShipmentLine{ItemId: item.Id, Title: item.Title, Quantity: quantity})
+				delete(unshipped, item.Id)
+			}
+		}
+		return usecase.store.CreateShipment(ctx, shipment)
+	}
+	quantities := make(map[uuid.UUID]int, len(lines))
+	for _, line := range lines {
+		if line.Quantity < 1 {
+			return uuid.Nil, fmt.Errorf("%w: quantity must be positive", models.ErrInvalidShipment)
+		}
+		if _, ok := quantities[line.ItemId]; !ok {
+			shipment.Lines = append(shipment.Lines, models.ShipmentLine{ItemId: line.ItemId})
+		}
+		quantities[line.ItemId] += line.Quantity
+	}
+	for i := range shipment.Lines {
+		line := &shipment.Lines[i]
+		line.Quantity = quantities[line.ItemId]
+		if line.Quantity > unshipped[line.ItemId] {
+			return uuid.Nil, fmt.Errorf("%w: only %d of item %v can be shipped", models.ErrInvalidShipment, unshipped[line.ItemId], line.ItemId)
+		}
+	}
+	return usecase.store.CreateShipment(ctx, shipment)
+}
+
+// GetShipment returns the shipment by id
+func (usecase *ShipmentUsecase) GetShipment(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetShipment() with args: ctx, id: %v", id)
+	return usecase.store.GetShipment(ctx, id)
+}
+
+// GetOrderShipments returns shipments of the order
+func (usecase *ShipmentUsecase) GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetOrderShipments() with args: ctx, orderId: %v", orderId)
+	return usecase.store.GetOrderShipments(ctx, orderId)
+}
+
+// GetUserOrderShipments returns shipments of the order of the user,
+// orders of other users are not found
+func (usecase *ShipmentUsecase) GetUserOrderShipments(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) ([]models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetUserOrderShipments() with args: ctx, userId: %v, orderId: %v", userId, orderId)
+	order, err := usecase.orderStore.GetOrderByID(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if order.User.ID != userId {
+		return nil, models.ErrorNotFound{}
+	}
+	return usecase.store.GetOrderShipments(ctx, orderId)
+}
+
+// Ship hands the pending shipment to the carrier and saves its tracking number
+func (usecase *ShipmentUsecase) Ship(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Ship() with args: ctx, id: %v", id)
+	shipment, err := usecase.store.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Status != models.ShipmentPending {
+		return nil, fmt.Errorf("%w: shipment is %s", models.ErrShipmentNotAllowed, shipment.Status)
+	}
+	if shipment.Carrier != usecase.carrier.Name() {
+		return nil, fmt.Errorf("%w: shipment is sent by %s", models.ErrShipmentNotAllowed, shipment.Carrier)
+	}
+	shipment.TrackingNumber, err = usecase.carrier.Register(ctx, shipment)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrCarrier, err)
+	}
+	return shipment, usecase.applyStatus(ctx, shipment, models.ShipmentShipped, time.Now())
+}
+
+// Track updates status of the shipment on the way from the carrier
+func (usecase *ShipmentUsecase) Track(ctx context.Context, id uuid.UUID) (*models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase Track() with args: ctx, id: %v", id)
+	shipment, err := usecase.store.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !shipment.Tracked() {
+		return nil, fmt.Errorf("%w: shipment is %s", models.ErrShipmentNotAllowed, shipment.Status)
+	}
+	if shipment.Carrier != usecase.carrier.Name() {
+		return nil, fmt.Errorf("%w: shipment is sent by %s", models.ErrShipmentNotAllowed, shipment.Carrier)
+	}
+	return shipment, usecase.track(ctx, shipment)
+}
+
+// TrackShipments updates statuses of all shipments of the carrier which are
+// on the way, the number of changed shipments is returned. Errors of single
+// shipments are logged, so other shipments are still updated
+func (usecase *ShipmentUsecase) TrackShipments(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase TrackShipments()")
+	shipments, err := usecase.store.GetTrackedShipments(ctx, usecase.carrier.Name())
+	if err != nil {
+		return 0, fmt.Errorf("error on get tracked shipments: %w", err)
+	}
+	changed := 0
+	for i := range shipments {
+		status := shipments[i].Status
+		if err = usecase.track(ctx, &shipments[i]); err != nil {
+			usecase.logger.Sugar().Errorf("error on track shipment %v: %v", shipments[i].Id, err)
+			continue
+		}
+		if shipments[i].Status != status {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// SetStatus changes status of the shipment handed to the carrier by hand,
+// e.g. when the carrier doesn't report failed delivery
+func (usecase *ShipmentUsecase) SetStatus(ctx context.Context, id uuid.UUID, status models.ShipmentStatus) (*models.Shipment, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetStatus() with args: ctx, id: %v, status: %s", id, status)
+	shipment, err := usecase.store.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Status == models.ShipmentPending {
+		return nil, fmt.Errorf("%w: shipment isn't handed to the carrier", models.ErrShipmentNotAllowed)
+	}
+	return shipment, usecase.applyStatus(ctx, shipment, status, time.Now())
+}
+
+// DeleteShipment deletes the pending shipment, its lines can be shipped again
+func (usecase *ShipmentUsecase) DeleteShipment(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteShipment() with args: ctx, id: %v", id)
+	if _, err := usecase.store.GetShipment(ctx, id); err != nil {
+		return err
+	}
+	return usecase.store.DeleteShipment(ctx, id)
+}
+
+// track applies the status reported by the carrier to the shipment
+func (usecase *ShipmentUsecase) track(ctx context.Context, shipment *models.Shipment) error {
+	tracking, err := usecase.carrier.Track(ctx, shipment.TrackingNumber)
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrCarrier, err)
+	}
+	return usecase.applyStatus(ctx, shipment, tracking.Status, tracking.UpdatedAt)
+}
+
+// applyStatus changes status of the shipment and saves it with status
+// of the order derived from all its shipments
+func (usecase *ShipmentUsecase) applyStatus(ctx context.Context, shipment *models.Shipment, status models.ShipmentStatus, at time.Time) error {
+	changed, err := shipment.Apply(status, at)
+	if err != nil || !changed {
+		return err
+	}
+	order, err := usecase.orderStore.GetOrderByID(ctx, shipment.OrderId)
+	if err != nil {
+		return fmt.Errorf("error on get order of shipment: %w", err)
+	}
+	shipments, err := usecase.store.GetOrderShipments(ctx, shipment.OrderId)
+	if err != nil {
+		return fmt.Errorf("error on get shipments of order: %w", err)
+	}
+	for i := range shipments {
+		if shipments[i].Id == shipment.Id {
+			shipments[i] = *shipment
+		}
+	}
+	orderStatus, changed := order.ShipmentsStatus(shipments)
+	if !changed {
+		orderStatus = ""
+	}
+	return usecase.store.UpdateShipment(ctx, shipment, orderStatus)
+}
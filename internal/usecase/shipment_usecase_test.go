@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/carrier"
+	carrierMocks "OnlineShopBackend/internal/carrier/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateShipment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	fakeCarrier := carrierMocks.NewMockCarrier(ctrl)
+	fakeCarrier.EXPECT().Name().Return("fake").AnyTimes()
+	usecase := NewShipmentUsecase(shipmentRepo, orderRepo, paymentRepo, fakeCarrier, zap.L())
+	first, second := uuid.New(), uuid.New()
+	order := models.Order{ID: uuid.New(), Status: models.StatusPaid, Items: []models.ItemWithQuantity{
+		{Item: models.Item{Id: first, Title: "First"}, Quantity: 3},
+		{Item: models.Item{Id: second, Title: "Second"}, Quantity: 1},
+	}}
+	// Lines of failed shipments can be sent again
+	existing := []models.Shipment{
+		{OrderId: order.ID, Status: models.ShipmentShipped, Lines: []models.ShipmentLine{{ItemId: first, Quantity: 1}}},
+		{OrderId: order.ID, Status: models.ShipmentFailed, Lines: []models.ShipmentLine{{ItemId: second, Quantity: 1}}},
+	}
+
+	captured := []models.Payment{{OrderId: order.ID, Status: models.PaymentFailed}, {OrderId: order.ID, Status: models.PaymentCaptured}}
+	paymentRepo.EXPECT().GetOrderPayments(ctx, order.ID).Return(captured, nil).AnyTimes()
+
+	// Order isn't shipped without authorized or captured payment, whatever its status is
+	unpaid := order
+	unpaid.ID = uuid.New()
+	orderRepo.EXPECT().GetOrderByID(ctx, unpaid.ID).Return(unpaid, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, unpaid.ID).Return([]models.Payment{{OrderId: unpaid.ID, Status: models.PaymentPending}}, nil)
+	_, err := usecase.CreateShipment(ctx, unpaid.ID, nil)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+
+	refunded := order
+	refunded.ID = uuid.New()
+	orderRepo.EXPECT().GetOrderByID(ctx, refunded.ID).Return(refunded, nil)
+	paymentRepo.EXPECT().GetOrderPayments(ctx, refunded.ID).Return([]models.Payment{{OrderId: refunded.ID, Status: models.PaymentRefunded}}, nil)
+	_, err = usecase.CreateShipment(ctx, refunded.ID, nil)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return(existing, nil)
+	_, err = usecase.CreateShipment(ctx, order.ID, []models.ShipmentLine{{ItemId: first, Quantity: 2}, {ItemId: first, Quantity: 1}})
+	require.ErrorIs(t, err, models.ErrInvalidShipment)
+
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return(existing, nil)
+	_, err = usecase.CreateShipment(ctx, order.ID, []models.ShipmentLine{{ItemId: uuid.New(), Quantity: 1}})
+	require.ErrorIs(t, err, models.ErrInvalidShipment)
+
+	id := uuid.New()
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return(existing, nil)
+	shipmentRepo.EXPECT().CreateShipment(ctx, &models.Shipment{OrderId: order.ID, Carrier: "fake", Status: models.ShipmentPending,
+		Lines: []models.ShipmentLine{{ItemId: second, Quantity: 1}, {ItemId: first, Quantity: 1}}}).Return(id, nil)
+	result, err := usecase.CreateShipment(ctx, order.ID, []models.ShipmentLine{{ItemId: second, Quantity: 1}, {ItemId: first, Quantity: 1}})
+	require.NoError(t, err)
+	require.Equal(t, id, result)
+
+	// Without lines all unsent lines are shipped
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return(existing, nil)
+	shipmentRepo.EXPECT().CreateShipment(ctx, &models.Shipment{OrderId: order.ID, Carrier: "fake", Status: models.ShipmentPending,
+		Lines: []models.ShipmentLine{{ItemId: first, Title: "First", Quantity: 2}, {ItemId: second, Title: "Second", Quantity: 1}}}).Return(id, nil)
+	_, err = usecase.CreateShipment(ctx, order.ID, nil)
+	require.NoError(t, err)
+
+	all := append(existing, models.Shipment{OrderId: order.ID, Status: models.ShipmentPending,
+		Lines: []models.ShipmentLine{{ItemId: first, Quantity: 2}, {ItemId: second, Quantity: 1}}})
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return(all, nil)
+	_, err = usecase.CreateShipment(ctx, order.ID, nil)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+}
+
+func TestShipAndTrack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	fakeCarrier := carrierMocks.NewMockCarrier(ctrl)
+	fakeCarrier.EXPECT().Name().Return("fake").AnyTimes()
+	usecase := NewShipmentUsecase(shipmentRepo, orderRepo, paymentRepo, fakeCarrier, zap.L())
+	first, second := uuid.New(), uuid.New()
+	order := models.Order{ID: uuid.New(), Status: models.StatusPaid, Items: []models.ItemWithQuantity{
+		{Item: models.Item{Id: first}, Quantity: 1},
+		{Item: models.Item{Id: second}, Quantity: 1},
+	}}
+	newShipment := func(itemId uuid.UUID, status models.ShipmentStatus) models.Shipment {
+		return models.Shipment{Id: uuid.New(), OrderId: order.ID, Carrier: "fake", Status: status,
+			Lines: []models.ShipmentLine{{ItemId: itemId, Quantity: 1}}}
+	}
+
+	pending := newShipment(first, models.ShipmentPending)
+	shipmentRepo.EXPECT().GetShipment(ctx, pending.Id).Return(&pending, nil)
+	fakeCarrier.EXPECT().Register(ctx, &pending).Return("", fmt.Errorf("timeout"))
+	_, err := usecase.Ship(ctx, pending.Id)
+	require.ErrorIs(t, err, models.ErrCarrier)
+
+	// The order is picked by courier when the first shipment is sent
+	other := newShipment(second, models.ShipmentPending)
+	shipmentRepo.EXPECT().GetShipment(ctx, pending.Id).Return(&pending, nil)
+	fakeCarrier.EXPECT().Register(ctx, &pending).Return("FAKE-1", nil)
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return([]models.Shipment{pending, other}, nil)
+	shipmentRepo.EXPECT().UpdateShipment(ctx, gomock.Any(), models.StatusCourier).Return(nil)
+	shipped, err := usecase.Ship(ctx, pending.Id)
+	require.NoError(t, err)
+	require.Equal(t, models.ShipmentShipped, shipped.Status)
+	require.Equal(t, "FAKE-1", shipped.TrackingNumber)
+	require.False(t, shipped.ShippedAt.IsZero())
+
+	shipmentRepo.EXPECT().GetShipment(ctx, shipped.Id).Return(shipped, nil)
+	_, err = usecase.Ship(ctx, shipped.Id)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+
+	// The order isn't delivered while other lines are not delivered
+	order.Status = models.StatusCourier
+	shipmentRepo.EXPECT().GetShipment(ctx, shipped.Id).Return(shipped, nil)
+	fakeCarrier.EXPECT().Track(ctx, "FAKE-1").Return(&carrier.Tracking{Status: models.ShipmentDelivered, UpdatedAt: time.Now()}, nil)
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return([]models.Shipment{*shipped, other}, nil)
+	shipmentRepo.EXPECT().UpdateShipment(ctx, gomock.Any(), models.Status("")).Return(nil)
+	delivered, err := usecase.Track(ctx, shipped.Id)
+	require.NoError(t, err)
+	require.Equal(t, models.ShipmentDelivered, delivered.Status)
+
+	shipmentRepo.EXPECT().GetShipment(ctx, delivered.Id).Return(delivered, nil)
+	_, err = usecase.Track(ctx, delivered.Id)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+}
+
+func TestTrackShipments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	paymentRepo := mocks.NewMockPaymentStore(ctrl)
+	fakeCarrier := carrierMocks.NewMockCarrier(ctrl)
+	fakeCarrier.EXPECT().Name().Return("fake").AnyTimes()
+	usecase := NewShipmentUsecase(shipmentRepo, orderRepo, paymentRepo, fakeCarrier, zap.L())
+	itemId := uuid.New()
+	order := models.Order{ID: uuid.New(), Status: models.StatusCourier, Items: []models.ItemWithQuantity{
+		{Item: models.Item{Id: itemId}, Quantity: 2},
+	}}
+	delivered := models.Shipment{Id: uuid.New(), OrderId: order.ID, Carrier: "fake", Status: models.ShipmentDelivered,
+		Lines: []models.ShipmentLine{{ItemId: itemId, Quantity: 1}}}
+	tracked := []models.Shipment{
+		{Id: uuid.New(), OrderId: order.ID, Carrier: "fake", TrackingNumber: "FAKE-1", Status: models.ShipmentInTransit,
+			Lines: []models.ShipmentLine{{ItemId: itemId, Quantity: 1}}},
+		{Id: uuid.New(), OrderId: uuid.New(), Carrier: "fake", TrackingNumber: "FAKE-2", Status: models.ShipmentShipped},
+		{Id: uuid.New(), OrderId: uuid.New(), Carrier: "fake", TrackingNumber: "FAKE-3", Status: models.ShipmentShipped},
+	}
+
+	shipmentRepo.EXPECT().GetTrackedShipments(ctx, "fake").Return(tracked, nil)
+	// The order is delivered with the last line
+	fakeCarrier.EXPECT().Track(ctx, "FAKE-1").Return(&carrier.Tracking{Status: models.ShipmentDelivered, UpdatedAt: time.Now()}, nil)
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return([]models.Shipment{delivered, tracked[0]}, nil)
+	shipmentRepo.EXPECT().UpdateShipment(ctx, gomock.Any(), models.StatusShipped).Return(nil)
+	// Errors of one shipment don't stop tracking of others
+	fakeCarrier.EXPECT().Track(ctx, "FAKE-2").Return(nil, fmt.Errorf("timeout"))
+	fakeCarrier.EXPECT().Track(ctx, "FAKE-3").Return(&carrier.Tracking{Status: models.ShipmentShipped}, nil)
+	changed, err := usecase.TrackShipments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, changed)
+}
+
+func TestSetShipmentStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shipmentRepo := mocks.NewMockShipmentStore(ctrl)
+	orderRepo := mocks.NewMockOrderStore(ctrl)
+	usecase := NewShipmentUsecase(shipmentRepo, orderRepo, nil, nil, zap.L())
+	order := models.Order{ID: uuid.New(), Status: models.StatusCourier}
+
+	pending := &models.Shipment{Id: uuid.New(), OrderId: order.ID, Status: models.ShipmentPending}
+	shipmentRepo.EXPECT().GetShipment(ctx, pending.Id).Return(pending, nil)
+	_, err := usecase.SetStatus(ctx, pending.Id, models.ShipmentFailed)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+
+	delivered := &models.Shipment{Id: uuid.New(), OrderId: order.ID, Status: models.ShipmentDelivered}
+	shipmentRepo.EXPECT().GetShipment(ctx, delivered.Id).Return(delivered, nil)
+	_, err = usecase.SetStatus(ctx, delivered.Id, models.ShipmentFailed)
+	require.ErrorIs(t, err, models.ErrShipmentNotAllowed)
+
+	shipped := &models.Shipment{Id: uuid.New(), OrderId: order.ID, Status: models.ShipmentShipped}
+	shipmentRepo.EXPECT().GetShipment(ctx, shipped.Id).Return(shipped, nil)
+	_, err = usecase.SetStatus(ctx, shipped.Id, models.ShipmentPending)
+	require.ErrorIs(t, err, models.ErrInvalidShipment)
+
+	// Status of the order is kept without shipments on the way
+	shipmentRepo.EXPECT().GetShipment(ctx, shipped.Id).Return(shipped, nil)
+	orderRepo.EXPECT().GetOrderByID(ctx, order.ID).Return(order, nil)
+	shipmentRepo.EXPECT().GetOrderShipments(ctx, order.ID).Return([]models.Shipment{*shipped}, nil)
+	shipmentRepo.EXPECT().UpdateShipment(ctx, gomock.Any(), models.Status("")).Return(nil)
+	result, err := usecase.SetStatus(ctx, shipped.Id, models.ShipmentFailed)
+	require.NoError(t, err)
+	require.Equal(t, models.ShipmentFailed, result.Status)
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IShippingUsecase = &ShippingUsecase{}
+
+type ShippingUsecase struct {
+	store  repository.ShippingStore
+	logger *zap.Logger
+}
+
+func NewShippingUsecase(store repository.ShippingStore, logger *zap.Logger) IShippingUsecase {
+	logger.Debug("Enter in usecase NewShippingUsecase()")
+	return &ShippingUsecase{store: store, logger: logger}
+}
+
+// CreateShippingMethod checks settings of the shipping method and saves it
+func (usecase *ShippingUsecase) CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateShippingMethod() with args: ctx, method: %v", method)
+	method.Normalize()
+	err := method.Validate()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := usecase.store.CreateShippingMethod(ctx, method)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on create shipping method: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateShippingMethod checks settings of the shipping method and updates it
+func (usecase *ShippingUsecase) UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase UpdateShippingMethod() with args: ctx, method: %v", method)
+	method.Normalize()
+	err := method.Validate()
+	if err != nil {
+		return err
+	}
+	return usecase.store.UpdateShippingMethod(ctx, method)
+}
+
+// GetShippingMethods returns all shipping methods
+func (usecase *ShippingUsecase) GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error) {
+	usecase.logger.Debug("Enter in usecase GetShippingMethods()")
+	return usecase.store.GetShippingMethods(ctx)
+}
+
+// DeleteShippingMethod deletes shipping method by id
+func (usecase *ShippingUsecase) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteShippingMethod() with args: ctx, id: %v", id)
+	return usecase.store.DeleteShippingMethod(ctx, id)
+}
+
+// SetItemWeight sets weight of the item in grams
+func (usecase *ShippingUsecase) SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetItemWeight() with args: ctx, itemId: %v, weight: %d", itemId, weight)
+	if weight < 0 {
+		return fmt.Errorf("%w: weight can't be negative", models.ErrInvalidShippingMethod)
+	}
+	return usecase.store.SetItemWeight(ctx, itemId, weight)
+}
+
+// QuoteShipping returns methods which can deliver the priced cart to the
+// address with prices and estimated dates of delivery
+func (usecase *ShippingUsecase) QuoteShipping(ctx context.Context, cart *models.Cart, address models.UserAddress) ([]models.ShippingQuote, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase QuoteShipping() with args: ctx, cartId: %v, address: %v", cart.Id, address)
+	methods, err := usecase.store.GetActiveShippingMethods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error on get shipping methods: %w", err)
+	}
+	if len(methods) == 0 {
+		return []models.ShippingQuote{}, nil
+	}
+	weight, err := itemsWeight(ctx, usecase.store, cart.Items)
+	if err != nil {
+		return nil, err
+	}
+	return models.ShippingQuotes(methods, weight, cart.Pricing.Discounted(), address, time.Now()), nil
+}
+
+// itemsWeight returns weight of the items in grams
+func itemsWeight(ctx context.Context, store repository.ShippingStore, items []models.ItemWithQuantity) (int64, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+	weights, err := store.GetItemWeights(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("error on get weights of items: %w", err)
+	}
+	return models.Weight(items, weights), nil
+}
+
+// shippingQuote returns price and estimated dates of delivery of the items
+// with the given total by the method to the address
+func shippingQuote(ctx context.Context, store repository.ShippingStore, methodId uuid.UUID, items []models.ItemWithQuantity, total int64, address models.UserAddress, now time.Time) (*models.ShippingQuote, error) {
+	method, err := store.GetShippingMethod(ctx, methodId)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		return nil, fmt.Errorf("%w: method %v not found", models.ErrShippingUnavailable, methodId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error on get shipping method: %w", err)
+	}
+	weight, err := itemsWeight(ctx, store, items)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := method.Quote(weight, total, address, now)
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
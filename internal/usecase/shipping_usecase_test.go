@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateShippingMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shippingRepo := mocks.NewMockShippingStore(ctrl)
+	usecase := NewShippingUsecase(shippingRepo, zap.L())
+
+	_, err := usecase.CreateShippingMethod(ctx, &models.ShippingMethod{Name: "Courier", Kind: "drone"})
+	require.ErrorIs(t, err, models.ErrInvalidShippingMethod)
+	_, err = usecase.CreateShippingMethod(ctx, &models.ShippingMethod{Name: "Courier", Kind: models.ShippingCourier,
+		MinDays: 3, MaxDays: 1})
+	require.ErrorIs(t, err, models.ErrInvalidShippingMethod)
+
+	id := uuid.New()
+	shippingRepo.EXPECT().CreateShippingMethod(ctx, &models.ShippingMethod{Name: "Courier", Kind: models.ShippingCourier,
+		Price: 300, MaxDays: 2, Zones: []models.ShippingZone{{Country: "RUSSIA", Region: "MOSCOW"}}}).Return(id, nil)
+	result, err := usecase.CreateShippingMethod(ctx, &models.ShippingMethod{Name: "Courier", Kind: models.ShippingCourier,
+		Price: 300, MaxDays: 2, Zones: []models.ShippingZone{{Country: " russia", Region: "Moscow"}}})
+	require.NoError(t, err)
+	require.Equal(t, id, result)
+}
+
+func TestQuoteShipping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	shippingRepo := mocks.NewMockShippingStore(ctrl)
+	usecase := NewShippingUsecase(shippingRepo, zap.L())
+	itemId := uuid.New()
+	cart := &models.Cart{
+		Id:      uuid.New(),
+		Items:   []models.ItemWithQuantity{{Item: models.Item{Id: itemId, Price: 1000}, Quantity: 3}},
+		Pricing: models.Pricing{Currency: "RUB", Subtotal: 3000, Total: 3000},
+	}
+	address := models.UserAddress{Country: "Russia", City: "Kazan"}
+	methods := []models.ShippingMethod{
+		{Id: uuid.New(), Name: "Express", Kind: models.ShippingExpress, Price: 500, MaxDays: 1, Active: true},
+		{Id: uuid.New(), Name: "Courier", Kind: models.ShippingCourier, Price: 500, MinDays: 1, MaxDays: 3, Active: true},
+		{Id: uuid.New(), Name: "Pickup", Kind: models.ShippingPickup, Price: 100, FreeFrom: 3000, MaxDays: 5, Active: true},
+		{Id: uuid.New(), Name: "Moscow", Kind: models.ShippingCourier, Price: 100, Active: true,
+			Zones: []models.ShippingZone{{Country: "RUSSIA", Region: "MOSCOW"}}},
+		{Id: uuid.New(), Name: "Post", Kind: models.ShippingStandard, PricePerKg: 100, MaxWeight: 2000, Active: true},
+	}
+
+	shippingRepo.EXPECT().GetActiveShippingMethods(ctx).Return(methods, nil)
+	shippingRepo.EXPECT().GetItemWeights(ctx, []uuid.UUID{itemId}).Return(map[uuid.UUID]int64{itemId: 700}, nil)
+	quotes, err := usecase.QuoteShipping(ctx, cart, address)
+	require.NoError(t, err)
+	// Delivery to Moscow only and too heavy post are skipped,
+	// methods with the same price are ordered by date of delivery
+	require.Len(t, quotes, 3)
+	require.Equal(t, "Pickup", quotes[0].Method.Name)
+	require.Equal(t, int64(0), quotes[0].Price)
+	require.Equal(t, "Express", quotes[1].Method.Name)
+	require.Equal(t, "Courier", quotes[2].Method.Name)
+	require.Equal(t, int64(500), quotes[2].Price)
+
+	shippingRepo.EXPECT().GetActiveShippingMethods(ctx).Return([]models.ShippingMethod{}, nil)
+	quotes, err = usecase.QuoteShipping(ctx, cart, address)
+	require.NoError(t, err)
+	require.Empty(t, quotes)
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/imaging"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/repository/cash"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ IStorageUsecase = &StorageUsecase{}
+
+type StorageUsecase struct {
+	store       repository.ImageStore
+	filestorage filestorage.FileStorager
+	cash        cash.ITagsCash
+	// gracePeriod protects new files which are put in the storage,
+	// but not yet referenced by the item or category
+	gracePeriod time.Duration
+	logger      *zap.Logger
+}
+
+func NewStorageUsecase(store repository.ImageStore, filestorage filestorage.FileStorager, cash cash.ITagsCash, gracePeriod time.Duration, logger *zap.Logger) IStorageUsecase {
+	logger.Debug("Enter in usecase NewStorageUsecase()")
+	return &StorageUsecase{store: store, filestorage: filestorage, cash: cash, gracePeriod: gracePeriod, logger: logger}
+}
+
+// CheckStorage compares files of the storage with references to images in
+// the database. It reports files not referenced by any item or category and
+// references to missing files. Without dry run orphaned files are deleted
+// and dangling references are removed
+func (usecase *StorageUsecase) CheckStorage(ctx context.Context, dryRun bool) (*models.StorageReport, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CheckStorage() with args: ctx, dryRun: %t", dryRun)
+	now := time.Now()
+	// References are read before files, because the file is always put before
+	// the reference is saved, so every read reference has its file listed
+	refs, err := usecase.store.GetImageRefs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error on get image references: %w", err)
+	}
+	files, err := usecase.filestorage.ListFiles()
+	if err != nil {
+		return nil, fmt.Errorf("error on list files: %w", err)
+	}
+	report := &models.StorageReport{
+		DryRun:       dryRun,
+		CheckedAt:    now.UTC(),
+		Files:        len(files),
+		Refs:         len(refs),
+		OrphanFiles:  make([]string, 0),
+		DanglingRefs: make([]models.ImageRef, 0),
+		Errors:       make([]string, 0),
+	}
+
+	stored := make(map[string]bool, len(files))
+	for _, file := range files {
+		stored[file.Name] = true
+	}
+	referenced := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		name, ok := usecase.filestorage.FileName(ref.URL)
+		if !ok {
+			// Images of other hosts are not checked
+			continue
+		}
+		// Reference to the large variant keeps all variants of the image
+		referenced[name] = true
+		dir := path.Dir(name)
+		for _, variant := range imaging.FileNames(path.Base(name)) {
+			referenced[path.Join(dir, variant)] = true
+		}
+		if !stored[name] {
+			report.DanglingRefs = append(report.DanglingRefs, ref)
+		}
+	}
+	for _, file := range files {
+		if !referenced[file.Name] && now.Sub(file.ModTime) > usecase.gracePeriod {
+			report.OrphanFiles = append(report.OrphanFiles, file.Name)
+		}
+	}
+	sort.Strings(report.OrphanFiles)
+
+	if !dryRun {
+		usecase.deleteOrphanFiles(report)
+		usecase.removeDanglingRefs(ctx, report)
+	}
+	usecase.logger.Sugar().Infof("Storage checked: %d files, %d references, %d orphaned files, %d dangling references, dry run: %t",
+		report.Files, report.Refs, len(report.OrphanFiles), len(report.DanglingRefs), dryRun)
+	return report, nil
+}
+
+// deleteOrphanFiles deletes orphaned files of the report
+func (usecase *StorageUsecase) deleteOrphanFiles(report *models.StorageReport) {
+	for _, name := range report.OrphanFiles {
+		err := usecase.filestorage.DeleteFile(name)
+		if err != nil {
+			usecase.logger.Sugar().Errorf("error on delete orphaned file %s: %v", name, err)
+			report.Errors = append(report.Errors, fmt.Sprintf("error on delete file %s: %v", name, err))
+			continue
+		}
+		report.DeletedFiles++
+	}
+}
+
+// removeDanglingRefs removes dangling references of the report and
+// invalidates cached lists with their items and categories
+func (usecase *StorageUsecase) removeDanglingRefs(ctx context.Context, report *models.StorageReport) {
+	if len(report.DanglingRefs) == 0 {
+		return
+	}
+	// Empty storage more likely is misconfigured than lost all files
+	if report.Files == 0 {
+		usecase.logger.Error("storage has no files, dangling references are not removed")
+		report.Errors = append(report.Errors, "storage has no files, dangling references are not removed")
+		return
+	}
+	tags := make(map[string]bool)
+	for _, ref := range report.DanglingRefs {
+		err := usecase.store.DeleteImageRef(ctx, ref)
+		if err != nil {
+			usecase.logger.Sugar().Errorf("error on remove image reference %s: %v", ref.URL, err)
+			report.Errors = append(report.Errors, fmt.Sprintf("error on remove reference %s of %s %v: %v", ref.URL, ref.Kind, ref.OwnerId, err))
+			continue
+		}
+		report.RemovedRefs++
+		// Items lists contain data of their categories
+		tags[cash.TagItems] = true
+		tags[cash.CategoryTag(ref.Category)] = true
+		if ref.Kind == models.UploadKindCategory {
+			tags[cash.TagCategories] = true
+		}
+	}
+	if len(tags) == 0 {
+		return
+	}
+	invalidated := make([]string, 0, len(tags))
+	for tag := range tags {
+		invalidated = append(invalidated, tag)
+	}
+	err := usecase.cash.InvalidateTags(ctx, invalidated...)
+	if err != nil {
+		usecase.logger.Sugar().Errorf("error on invalidate cash tags: %v, error: %v", invalidated, err)
+	}
+}
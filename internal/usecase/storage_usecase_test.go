@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	storage "OnlineShopBackend/internal/filestorage"
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testFilesURL = "http://localhost:8000/files/"
+
+func testStoredFiles() []storage.StoredFile {
+	old := time.Now().Add(-2 * time.Hour)
+	return []storage.StoredFile{
+		{Name: "items/1/20230101120000_thumb.jpeg", ModTime: old},
+		{Name: "items/1/20230101120000_medium.jpeg", ModTime: old},
+		{Name: "items/1/20230101120000_large.jpeg", ModTime: old},
+		// WebP isn't made for JPEG images anymore
+		{Name: "items/1/20230101120000_large.webp", ModTime: old},
+		{Name: "items/1/old.jpeg", ModTime: old},
+		// Image of the item is being uploaded now
+		{Name: "items/1/20230101130000_large.jpeg", ModTime: time.Now()},
+		{Name: "categories/2/20230101120000_large.png", ModTime: old},
+	}
+}
+
+func testImageRefs() []models.ImageRef {
+	return []models.ImageRef{
+		{Kind: models.UploadKindItem, OwnerId: testId, Category: "Tools", URL: testFilesURL + "items/1/20230101120000_large.jpeg"},
+		{Kind: models.UploadKindItem, OwnerId: testId, Category: "Tools", URL: testFilesURL + "items/1/missing.jpeg"},
+		{Kind: models.UploadKindItem, OwnerId: testId, Category: "Tools", URL: "https://example.com/image.jpeg"},
+		{Kind: models.UploadKindCategory, OwnerId: testId, Category: "Tools", URL: testFilesURL + "categories/3/20230101120000_large.png"},
+	}
+}
+
+func expectFileName(filestorage *fs.MockFileStorager) {
+	filestorage.EXPECT().FileName(gomock.Any()).DoAndReturn(func(fileURL string) (string, bool) {
+		if !strings.HasPrefix(fileURL, testFilesURL) {
+			return "", false
+		}
+		return strings.TrimPrefix(fileURL, testFilesURL), true
+	}).AnyTimes()
+}
+
+func TestCheckStorageDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	imageRepo := mocks.NewMockImageStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	tagsCash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewStorageUsecase(imageRepo, filestorage, tagsCash, time.Hour, zap.L())
+	expectFileName(filestorage)
+
+	imageRepo.EXPECT().GetImageRefs(ctx).Return(nil, fmt.Errorf("error"))
+	_, err := usecase.CheckStorage(ctx, true)
+	require.Error(t, err)
+
+	imageRepo.EXPECT().GetImageRefs(ctx).Return(testImageRefs(), nil)
+	filestorage.EXPECT().ListFiles().Return(nil, fmt.Errorf("error"))
+	_, err = usecase.CheckStorage(ctx, true)
+	require.Error(t, err)
+
+	imageRepo.EXPECT().GetImageRefs(ctx).Return(testImageRefs(), nil)
+	filestorage.EXPECT().ListFiles().Return(testStoredFiles(), nil)
+	report, err := usecase.CheckStorage(ctx, true)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 7, report.Files)
+	require.Equal(t, 4, report.Refs)
+	require.Equal(t, []string{"categories/2/20230101120000_large.png", "items/1/20230101120000_large.webp", "items/1/old.jpeg"}, report.OrphanFiles)
+	require.Equal(t, []models.ImageRef{testImageRefs()[1], testImageRefs()[3]}, report.DanglingRefs)
+	require.Zero(t, report.DeletedFiles)
+	require.Zero(t, report.RemovedRefs)
+}
+
+func TestCheckStorage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	imageRepo := mocks.NewMockImageStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	tagsCash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewStorageUsecase(imageRepo, filestorage, tagsCash, time.Hour, zap.L())
+	expectFileName(filestorage)
+
+	imageRepo.EXPECT().GetImageRefs(ctx).Return(testImageRefs(), nil)
+	filestorage.EXPECT().ListFiles().Return(testStoredFiles(), nil)
+	filestorage.EXPECT().DeleteFile("categories/2/20230101120000_large.png").Return(fmt.Errorf("error"))
+	filestorage.EXPECT().DeleteFile("items/1/20230101120000_large.webp").Return(nil)
+	filestorage.EXPECT().DeleteFile("items/1/old.jpeg").Return(nil)
+	imageRepo.EXPECT().DeleteImageRef(ctx, testImageRefs()[1]).Return(nil)
+	imageRepo.EXPECT().DeleteImageRef(ctx, testImageRefs()[3]).Return(nil)
+	tagsCash.EXPECT().InvalidateTags(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, tags ...string) error {
+		require.ElementsMatch(t, []string{cash.TagItems, cash.TagCategories, cash.CategoryTag("Tools")}, tags)
+		return nil
+	})
+	report, err := usecase.CheckStorage(ctx, false)
+	require.NoError(t, err)
+	require.False(t, report.DryRun)
+	require.Equal(t, 2, report.DeletedFiles)
+	require.Equal(t, 2, report.RemovedRefs)
+	require.Len(t, report.Errors, 1)
+
+	// References are kept if the storage looks misconfigured
+	imageRepo.EXPECT().GetImageRefs(ctx).Return(testImageRefs(), nil)
+	filestorage.EXPECT().ListFiles().Return([]storage.StoredFile{}, nil)
+	report, err = usecase.CheckStorage(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, report.DanglingRefs, 3)
+	require.Zero(t, report.RemovedRefs)
+	require.Len(t, report.Errors, 1)
+
+	// Nothing to do
+	imageRepo.EXPECT().GetImageRefs(ctx).Return([]models.ImageRef{
+		{Kind: models.UploadKindCategory, OwnerId: uuid.New(), URL: testFilesURL + "categories/2/20230101120000_large.png"},
+	}, nil)
+	filestorage.EXPECT().ListFiles().Return(testStoredFiles()[6:], nil)
+	report, err = usecase.CheckStorage(ctx, false)
+	require.NoError(t, err)
+	require.Empty(t, report.OrphanFiles)
+	require.Empty(t, report.DanglingRefs)
+	require.Empty(t, report.Errors)
+}
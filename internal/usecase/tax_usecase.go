@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ ITaxUsecase = &TaxUsecase{}
+
+type TaxUsecase struct {
+	store  repository.TaxStore
+	logger *zap.Logger
+}
+
+func NewTaxUsecase(store repository.TaxStore, logger *zap.Logger) ITaxUsecase {
+	logger.Debug("Enter in usecase NewTaxUsecase()")
+	return &TaxUsecase{store: store, logger: logger}
+}
+
+// CreateTaxRule checks settings of the tax rule and saves it
+func (usecase *TaxUsecase) CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateTaxRule() with args: ctx, rule: %v", rule)
+	rule.Normalize()
+	err := rule.Validate()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := usecase.store.CreateTaxRule(ctx, rule)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error on create tax rule: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateTaxRule checks settings of the tax rule and updates it
+func (usecase *TaxUsecase) UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase UpdateTaxRule() with args: ctx, rule: %v", rule)
+	rule.Normalize()
+	err := rule.Validate()
+	if err != nil {
+		return err
+	}
+	return usecase.store.UpdateTaxRule(ctx, rule)
+}
+
+// GetTaxRules returns all tax rules
+func (usecase *TaxUsecase) GetTaxRules(ctx context.Context) ([]models.TaxRule, error) {
+	usecase.logger.Debug("Enter in usecase GetTaxRules()")
+	return usecase.store.GetTaxRules(ctx)
+}
+
+// DeleteTaxRule deletes tax rule by id
+func (usecase *TaxUsecase) DeleteTaxRule(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteTaxRule() with args: ctx, id: %v", id)
+	return usecase.store.DeleteTaxRule(ctx, id)
+}
+
+// SetItemTaxClass sets tax class of the item, empty class resets
+// the item to the class of its category
+func (usecase *TaxUsecase) SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetItemTaxClass() with args: ctx, itemId: %v, class: %s", itemId, class)
+	return usecase.store.SetItemTaxClass(ctx, itemId, models.NormalizeTaxClass(class))
+}
+
+// SetCategoryTaxClass sets tax class of the category, empty class resets
+// the category to the standard class
+func (usecase *TaxUsecase) SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase SetCategoryTaxClass() with args: ctx, categoryId: %v, class: %s", categoryId, class)
+	return usecase.store.SetCategoryTaxClass(ctx, categoryId, models.NormalizeTaxClass(class))
+}
+
+// applyTaxes adds taxes of the items shipped to the address to the pricing,
+// prices of the items must be in the currency of the pricing. Without
+// country of the address or rules of the country nothing is taxed
+func applyTaxes(ctx context.Context, store repository.TaxStore, pricing *models.Pricing, items []models.ItemWithQuantity, address models.UserAddress) error {
+	pricing.Taxes = nil
+	country := models.NormalizePlace(address.Country)
+	if country == "" || len(items) == 0 {
+		return nil
+	}
+	rules, err := store.GetTaxRulesByCountry(ctx, country)
+	if err != nil {
+		return fmt.Errorf("error on get tax rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+	classes, err := store.GetTaxClasses(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("error on get tax classes: %w", err)
+	}
+	models.ApplyTaxes(pricing, items, classes, rules, address)
+	return nil
+}
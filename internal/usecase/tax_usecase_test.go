@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateTaxRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	taxRepo := mocks.NewMockTaxStore(ctrl)
+	usecase := NewTaxUsecase(taxRepo, zap.L())
+	newRule := func() *models.TaxRule {
+		return &models.TaxRule{Name: "VAT", Country: " russia", Region: "moscow ", Rate: 2000, Inclusive: true}
+	}
+
+	invalid := []func(rule *models.TaxRule){
+		func(rule *models.TaxRule) { rule.Name = " " },
+		func(rule *models.TaxRule) { rule.Country = "" },
+		func(rule *models.TaxRule) { rule.Rate = -1 },
+		func(rule *models.TaxRule) { rule.Rate = models.MaxTaxRate + 1 },
+	}
+	for _, change := range invalid {
+		rule := newRule()
+		change(rule)
+		_, err := usecase.CreateTaxRule(ctx, rule)
+		require.ErrorIs(t, err, models.ErrInvalidTaxRule)
+	}
+
+	taxRepo.EXPECT().CreateTaxRule(ctx, gomock.Any()).Return(uuid.Nil, fmt.Errorf("error"))
+	_, err := usecase.CreateTaxRule(ctx, newRule())
+	require.Error(t, err)
+
+	taxRepo.EXPECT().CreateTaxRule(ctx, &models.TaxRule{
+		Name:      "VAT",
+		Country:   "RUSSIA",
+		Region:    "MOSCOW",
+		TaxClass:  models.TaxClassStandard,
+		Rate:      2000,
+		Inclusive: true,
+	}).Return(testId, nil)
+	id, err := usecase.CreateTaxRule(ctx, newRule())
+	require.NoError(t, err)
+	require.Equal(t, testId, id)
+}
+
+func TestSetItemTaxClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	taxRepo := mocks.NewMockTaxStore(ctrl)
+	usecase := NewTaxUsecase(taxRepo, zap.L())
+
+	taxRepo.EXPECT().SetItemTaxClass(ctx, testId, "reduced").Return(nil)
+	require.NoError(t, usecase.SetItemTaxClass(ctx, testId, " Reduced"))
+	// Empty class resets the item to the class of its category
+	taxRepo.EXPECT().SetItemTaxClass(ctx, testId, "").Return(models.ErrorNotFound{})
+	require.ErrorIs(t, usecase.SetItemTaxClass(ctx, testId, " "), models.ErrorNotFound{})
+}
+
+func TestApplyTaxesLargeAmounts(t *testing.T) {
+	item := models.ItemWithQuantity{Item: models.Item{Id: uuid.New(), Price: 1_000_000_000_000_000}, Quantity: 1}
+	rules := []models.TaxRule{
+		{Id: uuid.New(), Name: "VAT", Country: "RUSSIA", TaxClass: models.TaxClassStandard, Rate: 2000, Inclusive: true},
+		{Id: uuid.New(), Name: "Sales tax", Country: "RUSSIA", TaxClass: "luxury", Rate: 2000},
+	}
+	address := models.UserAddress{Country: "RUSSIA"}
+
+	// Discounted base of the item doesn't overflow
+	pricing := models.Pricing{Subtotal: item.Price, Total: 900_000_000_000_000}
+	models.ApplyTaxes(&pricing, []models.ItemWithQuantity{item}, nil, rules, address)
+	require.Len(t, pricing.Taxes, 1)
+	require.Equal(t, int64(150_000_000_000_000), pricing.Taxes[0].Amount)
+
+	pricing = models.Pricing{Subtotal: item.Price, Total: 900_000_000_000_000}
+	models.ApplyTaxes(&pricing, []models.ItemWithQuantity{item}, map[uuid.UUID]string{item.Id: "luxury"}, rules, address)
+	require.Len(t, pricing.Taxes, 1)
+	require.Equal(t, int64(180_000_000_000_000), pricing.Taxes[0].Amount)
+	require.Equal(t, int64(1_080_000_000_000_000), pricing.Total)
+}
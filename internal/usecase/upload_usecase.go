@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/filestorage"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IUploadUsecase = &UploadUsecase{}
+
+type UploadUsecase struct {
+	store       repository.UploadStore
+	filestorage filestorage.FileStorager
+	// ttl is a time for upload and confirmation of the file
+	ttl time.Duration
+	// maxSize limits files uploaded through the service
+	maxSize int64
+	logger  *zap.Logger
+}
+
+func NewUploadUsecase(store repository.UploadStore, filestorage filestorage.FileStorager, ttl time.Duration, maxSize int64, logger *zap.Logger) IUploadUsecase {
+	logger.Debug("Enter in usecase NewUploadUsecase()")
+	return &UploadUsecase{store: store, filestorage: filestorage, ttl: ttl, maxSize: maxSize, logger: logger}
+}
+
+// CreateUpload creates upload session for image of the item or category
+// and returns it with url to upload the file
+func (usecase *UploadUsecase) CreateUpload(ctx context.Context, kind string, ownerId uuid.UUID) (*models.UploadSession, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateUpload() with args: ctx, kind: %s, ownerId: %v", kind, ownerId)
+	if kind != models.UploadKindItem && kind != models.UploadKindCategory {
+		return nil, fmt.Errorf("unknown kind of upload: %q", kind)
+	}
+	session := &models.UploadSession{
+		Kind:      kind,
+		OwnerId:   ownerId,
+		Status:    models.UploadPending,
+		ExpiresAt: time.Now().Add(usecase.ttl).UTC(),
+	}
+	id, err := usecase.store.CreateUpload(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("error on create upload session: %w", err)
+	}
+	session.Id = id
+	session.UploadURL, err = usecase.filestorage.UploadURL(id.String(), usecase.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("error on get upload url: %w", err)
+	}
+	usecase.logger.Sugar().Infof("Upload session %v for %s %v created", id, kind, ownerId)
+	return session, nil
+}
+
+// PutUploadFile writes the file of the session uploaded through the service
+func (usecase *UploadUsecase) PutUploadFile(ctx context.Context, id uuid.UUID, r io.Reader) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase PutUploadFile() with args: ctx, id: %v, r", id)
+	session, err := usecase.store.GetUpload(ctx, id)
+	if err != nil {
+		return err
+	}
+	if session.IsExpired(time.Now()) {
+		return models.ErrUploadExpired
+	}
+	if session.Status != models.UploadPending {
+		return models.ErrUploadCompleted
+	}
+	limited := &limitedReader{r: r, n: usecase.maxSize}
+	err = usecase.filestorage.PutUpload(id.String(), limited)
+	if limited.exceeded {
+		return models.ErrUploadTooLarge
+	}
+	if err != nil {
+		return fmt.Errorf("error on put upload file: %w", err)
+	}
+	err = usecase.store.UpdateUploadStatus(ctx, id, []models.UploadStatus{models.UploadPending}, models.UploadUploaded)
+	if err != nil && !errors.Is(err, models.ErrorNotFound{}) {
+		return fmt.Errorf("error on update upload session: %w", err)
+	}
+	return nil
+}
+
+// StartUpload takes the session for processing and returns reader of its file.
+// Only one request can process the session, others get ErrUploadCompleted
+func (usecase *UploadUsecase) StartUpload(ctx context.Context, id uuid.UUID) (*models.UploadSession, io.ReadCloser, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase StartUpload() with args: ctx, id: %v", id)
+	session, err := usecase.store.GetUpload(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.IsExpired(time.Now()) {
+		return nil, nil, models.ErrUploadExpired
+	}
+	err = usecase.store.UpdateUploadStatus(ctx, id,
+		[]models.UploadStatus{models.UploadPending, models.UploadUploaded}, models.UploadProcessing)
+	if err != nil && errors.Is(err, models.ErrorNotFound{}) {
+		return nil, nil, models.ErrUploadCompleted
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error on update upload session: %w", err)
+	}
+	file, err := usecase.filestorage.GetUpload(id.String())
+	if err != nil {
+		usecase.FailUpload(ctx, id)
+		return nil, nil, fmt.Errorf("error on get upload file: %w", err)
+	}
+	return session, file, nil
+}
+
+// FailUpload returns the session taken for processing to uploaded
+// status, so the confirmation can be repeated
+func (usecase *UploadUsecase) FailUpload(ctx context.Context, id uuid.UUID) {
+	usecase.logger.Sugar().Debugf("Enter in usecase FailUpload() with args: ctx, id: %v", id)
+	err := usecase.store.UpdateUploadStatus(ctx, id, []models.UploadStatus{models.UploadProcessing}, models.UploadUploaded)
+	if err != nil {
+		usecase.logger.Sugar().Errorf("error on return upload session %v: %v", id, err)
+	}
+}
+
+// CompleteUpload marks the session completed and deletes its file
+func (usecase *UploadUsecase) CompleteUpload(ctx context.Context, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase CompleteUpload() with args: ctx, id: %v", id)
+	err := usecase.store.UpdateUploadStatus(ctx, id, []models.UploadStatus{models.UploadProcessing}, models.UploadCompleted)
+	if err != nil {
+		return fmt.Errorf("error on complete upload session: %w", err)
+	}
+	err = usecase.filestorage.DeleteUpload(id.String())
+	if err != nil {
+		// The image is already attached, the file is only garbage
+		usecase.logger.Sugar().Errorf("error on delete upload file %v: %v", id, err)
+	}
+	return nil
+}
+
+// DeleteExpiredUploads deletes expired sessions and their files,
+// it returns the number of deleted sessions
+func (usecase *UploadUsecase) DeleteExpiredUploads(ctx context.Context) (int, error) {
+	usecase.logger.Debug("Enter in usecase DeleteExpiredUploads()")
+	sessions, err := usecase.store.DeleteExpiredUploads(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error on delete expired upload sessions: %w", err)
+	}
+	for _, session := range sessions {
+		if session.Status == models.UploadCompleted {
+			continue
+		}
+		err := usecase.filestorage.DeleteUpload(session.Id.String())
+		if err != nil {
+			usecase.logger.Sugar().Errorf("error on delete upload file %v: %v", session.Id, err)
+		}
+	}
+	if len(sessions) > 0 {
+		usecase.logger.Sugar().Infof("%d expired upload sessions deleted", len(sessions))
+	}
+	return len(sessions), nil
+}
+
+// limitedReader reads at most n bytes and reports if the source is longer
+type limitedReader struct {
+	r        io.Reader
+	n        int64
+	exceeded bool
+}
+
+func (reader *limitedReader) Read(p []byte) (int, error) {
+	if reader.n < 0 {
+		reader.exceeded = true
+		return 0, models.ErrUploadTooLarge
+	}
+	// Read one byte more than allowed to find out that the source is longer
+	if int64(len(p)) > reader.n+1 {
+		p = p[:reader.n+1]
+	}
+	n, err := reader.r.Read(p)
+	reader.n -= int64(n)
+	if reader.n < 0 {
+		reader.exceeded = true
+		return 0, models.ErrUploadTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	fs "OnlineShopBackend/internal/filestorage/mocks"
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/mocks"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testUploadSession(status models.UploadStatus, expiresAt time.Time) *models.UploadSession {
+	return &models.UploadSession{
+		Id:        testId,
+		Kind:      models.UploadKindItem,
+		OwnerId:   testId,
+		Status:    status,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func TestCreateUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	uploadRepo := mocks.NewMockUploadStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	usecase := NewUploadUsecase(uploadRepo, filestorage, 15*time.Minute, 10, logger)
+	ctx := context.Background()
+
+	_, err := usecase.CreateUpload(ctx, "user", testId)
+	require.Error(t, err)
+
+	uploadRepo.EXPECT().CreateUpload(ctx, gomock.Any()).Return(testId, fmt.Errorf("error"))
+	_, err = usecase.CreateUpload(ctx, models.UploadKindItem, testId)
+	require.Error(t, err)
+
+	uploadRepo.EXPECT().CreateUpload(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, session *models.UploadSession) (uuid.UUID, error) {
+			require.Equal(t, models.UploadPending, session.Status)
+			require.WithinDuration(t, time.Now().Add(15*time.Minute), session.ExpiresAt, time.Minute)
+			return testId, nil
+		})
+	filestorage.EXPECT().UploadURL(testId.String(), 15*time.Minute).Return("http://localhost:8000/files/uploads/"+testId.String(), nil)
+	session, err := usecase.CreateUpload(ctx, models.UploadKindItem, testId)
+	require.NoError(t, err)
+	require.Equal(t, testId, session.Id)
+	require.Equal(t, "http://localhost:8000/files/uploads/"+testId.String(), session.UploadURL)
+}
+
+func TestPutUploadFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	uploadRepo := mocks.NewMockUploadStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	usecase := NewUploadUsecase(uploadRepo, filestorage, 15*time.Minute, 10, logger)
+	ctx := context.Background()
+	future := time.Now().Add(time.Minute)
+
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(nil, models.ErrorNotFound{})
+	err := usecase.PutUploadFile(ctx, testId, strings.NewReader("file"))
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadPending, time.Now().Add(-time.Minute)), nil)
+	err = usecase.PutUploadFile(ctx, testId, strings.NewReader("file"))
+	require.ErrorIs(t, err, models.ErrUploadExpired)
+
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadUploaded, future), nil)
+	err = usecase.PutUploadFile(ctx, testId, strings.NewReader("file"))
+	require.ErrorIs(t, err, models.ErrUploadCompleted)
+
+	// The file is longer than the limit
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadPending, future), nil)
+	filestorage.EXPECT().PutUpload(testId.String(), gomock.Any()).DoAndReturn(func(_ string, r io.Reader) error {
+		_, err := io.ReadAll(r)
+		return err
+	})
+	err = usecase.PutUploadFile(ctx, testId, strings.NewReader("0123456789a"))
+	require.ErrorIs(t, err, models.ErrUploadTooLarge)
+
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadPending, future), nil)
+	filestorage.EXPECT().PutUpload(testId.String(), gomock.Any()).DoAndReturn(func(_ string, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		require.Equal(t, "0123456789", string(data))
+		return err
+	})
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, []models.UploadStatus{models.UploadPending}, models.UploadUploaded).Return(nil)
+	err = usecase.PutUploadFile(ctx, testId, strings.NewReader("0123456789"))
+	require.NoError(t, err)
+}
+
+func TestStartUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	uploadRepo := mocks.NewMockUploadStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	usecase := NewUploadUsecase(uploadRepo, filestorage, 15*time.Minute, 10, logger)
+	ctx := context.Background()
+	future := time.Now().Add(time.Minute)
+	started := []models.UploadStatus{models.UploadPending, models.UploadUploaded}
+
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadPending, time.Now()), nil)
+	_, _, err := usecase.StartUpload(ctx, testId)
+	require.ErrorIs(t, err, models.ErrUploadExpired)
+
+	// Other request takes the session
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadPending, future), nil)
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, started, models.UploadProcessing).Return(models.ErrorNotFound{})
+	_, _, err = usecase.StartUpload(ctx, testId)
+	require.ErrorIs(t, err, models.ErrUploadCompleted)
+
+	// The file is not uploaded, the session is returned back
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadPending, future), nil)
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, started, models.UploadProcessing).Return(nil)
+	filestorage.EXPECT().GetUpload(testId.String()).Return(nil, fmt.Errorf("error"))
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, []models.UploadStatus{models.UploadProcessing}, models.UploadUploaded).Return(nil)
+	_, _, err = usecase.StartUpload(ctx, testId)
+	require.Error(t, err)
+
+	uploadRepo.EXPECT().GetUpload(ctx, testId).Return(testUploadSession(models.UploadUploaded, future), nil)
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, started, models.UploadProcessing).Return(nil)
+	filestorage.EXPECT().GetUpload(testId.String()).Return(io.NopCloser(bytes.NewReader([]byte("file"))), nil)
+	session, file, err := usecase.StartUpload(ctx, testId)
+	require.NoError(t, err)
+	require.Equal(t, testId, session.OwnerId)
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, "file", string(data))
+}
+
+func TestCompleteUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	uploadRepo := mocks.NewMockUploadStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	usecase := NewUploadUsecase(uploadRepo, filestorage, 15*time.Minute, 10, logger)
+	ctx := context.Background()
+	processing := []models.UploadStatus{models.UploadProcessing}
+
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, processing, models.UploadCompleted).Return(fmt.Errorf("error"))
+	require.Error(t, usecase.CompleteUpload(ctx, testId))
+
+	// Error on delete of the file is not an error of completion
+	uploadRepo.EXPECT().UpdateUploadStatus(ctx, testId, processing, models.UploadCompleted).Return(nil)
+	filestorage.EXPECT().DeleteUpload(testId.String()).Return(fmt.Errorf("error"))
+	require.NoError(t, usecase.CompleteUpload(ctx, testId))
+}
+
+func TestDeleteExpiredUploads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	uploadRepo := mocks.NewMockUploadStore(ctrl)
+	filestorage := fs.NewMockFileStorager(ctrl)
+	usecase := NewUploadUsecase(uploadRepo, filestorage, 15*time.Minute, 10, logger)
+	ctx := context.Background()
+
+	uploadRepo.EXPECT().DeleteExpiredUploads(ctx, gomock.Any()).Return(nil, fmt.Errorf("error"))
+	_, err := usecase.DeleteExpiredUploads(ctx)
+	require.Error(t, err)
+
+	completed := *testUploadSession(models.UploadCompleted, time.Now())
+	completed.Id = uuid.New()
+	uploadRepo.EXPECT().DeleteExpiredUploads(ctx, gomock.Any()).Return([]models.UploadSession{
+		*testUploadSession(models.UploadPending, time.Now()),
+		completed,
+	}, nil)
+	// Files of completed sessions are already deleted
+	filestorage.EXPECT().DeleteUpload(testId.String()).Return(nil)
+	count, err := usecase.DeleteExpiredUploads(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
@@ -4,6 +4,8 @@ import (
 	"OnlineShopBackend/internal/delivery/user"
 	"OnlineShopBackend/internal/models"
 	"context"
+	"io"
+	"net/http"
 
 	"github.com/google/uuid"
 )
@@ -17,18 +19,16 @@ type IItemUsecase interface {
 	ItemsQuantityInCategory(ctx context.Context, categoryName string) (int, error)
 	SearchLine(ctx context.Context, param string, limitOptions map[string]int, sortOptions map[string]string) ([]models.Item, error)
 	GetItemsByCategory(ctx context.Context, categoryName string, limitOptions map[string]int, sortOptions map[string]string) ([]models.Item, error)
-	UpdateCash(ctx context.Context, id uuid.UUID, op string) error
-	UpdateItemsInCategoryCash(ctx context.Context, newItem *models.Item, op string) error
 	DeleteItem(ctx context.Context, id uuid.UUID) error
 	AddFavouriteItem(ctx context.Context, userId uuid.UUID, itemId uuid.UUID) error
 	DeleteFavouriteItem(ctx context.Context, userId uuid.UUID, itemId uuid.UUID) error
 	GetFavouriteItems(ctx context.Context, userId uuid.UUID, limitOptions map[string]int, sortOptions map[string]string) ([]models.Item, error)
 	ItemsQuantityInFavourite(ctx context.Context, userId uuid.UUID) (int, error)
-	UpdateFavouriteItemsCash(ctx context.Context, userId uuid.UUID, itemId uuid.UUID, op string)
 	SortItems(items []models.Item, sortType string, sortOrder string)
 	ItemsQuantityInSearch(ctx context.Context, search string) (int, error)
 	GetFavouriteItemsId(ctx context.Context, userId uuid.UUID) (*map[uuid.UUID]uuid.UUID, error)
-	UpdateFavIdsCash(ctx context.Context, userId, itemId uuid.UUID, op string)
+	SetItemStock(ctx context.Context, id uuid.UUID, stock *int) error
+	SetQuantityLimits(ctx context.Context, id uuid.UUID, limits models.QuantityLimits) error
 }
 
 type ICategoryUsecase interface {
@@ -36,14 +36,12 @@ type ICategoryUsecase interface {
 	UpdateCategory(ctx context.Context, category *models.Category) error
 	GetCategory(ctx context.Context, id uuid.UUID) (*models.Category, error)
 	GetCategoryList(ctx context.Context) ([]models.Category, error)
-	UpdateCash(ctx context.Context, id uuid.UUID, op string) error
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
 	GetCategoryByName(ctx context.Context, name string) (*models.Category, error)
-	DeleteCategoryCash(ctx context.Context, name string) error
 }
 
 type IOrderUsecase interface {
-	PlaceOrder(ctx context.Context, cart *models. Cart, user models.User, address models.UserAddress) (*models.Order, error)
+	PlaceOrder(ctx context.Context, cart *models. Cart, user models.User, address models.UserAddress, shippingMethodId uuid.UUID) (*models.Order, error)
 	ChangeStatus(ctx context.Context, order *models.Order, newStatus models.Status) error
 	GetOrdersForUser(ctx context.Context, user *models.User) ([]models.Order, error)
 	DeleteOrder(ctx context.Context, order *models.Order) error
@@ -57,9 +55,23 @@ type ICartUsecase interface {
 	AddItemToCart(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID) error
 	DeleteCart(ctx context.Context, cartId uuid.UUID) error
 	GetCartByUserId(ctx context.Context, userId uuid.UUID) (*models.Cart, error)
+	ApplyPromoCode(ctx context.Context, cartId uuid.UUID, code string) (*models.Cart, error)
+	RemovePromoCode(ctx context.Context, cartId uuid.UUID) error
+	ApplyTaxes(ctx context.Context, cart *models.Cart, address models.UserAddress) error
+	MergeGuestCart(ctx context.Context, guestCartId uuid.UUID, userId uuid.UUID) (uuid.UUID, error)
+	SetItemQuantity(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, quantity int) error
+	DecrementItem(ctx context.Context, cartId uuid.UUID, itemId uuid.UUID, decrement int) (int, error)
+	PurgeExpiredCarts(ctx context.Context) (int, error)
+	Reorder(ctx context.Context, userId uuid.UUID, order *models.Order) (*models.Reorder, error)
 
 }
 
+type ICartReminderUsecase interface {
+	RemindAbandonedCarts(ctx context.Context) (int, int, error)
+	RecoverCart(ctx context.Context, cartId uuid.UUID) (bool, error)
+	Unsubscribe(ctx context.Context, userId uuid.UUID) error
+}
+
 type IUserUsecase interface {
 	CreateUser(ctx context.Context, user *user.CreateUserData) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
@@ -69,3 +81,113 @@ type IUserUsecase interface {
 	GetRightsList(ctx context.Context) ([]models.Rights, error)
 	CreateRights(ctx context.Context, rights *models.Rights) (uuid.UUID, error)
 }
+
+type ICatalogueUsecase interface {
+	Import(ctx context.Context, r io.Reader, format string, dryRun bool) (*models.ImportReport, error)
+	Export(ctx context.Context, w io.Writer, format string) error
+}
+
+type IUploadUsecase interface {
+	CreateUpload(ctx context.Context, kind string, ownerId uuid.UUID) (*models.UploadSession, error)
+	PutUploadFile(ctx context.Context, id uuid.UUID, r io.Reader) error
+	StartUpload(ctx context.Context, id uuid.UUID) (*models.UploadSession, io.ReadCloser, error)
+	FailUpload(ctx context.Context, id uuid.UUID)
+	CompleteUpload(ctx context.Context, id uuid.UUID) error
+	DeleteExpiredUploads(ctx context.Context) (int, error)
+}
+
+type IStorageUsecase interface {
+	CheckStorage(ctx context.Context, dryRun bool) (*models.StorageReport, error)
+}
+
+type IReviewUsecase interface {
+	CreateReview(ctx context.Context, review *models.Review) (uuid.UUID, error)
+	GetItemReviews(ctx context.Context, itemId uuid.UUID, offset, limit int) ([]models.Review, error)
+	GetReviews(ctx context.Context, status models.ReviewStatus, offset, limit int) ([]models.Review, error)
+	ModerateReview(ctx context.Context, id uuid.UUID, status models.ReviewStatus) error
+}
+
+type IPromotionUsecase interface {
+	CreatePromotion(ctx context.Context, promotion *models.Promotion) (uuid.UUID, error)
+	UpdatePromotion(ctx context.Context, promotion *models.Promotion) error
+	GetPromotion(ctx context.Context, id uuid.UUID) (*models.Promotion, error)
+	GetPromotions(ctx context.Context) ([]models.Promotion, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+}
+
+type ICurrencyUsecase interface {
+	BaseCurrency() string
+	GetRates(ctx context.Context) (*models.ExchangeRates, error)
+	GetRatesList(ctx context.Context) ([]models.ExchangeRate, error)
+	SetRate(ctx context.Context, rate *models.ExchangeRate) error
+	DeleteRate(ctx context.Context, currency string) error
+}
+
+type ITaxUsecase interface {
+	CreateTaxRule(ctx context.Context, rule *models.TaxRule) (uuid.UUID, error)
+	UpdateTaxRule(ctx context.Context, rule *models.TaxRule) error
+	GetTaxRules(ctx context.Context) ([]models.TaxRule, error)
+	DeleteTaxRule(ctx context.Context, id uuid.UUID) error
+	SetItemTaxClass(ctx context.Context, itemId uuid.UUID, class string) error
+	SetCategoryTaxClass(ctx context.Context, categoryId uuid.UUID, class string) error
+}
+
+type IShippingUsecase interface {
+	CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (uuid.UUID, error)
+	UpdateShippingMethod(ctx context.Context, method *models.ShippingMethod) error
+	GetShippingMethods(ctx context.Context) ([]models.ShippingMethod, error)
+	DeleteShippingMethod(ctx context.Context, id uuid.UUID) error
+	SetItemWeight(ctx context.Context, itemId uuid.UUID, weight int64) error
+	QuoteShipping(ctx context.Context, cart *models.Cart, address models.UserAddress) ([]models.ShippingQuote, error)
+}
+
+type IPaymentUsecase interface {
+	Pay(ctx context.Context, orderId uuid.UUID, userId uuid.UUID) (*models.Payment, error)
+	Capture(ctx context.Context, id uuid.UUID) (*models.Payment, error)
+	Refund(ctx context.Context, id uuid.UUID, amount int64) (*models.Payment, error)
+	GetOrderPayments(ctx context.Context, orderId uuid.UUID) ([]models.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, header http.Header) error
+}
+
+type IReturnUsecase interface {
+	RequestReturn(ctx context.Context, ret *models.Return) (uuid.UUID, error)
+	GetOrderReturns(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) ([]models.Return, error)
+	GetReturns(ctx context.Context, status models.ReturnStatus) ([]models.Return, error)
+	ApproveReturn(ctx context.Context, id uuid.UUID, comment string) error
+	RejectReturn(ctx context.Context, id uuid.UUID, comment string) error
+	ReceiveReturn(ctx context.Context, id uuid.UUID) error
+	RefundReturn(ctx context.Context, id uuid.UUID, amount int64, offline bool) (*models.Refund, error)
+	GetOrderRefunds(ctx context.Context, orderId uuid.UUID) ([]models.Refund, error)
+}
+
+type IShipmentUsecase interface {
+	CreateShipment(ctx context.Context, orderId uuid.UUID, lines []models.ShipmentLine) (uuid.UUID, error)
+	GetShipment(ctx context.Context, id uuid.UUID) (*models.Shipment, error)
+	GetOrderShipments(ctx context.Context, orderId uuid.UUID) ([]models.Shipment, error)
+	GetUserOrderShipments(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) ([]models.Shipment, error)
+	Ship(ctx context.Context, id uuid.UUID) (*models.Shipment, error)
+	Track(ctx context.Context, id uuid.UUID) (*models.Shipment, error)
+	TrackShipments(ctx context.Context) (int, error)
+	SetStatus(ctx context.Context, id uuid.UUID, status models.ShipmentStatus) (*models.Shipment, error)
+	DeleteShipment(ctx context.Context, id uuid.UUID) error
+}
+
+type IInvoiceUsecase interface {
+	GetInvoice(ctx context.Context, orderId uuid.UUID) (*models.Invoice, []byte, error)
+	GetUserInvoice(ctx context.Context, userId uuid.UUID, orderId uuid.UUID) (*models.Invoice, []byte, error)
+}
+
+type IWishlistUsecase interface {
+	CreateWishlist(ctx context.Context, userId uuid.UUID, name string) (uuid.UUID, error)
+	GetWishlists(ctx context.Context, userId uuid.UUID) ([]models.Wishlist, error)
+	GetWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Wishlist, error)
+	GetSharedWishlist(ctx context.Context, token string) (*models.Wishlist, error)
+	RenameWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID, name string) error
+	DeleteWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	ShareWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) (string, error)
+	UnshareWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error
+	AddWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error
+	DeleteWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error
+	MoveWishlistItem(ctx context.Context, userId uuid.UUID, fromId uuid.UUID, toId uuid.UUID, itemId uuid.UUID) error
+	MoveItemToCart(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) (uuid.UUID, error)
+}
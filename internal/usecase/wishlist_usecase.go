@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository"
+	"OnlineShopBackend/internal/repository/cash"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ IWishlistUsecase = &WishlistUsecase{}
+
+// shareTokenSize is a quantity of random bytes of the token of shared wishlist
+const shareTokenSize = 32
+
+type WishlistUsecase struct {
+	wishlistStore repository.WishlistStore
+	itemStore     repository.ItemStore
+	cartUsecase   ICartUsecase
+	cash          cash.ITagsCash
+	logger        *zap.Logger
+}
+
+func NewWishlistUsecase(wishlistStore repository.WishlistStore, itemStore repository.ItemStore, cartUsecase ICartUsecase, cash cash.ITagsCash, logger *zap.Logger) IWishlistUsecase {
+	logger.Debug("Enter in usecase NewWishlistUsecase()")
+	return &WishlistUsecase{
+		wishlistStore: wishlistStore,
+		itemStore:     itemStore,
+		cartUsecase:   cartUsecase,
+		cash:          cash,
+		logger:        logger,
+	}
+}
+
+// CreateWishlist creates named wishlist of the user
+func (usecase *WishlistUsecase) CreateWishlist(ctx context.Context, userId uuid.UUID, name string) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase CreateWishlist() with args: ctx, userId: %v, name: %s", userId, name)
+	name, err := wishlistName(name)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return usecase.wishlistStore.CreateWishlist(ctx, &models.Wishlist{UserId: userId, Name: name})
+}
+
+// GetWishlists returns the default list of favourites and named wishlists of the user
+func (usecase *WishlistUsecase) GetWishlists(ctx context.Context, userId uuid.UUID) ([]models.Wishlist, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetWishlists() with args: ctx, userId: %v", userId)
+	quantity, err := usecase.itemStore.ItemsInFavouriteQuantity(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get quantity of favourites: %w", err)
+	}
+	wishlists, err := usecase.wishlistStore.GetWishlists(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error on get wishlists: %w", err)
+	}
+	return append([]models.Wishlist{defaultWishlist(userId, quantity)}, wishlists...), nil
+}
+
+// GetWishlist returns the wishlist of the user with its items,
+// nil id is the default list of favourites
+func (usecase *WishlistUsecase) GetWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) (*models.Wishlist, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase GetWishlist() with args: ctx, userId: %v, id: %v", userId, id)
+	wishlist := defaultWishlist(userId, 0)
+	if id != uuid.Nil {
+		named, err := usecase.wishlistStore.GetWishlist(ctx, userId, id)
+		if err != nil {
+			return nil, err
+		}
+		wishlist = *named
+	}
+	return usecase.withItems(ctx, &wishlist)
+}
+
+// GetSharedWishlist returns the shared wishlist with its items by token of the link
+func (usecase *WishlistUsecase) GetSharedWishlist(ctx context.Context, token string) (*models.Wishlist, error) {
+	usecase.logger.Debug("Enter in usecase GetSharedWishlist() with args: ctx, token")
+	if token == "" {
+		return nil, models.ErrorNotFound{}
+	}
+	wishlist, err := usecase.wishlistStore.GetWishlistByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.withItems(ctx, wishlist)
+}
+
+// RenameWishlist changes name of the named wishlist of the user
+func (usecase *WishlistUsecase) RenameWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID, name string) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase RenameWishlist() with args: ctx, userId: %v, id: %v, name: %s", userId, id, name)
+	if id == uuid.Nil {
+		return models.ErrDefaultWishlist
+	}
+	name, err := wishlistName(name)
+	if err != nil {
+		return err
+	}
+	return usecase.wishlistStore.RenameWishlist(ctx, userId, id, name)
+}
+
+// DeleteWishlist deletes named wishlist of the user with its items
+func (usecase *WishlistUsecase) DeleteWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteWishlist() with args: ctx, userId: %v, id: %v", userId, id)
+	if id == uuid.Nil {
+		return models.ErrDefaultWishlist
+	}
+	return usecase.wishlistStore.DeleteWishlist(ctx, userId, id)
+}
+
+// ShareWishlist creates new token of the public read-only link of the
+// named wishlist, the previous link stops working
+func (usecase *WishlistUsecase) ShareWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) (string, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase ShareWishlist() with args: ctx, userId: %v, id: %v", userId, id)
+	if id == uuid.Nil {
+		return "", models.ErrDefaultWishlist
+	}
+	buf := make([]byte, shareTokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error on generate share token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	err := usecase.wishlistStore.SetShareToken(ctx, userId, id, token)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// UnshareWishlist stops sharing of the named wishlist
+func (usecase *WishlistUsecase) UnshareWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase UnshareWishlist() with args: ctx, userId: %v, id: %v", userId, id)
+	if id == uuid.Nil {
+		return models.ErrDefaultWishlist
+	}
+	return usecase.wishlistStore.SetShareToken(ctx, userId, id, "")
+}
+
+// AddWishlistItem adds the item to the wishlist of the user
+func (usecase *WishlistUsecase) AddWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase AddWishlistItem() with args: ctx, userId: %v, id: %v, itemId: %v", userId, id, itemId)
+	if err := usecase.checkWishlist(ctx, userId, id); err != nil {
+		return err
+	}
+	if _, err := usecase.itemStore.GetItem(ctx, itemId); err != nil {
+		return fmt.Errorf("error on get item: %w", err)
+	}
+	err := usecase.wishlistStore.AddWishlistItem(ctx, userId, id, itemId)
+	if err != nil {
+		return err
+	}
+	usecase.invalidate(ctx, userId, id)
+	return nil
+}
+
+// DeleteWishlistItem deletes the item from the wishlist of the user
+func (usecase *WishlistUsecase) DeleteWishlistItem(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase DeleteWishlistItem() with args: ctx, userId: %v, id: %v, itemId: %v", userId, id, itemId)
+	err := usecase.wishlistStore.DeleteWishlistItem(ctx, userId, id, itemId)
+	if err != nil {
+		return err
+	}
+	usecase.invalidate(ctx, userId, id)
+	return nil
+}
+
+// MoveWishlistItem moves the item between wishlists of the user
+func (usecase *WishlistUsecase) MoveWishlistItem(ctx context.Context, userId uuid.UUID, fromId uuid.UUID, toId uuid.UUID, itemId uuid.UUID) error {
+	usecase.logger.Sugar().Debugf("Enter in usecase MoveWishlistItem() with args: ctx, userId: %v, fromId: %v, toId: %v, itemId: %v", userId, fromId, toId, itemId)
+	if fromId == toId {
+		return models.ErrSameWishlist
+	}
+	if err := usecase.checkWishlist(ctx, userId, toId); err != nil {
+		return err
+	}
+	err := usecase.wishlistStore.MoveWishlistItem(ctx, userId, fromId, toId, itemId)
+	if err != nil {
+		return err
+	}
+	usecase.invalidate(ctx, userId, fromId, toId)
+	return nil
+}
+
+// MoveItemToCart adds the item of the wishlist to the cart of the user and
+// removes it from the wishlist. Returns id of the cart, which is created
+// if the user has no cart
+func (usecase *WishlistUsecase) MoveItemToCart(ctx context.Context, userId uuid.UUID, id uuid.UUID, itemId uuid.UUID) (uuid.UUID, error) {
+	usecase.logger.Sugar().Debugf("Enter in usecase MoveItemToCart() with args: ctx, userId: %v, id: %v, itemId: %v", userId, id, itemId)
+	exists, err := usecase.wishlistStore.HasWishlistItem(ctx, userId, id, itemId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !exists {
+		return uuid.Nil, models.ErrorNotFound{}
+	}
+	var cartId uuid.UUID
+	cart, err := usecase.cartUsecase.GetCartByUserId(ctx, userId)
+	switch {
+	case err == nil:
+		cartId = cart.Id
+	case errors.Is(err, models.ErrorNotFound{}):
+		cartId, err = usecase.cartUsecase.Create(ctx, userId)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("error on create cart: %w", err)
+		}
+	default:
+		return uuid.Nil, fmt.Errorf("error on get cart: %w", err)
+	}
+	err = usecase.cartUsecase.AddItemToCart(ctx, cartId, itemId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	err = usecase.wishlistStore.DeleteWishlistItem(ctx, userId, id, itemId)
+	if err != nil && !errors.Is(err, models.ErrorNotFound{}) {
+		return uuid.Nil, err
+	}
+	usecase.invalidate(ctx, userId, id)
+	return cartId, nil
+}
+
+// checkWishlist checks that the named wishlist belongs to the user,
+// the default list always exists
+func (usecase *WishlistUsecase) checkWishlist(ctx context.Context, userId uuid.UUID, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return nil
+	}
+	_, err := usecase.wishlistStore.GetWishlist(ctx, userId, id)
+	return err
+}
+
+// withItems reads items of the wishlist
+func (usecase *WishlistUsecase) withItems(ctx context.Context, wishlist *models.Wishlist) (*models.Wishlist, error) {
+	items, err := usecase.wishlistStore.GetWishlistItems(ctx, wishlist.UserId, wishlist.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error on get items of wishlist: %w", err)
+	}
+	wishlist.Items = items
+	wishlist.ItemsCount = len(items)
+	return wishlist, nil
+}
+
+// invalidate invalidates cached favourites of the user
+// if the default list is among changed wishlists
+func (usecase *WishlistUsecase) invalidate(ctx context.Context, userId uuid.UUID, ids ...uuid.UUID) {
+	for _, id := range ids {
+		if id != uuid.Nil {
+			continue
+		}
+		tag := cash.FavouritesTag(userId)
+		err := usecase.cash.InvalidateTags(ctx, tag)
+		if err != nil {
+			usecase.logger.Sugar().Errorf("error on invalidate cash tags: %v, error: %v", tag, err)
+		}
+		return
+	}
+}
+
+// defaultWishlist returns the list of favourites of the user
+func defaultWishlist(userId uuid.UUID, quantity int) models.Wishlist {
+	return models.Wishlist{
+		UserId:     userId,
+		Name:       models.DefaultWishlistName,
+		ItemsCount: quantity,
+	}
+}
+
+// wishlistName returns trimmed name of wishlist or error if it's invalid
+func wishlistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > models.MaxWishlistName {
+		return "", models.ErrInvalidWishlistName
+	}
+	return name, nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"OnlineShopBackend/internal/models"
+	"OnlineShopBackend/internal/repository/cash"
+	"OnlineShopBackend/internal/repository/mocks"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	wishlistRepo := mocks.NewMockWishlistStore(ctrl)
+	usecase := NewWishlistUsecase(wishlistRepo, nil, nil, nil, zap.L())
+	ctx := context.Background()
+
+	_, err := usecase.CreateWishlist(ctx, testId, "  ")
+	require.ErrorIs(t, err, models.ErrInvalidWishlistName)
+	_, err = usecase.CreateWishlist(ctx, testId, strings.Repeat("a", models.MaxWishlistName+1))
+	require.ErrorIs(t, err, models.ErrInvalidWishlistName)
+
+	id := uuid.New()
+	wishlistRepo.EXPECT().CreateWishlist(ctx, &models.Wishlist{UserId: testId, Name: "Gifts"}).Return(id, nil)
+	res, err := usecase.CreateWishlist(ctx, testId, " Gifts ")
+	require.NoError(t, err)
+	require.Equal(t, id, res)
+}
+
+func TestGetWishlists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	wishlistRepo := mocks.NewMockWishlistStore(ctrl)
+	itemRepo := mocks.NewMockItemStore(ctrl)
+	usecase := NewWishlistUsecase(wishlistRepo, itemRepo, nil, nil, zap.L())
+	ctx := context.Background()
+
+	named := models.Wishlist{Id: uuid.New(), UserId: testId, Name: "Gifts", ItemsCount: 1}
+	itemRepo.EXPECT().ItemsInFavouriteQuantity(ctx, testId).Return(2, nil)
+	wishlistRepo.EXPECT().GetWishlists(ctx, testId).Return([]models.Wishlist{named}, nil)
+	res, err := usecase.GetWishlists(ctx, testId)
+	require.NoError(t, err)
+	// Favourites are the first list
+	require.Equal(t, []models.Wishlist{
+		{UserId: testId, Name: models.DefaultWishlistName, ItemsCount: 2},
+		named,
+	}, res)
+}
+
+func TestDefaultWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	wishlistRepo := mocks.NewMockWishlistStore(ctrl)
+	usecase := NewWishlistUsecase(wishlistRepo, nil, nil, nil, zap.L())
+	ctx := context.Background()
+
+	err := usecase.RenameWishlist(ctx, testId, uuid.Nil, "Gifts")
+	require.ErrorIs(t, err, models.ErrDefaultWishlist)
+	err = usecase.DeleteWishlist(ctx, testId, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrDefaultWishlist)
+	_, err = usecase.ShareWishlist(ctx, testId, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrDefaultWishlist)
+	err = usecase.UnshareWishlist(ctx, testId, uuid.Nil)
+	require.ErrorIs(t, err, models.ErrDefaultWishlist)
+
+	items := []models.Item{{Id: uuid.New()}}
+	wishlistRepo.EXPECT().GetWishlistItems(ctx, testId, uuid.Nil).Return(items, nil)
+	res, err := usecase.GetWishlist(ctx, testId, uuid.Nil)
+	require.NoError(t, err)
+	require.True(t, res.IsDefault())
+	require.Equal(t, models.DefaultWishlistName, res.Name)
+	require.Equal(t, items, res.Items)
+	require.Equal(t, 1, res.ItemsCount)
+}
+
+func TestShareWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	wishlistRepo := mocks.NewMockWishlistStore(ctrl)
+	usecase := NewWishlistUsecase(wishlistRepo, nil, nil, nil, zap.L())
+	ctx := context.Background()
+	id := uuid.New()
+
+	var tokens []string
+	wishlistRepo.EXPECT().SetShareToken(ctx, testId, id, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, userId uuid.UUID, id uuid.UUID, token string) error {
+			tokens = append(tokens, token)
+			return nil
+		}).Times(2)
+	first, err := usecase.ShareWishlist(ctx, testId, id)
+	require.NoError(t, err)
+	second, err := usecase.ShareWishlist(ctx, testId, id)
+	require.NoError(t, err)
+	require.Equal(t, []string{first, second}, tokens)
+	// Token is new on every sharing
+	require.NotEqual(t, first, second)
+	require.Len(t, first, 43)
+
+	_, err = usecase.GetSharedWishlist(ctx, "")
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	wishlistRepo.EXPECT().GetWishlistByToken(ctx, first).Return(&models.Wishlist{Id: id, UserId: testId, ShareToken: first}, nil)
+	wishlistRepo.EXPECT().GetWishlistItems(ctx, testId, id).Return(nil, nil)
+	res, err := usecase.GetSharedWishlist(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, id, res.Id)
+}
+
+func TestMoveWishlistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	wishlistRepo := mocks.NewMockWishlistStore(ctrl)
+	tagsCash := mocks.NewMockITagsCash(ctrl)
+	usecase := NewWishlistUsecase(wishlistRepo, nil, nil, tagsCash, zap.L())
+	ctx := context.Background()
+	id := uuid.New()
+	itemId := uuid.New()
+
+	err := usecase.MoveWishlistItem(ctx, testId, id, id, itemId)
+	require.ErrorIs(t, err, models.ErrSameWishlist)
+
+	// Target list of other user isn't found
+	wishlistRepo.EXPECT().GetWishlist(ctx, testId, id).Return(nil, models.ErrorNotFound{})
+	err = usecase.MoveWishlistItem(ctx, testId, uuid.Nil, id, itemId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Favourites are changed, so their cache is invalidated
+	wishlistRepo.EXPECT().GetWishlist(ctx, testId, id).Return(&models.Wishlist{Id: id, UserId: testId}, nil)
+	wishlistRepo.EXPECT().MoveWishlistItem(ctx, testId, uuid.Nil, id, itemId).Return(nil)
+	tagsCash.EXPECT().InvalidateTags(ctx, cash.FavouritesTag(testId)).Return(nil)
+	err = usecase.MoveWishlistItem(ctx, testId, uuid.Nil, id, itemId)
+	require.NoError(t, err)
+
+	other := uuid.New()
+	wishlistRepo.EXPECT().GetWishlist(ctx, testId, other).Return(&models.Wishlist{Id: other, UserId: testId}, nil)
+	wishlistRepo.EXPECT().MoveWishlistItem(ctx, testId, id, other, itemId).Return(nil)
+	err = usecase.MoveWishlistItem(ctx, testId, id, other, itemId)
+	require.NoError(t, err)
+}
+
+func TestMoveItemToCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := zap.L()
+	wishlistRepo := mocks.NewMockWishlistStore(ctrl)
+	cartRepo := mocks.NewMockCartStore(ctrl)
+	cartUsecase := NewCartUseCase(cartRepo, nil, nil, nil, "RUB", models.CartTTL{}, logger)
+	usecase := NewWishlistUsecase(wishlistRepo, nil, cartUsecase, nil, logger)
+	ctx := context.Background()
+	id := uuid.New()
+	itemId := uuid.New()
+	cartId := uuid.New()
+	// Activity prolongs the cart
+	cartRepo.EXPECT().RefreshCartExpiry(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	wishlistRepo.EXPECT().HasWishlistItem(ctx, testId, id, itemId).Return(false, nil)
+	_, err := usecase.MoveItemToCart(ctx, testId, id, itemId)
+	require.ErrorIs(t, err, models.ErrorNotFound{})
+
+	// Item isn't removed from the list if it can't be added to the cart
+	wishlistRepo.EXPECT().HasWishlistItem(ctx, testId, id, itemId).Return(true, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, testId).Return(nil, models.ErrorNotFound{})
+	cartRepo.EXPECT().Create(ctx, testId).Return(cartId, nil)
+	cartRepo.EXPECT().AddItemToCart(ctx, cartId, itemId).Return(models.ErrQuantityLimit)
+	_, err = usecase.MoveItemToCart(ctx, testId, id, itemId)
+	require.ErrorIs(t, err, models.ErrQuantityLimit)
+
+	wishlistRepo.EXPECT().HasWishlistItem(ctx, testId, id, itemId).Return(true, nil)
+	cartRepo.EXPECT().GetCartByUserId(ctx, testId).Return(nil, models.ErrorNotFound{})
+	cartRepo.EXPECT().Create(ctx, testId).Return(cartId, nil)
+	cartRepo.EXPECT().AddItemToCart(ctx, cartId, itemId).Return(nil)
+	wishlistRepo.EXPECT().DeleteWishlistItem(ctx, testId, id, itemId).Return(nil)
+	res, err := usecase.MoveItemToCart(ctx, testId, id, itemId)
+	require.NoError(t, err)
+	require.Equal(t, cartId, res)
+}
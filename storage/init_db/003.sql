@@ -0,0 +1,2 @@
+-- External stock keeping unit of item, it is used to upsert items on catalogue import
+ALTER TABLE items ADD COLUMN sku VARCHAR(256) UNIQUE;
@@ -0,0 +1,11 @@
+-- Sessions of images uploaded by clients directly to the file storage
+CREATE TABLE upload_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(16) NOT NULL,
+    owner_id UUID NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX upload_sessions_expires_at_idx ON upload_sessions (expires_at);
@@ -0,0 +1,30 @@
+-- Images of items with their order, primary flag and alternative text
+CREATE TABLE item_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    alt TEXT NOT NULL DEFAULT '',
+    position INT NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (item_id, url)
+);
+
+-- Item has only one primary image
+CREATE UNIQUE INDEX item_images_primary_idx ON item_images (item_id) WHERE is_primary;
+
+-- Empty urls were kept in the list of pictures for the frontend,
+-- the first picture of the list becomes the primary image
+INSERT INTO item_images (item_id, url, position, is_primary)
+SELECT item_id, url, position - 1, position = 1
+FROM (
+    SELECT item_id, url, row_number() OVER (PARTITION BY item_id ORDER BY ordinality) AS position
+    FROM (
+        SELECT DISTINCT ON (items.id, picture.url) items.id AS item_id, picture.url, picture.ordinality
+        FROM items, unnest(items.pictures) WITH ORDINALITY AS picture(url, ordinality)
+        WHERE picture.url <> ''
+        ORDER BY items.id, picture.url, picture.ordinality
+    ) AS pictures
+) AS numbered;
+
+ALTER TABLE items DROP COLUMN pictures;
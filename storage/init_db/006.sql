@@ -0,0 +1,16 @@
+-- Reviews of items by users who ordered them, only approved
+-- reviews are shown and counted in the rating of the item
+CREATE TABLE reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (item_id, user_id)
+);
+
+CREATE INDEX reviews_item_status_idx ON reviews (item_id, status);
+CREATE INDEX reviews_status_created_at_idx ON reviews (status, created_at);
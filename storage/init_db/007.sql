@@ -0,0 +1,49 @@
+-- Promotions discount carts by promo code or, if the code
+-- is null, automatically when the cart matches their conditions
+CREATE TABLE promotions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(64) UNIQUE,
+    name VARCHAR(256) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    value BIGINT NOT NULL CHECK (value > 0),
+    scope VARCHAR(16) NOT NULL,
+    scope_id UUID,
+    min_order BIGINT NOT NULL DEFAULT 0,
+    usage_limit INTEGER NOT NULL DEFAULT 0,
+    per_user_limit INTEGER NOT NULL DEFAULT 0,
+    used INTEGER NOT NULL DEFAULT 0,
+    starts_at timestamptz,
+    ends_at timestamptz,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX promotions_automatic_idx ON promotions (active) WHERE code IS NULL;
+
+-- Usages of promotions in orders for limits per user
+CREATE TABLE promotion_usages (
+    promotion_id UUID NOT NULL REFERENCES promotions (id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    PRIMARY KEY (promotion_id, order_id)
+);
+
+CREATE INDEX promotion_usages_user_idx ON promotion_usages (promotion_id, user_id);
+
+-- Discounts applied to the order, code and name are copied
+-- so the breakdown is kept when the promotion is changed
+CREATE TABLE order_discounts (
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    promotion_id UUID REFERENCES promotions (id) ON DELETE SET NULL,
+    code VARCHAR(64) NOT NULL DEFAULT '',
+    name VARCHAR(256) NOT NULL,
+    amount BIGINT NOT NULL,
+    position INTEGER NOT NULL
+);
+
+CREATE INDEX order_discounts_order_idx ON order_discounts (order_id);
+
+ALTER TABLE orders ADD COLUMN subtotal BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN total BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE carts ADD COLUMN promo_code VARCHAR(64);
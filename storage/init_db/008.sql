@@ -0,0 +1,20 @@
+-- Prices are stored in minor units of their currency,
+-- existing prices were in whole rubles
+ALTER TABLE items ALTER COLUMN price TYPE BIGINT;
+UPDATE items SET price = price * 100;
+ALTER TABLE items ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+-- Amounts of promotions and orders are in minor units of the base currency
+UPDATE promotions SET value = value * 100 WHERE kind = 'fixed';
+UPDATE promotions SET min_order = min_order * 100;
+UPDATE order_discounts SET amount = amount * 100;
+UPDATE orders SET subtotal = subtotal * 100, total = total * 100;
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+-- Exchange rates are numbers of units of the currency
+-- for one unit of the base currency, used for display prices
+CREATE TABLE exchange_rates (
+    currency CHAR(3) PRIMARY KEY,
+    rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
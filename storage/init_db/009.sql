@@ -0,0 +1,33 @@
+-- Tax rules by country or region of the shipping address and tax class,
+-- rate is in hundredths of percent
+CREATE TABLE tax_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(256) NOT NULL,
+    country VARCHAR(64) NOT NULL,
+    region VARCHAR(64) NOT NULL DEFAULT '',
+    tax_class VARCHAR(64) NOT NULL DEFAULT 'standard',
+    rate INTEGER NOT NULL CHECK (rate >= 0 AND rate <= 10000),
+    inclusive BOOLEAN NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (country, region, tax_class)
+);
+
+-- Tax class of the item overrides the class of its category,
+-- null means the class is inherited
+ALTER TABLE categories ADD COLUMN tax_class VARCHAR(64);
+ALTER TABLE items ADD COLUMN tax_class VARCHAR(64);
+
+-- Taxes of the order, name and rate are copied
+-- so the breakdown is kept when the rule is changed
+CREATE TABLE order_taxes (
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    tax_rule_id UUID REFERENCES tax_rules (id) ON DELETE SET NULL,
+    name VARCHAR(256) NOT NULL,
+    tax_class VARCHAR(64) NOT NULL,
+    rate INTEGER NOT NULL,
+    inclusive BOOLEAN NOT NULL,
+    amount BIGINT NOT NULL,
+    position INTEGER NOT NULL
+);
+
+CREATE INDEX order_taxes_order_idx ON order_taxes (order_id);
@@ -0,0 +1,24 @@
+-- Shipping methods, prices are in minor units of the base currency
+-- and weights are in grams. Zones is an array of objects with country,
+-- region and surcharge, empty array means delivery everywhere
+CREATE TABLE shipping_methods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(256) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    price BIGINT NOT NULL DEFAULT 0 CHECK (price >= 0),
+    price_per_kg BIGINT NOT NULL DEFAULT 0 CHECK (price_per_kg >= 0),
+    free_from BIGINT NOT NULL DEFAULT 0,
+    max_weight BIGINT NOT NULL DEFAULT 0,
+    min_days INTEGER NOT NULL DEFAULT 0,
+    max_days INTEGER NOT NULL DEFAULT 0,
+    zones JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE items ADD COLUMN weight BIGINT NOT NULL DEFAULT 0 CHECK (weight >= 0);
+
+-- Name of the method is copied so it is kept when the method is changed
+ALTER TABLE orders ADD COLUMN shipping_method_id UUID REFERENCES shipping_methods (id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN shipping_method VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN shipping_price BIGINT NOT NULL DEFAULT 0;
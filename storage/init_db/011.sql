@@ -0,0 +1,37 @@
+-- Payments of orders, amounts are in minor units of the currency of the order.
+-- Payments are kept for reconciliation, the order with payments can't be deleted.
+-- Id of the payment in the provider is empty until the provider answers
+CREATE TABLE payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    provider VARCHAR(64) NOT NULL,
+    provider_payment_id VARCHAR(256) NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    refunded BIGINT NOT NULL DEFAULT 0 CHECK (refunded >= 0 AND refunded <= amount),
+    redirect_url TEXT NOT NULL DEFAULT '',
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX payments_order_id_idx ON payments (order_id);
+CREATE UNIQUE INDEX payments_provider_payment_id_idx ON payments (provider, provider_payment_id)
+    WHERE provider_payment_id <> '';
+
+-- Handled events of webhooks, repeated events are skipped
+CREATE TABLE payment_events (
+    provider VARCHAR(64) NOT NULL,
+    event_id VARCHAR(256) NOT NULL,
+    payment_id UUID NOT NULL REFERENCES payments (id) ON DELETE RESTRICT,
+    status VARCHAR(16) NOT NULL,
+    amount BIGINT NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, event_id)
+);
+
+-- The order has at most one payment in progress or done, concurrent
+-- payments of the order are not created
+CREATE UNIQUE INDEX payments_order_id_active_idx ON payments (order_id)
+    WHERE status IN ('pending', 'authorized', 'captured');
@@ -0,0 +1,3 @@
+-- Stock of the item, NULL means that the stock isn't tracked and the
+-- quantity of the item in carts isn't limited
+ALTER TABLE items ADD COLUMN stock INTEGER CHECK (stock >= 0);
@@ -0,0 +1,4 @@
+-- Limits of the quantity of the item in a cart line,
+-- zero max_quantity means that the quantity isn't limited
+ALTER TABLE items ADD COLUMN min_quantity INTEGER NOT NULL DEFAULT 1 CHECK (min_quantity >= 1);
+ALTER TABLE items ADD COLUMN max_quantity INTEGER NOT NULL DEFAULT 0 CHECK (max_quantity = 0 OR max_quantity >= min_quantity);
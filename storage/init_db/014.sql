@@ -0,0 +1,10 @@
+-- Expiry of carts is prolonged on activity with the lifetime of the cart
+-- of the user or of the guest and compared with the time of the service
+ALTER TABLE carts ALTER COLUMN expire_at TYPE timestamptz;
+-- Carts created before expired an hour after creation, they get the
+-- default lifetime of the cart of the user (CART_USER_TTL) or of the
+-- guest (CART_GUEST_TTL) from now
+UPDATE carts SET expire_at = now() + interval '30 days' WHERE user_id IS NOT NULL;
+UPDATE carts SET expire_at = now() + interval '7 days' WHERE user_id IS NULL;
+ALTER TABLE carts ALTER COLUMN expire_at SET DEFAULT now() + interval '30 days';
+CREATE INDEX carts_expire_at_idx ON carts (expire_at);
@@ -0,0 +1,5 @@
+-- Price of the item when it was added to the cart line, it is compared
+-- with the current price on reading of the cart. Lines added before
+-- have no price and aren't compared
+ALTER TABLE cart_items ADD COLUMN price BIGINT;
+ALTER TABLE cart_items ADD COLUMN currency CHAR(3);
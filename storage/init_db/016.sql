@@ -0,0 +1,19 @@
+-- Named wishlists of users, favourites are the default list of the user
+-- and their items have no wishlist. Shared list is read by its token
+CREATE TABLE wishlists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    share_token VARCHAR(64) UNIQUE,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id)
+);
+CREATE INDEX wishlists_user_id_idx ON wishlists (user_id);
+
+ALTER TABLE favourite_items ADD COLUMN wishlist_id UUID REFERENCES wishlists(id) ON DELETE CASCADE;
+ALTER TABLE favourite_items ADD COLUMN added_at timestamptz NOT NULL DEFAULT now();
+-- The same item can be in several lists of the user
+ALTER TABLE favourite_items DROP CONSTRAINT favourite_items_pkey;
+CREATE UNIQUE INDEX favourite_items_default_idx ON favourite_items (user_id, item_id) WHERE wishlist_id IS NULL;
+CREATE UNIQUE INDEX favourite_items_wishlist_idx ON favourite_items (wishlist_id, item_id) WHERE wishlist_id IS NOT NULL;
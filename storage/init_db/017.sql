@@ -0,0 +1,21 @@
+-- Carts of users idle for a long time with items in them are abandoned.
+-- The event is recorded once per idle period and the user is reminded
+-- unless the user unsubscribed from reminders
+ALTER TABLE carts ADD COLUMN active_at timestamptz NOT NULL DEFAULT now();
+ALTER TABLE users ADD COLUMN cart_reminders BOOLEAN NOT NULL DEFAULT true;
+
+-- Carts are deleted after ordering, so events keep id of the cart without reference
+CREATE TABLE cart_abandonments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    cart_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    items_count INTEGER NOT NULL,
+    active_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    notified_at timestamptz,
+    recovered_at timestamptz,
+    UNIQUE (cart_id, active_at),
+    CONSTRAINT fk_user_id
+        FOREIGN KEY(user_id) REFERENCES users(id)
+);
+CREATE INDEX carts_active_at_idx ON carts (active_at) WHERE user_id IS NOT NULL;
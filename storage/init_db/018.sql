@@ -0,0 +1,30 @@
+-- Return requests of lines of delivered orders. Returned quantity is added
+-- to the stock of the item when the return is received. Returns and refunds
+-- are kept for reconciliation, the order with them can't be deleted
+CREATE TABLE returns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    user_id UUID NOT NULL REFERENCES users (id),
+    item_id UUID NOT NULL REFERENCES items (id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    reason TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'requested',
+    comment TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX returns_order_id_idx ON returns (order_id);
+CREATE INDEX returns_status_idx ON returns (status);
+
+-- Refunds of orders for reconciliation, amounts are in minor units of the
+-- currency of the order. Payment is empty for refunds made outside of the provider
+CREATE TABLE refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    return_id UUID REFERENCES returns (id) ON DELETE RESTRICT,
+    payment_id UUID REFERENCES payments (id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX refunds_order_id_idx ON refunds (order_id);
@@ -0,0 +1,24 @@
+-- Shipments of orders. The order can be split into several shipments,
+-- each with its own carrier, tracking number and status
+CREATE TABLE shipments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    carrier VARCHAR(64) NOT NULL,
+    tracking_number VARCHAR(128) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    shipped_at timestamptz,
+    delivered_at timestamptz
+);
+CREATE INDEX shipments_order_id_idx ON shipments (order_id);
+CREATE INDEX shipments_status_idx ON shipments (status);
+CREATE UNIQUE INDEX shipments_tracking_number_idx ON shipments (carrier, tracking_number) WHERE tracking_number <> '';
+
+-- Lines of the order sent in the shipment
+CREATE TABLE shipment_items (
+    shipment_id UUID NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items (id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (shipment_id, item_id)
+);
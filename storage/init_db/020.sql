@@ -0,0 +1,14 @@
+-- Unit price of the line in the order currency at the time of the order,
+-- lines of old orders have no price and are shown with the current price
+ALTER TABLE order_items ADD COLUMN price BIGINT;
+
+-- Rendered invoices of orders. The number is kept when the invoice is
+-- regenerated, the fingerprint identifies the content of the rendered file
+CREATE TABLE invoices (
+    order_id UUID PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    number VARCHAR(64) NOT NULL UNIQUE,
+    file_name VARCHAR(256) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);